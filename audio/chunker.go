@@ -0,0 +1,123 @@
+package audio
+
+import "time"
+
+// DefaultChunkDuration is the default amount of audio carried by each chunk produced by a Chunker
+const DefaultChunkDuration = 100 * time.Millisecond
+
+// Chunker splits a stream of PCM16 bytes into fixed-size chunks suitable for
+// input_audio_buffer.append messages.
+//
+// The Chunker also keeps track of how much audio has been emitted since the last
+// Flush. Flush pads the final chunk with silence when necessary so that a commit
+// following the flush always carries at least MinCommitDuration of audio, which
+// keeps short utterances from triggering server errors.
+//
+// A Chunker is not safe for concurrent use.
+type Chunker struct {
+	chunkBytes  int
+	minBytes    int
+	buf         []byte
+	emitted     int
+	trimSilence bool
+	threshold   int16
+}
+
+// ChunkerOption is a function that configures a Chunker
+type ChunkerOption func(*Chunker)
+
+// WithTrimTrailingSilence makes Flush drop trailing silence from the buffered audio
+// before padding it. Samples at or below threshold are considered silent.
+func WithTrimTrailingSilence(threshold int16) ChunkerOption {
+	return func(c *Chunker) {
+		c.trimSilence = true
+		c.threshold = threshold
+	}
+}
+
+// NewChunker creates a Chunker for PCM16 audio at the given sample rate.
+// Each chunk carries chunkDuration of audio; a non-positive duration uses DefaultChunkDuration.
+func NewChunker(sampleRate int, chunkDuration time.Duration, opts ...ChunkerOption) *Chunker {
+	if sampleRate <= 0 {
+		sampleRate = DefaultSampleRate
+	}
+	if chunkDuration <= 0 {
+		chunkDuration = DefaultChunkDuration
+	}
+
+	c := &Chunker{
+		chunkBytes: SamplesForDuration(sampleRate, chunkDuration) * BytesPerSample,
+		minBytes:   SamplesForDuration(sampleRate, MinCommitDuration) * BytesPerSample,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Write buffers PCM16 data and returns any complete chunks that are now available.
+// The returned chunks are newly allocated and safe to retain.
+func (c *Chunker) Write(data []byte) [][]byte {
+	c.buf = append(c.buf, data...)
+
+	var chunks [][]byte
+	for len(c.buf) >= c.chunkBytes {
+		chunk := make([]byte, c.chunkBytes)
+		copy(chunk, c.buf)
+		c.buf = c.buf[c.chunkBytes:]
+		c.emitted += len(chunk)
+		chunks = append(chunks, chunk)
+	}
+	return chunks
+}
+
+// Flush returns the remaining buffered audio, padded with silence so that the total
+// audio emitted since the previous Flush is at least MinCommitDuration.
+// It returns nil if nothing was written since the previous Flush.
+// After Flush, the Chunker is ready for the next utterance.
+func (c *Chunker) Flush() []byte {
+	defer c.reset()
+
+	rest := c.buf
+	if c.trimSilence {
+		rest = c.trimTrailing(rest)
+	}
+
+	if c.emitted == 0 && len(c.buf) == 0 {
+		return nil
+	}
+
+	size := len(rest)
+	if c.emitted+size < c.minBytes {
+		size = c.minBytes - c.emitted
+	}
+	if size == 0 {
+		return nil
+	}
+
+	out := make([]byte, size)
+	copy(out, rest)
+	return out
+}
+
+// Buffered returns the number of bytes waiting for a full chunk
+func (c *Chunker) Buffered() int {
+	return len(c.buf)
+}
+
+// reset prepares the Chunker for the next utterance
+func (c *Chunker) reset() {
+	c.buf = nil
+	c.emitted = 0
+}
+
+// trimTrailing drops trailing silent samples from PCM16 data
+func (c *Chunker) trimTrailing(data []byte) []byte {
+	data = data[:len(data)-len(data)%BytesPerSample]
+	samples, err := DecodePCM16(data)
+	if err != nil {
+		return data
+	}
+	kept := TrimTrailingSilence(samples, c.threshold)
+	return data[:len(kept)*BytesPerSample]
+}
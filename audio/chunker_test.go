@@ -0,0 +1,86 @@
+package audio
+
+import (
+	"testing"
+	"time"
+)
+
+func TestChunkerWrite(t *testing.T) {
+	// 1000 Hz with 10ms chunks gives 10 samples (20 bytes) per chunk
+	c := NewChunker(1000, 10*time.Millisecond)
+
+	if chunks := c.Write(make([]byte, 15)); len(chunks) != 0 {
+		t.Errorf("Expected no chunks yet, got %d", len(chunks))
+	}
+
+	chunks := c.Write(make([]byte, 30))
+	if len(chunks) != 2 {
+		t.Fatalf("Expected 2 chunks, got %d", len(chunks))
+	}
+	for i, chunk := range chunks {
+		if len(chunk) != 20 {
+			t.Errorf("Expected chunk %d to be 20 bytes, got %d", i, len(chunk))
+		}
+	}
+
+	if c.Buffered() != 5 {
+		t.Errorf("Expected 5 buffered bytes, got %d", c.Buffered())
+	}
+}
+
+func TestChunkerFlushPadsShortUtterance(t *testing.T) {
+	// 1000 Hz means the 100ms minimum commit is 100 samples (200 bytes)
+	c := NewChunker(1000, 20*time.Millisecond)
+
+	chunks := c.Write(make([]byte, 50))
+	if len(chunks) != 1 {
+		t.Fatalf("Expected 1 chunk, got %d", len(chunks))
+	}
+
+	rest := c.Flush()
+	if len(chunks[0])+len(rest) != 200 {
+		t.Errorf("Expected a total of 200 bytes, got %d", len(chunks[0])+len(rest))
+	}
+
+	if c.Buffered() != 0 {
+		t.Errorf("Expected chunker to be reset after flush, got %d buffered bytes", c.Buffered())
+	}
+
+	if rest := c.Flush(); rest != nil {
+		t.Errorf("Expected nil flush with no new audio, got %d bytes", len(rest))
+	}
+}
+
+func TestChunkerFlushLongUtterance(t *testing.T) {
+	c := NewChunker(1000, 20*time.Millisecond)
+
+	chunks := c.Write(make([]byte, 250))
+	total := 0
+	for _, chunk := range chunks {
+		total += len(chunk)
+	}
+
+	rest := c.Flush()
+	if len(rest) != 10 {
+		t.Errorf("Expected the 10 remaining bytes without padding, got %d", len(rest))
+	}
+	if total+len(rest) != 250 {
+		t.Errorf("Expected a total of 250 bytes, got %d", total+len(rest))
+	}
+}
+
+func TestChunkerTrimTrailingSilence(t *testing.T) {
+	c := NewChunker(1000, time.Second, WithTrimTrailingSilence(DefaultSilenceThreshold))
+
+	samples := make([]int16, 200)
+	for i := range 150 {
+		samples[i] = 10000
+	}
+	data := EncodePCM16(samples)
+
+	c.Write(data)
+	rest := c.Flush()
+	if len(rest) != 300 {
+		t.Errorf("Expected trailing silence to be trimmed to 300 bytes, got %d", len(rest))
+	}
+}
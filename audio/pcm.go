@@ -0,0 +1,156 @@
+// Package audio provides helpers for preparing audio for the OpenAI Realtime API.
+//
+// The Realtime API expects input audio as mono, little-endian, 16-bit PCM
+// (base64-encoded inside input_audio_buffer.append messages). Many capture
+// sources, such as browsers, WebRTC stacks and native audio libraries, deliver
+// interleaved float32 samples with two or more channels instead. This package
+// contains the conversions needed to bridge the two representations.
+//
+// Example usage:
+//
+//	// Convert interleaved float32 stereo samples to mono PCM16 bytes
+//	mono, err := audio.DownmixFloat32(samples, 2)
+//	if err != nil {
+//		return err
+//	}
+//	pcm := audio.EncodePCM16(audio.Float32ToInt16(mono))
+//
+//	// Send the audio to the server
+//	err = msgClient.SendAudioBufferAppend(ctx, base64.StdEncoding.EncodeToString(pcm))
+package audio
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+)
+
+// Error definitions
+var (
+	// ErrInvalidChannelCount is returned when a channel count is less than one
+	ErrInvalidChannelCount = errors.New("channel count must be at least 1")
+
+	// ErrIncompleteFrame is returned when the number of samples is not a multiple of the channel count
+	ErrIncompleteFrame = errors.New("sample count is not a multiple of the channel count")
+
+	// ErrOddByteCount is returned when PCM16 data does not contain a whole number of samples
+	ErrOddByteCount = errors.New("pcm16 data must contain an even number of bytes")
+)
+
+// BytesPerSample is the number of bytes used by a single PCM16 sample
+const BytesPerSample = 2
+
+// Float32ToInt16 converts float32 samples in the range [-1.0, 1.0] to int16 samples.
+// Values outside the range are clipped, and NaN values are treated as silence.
+func Float32ToInt16(samples []float32) []int16 {
+	out := make([]int16, len(samples))
+	for i, s := range samples {
+		out[i] = float32ToInt16(s)
+	}
+	return out
+}
+
+// Int16ToFloat32 converts int16 samples to float32 samples in the range [-1.0, 1.0].
+func Int16ToFloat32(samples []int16) []float32 {
+	out := make([]float32, len(samples))
+	for i, s := range samples {
+		out[i] = float32(s) / 32768
+	}
+	return out
+}
+
+// EncodePCM16 serializes int16 samples as little-endian PCM16 bytes,
+// the byte layout expected by the API for the pcm16 audio format.
+func EncodePCM16(samples []int16) []byte {
+	out := make([]byte, len(samples)*BytesPerSample)
+	for i, s := range samples {
+		binary.LittleEndian.PutUint16(out[i*BytesPerSample:], uint16(s))
+	}
+	return out
+}
+
+// DecodePCM16 parses little-endian PCM16 bytes into int16 samples.
+// Returns ErrOddByteCount if the data does not contain a whole number of samples.
+func DecodePCM16(data []byte) ([]int16, error) {
+	if len(data)%BytesPerSample != 0 {
+		return nil, ErrOddByteCount
+	}
+	out := make([]int16, len(data)/BytesPerSample)
+	for i := range out {
+		out[i] = int16(binary.LittleEndian.Uint16(data[i*BytesPerSample:]))
+	}
+	return out, nil
+}
+
+// DownmixFloat32 averages interleaved multi-channel float32 samples into a single channel.
+// A channel count of 1 returns a copy of the input.
+func DownmixFloat32(samples []float32, channels int) ([]float32, error) {
+	if err := validateFrames(len(samples), channels); err != nil {
+		return nil, err
+	}
+
+	out := make([]float32, len(samples)/channels)
+	for i := range out {
+		var sum float64
+		for _, s := range samples[i*channels : (i+1)*channels] {
+			sum += float64(s)
+		}
+		out[i] = float32(sum / float64(channels))
+	}
+	return out, nil
+}
+
+// DownmixInt16 averages interleaved multi-channel int16 samples into a single channel.
+// A channel count of 1 returns a copy of the input.
+func DownmixInt16(samples []int16, channels int) ([]int16, error) {
+	if err := validateFrames(len(samples), channels); err != nil {
+		return nil, err
+	}
+
+	out := make([]int16, len(samples)/channels)
+	for i := range out {
+		var sum int64
+		for _, s := range samples[i*channels : (i+1)*channels] {
+			sum += int64(s)
+		}
+		out[i] = int16(sum / int64(channels))
+	}
+	return out, nil
+}
+
+// Float32ToPCM16 downmixes interleaved float32 samples to mono and encodes them
+// as little-endian PCM16 bytes in a single step.
+func Float32ToPCM16(samples []float32, channels int) ([]byte, error) {
+	mono, err := DownmixFloat32(samples, channels)
+	if err != nil {
+		return nil, err
+	}
+	return EncodePCM16(Float32ToInt16(mono)), nil
+}
+
+// validateFrames checks that the sample count describes whole frames for the channel count
+func validateFrames(sampleCount, channels int) error {
+	if channels < 1 {
+		return ErrInvalidChannelCount
+	}
+	if sampleCount%channels != 0 {
+		return ErrIncompleteFrame
+	}
+	return nil
+}
+
+// float32ToInt16 converts a single sample, clipping values outside [-1.0, 1.0]
+func float32ToInt16(s float32) int16 {
+	switch {
+	case math.IsNaN(float64(s)):
+		return 0
+	case s >= 1:
+		return math.MaxInt16
+	case s <= -1:
+		return math.MinInt16
+	case s < 0:
+		return int16(s * 32768)
+	default:
+		return int16(s * math.MaxInt16)
+	}
+}
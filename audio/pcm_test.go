@@ -0,0 +1,141 @@
+package audio
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestFloat32ToInt16(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    float32
+		expected int16
+	}{
+		{name: "Silence", input: 0, expected: 0},
+		{name: "FullScalePositive", input: 1, expected: math.MaxInt16},
+		{name: "FullScaleNegative", input: -1, expected: math.MinInt16},
+		{name: "ClippedPositive", input: 1.5, expected: math.MaxInt16},
+		{name: "ClippedNegative", input: -2, expected: math.MinInt16},
+		{name: "HalfPositive", input: 0.5, expected: 16383},
+		{name: "HalfNegative", input: -0.5, expected: -16384},
+		{name: "NaN", input: float32(math.NaN()), expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Float32ToInt16([]float32{tt.input})
+			if got[0] != tt.expected {
+				t.Errorf("Expected %d, got %d", tt.expected, got[0])
+			}
+		})
+	}
+}
+
+func TestInt16ToFloat32RoundTrip(t *testing.T) {
+	input := []int16{0, 1000, -1000, math.MaxInt16, math.MinInt16}
+
+	floats := Int16ToFloat32(input)
+	for i, f := range floats {
+		if f < -1 || f > 1 {
+			t.Errorf("Expected sample %d to be within [-1, 1], got %f", i, f)
+		}
+	}
+
+	back := Float32ToInt16(floats)
+	for i := range input {
+		diff := int(back[i]) - int(input[i])
+		if diff < -1 || diff > 1 {
+			t.Errorf("Expected round trip of %d to be within 1, got %d", input[i], back[i])
+		}
+	}
+}
+
+func TestEncodeDecodePCM16(t *testing.T) {
+	samples := []int16{0, 1, -1, 256, math.MaxInt16, math.MinInt16}
+
+	data := EncodePCM16(samples)
+	if len(data) != len(samples)*BytesPerSample {
+		t.Fatalf("Expected %d bytes, got %d", len(samples)*BytesPerSample, len(data))
+	}
+
+	// 256 is 0x0100, which is 0x00 0x01 in little-endian order
+	if data[6] != 0x00 || data[7] != 0x01 {
+		t.Errorf("Expected little-endian encoding, got %#x %#x", data[6], data[7])
+	}
+
+	decoded, err := DecodePCM16(data)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for i := range samples {
+		if decoded[i] != samples[i] {
+			t.Errorf("Expected sample %d to be %d, got %d", i, samples[i], decoded[i])
+		}
+	}
+
+	if _, err := DecodePCM16([]byte{1, 2, 3}); !errors.Is(err, ErrOddByteCount) {
+		t.Errorf("Expected ErrOddByteCount, got %v", err)
+	}
+}
+
+func TestDownmixFloat32(t *testing.T) {
+	stereo := []float32{1, 0, 0.5, 0.5, -1, 1}
+
+	mono, err := DownmixFloat32(stereo, 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []float32{0.5, 0.5, 0}
+	if len(mono) != len(expected) {
+		t.Fatalf("Expected %d samples, got %d", len(expected), len(mono))
+	}
+	for i := range expected {
+		if mono[i] != expected[i] {
+			t.Errorf("Expected sample %d to be %f, got %f", i, expected[i], mono[i])
+		}
+	}
+}
+
+func TestDownmixInt16(t *testing.T) {
+	stereo := []int16{math.MaxInt16, math.MaxInt16, 100, 200, -100, -300}
+
+	mono, err := DownmixInt16(stereo, 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []int16{math.MaxInt16, 150, -200}
+	for i := range expected {
+		if mono[i] != expected[i] {
+			t.Errorf("Expected sample %d to be %d, got %d", i, expected[i], mono[i])
+		}
+	}
+}
+
+func TestDownmixErrors(t *testing.T) {
+	if _, err := DownmixFloat32([]float32{0, 0}, 0); !errors.Is(err, ErrInvalidChannelCount) {
+		t.Errorf("Expected ErrInvalidChannelCount, got %v", err)
+	}
+
+	if _, err := DownmixInt16([]int16{0, 0, 0}, 2); !errors.Is(err, ErrIncompleteFrame) {
+		t.Errorf("Expected ErrIncompleteFrame, got %v", err)
+	}
+}
+
+func TestFloat32ToPCM16(t *testing.T) {
+	data, err := Float32ToPCM16([]float32{1, 1, -1, -1}, 2)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	samples, err := DecodePCM16(data)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(samples) != 2 || samples[0] != math.MaxInt16 || samples[1] != math.MinInt16 {
+		t.Errorf("Unexpected samples: %v", samples)
+	}
+}
@@ -0,0 +1,32 @@
+package audio
+
+import "fmt"
+
+// Resample converts mono PCM16 samples from one sample rate to another using linear
+// interpolation. It is meant for preparing recordings for the API's 24kHz pcm16 format;
+// it does not low-pass filter, so downsampling audio with content above the new
+// Nyquist frequency may alias.
+func Resample(samples []int16, fromRate, toRate int) ([]int16, error) {
+	if fromRate <= 0 || toRate <= 0 {
+		return nil, fmt.Errorf("sample rates must be positive, got %d and %d", fromRate, toRate)
+	}
+	if fromRate == toRate || len(samples) == 0 {
+		return append([]int16(nil), samples...), nil
+	}
+
+	n := int((int64(len(samples))*int64(toRate) + int64(fromRate) - 1) / int64(fromRate))
+	out := make([]int16, n)
+	step := float64(fromRate) / float64(toRate)
+	last := len(samples) - 1
+	for i := range out {
+		pos := float64(i) * step
+		j := int(pos)
+		if j >= last {
+			out[i] = samples[last]
+			continue
+		}
+		frac := pos - float64(j)
+		out[i] = int16(float64(samples[j])*(1-frac) + float64(samples[j+1])*frac)
+	}
+	return out, nil
+}
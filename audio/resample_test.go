@@ -0,0 +1,37 @@
+package audio
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestResample(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    []int16
+		from     int
+		to       int
+		expected []int16
+	}{
+		{name: "SameRate", input: []int16{1, 2, 3}, from: 24000, to: 24000, expected: []int16{1, 2, 3}},
+		{name: "Upsample", input: []int16{0, 100, 200}, from: 12000, to: 24000, expected: []int16{0, 50, 100, 150, 200, 200}},
+		{name: "Downsample", input: []int16{0, 10, 20, 30, 40, 50}, from: 48000, to: 24000, expected: []int16{0, 20, 40}},
+		{name: "Empty", input: nil, from: 16000, to: 24000, expected: []int16{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Resample(tt.input, tt.from, tt.to)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !slices.Equal(got, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+
+	if _, err := Resample([]int16{1}, 0, 24000); err == nil {
+		t.Error("Expected error for a zero sample rate")
+	}
+}
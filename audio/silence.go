@@ -0,0 +1,95 @@
+package audio
+
+import "time"
+
+const (
+	// DefaultSampleRate is the sample rate used by the API for the pcm16 audio format.
+	// It matches session.AudioFormatPCM16.SampleRate().
+	DefaultSampleRate = 24000
+
+	// MinCommitDuration is the minimum amount of audio the server accepts in a buffer commit.
+	// Committing less audio than this results in an input_audio_buffer_commit_empty error.
+	MinCommitDuration = 100 * time.Millisecond
+
+	// DefaultSilenceThreshold is the default absolute amplitude at or below which
+	// a PCM16 sample is treated as silence (roughly -36 dBFS)
+	DefaultSilenceThreshold int16 = 500
+)
+
+// SamplesForDuration returns the number of samples needed to cover d at the given sample rate.
+// Partial samples are rounded up so the result always covers at least d.
+func SamplesForDuration(sampleRate int, d time.Duration) int {
+	if sampleRate <= 0 || d <= 0 {
+		return 0
+	}
+	n := int64(sampleRate) * int64(d)
+	samples := n / int64(time.Second)
+	if n%int64(time.Second) != 0 {
+		samples++
+	}
+	return int(samples)
+}
+
+// DurationForSamples returns the playback duration of n samples at the given sample rate.
+func DurationForSamples(sampleRate int, n int) time.Duration {
+	if sampleRate <= 0 || n <= 0 {
+		return 0
+	}
+	return time.Duration(int64(n) * int64(time.Second) / int64(sampleRate))
+}
+
+// TrimSilence removes leading and trailing samples whose absolute amplitude is
+// at or below threshold. The returned slice shares memory with the input.
+// If every sample is silent, an empty slice is returned.
+func TrimSilence(samples []int16, threshold int16) []int16 {
+	return TrimTrailingSilence(TrimLeadingSilence(samples, threshold), threshold)
+}
+
+// TrimLeadingSilence removes leading samples whose absolute amplitude is at or below threshold.
+// The returned slice shares memory with the input.
+func TrimLeadingSilence(samples []int16, threshold int16) []int16 {
+	for i, s := range samples {
+		if !isSilent(s, threshold) {
+			return samples[i:]
+		}
+	}
+	return samples[:0]
+}
+
+// TrimTrailingSilence removes trailing samples whose absolute amplitude is at or below threshold.
+// The returned slice shares memory with the input.
+func TrimTrailingSilence(samples []int16, threshold int16) []int16 {
+	for i := len(samples) - 1; i >= 0; i-- {
+		if !isSilent(samples[i], threshold) {
+			return samples[:i+1]
+		}
+	}
+	return samples[:0]
+}
+
+// PadSilence appends silent samples until the slice holds at least minSamples samples.
+// Slices that are already long enough are returned unchanged.
+func PadSilence(samples []int16, minSamples int) []int16 {
+	if len(samples) >= minSamples {
+		return samples
+	}
+	out := make([]int16, minSamples)
+	copy(out, samples)
+	return out
+}
+
+// PadToMinCommit pads PCM16 samples at the given sample rate so that they satisfy
+// the server's minimum commit duration.
+func PadToMinCommit(samples []int16, sampleRate int) []int16 {
+	return PadSilence(samples, SamplesForDuration(sampleRate, MinCommitDuration))
+}
+
+// isSilent reports whether a sample's absolute amplitude is at or below threshold
+func isSilent(s int16, threshold int16) bool {
+	// Widen before negating so math.MinInt16 does not overflow
+	v := int32(s)
+	if v < 0 {
+		v = -v
+	}
+	return v <= int32(threshold)
+}
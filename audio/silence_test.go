@@ -0,0 +1,85 @@
+package audio
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/session"
+)
+
+func TestDefaultSampleRateMatchesPCM16(t *testing.T) {
+	if DefaultSampleRate != session.AudioFormatPCM16.SampleRate() {
+		t.Errorf("Expected DefaultSampleRate to match pcm16 (%d), got %d", session.AudioFormatPCM16.SampleRate(), DefaultSampleRate)
+	}
+}
+
+func TestSamplesForDuration(t *testing.T) {
+	tests := []struct {
+		name       string
+		sampleRate int
+		duration   time.Duration
+		expected   int
+	}{
+		{name: "OneSecond", sampleRate: 24000, duration: time.Second, expected: 24000},
+		{name: "MinCommit", sampleRate: 24000, duration: MinCommitDuration, expected: 2400},
+		{name: "RoundsUp", sampleRate: 8000, duration: time.Microsecond, expected: 1},
+		{name: "ZeroRate", sampleRate: 0, duration: time.Second, expected: 0},
+		{name: "NegativeDuration", sampleRate: 24000, duration: -time.Second, expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SamplesForDuration(tt.sampleRate, tt.duration); got != tt.expected {
+				t.Errorf("Expected %d samples, got %d", tt.expected, got)
+			}
+		})
+	}
+
+	if d := DurationForSamples(24000, 2400); d != MinCommitDuration {
+		t.Errorf("Expected %v, got %v", MinCommitDuration, d)
+	}
+}
+
+func TestTrimSilence(t *testing.T) {
+	samples := []int16{0, 10, -20, 1000, 0, -2000, 5, math.MinInt16, 3, 0}
+
+	trimmed := TrimSilence(samples, 100)
+	expected := []int16{1000, 0, -2000, 5, math.MinInt16}
+	if len(trimmed) != len(expected) {
+		t.Fatalf("Expected %d samples, got %d (%v)", len(expected), len(trimmed), trimmed)
+	}
+	for i := range expected {
+		if trimmed[i] != expected[i] {
+			t.Errorf("Expected sample %d to be %d, got %d", i, expected[i], trimmed[i])
+		}
+	}
+
+	if got := TrimLeadingSilence(samples, 100); got[0] != 1000 {
+		t.Errorf("Expected leading trim to start at 1000, got %d", got[0])
+	}
+
+	if got := TrimTrailingSilence(samples, 100); got[len(got)-1] != math.MinInt16 {
+		t.Errorf("Expected trailing trim to end at MinInt16, got %d", got[len(got)-1])
+	}
+
+	if got := TrimSilence([]int16{0, 1, -1}, 100); len(got) != 0 {
+		t.Errorf("Expected all-silent input to be trimmed to nothing, got %v", got)
+	}
+}
+
+func TestPadSilence(t *testing.T) {
+	padded := PadSilence([]int16{1, 2}, 4)
+	if len(padded) != 4 || padded[0] != 1 || padded[1] != 2 || padded[2] != 0 || padded[3] != 0 {
+		t.Errorf("Unexpected padding result: %v", padded)
+	}
+
+	long := []int16{1, 2, 3}
+	if got := PadSilence(long, 2); len(got) != 3 {
+		t.Errorf("Expected long input to be unchanged, got %v", got)
+	}
+
+	if got := PadToMinCommit(nil, DefaultSampleRate); len(got) != 2400 {
+		t.Errorf("Expected 2400 samples, got %d", len(got))
+	}
+}
@@ -0,0 +1,87 @@
+package audio
+
+import (
+	"io"
+	"sync"
+)
+
+// Source delivers mono PCM16 audio, such as a microphone, a file or a remote track.
+// Transports read from a Source to send audio to the API.
+type Source interface {
+	// SampleRate returns the rate of the samples returned by Read
+	SampleRate() int
+
+	// Read reads up to len(buf) samples into buf, blocking until at least one is
+	// available. It returns io.EOF when the stream has ended.
+	Read(buf []int16) (int, error)
+}
+
+// Sink consumes mono PCM16 audio, such as a speaker or a file.
+// Transports write the audio received from the API to a Sink.
+type Sink interface {
+	// SampleRate returns the rate the Sink expects its samples at
+	SampleRate() int
+
+	// Write consumes the samples; the Sink must not retain the slice
+	Write(samples []int16) error
+}
+
+// SliceSource is a Source that reads from an in-memory slice of samples
+type SliceSource struct {
+	rate    int
+	samples []int16
+}
+
+// NewSliceSource creates a Source reading samples at the given sample rate
+func NewSliceSource(sampleRate int, samples []int16) *SliceSource {
+	return &SliceSource{rate: sampleRate, samples: samples}
+}
+
+// SampleRate implements Source
+func (s *SliceSource) SampleRate() int {
+	return s.rate
+}
+
+// Read implements Source
+func (s *SliceSource) Read(buf []int16) (int, error) {
+	if len(s.samples) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(buf, s.samples)
+	s.samples = s.samples[n:]
+	return n, nil
+}
+
+// BufferSink is a Sink that collects the samples written to it in memory.
+// It is safe for concurrent use.
+type BufferSink struct {
+	rate int
+
+	mu      sync.Mutex
+	samples []int16
+}
+
+// NewBufferSink creates a Sink collecting samples at the given sample rate
+func NewBufferSink(sampleRate int) *BufferSink {
+	return &BufferSink{rate: sampleRate}
+}
+
+// SampleRate implements Sink
+func (s *BufferSink) SampleRate() int {
+	return s.rate
+}
+
+// Write implements Sink
+func (s *BufferSink) Write(samples []int16) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.samples = append(s.samples, samples...)
+	return nil
+}
+
+// Samples returns a copy of the samples written so far
+func (s *BufferSink) Samples() []int16 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]int16(nil), s.samples...)
+}
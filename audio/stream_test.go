@@ -0,0 +1,46 @@
+package audio
+
+import (
+	"io"
+	"testing"
+)
+
+func TestSliceSource(t *testing.T) {
+	var src Source = NewSliceSource(16000, []int16{1, 2, 3, 4, 5})
+	if src.SampleRate() != 16000 {
+		t.Errorf("Expected sample rate 16000, got %d", src.SampleRate())
+	}
+
+	buf := make([]int16, 2)
+	var got []int16
+	for {
+		n, err := src.Read(buf)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		got = append(got, buf[:n]...)
+	}
+	if len(got) != 5 || got[0] != 1 || got[4] != 5 {
+		t.Errorf("Expected all 5 samples in order, got %v", got)
+	}
+}
+
+func TestBufferSink(t *testing.T) {
+	var sink Sink = NewBufferSink(DefaultSampleRate)
+	if sink.SampleRate() != DefaultSampleRate {
+		t.Errorf("Expected sample rate %d, got %d", DefaultSampleRate, sink.SampleRate())
+	}
+
+	samples := []int16{1, 2}
+	sink.Write(samples)
+	samples[0] = 9
+	sink.Write([]int16{3})
+
+	got := sink.(*BufferSink).Samples()
+	if len(got) != 3 || got[0] != 1 || got[2] != 3 {
+		t.Errorf("Expected [1 2 3], got %v", got)
+	}
+}
@@ -0,0 +1,150 @@
+package audio
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Error definitions
+var (
+	// ErrNotWAV is returned when data does not start with a RIFF/WAVE header
+	ErrNotWAV = errors.New("not a WAV file")
+
+	// ErrUnsupportedWAV is returned for WAV files that are not 16-bit integer PCM
+	ErrUnsupportedWAV = errors.New("unsupported WAV format: only 16-bit PCM is supported")
+)
+
+// wavFormatPCM is the WAVE format tag for integer PCM
+const wavFormatPCM = 1
+
+// wavFormatExtensible is the WAVE format tag whose actual format is in the extension
+const wavFormatExtensible = 0xFFFE
+
+// WAV holds decoded 16-bit PCM audio from a WAV file
+type WAV struct {
+	SampleRate int     // Samples per second per channel
+	Channels   int     // Number of interleaved channels
+	Samples    []int16 // Interleaved samples
+}
+
+// Mono returns the samples downmixed to a single channel
+func (w *WAV) Mono() ([]int16, error) {
+	return DownmixInt16(w.Samples, w.Channels)
+}
+
+// ReadWAV decodes a 16-bit PCM WAV file. Chunks other than "fmt " and "data" are skipped.
+// A data chunk with an unknown (zero or oversized) length, as written by streaming
+// encoders, is read until the end of the input.
+func ReadWAV(r io.Reader) (*WAV, error) {
+	var header [12]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrNotWAV, err)
+	}
+	if string(header[0:4]) != "RIFF" || string(header[8:12]) != "WAVE" {
+		return nil, ErrNotWAV
+	}
+
+	var wav *WAV
+	for {
+		var chunk [8]byte
+		if _, err := io.ReadFull(r, chunk[:]); err != nil {
+			return nil, fmt.Errorf("failed to read WAV chunk: %w", err)
+		}
+		id := string(chunk[0:4])
+		size := binary.LittleEndian.Uint32(chunk[4:8])
+
+		switch id {
+		case "fmt ":
+			format := make([]byte, size)
+			if _, err := io.ReadFull(r, format); err != nil {
+				return nil, fmt.Errorf("failed to read WAV format: %w", err)
+			}
+			parsed, err := parseWAVFormat(format)
+			if err != nil {
+				return nil, err
+			}
+			wav = parsed
+		case "data":
+			if wav == nil {
+				return nil, fmt.Errorf("%w: data chunk before format chunk", ErrUnsupportedWAV)
+			}
+			var data []byte
+			var err error
+			if size == 0 || size == 0xFFFFFFFF {
+				data, err = io.ReadAll(r)
+			} else {
+				data, err = io.ReadAll(io.LimitReader(r, int64(size)))
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to read WAV data: %w", err)
+			}
+			// Drop a trailing partial sample from a truncated file
+			data = data[:len(data)-len(data)%BytesPerSample]
+			samples, err := DecodePCM16(data)
+			if err != nil {
+				return nil, err
+			}
+			wav.Samples = samples
+			return wav, nil
+		default:
+			// Chunks are padded to an even size
+			if _, err := io.CopyN(io.Discard, r, int64(size+size%2)); err != nil {
+				return nil, fmt.Errorf("failed to skip WAV chunk %q: %w", id, err)
+			}
+		}
+	}
+}
+
+// parseWAVFormat parses the body of a "fmt " chunk
+func parseWAVFormat(format []byte) (*WAV, error) {
+	if len(format) < 16 {
+		return nil, fmt.Errorf("%w: format chunk too short", ErrUnsupportedWAV)
+	}
+	tag := binary.LittleEndian.Uint16(format[0:2])
+	channels := binary.LittleEndian.Uint16(format[2:4])
+	sampleRate := binary.LittleEndian.Uint32(format[4:8])
+	bitsPerSample := binary.LittleEndian.Uint16(format[14:16])
+
+	if tag == wavFormatExtensible && len(format) >= 26 {
+		// The first two bytes of the sub-format GUID hold the actual format tag
+		tag = binary.LittleEndian.Uint16(format[24:26])
+	}
+	if tag != wavFormatPCM || bitsPerSample != 16 {
+		return nil, fmt.Errorf("%w (format %d, %d bits)", ErrUnsupportedWAV, tag, bitsPerSample)
+	}
+	if channels == 0 {
+		return nil, ErrInvalidChannelCount
+	}
+	return &WAV{SampleRate: int(sampleRate), Channels: int(channels)}, nil
+}
+
+// WriteWAV encodes interleaved 16-bit PCM samples as a WAV file
+func WriteWAV(w io.Writer, sampleRate, channels int, samples []int16) error {
+	if channels < 1 {
+		return ErrInvalidChannelCount
+	}
+	dataSize := len(samples) * BytesPerSample
+
+	header := make([]byte, 44)
+	copy(header[0:4], "RIFF")
+	binary.LittleEndian.PutUint32(header[4:8], uint32(36+dataSize))
+	copy(header[8:12], "WAVE")
+	copy(header[12:16], "fmt ")
+	binary.LittleEndian.PutUint32(header[16:20], 16)
+	binary.LittleEndian.PutUint16(header[20:22], wavFormatPCM)
+	binary.LittleEndian.PutUint16(header[22:24], uint16(channels))
+	binary.LittleEndian.PutUint32(header[24:28], uint32(sampleRate))
+	binary.LittleEndian.PutUint32(header[28:32], uint32(sampleRate*channels*BytesPerSample))
+	binary.LittleEndian.PutUint16(header[32:34], uint16(channels*BytesPerSample))
+	binary.LittleEndian.PutUint16(header[34:36], 16)
+	copy(header[36:40], "data")
+	binary.LittleEndian.PutUint32(header[40:44], uint32(dataSize))
+
+	if _, err := w.Write(header); err != nil {
+		return err
+	}
+	_, err := w.Write(EncodePCM16(samples))
+	return err
+}
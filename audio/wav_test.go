@@ -0,0 +1,93 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestWAVRoundTrip(t *testing.T) {
+	samples := []int16{0, 100, -100, 32767, -32768, 5}
+
+	var buf bytes.Buffer
+	if err := WriteWAV(&buf, 16000, 2, samples); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if buf.Len() != 44+len(samples)*BytesPerSample {
+		t.Errorf("Expected %d bytes, got %d", 44+len(samples)*BytesPerSample, buf.Len())
+	}
+
+	wav, err := ReadWAV(&buf)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if wav.SampleRate != 16000 {
+		t.Errorf("Expected sample rate 16000, got %d", wav.SampleRate)
+	}
+	if wav.Channels != 2 {
+		t.Errorf("Expected 2 channels, got %d", wav.Channels)
+	}
+	if !slices.Equal(wav.Samples, samples) {
+		t.Errorf("Expected samples %v, got %v", samples, wav.Samples)
+	}
+
+	mono, err := wav.Mono()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(mono) != 3 {
+		t.Errorf("Expected 3 mono samples, got %d", len(mono))
+	}
+}
+
+func TestReadWAVSkipsChunksAndStreamingLength(t *testing.T) {
+	var buf bytes.Buffer
+	if err := WriteWAV(&buf, DefaultSampleRate, 1, []int16{1, 2, 3}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	data := buf.Bytes()
+
+	// Insert an odd-sized LIST chunk before the data chunk and mark the data length unknown
+	list := []byte{'L', 'I', 'S', 'T', 3, 0, 0, 0, 'a', 'b', 'c', 0}
+	withList := slices.Concat(data[:36], list, data[36:])
+	binary.LittleEndian.PutUint32(withList[36+len(list)+4:], 0xFFFFFFFF)
+
+	wav, err := ReadWAV(bytes.NewReader(withList))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !slices.Equal(wav.Samples, []int16{1, 2, 3}) {
+		t.Errorf("Expected samples [1 2 3], got %v", wav.Samples)
+	}
+}
+
+func TestReadWAVErrors(t *testing.T) {
+	float := make([]byte, 44)
+	copy(float[0:4], "RIFF")
+	copy(float[8:16], "WAVEfmt ")
+	binary.LittleEndian.PutUint32(float[16:20], 16)
+	binary.LittleEndian.PutUint16(float[20:22], 3)
+	binary.LittleEndian.PutUint16(float[22:24], 1)
+	binary.LittleEndian.PutUint16(float[34:36], 32)
+
+	tests := []struct {
+		name     string
+		data     []byte
+		expected error
+	}{
+		{name: "Empty", data: nil, expected: ErrNotWAV},
+		{name: "RawPCM", data: EncodePCM16(make([]int16, 32)), expected: ErrNotWAV},
+		{name: "Float", data: float, expected: ErrUnsupportedWAV},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ReadWAV(bytes.NewReader(tt.data))
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
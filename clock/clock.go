@@ -0,0 +1,85 @@
+// Package clock provides the time source used for timeouts, backoff and pacing, so tests
+// can replace the system clock with a Fake and advance time without sleeping.
+//
+// Example:
+//
+//	fake := clock.NewFake(time.Unix(0, 0))
+//	sup := messaging.NewSupervisor(dial, run, messaging.WithSupervisorClock(fake))
+//	go sup.Run(ctx)
+//	fake.BlockUntil(1)           // the supervisor is waiting to restart
+//	fake.Advance(time.Second)    // restart without waiting a real second
+package clock
+
+import (
+	"context"
+	"time"
+)
+
+// Clock tells the time and creates timers
+type Clock interface {
+	// Now returns the current time
+	Now() time.Time
+
+	// NewTimer creates a timer that sends the current time on its channel after d
+	NewTimer(d time.Duration) Timer
+
+	// AfterFunc calls f in its own goroutine after d. The returned timer has no channel.
+	AfterFunc(d time.Duration, f func()) Timer
+}
+
+// Timer is a single-shot timer created by a Clock, like time.Timer
+type Timer interface {
+	// C returns the channel the time is sent on when the timer fires, or nil for AfterFunc timers
+	C() <-chan time.Time
+
+	// Stop prevents the timer from firing and reports whether it was stopped before firing
+	Stop() bool
+
+	// Reset changes the timer to fire after d and reports whether it had been active
+	Reset(d time.Duration) bool
+}
+
+// Real returns the system clock
+func Real() Clock {
+	return realClock{}
+}
+
+// Sleep waits for d on c or until ctx is done, returning the context error in that case
+func Sleep(ctx context.Context, c Clock, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := c.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C():
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// realClock is the system clock
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) NewTimer(d time.Duration) Timer {
+	return realTimer{time.NewTimer(d)}
+}
+
+func (realClock) AfterFunc(d time.Duration, f func()) Timer {
+	return realTimer{time.AfterFunc(d, f)}
+}
+
+// realTimer wraps a time.Timer
+type realTimer struct {
+	*time.Timer
+}
+
+func (t realTimer) C() <-chan time.Time {
+	return t.Timer.C
+}
@@ -0,0 +1,85 @@
+package clock
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFakeTimers(t *testing.T) {
+	start := time.Unix(0, 0)
+	fake := NewFake(start)
+
+	var fired []string
+	late := fake.NewTimer(3 * time.Second)
+	fake.AfterFunc(time.Second, func() {
+		fired = append(fired, "func at "+fake.Now().Sub(start).String())
+		// A timer set while firing is scheduled from the firing time
+		fake.AfterFunc(time.Second, func() { fired = append(fired, "nested at "+fake.Now().Sub(start).String()) })
+	})
+	stopped := fake.NewTimer(2 * time.Second)
+
+	if n := fake.Timers(); n != 3 {
+		t.Errorf("Expected 3 pending timers, got %d", n)
+	}
+	if !stopped.Stop() {
+		t.Error("Expected Stop to report an active timer")
+	}
+
+	fake.Advance(2500 * time.Millisecond)
+	expected := []string{"func at 1s", "nested at 2s"}
+	if len(fired) != 2 || fired[0] != expected[0] || fired[1] != expected[1] {
+		t.Errorf("Expected %v, got %v", expected, fired)
+	}
+	if got := fake.Now().Sub(start); got != 2500*time.Millisecond {
+		t.Errorf("Expected the time to be 2.5s, got %v", got)
+	}
+	select {
+	case <-late.C():
+		t.Error("Expected the 3s timer not to fire yet")
+	default:
+	}
+
+	fake.Advance(time.Second)
+	select {
+	case at := <-late.C():
+		if at.Sub(start) != 3*time.Second {
+			t.Errorf("Expected the timer to fire at its deadline, got %v", at.Sub(start))
+		}
+	default:
+		t.Error("Expected the 3s timer to fire")
+	}
+	select {
+	case <-stopped.C():
+		t.Error("Expected the stopped timer not to fire")
+	default:
+	}
+}
+
+func TestSleep(t *testing.T) {
+	fake := NewFake(time.Unix(0, 0))
+
+	done := make(chan error, 1)
+	go func() { done <- Sleep(context.Background(), fake, time.Minute) }()
+	fake.BlockUntil(1)
+	fake.Advance(time.Minute)
+	if err := <-done; err != nil {
+		t.Errorf("Expected the sleep to end, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() { done <- Sleep(ctx, fake, time.Minute) }()
+	fake.BlockUntil(1)
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the sleep to be cancelled, got %v", err)
+	}
+	if n := fake.Timers(); n != 0 {
+		t.Errorf("Expected the cancelled sleep to stop its timer, got %d", n)
+	}
+
+	if err := Sleep(context.Background(), Real(), time.Millisecond); err != nil {
+		t.Errorf("Expected the real sleep to end, got %v", err)
+	}
+}
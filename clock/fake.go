@@ -0,0 +1,168 @@
+package clock
+
+import (
+	"slices"
+	"sync"
+	"time"
+)
+
+// Fake is a Clock whose time only moves when Advance or Set is called. Timers fire, in
+// order of their deadlines, when the time reaches them. AfterFunc functions are called
+// synchronously by Advance and Set, so their effects are visible when those return.
+type Fake struct {
+	mu     sync.Mutex
+	cond   *sync.Cond
+	now    time.Time
+	timers []*fakeTimer
+}
+
+// NewFake creates a Fake set to now
+func NewFake(now time.Time) *Fake {
+	f := &Fake{now: now}
+	f.cond = sync.NewCond(&f.mu)
+	return f
+}
+
+// Now returns the fake time
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.now
+}
+
+// NewTimer creates a timer that fires when the fake time reaches d from now
+func (f *Fake) NewTimer(d time.Duration) Timer {
+	t := &fakeTimer{clock: f, ch: make(chan time.Time, 1)}
+	t.Reset(d)
+	return t
+}
+
+// AfterFunc creates a timer that calls fn when the fake time reaches d from now
+func (f *Fake) AfterFunc(d time.Duration, fn func()) Timer {
+	t := &fakeTimer{clock: f, fn: fn}
+	t.Reset(d)
+	return t
+}
+
+// Advance moves the fake time forward by d, firing the timers that become due
+func (f *Fake) Advance(d time.Duration) {
+	f.Set(f.Now().Add(d))
+}
+
+// Set moves the fake time to now, firing the timers that become due.
+// Moving the time backwards fires nothing.
+func (f *Fake) Set(now time.Time) {
+	for {
+		f.mu.Lock()
+		if len(f.timers) == 0 || f.timers[0].deadline.After(now) {
+			if now.After(f.now) {
+				f.now = now
+			}
+			f.mu.Unlock()
+			return
+		}
+
+		// Fire the earliest timer at its own deadline, so timers it sets are scheduled correctly
+		t := f.timers[0]
+		f.timers = f.timers[1:]
+		t.active = false
+		if t.deadline.After(f.now) {
+			f.now = t.deadline
+		}
+		fired := f.now
+		f.cond.Broadcast()
+		f.mu.Unlock()
+
+		if t.fn != nil {
+			t.fn()
+		} else {
+			select {
+			case t.ch <- fired:
+			default:
+			}
+		}
+	}
+}
+
+// Timers returns the number of timers that have not fired or been stopped
+func (f *Fake) Timers() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.timers)
+}
+
+// BlockUntil waits until at least n timers are pending, e.g. until the code under test
+// is waiting on the clock, so that Advance is not called too early
+func (f *Fake) BlockUntil(n int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for len(f.timers) < n {
+		f.cond.Wait()
+	}
+}
+
+// schedule adds or removes a timer; the lock must be held
+func (f *Fake) schedule(t *fakeTimer, active bool) {
+	f.timers = slices.DeleteFunc(f.timers, func(other *fakeTimer) bool { return other == t })
+	t.active = active
+	if active {
+		i, _ := slices.BinarySearchFunc(f.timers, t.deadline, func(other *fakeTimer, deadline time.Time) int {
+			// Timers with the same deadline fire in the order they were set
+			if other.deadline.After(deadline) {
+				return 1
+			}
+			return -1
+		})
+		f.timers = slices.Insert(f.timers, i, t)
+	}
+	f.cond.Broadcast()
+}
+
+// fakeTimer is a timer of a Fake clock
+type fakeTimer struct {
+	clock    *Fake
+	ch       chan time.Time
+	fn       func()
+	deadline time.Time
+	active   bool
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	if t.fn != nil {
+		return nil
+	}
+	return t.ch
+}
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	wasActive := t.active
+	t.clock.schedule(t, false)
+	return wasActive
+}
+
+func (t *fakeTimer) Reset(d time.Duration) bool {
+	t.clock.mu.Lock()
+	defer t.clock.mu.Unlock()
+	wasActive := t.active
+	t.deadline = t.clock.now.Add(d)
+
+	// A timer that is already due fires now, as a real timer would almost immediately.
+	// Its function runs in its own goroutine since the caller may hold locks it needs.
+	if d <= 0 {
+		t.clock.schedule(t, false)
+		if t.fn != nil {
+			go t.fn()
+		} else {
+			select {
+			case t.ch <- t.clock.now:
+			default:
+			}
+		}
+		return wasActive
+	}
+
+	t.clock.schedule(t, true)
+	return wasActive
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/audio"
+	"github.com/Mliviu79/openai-realtime-go/messaging"
+)
+
+// trailingSilence is appended to audio sent with turn detection, so the server
+// detects the end of speech even when the recording stops abruptly
+const trailingSilence = time.Second
+
+// loadAudio reads a WAV file or raw PCM16 audio from path ("-" for stdin) and converts it
+// to mono PCM16 at the API's sample rate. Raw audio is assumed to be mono at rawRate.
+func loadAudio(path string, rawRate int) ([]int16, error) {
+	var r io.Reader = os.Stdin
+	if path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		r = f
+	}
+
+	br := bufio.NewReader(r)
+	magic, _ := br.Peek(4)
+
+	var samples []int16
+	rate := rawRate
+	if string(magic) == "RIFF" {
+		wav, err := audio.ReadWAV(br)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		if samples, err = wav.Mono(); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		rate = wav.SampleRate
+	} else {
+		data, err := io.ReadAll(br)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		if samples, err = audio.DecodePCM16(data); err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+	}
+
+	if len(samples) == 0 {
+		return nil, fmt.Errorf("%s contains no audio", path)
+	}
+	return audio.Resample(samples, rate, audio.DefaultSampleRate)
+}
+
+// sendAudio appends the samples to the input audio buffer in 100ms chunks, padding the
+// final chunk to the minimum commit size and optionally following it with silence
+func sendAudio(ctx context.Context, client *messaging.Client, samples []int16, withSilence bool) error {
+	if withSilence {
+		samples = append(samples, make([]int16, audio.SamplesForDuration(audio.DefaultSampleRate, trailingSilence))...)
+	}
+
+	chunker := audio.NewChunker(audio.DefaultSampleRate, audio.DefaultChunkDuration)
+	chunks := chunker.Write(audio.EncodePCM16(samples))
+	if last := chunker.Flush(); last != nil {
+		chunks = append(chunks, last)
+	}
+
+	for _, chunk := range chunks {
+		if err := client.SendAudioBufferAppend(ctx, base64.StdEncoding.EncodeToString(chunk)); err != nil {
+			return fmt.Errorf("failed to send audio: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/messages/types"
+	"github.com/Mliviu79/openai-realtime-go/messaging"
+	"github.com/Mliviu79/openai-realtime-go/openaiClient"
+	"github.com/Mliviu79/openai-realtime-go/session"
+)
+
+// dumpConfig holds the flags of the dump subcommand
+type dumpConfig struct {
+	model         string
+	transcription bool
+	format        string
+	outPath       string
+	outgoing      bool
+	fixturesDir   string
+	text          string
+	duration      time.Duration
+}
+
+// parseDumpFlags parses the flags of the dump subcommand
+func parseDumpFlags(args []string) dumpConfig {
+	fs := flag.NewFlagSet("dump", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: realtime-cli dump [flags]")
+		fs.PrintDefaults()
+	}
+
+	var cfg dumpConfig
+	fs.StringVar(&cfg.model, "model", string(session.GPTRealtime), "realtime model to connect to")
+	fs.BoolVar(&cfg.transcription, "transcription", false, "connect to a transcription session instead of a conversation")
+	fs.StringVar(&cfg.format, "format", "jsonl", `output format: "jsonl" or "pretty"`)
+	fs.StringVar(&cfg.outPath, "out", "-", `file to write the events to; "-" writes stdout`)
+	fs.BoolVar(&cfg.outgoing, "outgoing", false, "also dump the events sent by the CLI")
+	fs.StringVar(&cfg.fixturesDir, "fixtures", "", "write the first event of each type to DIR/<type>.json as a golden fixture")
+	fs.StringVar(&cfg.text, "text", "", "send this user message and request a response after connecting")
+	fs.DurationVar(&cfg.duration, "duration", 0, "stop after this long; zero runs until interrupted")
+	fs.Parse(args)
+
+	if cfg.format != string(messaging.DumpFormatJSONL) && cfg.format != string(messaging.DumpFormatPretty) {
+		fmt.Fprintln(os.Stderr, "realtime-cli: -format must be jsonl or pretty")
+		os.Exit(2)
+	}
+	if cfg.text != "" && cfg.transcription {
+		fmt.Fprintln(os.Stderr, "realtime-cli: -text cannot be used with -transcription")
+		os.Exit(2)
+	}
+	return cfg
+}
+
+// runDump connects and dumps every event until interrupted or the duration has passed
+func runDump(ctx context.Context, cfg dumpConfig) error {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		return errors.New("OPENAI_API_KEY environment variable is required")
+	}
+
+	var out io.Writer = os.Stdout
+	if cfg.outPath != "-" {
+		f, err := os.Create(cfg.outPath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		out = f
+	}
+
+	opts := []messaging.EventDumperOption{messaging.WithDumpFormat(messaging.DumpFormat(cfg.format))}
+	if cfg.outgoing {
+		opts = append(opts, messaging.WithDumpOutgoing())
+	}
+
+	var fixtures *fixtureWriter
+	if cfg.fixturesDir != "" {
+		if err := os.MkdirAll(cfg.fixturesDir, 0o755); err != nil {
+			return err
+		}
+		fixtures = newFixtureWriter(cfg.fixturesDir)
+		out = io.MultiWriter(out, fixtures)
+	}
+	dumper := messaging.NewEventDumper(out, opts...)
+
+	connectOpts := []openaiClient.ConnectOption{openaiClient.WithModel(session.Model(cfg.model))}
+	if cfg.transcription {
+		connectOpts = []openaiClient.ConnectOption{openaiClient.WithIntent(openaiClient.IntentTranscription)}
+	}
+	conn, err := openaiClient.NewClient(apiKey).Connect(ctx, connectOpts...)
+	if err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+
+	msgClient := messaging.NewClient(conn)
+	defer msgClient.Close()
+	msgClient.SetEventDumper(dumper)
+
+	if cfg.duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.duration)
+		defer cancel()
+	}
+
+	handler := messaging.NewHandler(ctx, msgClient)
+	handler.Start()
+	defer handler.Stop()
+
+	if cfg.text != "" {
+		if err := msgClient.SendText(ctx, cfg.text); err != nil {
+			return fmt.Errorf("failed to send message: %w", err)
+		}
+		if err := msgClient.SendResponseCreate(ctx, &types.ResponseConfig{}); err != nil {
+			return fmt.Errorf("failed to request response: %w", err)
+		}
+	}
+
+	select {
+	case <-ctx.Done():
+	case err := <-handler.Err():
+		if err != nil && ctx.Err() == nil {
+			return fmt.Errorf("connection closed: %w", err)
+		}
+	}
+
+	// Interrupting the dump or reaching the duration is the normal way to stop it
+	if err := dumper.Err(); err != nil {
+		return err
+	}
+	if fixtures != nil {
+		fmt.Fprintf(os.Stderr, "wrote %d fixtures to %s\n", fixtures.Count(), cfg.fixturesDir)
+		return fixtures.Err()
+	}
+	return nil
+}
+
+// fixtureWriter receives dump records, one per Write in either format, and writes the
+// first incoming event of each type to dir/<type>.json, indented like the golden
+// fixtures in messages/messagestest so they can be checked with CheckIncomingDir
+type fixtureWriter struct {
+	dir string
+
+	mu      sync.Mutex
+	written map[string]bool
+	err     error
+}
+
+// newFixtureWriter creates a fixtureWriter writing to dir
+func newFixtureWriter(dir string) *fixtureWriter {
+	return &fixtureWriter{dir: dir, written: make(map[string]bool)}
+}
+
+// Write processes one dump record
+func (f *fixtureWriter) Write(p []byte) (int, error) {
+	var record messaging.DumpRecord
+	if err := json.Unmarshal(p, &record); err != nil {
+		return 0, err
+	}
+	if record.Direction != messaging.DumpDirectionIncoming {
+		return len(p), nil
+	}
+
+	var base struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(record.Event, &base); err != nil || base.Type == "" {
+		return len(p), nil
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.written[base.Type] {
+		return len(p), nil
+	}
+	f.written[base.Type] = true
+
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, record.Event, "", "  "); err != nil {
+		return len(p), nil
+	}
+	buf.WriteByte('\n')
+	if err := os.WriteFile(filepath.Join(f.dir, base.Type+".json"), buf.Bytes(), 0o644); err != nil && f.err == nil {
+		f.err = err
+	}
+	return len(p), nil
+}
+
+// Count returns the number of fixtures written
+func (f *fixtureWriter) Count() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.written)
+}
+
+// Err returns the first error writing a fixture, if any
+func (f *fixtureWriter) Err() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.err
+}
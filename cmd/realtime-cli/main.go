@@ -0,0 +1,285 @@
+// Command realtime-cli opens a Realtime API session from the terminal, for trying out
+// session configurations without writing a program.
+//
+// It runs in one of these modes:
+//   - Interactive text chat (the default): each line typed on stdin is sent as a user
+//     message and the streaming response is printed.
+//   - Audio: -audio sends a WAV file, or raw 24kHz mono PCM16 audio, as one user turn
+//     and prints the response. Use "-audio -" to read the audio from stdin.
+//   - Transcription: the transcribe subcommand streams audio into a transcription
+//     session and prints the transcript as text or JSON lines.
+//   - Dump: the dump subcommand prints every raw server event with a timestamp, and can
+//     save the first event of each type as a golden fixture.
+//
+// Usage:
+//
+//	export OPENAI_API_KEY=sk-...
+//	realtime-cli -instructions "Be brief."
+//	realtime-cli -audio question.wav -modalities audio -voice verse -save answer.wav
+//	sox input.mp3 -t wav - | realtime-cli -audio - -vad none
+//	realtime-cli transcribe -format json -logprobs meeting.wav
+//	realtime-cli dump -text "Hello" -duration 10s -fixtures testdata/captured
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/messages/types"
+	"github.com/Mliviu79/openai-realtime-go/messaging"
+	"github.com/Mliviu79/openai-realtime-go/openaiClient"
+	"github.com/Mliviu79/openai-realtime-go/session"
+)
+
+// config holds the command line flags
+type config struct {
+	model         string
+	voice         string
+	instructions  string
+	modalities    string
+	vad           string
+	transcription string
+	audioPath     string
+	rawRate       int
+	savePath      string
+	timeout       time.Duration
+	debug         bool
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	var err error
+	switch {
+	case len(os.Args) > 1 && os.Args[1] == "transcribe":
+		err = runTranscribe(ctx, parseTranscribeFlags(os.Args[2:]))
+	case len(os.Args) > 1 && os.Args[1] == "dump":
+		err = runDump(ctx, parseDumpFlags(os.Args[2:]))
+	default:
+		err = run(ctx, parseFlags())
+	}
+	if err != nil && !errors.Is(err, context.Canceled) {
+		fmt.Fprintln(os.Stderr, "realtime-cli:", err)
+		os.Exit(1)
+	}
+}
+
+// parseFlags parses and checks the command line flags
+func parseFlags() config {
+	var cfg config
+	flag.StringVar(&cfg.model, "model", string(session.GPTRealtime), "realtime model to connect to")
+	flag.StringVar(&cfg.voice, "voice", "", "voice for audio responses, e.g. alloy or verse")
+	flag.StringVar(&cfg.instructions, "instructions", "", "system instructions for the session")
+	flag.StringVar(&cfg.modalities, "modalities", "text", `response modalities: "text" or "audio"`)
+	flag.StringVar(&cfg.vad, "vad", "server_vad", `turn detection for audio input: "server_vad", "semantic_vad" or "none"`)
+	flag.StringVar(&cfg.transcription, "transcribe", "", "transcription model for input audio, e.g. gpt-4o-transcribe")
+	flag.StringVar(&cfg.audioPath, "audio", "", `WAV or raw PCM16 file to send as one user turn; "-" reads stdin`)
+	flag.IntVar(&cfg.rawRate, "rate", session.AudioFormatPCM16.SampleRate(), "sample rate of raw PCM16 input")
+	flag.StringVar(&cfg.savePath, "save", "", "write the audio of the responses to this WAV file")
+	flag.DurationVar(&cfg.timeout, "timeout", 2*time.Minute, "maximum time to wait for each response")
+	flag.BoolVar(&cfg.debug, "debug", false, "print the type of every server event")
+	flag.Parse()
+
+	if cfg.modalities != "text" && cfg.modalities != "audio" {
+		usageError("-modalities must be text or audio")
+	}
+	switch cfg.vad {
+	case "server_vad", "semantic_vad", "none":
+	default:
+		usageError("-vad must be server_vad, semantic_vad or none")
+	}
+	if cfg.savePath != "" && cfg.modalities != "audio" {
+		usageError("-save requires -modalities audio")
+	}
+	return cfg
+}
+
+// usageError reports an invalid flag combination and exits
+func usageError(msg string) {
+	fmt.Fprintln(os.Stderr, "realtime-cli:", msg)
+	flag.Usage()
+	os.Exit(2)
+}
+
+// run connects, configures the session and runs the selected mode
+func run(ctx context.Context, cfg config) error {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		return errors.New("OPENAI_API_KEY environment variable is required")
+	}
+
+	client := openaiClient.NewClient(apiKey)
+	conn, err := client.Connect(ctx, openaiClient.WithModel(session.Model(cfg.model)))
+	if err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+
+	msgClient := messaging.NewClient(conn)
+	defer msgClient.Close()
+
+	printer := newPrinter(os.Stdout, cfg.debug)
+	handler := messaging.NewHandler(ctx, msgClient, printer.Handle)
+	handler.Start()
+	defer handler.Stop()
+
+	if err := configure(ctx, msgClient, cfg); err != nil {
+		return err
+	}
+
+	if cfg.audioPath != "" {
+		err = runAudio(ctx, msgClient, printer, cfg)
+	} else {
+		err = runChat(ctx, msgClient, printer, cfg)
+	}
+
+	if cfg.savePath != "" {
+		if saveErr := printer.SaveAudio(cfg.savePath); saveErr != nil && err == nil {
+			err = saveErr
+		}
+	}
+	return err
+}
+
+// configure sends the session.update built from the flags
+func configure(ctx context.Context, client *messaging.Client, cfg config) error {
+	req := session.SessionRequest{}
+	if cfg.instructions != "" {
+		req.Instructions = &cfg.instructions
+	}
+	if cfg.voice != "" {
+		voice := session.Voice(cfg.voice)
+		req.Voice = &voice
+	}
+	modalities := []session.Modality{session.ModalityText}
+	if cfg.modalities == "audio" {
+		modalities = []session.Modality{session.ModalityAudio, session.ModalityText}
+	}
+	req.Modalities = &modalities
+	if cfg.transcription != "" {
+		req.InputAudioTranscription = &session.InputAudioTranscription{Model: session.TranscriptionModel(cfg.transcription)}
+	}
+	if cfg.vad != "none" {
+		req.TurnDetection = &session.TurnDetection{Type: session.TurnDetectionType(cfg.vad)}
+	}
+
+	if err := client.SendSessionUpdate(ctx, req); err != nil {
+		return fmt.Errorf("failed to configure session: %w", err)
+	}
+
+	if cfg.vad == "none" {
+		return disableTurnDetection(ctx, client, "session.update")
+	}
+	return nil
+}
+
+// disableTurnDetection sends an update of the given type that disables turn detection.
+// A nil TurnDetection is omitted from typed updates, so the null is sent as a raw event.
+func disableTurnDetection(ctx context.Context, client *messaging.Client, eventType string) error {
+	data, _ := json.Marshal(map[string]any{
+		"type":    eventType,
+		"session": map[string]any{"turn_detection": nil},
+	})
+	if err := client.SendRaw(ctx, data); err != nil {
+		return fmt.Errorf("failed to disable turn detection: %w", err)
+	}
+	return nil
+}
+
+// runChat sends each line from stdin as a user message and prints the responses
+func runChat(ctx context.Context, client *messaging.Client, printer *printer, cfg config) error {
+	lines := make(chan string)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+	}()
+
+	fmt.Println("Connected. Type a message and press enter; /quit or Ctrl-D exits.")
+	for {
+		fmt.Print("> ")
+		var line string
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case l, ok := <-lines:
+			if !ok {
+				fmt.Println()
+				return nil
+			}
+			line = strings.TrimSpace(l)
+		}
+
+		switch line {
+		case "":
+			continue
+		case "/quit", "/exit":
+			return nil
+		}
+
+		if err := client.SendText(ctx, line); err != nil {
+			return fmt.Errorf("failed to send message: %w", err)
+		}
+		if err := requestResponse(ctx, client, printer, cfg); err != nil {
+			return err
+		}
+	}
+}
+
+// runAudio sends the audio input as one user turn and prints the response
+func runAudio(ctx context.Context, client *messaging.Client, printer *printer, cfg config) error {
+	samples, err := loadAudio(cfg.audioPath, cfg.rawRate)
+	if err != nil {
+		return err
+	}
+
+	if cfg.vad == "none" {
+		if err := sendAudio(ctx, client, samples, false); err != nil {
+			return err
+		}
+		if err := client.SendAudioBufferCommit(ctx, ""); err != nil {
+			return fmt.Errorf("failed to commit audio: %w", err)
+		}
+		return requestResponse(ctx, client, printer, cfg)
+	}
+
+	// Turn detection commits the audio and starts the response on its own
+	wait := printer.ExpectResponse()
+	if err := sendAudio(ctx, client, samples, true); err != nil {
+		return err
+	}
+	return awaitResponse(ctx, wait, cfg.timeout)
+}
+
+// requestResponse asks for a response and waits until it is done
+func requestResponse(ctx context.Context, client *messaging.Client, printer *printer, cfg config) error {
+	wait := printer.ExpectResponse()
+	if err := client.SendResponseCreate(ctx, &types.ResponseConfig{}); err != nil {
+		return fmt.Errorf("failed to request response: %w", err)
+	}
+	return awaitResponse(ctx, wait, cfg.timeout)
+}
+
+// awaitResponse waits for the response the printer is expecting
+func awaitResponse(ctx context.Context, wait <-chan error, timeout time.Duration) error {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case err := <-wait:
+		return err
+	case <-timer.C:
+		return fmt.Errorf("no response within %s", timeout)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Mliviu79/openai-realtime-go/audio"
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+	"github.com/Mliviu79/openai-realtime-go/messaging"
+)
+
+func mustParse(t *testing.T, data string) incoming.RcvdMsg {
+	t.Helper()
+	msg, err := incoming.UnmarshalRcvdMsg([]byte(data))
+	if err != nil {
+		t.Fatalf("Failed to parse %s: %v", data, err)
+	}
+	return msg
+}
+
+func TestPrinter(t *testing.T) {
+	var out bytes.Buffer
+	p := newPrinter(&out, false)
+	wait := p.ExpectResponse()
+
+	pcm := base64.StdEncoding.EncodeToString(audio.EncodePCM16([]int16{1, 2, 3}))
+	for _, data := range []string{
+		`{"type":"response.output_text.delta","delta":"Hel"}`,
+		`{"type":"response.output_audio.delta","delta":"` + pcm + `"}`,
+		`{"type":"response.output_text.delta","delta":"lo"}`,
+		`{"type":"response.done","response":{"status":"completed"}}`,
+	} {
+		p.Handle(context.Background(), mustParse(t, data))
+	}
+
+	select {
+	case err := <-wait:
+		if err != nil {
+			t.Errorf("Expected response to complete, got %v", err)
+		}
+	default:
+		t.Fatal("Expected the response to be done")
+	}
+	if out.String() != "Hello\n" {
+		t.Errorf("Expected output %q, got %q", "Hello\n", out.String())
+	}
+
+	path := filepath.Join(t.TempDir(), "out.wav")
+	if err := p.SaveAudio(path); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer f.Close()
+	wav, err := audio.ReadWAV(f)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(wav.Samples) != 3 {
+		t.Errorf("Expected 3 saved samples, got %d", len(wav.Samples))
+	}
+}
+
+func TestPrinterError(t *testing.T) {
+	p := newPrinter(&bytes.Buffer{}, false)
+	wait := p.ExpectResponse()
+
+	p.Handle(context.Background(), mustParse(t, `{"type":"error","error":{"type":"invalid_request_error","message":"Invalid voice"}}`))
+
+	select {
+	case err := <-wait:
+		if err == nil || !strings.Contains(err.Error(), "Invalid voice") {
+			t.Errorf("Expected the server error, got %v", err)
+		}
+	default:
+		t.Fatal("Expected the error to end the wait")
+	}
+}
+
+func TestLoadAudio(t *testing.T) {
+	dir := t.TempDir()
+
+	var wav bytes.Buffer
+	if err := audio.WriteWAV(&wav, 12000, 2, []int16{100, 300, 200, 200}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	wavPath := filepath.Join(dir, "in.wav")
+	if err := os.WriteFile(wavPath, wav.Bytes(), 0o600); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	rawPath := filepath.Join(dir, "in.pcm")
+	if err := os.WriteFile(rawPath, audio.EncodePCM16([]int16{1, 2, 3, 4}), 0o600); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		path     string
+		expected int
+	}{
+		// Two stereo frames at 12kHz become four mono samples at 24kHz
+		{name: "wav", path: wavPath, expected: 4},
+		{name: "raw", path: rawPath, expected: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			samples, err := loadAudio(tt.path, audio.DefaultSampleRate)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if len(samples) != tt.expected {
+				t.Errorf("Expected %d samples, got %d", tt.expected, len(samples))
+			}
+		})
+	}
+}
+
+func TestFixtureWriter(t *testing.T) {
+	dir := t.TempDir()
+	fixtures := newFixtureWriter(dir)
+
+	for _, format := range []messaging.DumpFormat{messaging.DumpFormatJSONL, messaging.DumpFormatPretty} {
+		dumper := messaging.NewEventDumper(fixtures, messaging.WithDumpFormat(format), messaging.WithDumpOutgoing())
+		dumper.Dump(messaging.DumpDirectionIncoming, []byte(`{"type":"response.created","event_id":"`+string(format)+`"}`))
+		dumper.Dump(messaging.DumpDirectionOutgoing, []byte(`{"type":"response.create"}`))
+		if err := dumper.Err(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	if fixtures.Count() != 1 {
+		t.Errorf("Expected 1 fixture, got %d", fixtures.Count())
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "response.created.json"))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := "{\n  \"type\": \"response.created\",\n  \"event_id\": \"jsonl\"\n}\n"
+	if string(data) != expected {
+		t.Errorf("Expected the first event as fixture %q, got %q", expected, string(data))
+	}
+	if _, err := os.Stat(filepath.Join(dir, "response.create.json")); !os.IsNotExist(err) {
+		t.Errorf("Expected outgoing events to be skipped, got %v", err)
+	}
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"github.com/Mliviu79/openai-realtime-go/audio"
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+	"github.com/Mliviu79/openai-realtime-go/messages/types"
+)
+
+// printer prints the streaming output of the session and collects response audio
+type printer struct {
+	out   io.Writer
+	debug bool
+
+	mu      sync.Mutex
+	pending chan error
+	audio   []byte
+}
+
+// newPrinter creates a printer writing to out
+func newPrinter(out io.Writer, debug bool) *printer {
+	return &printer{out: out, debug: debug}
+}
+
+// ExpectResponse returns a channel that receives nil when the next response is done,
+// or an error if it fails or the server reports an error first
+func (p *printer) ExpectResponse() <-chan error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.pending = make(chan error, 1)
+	return p.pending
+}
+
+// finish completes the response being waited for, if any
+func (p *printer) finish(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.pending != nil {
+		p.pending <- err
+		p.pending = nil
+	}
+}
+
+// Handle prints an incoming message. It has the MessageHandler signature.
+func (p *printer) Handle(ctx context.Context, msg incoming.RcvdMsg) {
+	if p.debug {
+		fmt.Fprintf(os.Stderr, "[event] %s\n", msg.RcvdMsgType())
+	}
+
+	switch m := msg.(type) {
+	case *incoming.ResponseOutputTextDeltaMessage:
+		fmt.Fprint(p.out, m.Delta)
+	case *incoming.ResponseOutputAudioTranscriptDeltaMessage:
+		fmt.Fprint(p.out, m.Delta)
+	case *incoming.ResponseOutputAudioDeltaMessage:
+		data, err := m.DecodeAudio()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "invalid audio delta: %v\n", err)
+			return
+		}
+		p.mu.Lock()
+		p.audio = append(p.audio, data...)
+		p.mu.Unlock()
+	case *incoming.ConversationItemTranscriptionCompletedMessage:
+		fmt.Fprintf(p.out, "[you] %s\n", m.Transcript)
+	case *incoming.ResponseDoneMessage:
+		fmt.Fprintln(p.out)
+		if m.Response.Status.IsError() {
+			p.finish(fmt.Errorf("response failed: %s", responseFailure(m.Response)))
+			return
+		}
+		p.finish(nil)
+	case *incoming.ErrorMessage:
+		err := m.AsAPIError()
+		fmt.Fprintf(os.Stderr, "error: %v\n", err)
+		p.finish(err)
+	}
+}
+
+// SaveAudio writes the collected response audio to a WAV file
+func (p *printer) SaveAudio(path string) error {
+	p.mu.Lock()
+	data := p.audio
+	p.mu.Unlock()
+
+	samples, err := audio.DecodePCM16(data)
+	if err != nil {
+		return fmt.Errorf("invalid response audio: %w", err)
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := audio.WriteWAV(f, audio.DefaultSampleRate, 1, samples); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "saved %s of audio to %s\n", audio.DurationForSamples(audio.DefaultSampleRate, len(samples)), path)
+	return nil
+}
+
+// responseFailure describes why a response failed
+func responseFailure(resp types.Response) string {
+	if failed, ok := resp.StatusDetails.Failed(); ok && failed.Error.Code != "" {
+		return fmt.Sprintf("%s (%s)", failed.Error.Code, failed.Error.Type)
+	}
+	if resp.StatusDetails != nil && resp.StatusDetails.Reason != "" {
+		return resp.StatusDetails.Reason
+	}
+	return "unknown reason"
+}
@@ -0,0 +1,309 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+	"github.com/Mliviu79/openai-realtime-go/messaging"
+	"github.com/Mliviu79/openai-realtime-go/openaiClient"
+	"github.com/Mliviu79/openai-realtime-go/session"
+)
+
+// transcriptionSettle is how long the session must stay quiet after the audio was sent
+// before the transcription is considered complete
+const transcriptionSettle = 1500 * time.Millisecond
+
+// transcribeConfig holds the flags of the transcribe subcommand
+type transcribeConfig struct {
+	model      string
+	language   string
+	prompt     string
+	vad        string
+	rawRate    int
+	format     string
+	logprobs   bool
+	timestamps bool
+	timeout    time.Duration
+	debug      bool
+	audioPath  string
+}
+
+// parseTranscribeFlags parses the flags of the transcribe subcommand. The audio
+// path is the single positional argument; it defaults to stdin.
+func parseTranscribeFlags(args []string) transcribeConfig {
+	fs := flag.NewFlagSet("transcribe", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: realtime-cli transcribe [flags] [file.wav|-]")
+		fs.PrintDefaults()
+	}
+
+	var cfg transcribeConfig
+	fs.StringVar(&cfg.model, "model", string(session.TranscriptionModelGPT4oTranscribe), "transcription model")
+	fs.StringVar(&cfg.language, "language", "", "ISO-639-1 language of the audio, e.g. en")
+	fs.StringVar(&cfg.prompt, "prompt", "", "text to guide the transcription style or vocabulary")
+	fs.StringVar(&cfg.vad, "vad", "server_vad", `turn detection: "server_vad", "semantic_vad" or "none" for a single segment`)
+	fs.IntVar(&cfg.rawRate, "rate", session.AudioFormatPCM16.SampleRate(), "sample rate of raw PCM16 input")
+	fs.StringVar(&cfg.format, "format", "text", `output format: "text" or "json" (one object per line)`)
+	fs.BoolVar(&cfg.logprobs, "logprobs", false, "include token log probabilities (gpt-4o transcription models only)")
+	fs.BoolVar(&cfg.timestamps, "timestamps", false, "include the start and end of each segment in text output")
+	fs.DurationVar(&cfg.timeout, "timeout", 5*time.Minute, "maximum time to wait for the transcription")
+	fs.BoolVar(&cfg.debug, "debug", false, "print the type of every server event")
+	fs.Parse(args)
+
+	switch cfg.vad {
+	case "server_vad", "semantic_vad", "none":
+	default:
+		fmt.Fprintln(os.Stderr, "realtime-cli: -vad must be server_vad, semantic_vad or none")
+		os.Exit(2)
+	}
+	if cfg.format != "text" && cfg.format != "json" {
+		fmt.Fprintln(os.Stderr, "realtime-cli: -format must be text or json")
+		os.Exit(2)
+	}
+
+	switch fs.NArg() {
+	case 0:
+		cfg.audioPath = "-"
+	case 1:
+		cfg.audioPath = fs.Arg(0)
+	default:
+		fs.Usage()
+		os.Exit(2)
+	}
+	return cfg
+}
+
+// runTranscribe streams the audio into a transcription session and prints each segment
+func runTranscribe(ctx context.Context, cfg transcribeConfig) error {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		return errors.New("OPENAI_API_KEY environment variable is required")
+	}
+
+	samples, err := loadAudio(cfg.audioPath, cfg.rawRate)
+	if err != nil {
+		return err
+	}
+
+	client := openaiClient.NewClient(apiKey)
+	conn, err := client.Connect(ctx, openaiClient.WithIntent(openaiClient.IntentTranscription))
+	if err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+
+	msgClient := messaging.NewClient(conn)
+	defer msgClient.Close()
+
+	t := newTranscriber(os.Stdout, cfg.format, cfg.timestamps, cfg.debug)
+	handler := messaging.NewHandler(ctx, msgClient, t.Handle)
+	handler.Start()
+	defer handler.Stop()
+
+	if err := configureTranscription(ctx, msgClient, cfg); err != nil {
+		return err
+	}
+
+	if err := sendAudio(ctx, msgClient, samples, cfg.vad != "none"); err != nil {
+		return err
+	}
+	if cfg.vad == "none" {
+		if err := msgClient.SendAudioBufferCommit(ctx, ""); err != nil {
+			return fmt.Errorf("failed to commit audio: %w", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, cfg.timeout)
+	defer cancel()
+	return t.Wait(ctx, transcriptionSettle)
+}
+
+// configureTranscription sends the transcription_session.update built from the flags
+func configureTranscription(ctx context.Context, client *messaging.Client, cfg transcribeConfig) error {
+	format := session.AudioFormatPCM16
+	req := session.TranscriptionSessionRequest{
+		InputAudioFormat: &format,
+		InputAudioTranscription: &session.InputAudioTranscription{
+			Model:    session.TranscriptionModel(cfg.model),
+			Language: cfg.language,
+			Prompt:   cfg.prompt,
+		},
+	}
+	if cfg.logprobs {
+		req.Include = &[]session.TranscriptionSessionInclude{session.TranscriptionSessionIncludeLogprobs}
+	}
+	if cfg.vad != "none" {
+		req.TurnDetection = &session.TurnDetection{Type: session.TurnDetectionType(cfg.vad)}
+	}
+
+	if err := client.SendTranscriptionSessionUpdate(ctx, req); err != nil {
+		return fmt.Errorf("failed to configure transcription session: %w", err)
+	}
+	if cfg.vad == "none" {
+		return disableTurnDetection(ctx, client, "transcription_session.update")
+	}
+	return nil
+}
+
+// segmentLogprob is the log probability of a transcribed token
+type segmentLogprob struct {
+	Token   string  `json:"token"`
+	Logprob float64 `json:"logprob"`
+}
+
+// segment is one transcribed turn, written as a JSON line in json output
+type segment struct {
+	ItemID     string           `json:"item_id"`
+	StartMs    *int64           `json:"start_ms,omitempty"`
+	EndMs      *int64           `json:"end_ms,omitempty"`
+	Transcript string           `json:"transcript"`
+	Logprobs   []segmentLogprob `json:"logprobs,omitempty"`
+	Error      string           `json:"error,omitempty"`
+}
+
+// transcriber prints transcription segments and tracks when all audio is transcribed
+type transcriber struct {
+	out        io.Writer
+	json       bool
+	timestamps bool
+	debug      bool
+
+	mu        sync.Mutex
+	starts    map[string]int64
+	ends      map[string]int64
+	speaking  bool
+	pending   map[string]bool
+	segments  int
+	lastEvent time.Time
+	err       error
+}
+
+// newTranscriber creates a transcriber writing segments to out in the given format
+func newTranscriber(out io.Writer, format string, timestamps, debug bool) *transcriber {
+	return &transcriber{
+		out:        out,
+		json:       format == "json",
+		timestamps: timestamps,
+		debug:      debug,
+		starts:     make(map[string]int64),
+		ends:       make(map[string]int64),
+		pending:    make(map[string]bool),
+		lastEvent:  time.Now(),
+	}
+}
+
+// Handle records the speech timing, committed items and transcription results of the
+// input audio
+func (t *transcriber) Handle(ctx context.Context, msg incoming.RcvdMsg) {
+	if t.debug {
+		fmt.Fprintf(os.Stderr, "[event] %s\n", msg.RcvdMsgType())
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.lastEvent = time.Now()
+
+	switch m := msg.(type) {
+	case *incoming.AudioBufferSpeechStartedMessage:
+		t.speaking = true
+		t.starts[m.ItemID] = m.AudioStartMs
+	case *incoming.AudioBufferSpeechStoppedMessage:
+		t.speaking = false
+		t.ends[m.ItemID] = m.AudioEndMs
+	case *incoming.AudioBufferCommittedMessage:
+		t.pending[m.ItemID] = true
+	case *incoming.ConversationItemTranscriptionCompletedMessage:
+		seg := t.segment(m.ItemID)
+		seg.Transcript = m.Transcript
+		for _, lp := range m.Logprobs {
+			seg.Logprobs = append(seg.Logprobs, segmentLogprob{Token: lp.Token, Logprob: lp.Logprob})
+		}
+		t.write(seg)
+	case *incoming.ConversationItemTranscriptionFailedMessage:
+		seg := t.segment(m.ItemID)
+		seg.Error = m.Error.Message
+		t.write(seg)
+	case *incoming.ErrorMessage:
+		fmt.Fprintf(os.Stderr, "error: %v\n", m.AsAPIError())
+		if t.err == nil {
+			t.err = m.AsAPIError()
+		}
+	}
+}
+
+// segment starts a segment for the item and marks it transcribed; the lock must be held
+func (t *transcriber) segment(itemID string) segment {
+	delete(t.pending, itemID)
+	t.segments++
+	seg := segment{ItemID: itemID}
+	if start, ok := t.starts[itemID]; ok {
+		seg.StartMs = &start
+	}
+	if end, ok := t.ends[itemID]; ok {
+		seg.EndMs = &end
+	}
+	return seg
+}
+
+// write prints a segment in the configured format; the lock must be held
+func (t *transcriber) write(seg segment) {
+	if t.json {
+		data, _ := json.Marshal(seg)
+		fmt.Fprintln(t.out, string(data))
+		return
+	}
+
+	if seg.Error != "" {
+		fmt.Fprintf(os.Stderr, "transcription of %s failed: %s\n", seg.ItemID, seg.Error)
+		return
+	}
+	if t.timestamps && seg.StartMs != nil && seg.EndMs != nil {
+		fmt.Fprintf(t.out, "[%s - %s] ", formatTimestamp(*seg.StartMs), formatTimestamp(*seg.EndMs))
+	}
+	fmt.Fprintln(t.out, seg.Transcript)
+	for _, lp := range seg.Logprobs {
+		fmt.Fprintf(t.out, "  %-16q %.4f\n", lp.Token, lp.Logprob)
+	}
+}
+
+// Wait blocks until every committed segment is transcribed and no event arrived for
+// settle, or until a server error, or the context is done
+func (t *transcriber) Wait(ctx context.Context, settle time.Duration) error {
+	ticker := time.NewTicker(settle / 10)
+	defer ticker.Stop()
+	for {
+		t.mu.Lock()
+		err := t.err
+		done := !t.speaking && len(t.pending) == 0 && time.Since(t.lastEvent) >= settle
+		segments := t.segments
+		t.mu.Unlock()
+
+		if err != nil {
+			return err
+		}
+		if done {
+			if segments == 0 {
+				return errors.New("no speech was detected in the audio")
+			}
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// formatTimestamp formats milliseconds as mm:ss.mmm
+func formatTimestamp(ms int64) string {
+	return fmt.Sprintf("%02d:%02d.%03d", ms/60000, ms/1000%60, ms%1000)
+}
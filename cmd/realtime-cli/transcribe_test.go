@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+// transcriptionEvents is a server VAD transcription of a single segment
+var transcriptionEvents = []string{
+	`{"type":"input_audio_buffer.speech_started","audio_start_ms":1230,"item_id":"item_1"}`,
+	`{"type":"input_audio_buffer.speech_stopped","audio_end_ms":64500,"item_id":"item_1"}`,
+	`{"type":"input_audio_buffer.committed","item_id":"item_1"}`,
+	`{"type":"conversation.item.input_audio_transcription.completed","item_id":"item_1","content_index":0,"transcript":"Hello there.","logprobs":[{"token":"Hello","logprob":-0.01,"bytes":[72]}]}`,
+}
+
+func TestTranscriberOutput(t *testing.T) {
+	tests := []struct {
+		name       string
+		format     string
+		timestamps bool
+		expected   string
+	}{
+		{name: "text", format: "text", expected: "Hello there.\n  \"Hello\"          -0.0100\n"},
+		{name: "text with timestamps", format: "text", timestamps: true, expected: "[00:01.230 - 01:04.500] Hello there.\n  \"Hello\"          -0.0100\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			tr := newTranscriber(&out, tt.format, tt.timestamps, false)
+			for _, data := range transcriptionEvents {
+				tr.Handle(context.Background(), mustParse(t, data))
+			}
+			if out.String() != tt.expected {
+				t.Errorf("Expected output %q, got %q", tt.expected, out.String())
+			}
+		})
+	}
+}
+
+func TestTranscriberJSON(t *testing.T) {
+	var out bytes.Buffer
+	tr := newTranscriber(&out, "json", false, false)
+	for _, data := range transcriptionEvents {
+		tr.Handle(context.Background(), mustParse(t, data))
+	}
+
+	var seg segment
+	if err := json.Unmarshal(out.Bytes(), &seg); err != nil {
+		t.Fatalf("Expected a JSON line, got %q: %v", out.String(), err)
+	}
+	if seg.ItemID != "item_1" || seg.Transcript != "Hello there." {
+		t.Errorf("Expected item_1 with transcript, got %+v", seg)
+	}
+	if seg.StartMs == nil || *seg.StartMs != 1230 || seg.EndMs == nil || *seg.EndMs != 64500 {
+		t.Errorf("Expected timestamps 1230-64500, got %v-%v", seg.StartMs, seg.EndMs)
+	}
+	if len(seg.Logprobs) != 1 || seg.Logprobs[0].Token != "Hello" {
+		t.Errorf("Expected one logprob for Hello, got %v", seg.Logprobs)
+	}
+}
+
+func TestTranscriberWait(t *testing.T) {
+	tr := newTranscriber(&bytes.Buffer{}, "text", false, false)
+	for _, data := range transcriptionEvents[:3] {
+		tr.Handle(context.Background(), mustParse(t, data))
+	}
+
+	// The committed segment is still being transcribed
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := tr.Wait(ctx, 10*time.Millisecond); err != context.DeadlineExceeded {
+		t.Errorf("Expected to wait for the pending segment, got %v", err)
+	}
+
+	tr.Handle(context.Background(), mustParse(t, transcriptionEvents[3]))
+	if err := tr.Wait(context.Background(), 10*time.Millisecond); err != nil {
+		t.Errorf("Expected the transcription to complete, got %v", err)
+	}
+
+	empty := newTranscriber(&bytes.Buffer{}, "text", false, false)
+	if err := empty.Wait(context.Background(), 10*time.Millisecond); err == nil || !strings.Contains(err.Error(), "no speech") {
+		t.Errorf("Expected a no speech error, got %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+// Command realtime-sidecar serves the Realtime gRPC service defined in
+// realtimepb/realtime.proto, opening an OpenAI realtime session for each stream.
+//
+// Usage:
+//
+//	OPENAI_API_KEY=... realtime-sidecar -addr :50051 -model gpt-realtime
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net"
+	"os"
+	"os/signal"
+
+	"google.golang.org/grpc"
+
+	realtimegrpc "github.com/Mliviu79/openai-realtime-go/contrib/grpc"
+	"github.com/Mliviu79/openai-realtime-go/contrib/grpc/realtimepb"
+	"github.com/Mliviu79/openai-realtime-go/openaiClient"
+	"github.com/Mliviu79/openai-realtime-go/session"
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+func main() {
+	addr := flag.String("addr", ":50051", "address to listen on")
+	model := flag.String("model", string(session.GPTRealtime), "realtime model to connect to")
+	flag.Parse()
+
+	if err := run(*addr, session.Model(*model)); err != nil {
+		fmt.Fprintf(os.Stderr, "realtime-sidecar: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run serves until interrupted
+func run(addr string, model session.Model) error {
+	apiKey := os.Getenv("OPENAI_API_KEY")
+	if apiKey == "" {
+		return fmt.Errorf("OPENAI_API_KEY environment variable is required")
+	}
+
+	lis, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	client := openaiClient.NewClient(apiKey)
+	srv := realtimegrpc.NewServer(func(ctx context.Context) (*ws.Conn, error) {
+		return client.Connect(ctx, openaiClient.WithModel(model))
+	})
+	grpcServer := grpc.NewServer()
+	realtimepb.RegisterRealtimeServer(grpcServer, srv)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		// Sessions last as long as their callers keep the stream open, so waiting for
+		// them to finish could block forever
+		grpcServer.Stop()
+	}()
+
+	fmt.Fprintf(os.Stderr, "listening on %s\n", lis.Addr())
+	return grpcServer.Serve(lis)
+}
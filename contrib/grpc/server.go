@@ -0,0 +1,279 @@
+// Package realtimegrpc exposes realtime sessions as a bidirectional gRPC stream, so
+// services written in other languages can drive them through a thin Go sidecar that
+// holds the API key. The protocol is defined in realtimepb/realtime.proto.
+//
+// Example:
+//
+//	client := openaiClient.NewClient(os.Getenv("OPENAI_API_KEY"))
+//	srv := realtimegrpc.NewServer(func(ctx context.Context) (*ws.Conn, error) {
+//		return client.Connect(ctx, openaiClient.WithModel(session.GPTRealtime))
+//	})
+//	grpcServer := grpc.NewServer()
+//	realtimepb.RegisterRealtimeServer(grpcServer, srv)
+//	grpcServer.Serve(lis)
+package realtimegrpc
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative realtimepb/realtime.proto
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/Mliviu79/openai-realtime-go/contrib/grpc/realtimepb"
+	"github.com/Mliviu79/openai-realtime-go/logger"
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+	"github.com/Mliviu79/openai-realtime-go/messages/types"
+	"github.com/Mliviu79/openai-realtime-go/messaging"
+	"github.com/Mliviu79/openai-realtime-go/session"
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+// ServerOption configures a Server
+type ServerOption func(*Server)
+
+// WithDefaultSession sets the configuration sent as a session.update when a caller starts
+// a session without one of its own
+func WithDefaultSession(config session.SessionRequest) ServerOption {
+	return func(s *Server) {
+		s.config = config
+	}
+}
+
+// WithServerLogger sets the logger used by the server and every session's client
+func WithServerLogger(logger logger.Logger) ServerOption {
+	return func(s *Server) {
+		s.logger = logger
+	}
+}
+
+// Server implements realtimepb.RealtimeServer by opening a realtime connection for each
+// Session stream and relaying events in both directions
+type Server struct {
+	realtimepb.UnimplementedRealtimeServer
+
+	dial   messaging.DialFunc
+	config session.SessionRequest
+	logger logger.Logger
+}
+
+// NewServer creates a Server that opens connections with dial
+func NewServer(dial messaging.DialFunc, opts ...ServerOption) *Server {
+	s := &Server{dial: dial}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Session implements realtimepb.RealtimeServer. It waits for the Start event, connects,
+// then relays client events to the realtime connection and server events to the stream
+// until either side closes.
+func (s *Server) Session(stream grpc.BidiStreamingServer[realtimepb.ClientEvent, realtimepb.ServerEvent]) error {
+	ctx, cancel := context.WithCancel(stream.Context())
+	defer cancel()
+
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	start := first.GetStart()
+	if start == nil {
+		return status.Error(codes.FailedPrecondition, "the first event must be start")
+	}
+	config := s.config
+	if len(start.GetSession()) > 0 {
+		config = session.SessionRequest{}
+		if err := json.Unmarshal(start.GetSession(), &config); err != nil {
+			return status.Errorf(codes.InvalidArgument, "invalid session: %v", err)
+		}
+	}
+
+	conn, err := s.dial(ctx)
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "failed to connect: %v", err)
+	}
+	client := messaging.NewClient(conn)
+	defer client.Close()
+	if s.logger != nil {
+		client.SetLogger(s.logger)
+	}
+
+	if !config.IsEmpty() {
+		if err := client.SendSessionUpdate(ctx, config); err != nil {
+			return status.Errorf(codes.InvalidArgument, "failed to configure session: %v", err)
+		}
+	}
+
+	relayErr := make(chan error, 1)
+	go func() {
+		relayErr <- s.relayServerEvents(ctx, conn, stream)
+	}()
+	recvErr := make(chan error, 1)
+	go func() {
+		recvErr <- s.relayClientEvents(ctx, client, stream)
+	}()
+
+	select {
+	case err := <-relayErr:
+		return err
+	case err := <-recvErr:
+		if err != nil {
+			return err
+		}
+	}
+
+	// The caller has finished sending; keep relaying until it hangs up
+	select {
+	case err := <-relayErr:
+		return err
+	case <-ctx.Done():
+		return nil
+	}
+}
+
+// relayClientEvents receives events from the stream and sends them to the realtime
+// connection. It returns nil when the caller closes its side of the stream.
+func (s *Server) relayClientEvents(ctx context.Context, client *messaging.Client, stream grpc.BidiStreamingServer[realtimepb.ClientEvent, realtimepb.ServerEvent]) error {
+	for {
+		event, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := s.send(ctx, client, event); err != nil {
+			return err
+		}
+	}
+}
+
+// send forwards a client event to the realtime connection
+func (s *Server) send(ctx context.Context, client *messaging.Client, event *realtimepb.ClientEvent) error {
+	var err error
+	switch e := event.GetEvent().(type) {
+	case *realtimepb.ClientEvent_Start:
+		return status.Error(codes.FailedPrecondition, "the session has already started")
+	case *realtimepb.ClientEvent_Text:
+		err = client.SendText(ctx, e.Text)
+	case *realtimepb.ClientEvent_Audio:
+		err = client.SendAudioBufferAppend(ctx, base64.StdEncoding.EncodeToString(e.Audio))
+	case *realtimepb.ClientEvent_CommitAudio:
+		err = client.SendAudioBufferCommit(ctx, e.CommitAudio.GetPreviousItemId())
+	case *realtimepb.ClientEvent_CreateResponse:
+		config := &types.ResponseConfig{}
+		if data := e.CreateResponse.GetConfig(); len(data) > 0 {
+			if err := json.Unmarshal(data, config); err != nil {
+				return status.Errorf(codes.InvalidArgument, "invalid response config: %v", err)
+			}
+		}
+		err = client.SendResponseCreate(ctx, config)
+	case *realtimepb.ClientEvent_CancelResponse:
+		err = client.SendResponseCancel(ctx, e.CancelResponse.GetResponseId())
+	case *realtimepb.ClientEvent_Raw:
+		if !json.Valid(e.Raw) {
+			return status.Error(codes.InvalidArgument, "raw event is not valid JSON")
+		}
+		err = client.SendRaw(ctx, e.Raw)
+	default:
+		return status.Error(codes.InvalidArgument, "empty client event")
+	}
+
+	if err != nil {
+		if ctx.Err() != nil {
+			return status.FromContextError(ctx.Err()).Err()
+		}
+		return status.Errorf(codes.Unavailable, "failed to send event: %v", err)
+	}
+	return nil
+}
+
+// relayServerEvents reads events from the realtime connection and sends them to the stream
+func (s *Server) relayServerEvents(ctx context.Context, conn *ws.Conn, stream grpc.BidiStreamingServer[realtimepb.ClientEvent, realtimepb.ServerEvent]) error {
+	for {
+		messageType, data, err := conn.ReadRaw(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return status.Errorf(codes.Unavailable, "connection closed: %v", err)
+		}
+		if messageType != ws.MessageText {
+			continue
+		}
+
+		event, err := serverEvent(data)
+		if err != nil {
+			if s.logger != nil {
+				s.logger.Warnf("Dropping malformed server event: %v", err)
+			}
+			continue
+		}
+		if err := stream.Send(event); err != nil {
+			return err
+		}
+	}
+}
+
+// serverEvent converts a raw server event, decoding the payload of deltas and errors.
+// Events the typed messages do not know are relayed with only their type and raw JSON.
+func serverEvent(data []byte) (*realtimepb.ServerEvent, error) {
+	var base struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &base); err != nil {
+		return nil, err
+	}
+	event := &realtimepb.ServerEvent{Type: base.Type, Raw: data}
+
+	msg, err := incoming.UnmarshalRcvdMsg(data)
+	if err != nil {
+		return event, nil
+	}
+
+	switch m := msg.(type) {
+	case *incoming.ResponseOutputTextDeltaMessage:
+		event.Payload = deltaPayload(&realtimepb.Delta{Kind: string(messaging.DeltaTypeText), ResponseId: m.ResponseID, ItemId: m.ItemID, Text: m.Delta})
+	case *incoming.ResponseOutputAudioTranscriptDeltaMessage:
+		event.Payload = deltaPayload(&realtimepb.Delta{Kind: string(messaging.DeltaTypeTranscript), ResponseId: m.ResponseID, ItemId: m.ItemID, Text: m.Delta})
+	case *incoming.ResponseFunctionCallArgumentsDeltaMessage:
+		event.Payload = deltaPayload(&realtimepb.Delta{Kind: string(messaging.DeltaTypeFunctionArguments), ResponseId: m.ResponseID, ItemId: m.ItemID, CallId: m.CallID, Text: m.Delta})
+	case *incoming.ResponseOutputAudioDeltaMessage:
+		audio, err := m.DecodeAudio()
+		if err != nil {
+			return nil, fmt.Errorf("invalid audio delta: %w", err)
+		}
+		if m.Audio != nil {
+			// A pooled buffer is reused once the handlers return
+			audio = bytes.Clone(audio)
+		}
+		event.Payload = deltaPayload(&realtimepb.Delta{Kind: string(messaging.DeltaTypeAudio), ResponseId: m.ResponseID, ItemId: m.ItemID, Audio: audio})
+	case *incoming.ErrorMessage:
+		details := m.AsAPIError().Response.Error
+		e := &realtimepb.Error{
+			Type:    string(details.Type),
+			Code:    string(details.Code),
+			Message: details.Message,
+			EventId: details.EventID,
+		}
+		if details.Param != nil {
+			e.Param = *details.Param
+		}
+		event.Payload = &realtimepb.ServerEvent_Error{Error: e}
+	}
+	return event, nil
+}
+
+// deltaPayload wraps a delta as a ServerEvent payload
+func deltaPayload(delta *realtimepb.Delta) *realtimepb.ServerEvent_Delta {
+	return &realtimepb.ServerEvent_Delta{Delta: delta}
+}
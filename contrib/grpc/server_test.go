@@ -0,0 +1,235 @@
+package realtimegrpc
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/Mliviu79/openai-realtime-go/contrib/grpc/realtimepb"
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+// fakeConn is a WebSocketConn whose server frames are pushed by the test and whose
+// written frames are collected on a channel
+type fakeConn struct {
+	frames  chan []byte
+	written chan string
+}
+
+func newFakeConn() *fakeConn {
+	return &fakeConn{frames: make(chan []byte, 16), written: make(chan string, 16)}
+}
+
+func (c *fakeConn) WriteMessage(ctx context.Context, messageType ws.MessageType, data []byte) error {
+	c.written <- string(data)
+	return nil
+}
+
+func (c *fakeConn) ReadMessage(ctx context.Context) (ws.MessageType, []byte, error) {
+	select {
+	case data := <-c.frames:
+		return ws.MessageText, data, nil
+	case <-ctx.Done():
+		return ws.MessageText, nil, ctx.Err()
+	}
+}
+
+func (c *fakeConn) Close() error { return nil }
+
+func (c *fakeConn) Ping(ctx context.Context) error { return nil }
+
+// next returns the next frame written to the connection
+func (c *fakeConn) next(t *testing.T) string {
+	t.Helper()
+	select {
+	case data := <-c.written:
+		return data
+	case <-time.After(time.Second):
+		t.Fatal("Expected a frame to be written")
+		return ""
+	}
+}
+
+// startServer serves srv over an in-memory listener and returns a client for it
+func startServer(t *testing.T, srv *Server) realtimepb.RealtimeClient {
+	t.Helper()
+	lis := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer()
+	realtimepb.RegisterRealtimeServer(grpcServer, srv)
+	go grpcServer.Serve(lis)
+	t.Cleanup(grpcServer.Stop)
+
+	cc, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	t.Cleanup(func() { cc.Close() })
+	return realtimepb.NewRealtimeClient(cc)
+}
+
+func TestServerSession(t *testing.T) {
+	conn := newFakeConn()
+	client := startServer(t, NewServer(func(ctx context.Context) (*ws.Conn, error) {
+		return ws.NewConn(conn), nil
+	}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream, err := client.Session(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	start := &realtimepb.Start{Session: []byte(`{"instructions":"Be brief"}`)}
+	if err := stream.Send(&realtimepb.ClientEvent{Event: &realtimepb.ClientEvent_Start{Start: start}}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if frame := conn.next(t); !strings.Contains(frame, `"type":"session.update"`) || !strings.Contains(frame, "Be brief") {
+		t.Errorf("Expected a session.update with the instructions, got %s", frame)
+	}
+
+	clientEvents := []struct {
+		name     string
+		event    *realtimepb.ClientEvent
+		expected string
+	}{
+		{name: "text", event: &realtimepb.ClientEvent{Event: &realtimepb.ClientEvent_Text{Text: "Hello"}}, expected: `"type":"conversation.item.create"`},
+		{name: "audio", event: &realtimepb.ClientEvent{Event: &realtimepb.ClientEvent_Audio{Audio: []byte{1, 2}}}, expected: `"audio":"AQI="`},
+		{name: "commit", event: &realtimepb.ClientEvent{Event: &realtimepb.ClientEvent_CommitAudio{CommitAudio: &realtimepb.CommitAudio{}}}, expected: `"type":"input_audio_buffer.commit"`},
+		{name: "response", event: &realtimepb.ClientEvent{Event: &realtimepb.ClientEvent_CreateResponse{CreateResponse: &realtimepb.CreateResponse{Config: []byte(`{"instructions":"Now"}`)}}}, expected: `"instructions":"Now"`},
+		{name: "cancel", event: &realtimepb.ClientEvent{Event: &realtimepb.ClientEvent_CancelResponse{CancelResponse: &realtimepb.CancelResponse{ResponseId: "resp_1"}}}, expected: `"response_id":"resp_1"`},
+		{name: "raw", event: &realtimepb.ClientEvent{Event: &realtimepb.ClientEvent_Raw{Raw: []byte(`{"type":"input_audio_buffer.clear"}`)}}, expected: `{"type":"input_audio_buffer.clear"}`},
+	}
+	for _, tt := range clientEvents {
+		if err := stream.Send(tt.event); err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.name, err)
+		}
+		if frame := conn.next(t); !strings.Contains(frame, tt.expected) {
+			t.Errorf("%s: expected frame containing %s, got %s", tt.name, tt.expected, frame)
+		}
+	}
+
+	conn.frames <- []byte(`{"type":"response.output_text.delta","response_id":"resp_1","item_id":"item_1","delta":"Hi"}`)
+	conn.frames <- []byte(`{"type":"response.output_audio.delta","response_id":"resp_1","item_id":"item_1","delta":"AQI="}`)
+	conn.frames <- []byte(`{"type":"error","error":{"type":"invalid_request_error","code":"bad","message":"Oops"}}`)
+	conn.frames <- []byte(`{"type":"some.future.event","value":1}`)
+
+	event, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if delta := event.GetDelta(); delta == nil || delta.GetKind() != "text" || delta.GetText() != "Hi" || delta.GetResponseId() != "resp_1" {
+		t.Errorf("Expected a text delta, got %v", event)
+	}
+	if !strings.Contains(string(event.GetRaw()), `"delta":"Hi"`) {
+		t.Errorf("Expected the raw event, got %s", event.GetRaw())
+	}
+
+	event, err = stream.Recv()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if delta := event.GetDelta(); delta == nil || delta.GetKind() != "audio" || string(delta.GetAudio()) != "\x01\x02" {
+		t.Errorf("Expected a decoded audio delta, got %v", event)
+	}
+
+	event, err = stream.Recv()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if e := event.GetError(); e == nil || e.GetCode() != "bad" || e.GetMessage() != "Oops" {
+		t.Errorf("Expected an error payload, got %v", event)
+	}
+
+	event, err = stream.Recv()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if event.GetType() != "some.future.event" || event.GetPayload() != nil {
+		t.Errorf("Expected an unknown event relayed without payload, got %v", event)
+	}
+}
+
+func TestServerSessionErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		events []*realtimepb.ClientEvent
+		dial   error
+		code   codes.Code
+	}{
+		{
+			name:   "missing start",
+			events: []*realtimepb.ClientEvent{{Event: &realtimepb.ClientEvent_Text{Text: "Hello"}}},
+			code:   codes.FailedPrecondition,
+		},
+		{
+			name:   "invalid session",
+			events: []*realtimepb.ClientEvent{{Event: &realtimepb.ClientEvent_Start{Start: &realtimepb.Start{Session: []byte("{")}}}},
+			code:   codes.InvalidArgument,
+		},
+		{
+			name:   "dial failure",
+			events: []*realtimepb.ClientEvent{{Event: &realtimepb.ClientEvent_Start{Start: &realtimepb.Start{}}}},
+			dial:   errors.New("refused"),
+			code:   codes.Unavailable,
+		},
+		{
+			name: "second start",
+			events: []*realtimepb.ClientEvent{
+				{Event: &realtimepb.ClientEvent_Start{Start: &realtimepb.Start{}}},
+				{Event: &realtimepb.ClientEvent_Start{Start: &realtimepb.Start{}}},
+			},
+			code: codes.FailedPrecondition,
+		},
+		{
+			name: "invalid raw",
+			events: []*realtimepb.ClientEvent{
+				{Event: &realtimepb.ClientEvent_Start{Start: &realtimepb.Start{}}},
+				{Event: &realtimepb.ClientEvent_Raw{Raw: []byte("not json")}},
+			},
+			code: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := startServer(t, NewServer(func(ctx context.Context) (*ws.Conn, error) {
+				if tt.dial != nil {
+					return nil, tt.dial
+				}
+				return ws.NewConn(newFakeConn()), nil
+			}))
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			stream, err := client.Session(ctx)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			for _, event := range tt.events {
+				if err := stream.Send(event); err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+			}
+
+			_, err = stream.Recv()
+			if status.Code(err) != tt.code {
+				t.Errorf("Expected code %s, got %v", tt.code, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+// Package realtimenats publishes realtime events to NATS. Publisher implements
+// messaging.Publisher with core NATS, which is fire-and-forget, or with JetStream, which
+// waits for the stream to store each event, for consumers such as compliance archives
+// that must not miss one.
+//
+// Example:
+//
+//	nc, err := nats.Connect(nats.DefaultURL)
+//	if err != nil {
+//		return err
+//	}
+//	defer nc.Drain()
+//	publisher := messaging.NewEventPublisher(realtimenats.NewPublisher(nc))
+//	handler := messaging.NewHandler(ctx, msgClient, publisher.Handle)
+package realtimenats
+
+import (
+	"context"
+
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+
+	"github.com/Mliviu79/openai-realtime-go/messaging"
+)
+
+// Publisher is a messaging.Publisher that publishes to NATS
+type Publisher struct {
+	publish func(ctx context.Context, subject string, data []byte) error
+}
+
+var _ messaging.Publisher = (*Publisher)(nil)
+
+// NewPublisher creates a Publisher that publishes on a core NATS connection. Publish
+// returns once the event is buffered by the connection; it is not acknowledged.
+func NewPublisher(nc *nats.Conn) *Publisher {
+	return &Publisher{
+		publish: func(ctx context.Context, subject string, data []byte) error {
+			return nc.Publish(subject, data)
+		},
+	}
+}
+
+// NewJetStreamPublisher creates a Publisher that publishes to JetStream. Publish waits
+// until a stream has stored the event, so a stream must capture the subjects.
+func NewJetStreamPublisher(js jetstream.JetStream) *Publisher {
+	return &Publisher{
+		publish: func(ctx context.Context, subject string, data []byte) error {
+			_, err := js.Publish(ctx, subject, data)
+			return err
+		},
+	}
+}
+
+// Publish implements messaging.Publisher
+func (p *Publisher) Publish(ctx context.Context, subject string, data []byte) error {
+	return p.publish(ctx, subject, data)
+}
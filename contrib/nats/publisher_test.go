@@ -0,0 +1,102 @@
+package realtimenats
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nats-io/nats-server/v2/server"
+	natstest "github.com/nats-io/nats-server/v2/test"
+	"github.com/nats-io/nats.go"
+	"github.com/nats-io/nats.go/jetstream"
+
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+	"github.com/Mliviu79/openai-realtime-go/messaging"
+)
+
+// runServer starts an embedded NATS server with JetStream and connects to it
+func runServer(t *testing.T) *nats.Conn {
+	opts := natstest.DefaultTestOptions
+	opts.Port = server.RANDOM_PORT
+	opts.JetStream = true
+	opts.StoreDir = t.TempDir()
+	s := natstest.RunServer(&opts)
+	t.Cleanup(s.Shutdown)
+
+	nc, err := nats.Connect(s.ClientURL())
+	if err != nil {
+		t.Fatalf("Failed to connect: %v", err)
+	}
+	t.Cleanup(nc.Close)
+	return nc
+}
+
+// handle passes a server event to the publisher
+func handle(t *testing.T, publisher *messaging.EventPublisher, data string) {
+	msg, err := incoming.UnmarshalRcvdMsg([]byte(data))
+	if err != nil {
+		t.Fatalf("Failed to parse %s: %v", data, err)
+	}
+	publisher.Handle(context.Background(), msg)
+}
+
+func TestPublisher(t *testing.T) {
+	nc := runServer(t)
+	sub, err := nc.SubscribeSync("realtime.*.response.done")
+	if err != nil {
+		t.Fatalf("Failed to subscribe: %v", err)
+	}
+
+	publisher := messaging.NewEventPublisher(NewPublisher(nc))
+	handle(t, publisher, `{"type":"session.created","session":{"id":"sess_1"}}`)
+	handle(t, publisher, `{"type":"response.done","response":{"id":"resp_1","status":"completed"}}`)
+
+	msg, err := sub.NextMsg(time.Second)
+	if err != nil {
+		t.Fatalf("Expected a message, got %v", err)
+	}
+	if msg.Subject != "realtime.sess_1.response.done" {
+		t.Errorf("Expected subject realtime.sess_1.response.done, got %s", msg.Subject)
+	}
+}
+
+func TestJetStreamPublisher(t *testing.T) {
+	nc := runServer(t)
+	js, err := jetstream.New(nc)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	ctx := context.Background()
+	stream, err := js.CreateStream(ctx, jetstream.StreamConfig{Name: "CALLS", Subjects: []string{"realtime.>"}})
+	if err != nil {
+		t.Fatalf("Failed to create stream: %v", err)
+	}
+
+	var errs []error
+	publisher := messaging.NewEventPublisher(NewJetStreamPublisher(js),
+		messaging.WithOnPublishError(func(msgType incoming.RcvdMsgType, err error) { errs = append(errs, err) }),
+	)
+	handle(t, publisher, `{"type":"session.created","session":{"id":"sess_1"}}`)
+	handle(t, publisher, `{"type":"response.done","response":{"id":"resp_1","status":"completed"}}`)
+
+	if len(errs) != 0 {
+		t.Fatalf("Unexpected errors: %v", errs)
+	}
+	info, err := stream.Info(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if info.State.Msgs != 2 {
+		t.Errorf("Expected 2 stored events, got %d", info.State.Msgs)
+	}
+
+	// Without a stream for the subject the publish is not acknowledged
+	unstored := messaging.NewEventPublisher(NewJetStreamPublisher(js),
+		messaging.WithSubjectPrefix("other"),
+		messaging.WithOnPublishError(func(msgType incoming.RcvdMsgType, err error) { errs = append(errs, err) }),
+	)
+	handle(t, unstored, `{"type":"response.done","response":{"id":"resp_2"}}`)
+	if len(errs) != 1 {
+		t.Errorf("Expected a publish error, got %v", errs)
+	}
+}
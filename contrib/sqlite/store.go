@@ -0,0 +1,226 @@
+// Package realtimesqlite is a store.Store that keeps conversations in SQLite, so
+// transcripts survive process restarts. It uses the pure-Go modernc.org/sqlite driver,
+// so no cgo toolchain is needed.
+//
+// Example:
+//
+//	s, err := realtimesqlite.Open("conversations.db")
+//	if err != nil {
+//		return err
+//	}
+//	defer s.Close()
+//	tracker := messaging.NewConversationTracker(messaging.WithConversationStore(s, callID))
+package realtimesqlite
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/Mliviu79/openai-realtime-go/messages/types"
+	"github.com/Mliviu79/openai-realtime-go/store"
+
+	_ "modernc.org/sqlite" // registers the "sqlite" driver
+)
+
+// schema creates the tables on first use. Items and responses keep the order in which
+// they were first saved (seq) so the conversation order can be rebuilt on load.
+const schema = `
+CREATE TABLE IF NOT EXISTS realtime_items (
+	conversation_id  TEXT NOT NULL,
+	item_id          TEXT NOT NULL,
+	previous_item_id TEXT NOT NULL,
+	seq              INTEGER NOT NULL,
+	data             TEXT NOT NULL,
+	PRIMARY KEY (conversation_id, item_id)
+);
+CREATE TABLE IF NOT EXISTS realtime_responses (
+	conversation_id TEXT NOT NULL,
+	response_id     TEXT NOT NULL,
+	seq             INTEGER NOT NULL,
+	data            TEXT NOT NULL,
+	PRIMARY KEY (conversation_id, response_id)
+);
+CREATE TABLE IF NOT EXISTS realtime_usage (
+	conversation_id TEXT NOT NULL,
+	response_id     TEXT NOT NULL,
+	data            TEXT NOT NULL,
+	PRIMARY KEY (conversation_id, response_id)
+);`
+
+// Store is a store.Store backed by a SQLite database
+type Store struct {
+	db *sql.DB
+}
+
+// Open opens or creates the SQLite database at path and its tables.
+// The path ":memory:" creates a database that lives as long as the Store.
+func Open(path string) (*Store, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	// SQLite allows a single writer, and every connection to ":memory:" is a separate database
+	db.SetMaxOpenConns(1)
+
+	s, err := New(db)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// New creates a Store on an open SQLite database, creating its tables if needed.
+// Closing the Store closes db.
+func New(db *sql.DB) (*Store, error) {
+	if _, err := db.Exec(schema); err != nil {
+		return nil, fmt.Errorf("failed to create tables: %w", err)
+	}
+	return &Store{db: db}, nil
+}
+
+// Close closes the database
+func (s *Store) Close() error {
+	return s.db.Close()
+}
+
+// SaveItem implements store.Store. Items must have an ID.
+func (s *Store) SaveItem(ctx context.Context, conversationID, previousItemID string, item types.MessageItem) error {
+	if item.ID == "" {
+		return errors.New("cannot store an item without an ID")
+	}
+	data, err := json.Marshal(item)
+	if err != nil {
+		return fmt.Errorf("failed to encode item %s: %w", item.ID, err)
+	}
+
+	// A saved item keeps its position; only its data is replaced
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO realtime_items (conversation_id, item_id, previous_item_id, seq, data)
+		VALUES (?, ?, ?, (SELECT COALESCE(MAX(seq), 0) + 1 FROM realtime_items WHERE conversation_id = ?), ?)
+		ON CONFLICT (conversation_id, item_id) DO UPDATE SET data = excluded.data`,
+		conversationID, item.ID, previousItemID, conversationID, string(data))
+	if err != nil {
+		return fmt.Errorf("failed to save item %s: %w", item.ID, err)
+	}
+	return nil
+}
+
+// SaveResponse implements store.Store
+func (s *Store) SaveResponse(ctx context.Context, conversationID string, response types.Response) error {
+	data, err := json.Marshal(response)
+	if err != nil {
+		return fmt.Errorf("failed to encode response %s: %w", response.ID, err)
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO realtime_responses (conversation_id, response_id, seq, data)
+		VALUES (?, ?, (SELECT COALESCE(MAX(seq), 0) + 1 FROM realtime_responses WHERE conversation_id = ?), ?)
+		ON CONFLICT (conversation_id, response_id) DO UPDATE SET data = excluded.data`,
+		conversationID, response.ID, conversationID, string(data))
+	if err != nil {
+		return fmt.Errorf("failed to save response %s: %w", response.ID, err)
+	}
+	return nil
+}
+
+// SaveUsage implements store.Store
+func (s *Store) SaveUsage(ctx context.Context, conversationID, responseID string, usage types.Usage) error {
+	data, err := json.Marshal(usage)
+	if err != nil {
+		return fmt.Errorf("failed to encode usage of %s: %w", responseID, err)
+	}
+
+	_, err = s.db.ExecContext(ctx, `
+		INSERT INTO realtime_usage (conversation_id, response_id, data) VALUES (?, ?, ?)
+		ON CONFLICT (conversation_id, response_id) DO UPDATE SET data = excluded.data`,
+		conversationID, responseID, string(data))
+	if err != nil {
+		return fmt.Errorf("failed to save usage of %s: %w", responseID, err)
+	}
+	return nil
+}
+
+// LoadConversation implements store.Store
+func (s *Store) LoadConversation(ctx context.Context, conversationID string) (*store.Conversation, error) {
+	c := &store.Conversation{ID: conversationID, Usage: make(map[string]types.Usage)}
+	found := false
+
+	err := s.query(ctx, `SELECT previous_item_id, data FROM realtime_items WHERE conversation_id = ? ORDER BY seq`,
+		conversationID, func(rows *sql.Rows) error {
+			var previousItemID, data string
+			var item types.MessageItem
+			if err := rows.Scan(&previousItemID, &data); err != nil {
+				return err
+			}
+			if err := json.Unmarshal([]byte(data), &item); err != nil {
+				return err
+			}
+			c.Items = store.InsertItem(c.Items, previousItemID, item)
+			found = true
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load items: %w", err)
+	}
+
+	err = s.query(ctx, `SELECT data FROM realtime_responses WHERE conversation_id = ? ORDER BY seq`,
+		conversationID, func(rows *sql.Rows) error {
+			var data string
+			var response types.Response
+			if err := rows.Scan(&data); err != nil {
+				return err
+			}
+			if err := json.Unmarshal([]byte(data), &response); err != nil {
+				return err
+			}
+			c.Responses = append(c.Responses, response)
+			found = true
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load responses: %w", err)
+	}
+
+	err = s.query(ctx, `SELECT response_id, data FROM realtime_usage WHERE conversation_id = ?`,
+		conversationID, func(rows *sql.Rows) error {
+			var responseID, data string
+			var usage types.Usage
+			if err := rows.Scan(&responseID, &data); err != nil {
+				return err
+			}
+			if err := json.Unmarshal([]byte(data), &usage); err != nil {
+				return err
+			}
+			c.Usage[responseID] = usage
+			found = true
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load usage: %w", err)
+	}
+
+	if !found {
+		return nil, store.ErrNotFound
+	}
+	return c, nil
+}
+
+// query runs a query for a conversation and calls scan for each row
+func (s *Store) query(ctx context.Context, query, conversationID string, scan func(*sql.Rows) error) error {
+	rows, err := s.db.QueryContext(ctx, query, conversationID)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		if err := scan(rows); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
@@ -0,0 +1,62 @@
+package realtimesqlite
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/Mliviu79/openai-realtime-go/messages/types"
+	"github.com/Mliviu79/openai-realtime-go/store"
+	"github.com/Mliviu79/openai-realtime-go/store/storetest"
+)
+
+func TestStore(t *testing.T) {
+	storetest.Run(t, func(t *testing.T) store.Store {
+		s, err := Open(":memory:")
+		if err != nil {
+			t.Fatalf("Failed to open store: %v", err)
+		}
+		t.Cleanup(func() { s.Close() })
+		return s
+	})
+}
+
+func TestStoreSurvivesReopen(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "conversations.db")
+
+	s, err := Open(path)
+	if err != nil {
+		t.Fatalf("Failed to open store: %v", err)
+	}
+	item := types.MessageItem{
+		ID:      "item_1",
+		Type:    types.MessageItemTypeMessage,
+		Role:    types.MessageRoleUser,
+		Content: []types.MessageContentPart{{Type: types.MessageContentTypeInputAudio, Transcript: "Hello there"}},
+	}
+	if err := s.SaveItem(ctx, "call-1", "", item); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	s, err = Open(path)
+	if err != nil {
+		t.Fatalf("Failed to reopen store: %v", err)
+	}
+	defer s.Close()
+	conv, err := s.LoadConversation(ctx, "call-1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	items := conv.ImportableItems()
+	if len(items) != 1 || items[0].Content[0].Text != "Hello there" {
+		t.Errorf("Expected the transcript to survive the restart, got %+v", items)
+	}
+
+	if err := s.SaveItem(ctx, "call-1", "", types.MessageItem{Type: types.MessageItemTypeMessage}); err == nil {
+		t.Error("Expected an error for an item without an ID")
+	}
+}
@@ -0,0 +1,166 @@
+package realtimewebrtc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/pion/webrtc/v4/pkg/media"
+
+	"github.com/Mliviu79/openai-realtime-go/audio"
+)
+
+const (
+	// opusSampleRate is the RTP clock rate of Opus
+	opusSampleRate = 48000
+
+	// opusFrameDuration is the amount of audio in each frame sent by AttachSource
+	opusFrameDuration = 20 * time.Millisecond
+
+	// maxOpusFrameSamples is the number of samples in the longest Opus frame (120ms at 48kHz)
+	maxOpusFrameSamples = 5760
+
+	// maxOpusPacketBytes bounds the size of an encoded frame
+	maxOpusPacketBytes = 1500
+)
+
+// OpusEncoder encodes mono 48kHz PCM16 frames. Its method matches the encoder of
+// github.com/hraban/opus, so an encoder created with opus.NewEncoder(48000, 1, ...)
+// can be passed directly.
+type OpusEncoder interface {
+	// Encode encodes one frame of pcm into data and returns the encoded length
+	Encode(pcm []int16, data []byte) (int, error)
+}
+
+// OpusDecoder decodes Opus packets into mono 48kHz PCM16. Its method matches the
+// decoder of github.com/hraban/opus created with opus.NewDecoder(48000, 1).
+type OpusDecoder interface {
+	// Decode decodes one packet into pcm and returns the number of samples written
+	Decode(data []byte, pcm []int16) (int, error)
+}
+
+// AttachSource sends the audio read from src over the peer's audio track, encoded in
+// 20ms frames at real-time pace. It blocks until src returns io.EOF, the context is
+// done, or the peer is closed, so it is usually run in its own goroutine. The final
+// partial frame is padded with silence.
+func (p *Peer) AttachSource(ctx context.Context, src audio.Source, enc OpusEncoder) error {
+	rate := src.SampleRate()
+	frameSamples := audio.SamplesForDuration(rate, opusFrameDuration)
+	opusFrameSamples := audio.SamplesForDuration(opusSampleRate, opusFrameDuration)
+	if frameSamples == 0 {
+		return fmt.Errorf("invalid source sample rate %d", rate)
+	}
+
+	frame := make([]int16, frameSamples)
+	packet := make([]byte, maxOpusPacketBytes)
+	ticker := time.NewTicker(opusFrameDuration)
+	defer ticker.Stop()
+
+	for {
+		n, readErr := readFrame(src, frame)
+		if n > 0 {
+			clear(frame[n:])
+			pcm, err := audio.Resample(frame, rate, opusSampleRate)
+			if err != nil {
+				return err
+			}
+			pcm = fitFrame(pcm, opusFrameSamples)
+
+			size, err := enc.Encode(pcm, packet)
+			if err != nil {
+				return fmt.Errorf("failed to encode audio: %w", err)
+			}
+			sample := media.Sample{Data: append([]byte(nil), packet[:size]...), Duration: opusFrameDuration}
+			if err := p.local.WriteSample(sample); err != nil {
+				return fmt.Errorf("failed to send audio: %w", err)
+			}
+		}
+		if errors.Is(readErr, io.EOF) {
+			return nil
+		}
+		if readErr != nil {
+			return fmt.Errorf("failed to read audio: %w", readErr)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-p.closed:
+			return ErrDataChannelClosed
+		}
+	}
+}
+
+// AttachSink writes the model's audio, received on the peer's remote track, to sink at
+// the sink's sample rate. It waits for the remote track to arrive and blocks until the
+// track ends, the context is done, or the peer is closed, so it is usually run in its
+// own goroutine.
+func (p *Peer) AttachSink(ctx context.Context, sink audio.Sink, dec OpusDecoder) error {
+	select {
+	case <-p.remoteReady:
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-p.closed:
+		return ErrDataChannelClosed
+	}
+	track := p.remote
+
+	// ReadRTP takes no context, so an expired deadline is what unblocks it
+	stop := context.AfterFunc(ctx, func() { track.SetReadDeadline(time.Now()) })
+	defer stop()
+	defer track.SetReadDeadline(time.Time{})
+
+	pcm := make([]int16, maxOpusFrameSamples)
+	for {
+		packet, _, err := track.ReadRTP()
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("failed to read audio: %w", err)
+		}
+		if len(packet.Payload) == 0 {
+			continue
+		}
+
+		n, err := dec.Decode(packet.Payload, pcm)
+		if err != nil {
+			return fmt.Errorf("failed to decode audio: %w", err)
+		}
+		samples, err := audio.Resample(pcm[:n], opusSampleRate, sink.SampleRate())
+		if err != nil {
+			return err
+		}
+		if err := sink.Write(samples); err != nil {
+			return fmt.Errorf("failed to write audio: %w", err)
+		}
+	}
+}
+
+// readFrame fills frame from src, returning early only at the end of the stream or on an error
+func readFrame(src audio.Source, frame []int16) (int, error) {
+	n := 0
+	for n < len(frame) {
+		m, err := src.Read(frame[n:])
+		n += m
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+// fitFrame pads or truncates resampled audio to exactly n samples, since rounding in
+// Resample can leave a frame one sample off
+func fitFrame(pcm []int16, n int) []int16 {
+	if len(pcm) >= n {
+		return pcm[:n]
+	}
+	return append(pcm, make([]int16, n-len(pcm))...)
+}
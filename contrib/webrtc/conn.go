@@ -0,0 +1,135 @@
+package realtimewebrtc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/pion/webrtc/v4"
+
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+// ErrDataChannelClosed is returned by reads and writes after the events data channel closed
+var ErrDataChannelClosed = errors.New("data channel closed")
+
+// dataChannelConn carries realtime events over a data channel. It implements
+// ws.WebSocketConn, so a messaging.Client works the same over WebRTC as over a WebSocket.
+type dataChannelConn struct {
+	dc *webrtc.DataChannel
+
+	open     chan struct{}
+	openOnce sync.Once
+	messages chan dataChannelMessage
+
+	closed    chan struct{}
+	closeOnce sync.Once
+	err       error
+}
+
+// dataChannelMessage is a message received on the data channel
+type dataChannelMessage struct {
+	messageType ws.MessageType
+	data        []byte
+}
+
+// newDataChannelConn wraps dc and registers its event handlers
+func newDataChannelConn(dc *webrtc.DataChannel) *dataChannelConn {
+	c := &dataChannelConn{
+		dc:       dc,
+		open:     make(chan struct{}),
+		messages: make(chan dataChannelMessage, 64),
+		closed:   make(chan struct{}),
+	}
+
+	dc.OnOpen(func() {
+		c.openOnce.Do(func() { close(c.open) })
+	})
+	dc.OnMessage(func(msg webrtc.DataChannelMessage) {
+		messageType := ws.MessageBinary
+		if msg.IsString {
+			messageType = ws.MessageText
+		}
+		// Blocking here applies backpressure to the sender until the reader catches up
+		select {
+		case c.messages <- dataChannelMessage{messageType: messageType, data: msg.Data}:
+		case <-c.closed:
+		}
+	})
+	dc.OnClose(func() {
+		c.shutdown(ErrDataChannelClosed)
+	})
+	dc.OnError(func(err error) {
+		c.shutdown(fmt.Errorf("data channel failed: %w", err))
+	})
+	return c
+}
+
+// shutdown records why the channel stopped and wakes up blocked readers
+func (c *dataChannelConn) shutdown(err error) {
+	c.closeOnce.Do(func() {
+		c.err = err
+		close(c.closed)
+	})
+}
+
+// waitOpen blocks until the data channel is open, closed, or the context is done
+func (c *dataChannelConn) waitOpen(ctx context.Context) error {
+	select {
+	case <-c.open:
+		return nil
+	case <-c.closed:
+		return c.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// WriteMessage implements ws.WebSocketConn
+func (c *dataChannelConn) WriteMessage(ctx context.Context, messageType ws.MessageType, data []byte) error {
+	select {
+	case <-c.closed:
+		return c.err
+	default:
+	}
+
+	if messageType == ws.MessageText {
+		return c.dc.SendText(string(data))
+	}
+	return c.dc.Send(data)
+}
+
+// ReadMessage implements ws.WebSocketConn. Messages that arrived before the channel
+// closed are still returned before the close is reported.
+func (c *dataChannelConn) ReadMessage(ctx context.Context) (ws.MessageType, []byte, error) {
+	select {
+	case msg := <-c.messages:
+		return msg.messageType, msg.data, nil
+	default:
+	}
+
+	select {
+	case msg := <-c.messages:
+		return msg.messageType, msg.data, nil
+	case <-c.closed:
+		return 0, nil, c.err
+	case <-ctx.Done():
+		return 0, nil, ctx.Err()
+	}
+}
+
+// Close implements ws.WebSocketConn
+func (c *dataChannelConn) Close() error {
+	c.shutdown(ErrDataChannelClosed)
+	return c.dc.Close()
+}
+
+// Ping implements ws.WebSocketConn. Data channels have no ping frame; liveness is
+// tracked by ICE, so Ping only reports whether the channel is still open.
+func (c *dataChannelConn) Ping(ctx context.Context) error {
+	if c.dc.ReadyState() != webrtc.DataChannelStateOpen {
+		return ErrDataChannelClosed
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package realtimewebrtc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Mliviu79/openai-realtime-go/messaging"
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+// DialWithFallback tries to connect over WebRTC and, if negotiation fails, connects with
+// fallback instead, typically a WebSocket dial with openaiClient.Client.Connect.
+//
+// The returned connection carries the realtime events either way. The peer is nil when
+// the WebSocket fallback was used; audio must then be sent with input_audio_buffer.append
+// and read from response.output_audio.delta events instead of AttachSource and AttachSink.
+//
+// Example:
+//
+//	conn, peer, err := realtimewebrtc.DialWithFallback(ctx, signal, func(ctx context.Context) (*ws.Conn, error) {
+//		return client.Connect(ctx, openaiClient.WithModel(session.GPTRealtime))
+//	})
+func DialWithFallback(ctx context.Context, signal SignalFunc, fallback messaging.DialFunc, opts ...Option) (*ws.Conn, *Peer, error) {
+	peer, err := Dial(ctx, signal, opts...)
+	if err == nil {
+		return peer.Conn(), peer, nil
+	}
+	if ctx.Err() != nil {
+		return nil, nil, err
+	}
+
+	conn, fallbackErr := fallback(ctx)
+	if fallbackErr != nil {
+		return nil, nil, fmt.Errorf("webrtc failed (%v) and websocket fallback failed: %w", err, fallbackErr)
+	}
+	return conn, nil, nil
+}
@@ -0,0 +1,222 @@
+// Package realtimewebrtc connects to realtime sessions over WebRTC using pion. Events
+// travel over the "oai-events" data channel, wrapped as a ws.Conn so the messaging
+// package works unchanged, and audio travels as Opus over media tracks.
+//
+// Example:
+//
+//	peer, err := realtimewebrtc.Dial(ctx, realtimewebrtc.OpenAISignaler(secret, session.GPTRealtime))
+//	if err != nil {
+//		return err
+//	}
+//	defer peer.Close()
+//
+//	msgClient := messaging.NewClient(peer.Conn())
+//	go peer.AttachSource(ctx, mic, encoder)
+//	go peer.AttachSink(ctx, speaker, decoder)
+package realtimewebrtc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pion/webrtc/v4"
+
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+// EventsChannelLabel is the label of the data channel carrying realtime events
+const EventsChannelLabel = "oai-events"
+
+// DefaultNegotiationTimeout bounds how long Dial waits for the events data channel to open
+const DefaultNegotiationTimeout = 10 * time.Second
+
+// Option configures Dial
+type Option func(*options)
+
+// options holds the configuration for Dial
+type options struct {
+	api        *webrtc.API
+	iceServers []webrtc.ICEServer
+	timeout    time.Duration
+}
+
+// WithICEServers sets the STUN and TURN servers used to gather candidates
+func WithICEServers(servers ...webrtc.ICEServer) Option {
+	return func(o *options) {
+		o.iceServers = servers
+	}
+}
+
+// WithNegotiationTimeout sets how long Dial waits for candidate gathering, signaling
+// and the events data channel to open; the default is DefaultNegotiationTimeout
+func WithNegotiationTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		if timeout > 0 {
+			o.timeout = timeout
+		}
+	}
+}
+
+// WithAPI sets the pion API used to create the peer connection, e.g. to configure
+// its media or setting engine. The API must support the Opus codec.
+func WithAPI(api *webrtc.API) Option {
+	return func(o *options) {
+		o.api = api
+	}
+}
+
+// Peer is a WebRTC connection to a realtime session
+type Peer struct {
+	pc     *webrtc.PeerConnection
+	local  *webrtc.TrackLocalStaticSample
+	events *dataChannelConn
+	conn   *ws.Conn
+
+	// remoteReady is closed once the model's audio track has arrived in remote
+	remote      *webrtc.TrackRemote
+	remoteReady chan struct{}
+	remoteOnce  sync.Once
+
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// Dial negotiates a WebRTC connection using signal to exchange the offer and answer,
+// and returns once the events data channel is open. The peer sends one audio track,
+// fed by AttachSource, and receives the model's audio, read by AttachSink.
+func Dial(ctx context.Context, signal SignalFunc, opts ...Option) (*Peer, error) {
+	o := options{timeout: DefaultNegotiationTimeout}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.api == nil {
+		o.api = webrtc.NewAPI()
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, o.timeout)
+	defer cancel()
+
+	pc, err := o.api.NewPeerConnection(webrtc.Configuration{ICEServers: o.iceServers})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create peer connection: %w", err)
+	}
+	p, err := newPeer(pc)
+	if err != nil {
+		pc.Close()
+		return nil, err
+	}
+
+	if err := p.negotiate(ctx, signal); err != nil {
+		p.Close()
+		return nil, err
+	}
+	return p, nil
+}
+
+// newPeer adds the local audio track and the events data channel to pc
+func newPeer(pc *webrtc.PeerConnection) (*Peer, error) {
+	local, err := webrtc.NewTrackLocalStaticSample(
+		webrtc.RTPCodecCapability{MimeType: webrtc.MimeTypeOpus, ClockRate: opusSampleRate, Channels: 2},
+		"audio", "realtime",
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create audio track: %w", err)
+	}
+	sender, err := pc.AddTrack(local)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add audio track: %w", err)
+	}
+	// RTCP must be read for interceptors such as NACK to work
+	go func() {
+		buf := make([]byte, 1500)
+		for {
+			if _, _, err := sender.Read(buf); err != nil {
+				return
+			}
+		}
+	}()
+
+	dc, err := pc.CreateDataChannel(EventsChannelLabel, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create data channel: %w", err)
+	}
+
+	p := &Peer{
+		pc:          pc,
+		local:       local,
+		events:      newDataChannelConn(dc),
+		remoteReady: make(chan struct{}),
+		closed:      make(chan struct{}),
+	}
+	p.conn = ws.NewConn(p.events)
+
+	pc.OnTrack(func(track *webrtc.TrackRemote, _ *webrtc.RTPReceiver) {
+		if track.Kind() != webrtc.RTPCodecTypeAudio {
+			return
+		}
+		p.remoteOnce.Do(func() {
+			p.remote = track
+			close(p.remoteReady)
+		})
+	})
+	pc.OnConnectionStateChange(func(state webrtc.PeerConnectionState) {
+		if state == webrtc.PeerConnectionStateFailed || state == webrtc.PeerConnectionStateClosed {
+			p.events.shutdown(fmt.Errorf("peer connection %s", state))
+		}
+	})
+	return p, nil
+}
+
+// negotiate exchanges the offer and answer and waits for the events data channel to open
+func (p *Peer) negotiate(ctx context.Context, signal SignalFunc) error {
+	offer, err := p.pc.CreateOffer(nil)
+	if err != nil {
+		return fmt.Errorf("failed to create offer: %w", err)
+	}
+	gathered := webrtc.GatheringCompletePromise(p.pc)
+	if err := p.pc.SetLocalDescription(offer); err != nil {
+		return fmt.Errorf("failed to set local description: %w", err)
+	}
+	// The offer is sent once with every candidate, since the signaling has no trickle ICE
+	select {
+	case <-gathered:
+	case <-ctx.Done():
+		return fmt.Errorf("failed to gather candidates: %w", ctx.Err())
+	}
+
+	answer, err := signal(ctx, p.pc.LocalDescription().SDP)
+	if err != nil {
+		return fmt.Errorf("failed to signal offer: %w", err)
+	}
+	if err := p.pc.SetRemoteDescription(webrtc.SessionDescription{Type: webrtc.SDPTypeAnswer, SDP: answer}); err != nil {
+		return fmt.Errorf("failed to set remote description: %w", err)
+	}
+
+	if err := p.events.waitOpen(ctx); err != nil {
+		return fmt.Errorf("events data channel did not open: %w", err)
+	}
+	return nil
+}
+
+// Conn returns the connection carrying realtime events, for use with messaging.NewClient
+func (p *Peer) Conn() *ws.Conn {
+	return p.conn
+}
+
+// PeerConnection returns the underlying pion peer connection, e.g. to read its stats
+func (p *Peer) PeerConnection() *webrtc.PeerConnection {
+	return p.pc
+}
+
+// Close closes the data channel and the peer connection
+func (p *Peer) Close() error {
+	var err error
+	p.closeOnce.Do(func() {
+		close(p.closed)
+		err = errors.Join(p.events.Close(), p.pc.Close())
+	})
+	return err
+}
@@ -0,0 +1,260 @@
+package realtimewebrtc
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pion/ice/v4"
+	"github.com/pion/webrtc/v4"
+	"github.com/pion/webrtc/v4/pkg/media"
+
+	"github.com/Mliviu79/openai-realtime-go/audio"
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+// newTestAPI returns a pion API that connects peers over loopback without mDNS
+func newTestAPI() *webrtc.API {
+	se := webrtc.SettingEngine{}
+	se.SetIncludeLoopbackCandidate(true)
+	se.SetICEMulticastDNSMode(ice.MulticastDNSModeDisabled)
+	se.SetNetworkTypes([]webrtc.NetworkType{webrtc.NetworkTypeUDP4})
+	return webrtc.NewAPI(webrtc.WithSettingEngine(se))
+}
+
+// testServer is an in-process answering peer standing in for the realtime server.
+// It echoes events back with an "echo." type prefix, records the audio payloads it
+// receives, and sends audio on its own track.
+type testServer struct {
+	t        *testing.T
+	pc       *webrtc.PeerConnection
+	track    *webrtc.TrackLocalStaticSample
+	payloads chan []byte
+}
+
+func newTestServer(t *testing.T) *testServer {
+	return &testServer{t: t, payloads: make(chan []byte, 256)}
+}
+
+// signal implements SignalFunc by answering the offer in process
+func (s *testServer) signal(ctx context.Context, offer string) (string, error) {
+	pc, err := newTestAPI().NewPeerConnection(webrtc.Configuration{})
+	if err != nil {
+		return "", err
+	}
+	s.pc = pc
+	s.t.Cleanup(func() { pc.Close() })
+
+	s.track, err = webrtc.NewTrackLocalStaticSample(webrtc.RTPCodecCapability{MimeType: webrtc.MimeTypeOpus}, "audio", "server")
+	if err != nil {
+		return "", err
+	}
+	if _, err := pc.AddTrack(s.track); err != nil {
+		return "", err
+	}
+
+	pc.OnDataChannel(func(dc *webrtc.DataChannel) {
+		dc.OnMessage(func(msg webrtc.DataChannelMessage) {
+			dc.SendText(strings.Replace(string(msg.Data), `"type":"`, `"type":"echo.`, 1))
+		})
+	})
+	pc.OnTrack(func(track *webrtc.TrackRemote, _ *webrtc.RTPReceiver) {
+		for {
+			packet, _, err := track.ReadRTP()
+			if err != nil {
+				return
+			}
+			s.payloads <- packet.Payload
+		}
+	})
+
+	if err := pc.SetRemoteDescription(webrtc.SessionDescription{Type: webrtc.SDPTypeOffer, SDP: offer}); err != nil {
+		return "", err
+	}
+	answer, err := pc.CreateAnswer(nil)
+	if err != nil {
+		return "", err
+	}
+	gathered := webrtc.GatheringCompletePromise(pc)
+	if err := pc.SetLocalDescription(answer); err != nil {
+		return "", err
+	}
+	<-gathered
+	return pc.LocalDescription().SDP, nil
+}
+
+// fakeOpus stands in for an Opus codec: a packet holds the first sample of the frame
+// and the frame length, and decodes back to a frame filled with that sample
+type fakeOpus struct{}
+
+func (fakeOpus) Encode(pcm []int16, data []byte) (int, error) {
+	binary.LittleEndian.PutUint16(data, uint16(pcm[0]))
+	binary.LittleEndian.PutUint16(data[2:], uint16(len(pcm)))
+	return 4, nil
+}
+
+func (fakeOpus) Decode(data []byte, pcm []int16) (int, error) {
+	n := int(binary.LittleEndian.Uint16(data[2:]))
+	for i := 0; i < n; i++ {
+		pcm[i] = int16(binary.LittleEndian.Uint16(data))
+	}
+	return n, nil
+}
+
+func TestDialEvents(t *testing.T) {
+	server := newTestServer(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	peer, err := Dial(ctx, server.signal, WithAPI(newTestAPI()))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer peer.Close()
+
+	if err := peer.Conn().SendRaw(ctx, ws.MessageText, []byte(`{"type":"response.create"}`)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	messageType, data, err := peer.Conn().ReadRaw(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if messageType != ws.MessageText || string(data) != `{"type":"echo.response.create"}` {
+		t.Errorf("Expected the echoed event as text, got %s %s", messageType, data)
+	}
+	if err := peer.Conn().Ping(ctx); err != nil {
+		t.Errorf("Expected ping on an open channel to succeed, got %v", err)
+	}
+
+	peer.Close()
+	if _, _, err := peer.Conn().ReadRaw(ctx); !errors.Is(err, ErrDataChannelClosed) {
+		t.Errorf("Expected ErrDataChannelClosed after close, got %v", err)
+	}
+}
+
+func TestPeerAudio(t *testing.T) {
+	server := newTestServer(t)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	peer, err := Dial(ctx, server.signal, WithAPI(newTestAPI()))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer peer.Close()
+
+	// 50ms at 24kHz is two full 20ms frames and a padded partial one
+	samples := make([]int16, 1200)
+	for i := range samples {
+		samples[i] = 7
+	}
+	if err := peer.AttachSource(ctx, audio.NewSliceSource(24000, samples), fakeOpus{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		select {
+		case payload := <-server.payloads:
+			if first := int16(binary.LittleEndian.Uint16(payload)); first != 7 {
+				t.Errorf("Expected frame %d to start with sample 7, got %d", i, first)
+			}
+			if n := binary.LittleEndian.Uint16(payload[2:]); n != 960 {
+				t.Errorf("Expected frame %d to hold 960 samples at 48kHz, got %d", i, n)
+			}
+		case <-ctx.Done():
+			t.Fatalf("Expected 3 frames, got %d", i)
+		}
+	}
+
+	sink := audio.NewBufferSink(24000)
+	sinkDone := make(chan error, 1)
+	sinkCtx, stopSink := context.WithCancel(ctx)
+	go func() { sinkDone <- peer.AttachSink(sinkCtx, sink, fakeOpus{}) }()
+
+	// A 20ms frame at 48kHz filled with the sample 3
+	frame := make([]byte, 4)
+	binary.LittleEndian.PutUint16(frame, 3)
+	binary.LittleEndian.PutUint16(frame[2:], 960)
+	for len(sink.Samples()) < 480 && ctx.Err() == nil {
+		server.track.WriteSample(media.Sample{Data: frame, Duration: opusFrameDuration})
+		time.Sleep(opusFrameDuration)
+	}
+	stopSink()
+	if err := <-sinkDone; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected AttachSink to stop with the context, got %v", err)
+	}
+
+	got := sink.Samples()
+	if len(got) < 480 || len(got)%480 != 0 || got[0] != 3 {
+		t.Errorf("Expected whole frames of 480 samples of 3 at 24kHz, got %d samples", len(got))
+	}
+}
+
+func TestDialWithFallback(t *testing.T) {
+	failingSignal := func(ctx context.Context, offer string) (string, error) {
+		return "", errors.New("signaling rejected")
+	}
+	fallbackConn := ws.NewConn(nil)
+
+	tests := []struct {
+		name        string
+		signal      func(t *testing.T) SignalFunc
+		fallbackErr error
+		expectPeer  bool
+		expectErr   string
+	}{
+		{
+			name:       "webrtc",
+			signal:     func(t *testing.T) SignalFunc { return newTestServer(t).signal },
+			expectPeer: true,
+		},
+		{
+			name:   "fallback",
+			signal: func(t *testing.T) SignalFunc { return failingSignal },
+		},
+		{
+			name:        "both fail",
+			signal:      func(t *testing.T) SignalFunc { return failingSignal },
+			fallbackErr: errors.New("dial refused"),
+			expectErr:   "signaling rejected",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			fellBack := false
+			conn, peer, err := DialWithFallback(ctx, tt.signal(t), func(ctx context.Context) (*ws.Conn, error) {
+				fellBack = true
+				if tt.fallbackErr != nil {
+					return nil, tt.fallbackErr
+				}
+				return fallbackConn, nil
+			}, WithAPI(newTestAPI()))
+
+			if tt.expectErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectErr) || !errors.Is(err, tt.fallbackErr) {
+					t.Errorf("Expected an error wrapping both failures, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if tt.expectPeer {
+				defer peer.Close()
+				if peer == nil || conn != peer.Conn() || fellBack {
+					t.Errorf("Expected a WebRTC peer without fallback, got peer %v fallback %v", peer, fellBack)
+				}
+				return
+			}
+			if peer != nil || conn != fallbackConn {
+				t.Errorf("Expected the fallback connection and no peer, got peer %v", peer)
+			}
+		})
+	}
+}
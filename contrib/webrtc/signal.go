@@ -0,0 +1,92 @@
+package realtimewebrtc
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Mliviu79/openai-realtime-go/session"
+)
+
+// DefaultAPIBaseURL is the base URL the OpenAI signaler posts offers to
+const DefaultAPIBaseURL = "https://api.openai.com/v1"
+
+// maxAnswerBytes bounds the size of an SDP answer read from the signaling endpoint
+const maxAnswerBytes = 1 << 20
+
+// SignalFunc exchanges the local SDP offer for the remote peer's SDP answer
+type SignalFunc func(ctx context.Context, offer string) (answer string, err error)
+
+// SignalOption configures the signaler returned by OpenAISignaler
+type SignalOption func(*signaler)
+
+// WithSignalBaseURL sets the API base URL; the default is DefaultAPIBaseURL
+func WithSignalBaseURL(baseURL string) SignalOption {
+	return func(s *signaler) {
+		s.baseURL = strings.TrimSuffix(baseURL, "/")
+	}
+}
+
+// WithSignalHTTPClient sets the HTTP client used to post the offer
+func WithSignalHTTPClient(client *http.Client) SignalOption {
+	return func(s *signaler) {
+		s.client = client
+	}
+}
+
+// signaler posts offers to the realtime calls endpoint
+type signaler struct {
+	token   string
+	model   session.Model
+	baseURL string
+	client  *http.Client
+}
+
+// OpenAISignaler returns a SignalFunc that posts the offer to the realtime calls
+// endpoint. The token can be an API key on a server, or an ephemeral client secret
+// created with openaiClient.Client.CreateSession when the key must not leave it.
+func OpenAISignaler(token string, model session.Model, opts ...SignalOption) SignalFunc {
+	s := &signaler{
+		token:   token,
+		model:   model,
+		baseURL: DefaultAPIBaseURL,
+		client:  http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s.signal
+}
+
+// signal implements SignalFunc
+func (s *signaler) signal(ctx context.Context, offer string) (string, error) {
+	endpoint := s.baseURL + "/realtime/calls"
+	if s.model != "" {
+		endpoint += "?" + url.Values{"model": {string(s.model)}}.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(offer))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+s.token)
+	req.Header.Set("Content-Type", "application/sdp")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to send offer: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxAnswerBytes))
+	if err != nil {
+		return "", fmt.Errorf("failed to read answer: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("signaling failed with status %d: %s", resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return string(body), nil
+}
@@ -0,0 +1,52 @@
+package realtimewebrtc
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Mliviu79/openai-realtime-go/session"
+)
+
+func TestOpenAISignaler(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/realtime/calls" {
+			t.Errorf("Expected POST /v1/realtime/calls, got %s %s", r.Method, r.URL.Path)
+		}
+		if model := r.URL.Query().Get("model"); model != string(session.GPTRealtime) {
+			t.Errorf("Expected model %s, got %s", session.GPTRealtime, model)
+		}
+		if auth := r.Header.Get("Authorization"); auth != "Bearer secret" {
+			t.Errorf("Expected bearer auth, got %s", auth)
+		}
+		if ct := r.Header.Get("Content-Type"); ct != "application/sdp" {
+			t.Errorf("Expected content type application/sdp, got %s", ct)
+		}
+		body, _ := io.ReadAll(r.Body)
+		if string(body) == "bad offer" {
+			http.Error(w, "invalid SDP", http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("answer for " + string(body)))
+	}))
+	defer srv.Close()
+
+	signal := OpenAISignaler("secret", session.GPTRealtime, WithSignalBaseURL(srv.URL+"/v1/"), WithSignalHTTPClient(srv.Client()))
+
+	answer, err := signal(context.Background(), "offer")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if answer != "answer for offer" {
+		t.Errorf("Expected the answer SDP, got %q", answer)
+	}
+
+	_, err = signal(context.Background(), "bad offer")
+	if err == nil || !strings.Contains(err.Error(), "status 400") || !strings.Contains(err.Error(), "invalid SDP") {
+		t.Errorf("Expected a status error with the body, got %v", err)
+	}
+}
@@ -3,6 +3,10 @@
 mods=(
     .
     ./contrib/ws-gorilla
+    ./contrib/grpc
+    ./contrib/webrtc
+    ./contrib/sqlite
+    ./contrib/nats
 )
 
 for mod in "${mods[@]}"; do
@@ -3,7 +3,7 @@
 // This example demonstrates how to:
 // - Create and establish a session with the OpenAI Realtime API
 // - Send every type of outgoing message (all 9 types)
-// - Handle every type of incoming message (all 28 types)
+// - Handle every known type of incoming message (see incoming.AllTypes)
 // - Test all the API's functionality in a real environment
 // - Test edge cases and error conditions
 //
@@ -65,56 +65,8 @@ func (t *TestTracker) GetUnseen() []string {
 	defer t.mutex.Unlock()
 
 	var unseen []string
-	// All 28 incoming message types that can be received from the server
-	allTypes := []string{
-		// Session management
-		"session.created",
-		"session.updated",
-
-		// Conversation management
-		"conversation.created",
-		"conversation.item.created",
-		"conversation.truncated",       // Added: Confirmation of conversation truncation
-		"conversation.items.truncated", // Added: Multiple items truncated
-
-		// Audio buffer events
-		"input_audio_buffer.committed",
-		"input_audio_buffer.speech_started",
-		"input_audio_buffer.speech_stopped",
-
-		// Audio transcription events
-		"conversation.item.input_audio_transcription.completed",
-		"conversation.item.input_audio_transcription.failed", // Added: Audio transcription failure
-
-		// Response lifecycle events
-		"response.created",
-		"response.canceled", // Added: Response cancellation confirmation
-		"response.done",
-
-		// Response delta events
-		"response.output_text.delta",
-		"response.output_audio.delta",
-		"response.output_audio_transcript.delta",
-		"response.function_call_arguments.delta",
-
-		// Response completion events
-		"response.output_audio.done",
-		"response.output_audio_transcript.done",
-		"response.function_call_arguments.done",
-		"response.function_call_output.done", // Added: Function call output completion
-
-		// Response content events
-		"response.content_part.added",
-		"response.content_part.done",
-		"response.output_item.added",
-		"response.output_item.done",
-
-		// System events
-		"rate_limits.updated",
-		"error", // Added: Generic error message
-	}
-
-	for _, typeName := range allTypes {
+	for _, msgType := range incoming.AllTypes() {
+		typeName := string(msgType)
 		if !t.seen[typeName] {
 			unseen = append(unseen, typeName)
 		}
@@ -136,7 +88,7 @@ func prettyPrint(msg interface{}) {
 // RunComprehensiveTest runs all the tests for the API
 func RunComprehensiveTest() {
 	fmt.Println("Starting comprehensive OpenAI Realtime API test...")
-	fmt.Println("This test will attempt to trigger all 28 incoming message types and send all 9 outgoing message types.")
+	fmt.Printf("This test will attempt to trigger all %d incoming message types and send all 9 outgoing message types.\n", len(incoming.AllTypes()))
 
 	// Get API key from environment variable
 	apiKey := os.Getenv("OPENAI_API_KEY")
@@ -169,8 +121,7 @@ func RunComprehensiveTest() {
 		cancel()
 	}()
 
-	// Store for conversation ID and item ID to use in later tests
-	var conversationID string
+	// Store for item ID to use in later tests
 	var itemID string
 	var responseID string
 
@@ -343,8 +294,7 @@ func RunComprehensiveTest() {
 
 				case *incoming.ConversationCreatedMessage:
 					tracker.MarkSeen("conversation.created")
-					conversationID = event.Conversation.ID
-					fmt.Printf("Conversation created with ID: %s\n", conversationID)
+					fmt.Printf("Conversation created with ID: %s\n", msgClient.ConversationID())
 
 				case *incoming.ConversationItemCreatedMessage:
 					tracker.MarkSeen("conversation.item.created")
@@ -450,13 +400,15 @@ func RunComprehensiveTest() {
 
 	// Configure session update parameters - using only the standard approach
 	sessionUpdateReq := session.SessionRequest{
-		Modalities:        &modalities,
-		Instructions:      &instructions,
-		Voice:             &voice,
-		OutputAudioFormat: &outputAudioFormat,
-		TurnDetection:     &turnDetection,
-		Temperature:       &temperature,
-		Tools:             &tools,
+		Modalities: &modalities,
+		ResponseParams: session.ResponseParams{
+			Instructions:      &instructions,
+			Voice:             &voice,
+			OutputAudioFormat: &outputAudioFormat,
+			Temperature:       &temperature,
+		},
+		TurnDetection: &turnDetection,
+		Tools:         &tools,
 	}
 
 	// Send the session update message
@@ -563,10 +515,12 @@ func RunComprehensiveTest() {
 	responseInstructions := "Give a very brief weather report for San Francisco, using the get_weather function."
 
 	responseConfig := types.ResponseConfig{
-		Modalities:   responseModalities,
-		Instructions: &responseInstructions,
-		Voice:        &voice,
-		Tools:        tools,
+		Modalities: responseModalities,
+		ResponseParams: session.ResponseParams{
+			Instructions: &responseInstructions,
+			Voice:        &voice,
+		},
+		Tools: tools,
 		Metadata: map[string]string{
 			"test_id": "comprehensive_test",
 		},
@@ -588,8 +542,10 @@ func RunComprehensiveTest() {
 
 	// Create another response
 	cancelResponseConfig := types.ResponseConfig{
-		Modalities:   responseModalities,
-		Instructions: &responseInstructions,
+		Modalities: responseModalities,
+		ResponseParams: session.ResponseParams{
+			Instructions: &responseInstructions,
+		},
 	}
 
 	// Send the response.create message
@@ -719,7 +675,7 @@ func RunComprehensiveTest() {
 	// Try to create a response with invalid configuration
 	invalidResponseConfig := types.ResponseConfig{
 		// Missing required fields intentionally to trigger errors
-		Modalities: []session.Modality{"invalid_modality"}, // Invalid modality
+		Modalities: []session.Modality{"invalid_modality"}, // Invalid modality, rejected before it is sent
 	}
 
 	err = msgClient.SendResponseCreate(ctx, &invalidResponseConfig)
@@ -741,7 +697,7 @@ func RunComprehensiveTest() {
 	unseen := tracker.GetUnseen()
 
 	if len(unseen) == 0 {
-		fmt.Println("SUCCESS! All 28 expected message types were received.")
+		fmt.Printf("SUCCESS! All %d expected message types were received.\n", len(incoming.AllTypes()))
 		fmt.Println("All 9 outgoing message types were sent.")
 	} else {
 		fmt.Println("The following message types were NOT observed during testing:")
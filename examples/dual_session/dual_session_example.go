@@ -131,9 +131,10 @@ func runTranscriptionSession(ctx context.Context, client *openaiClient.Client, l
 	fmt.Printf("Created transcription session with ID: %s\n", sessionResp.ID)
 
 	// Connect to the transcription session
-	conn, err := client.ConnectTranscription(ctx,
-		openaiClient.WithTranscriptionSessionID(sessionResp.ID),
-		openaiClient.WithTranscriptionLogger(logger))
+	conn, err := client.Connect(ctx,
+		openaiClient.WithIntent(openaiClient.IntentTranscription),
+		openaiClient.WithSessionID(sessionResp.ID),
+		openaiClient.WithLogger(logger))
 	if err != nil {
 		return fmt.Errorf("failed to connect to transcription session: %w", err)
 	}
@@ -2,17 +2,18 @@ package main
 
 import (
 	"context"
-	"encoding/base64"
+	"errors"
 	"fmt"
 	"log"
 	"os"
 	"time"
 
+	"github.com/Mliviu79/openai-realtime-go/apierrs"
 	"github.com/Mliviu79/openai-realtime-go/logger"
-	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
 	"github.com/Mliviu79/openai-realtime-go/messaging"
 	"github.com/Mliviu79/openai-realtime-go/openaiClient"
 	"github.com/Mliviu79/openai-realtime-go/session"
+	"github.com/Mliviu79/openai-realtime-go/transcription"
 	"github.com/rs/zerolog"
 )
 
@@ -74,19 +75,14 @@ func main() {
 	}
 	debugLogger := logger.NewZeroLogger(debugLoggerOpts)
 
-	conn, err := client.ConnectTranscription(ctx,
-		openaiClient.WithTranscriptionSessionID(sessionResp.ID),
-		openaiClient.WithTranscriptionLogger(debugLogger))
+	conn, err := client.Connect(ctx,
+		openaiClient.WithIntent(openaiClient.IntentTranscription),
+		openaiClient.WithSessionID(sessionResp.ID),
+		openaiClient.WithLogger(debugLogger))
 	if err != nil {
 		log.Fatalf("Failed to connect: %v", err)
 	}
 
-	// Create messaging client to handle the protocol
-	msgClient := messaging.NewClient(conn)
-
-	// Update the transcription session with new settings
-	fmt.Println("Updating transcription session...")
-
 	// Create noise reduction configuration
 	noiseReduction := &session.InputAudioNoiseReduction{
 		Type: session.NoiseReductionTypeNearField,
@@ -100,98 +96,46 @@ func main() {
 		Eagerness: eagerness,
 	}
 
-	// Create update request
-	updateReq := session.TranscriptionSessionRequest{
-		InputAudioNoiseReduction: noiseReduction,
-		TurnDetection:            turnDetection,
-	}
-
-	// Send the update with a custom ID so we can track the response
-	err = msgClient.SendTranscriptionSessionUpdateWithID(ctx, "update-1", updateReq)
-	if err != nil {
-		log.Fatalf("Failed to update transcription session: %v", err)
-	}
-
-	// Wait for confirmation of update
-	fmt.Println("Waiting for update confirmation...")
-	updateConfirmed := false
-	timeout := time.After(10 * time.Second)
-
-updateLoop:
-	for !updateConfirmed {
-		select {
-		case <-timeout:
-			log.Fatalf("Timed out waiting for update confirmation")
-		default:
-			msg, err := msgClient.ReadMessage(ctx)
-			if err != nil {
-				log.Fatalf("Error reading message: %v", err)
-			}
-
-			switch msg.RcvdMsgType() {
-			case incoming.RcvdMsgTypeTranscriptionSessionUpdated:
-				if updated, ok := msg.(*incoming.TranscriptionSessionUpdatedMessage); ok {
-					fmt.Println("Session updated successfully")
-					fmt.Printf("  - Noise reduction type: %v\n", updated.Session.InputAudioNoiseReduction.Type)
-					fmt.Printf("  - Turn detection type: %v\n", updated.Session.TurnDetection.Type)
-					updateConfirmed = true
-					break updateLoop
-				}
-			case incoming.RcvdMsgTypeError:
-				if errMsg, ok := msg.(*incoming.ErrorMessage); ok {
-					fmt.Printf("Error updating session: %s\n", errMsg.Error.Message)
-					return
-				}
-			}
-		}
-	}
+	// The stream sends the session update and waits for the server to confirm it
+	// before yielding the first segment
+	stream := transcription.NewStream(messaging.NewClient(conn),
+		transcription.WithSessionUpdate(session.TranscriptionSessionRequest{
+			InputAudioNoiseReduction: noiseReduction,
+			TurnDetection:            turnDetection,
+		}),
+		transcription.WithPartialSegments(),
+	)
 
 	// Send audio data (this is a placeholder - you would read actual audio data from a file or microphone)
-	// In a real application, you would stream audio chunks continuously
+	// In a real application, you would stream audio chunks continuously, and server VAD
+	// commits each utterance
 	fmt.Println("Sending audio data...")
 	audioChunk := make([]byte, 1024) // Placeholder for audio data
-	audioBase64 := base64.StdEncoding.EncodeToString(audioChunk)
-	err = msgClient.SendAudio(ctx, audioBase64, "")
-	if err != nil {
+	if err := stream.Write(ctx, audioChunk); err != nil {
 		log.Fatalf("Failed to send audio: %v", err)
 	}
+	if err := stream.Commit(ctx); err != nil {
+		log.Fatalf("Failed to commit audio: %v", err)
+	}
 
-	// Alternative method: Use audio buffer append and commit
-	// err = msgClient.SendAudioBufferAppend(ctx, audioBase64)
-	// if err != nil {
-	//     log.Fatalf("Failed to append audio: %v", err)
-	// }
-	// err = msgClient.SendAudioBufferCommit(ctx, "")
-	// if err != nil {
-	//     log.Fatalf("Failed to commit audio: %v", err)
-	// }
-
-	// Read and process messages
+	// Read and process segments
 	fmt.Println("Waiting for transcriptions...")
-	for {
-		msg, err := msgClient.ReadMessage(ctx)
+	for segment, err := range stream.Segments(ctx) {
 		if err != nil {
-			log.Fatalf("Error reading message: %v", err)
-		}
-
-		// Handle different message types
-		switch msg.RcvdMsgType() {
-		case incoming.RcvdMsgTypeInputAudioTranscription:
-			transcription, ok := msg.(*incoming.InputAudioTranscriptionMessage)
-			if ok {
-				fmt.Printf("Transcription: %s\n", transcription.Text)
-				if len(transcription.Logprobs) > 0 {
-					fmt.Printf("Log probabilities available: %d items\n", len(transcription.Logprobs))
-				}
-			}
-		case incoming.RcvdMsgTypeTranscriptionDone:
-			fmt.Println("Transcription complete")
-			return
-		case incoming.RcvdMsgTypeError:
-			if errMsg, ok := msg.(*incoming.ErrorMessage); ok {
-				fmt.Printf("Error: %s\n", errMsg.Error.Message)
+			var apiErr *apierrs.APIError
+			if errors.As(err, &apiErr) && segment.ItemID != "" {
+				fmt.Printf("Transcription of %s failed: %v\n", segment.ItemID, err)
+				continue
 			}
-			return
+			log.Fatalf("Error reading transcriptions: %v", err)
+		}
+		if segment.Partial {
+			fmt.Printf("... %s\n", segment.Text)
+			continue
+		}
+		fmt.Printf("Transcription [%d-%d ms]: %s\n", segment.StartMs, segment.EndMs, segment.Text)
+		if len(segment.Logprobs) > 0 {
+			fmt.Printf("Log probabilities available: %d items\n", len(segment.Logprobs))
 		}
 	}
 }
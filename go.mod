@@ -5,9 +5,11 @@ go 1.23.0
 toolchain go1.23.5
 
 require (
+	github.com/go-logr/logr v1.4.4
 	github.com/gorilla/websocket v1.5.3
 	github.com/rs/zerolog v1.33.0
 	github.com/stretchr/testify v1.10.0
+	golang.org/x/text v0.21.0
 )
 
 require (
@@ -104,6 +104,13 @@ func Do[Q any, R any](ctx context.Context, url string, req *Q, opts ...HTTPOptio
 		o(&opt)
 	}
 
+	// Wait for the rate limiter, if any
+	if opt.limiter != nil {
+		if err := opt.limiter.Wait(ctx); err != nil {
+			return nil, fmt.Errorf("rate limiter: %w", err)
+		}
+	}
+
 	// Prepare the request
 	request, err := prepareRequest(ctx, opt.method, url, req, opt.headers)
 	if err != nil {
@@ -15,6 +15,10 @@ type ClientConfig struct {
 	APIVersion string       // API version (used for Azure)
 	HTTPClient *http.Client // HTTP client to use for requests
 	APIBaseURL string       // Base URL for the REST API
+
+	// RateLimiter limits the rate of REST requests made with this configuration.
+	// It is nil by default, which leaves requests unlimited.
+	RateLimiter *RateLimiter
 }
 
 // DefaultConfig creates a default configuration with the given auth token
@@ -83,6 +87,26 @@ func GetHeaders(config ClientConfig) http.Header {
 	return headers
 }
 
+// GetSubprotocols returns the WebSocket subprotocols that carry the credentials
+// in place of the authorization header, mirroring how browsers and relays connect.
+// Only the OpenAI API supports subprotocol authentication; nil is returned otherwise.
+//
+// Parameters:
+//   - config: The client configuration
+//
+// Returns:
+//   - []string: The subprotocols to offer during the WebSocket handshake
+func GetSubprotocols(config ClientConfig) []string {
+	if config.APIType != APITypeOpenAI {
+		return nil
+	}
+	return []string{
+		SubprotocolRealtime,
+		SubprotocolInsecureAPIKeyPrefix + config.authToken,
+		SubprotocolBetaRealtimeV1,
+	}
+}
+
 // GetURL constructs the appropriate URL based on API type and model
 //
 // Parameters:
@@ -80,6 +80,23 @@ func TestGetHeadersAzure(t *testing.T) {
 	}
 }
 
+func TestGetSubprotocols(t *testing.T) {
+	protocols := GetSubprotocols(DefaultConfig("test-token"))
+	expected := []string{"realtime", "openai-insecure-api-key.test-token", "openai-beta.realtime-v1"}
+	if len(protocols) != len(expected) {
+		t.Fatalf("Expected %d subprotocols, got %d", len(expected), len(protocols))
+	}
+	for i := range expected {
+		if protocols[i] != expected[i] {
+			t.Errorf("Expected subprotocol %d to be %q, got %q", i, expected[i], protocols[i])
+		}
+	}
+
+	if protocols := GetSubprotocols(DefaultAzureConfig("test-api-key", "https://test.openai.azure.com/openai")); protocols != nil {
+		t.Errorf("Expected no subprotocols for Azure, got %v", protocols)
+	}
+}
+
 func TestConfigString(t *testing.T) {
 	config := ClientConfig{
 		BaseURL:    "https://api.example.com/v1",
@@ -18,4 +18,13 @@ const (
 
 	// azureAPIVersion20241001Preview is the API version for Azure.
 	azureAPIVersion20241001Preview = "2024-10-01-preview"
+
+	// SubprotocolRealtime is the WebSocket subprotocol identifying a realtime connection.
+	SubprotocolRealtime = "realtime"
+
+	// SubprotocolInsecureAPIKeyPrefix prefixes the API key when it is sent as a subprotocol.
+	SubprotocolInsecureAPIKeyPrefix = "openai-insecure-api-key."
+
+	// SubprotocolBetaRealtimeV1 is the beta subprotocol sent alongside subprotocol authentication.
+	SubprotocolBetaRealtimeV1 = "openai-beta.realtime-v1"
 )
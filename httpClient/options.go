@@ -35,6 +35,7 @@ type option struct {
 	method      string
 	timeout     time.Duration
 	retryConfig RetryConfig
+	limiter     *RateLimiter
 }
 
 // defaultOption returns an option with sensible defaults
@@ -129,6 +130,16 @@ func WithRetryConfig(config RetryConfig) HTTPOption {
 	}
 }
 
+// WithRateLimiter makes the request wait for the rate limiter before it is sent.
+// A nil limiter sends the request immediately.
+// Parameters:
+//   - limiter: The rate limiter shared by the requests to limit
+func WithRateLimiter(limiter *RateLimiter) HTTPOption {
+	return func(o *option) {
+		o.limiter = limiter
+	}
+}
+
 // WithBasicAuth sets basic authentication headers for the HTTP request
 // Parameters:
 //   - username: The username for basic auth
@@ -0,0 +1,116 @@
+package httpClient
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/clock"
+)
+
+// RateLimiterOption configures a RateLimiter
+type RateLimiterOption func(*RateLimiter)
+
+// WithRateLimiterClock sets the clock used for refilling and waiting, e.g. a clock.Fake
+// in tests. The default is the system clock.
+func WithRateLimiterClock(c clock.Clock) RateLimiterOption {
+	return func(l *RateLimiter) {
+		if c != nil {
+			l.clock = c
+		}
+	}
+}
+
+// RateLimiter is a token bucket that limits the rate of requests.
+// The bucket holds up to burst tokens and refills at rps tokens per second;
+// each request takes one token, waiting for it if the bucket is empty.
+//
+// A RateLimiter is safe for concurrent use. Share one limiter between all clients that
+// must respect the same limit, for example by setting it on a ClientConfig:
+//
+//	config := httpClient.DefaultConfig(apiKey)
+//	config.RateLimiter = httpClient.NewRateLimiter(10, 20)
+//	client := openaiClient.NewClientWithConfig(config)
+type RateLimiter struct {
+	rps   float64
+	burst float64
+
+	mu     sync.Mutex
+	tokens float64
+	last   time.Time
+
+	clock clock.Clock
+}
+
+// NewRateLimiter creates a RateLimiter that allows rps requests per second on average
+// and bursts of up to burst requests. The bucket starts full. A burst below 1 is treated as 1.
+func NewRateLimiter(rps float64, burst int, opts ...RateLimiterOption) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	l := &RateLimiter{
+		rps:   rps,
+		burst: float64(burst),
+		clock: clock.Real(),
+	}
+	for _, opt := range opts {
+		opt(l)
+	}
+	l.tokens = l.burst
+	l.last = l.clock.Now()
+	return l
+}
+
+// Wait blocks until a request may be made or the context is done.
+// If the context is done first, the reserved token is returned to the bucket.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	delay := l.reserve()
+	if delay <= 0 {
+		return nil
+	}
+
+	if err := clock.Sleep(ctx, l.clock, delay); err != nil {
+		l.cancel()
+		return err
+	}
+	return nil
+}
+
+// reserve takes a token and returns how long to wait before it may be used.
+// The bucket goes negative while requests are waiting so they are served in order.
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock.Now()
+	l.tokens += now.Sub(l.last).Seconds() * l.rps
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+
+	l.tokens--
+	if l.tokens >= 0 {
+		return 0
+	}
+	if l.rps <= 0 {
+		// No refill; the limiter never allows more than the initial burst
+		return time.Duration(math.MaxInt64)
+	}
+	return time.Duration(-l.tokens / l.rps * float64(time.Second))
+}
+
+// cancel returns a reserved token to the bucket
+func (l *RateLimiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tokens++
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+}
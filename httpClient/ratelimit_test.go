@@ -0,0 +1,118 @@
+package httpClient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/clock"
+)
+
+func TestRateLimiterReserve(t *testing.T) {
+	fake := clock.NewFake(time.Unix(0, 0))
+	limiter := NewRateLimiter(10, 2, WithRateLimiterClock(fake))
+
+	tests := []struct {
+		name     string
+		advance  time.Duration
+		expected time.Duration
+	}{
+		{name: "first burst token", expected: 0},
+		{name: "second burst token", expected: 0},
+		{name: "bucket empty", expected: 100 * time.Millisecond},
+		{name: "queued behind waiting request", expected: 200 * time.Millisecond},
+		{name: "refilled while waiting", advance: 300 * time.Millisecond, expected: 0},
+		{name: "refill does not exceed burst", advance: time.Minute, expected: 0},
+	}
+
+	for _, tt := range tests {
+		fake.Advance(tt.advance)
+		if delay := limiter.reserve(); delay != tt.expected {
+			t.Errorf("%s: Expected delay %v, got %v", tt.name, tt.expected, delay)
+		}
+	}
+
+	// After a long pause the bucket holds at most burst tokens
+	limiter.reserve()
+	if delay := limiter.reserve(); delay != 100*time.Millisecond {
+		t.Errorf("Expected delay 100ms after the burst, got %v", delay)
+	}
+}
+
+func TestRateLimiterWait(t *testing.T) {
+	fake := clock.NewFake(time.Unix(0, 0))
+	limiter := NewRateLimiter(1, 1, WithRateLimiterClock(fake))
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatalf("Expected first request to pass, got %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() { done <- limiter.Wait(context.Background()) }()
+	fake.BlockUntil(1)
+	select {
+	case err := <-done:
+		t.Fatalf("Expected the second request to wait for a token, got %v", err)
+	default:
+	}
+	fake.Advance(time.Second)
+	if err := <-done; err != nil {
+		t.Errorf("Expected the second request to pass after a second, got %v", err)
+	}
+}
+
+func TestRateLimiterWaitCancelled(t *testing.T) {
+	fake := clock.NewFake(time.Unix(0, 0))
+	limiter := NewRateLimiter(0.001, 1, WithRateLimiterClock(fake))
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatalf("Expected first request to pass, got %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- limiter.Wait(ctx) }()
+	fake.BlockUntil(1)
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context canceled, got %v", err)
+	}
+
+	// The cancelled reservation is returned, so only one request is queued
+	limiter.mu.Lock()
+	tokens := limiter.tokens
+	limiter.mu.Unlock()
+	if tokens < 0 {
+		t.Errorf("Expected cancelled reservation to be returned, got %v tokens", tokens)
+	}
+}
+
+func TestDoWithRateLimiter(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"success": true}`))
+	}))
+	defer server.Close()
+
+	limiter := NewRateLimiter(0.001, 2)
+	for i := 0; i < 2; i++ {
+		if _, err := Do[testRequest, testResponse](context.Background(), server.URL, &testRequest{}, WithRateLimiter(limiter)); err != nil {
+			t.Fatalf("Expected request %d to succeed, got %v", i, err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := Do[testRequest, testResponse](ctx, server.URL, &testRequest{}, WithRateLimiter(limiter))
+	if err == nil || !strings.Contains(err.Error(), "rate limiter") {
+		t.Errorf("Expected rate limiter error, got %v", err)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("Expected 2 requests to reach the server, got %d", got)
+	}
+}
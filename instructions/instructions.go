@@ -0,0 +1,187 @@
+// Package instructions composes session and response instructions from templates, so
+// per-user details such as a name, a locale or business rules can be filled in without
+// string concatenation scattered across the application.
+//
+// Templates reference variables as {{name}}. Values are inserted as they are and never
+// expanded again, so text supplied by a user cannot inject further variables, and a
+// variable the template uses but vars does not set is an error rather than an empty
+// string. The rendered text is checked against session.MaxInstructionsLength.
+//
+// Example:
+//
+//	opt, err := instructions.WithTemplate(
+//		"You are a support agent for {{company}}. Address the customer as {{name}}.",
+//		map[string]string{"company": "Acme", "name": customer.Name},
+//	)
+//	if err != nil {
+//		return err
+//	}
+//	req, err := session.BuildSessionRequest(session.WithModel(session.GPTRealtime), opt)
+package instructions
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+
+	"golang.org/x/text/language/display"
+
+	"github.com/Mliviu79/openai-realtime-go/session"
+)
+
+// Template is a parsed instructions template. It is safe for concurrent use, so a
+// template can be parsed once and rendered for every session.
+type Template struct {
+	// parts alternates literal text and variable names, starting with text
+	parts []string
+}
+
+// Parse parses an instructions template. Variables are written {{name}}, with optional
+// spaces inside the braces; names may contain letters, digits, '_', '-' and '.'.
+// An unterminated or empty variable is an error.
+func Parse(tmpl string) (*Template, error) {
+	t := &Template{}
+	rest := tmpl
+	for {
+		start := strings.Index(rest, "{{")
+		if start < 0 {
+			t.parts = append(t.parts, rest)
+			return t, nil
+		}
+		end := strings.Index(rest[start:], "}}")
+		if end < 0 {
+			return nil, fmt.Errorf("instructions template: unterminated variable at offset %d", len(tmpl)-len(rest)+start)
+		}
+		name := strings.TrimSpace(rest[start+2 : start+end])
+		if !validName(name) {
+			return nil, fmt.Errorf("instructions template: invalid variable name %q", name)
+		}
+		t.parts = append(t.parts, rest[:start], name)
+		rest = rest[start+end+2:]
+	}
+}
+
+// MustParse is like Parse but panics if the template cannot be parsed. It is intended
+// for templates that are constants of the program.
+func MustParse(tmpl string) *Template {
+	t, err := Parse(tmpl)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+// Variables returns the names of the variables the template uses, in order of first use
+func (t *Template) Variables() []string {
+	var names []string
+	seen := make(map[string]bool)
+	for i := 1; i < len(t.parts); i += 2 {
+		if !seen[t.parts[i]] {
+			seen[t.parts[i]] = true
+			names = append(names, t.parts[i])
+		}
+	}
+	return names
+}
+
+// Render substitutes vars into the template. It returns an error naming the variables
+// that vars does not set, or an *apierrs.APIError if the result is longer than
+// session.MaxInstructionsLength. Variables that the template does not use are ignored.
+func (t *Template) Render(vars map[string]string) (string, error) {
+	var missing []string
+	var b strings.Builder
+	for i, part := range t.parts {
+		if i%2 == 0 {
+			b.WriteString(part)
+			continue
+		}
+		value, ok := vars[part]
+		if !ok {
+			missing = append(missing, part)
+			continue
+		}
+		b.WriteString(value)
+	}
+	if len(missing) > 0 {
+		return "", fmt.Errorf("instructions template: missing variables %s", strings.Join(missing, ", "))
+	}
+
+	out := b.String()
+	if err := session.ValidateInstructions("instructions", out); err != nil {
+		return "", err
+	}
+	return out, nil
+}
+
+// Option renders the template with vars and returns a session.ConfigOption that sets
+// the result as the session instructions. Rendering happens here rather than when the
+// option is applied, so errors are reported before the session request is built.
+func (t *Template) Option(vars map[string]string) (session.ConfigOption, error) {
+	out, err := t.Render(vars)
+	if err != nil {
+		return nil, err
+	}
+	return session.WithInstructions(out), nil
+}
+
+// Render parses tmpl and substitutes vars into it; see Template.Render
+func Render(tmpl string, vars map[string]string) (string, error) {
+	t, err := Parse(tmpl)
+	if err != nil {
+		return "", err
+	}
+	return t.Render(vars)
+}
+
+// WithTemplate parses and renders tmpl and returns a session.ConfigOption that sets the
+// result as the session instructions; see Template.Option
+func WithTemplate(tmpl string, vars map[string]string) (session.ConfigOption, error) {
+	t, err := Parse(tmpl)
+	if err != nil {
+		return nil, err
+	}
+	return t.Option(vars)
+}
+
+// validName reports whether name is a non-empty variable name
+func validName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == '-', r == '.':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// LocaleVars returns a copy of vars with the template variables describing the user's
+// locale: "locale" (the BCP-47 tag, such as "pt-BR"), "language" (its ISO-639-1 code)
+// and "language_name" (its English name, such as "Portuguese"). Variables already set
+// in vars are kept, so an application can override them.
+//
+// Example:
+//
+//	tmpl := instructions.MustParse("Always reply in {{language_name}}.")
+//	opt, err := tmpl.Option(instructions.LocaleVars(locale, nil))
+func LocaleVars(locale session.Locale, vars map[string]string) map[string]string {
+	out := make(map[string]string, len(vars)+3)
+	out["locale"] = string(locale)
+	out["language"] = locale.TranscriptionLanguage()
+	out["language_name"] = LanguageName(locale)
+	maps.Copy(out, vars)
+	return out
+}
+
+// LanguageName returns the English name of the locale's language, such as "Portuguese"
+// for "pt-BR", for use in instructions. It returns an empty string for an invalid locale.
+func LanguageName(locale session.Locale) string {
+	base, ok := locale.Base()
+	if !ok {
+		return ""
+	}
+	return display.English.Languages().Name(base)
+}
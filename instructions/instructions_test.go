@@ -0,0 +1,107 @@
+package instructions
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Mliviu79/openai-realtime-go/apierrs"
+	"github.com/Mliviu79/openai-realtime-go/session"
+)
+
+func TestRender(t *testing.T) {
+	out, err := Render("Hello {{name}}, reply in {{ locale }}. Goodbye {{name}}.", map[string]string{
+		"name":   "{{locale}}",
+		"locale": "fr-FR",
+		"unused": "x",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// Values are inserted as they are, not expanded again
+	if expected := "Hello {{locale}}, reply in fr-FR. Goodbye {{locale}}."; out != expected {
+		t.Errorf("Expected %q, got %q", expected, out)
+	}
+}
+
+func TestRenderErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		tmpl    string
+		vars    map[string]string
+		wantErr string
+	}{
+		{name: "missing variables", tmpl: "{{a}} {{b}} {{c}}", vars: map[string]string{"b": ""}, wantErr: "missing variables a, c"},
+		{name: "unterminated", tmpl: "Hi {{name", wantErr: "unterminated variable at offset 3"},
+		{name: "empty name", tmpl: "Hi {{ }}", wantErr: `invalid variable name ""`},
+		{name: "invalid name", tmpl: "Hi {{first name}}", wantErr: `invalid variable name "first name"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Render(tt.tmpl, tt.vars)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestRenderLengthLimit(t *testing.T) {
+	_, err := Render("Rules: {{rules}}", map[string]string{"rules": strings.Repeat("x", session.MaxInstructionsLength)})
+	var apiErr *apierrs.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected an *apierrs.APIError, got %v", err)
+	}
+}
+
+func TestTemplateVariables(t *testing.T) {
+	tmpl := MustParse("{{name}} speaks {{locale}}; greet {{name}}")
+	if got := tmpl.Variables(); !slices.Equal(got, []string{"name", "locale"}) {
+		t.Errorf("Expected [name locale], got %v", got)
+	}
+}
+
+func TestWithTemplate(t *testing.T) {
+	opt, err := WithTemplate("You are helping {{name}}.", map[string]string{"name": "Ada"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	req, err := session.BuildSessionRequest(session.WithModel(session.GPTRealtime), opt)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if req.Instructions == nil || *req.Instructions != "You are helping Ada." {
+		t.Errorf("Expected rendered instructions, got %v", req.Instructions)
+	}
+
+	if _, err := WithTemplate("You are helping {{name}}.", nil); err == nil {
+		t.Error("Expected a missing variable to be reported before building the request")
+	}
+}
+
+func TestLocaleVars(t *testing.T) {
+	vars := LocaleVars("pt-BR", map[string]string{"name": "Ana", "language_name": "Brazilian Portuguese"})
+	out, err := Render("{{name}}: {{locale}} {{language}} {{language_name}}", vars)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected := "Ana: pt-BR pt Brazilian Portuguese"; out != expected {
+		t.Errorf("Expected %q, got %q", expected, out)
+	}
+}
+
+func TestLanguageName(t *testing.T) {
+	tests := map[session.Locale]string{
+		"pt-BR":      "Portuguese",
+		"zh-Hant-TW": "Chinese",
+		"fil":        "Filipino",
+		"":           "",
+	}
+	for locale, expected := range tests {
+		if got := LanguageName(locale); got != expected {
+			t.Errorf("%q: expected language name %q, got %q", locale, expected, got)
+		}
+	}
+}
@@ -0,0 +1,284 @@
+// Command msggen generates message type constants, structs and the incoming message
+// registry from the event spec in messages/events.json.
+//
+// It is run through go:generate from the messages package:
+//
+//	go generate ./messages
+//
+// To add a new server event, add an entry to the "incoming" section of the spec.
+// Entries with a "fields" list get a generated struct; entries without one refer to
+// a hand-written struct with the given name.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+// Spec is the top-level event spec
+type Spec struct {
+	// Incoming lists server events grouped by area
+	Incoming []Group `json:"incoming"`
+
+	// Outgoing lists client events grouped by area
+	Outgoing []Group `json:"outgoing"`
+}
+
+// Group is a set of related events that share a constant block
+type Group struct {
+	// Group is the comment placed above the constant block
+	Group string `json:"group"`
+
+	// Events lists the events in the group
+	Events []Event `json:"events"`
+}
+
+// Event describes a single wire event
+type Event struct {
+	// Type is the wire value of the "type" field
+	Type string `json:"type"`
+
+	// Const is the constant name without the RcvdMsgType/OutMsgType prefix
+	Const string `json:"const"`
+
+	// Struct is the Go struct the event decodes into (incoming) or is encoded from (outgoing)
+	Struct string `json:"struct,omitempty"`
+
+	// Doc is the doc comment for a generated struct
+	Doc string `json:"doc,omitempty"`
+
+	// Fields lists the struct fields; a nil list means the struct is hand-written
+	Fields *[]Field `json:"fields,omitempty"`
+}
+
+// Field describes a field of a generated struct
+type Field struct {
+	// Name is the Go field name
+	Name string `json:"name"`
+
+	// Type is the Go type, e.g. "string" or "types.Response"
+	Type string `json:"type"`
+
+	// JSON is the wire name of the field
+	JSON string `json:"json"`
+
+	// OmitEmpty adds omitempty to the JSON tag
+	OmitEmpty bool `json:"omitempty,omitempty"`
+
+	// Doc is the field comment
+	Doc string `json:"doc,omitempty"`
+}
+
+// importPaths maps package qualifiers usable in field types to their import paths
+var importPaths = map[string]string{
+	"types":   "github.com/Mliviu79/openai-realtime-go/messages/types",
+	"session": "github.com/Mliviu79/openai-realtime-go/session",
+	"apierrs": "github.com/Mliviu79/openai-realtime-go/apierrs",
+	"json":    "encoding/json",
+}
+
+func main() {
+	specPath := flag.String("spec", "events.json", "path to the event spec")
+	incomingOut := flag.String("incoming", "incoming/zz_generated.go", "output file for the incoming package")
+	outgoingOut := flag.String("outgoing", "outgoing/zz_generated.go", "output file for the outgoing package")
+	flag.Parse()
+
+	if err := run(*specPath, *incomingOut, *outgoingOut); err != nil {
+		fmt.Fprintf(os.Stderr, "msggen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// run loads the spec and writes both generated files
+func run(specPath, incomingOut, outgoingOut string) error {
+	spec, err := loadSpec(specPath)
+	if err != nil {
+		return err
+	}
+
+	incoming, err := Generate(spec, "incoming")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(incomingOut, incoming, 0o644); err != nil {
+		return err
+	}
+
+	outgoing, err := Generate(spec, "outgoing")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(outgoingOut, outgoing, 0o644)
+}
+
+// loadSpec reads and validates the event spec
+func loadSpec(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spec: %w", err)
+	}
+
+	var spec Spec
+	if err := json.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse spec: %w", err)
+	}
+	if err := spec.validate(); err != nil {
+		return nil, err
+	}
+	return &spec, nil
+}
+
+// validate checks the spec for missing names and duplicate events
+func (s *Spec) validate() error {
+	for direction, groups := range map[string][]Group{"incoming": s.Incoming, "outgoing": s.Outgoing} {
+		seenTypes := make(map[string]bool)
+		seenConsts := make(map[string]bool)
+		for _, group := range groups {
+			for _, event := range group.Events {
+				switch {
+				case event.Type == "" || event.Const == "":
+					return fmt.Errorf("%s event in group %q is missing a type or const", direction, group.Group)
+				case direction == "incoming" && event.Struct == "":
+					return fmt.Errorf("incoming event %s is missing a struct name", event.Type)
+				case event.Fields != nil && event.Struct == "":
+					return fmt.Errorf("%s event %s has fields but no struct name", direction, event.Type)
+				case seenTypes[event.Type]:
+					return fmt.Errorf("duplicate %s event type %s", direction, event.Type)
+				case seenConsts[event.Const]:
+					return fmt.Errorf("duplicate %s const %s", direction, event.Const)
+				}
+				seenTypes[event.Type] = true
+				seenConsts[event.Const] = true
+			}
+		}
+	}
+	return nil
+}
+
+// templateData is passed to the file template
+type templateData struct {
+	Package    string
+	TypeName   string
+	BaseName   string
+	Groups     []Group
+	Imports    []string
+	Generated  []Event
+	IsIncoming bool
+}
+
+// Generate renders the generated file for the "incoming" or "outgoing" package
+func Generate(spec *Spec, pkg string) ([]byte, error) {
+	data := templateData{Package: pkg, IsIncoming: pkg == "incoming"}
+	switch pkg {
+	case "incoming":
+		data.TypeName, data.BaseName, data.Groups = "RcvdMsgType", "RcvdMsgBase", spec.Incoming
+	case "outgoing":
+		data.TypeName, data.BaseName, data.Groups = "OutMsgType", "OutMsgBase", spec.Outgoing
+	default:
+		return nil, fmt.Errorf("unknown package %q", pkg)
+	}
+
+	imports := make(map[string]bool)
+	for _, group := range data.Groups {
+		for _, event := range group.Events {
+			if event.Fields == nil {
+				continue
+			}
+			data.Generated = append(data.Generated, event)
+			for _, field := range *event.Fields {
+				if qualifier, _, ok := strings.Cut(strings.TrimLeft(field.Type, "*[]"), "."); ok {
+					path, known := importPaths[qualifier]
+					if !known {
+						return nil, fmt.Errorf("event %s field %s uses unknown package %q", event.Type, field.Name, qualifier)
+					}
+					imports[path] = true
+				}
+			}
+		}
+	}
+	for path := range imports {
+		data.Imports = append(data.Imports, path)
+	}
+	sort.Strings(data.Imports)
+
+	var buf bytes.Buffer
+	if err := fileTemplate.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to render %s: %w", pkg, err)
+	}
+
+	formatted, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format %s: %w\n%s", pkg, err, buf.String())
+	}
+	return formatted, nil
+}
+
+var fileTemplate = template.Must(template.New("file").Funcs(template.FuncMap{
+	"tag": func(f Field) string {
+		if f.OmitEmpty {
+			return fmt.Sprintf("`json:\"%s,omitempty\"`", f.JSON)
+		}
+		return fmt.Sprintf("`json:\"%s\"`", f.JSON)
+	},
+}).Parse(`// Code generated by msggen from messages/events.json. DO NOT EDIT.
+
+package {{.Package}}
+{{if .Imports}}
+import (
+{{- range .Imports}}
+	"{{.}}"
+{{- end}}
+)
+{{end}}
+//-----------------------------------------------------------------------------
+// Message Type Constants
+//-----------------------------------------------------------------------------
+{{range .Groups}}
+// {{.Group}}
+const (
+{{- range .Events}}
+	{{$.TypeName}}{{.Const}} {{$.TypeName}} = "{{.Type}}"
+{{- end}}
+)
+{{end}}
+{{- range .Generated}}
+// {{if .Doc}}{{.Doc}}{{else}}{{.Struct}} is the {{.Type}} event{{end}}
+type {{.Struct}} struct {
+	{{$.BaseName}}
+{{- range .Fields}}
+{{- if .Doc}}
+	// {{.Doc}}
+{{- end}}
+	{{.Name}} {{.Type}} {{tag .}}
+{{- end}}
+}
+{{end}}
+{{- if .IsIncoming}}
+// knownTypes lists every message type defined in the spec, in spec order
+var knownTypes = []RcvdMsgType{
+{{- range .Groups}}
+{{- range .Events}}
+	RcvdMsgType{{.Const}},
+{{- end}}
+{{- end}}
+}
+
+// MessageTypeRegistry maps message types to factory functions
+var MessageTypeRegistry = map[RcvdMsgType]func() RcvdMsg{
+{{- range .Groups}}
+{{- range .Events}}
+	RcvdMsgType{{.Const}}: func() RcvdMsg {
+		return &{{.Struct}}{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgType{{.Const}}}}
+	},
+{{- end}}
+{{- end}}
+}
+{{- end}}
+`))
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestGeneratedFilesUpToDate(t *testing.T) {
+	spec, err := loadSpec(filepath.Join("..", "..", "..", "messages", "events.json"))
+	if err != nil {
+		t.Fatalf("Failed to load spec: %v", err)
+	}
+
+	for _, pkg := range []string{"incoming", "outgoing"} {
+		generated, err := Generate(spec, pkg)
+		if err != nil {
+			t.Fatalf("Failed to generate %s: %v", pkg, err)
+		}
+
+		committed, err := os.ReadFile(filepath.Join("..", "..", "..", "messages", pkg, "zz_generated.go"))
+		if err != nil {
+			t.Fatalf("Failed to read generated file: %v", err)
+		}
+		if !bytes.Equal(generated, committed) {
+			t.Errorf("messages/%s/zz_generated.go is out of date; run go generate ./messages", pkg)
+		}
+	}
+}
+
+func TestGenerateStruct(t *testing.T) {
+	fields := []Field{
+		{Name: "ItemID", Type: "string", JSON: "item_id", Doc: "ItemID identifies the item"},
+		{Name: "Response", Type: "*types.Response", JSON: "response", OmitEmpty: true},
+	}
+	spec := &Spec{
+		Incoming: []Group{{
+			Group: "Experimental message types",
+			Events: []Event{{
+				Type:   "experimental.event",
+				Const:  "ExperimentalEvent",
+				Struct: "ExperimentalEventMessage",
+				Doc:    "ExperimentalEventMessage is sent for experimental events",
+				Fields: &fields,
+			}},
+		}},
+	}
+
+	out, err := Generate(spec, "incoming")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{
+		`"github.com/Mliviu79/openai-realtime-go/messages/types"`,
+		`RcvdMsgTypeExperimentalEvent RcvdMsgType = "experimental.event"`,
+		"type ExperimentalEventMessage struct {",
+		"// ItemID identifies the item",
+		"ItemID   string          `json:\"item_id\"`",
+		"Response *types.Response `json:\"response,omitempty\"`",
+		"RcvdMsgTypeExperimentalEvent: func() RcvdMsg {",
+	}
+	for _, want := range expected {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("Expected generated code to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestSpecValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		spec Spec
+	}{
+		{
+			name: "DuplicateType",
+			spec: Spec{Outgoing: []Group{{Events: []Event{{Type: "a", Const: "A"}, {Type: "a", Const: "B"}}}}},
+		},
+		{
+			name: "MissingStruct",
+			spec: Spec{Incoming: []Group{{Events: []Event{{Type: "a", Const: "A"}}}}},
+		},
+		{
+			name: "MissingConst",
+			spec: Spec{Outgoing: []Group{{Events: []Event{{Type: "a"}}}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.spec.validate(); err == nil {
+				t.Error("Expected validation error")
+			}
+		})
+	}
+}
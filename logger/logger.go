@@ -4,7 +4,8 @@
 // The package offers:
 //   - A standard Logger interface with common logging methods (Debugf, Infof, Warnf, Errorf)
 //   - Field-based logging for structured logging with key-value pairs
-//   - Multiple implementations including ZeroLogger (using zerolog) and NopLogger (no-op logger)
+//   - Multiple implementations including ZeroLogger (using zerolog), LogrLogger (adapting any
+//     logr.Logger), NopLogger (no-op logger) and TestLogger (records entries for assertions in tests)
 //   - Simple configuration options for controlling log levels, formatting, and output
 //
 // Example usage:
@@ -0,0 +1,54 @@
+package logger
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/go-logr/logr"
+)
+
+// LogrLogger implements Logger on top of a logr.Logger, so the client can log
+// through any logr backend (klog, zapr, funcr, controller-runtime, ...).
+//
+// logr has no warning level; warnings are logged at V(0) with a "level" value of "warn".
+// Debug messages are logged at V(1).
+type LogrLogger struct {
+	log logr.Logger
+}
+
+// NewLogrLogger creates a Logger that writes to the given logr.Logger
+func NewLogrLogger(log logr.Logger) *LogrLogger {
+	return &LogrLogger{log: log}
+}
+
+func (l *LogrLogger) Debugf(format string, v ...any) {
+	l.log.V(1).Info(fmt.Sprintf(format, v...))
+}
+
+func (l *LogrLogger) Infof(format string, v ...any) {
+	l.log.Info(fmt.Sprintf(format, v...))
+}
+
+func (l *LogrLogger) Warnf(format string, v ...any) {
+	l.log.Info(fmt.Sprintf(format, v...), "level", "warn")
+}
+
+func (l *LogrLogger) Errorf(format string, v ...any) {
+	l.log.Error(nil, fmt.Sprintf(format, v...))
+}
+
+// WithField returns a new logger with the field added to the logger's values
+func (l *LogrLogger) WithField(key string, value any) Logger {
+	return &LogrLogger{log: l.log.WithValues(key, value)}
+}
+
+// WithFields returns a new logger with the fields added to the logger's values.
+// Keys are added in sorted order so output is deterministic.
+func (l *LogrLogger) WithFields(fields map[string]any) Logger {
+	keysAndValues := make([]any, 0, len(fields)*2)
+	for _, key := range slices.Sorted(maps.Keys(fields)) {
+		keysAndValues = append(keysAndValues, key, fields[key])
+	}
+	return &LogrLogger{log: l.log.WithValues(keysAndValues...)}
+}
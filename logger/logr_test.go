@@ -0,0 +1,55 @@
+package logger
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr/funcr"
+)
+
+func TestLogrLogger(t *testing.T) {
+	var lines []string
+	sink := funcr.New(func(prefix, args string) {
+		lines = append(lines, args)
+	}, funcr.Options{Verbosity: 1})
+
+	log := NewLogrLogger(sink)
+	log.Debugf("debug %d", 1)
+	log.Infof("info %d", 2)
+	log.Warnf("warn %d", 3)
+	log.Errorf("error %d", 4)
+
+	expected := []string{
+		`"level"=1 "msg"="debug 1"`,
+		`"level"=0 "msg"="info 2"`,
+		`"level"=0 "msg"="warn 3" "level"="warn"`,
+		`"msg"="error 4" "error"=null`,
+	}
+	if len(lines) != len(expected) {
+		t.Fatalf("Expected %d lines, got %d: %v", len(expected), len(lines), lines)
+	}
+	for i := range expected {
+		if lines[i] != expected[i] {
+			t.Errorf("Expected line %d to be %q, got %q", i, expected[i], lines[i])
+		}
+	}
+}
+
+func TestLogrLoggerFields(t *testing.T) {
+	var lines []string
+	sink := funcr.New(func(prefix, args string) {
+		lines = append(lines, args)
+	}, funcr.Options{})
+
+	log := NewLogrLogger(sink).
+		WithField("session_id", "sess_123").
+		WithFields(map[string]any{"b": 2, "a": 1})
+	log.Infof("connected")
+
+	if len(lines) != 1 {
+		t.Fatalf("Expected 1 line, got %d", len(lines))
+	}
+	if !strings.Contains(lines[0], `"session_id"="sess_123" "a"=1 "b"=2`) {
+		t.Errorf("Expected fields in order, got %q", lines[0])
+	}
+}
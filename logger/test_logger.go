@@ -0,0 +1,153 @@
+package logger
+
+import (
+	"fmt"
+	"maps"
+	"strings"
+	"sync"
+)
+
+// Level identifies the severity of an entry recorded by TestLogger
+type Level string
+
+const (
+	LevelDebug Level = "debug"
+	LevelInfo  Level = "info"
+	LevelWarn  Level = "warn"
+	LevelError Level = "error"
+)
+
+// Entry is a log entry recorded by TestLogger
+type Entry struct {
+	Level   Level
+	Message string
+	Fields  map[string]any
+}
+
+// TestLogger is a Logger that records entries in memory so tests can assert on
+// what was logged. Loggers derived with WithField and WithFields record into the
+// same store. It is safe for concurrent use.
+//
+// Example usage:
+//
+//	log := logger.NewTestLogger()
+//	client.SetLogger(log)
+//	// ...
+//	if !log.Contains(logger.LevelWarn, "schema mismatch") {
+//		t.Error("Expected a schema mismatch warning")
+//	}
+type TestLogger struct {
+	store  *testLogStore
+	fields map[string]any
+}
+
+// testLogStore holds the entries shared by a TestLogger and the loggers derived from it
+type testLogStore struct {
+	mu      sync.Mutex
+	entries []Entry
+}
+
+// NewTestLogger creates an empty TestLogger
+func NewTestLogger() *TestLogger {
+	return &TestLogger{
+		store:  &testLogStore{},
+		fields: make(map[string]any),
+	}
+}
+
+func (l *TestLogger) Debugf(format string, v ...any) {
+	l.record(LevelDebug, format, v...)
+}
+
+func (l *TestLogger) Infof(format string, v ...any) {
+	l.record(LevelInfo, format, v...)
+}
+
+func (l *TestLogger) Warnf(format string, v ...any) {
+	l.record(LevelWarn, format, v...)
+}
+
+func (l *TestLogger) Errorf(format string, v ...any) {
+	l.record(LevelError, format, v...)
+}
+
+// WithField returns a logger that records into the same store with the field added
+func (l *TestLogger) WithField(key string, value any) Logger {
+	return l.WithFields(map[string]any{key: value})
+}
+
+// WithFields returns a logger that records into the same store with the fields added
+func (l *TestLogger) WithFields(fields map[string]any) Logger {
+	newLogger := &TestLogger{
+		store:  l.store,
+		fields: make(map[string]any, len(l.fields)+len(fields)),
+	}
+	maps.Copy(newLogger.fields, l.fields)
+	maps.Copy(newLogger.fields, fields)
+	return newLogger
+}
+
+// record appends an entry with a copy of the logger's fields
+func (l *TestLogger) record(level Level, format string, v ...any) {
+	entry := Entry{
+		Level:   level,
+		Message: fmt.Sprintf(format, v...),
+		Fields:  maps.Clone(l.fields),
+	}
+	l.store.mu.Lock()
+	defer l.store.mu.Unlock()
+	l.store.entries = append(l.store.entries, entry)
+}
+
+// Entries returns a copy of all recorded entries in the order they were logged
+func (l *TestLogger) Entries() []Entry {
+	l.store.mu.Lock()
+	defer l.store.mu.Unlock()
+	entries := make([]Entry, len(l.store.entries))
+	copy(entries, l.store.entries)
+	return entries
+}
+
+// EntriesAt returns the recorded entries with the given level
+func (l *TestLogger) EntriesAt(level Level) []Entry {
+	var entries []Entry
+	for _, entry := range l.Entries() {
+		if entry.Level == level {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// Messages returns the messages recorded with the given level
+func (l *TestLogger) Messages(level Level) []string {
+	var messages []string
+	for _, entry := range l.EntriesAt(level) {
+		messages = append(messages, entry.Message)
+	}
+	return messages
+}
+
+// Contains reports whether an entry with the given level contains substr in its message
+func (l *TestLogger) Contains(level Level, substr string) bool {
+	return l.Count(level, substr) > 0
+}
+
+// Count returns the number of entries with the given level whose message contains substr.
+// An empty substr counts all entries at the level.
+func (l *TestLogger) Count(level Level, substr string) int {
+	count := 0
+	for _, entry := range l.EntriesAt(level) {
+		if strings.Contains(entry.Message, substr) {
+			count++
+		}
+	}
+	return count
+}
+
+// Reset discards all recorded entries
+func (l *TestLogger) Reset() {
+	l.store.mu.Lock()
+	defer l.store.mu.Unlock()
+	l.store.entries = nil
+}
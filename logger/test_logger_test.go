@@ -0,0 +1,74 @@
+package logger
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestTestLoggerRecordsEntries(t *testing.T) {
+	log := NewTestLogger()
+	log.Debugf("debug %d", 1)
+	log.Infof("info")
+	log.Warnf("schema mismatch for %s", "session.created")
+	log.Errorf("error")
+
+	entries := log.Entries()
+	if len(entries) != 4 {
+		t.Fatalf("Expected 4 entries, got %d", len(entries))
+	}
+	if entries[0].Level != LevelDebug || entries[0].Message != "debug 1" {
+		t.Errorf("Expected debug entry, got %+v", entries[0])
+	}
+	if !log.Contains(LevelWarn, "schema mismatch") {
+		t.Error("Expected warning to be recorded")
+	}
+	if log.Contains(LevelError, "schema mismatch") {
+		t.Error("Expected no error containing the warning message")
+	}
+	if got := log.Messages(LevelWarn); len(got) != 1 || got[0] != "schema mismatch for session.created" {
+		t.Errorf("Expected warning message, got %v", got)
+	}
+}
+
+func TestTestLoggerFieldsShareStore(t *testing.T) {
+	log := NewTestLogger()
+	child := log.WithField("session_id", "sess_123").WithFields(map[string]any{"attempt": 2})
+	child.Warnf("retrying")
+	log.Warnf("plain")
+
+	entries := log.EntriesAt(LevelWarn)
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 warnings, got %d", len(entries))
+	}
+	if entries[0].Fields["session_id"] != "sess_123" || entries[0].Fields["attempt"] != 2 {
+		t.Errorf("Expected fields on child entry, got %v", entries[0].Fields)
+	}
+	if len(entries[1].Fields) != 0 {
+		t.Errorf("Expected no fields on parent entry, got %v", entries[1].Fields)
+	}
+}
+
+func TestTestLoggerCountAndReset(t *testing.T) {
+	log := NewTestLogger()
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			log.Warnf("dropped frame")
+		}()
+	}
+	wg.Wait()
+
+	if got := log.Count(LevelWarn, "dropped"); got != 10 {
+		t.Errorf("Expected 10 warnings, got %d", got)
+	}
+	if got := log.Count(LevelWarn, ""); got != 10 {
+		t.Errorf("Expected 10 warnings, got %d", got)
+	}
+
+	log.Reset()
+	if got := len(log.Entries()); got != 0 {
+		t.Errorf("Expected no entries after reset, got %d", got)
+	}
+}
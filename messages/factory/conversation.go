@@ -83,3 +83,22 @@ func AssistantTextMessage(text string) types.MessageItem {
 		},
 	)
 }
+
+// ItemReference creates a reference to an existing conversation item, for use in
+// the input of a response.create message
+func ItemReference(id string) types.ConversationItem {
+	return types.ConversationItem{
+		Type: types.MessageItemTypeItemReference,
+		ID:   id,
+	}
+}
+
+// ItemReferences creates references to existing conversation items, in order, for use
+// as the input of a response.create message
+func ItemReferences(ids ...string) []types.ConversationItem {
+	refs := make([]types.ConversationItem, len(ids))
+	for i, id := range ids {
+		refs[i] = ItemReference(id)
+	}
+	return refs
+}
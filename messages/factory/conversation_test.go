@@ -1,6 +1,7 @@
 package factory
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
 
@@ -310,3 +311,44 @@ func TestAssistantTextMessage(t *testing.T) {
 		}
 	}
 }
+
+func TestItemReference(t *testing.T) {
+	item := ItemReference("item_123")
+
+	if item.Type != types.MessageItemTypeItemReference {
+		t.Errorf("ItemReference().Type = %v, want %v", item.Type, types.MessageItemTypeItemReference)
+	}
+
+	if item.ID != "item_123" {
+		t.Errorf("ItemReference().ID = %v, want %v", item.ID, "item_123")
+	}
+
+	// Only the type and ID should be marshaled
+	data, err := json.Marshal(item)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	want := `{"id":"item_123","type":"item_reference"}`
+	if string(data) != want {
+		t.Errorf("json.Marshal(ItemReference()) = %s, want %s", data, want)
+	}
+}
+
+func TestItemReferences(t *testing.T) {
+	config := types.ResponseConfig{
+		Input: ItemReferences("item_1", "item_2"),
+	}
+
+	data, err := json.Marshal(config)
+	if err != nil {
+		t.Fatalf("json.Marshal() error = %v", err)
+	}
+	want := `{"input":[{"id":"item_1","type":"item_reference"},{"id":"item_2","type":"item_reference"}]}`
+	if string(data) != want {
+		t.Errorf("json.Marshal(ResponseConfig) = %s, want %s", data, want)
+	}
+
+	if refs := ItemReferences(); len(refs) != 0 {
+		t.Errorf("ItemReferences() = %v, want empty", refs)
+	}
+}
@@ -0,0 +1,35 @@
+package incoming
+
+// betaTypes maps the names the beta API used for output events to their GA names, so
+// messages from either server decode into the same Go types
+var betaTypes = map[RcvdMsgType]RcvdMsgType{
+	"response.text.delta":             RcvdMsgTypeResponseOutputTextDelta,
+	"response.text.done":              RcvdMsgTypeResponseOutputTextDone,
+	"response.audio.delta":            RcvdMsgTypeResponseOutputAudioDelta,
+	"response.audio.done":             RcvdMsgTypeResponseOutputAudioDone,
+	"response.audio_transcript.delta": RcvdMsgTypeResponseOutputAudioTranscriptDelta,
+	"response.audio_transcript.done":  RcvdMsgTypeResponseOutputAudioTranscriptDone,
+}
+
+// CanonicalType returns the GA name of a beta event type, such as
+// RcvdMsgTypeResponseOutputAudioDelta for "response.audio.delta". Other types are
+// returned unchanged.
+func CanonicalType(msgType RcvdMsgType) RcvdMsgType {
+	if canonical, ok := betaTypes[msgType]; ok {
+		return canonical
+	}
+	return msgType
+}
+
+// setRcvdMsgType replaces the type decoded from the wire, so aliased messages report
+// their GA type
+func (m *RcvdMsgBase) setRcvdMsgType(msgType RcvdMsgType) {
+	m.Type = msgType
+}
+
+// canonicalize sets the GA type on a message decoded from a beta event
+func canonicalize(msg RcvdMsg, msgType RcvdMsgType) {
+	if setter, ok := msg.(interface{ setRcvdMsgType(RcvdMsgType) }); ok && msg.RcvdMsgType() != msgType {
+		setter.setRcvdMsgType(msgType)
+	}
+}
@@ -0,0 +1,106 @@
+package incoming
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestUnmarshalBetaEventNames(t *testing.T) {
+	tests := []struct {
+		name         string
+		json         string
+		expectedType RcvdMsgType
+		check        func(t *testing.T, msg RcvdMsg)
+	}{
+		{
+			name:         "text delta",
+			json:         `{"type":"response.text.delta","response_id":"resp_1","item_id":"item_1","delta":"Hi"}`,
+			expectedType: RcvdMsgTypeResponseOutputTextDelta,
+			check: func(t *testing.T, msg RcvdMsg) {
+				if m, ok := msg.(*ResponseOutputTextDeltaMessage); !ok || m.Delta != "Hi" {
+					t.Errorf("Expected a text delta of %q, got %+v", "Hi", msg)
+				}
+			},
+		},
+		{
+			name:         "text done",
+			json:         `{"type":"response.text.done","response_id":"resp_1","item_id":"item_1","text":"Hi there"}`,
+			expectedType: RcvdMsgTypeResponseOutputTextDone,
+			check: func(t *testing.T, msg RcvdMsg) {
+				if m, ok := msg.(*ResponseOutputTextDoneMessage); !ok || m.Text != "Hi there" {
+					t.Errorf("Expected the text %q, got %+v", "Hi there", msg)
+				}
+			},
+		},
+		{
+			name:         "audio delta",
+			json:         `{"type":"response.audio.delta","response_id":"resp_1","item_id":"item_1","delta":"AAE="}`,
+			expectedType: RcvdMsgTypeResponseOutputAudioDelta,
+			check: func(t *testing.T, msg RcvdMsg) {
+				if m, ok := msg.(*ResponseOutputAudioDeltaMessage); !ok || m.Delta != "AAE=" {
+					t.Errorf("Expected an audio delta, got %+v", msg)
+				}
+			},
+		},
+		{
+			name:         "audio done",
+			json:         `{"type":"response.audio.done","response_id":"resp_1","item_id":"item_1"}`,
+			expectedType: RcvdMsgTypeResponseOutputAudioDone,
+		},
+		{
+			name:         "audio transcript delta",
+			json:         `{"type":"response.audio_transcript.delta","response_id":"resp_1","item_id":"item_1","delta":"Hel"}`,
+			expectedType: RcvdMsgTypeResponseOutputAudioTranscriptDelta,
+			check: func(t *testing.T, msg RcvdMsg) {
+				if m, ok := msg.(*ResponseOutputAudioTranscriptDeltaMessage); !ok || m.Delta != "Hel" {
+					t.Errorf("Expected a transcript delta of %q, got %+v", "Hel", msg)
+				}
+			},
+		},
+		{
+			name:         "audio transcript done",
+			json:         `{"type":"response.audio_transcript.done","response_id":"resp_1","item_id":"item_1","transcript":"Hello"}`,
+			expectedType: RcvdMsgTypeResponseOutputAudioTranscriptDone,
+		},
+		{
+			name:         "GA name",
+			json:         `{"type":"response.output_audio.delta","response_id":"resp_1","item_id":"item_1","delta":"AAE="}`,
+			expectedType: RcvdMsgTypeResponseOutputAudioDelta,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, err := UnmarshalRcvdMsg([]byte(tt.json))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if msg.RcvdMsgType() != tt.expectedType {
+				t.Errorf("Expected message type %q, got %q", tt.expectedType, msg.RcvdMsgType())
+			}
+			if tt.check != nil {
+				tt.check(t, msg)
+			}
+		})
+	}
+}
+
+func TestUnmarshalBetaAudioDeltaPooled(t *testing.T) {
+	msg, err := UnmarshalRcvdMsgPooled([]byte(`{"type":"response.audio.delta","response_id":"resp_1","delta":"AAE="}`), NewAudioPool())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	delta, ok := msg.(*ResponseOutputAudioDeltaMessage)
+	if !ok || delta.RcvdMsgType() != RcvdMsgTypeResponseOutputAudioDelta || !bytes.Equal(delta.Audio, []byte{0, 1}) {
+		t.Errorf("Expected a pooled GA audio delta, got %+v", msg)
+	}
+}
+
+func TestCanonicalType(t *testing.T) {
+	if got := CanonicalType("response.audio.delta"); got != RcvdMsgTypeResponseOutputAudioDelta {
+		t.Errorf("Expected %q, got %q", RcvdMsgTypeResponseOutputAudioDelta, got)
+	}
+	if got := CanonicalType(RcvdMsgTypeResponseDone); got != RcvdMsgTypeResponseDone {
+		t.Errorf("Expected %q, got %q", RcvdMsgTypeResponseDone, got)
+	}
+}
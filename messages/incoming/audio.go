@@ -31,3 +31,38 @@ type AudioBufferSpeechStoppedMessage struct {
 	// ItemID identifies the item this audio buffer belongs to
 	ItemID string `json:"item_id"`
 }
+
+// AudioBufferTimeoutTriggeredMessage is sent when server VAD commits the input audio
+// buffer because the user was silent for longer than the idle timeout
+type AudioBufferTimeoutTriggeredMessage struct {
+	RcvdMsgBase
+	// AudioStartMs and AudioEndMs bound the audio committed by the timeout, in milliseconds
+	AudioStartMs int64 `json:"audio_start_ms"`
+	AudioEndMs   int64 `json:"audio_end_ms"`
+	// ItemID identifies the item created from the committed audio
+	ItemID string `json:"item_id"`
+}
+
+// OutputAudioBufferStartedMessage is sent on WebRTC and SIP connections when the server
+// starts playing a response's audio
+type OutputAudioBufferStartedMessage struct {
+	RcvdMsgBase
+	// ResponseID identifies the response whose audio is playing
+	ResponseID string `json:"response_id"`
+}
+
+// OutputAudioBufferStoppedMessage is sent on WebRTC and SIP connections when the server
+// has played all of a response's audio
+type OutputAudioBufferStoppedMessage struct {
+	RcvdMsgBase
+	// ResponseID identifies the response whose audio finished playing
+	ResponseID string `json:"response_id"`
+}
+
+// OutputAudioBufferClearedMessage is sent on WebRTC and SIP connections when the output
+// audio buffer is cleared, e.g. after an interruption
+type OutputAudioBufferClearedMessage struct {
+	RcvdMsgBase
+	// ResponseID identifies the response whose audio was cleared
+	ResponseID string `json:"response_id"`
+}
@@ -0,0 +1,165 @@
+package incoming
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// AudioPool recycles the buffers that audio deltas are decoded into by
+// UnmarshalRcvdMsgPooled. It is safe for concurrent use.
+type AudioPool struct {
+	pool sync.Pool
+}
+
+// NewAudioPool creates an empty AudioPool
+func NewAudioPool() *AudioPool {
+	return &AudioPool{}
+}
+
+// Get returns a buffer of length n, reusing a released buffer if one is large enough
+func (p *AudioPool) Get(n int) []byte {
+	if buf, ok := p.pool.Get().(*[]byte); ok && cap(*buf) >= n {
+		return (*buf)[:n]
+	}
+	return make([]byte, n)
+}
+
+// Put returns a buffer to the pool. The buffer must not be used afterwards.
+func (p *AudioPool) Put(buf []byte) {
+	if cap(buf) == 0 {
+		return
+	}
+	buf = buf[:0]
+	p.pool.Put(&buf)
+}
+
+// UnmarshalRcvdMsgPooled unmarshals a JSON message like UnmarshalRcvdMsg, except that
+// the audio of response.output_audio.delta is decoded straight from the frame into a
+// buffer from pool, without first being copied into the Delta string. The decoded
+// audio is in the message's Audio field and Delta is left empty; call Release once
+// the audio is no longer used to return the buffer to the pool.
+//
+// A nil pool decodes exactly like UnmarshalRcvdMsg.
+func UnmarshalRcvdMsgPooled(data []byte, pool *AudioPool) (RcvdMsg, error) {
+	if pool == nil {
+		return UnmarshalRcvdMsg(data)
+	}
+
+	var base struct {
+		Type RcvdMsgType `json:"type"`
+	}
+	if err := json.Unmarshal(data, &base); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal message base: %w", err)
+	}
+	msgType := base.Type
+	if !IsRegistered(msgType) {
+		msgType = CanonicalType(msgType)
+	}
+	if msgType != RcvdMsgTypeResponseOutputAudioDelta {
+		return UnmarshalRcvdMsg(data)
+	}
+
+	// The delta field shadows the message's own, so the base64 text is handed to
+	// pooledAudio as a slice of data rather than allocated as a string
+	msg := &ResponseOutputAudioDeltaMessage{}
+	audio := &pooledAudio{pool: pool}
+	fields := struct {
+		*ResponseOutputAudioDeltaMessage
+		Delta *pooledAudio `json:"delta"`
+	}{msg, audio}
+	if err := json.Unmarshal(data, &fields); err != nil {
+		audio.release()
+		return nil, fmt.Errorf("failed to unmarshal message of type %s: %w", base.Type, err)
+	}
+
+	canonicalize(msg, RcvdMsgTypeResponseOutputAudioDelta)
+	msg.Audio = audio.buf
+	msg.pool = pool
+	return msg, nil
+}
+
+// pooledAudio decodes a base64 JSON string into a buffer from pool
+type pooledAudio struct {
+	pool *AudioPool
+	buf  []byte
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (a *pooledAudio) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	if len(data) < 2 || data[0] != '"' || data[len(data)-1] != '"' {
+		return fmt.Errorf("audio delta is not a string")
+	}
+
+	encoded := data[1 : len(data)-1]
+	for _, c := range encoded {
+		if c == '\\' {
+			// Escaped characters, such as \/, are rare enough to take the slow path
+			var s string
+			if err := json.Unmarshal(data, &s); err != nil {
+				return err
+			}
+			encoded = []byte(s)
+			break
+		}
+	}
+
+	buf := a.pool.Get(base64.StdEncoding.DecodedLen(len(encoded)))
+	n, err := base64.StdEncoding.Decode(buf, encoded)
+	if err != nil {
+		a.pool.Put(buf)
+		return fmt.Errorf("invalid audio delta: %w", err)
+	}
+	a.buf = buf[:n]
+	return nil
+}
+
+// release returns the buffer to the pool, if one was taken
+func (a *pooledAudio) release() {
+	if a.buf != nil {
+		a.pool.Put(a.buf)
+		a.buf = nil
+	}
+}
+
+// AudioLen returns the number of bytes of decoded audio in the delta
+func (m *ResponseOutputAudioDeltaMessage) AudioLen() int {
+	if m.Audio != nil {
+		return len(m.Audio)
+	}
+	return base64.StdEncoding.DecodedLen(len(m.Delta)) - strings.Count(m.Delta[max(0, len(m.Delta)-2):], "=")
+}
+
+// DecodeAudio returns the decoded audio of the delta. For a pooled message it is
+// the pooled buffer itself, which must be copied to be kept past Release.
+func (m *ResponseOutputAudioDeltaMessage) DecodeAudio() ([]byte, error) {
+	if m.Audio != nil {
+		return m.Audio, nil
+	}
+	return base64.StdEncoding.DecodeString(m.Delta)
+}
+
+// EncodedAudio returns the base64-encoded audio of the delta, encoding the pooled
+// buffer if Delta is empty
+func (m *ResponseOutputAudioDeltaMessage) EncodedAudio() string {
+	if m.Audio != nil {
+		return base64.StdEncoding.EncodeToString(m.Audio)
+	}
+	return m.Delta
+}
+
+// Release returns the audio of a message decoded by UnmarshalRcvdMsgPooled to its
+// pool. It does nothing for messages decoded without a pool and is safe to call more
+// than once.
+func (m *ResponseOutputAudioDeltaMessage) Release() {
+	if m.pool != nil && m.Audio != nil {
+		m.pool.Put(m.Audio)
+	}
+	m.Audio = nil
+	m.pool = nil
+}
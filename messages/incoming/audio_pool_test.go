@@ -0,0 +1,152 @@
+package incoming
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+)
+
+func TestUnmarshalRcvdMsgPooled(t *testing.T) {
+	audio := []byte{0x00, 0x01, 0xfe, 0xff, 0x10, 0x20, 0x30}
+	encoded := base64.StdEncoding.EncodeToString(audio)
+
+	tests := []struct {
+		name          string
+		json          string
+		expectedAudio []byte
+		wantErr       bool
+	}{
+		{
+			name:          "audio delta",
+			json:          `{"type":"response.output_audio.delta","event_id":"evt_1","response_id":"resp_1","item_id":"item_1","output_index":1,"content_index":2,"delta":"` + encoded + `"}`,
+			expectedAudio: audio,
+		},
+		{
+			name:          "escaped delta",
+			json:          `{"type":"response.output_audio.delta","response_id":"resp_1","item_id":"item_1","delta":"` + "AAH\\/" + `"}`,
+			expectedAudio: []byte{0x00, 0x01, 0xff},
+		},
+		{
+			name:          "empty delta",
+			json:          `{"type":"response.output_audio.delta","response_id":"resp_1","item_id":"item_1","delta":""}`,
+			expectedAudio: []byte{},
+		},
+		{
+			name:    "invalid base64",
+			json:    `{"type":"response.output_audio.delta","response_id":"resp_1","item_id":"item_1","delta":"not base64!"}`,
+			wantErr: true,
+		},
+		{
+			name:    "delta is not a string",
+			json:    `{"type":"response.output_audio.delta","response_id":"resp_1","item_id":"item_1","delta":12}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, err := UnmarshalRcvdMsgPooled([]byte(tt.json), NewAudioPool())
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Expected error %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			delta, ok := msg.(*ResponseOutputAudioDeltaMessage)
+			if !ok {
+				t.Fatalf("Expected *ResponseOutputAudioDeltaMessage, got %T", msg)
+			}
+			if delta.RcvdMsgType() != RcvdMsgTypeResponseOutputAudioDelta || delta.ResponseID != "resp_1" || delta.ItemID != "item_1" {
+				t.Errorf("Expected the message fields to be decoded, got %+v", delta)
+			}
+			if delta.Delta != "" {
+				t.Errorf("Expected Delta to be empty, got %q", delta.Delta)
+			}
+			if !bytes.Equal(delta.Audio, tt.expectedAudio) {
+				t.Errorf("Expected audio %v, got %v", tt.expectedAudio, delta.Audio)
+			}
+			if delta.AudioLen() != len(tt.expectedAudio) {
+				t.Errorf("Expected audio length %d, got %d", len(tt.expectedAudio), delta.AudioLen())
+			}
+			if got := delta.EncodedAudio(); got != base64.StdEncoding.EncodeToString(tt.expectedAudio) {
+				t.Errorf("Expected encoded audio %q, got %q", base64.StdEncoding.EncodeToString(tt.expectedAudio), got)
+			}
+		})
+	}
+}
+
+func TestUnmarshalRcvdMsgPooledFields(t *testing.T) {
+	data := []byte(`{"type":"response.output_audio.delta","event_id":"evt_1","response_id":"resp_1","item_id":"item_1","output_index":1,"content_index":2,"delta":"AAE="}`)
+
+	plain, err := UnmarshalRcvdMsg(data)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	pooled, err := UnmarshalRcvdMsgPooled(data, NewAudioPool())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := *plain.(*ResponseOutputAudioDeltaMessage)
+	got := *pooled.(*ResponseOutputAudioDeltaMessage)
+	audio, _ := expected.DecodeAudio()
+	if !bytes.Equal(got.Audio, audio) {
+		t.Errorf("Expected audio %v, got %v", audio, got.Audio)
+	}
+	expected.Delta = ""
+	if got.RcvdMsgBase != expected.RcvdMsgBase || got.ResponseID != expected.ResponseID || got.ItemID != expected.ItemID ||
+		got.OutputIndex != expected.OutputIndex || got.ContentIndex != expected.ContentIndex {
+		t.Errorf("Expected %+v, got %+v", expected, got)
+	}
+}
+
+func TestUnmarshalRcvdMsgPooledOtherTypes(t *testing.T) {
+	data := []byte(`{"type":"response.output_text.delta","response_id":"resp_1","item_id":"item_1","delta":"Hello"}`)
+
+	msg, err := UnmarshalRcvdMsgPooled(data, NewAudioPool())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if text, ok := msg.(*ResponseOutputTextDeltaMessage); !ok || text.Delta != "Hello" {
+		t.Errorf("Expected the text delta to decode as usual, got %+v", msg)
+	}
+
+	// Without a pool the audio stays encoded
+	msg, err = UnmarshalRcvdMsgPooled([]byte(`{"type":"response.output_audio.delta","delta":"AAE="}`), nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if delta := msg.(*ResponseOutputAudioDeltaMessage); delta.Delta != "AAE=" || delta.Audio != nil {
+		t.Errorf("Expected the encoded delta, got %+v", delta)
+	}
+}
+
+func TestAudioPoolRelease(t *testing.T) {
+	pool := NewAudioPool()
+	msg, err := UnmarshalRcvdMsgPooled([]byte(`{"type":"response.output_audio.delta","delta":"AAECAw=="}`), pool)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	delta := msg.(*ResponseOutputAudioDeltaMessage)
+
+	delta.Release()
+	if delta.Audio != nil {
+		t.Errorf("Expected the audio to be cleared, got %v", delta.Audio)
+	}
+	// Releasing twice is harmless
+	delta.Release()
+
+	if buf := pool.Get(2); len(buf) != 2 {
+		t.Errorf("Expected a buffer of length 2, got %d", len(buf))
+	}
+}
+
+func TestAudioLen(t *testing.T) {
+	for n := 0; n < 8; n++ {
+		msg := &ResponseOutputAudioDeltaMessage{Delta: base64.StdEncoding.EncodeToString(make([]byte, n))}
+		if got := msg.AudioLen(); got != n {
+			t.Errorf("Expected %d bytes, got %d", n, got)
+		}
+	}
+}
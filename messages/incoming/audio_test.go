@@ -241,3 +241,77 @@ func TestAudioBufferSpeechStoppedMessage(t *testing.T) {
 		t.Errorf("Expected item_id to be %q, got %v", "msg_003", unmarshaled["item_id"])
 	}
 }
+
+func TestAudioBufferTimeoutTriggeredMessage(t *testing.T) {
+	jsonData := []byte(`{
+		"event_id": "event_1720",
+		"type": "input_audio_buffer.timeout_triggered",
+		"audio_start_ms": 2000,
+		"audio_end_ms": 8000,
+		"item_id": "msg_004"
+	}`)
+
+	msg, err := UnmarshalRcvdMsg(jsonData)
+	if err != nil {
+		t.Fatalf("Failed to unmarshal input_audio_buffer.timeout_triggered message: %v", err)
+	}
+
+	timeoutMsg, ok := msg.(*AudioBufferTimeoutTriggeredMessage)
+	if !ok {
+		t.Fatalf("Failed to cast message to AudioBufferTimeoutTriggeredMessage, got %T", msg)
+	}
+
+	if timeoutMsg.AudioStartMs != 2000 || timeoutMsg.AudioEndMs != 8000 {
+		t.Errorf("Expected audio 2000-8000 ms, got %d-%d", timeoutMsg.AudioStartMs, timeoutMsg.AudioEndMs)
+	}
+
+	if timeoutMsg.ItemID != "msg_004" {
+		t.Errorf("Expected ItemID to be %q, got %q", "msg_004", timeoutMsg.ItemID)
+	}
+}
+
+func TestOutputAudioBufferMessages(t *testing.T) {
+	tests := []struct {
+		msgType RcvdMsgType
+		check   func(RcvdMsg) (string, bool)
+	}{
+		{RcvdMsgTypeOutputAudioBufferStarted, func(m RcvdMsg) (string, bool) {
+			msg, ok := m.(*OutputAudioBufferStartedMessage)
+			if !ok {
+				return "", false
+			}
+			return msg.ResponseID, true
+		}},
+		{RcvdMsgTypeOutputAudioBufferStopped, func(m RcvdMsg) (string, bool) {
+			msg, ok := m.(*OutputAudioBufferStoppedMessage)
+			if !ok {
+				return "", false
+			}
+			return msg.ResponseID, true
+		}},
+		{RcvdMsgTypeOutputAudioBufferCleared, func(m RcvdMsg) (string, bool) {
+			msg, ok := m.(*OutputAudioBufferClearedMessage)
+			if !ok {
+				return "", false
+			}
+			return msg.ResponseID, true
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.msgType), func(t *testing.T) {
+			jsonData := []byte(`{"event_id":"event_1721","type":"` + string(tt.msgType) + `","response_id":"resp_001"}`)
+			msg, err := UnmarshalRcvdMsg(jsonData)
+			if err != nil {
+				t.Fatalf("Failed to unmarshal %s message: %v", tt.msgType, err)
+			}
+			responseID, ok := tt.check(msg)
+			if !ok {
+				t.Fatalf("Unexpected message type %T", msg)
+			}
+			if responseID != "resp_001" {
+				t.Errorf("Expected ResponseID to be %q, got %q", "resp_001", responseID)
+			}
+		})
+	}
+}
@@ -20,6 +20,14 @@ type ConversationItemCreatedMessage struct {
 	Item types.ResponseMessageItem `json:"item"`
 }
 
+// ConversationItemRetrievedMessage is sent in reply to conversation.item.retrieve with
+// the full item, including its audio
+type ConversationItemRetrievedMessage struct {
+	RcvdMsgBase
+	// Item contains the details of the retrieved conversation item
+	Item types.ResponseMessageItem `json:"item"`
+}
+
 // ConversationItemTranscriptionCompletedMessage is sent when audio transcription completes
 type ConversationItemTranscriptionCompletedMessage struct {
 	RcvdMsgBase
@@ -81,3 +89,41 @@ type ConversationItemTranscriptionDeltaMessage struct {
 	// Delta contains the incremental text transcribed from audio
 	Delta string `json:"delta"`
 }
+
+// ConversationItemAddedMessage is sent when an item is added to the conversation, before
+// its content is complete
+type ConversationItemAddedMessage struct {
+	RcvdMsgBase
+	// PreviousItemID references the item that comes before this one, if any
+	PreviousItemID string `json:"previous_item_id,omitempty"`
+	// Item contains the details of the added conversation item
+	Item types.ResponseMessageItem `json:"item"`
+}
+
+// ConversationItemDoneMessage is sent when an item of the conversation is complete
+type ConversationItemDoneMessage struct {
+	RcvdMsgBase
+	// PreviousItemID references the item that comes before this one, if any
+	PreviousItemID string `json:"previous_item_id,omitempty"`
+	// Item contains the details of the completed conversation item
+	Item types.ResponseMessageItem `json:"item"`
+}
+
+// ConversationItemTranscriptionSegmentMessage is sent with a diarized segment of input
+// audio transcription
+type ConversationItemTranscriptionSegmentMessage struct {
+	RcvdMsgBase
+	// ItemID identifies the conversation item this transcription belongs to
+	ItemID string `json:"item_id"`
+	// ContentIndex specifies which content part within the item was transcribed
+	ContentIndex int `json:"content_index"`
+	// ID identifies the segment
+	ID string `json:"id"`
+	// Text is the transcript of the segment
+	Text string `json:"text"`
+	// Speaker labels the speaker of the segment
+	Speaker string `json:"speaker"`
+	// Start and End are the offsets of the segment within the audio, in seconds
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+}
@@ -514,3 +514,65 @@ func TestConversationItemDeletedMessage(t *testing.T) {
 		t.Errorf("Expected item_id to be %q, got %v", "msg_005", unmarshaled["item_id"])
 	}
 }
+
+func TestConversationItemAddedAndDoneMessages(t *testing.T) {
+	added, err := UnmarshalRcvdMsg([]byte(`{
+		"event_id": "event_1922",
+		"type": "conversation.item.added",
+		"previous_item_id": "msg_001",
+		"item": {"id": "msg_002", "object": "realtime.item", "type": "message", "status": "in_progress", "role": "assistant", "content": []}
+	}`))
+	if err != nil {
+		t.Fatalf("Failed to unmarshal conversation.item.added message: %v", err)
+	}
+	addedMsg, ok := added.(*ConversationItemAddedMessage)
+	if !ok {
+		t.Fatalf("Failed to cast message to ConversationItemAddedMessage, got %T", added)
+	}
+	if addedMsg.PreviousItemID != "msg_001" || addedMsg.Item.ID != "msg_002" {
+		t.Errorf("Unexpected added item: previous %q, item %q", addedMsg.PreviousItemID, addedMsg.Item.ID)
+	}
+
+	done, err := UnmarshalRcvdMsg([]byte(`{
+		"event_id": "event_1923",
+		"type": "conversation.item.done",
+		"item": {"id": "msg_002", "object": "realtime.item", "type": "message", "status": "completed", "role": "assistant", "content": [{"type": "output_text", "text": "Hi there"}]}
+	}`))
+	if err != nil {
+		t.Fatalf("Failed to unmarshal conversation.item.done message: %v", err)
+	}
+	doneMsg, ok := done.(*ConversationItemDoneMessage)
+	if !ok {
+		t.Fatalf("Failed to cast message to ConversationItemDoneMessage, got %T", done)
+	}
+	if doneMsg.Item.ID != "msg_002" || len(doneMsg.Item.Content) != 1 {
+		t.Errorf("Unexpected done item: %+v", doneMsg.Item)
+	}
+}
+
+func TestConversationItemTranscriptionSegmentMessage(t *testing.T) {
+	msg, err := UnmarshalRcvdMsg([]byte(`{
+		"event_id": "event_1924",
+		"type": "conversation.item.input_audio_transcription.segment",
+		"item_id": "msg_003",
+		"content_index": 0,
+		"id": "seg_001",
+		"text": "Hello there",
+		"speaker": "A",
+		"start": 0.4,
+		"end": 1.8
+	}`))
+	if err != nil {
+		t.Fatalf("Failed to unmarshal transcription segment message: %v", err)
+	}
+	segmentMsg, ok := msg.(*ConversationItemTranscriptionSegmentMessage)
+	if !ok {
+		t.Fatalf("Failed to cast message to ConversationItemTranscriptionSegmentMessage, got %T", msg)
+	}
+	if segmentMsg.ItemID != "msg_003" || segmentMsg.ID != "seg_001" || segmentMsg.Text != "Hello there" {
+		t.Errorf("Unexpected segment: %+v", segmentMsg)
+	}
+	if segmentMsg.Speaker != "A" || segmentMsg.Start != 0.4 || segmentMsg.End != 1.8 {
+		t.Errorf("Unexpected segment speaker or timing: %+v", segmentMsg)
+	}
+}
@@ -0,0 +1,59 @@
+package incoming
+
+import (
+	"github.com/Mliviu79/openai-realtime-go/messages/types"
+)
+
+// DropInlineAudio clears the base64 audio of the content parts in msg whose audio is
+// longer than maxBytes, keeping their transcripts, and returns the number of bytes
+// dropped. It bounds the memory held by long, audio-heavy conversations; the audio
+// of a conversation item can be fetched again with conversation.item.retrieve.
+//
+// It applies to conversation.item.created, response.output_item.added and .done,
+// response.content_part.added and .done, and response.done. Retrieved items are left
+// intact, as are all messages when maxBytes is zero or negative.
+func DropInlineAudio(msg RcvdMsg, maxBytes int) int {
+	if maxBytes <= 0 {
+		return 0
+	}
+
+	switch m := msg.(type) {
+	case *ConversationItemCreatedMessage:
+		return dropContentAudio(m.Item.Content, maxBytes)
+	case *ResponseOutputItemAddedMessage:
+		return dropContentAudio(m.Item.Content, maxBytes)
+	case *ResponseOutputItemDoneMessage:
+		return dropContentAudio(m.Item.Content, maxBytes)
+	case *ResponseContentPartAddedMessage:
+		return dropPartAudio(&m.Part, maxBytes)
+	case *ResponseContentPartDoneMessage:
+		return dropPartAudio(&m.Part, maxBytes)
+	case *ResponseDoneMessage:
+		dropped := 0
+		for i := range m.Response.Output {
+			dropped += dropContentAudio(m.Response.Output[i].Content, maxBytes)
+		}
+		return dropped
+	default:
+		return 0
+	}
+}
+
+// dropContentAudio drops the audio of every part in content longer than maxBytes
+func dropContentAudio(content []types.MessageContentPart, maxBytes int) int {
+	dropped := 0
+	for i := range content {
+		dropped += dropPartAudio(&content[i], maxBytes)
+	}
+	return dropped
+}
+
+// dropPartAudio drops the audio of part if it is longer than maxBytes
+func dropPartAudio(part *types.MessageContentPart, maxBytes int) int {
+	size := len(part.Audio)
+	if size <= maxBytes {
+		return 0
+	}
+	part.Audio = ""
+	return size
+}
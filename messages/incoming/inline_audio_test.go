@@ -0,0 +1,70 @@
+package incoming
+
+import (
+	"testing"
+)
+
+func TestDropInlineAudio(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		maxBytes int
+		dropped  int
+	}{
+		{
+			name:     "ItemCreated",
+			data:     `{"type":"conversation.item.created","item":{"id":"item_1","type":"message","role":"user","content":[{"type":"input_audio","audio":"AAAAAAAA","transcript":"Hi"},{"type":"input_audio","audio":"BBBB"}]}}`,
+			maxBytes: 4,
+			dropped:  8,
+		},
+		{
+			name:     "ResponseDone",
+			data:     `{"type":"response.done","response":{"id":"resp_1","status":"completed","output":[{"id":"item_2","type":"message","content":[{"type":"audio","audio":"AAAAAAAA"}]}]}}`,
+			maxBytes: 4,
+			dropped:  8,
+		},
+		{
+			name:     "ContentPartDone",
+			data:     `{"type":"response.content_part.done","response_id":"resp_1","item_id":"item_2","output_index":0,"content_index":0,"part":{"type":"audio","audio":"AAAAAAAA"}}`,
+			maxBytes: 4,
+			dropped:  8,
+		},
+		{
+			name:     "Retrieved",
+			data:     `{"type":"conversation.item.retrieved","item":{"id":"item_1","type":"message","content":[{"type":"input_audio","audio":"AAAAAAAA"}]}}`,
+			maxBytes: 4,
+		},
+		{
+			name: "Disabled",
+			data: `{"type":"conversation.item.created","item":{"id":"item_1","type":"message","content":[{"type":"input_audio","audio":"AAAAAAAA"}]}}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, err := UnmarshalRcvdMsg([]byte(tt.data))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got := DropInlineAudio(msg, tt.maxBytes); got != tt.dropped {
+				t.Errorf("Expected %d bytes dropped, got %d", tt.dropped, got)
+			}
+		})
+	}
+}
+
+func TestDropInlineAudioKeepsTranscript(t *testing.T) {
+	msg, err := UnmarshalRcvdMsg([]byte(`{"type":"conversation.item.created","item":{"id":"item_1","type":"message","role":"user","content":[{"type":"input_audio","audio":"AAAAAAAA","transcript":"Hi"},{"type":"input_audio","audio":"BBBB"}]}}`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	DropInlineAudio(msg, 4)
+
+	content := msg.(*ConversationItemCreatedMessage).Item.Content
+	if content[0].Audio != "" || content[0].Transcript != "Hi" {
+		t.Errorf("Expected the audio to be dropped and the transcript kept, got %+v", content[0])
+	}
+	if content[1].Audio != "BBBB" {
+		t.Errorf("Expected audio within the limit to be kept, got %+v", content[1])
+	}
+}
@@ -1,125 +1,97 @@
 package incoming
 
-// This file contains a registry of message types and factory functions
-// for creating new instances of each message type.
+import (
+	"fmt"
+	"slices"
+	"sync"
+)
 
-// MessageTypeRegistry maps message types to factory functions
-var MessageTypeRegistry = map[RcvdMsgType]func() RcvdMsg{
-	// Error message
-	RcvdMsgTypeError: func() RcvdMsg { return &ErrorMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeError}} },
+// MessageTypeRegistry, which maps message types to factory functions, is generated
+// from messages/events.json into zz_generated.go. Use Register to add message types
+// at runtime rather than modifying the map directly.
 
-	// Session-related messages
-	RcvdMsgTypeSessionCreated: func() RcvdMsg {
-		return &SessionCreatedMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeSessionCreated}}
-	},
-	RcvdMsgTypeSessionUpdated: func() RcvdMsg {
-		return &SessionUpdatedMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeSessionUpdated}}
-	},
+// registryMu guards MessageTypeRegistry against concurrent registration and lookup
+var registryMu sync.RWMutex
 
-	// Transcription session-related messages
-	RcvdMsgTypeTranscriptionSessionCreated: func() RcvdMsg {
-		return &TranscriptionSessionCreatedMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeTranscriptionSessionCreated}}
-	},
-	RcvdMsgTypeTranscriptionSessionUpdated: func() RcvdMsg {
-		return &TranscriptionSessionUpdatedMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeTranscriptionSessionUpdated}}
-	},
-	RcvdMsgTypeInputAudioTranscription: func() RcvdMsg {
-		return &InputAudioTranscriptionMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeInputAudioTranscription}}
-	},
-	RcvdMsgTypeTranscriptionDone: func() RcvdMsg {
-		return &TranscriptionDoneMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeTranscriptionDone}}
-	},
-
-	// Conversation-related messages
-	RcvdMsgTypeConversationCreated: func() RcvdMsg {
-		return &ConversationCreatedMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeConversationCreated}}
-	},
-	RcvdMsgTypeConversationItemCreated: func() RcvdMsg {
-		return &ConversationItemCreatedMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeConversationItemCreated}}
-	},
-	RcvdMsgTypeConversationItemInputAudioTranscriptionCompleted: func() RcvdMsg {
-		return &ConversationItemTranscriptionCompletedMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeConversationItemInputAudioTranscriptionCompleted}}
-	},
-	RcvdMsgTypeConversationItemInputAudioTranscriptionDelta: func() RcvdMsg {
-		return &ConversationItemTranscriptionDeltaMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeConversationItemInputAudioTranscriptionDelta}}
-	},
-	RcvdMsgTypeConversationItemInputAudioTranscriptionFailed: func() RcvdMsg {
-		return &ConversationItemTranscriptionFailedMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeConversationItemInputAudioTranscriptionFailed}}
-	},
-	RcvdMsgTypeConversationItemTruncated: func() RcvdMsg {
-		return &ConversationItemTruncatedMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeConversationItemTruncated}}
-	},
-	RcvdMsgTypeConversationItemDeleted: func() RcvdMsg {
-		return &ConversationItemDeletedMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeConversationItemDeleted}}
-	},
+// Register adds a message type to the registry so UnmarshalRcvdMsg decodes it into the
+// struct returned by factory instead of failing with an unknown message type error.
+// This allows experimental or private-preview events to be decoded into user-defined
+// structs, which typically embed RcvdMsgBase:
+//
+//	type ExperimentalMessage struct {
+//		incoming.RcvdMsgBase
+//		Value int `json:"value"`
+//	}
+//
+//	err := incoming.Register("experimental.event", func() incoming.RcvdMsg {
+//		return &ExperimentalMessage{}
+//	})
+//
+// Register returns an error if the type is empty, the factory is nil, or the type is
+// already registered. Custom types are not included in AllTypes.
+func Register(msgType RcvdMsgType, factory func() RcvdMsg) error {
+	if msgType == "" {
+		return fmt.Errorf("message type cannot be empty")
+	}
+	if factory == nil {
+		return fmt.Errorf("factory for message type %s cannot be nil", msgType)
+	}
 
-	// Audio buffer-related messages
-	RcvdMsgTypeAudioBufferCommitted: func() RcvdMsg {
-		return &AudioBufferCommittedMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeAudioBufferCommitted}}
-	},
-	RcvdMsgTypeAudioBufferCleared: func() RcvdMsg {
-		return &AudioBufferClearedMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeAudioBufferCleared}}
-	},
-	RcvdMsgTypeAudioBufferSpeechStarted: func() RcvdMsg {
-		return &AudioBufferSpeechStartedMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeAudioBufferSpeechStarted}}
-	},
-	RcvdMsgTypeAudioBufferSpeechStopped: func() RcvdMsg {
-		return &AudioBufferSpeechStoppedMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeAudioBufferSpeechStopped}}
-	},
+	registryMu.Lock()
+	defer registryMu.Unlock()
 
-	// Response-related messages
-	RcvdMsgTypeResponseCreated: func() RcvdMsg {
-		return &ResponseCreatedMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeResponseCreated}}
-	},
-	RcvdMsgTypeResponseDone: func() RcvdMsg { return &ResponseDoneMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeResponseDone}} },
-	RcvdMsgTypeResponseContentPartAdded: func() RcvdMsg {
-		return &ResponseContentPartAddedMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeResponseContentPartAdded}}
-	},
-	RcvdMsgTypeResponseContentPartDone: func() RcvdMsg {
-		return &ResponseContentPartDoneMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeResponseContentPartDone}}
-	},
-	RcvdMsgTypeResponseOutputTextDelta: func() RcvdMsg {
-		return &ResponseOutputTextDeltaMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeResponseOutputTextDelta}}
-	},
-	RcvdMsgTypeResponseOutputTextDone: func() RcvdMsg {
-		return &ResponseOutputTextDoneMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeResponseOutputTextDone}}
-	},
-	RcvdMsgTypeResponseOutputItemAdded: func() RcvdMsg {
-		return &ResponseOutputItemAddedMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeResponseOutputItemAdded}}
-	},
-	RcvdMsgTypeResponseOutputItemDone: func() RcvdMsg {
-		return &ResponseOutputItemDoneMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeResponseOutputItemDone}}
-	},
-	RcvdMsgTypeResponseOutputAudioTranscriptDelta: func() RcvdMsg {
-		return &ResponseOutputAudioTranscriptDeltaMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeResponseOutputAudioTranscriptDelta}}
-	},
-	RcvdMsgTypeResponseOutputAudioTranscriptDone: func() RcvdMsg {
-		return &ResponseOutputAudioTranscriptDoneMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeResponseOutputAudioTranscriptDone}}
-	},
-	RcvdMsgTypeResponseOutputAudioDelta: func() RcvdMsg {
-		return &ResponseOutputAudioDeltaMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeResponseOutputAudioDelta}}
-	},
-	RcvdMsgTypeResponseOutputAudioDone: func() RcvdMsg {
-		return &ResponseOutputAudioDoneMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeResponseOutputAudioDone}}
-	},
-	RcvdMsgTypeResponseFunctionCallArgumentsDelta: func() RcvdMsg {
-		return &ResponseFunctionCallArgumentsDeltaMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeResponseFunctionCallArgumentsDelta}}
-	},
-	RcvdMsgTypeResponseFunctionCallArgumentsDone: func() RcvdMsg {
-		return &ResponseFunctionCallArgumentsDoneMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeResponseFunctionCallArgumentsDone}}
-	},
+	if _, exists := MessageTypeRegistry[msgType]; exists {
+		return fmt.Errorf("message type %s is already registered", msgType)
+	}
+	MessageTypeRegistry[msgType] = factory
+	return nil
+}
 
-	// Rate limit-related messages
-	RcvdMsgTypeRateLimitsUpdated: func() RcvdMsg {
-		return &RateLimitsUpdatedMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeRateLimitsUpdated}}
-	},
+// IsRegistered reports whether a message type can be decoded
+func IsRegistered(msgType RcvdMsgType) bool {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+	_, exists := MessageTypeRegistry[msgType]
+	return exists
 }
 
 // CreateMessage creates a new instance of the specified message type
 func CreateMessage(msgType RcvdMsgType) (RcvdMsg, bool) {
+	registryMu.RLock()
 	factory, exists := MessageTypeRegistry[msgType]
+	registryMu.RUnlock()
 	if !exists {
 		return nil, false
 	}
 	return factory(), true
 }
+
+// AllTypes returns every message type known to this package, in a stable order.
+// It is useful for building dispatch tables and for checking that a handler covers
+// every message type; see UnhandledTypes.
+func AllTypes() []RcvdMsgType {
+	return slices.Clone(knownTypes)
+}
+
+// UnhandledTypes calls handle with an empty message of every known type and returns
+// the types for which handle reported false. It lets tests verify that a type switch
+// or dispatcher covers every message type:
+//
+//	missing := incoming.UnhandledTypes(func(msg incoming.RcvdMsg) bool {
+//		switch msg.(type) {
+//		case *incoming.SessionCreatedMessage, *incoming.ErrorMessage:
+//			return true
+//		default:
+//			return false
+//		}
+//	})
+func UnhandledTypes(handle func(RcvdMsg) bool) []RcvdMsgType {
+	var unhandled []RcvdMsgType
+	for _, msgType := range knownTypes {
+		msg, _ := CreateMessage(msgType)
+		if !handle(msg) {
+			unhandled = append(unhandled, msgType)
+		}
+	}
+	return unhandled
+}
@@ -58,12 +58,22 @@ func TestMessageTypeRegistry(t *testing.T) {
 		RcvdMsgTypeConversationItemInputAudioTranscriptionFailed,
 		RcvdMsgTypeConversationItemTruncated,
 		RcvdMsgTypeConversationItemDeleted,
+		RcvdMsgTypeConversationItemRetrieved,
+		RcvdMsgTypeConversationItemAdded,
+		RcvdMsgTypeConversationItemDone,
+		RcvdMsgTypeConversationItemInputAudioTranscriptionSegment,
 
 		// Audio buffer-related message types
 		RcvdMsgTypeAudioBufferCommitted,
 		RcvdMsgTypeAudioBufferCleared,
 		RcvdMsgTypeAudioBufferSpeechStarted,
 		RcvdMsgTypeAudioBufferSpeechStopped,
+		RcvdMsgTypeAudioBufferTimeoutTriggered,
+
+		// Output audio buffer-related message types
+		RcvdMsgTypeOutputAudioBufferStarted,
+		RcvdMsgTypeOutputAudioBufferStopped,
+		RcvdMsgTypeOutputAudioBufferCleared,
 
 		// Response-related message types
 		RcvdMsgTypeResponseCreated,
@@ -96,3 +106,89 @@ func TestMessageTypeRegistry(t *testing.T) {
 		t.Errorf("MessageTypeRegistry has %d types, but expected %d types", len(MessageTypeRegistry), len(expectedTypes))
 	}
 }
+
+func TestAllTypes(t *testing.T) {
+	all := AllTypes()
+	if len(all) != len(MessageTypeRegistry) {
+		t.Errorf("Expected %d types, got %d", len(MessageTypeRegistry), len(all))
+	}
+	for _, msgType := range all {
+		if _, exists := MessageTypeRegistry[msgType]; !exists {
+			t.Errorf("Expected %s to be registered", msgType)
+		}
+	}
+
+	// Modifying the returned slice must not affect later calls
+	all[0] = "modified"
+	if AllTypes()[0] == "modified" {
+		t.Error("Expected AllTypes to return a copy")
+	}
+}
+
+func TestUnhandledTypes(t *testing.T) {
+	unhandled := UnhandledTypes(func(msg RcvdMsg) bool {
+		switch msg.(type) {
+		case *ErrorMessage:
+			return false
+		default:
+			return true
+		}
+	})
+
+	if len(unhandled) != 1 || unhandled[0] != RcvdMsgTypeError {
+		t.Errorf("Expected only error to be unhandled, got %v", unhandled)
+	}
+}
+
+// experimentalMessage is a user-defined message type used to test registration
+type experimentalMessage struct {
+	RcvdMsgBase
+	Value int `json:"value"`
+}
+
+func TestRegister(t *testing.T) {
+	const msgType RcvdMsgType = "experimental.event"
+	t.Cleanup(func() {
+		registryMu.Lock()
+		delete(MessageTypeRegistry, msgType)
+		registryMu.Unlock()
+	})
+
+	if _, err := UnmarshalRcvdMsg([]byte(`{"type":"experimental.event","value":42}`)); err == nil {
+		t.Fatal("Expected unknown message type error before registration")
+	}
+
+	err := Register(msgType, func() RcvdMsg { return &experimentalMessage{} })
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !IsRegistered(msgType) {
+		t.Error("Expected type to be registered")
+	}
+
+	msg, err := UnmarshalRcvdMsg([]byte(`{"type":"experimental.event","value":42}`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	custom, ok := msg.(*experimentalMessage)
+	if !ok {
+		t.Fatalf("Expected *experimentalMessage, got %T", msg)
+	}
+	if custom.Value != 42 || custom.RcvdMsgType() != msgType {
+		t.Errorf("Expected value 42 and type %s, got %d and %s", msgType, custom.Value, custom.RcvdMsgType())
+	}
+}
+
+func TestRegisterErrors(t *testing.T) {
+	factory := func() RcvdMsg { return &experimentalMessage{} }
+
+	if err := Register(RcvdMsgTypeError, factory); err == nil {
+		t.Error("Expected error when registering a built-in type")
+	}
+	if err := Register("", factory); err == nil {
+		t.Error("Expected error for empty type")
+	}
+	if err := Register("another.event", nil); err == nil {
+		t.Error("Expected error for nil factory")
+	}
+}
@@ -143,6 +143,12 @@ type ResponseOutputAudioDeltaMessage struct {
 	ContentIndex int `json:"content_index"`
 	// Delta contains the new audio data fragment as base64-encoded string
 	Delta string `json:"delta"`
+	// Audio contains the decoded audio data when the message was decoded by
+	// UnmarshalRcvdMsgPooled, in which case Delta is empty
+	Audio []byte `json:"-"`
+
+	// pool is the pool Audio was taken from, if any
+	pool *AudioPool
 }
 
 // ResponseOutputAudioDoneMessage is sent when audio generation is completed
@@ -5,17 +5,9 @@ import (
 )
 
 //-----------------------------------------------------------------------------
-// Transcription Message Types and Constants
+// Transcription Message Types
 //-----------------------------------------------------------------------------
 
-// Transcription-related message types
-const (
-	RcvdMsgTypeTranscriptionSessionCreated RcvdMsgType = "transcription_session.created"
-	RcvdMsgTypeTranscriptionSessionUpdated RcvdMsgType = "transcription_session.updated"
-	RcvdMsgTypeInputAudioTranscription     RcvdMsgType = "input_audio.transcription"
-	RcvdMsgTypeTranscriptionDone           RcvdMsgType = "transcription.done"
-)
-
 // LogProbItem represents a single token and its associated log probability
 type LogProbItem struct {
 	// Token is the text representation of the token
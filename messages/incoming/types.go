@@ -3,67 +3,14 @@
 // The package handles deserialization and structured access to these messages to simplify API interaction.
 package incoming
 
-import "github.com/Mliviu79/openai-realtime-go/apierrs"
+import (
+	"time"
 
-// RcvdMsgType represents the type of message received from the server
-type RcvdMsgType string
-
-//-----------------------------------------------------------------------------
-// Message Type Constants
-//-----------------------------------------------------------------------------
-
-// Error message type
-const (
-	RcvdMsgTypeError RcvdMsgType = "error"
+	"github.com/Mliviu79/openai-realtime-go/apierrs"
 )
 
-// Session-related message types
-const (
-	RcvdMsgTypeSessionCreated RcvdMsgType = "session.created"
-	RcvdMsgTypeSessionUpdated RcvdMsgType = "session.updated"
-)
-
-// Conversation-related message types
-const (
-	RcvdMsgTypeConversationCreated                              RcvdMsgType = "conversation.created"
-	RcvdMsgTypeConversationItemCreated                          RcvdMsgType = "conversation.item.created"
-	RcvdMsgTypeConversationItemInputAudioTranscriptionCompleted RcvdMsgType = "conversation.item.input_audio_transcription.completed"
-	RcvdMsgTypeConversationItemInputAudioTranscriptionDelta     RcvdMsgType = "conversation.item.input_audio_transcription.delta"
-	RcvdMsgTypeConversationItemInputAudioTranscriptionFailed    RcvdMsgType = "conversation.item.input_audio_transcription.failed"
-	RcvdMsgTypeConversationItemTruncated                        RcvdMsgType = "conversation.item.truncated"
-	RcvdMsgTypeConversationItemDeleted                          RcvdMsgType = "conversation.item.deleted"
-)
-
-// Audio buffer-related message types
-const (
-	RcvdMsgTypeAudioBufferCommitted     RcvdMsgType = "input_audio_buffer.committed"
-	RcvdMsgTypeAudioBufferCleared       RcvdMsgType = "input_audio_buffer.cleared"
-	RcvdMsgTypeAudioBufferSpeechStarted RcvdMsgType = "input_audio_buffer.speech_started"
-	RcvdMsgTypeAudioBufferSpeechStopped RcvdMsgType = "input_audio_buffer.speech_stopped"
-)
-
-// Response-related message types
-const (
-	RcvdMsgTypeResponseCreated                    RcvdMsgType = "response.created"
-	RcvdMsgTypeResponseDone                       RcvdMsgType = "response.done"
-	RcvdMsgTypeResponseContentPartAdded           RcvdMsgType = "response.content_part.added"
-	RcvdMsgTypeResponseContentPartDone            RcvdMsgType = "response.content_part.done"
-	RcvdMsgTypeResponseOutputTextDelta            RcvdMsgType = "response.output_text.delta"
-	RcvdMsgTypeResponseOutputTextDone             RcvdMsgType = "response.output_text.done"
-	RcvdMsgTypeResponseOutputItemAdded            RcvdMsgType = "response.output_item.added"
-	RcvdMsgTypeResponseOutputItemDone             RcvdMsgType = "response.output_item.done"
-	RcvdMsgTypeResponseOutputAudioTranscriptDelta RcvdMsgType = "response.output_audio_transcript.delta"
-	RcvdMsgTypeResponseOutputAudioTranscriptDone  RcvdMsgType = "response.output_audio_transcript.done"
-	RcvdMsgTypeResponseOutputAudioDelta           RcvdMsgType = "response.output_audio.delta"
-	RcvdMsgTypeResponseOutputAudioDone            RcvdMsgType = "response.output_audio.done"
-	RcvdMsgTypeResponseFunctionCallArgumentsDelta RcvdMsgType = "response.function_call_arguments.delta"
-	RcvdMsgTypeResponseFunctionCallArgumentsDone  RcvdMsgType = "response.function_call_arguments.done"
-)
-
-// Rate limit-related message types
-const (
-	RcvdMsgTypeRateLimitsUpdated RcvdMsgType = "rate_limits.updated"
-)
+// RcvdMsgType represents the type of message received from the server
+type RcvdMsgType string
 
 // RcvdMsg is the interface implemented by all received message types
 type RcvdMsg interface {
@@ -79,6 +26,9 @@ type RcvdMsgBase struct {
 	EventID string `json:"event_id,omitempty"`
 	// Type indicates the specific type of this message
 	Type RcvdMsgType `json:"type"`
+
+	// receivedAt is when the message was received; it is not part of the wire format
+	receivedAt time.Time
 }
 
 // RcvdMsgType returns the type of the message
@@ -86,6 +36,36 @@ func (m RcvdMsgBase) RcvdMsgType() RcvdMsgType {
 	return m.Type
 }
 
+// ReceivedAt returns when the message was received, or the zero time if it was not
+// stamped. Times taken from the system clock carry both a wall clock and a monotonic
+// reading, so the difference between two receive times is not affected by clock changes.
+func (m RcvdMsgBase) ReceivedAt() time.Time {
+	return m.receivedAt
+}
+
+// SetReceivedAt records when the message was received
+func (m *RcvdMsgBase) SetReceivedAt(t time.Time) {
+	m.receivedAt = t
+}
+
+// Timestamped is implemented by received messages that record when they were received.
+// Every message type that embeds RcvdMsgBase implements it through a pointer.
+type Timestamped interface {
+	ReceivedAt() time.Time
+	SetReceivedAt(t time.Time)
+}
+
+// ReceivedAt returns when msg was received. The second return value is false if msg
+// does not record it or was not stamped, e.g. because it was decoded directly with
+// UnmarshalRcvdMsg rather than read through a messaging client.
+func ReceivedAt(msg RcvdMsg) (time.Time, bool) {
+	stamped, ok := msg.(Timestamped)
+	if !ok || stamped.ReceivedAt().IsZero() {
+		return time.Time{}, false
+	}
+	return stamped.ReceivedAt(), true
+}
+
 // String returns the string representation of the message type
 func (t RcvdMsgType) String() string {
 	return string(t)
@@ -111,3 +91,21 @@ type ErrorMessage struct {
 	// Error contains detailed information about what went wrong
 	Error ErrorInfo `json:"error"`
 }
+
+// AsAPIError converts the server error into an *apierrs.APIError so it can be
+// returned as a Go error and classified with the apierrs helpers
+func (m *ErrorMessage) AsAPIError() *apierrs.APIError {
+	return &apierrs.APIError{
+		Response: apierrs.ErrorResponse{
+			EventID: m.EventID,
+			Type:    string(m.Type),
+			Error: apierrs.ErrorDetails{
+				Type:    m.Error.Type,
+				Code:    m.Error.Code,
+				Message: m.Error.Message,
+				Param:   m.Error.Param,
+				EventID: m.Error.EventID,
+			},
+		},
+	}
+}
@@ -2,6 +2,7 @@ package incoming
 
 import (
 	"testing"
+	"time"
 )
 
 func TestRcvdMsgBaseType(t *testing.T) {
@@ -83,3 +84,22 @@ func TestMessageTypeConsistency(t *testing.T) {
 		}
 	}
 }
+
+func TestReceivedAt(t *testing.T) {
+	msg, err := UnmarshalRcvdMsg([]byte(`{"type":"response.created","response":{"id":"resp_1"}}`))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, ok := ReceivedAt(msg); ok {
+		t.Error("Expected a decoded message not to be stamped")
+	}
+
+	at := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	msg.(Timestamped).SetReceivedAt(at)
+	if got, ok := ReceivedAt(msg); !ok || !got.Equal(at) {
+		t.Errorf("Expected the receive time %v, got %v", at, got)
+	}
+	if got := msg.(*ResponseCreatedMessage).ReceivedAt(); !got.Equal(at) {
+		t.Errorf("Expected the message to report the receive time %v, got %v", at, got)
+	}
+}
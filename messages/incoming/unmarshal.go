@@ -26,9 +26,14 @@ func UnmarshalRcvdMsg(data []byte) (RcvdMsg, error) {
 		return errMsg, nil
 	}
 
-	// Use the registry to create the appropriate message type
+	// Use the registry to create the appropriate message type. Beta names of output
+	// events decode into the types of their GA equivalents unless registered themselves.
 	msgType := RcvdMsgType(base.Type)
 	msg, exists := CreateMessage(msgType)
+	if !exists && CanonicalType(msgType) != msgType {
+		msgType = CanonicalType(msgType)
+		msg, exists = CreateMessage(msgType)
+	}
 	if !exists {
 		// For unknown message types, try to unmarshal as an error message as a fallback
 		// This is for backward compatibility
@@ -43,6 +48,33 @@ func UnmarshalRcvdMsg(data []byte) (RcvdMsg, error) {
 	if err := json.Unmarshal(data, msg); err != nil {
 		return nil, fmt.Errorf("failed to unmarshal message of type %s: %w", base.Type, err)
 	}
+	canonicalize(msg, msgType)
 
 	return msg, nil
 }
+
+// As returns msg as the concrete message type T, reporting whether the conversion succeeded.
+// It replaces the type-assert-and-check pattern:
+//
+//	if done, ok := incoming.As[*incoming.ResponseDoneMessage](msg); ok {
+//		// use done
+//	}
+func As[T RcvdMsg](msg RcvdMsg) (T, bool) {
+	typed, ok := msg.(T)
+	return typed, ok
+}
+
+// UnmarshalAs unmarshals a JSON message and returns it as the concrete message type T.
+// It returns an error if the message cannot be unmarshaled or is of a different type.
+func UnmarshalAs[T RcvdMsg](data []byte) (T, error) {
+	var zero T
+	msg, err := UnmarshalRcvdMsg(data)
+	if err != nil {
+		return zero, err
+	}
+	typed, ok := As[T](msg)
+	if !ok {
+		return zero, fmt.Errorf("unexpected message type %s: expected %T", msg.RcvdMsgType(), zero)
+	}
+	return typed, nil
+}
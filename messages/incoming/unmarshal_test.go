@@ -1,6 +1,7 @@
 package incoming
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -104,3 +105,79 @@ func TestUnmarshalRcvdMsg(t *testing.T) {
 		})
 	}
 }
+
+func TestAs(t *testing.T) {
+	msg, err := UnmarshalRcvdMsg([]byte(`{"type":"response.done","response":{"id":"resp_1","status":"completed"}}`))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	done, ok := As[*ResponseDoneMessage](msg)
+	if !ok {
+		t.Fatal("Expected conversion to *ResponseDoneMessage to succeed")
+	}
+	if done.Response.ID != "resp_1" {
+		t.Errorf("Expected response ID %q, got %q", "resp_1", done.Response.ID)
+	}
+
+	created, ok := As[*ResponseCreatedMessage](msg)
+	if ok {
+		t.Error("Expected conversion to *ResponseCreatedMessage to fail")
+	}
+	if created != nil {
+		t.Errorf("Expected nil message, got %v", created)
+	}
+
+	if _, ok := As[*ResponseDoneMessage](nil); ok {
+		t.Error("Expected conversion of nil message to fail")
+	}
+}
+
+func TestUnmarshalAs(t *testing.T) {
+	tests := []struct {
+		name    string
+		json    string
+		wantErr string
+	}{
+		{
+			name: "matching type",
+			json: `{"type":"session.created","session":{"id":"sess_1"}}`,
+		},
+		{
+			name:    "different type",
+			json:    `{"type":"conversation.created","conversation":{"id":"conv_1"}}`,
+			wantErr: "unexpected message type conversation.created",
+		},
+		{
+			name:    "invalid json",
+			json:    `{`,
+			wantErr: "failed to unmarshal message base",
+		},
+		{
+			name:    "unknown type",
+			json:    `{"type":"unknown.event"}`,
+			wantErr: "unknown message type",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msg, err := UnmarshalAs[*SessionCreatedMessage]([]byte(tt.json))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+				}
+				if msg != nil {
+					t.Errorf("Expected nil message, got %v", msg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if msg.Session.ID != "sess_1" {
+				t.Errorf("Expected session ID %q, got %q", "sess_1", msg.Session.ID)
+			}
+		})
+	}
+}
@@ -0,0 +1,252 @@
+// Code generated by msggen from messages/events.json. DO NOT EDIT.
+
+package incoming
+
+//-----------------------------------------------------------------------------
+// Message Type Constants
+//-----------------------------------------------------------------------------
+
+// Error message type
+const (
+	RcvdMsgTypeError RcvdMsgType = "error"
+)
+
+// Session-related message types
+const (
+	RcvdMsgTypeSessionCreated RcvdMsgType = "session.created"
+	RcvdMsgTypeSessionUpdated RcvdMsgType = "session.updated"
+)
+
+// Transcription-related message types
+const (
+	RcvdMsgTypeTranscriptionSessionCreated RcvdMsgType = "transcription_session.created"
+	RcvdMsgTypeTranscriptionSessionUpdated RcvdMsgType = "transcription_session.updated"
+	RcvdMsgTypeInputAudioTranscription     RcvdMsgType = "input_audio.transcription"
+	RcvdMsgTypeTranscriptionDone           RcvdMsgType = "transcription.done"
+)
+
+// Conversation-related message types
+const (
+	RcvdMsgTypeConversationCreated                              RcvdMsgType = "conversation.created"
+	RcvdMsgTypeConversationItemCreated                          RcvdMsgType = "conversation.item.created"
+	RcvdMsgTypeConversationItemInputAudioTranscriptionCompleted RcvdMsgType = "conversation.item.input_audio_transcription.completed"
+	RcvdMsgTypeConversationItemInputAudioTranscriptionDelta     RcvdMsgType = "conversation.item.input_audio_transcription.delta"
+	RcvdMsgTypeConversationItemInputAudioTranscriptionFailed    RcvdMsgType = "conversation.item.input_audio_transcription.failed"
+	RcvdMsgTypeConversationItemTruncated                        RcvdMsgType = "conversation.item.truncated"
+	RcvdMsgTypeConversationItemDeleted                          RcvdMsgType = "conversation.item.deleted"
+	RcvdMsgTypeConversationItemRetrieved                        RcvdMsgType = "conversation.item.retrieved"
+	RcvdMsgTypeConversationItemAdded                            RcvdMsgType = "conversation.item.added"
+	RcvdMsgTypeConversationItemDone                             RcvdMsgType = "conversation.item.done"
+	RcvdMsgTypeConversationItemInputAudioTranscriptionSegment   RcvdMsgType = "conversation.item.input_audio_transcription.segment"
+)
+
+// Audio buffer-related message types
+const (
+	RcvdMsgTypeAudioBufferCommitted        RcvdMsgType = "input_audio_buffer.committed"
+	RcvdMsgTypeAudioBufferCleared          RcvdMsgType = "input_audio_buffer.cleared"
+	RcvdMsgTypeAudioBufferSpeechStarted    RcvdMsgType = "input_audio_buffer.speech_started"
+	RcvdMsgTypeAudioBufferSpeechStopped    RcvdMsgType = "input_audio_buffer.speech_stopped"
+	RcvdMsgTypeAudioBufferTimeoutTriggered RcvdMsgType = "input_audio_buffer.timeout_triggered"
+)
+
+// Output audio buffer-related message types
+const (
+	RcvdMsgTypeOutputAudioBufferStarted RcvdMsgType = "output_audio_buffer.started"
+	RcvdMsgTypeOutputAudioBufferStopped RcvdMsgType = "output_audio_buffer.stopped"
+	RcvdMsgTypeOutputAudioBufferCleared RcvdMsgType = "output_audio_buffer.cleared"
+)
+
+// Response-related message types
+const (
+	RcvdMsgTypeResponseCreated                    RcvdMsgType = "response.created"
+	RcvdMsgTypeResponseDone                       RcvdMsgType = "response.done"
+	RcvdMsgTypeResponseContentPartAdded           RcvdMsgType = "response.content_part.added"
+	RcvdMsgTypeResponseContentPartDone            RcvdMsgType = "response.content_part.done"
+	RcvdMsgTypeResponseOutputTextDelta            RcvdMsgType = "response.output_text.delta"
+	RcvdMsgTypeResponseOutputTextDone             RcvdMsgType = "response.output_text.done"
+	RcvdMsgTypeResponseOutputItemAdded            RcvdMsgType = "response.output_item.added"
+	RcvdMsgTypeResponseOutputItemDone             RcvdMsgType = "response.output_item.done"
+	RcvdMsgTypeResponseOutputAudioTranscriptDelta RcvdMsgType = "response.output_audio_transcript.delta"
+	RcvdMsgTypeResponseOutputAudioTranscriptDone  RcvdMsgType = "response.output_audio_transcript.done"
+	RcvdMsgTypeResponseOutputAudioDelta           RcvdMsgType = "response.output_audio.delta"
+	RcvdMsgTypeResponseOutputAudioDone            RcvdMsgType = "response.output_audio.done"
+	RcvdMsgTypeResponseFunctionCallArgumentsDelta RcvdMsgType = "response.function_call_arguments.delta"
+	RcvdMsgTypeResponseFunctionCallArgumentsDone  RcvdMsgType = "response.function_call_arguments.done"
+)
+
+// Rate limit-related message types
+const (
+	RcvdMsgTypeRateLimitsUpdated RcvdMsgType = "rate_limits.updated"
+)
+
+// knownTypes lists every message type defined in the spec, in spec order
+var knownTypes = []RcvdMsgType{
+	RcvdMsgTypeError,
+	RcvdMsgTypeSessionCreated,
+	RcvdMsgTypeSessionUpdated,
+	RcvdMsgTypeTranscriptionSessionCreated,
+	RcvdMsgTypeTranscriptionSessionUpdated,
+	RcvdMsgTypeInputAudioTranscription,
+	RcvdMsgTypeTranscriptionDone,
+	RcvdMsgTypeConversationCreated,
+	RcvdMsgTypeConversationItemCreated,
+	RcvdMsgTypeConversationItemInputAudioTranscriptionCompleted,
+	RcvdMsgTypeConversationItemInputAudioTranscriptionDelta,
+	RcvdMsgTypeConversationItemInputAudioTranscriptionFailed,
+	RcvdMsgTypeConversationItemTruncated,
+	RcvdMsgTypeConversationItemDeleted,
+	RcvdMsgTypeConversationItemRetrieved,
+	RcvdMsgTypeConversationItemAdded,
+	RcvdMsgTypeConversationItemDone,
+	RcvdMsgTypeConversationItemInputAudioTranscriptionSegment,
+	RcvdMsgTypeAudioBufferCommitted,
+	RcvdMsgTypeAudioBufferCleared,
+	RcvdMsgTypeAudioBufferSpeechStarted,
+	RcvdMsgTypeAudioBufferSpeechStopped,
+	RcvdMsgTypeAudioBufferTimeoutTriggered,
+	RcvdMsgTypeOutputAudioBufferStarted,
+	RcvdMsgTypeOutputAudioBufferStopped,
+	RcvdMsgTypeOutputAudioBufferCleared,
+	RcvdMsgTypeResponseCreated,
+	RcvdMsgTypeResponseDone,
+	RcvdMsgTypeResponseContentPartAdded,
+	RcvdMsgTypeResponseContentPartDone,
+	RcvdMsgTypeResponseOutputTextDelta,
+	RcvdMsgTypeResponseOutputTextDone,
+	RcvdMsgTypeResponseOutputItemAdded,
+	RcvdMsgTypeResponseOutputItemDone,
+	RcvdMsgTypeResponseOutputAudioTranscriptDelta,
+	RcvdMsgTypeResponseOutputAudioTranscriptDone,
+	RcvdMsgTypeResponseOutputAudioDelta,
+	RcvdMsgTypeResponseOutputAudioDone,
+	RcvdMsgTypeResponseFunctionCallArgumentsDelta,
+	RcvdMsgTypeResponseFunctionCallArgumentsDone,
+	RcvdMsgTypeRateLimitsUpdated,
+}
+
+// MessageTypeRegistry maps message types to factory functions
+var MessageTypeRegistry = map[RcvdMsgType]func() RcvdMsg{
+	RcvdMsgTypeError: func() RcvdMsg {
+		return &ErrorMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeError}}
+	},
+	RcvdMsgTypeSessionCreated: func() RcvdMsg {
+		return &SessionCreatedMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeSessionCreated}}
+	},
+	RcvdMsgTypeSessionUpdated: func() RcvdMsg {
+		return &SessionUpdatedMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeSessionUpdated}}
+	},
+	RcvdMsgTypeTranscriptionSessionCreated: func() RcvdMsg {
+		return &TranscriptionSessionCreatedMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeTranscriptionSessionCreated}}
+	},
+	RcvdMsgTypeTranscriptionSessionUpdated: func() RcvdMsg {
+		return &TranscriptionSessionUpdatedMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeTranscriptionSessionUpdated}}
+	},
+	RcvdMsgTypeInputAudioTranscription: func() RcvdMsg {
+		return &InputAudioTranscriptionMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeInputAudioTranscription}}
+	},
+	RcvdMsgTypeTranscriptionDone: func() RcvdMsg {
+		return &TranscriptionDoneMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeTranscriptionDone}}
+	},
+	RcvdMsgTypeConversationCreated: func() RcvdMsg {
+		return &ConversationCreatedMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeConversationCreated}}
+	},
+	RcvdMsgTypeConversationItemCreated: func() RcvdMsg {
+		return &ConversationItemCreatedMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeConversationItemCreated}}
+	},
+	RcvdMsgTypeConversationItemInputAudioTranscriptionCompleted: func() RcvdMsg {
+		return &ConversationItemTranscriptionCompletedMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeConversationItemInputAudioTranscriptionCompleted}}
+	},
+	RcvdMsgTypeConversationItemInputAudioTranscriptionDelta: func() RcvdMsg {
+		return &ConversationItemTranscriptionDeltaMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeConversationItemInputAudioTranscriptionDelta}}
+	},
+	RcvdMsgTypeConversationItemInputAudioTranscriptionFailed: func() RcvdMsg {
+		return &ConversationItemTranscriptionFailedMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeConversationItemInputAudioTranscriptionFailed}}
+	},
+	RcvdMsgTypeConversationItemTruncated: func() RcvdMsg {
+		return &ConversationItemTruncatedMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeConversationItemTruncated}}
+	},
+	RcvdMsgTypeConversationItemDeleted: func() RcvdMsg {
+		return &ConversationItemDeletedMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeConversationItemDeleted}}
+	},
+	RcvdMsgTypeConversationItemRetrieved: func() RcvdMsg {
+		return &ConversationItemRetrievedMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeConversationItemRetrieved}}
+	},
+	RcvdMsgTypeConversationItemAdded: func() RcvdMsg {
+		return &ConversationItemAddedMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeConversationItemAdded}}
+	},
+	RcvdMsgTypeConversationItemDone: func() RcvdMsg {
+		return &ConversationItemDoneMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeConversationItemDone}}
+	},
+	RcvdMsgTypeConversationItemInputAudioTranscriptionSegment: func() RcvdMsg {
+		return &ConversationItemTranscriptionSegmentMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeConversationItemInputAudioTranscriptionSegment}}
+	},
+	RcvdMsgTypeAudioBufferCommitted: func() RcvdMsg {
+		return &AudioBufferCommittedMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeAudioBufferCommitted}}
+	},
+	RcvdMsgTypeAudioBufferCleared: func() RcvdMsg {
+		return &AudioBufferClearedMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeAudioBufferCleared}}
+	},
+	RcvdMsgTypeAudioBufferSpeechStarted: func() RcvdMsg {
+		return &AudioBufferSpeechStartedMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeAudioBufferSpeechStarted}}
+	},
+	RcvdMsgTypeAudioBufferSpeechStopped: func() RcvdMsg {
+		return &AudioBufferSpeechStoppedMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeAudioBufferSpeechStopped}}
+	},
+	RcvdMsgTypeAudioBufferTimeoutTriggered: func() RcvdMsg {
+		return &AudioBufferTimeoutTriggeredMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeAudioBufferTimeoutTriggered}}
+	},
+	RcvdMsgTypeOutputAudioBufferStarted: func() RcvdMsg {
+		return &OutputAudioBufferStartedMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeOutputAudioBufferStarted}}
+	},
+	RcvdMsgTypeOutputAudioBufferStopped: func() RcvdMsg {
+		return &OutputAudioBufferStoppedMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeOutputAudioBufferStopped}}
+	},
+	RcvdMsgTypeOutputAudioBufferCleared: func() RcvdMsg {
+		return &OutputAudioBufferClearedMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeOutputAudioBufferCleared}}
+	},
+	RcvdMsgTypeResponseCreated: func() RcvdMsg {
+		return &ResponseCreatedMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeResponseCreated}}
+	},
+	RcvdMsgTypeResponseDone: func() RcvdMsg {
+		return &ResponseDoneMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeResponseDone}}
+	},
+	RcvdMsgTypeResponseContentPartAdded: func() RcvdMsg {
+		return &ResponseContentPartAddedMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeResponseContentPartAdded}}
+	},
+	RcvdMsgTypeResponseContentPartDone: func() RcvdMsg {
+		return &ResponseContentPartDoneMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeResponseContentPartDone}}
+	},
+	RcvdMsgTypeResponseOutputTextDelta: func() RcvdMsg {
+		return &ResponseOutputTextDeltaMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeResponseOutputTextDelta}}
+	},
+	RcvdMsgTypeResponseOutputTextDone: func() RcvdMsg {
+		return &ResponseOutputTextDoneMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeResponseOutputTextDone}}
+	},
+	RcvdMsgTypeResponseOutputItemAdded: func() RcvdMsg {
+		return &ResponseOutputItemAddedMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeResponseOutputItemAdded}}
+	},
+	RcvdMsgTypeResponseOutputItemDone: func() RcvdMsg {
+		return &ResponseOutputItemDoneMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeResponseOutputItemDone}}
+	},
+	RcvdMsgTypeResponseOutputAudioTranscriptDelta: func() RcvdMsg {
+		return &ResponseOutputAudioTranscriptDeltaMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeResponseOutputAudioTranscriptDelta}}
+	},
+	RcvdMsgTypeResponseOutputAudioTranscriptDone: func() RcvdMsg {
+		return &ResponseOutputAudioTranscriptDoneMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeResponseOutputAudioTranscriptDone}}
+	},
+	RcvdMsgTypeResponseOutputAudioDelta: func() RcvdMsg {
+		return &ResponseOutputAudioDeltaMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeResponseOutputAudioDelta}}
+	},
+	RcvdMsgTypeResponseOutputAudioDone: func() RcvdMsg {
+		return &ResponseOutputAudioDoneMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeResponseOutputAudioDone}}
+	},
+	RcvdMsgTypeResponseFunctionCallArgumentsDelta: func() RcvdMsg {
+		return &ResponseFunctionCallArgumentsDeltaMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeResponseFunctionCallArgumentsDelta}}
+	},
+	RcvdMsgTypeResponseFunctionCallArgumentsDone: func() RcvdMsg {
+		return &ResponseFunctionCallArgumentsDoneMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeResponseFunctionCallArgumentsDone}}
+	},
+	RcvdMsgTypeRateLimitsUpdated: func() RcvdMsg {
+		return &RateLimitsUpdatedMessage{RcvdMsgBase: RcvdMsgBase{Type: RcvdMsgTypeRateLimitsUpdated}}
+	},
+}
@@ -6,6 +6,11 @@
 //   - outgoing: Contains types and functions for creating outgoing messages
 //   - factory: Contains factory functions for creating message components
 //
+// The message type constants and the incoming message registry are generated from
+// events.json. Adding a new event is a spec change followed by go generate.
+//
 // Users should import the specific subpackages they need rather than relying
 // on re-exports from this package.
 package messages
+
+//go:generate go run ../internal/cmd/msggen -spec events.json -incoming incoming/zz_generated.go -outgoing outgoing/zz_generated.go
@@ -0,0 +1,280 @@
+// Package messagestest provides helpers for verifying that realtime messages survive
+// a JSON round trip and match golden fixtures.
+//
+// The helpers are used by this module's own tests and can be run by downstream users
+// against payloads captured from the live API, to detect fields that the typed
+// messages silently drop or alter:
+//
+//	func TestCapturedEvents(t *testing.T) {
+//		messagestest.CheckIncomingDir(t, "testdata/captured")
+//	}
+//
+// Golden files can be regenerated by running the tests with MESSAGESTEST_UPDATE=1.
+package messagestest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+)
+
+// UpdateEnv is the environment variable that makes MatchGolden rewrite golden files
+// instead of comparing against them
+const UpdateEnv = "MESSAGESTEST_UPDATE"
+
+// CompareMode controls how strictly CompareJSON treats differences
+type CompareMode int
+
+const (
+	// Exact requires both documents to be semantically identical
+	Exact CompareMode = iota
+
+	// Preserved requires every non-zero value in want to be present and equal in got.
+	// Zero values may be missing from got and extra keys in got may hold zero values,
+	// which is what omitempty and fields without omitempty produce respectively.
+	Preserved
+)
+
+// CompareJSON compares two JSON documents and returns a description of every difference,
+// one per entry, using dotted paths such as "response.output[0].id".
+// An empty result means the documents match under the given mode.
+func CompareJSON(want, got []byte, mode CompareMode) ([]string, error) {
+	var w, g any
+	if err := json.Unmarshal(want, &w); err != nil {
+		return nil, fmt.Errorf("failed to decode expected JSON: %w", err)
+	}
+	if err := json.Unmarshal(got, &g); err != nil {
+		return nil, fmt.Errorf("failed to decode actual JSON: %w", err)
+	}
+
+	var diffs []string
+	compareValues("$", w, g, mode, &diffs)
+	return diffs, nil
+}
+
+// RoundTripIncoming decodes a server event with incoming.UnmarshalRcvdMsg, encodes it
+// again and returns the differences between the two documents in Preserved mode
+func RoundTripIncoming(data []byte) (incoming.RcvdMsg, []string, error) {
+	msg, err := incoming.UnmarshalRcvdMsg(data)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	encoded, err := json.Marshal(msg)
+	if err != nil {
+		return msg, nil, fmt.Errorf("failed to marshal %s: %w", msg.RcvdMsgType(), err)
+	}
+
+	diffs, err := CompareJSON(data, encoded, Preserved)
+	return msg, diffs, err
+}
+
+// RoundTrip decodes data into a T, encodes it again and returns the differences
+// between the two documents in Preserved mode. It is intended for client events,
+// which have no type registry.
+func RoundTrip[T any](data []byte) (T, []string, error) {
+	var v T
+	if err := json.Unmarshal(data, &v); err != nil {
+		return v, nil, err
+	}
+
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return v, nil, fmt.Errorf("failed to marshal %T: %w", v, err)
+	}
+
+	diffs, err := CompareJSON(data, encoded, Preserved)
+	return v, diffs, err
+}
+
+// CheckIncoming fails the test if data is not a known server event or if any of its
+// fields are lost in a round trip. It returns the decoded message.
+func CheckIncoming(t testing.TB, data []byte) incoming.RcvdMsg {
+	t.Helper()
+
+	msg, diffs, err := RoundTripIncoming(data)
+	if err != nil {
+		t.Fatalf("Failed to round-trip server event: %v", err)
+	}
+	reportDiffs(t, diffs)
+	return msg
+}
+
+// CheckIncomingDir runs CheckIncoming as a subtest for every .json file in dir
+func CheckIncomingDir(t *testing.T, dir string) {
+	t.Helper()
+
+	for _, path := range jsonFiles(t, dir) {
+		t.Run(strings.TrimSuffix(filepath.Base(path), ".json"), func(t *testing.T) {
+			CheckIncoming(t, readFile(t, path))
+		})
+	}
+}
+
+// CheckHandlesAll fails the test for every known server event type that handle does not
+// cover. handle receives an empty message of each type and reports whether it handled it,
+// which makes it easy to check that a type switch or dispatcher is exhaustive.
+func CheckHandlesAll(t testing.TB, handle func(incoming.RcvdMsg) bool) {
+	t.Helper()
+	for _, msgType := range incoming.UnhandledTypes(handle) {
+		t.Errorf("Message type %s is not handled", msgType)
+	}
+}
+
+// MatchGolden fails the test if v does not encode to the same JSON as the golden file
+// at path. When MESSAGESTEST_UPDATE is set, the golden file is rewritten instead.
+func MatchGolden(t testing.TB, path string, v any) {
+	t.Helper()
+
+	got, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		t.Fatalf("Failed to marshal %T: %v", v, err)
+	}
+
+	if os.Getenv(UpdateEnv) != "" {
+		if err := os.WriteFile(path, append(got, '\n'), 0o644); err != nil {
+			t.Fatalf("Failed to update golden file: %v", err)
+		}
+		return
+	}
+
+	diffs, err := CompareJSON(readFile(t, path), got, Exact)
+	if err != nil {
+		t.Fatalf("Failed to compare with %s: %v", path, err)
+	}
+	reportDiffs(t, diffs)
+}
+
+// reportDiffs reports every difference as a test error
+func reportDiffs(t testing.TB, diffs []string) {
+	t.Helper()
+	for _, diff := range diffs {
+		t.Errorf("JSON mismatch: %s", diff)
+	}
+}
+
+// jsonFiles returns the sorted paths of the .json files in dir
+func jsonFiles(t testing.TB, dir string) []string {
+	t.Helper()
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		t.Fatalf("Failed to list %s: %v", dir, err)
+	}
+	if len(paths) == 0 {
+		t.Fatalf("No .json files found in %s", dir)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// readFile returns the contents of path, failing the test on error
+func readFile(t testing.TB, path string) []byte {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Failed to read %s: %v", path, err)
+	}
+	return bytes.TrimSpace(data)
+}
+
+// compareValues recursively compares decoded JSON values and records differences
+func compareValues(path string, want, got any, mode CompareMode, diffs *[]string) {
+	switch w := want.(type) {
+	case map[string]any:
+		g, ok := got.(map[string]any)
+		if !ok {
+			*diffs = append(*diffs, fmt.Sprintf("%s: expected object, got %s", path, describe(got)))
+			return
+		}
+		for _, key := range sortedKeys(w) {
+			gv, exists := g[key]
+			if !exists {
+				if mode == Preserved && isZero(w[key]) {
+					continue
+				}
+				*diffs = append(*diffs, fmt.Sprintf("%s.%s: missing (expected %s)", path, key, describe(w[key])))
+				continue
+			}
+			compareValues(path+"."+key, w[key], gv, mode, diffs)
+		}
+		for _, key := range sortedKeys(g) {
+			if _, exists := w[key]; exists {
+				continue
+			}
+			if mode == Exact || !isZero(g[key]) {
+				*diffs = append(*diffs, fmt.Sprintf("%s.%s: unexpected %s", path, key, describe(g[key])))
+			}
+		}
+	case []any:
+		g, ok := got.([]any)
+		if !ok {
+			*diffs = append(*diffs, fmt.Sprintf("%s: expected array, got %s", path, describe(got)))
+			return
+		}
+		if len(w) != len(g) {
+			*diffs = append(*diffs, fmt.Sprintf("%s: expected %d elements, got %d", path, len(w), len(g)))
+			return
+		}
+		for i := range w {
+			compareValues(fmt.Sprintf("%s[%d]", path, i), w[i], g[i], mode, diffs)
+		}
+	default:
+		if !reflect.DeepEqual(want, got) {
+			*diffs = append(*diffs, fmt.Sprintf("%s: expected %s, got %s", path, describe(want), describe(got)))
+		}
+	}
+}
+
+// isZero reports whether a decoded JSON value is the zero value for its type
+func isZero(v any) bool {
+	switch x := v.(type) {
+	case nil:
+		return true
+	case string:
+		return x == ""
+	case float64:
+		return x == 0
+	case bool:
+		return !x
+	case []any:
+		return len(x) == 0
+	case map[string]any:
+		for _, value := range x {
+			if !isZero(value) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// describe renders a decoded JSON value for error messages
+func describe(v any) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(data)
+}
+
+// sortedKeys returns the keys of m in sorted order so differences are reported deterministically
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
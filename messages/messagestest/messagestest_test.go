@@ -0,0 +1,97 @@
+package messagestest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+)
+
+func TestIncomingGoldenFixtures(t *testing.T) {
+	CheckIncomingDir(t, filepath.Join("testdata", "incoming"))
+}
+
+func TestEveryIncomingTypeHasFixture(t *testing.T) {
+	for msgType := range incoming.MessageTypeRegistry {
+		path := filepath.Join("testdata", "incoming", string(msgType)+".json")
+		if _, err := os.Stat(path); err != nil {
+			t.Errorf("Expected a golden fixture for %s at %s", msgType, path)
+		}
+	}
+}
+
+func TestCompareJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		want     string
+		got      string
+		mode     CompareMode
+		expected []string
+	}{
+		{
+			name: "Equal",
+			want: `{"a":1,"b":[1,2]}`,
+			got:  `{"b":[1,2],"a":1}`,
+			mode: Exact,
+		},
+		{
+			name:     "MissingField",
+			want:     `{"a":1,"b":{"c":"x"}}`,
+			got:      `{"a":1,"b":{}}`,
+			mode:     Preserved,
+			expected: []string{`$.b.c: missing (expected "x")`},
+		},
+		{
+			name: "ZeroExtraFieldPreserved",
+			want: `{"a":1}`,
+			got:  `{"a":1,"b":0,"c":""}`,
+			mode: Preserved,
+		},
+		{
+			name:     "ZeroExtraFieldExact",
+			want:     `{"a":1}`,
+			got:      `{"a":1,"b":0}`,
+			mode:     Exact,
+			expected: []string{`$.b: unexpected 0`},
+		},
+		{
+			name:     "ChangedValue",
+			want:     `{"a":[{"b":true}]}`,
+			got:      `{"a":[{"b":false}]}`,
+			mode:     Preserved,
+			expected: []string{`$.a[0].b: expected true, got false`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diffs, err := CompareJSON([]byte(tt.want), []byte(tt.got), tt.mode)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if strings.Join(diffs, "\n") != strings.Join(tt.expected, "\n") {
+				t.Errorf("Expected diffs %v, got %v", tt.expected, diffs)
+			}
+		})
+	}
+}
+
+func TestRoundTripIncomingDetectsDroppedField(t *testing.T) {
+	data := []byte(`{"type":"input_audio_buffer.cleared","event_id":"event_1","unmodelled":"value"}`)
+
+	_, diffs, err := RoundTripIncoming(data)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(diffs) != 1 || !strings.Contains(diffs[0], "unmodelled") {
+		t.Errorf("Expected the dropped field to be reported, got %v", diffs)
+	}
+}
+
+func TestCheckHandlesAll(t *testing.T) {
+	CheckHandlesAll(t, func(msg incoming.RcvdMsg) bool {
+		return msg != nil
+	})
+}
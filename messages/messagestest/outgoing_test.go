@@ -0,0 +1,90 @@
+package messagestest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Mliviu79/openai-realtime-go/messages/factory"
+	"github.com/Mliviu79/openai-realtime-go/messages/outgoing"
+	"github.com/Mliviu79/openai-realtime-go/messages/types"
+	"github.com/Mliviu79/openai-realtime-go/session"
+)
+
+// withID sets a fixed event ID so golden files are deterministic
+func withID[T outgoing.OutMsg](msg T, setID func(*T)) T {
+	setID(&msg)
+	return msg
+}
+
+func outgoingFixtures() map[string]outgoing.OutMsg {
+	instructions := "Be concise."
+	voice := session.VoiceCoral
+	temperature := 0.7
+	modalities := []session.Modality{session.ModalityText, session.ModalityAudio}
+	conversation := "none"
+	item := factory.MessageItem(types.MessageRoleUser, []types.MessageContentPart{factory.InputTextContent("Hello")})
+
+	return map[string]outgoing.OutMsg{
+		"session.update": withID(outgoing.NewSessionUpdateMessage(session.SessionRequest{
+			Modalities: &modalities,
+			ResponseParams: session.ResponseParams{
+				Instructions: &instructions,
+				Voice:        &voice,
+				Temperature:  &temperature,
+			},
+		}), func(m *outgoing.SessionUpdateMessage) { m.ID = "event_001" }),
+		"transcription_session.update": outgoing.NewTranscriptionSessionUpdateMessageWithID("event_002", session.TranscriptionSessionRequest{
+			InputAudioTranscription: &session.InputAudioTranscription{Model: session.TranscriptionModelGPT4oTranscribe, Language: "en"},
+		}),
+		"input_audio_buffer.append": withID(outgoing.NewAudioBufferAppendMessage("Base64EncodedAudio"),
+			func(m *outgoing.AudioBufferAppendMessage) { m.ID = "event_003" }),
+		"input_audio_buffer.commit": withID(outgoing.NewAudioBufferCommitMessage(""),
+			func(m *outgoing.AudioBufferCommitMessage) { m.ID = "event_004" }),
+		"input_audio_buffer.clear": withID(outgoing.NewAudioBufferClearMessage(),
+			func(m *outgoing.AudioBufferClearMessage) { m.ID = "event_005" }),
+		"conversation.item.create": withID(outgoing.NewConversationCreateMessage("msg_001", item),
+			func(m *outgoing.ConversationCreateMessage) { m.ID = "event_006" }),
+		"conversation.item.truncate": withID(outgoing.NewConversationTruncateMessage("msg_002", 0, 1500),
+			func(m *outgoing.ConversationTruncateMessage) { m.ID = "event_007" }),
+		"conversation.item.delete": withID(outgoing.NewConversationDeleteMessage("msg_003"),
+			func(m *outgoing.ConversationDeleteMessage) { m.ID = "event_008" }),
+		"conversation.item.retrieve": withID(outgoing.NewConversationRetrieveMessage("msg_003"),
+			func(m *outgoing.ConversationRetrieveMessage) { m.ID = "event_011" }),
+		"response.create": withID(outgoing.NewResponseCreateMessage(types.ResponseConfig{
+			Modalities: []session.Modality{session.ModalityText},
+			ResponseParams: session.ResponseParams{
+				Instructions: &instructions,
+			},
+			Conversation: &conversation,
+			Metadata:     map[string]string{"topic": "greeting"},
+		}), func(m *outgoing.ResponseCreateMessage) { m.ID = "event_009" }),
+		"response.cancel": withID(outgoing.NewResponseCancelMessage("resp_001"),
+			func(m *outgoing.ResponseCancelMessage) { m.ID = "event_010" }),
+	}
+}
+
+func TestOutgoingGoldenFixtures(t *testing.T) {
+	for name, msg := range outgoingFixtures() {
+		t.Run(name, func(t *testing.T) {
+			MatchGolden(t, filepath.Join("testdata", "outgoing", name+".json"), msg)
+		})
+	}
+}
+
+func TestOutgoingGoldenRoundTrip(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "outgoing", "session.update.json"))
+	if err != nil {
+		t.Fatalf("Failed to read fixture: %v", err)
+	}
+
+	msg, diffs, err := RoundTrip[outgoing.SessionUpdateMessage](data)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	reportDiffs(t, diffs)
+
+	if msg.Session.Voice == nil || *msg.Session.Voice != session.VoiceCoral {
+		t.Errorf("Expected voice coral, got %v", msg.Session.Voice)
+	}
+}
@@ -63,3 +63,21 @@ func NewConversationDeleteMessage(itemID string) ConversationDeleteMessage {
 		ItemID: itemID,
 	}
 }
+
+// ConversationRetrieveMessage is used to retrieve a conversation item, for example to
+// fetch audio that was not kept locally
+type ConversationRetrieveMessage struct {
+	OutMsgBase
+	// ItemID identifies the conversation item to retrieve
+	ItemID string `json:"item_id"`
+}
+
+// NewConversationRetrieveMessage creates a new conversation retrieve message
+func NewConversationRetrieveMessage(itemID string) ConversationRetrieveMessage {
+	return ConversationRetrieveMessage{
+		OutMsgBase: OutMsgBase{
+			Type: OutMsgTypeConversationRetrieve,
+		},
+		ItemID: itemID,
+	}
+}
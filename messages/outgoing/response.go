@@ -24,8 +24,9 @@ func NewResponseCreateMessage(config types.ResponseConfig) ResponseCreateMessage
 // ResponseCancelMessage is used to cancel an in-progress response
 type ResponseCancelMessage struct {
 	OutMsgBase
-	// ResponseID identifies the response to cancel
-	ResponseID string `json:"response_id"`
+	// ResponseID identifies the response to cancel. If empty, it is omitted and the
+	// server cancels the response in progress.
+	ResponseID string `json:"response_id,omitempty"`
 }
 
 // NewResponseCancelMessage creates a new response cancel message
@@ -49,13 +49,15 @@ func TestResponseCreateMessageStructure(t *testing.T) {
 
 	// Create a response config
 	responseConfig := types.ResponseConfig{
-		Modalities:              modalities,
-		Instructions:            &instructions,
-		Voice:                   &voice,
-		OutputAudioFormat:       &outputFormat,
+		Modalities: modalities,
+		ResponseParams: session.ResponseParams{
+			Instructions:      &instructions,
+			Voice:             &voice,
+			OutputAudioFormat: &outputFormat,
+			ToolChoice:        &toolChoice,
+			Temperature:       &temperature,
+		},
 		Tools:                   tools,
-		ToolChoice:              &toolChoice,
-		Temperature:             &temperature,
 		MaxResponseOutputTokens: maxTokens,
 	}
 
@@ -66,17 +66,19 @@ func TestSessionUpdateMessage(t *testing.T) {
 
 	// Create a session update message
 	sessionReq := session.SessionRequest{
-		Modalities:              &modalities,
-		Model:                   &model,
-		Instructions:            &instructions,
-		Voice:                   &voice,
+		Modalities: &modalities,
+		Model:      &model,
+		ResponseParams: session.ResponseParams{
+			Instructions:      &instructions,
+			Voice:             &voice,
+			OutputAudioFormat: &outputFormat,
+			ToolChoice:        &toolChoice,
+			Temperature:       &temperature,
+		},
 		InputAudioFormat:        &inputFormat,
-		OutputAudioFormat:       &outputFormat,
 		InputAudioTranscription: &transcription,
 		TurnDetection:           &turnDetection,
 		Tools:                   &tools,
-		ToolChoice:              &toolChoice,
-		Temperature:             &temperature,
 		MaxResponseOutputTokens: &maxTokens,
 	}
 
@@ -6,31 +6,7 @@ package outgoing
 // OutMsgType represents the type of message being sent to the server
 type OutMsgType string
 
-// Session-related message types
-const (
-	OutMsgTypeSessionUpdate              OutMsgType = "session.update"
-	OutMsgTypeTranscriptionSessionUpdate OutMsgType = "transcription_session.update"
-)
-
-// Audio buffer-related message types
-const (
-	OutMsgTypeAudioBufferAppend OutMsgType = "input_audio_buffer.append"
-	OutMsgTypeAudioBufferCommit OutMsgType = "input_audio_buffer.commit"
-	OutMsgTypeAudioBufferClear  OutMsgType = "input_audio_buffer.clear"
-)
-
-// Conversation-related message types
-const (
-	OutMsgTypeConversationCreate   OutMsgType = "conversation.item.create"
-	OutMsgTypeConversationTruncate OutMsgType = "conversation.item.truncate"
-	OutMsgTypeConversationDelete   OutMsgType = "conversation.item.delete"
-)
-
-// Response-related message types
-const (
-	OutMsgTypeResponseCreate OutMsgType = "response.create"
-	OutMsgTypeResponseCancel OutMsgType = "response.cancel"
-)
+// The OutMsgType constants are generated from messages/events.json into zz_generated.go.
 
 // OutMsg is the interface implemented by all outgoing message types
 type OutMsg interface {
@@ -0,0 +1,34 @@
+// Code generated by msggen from messages/events.json. DO NOT EDIT.
+
+package outgoing
+
+//-----------------------------------------------------------------------------
+// Message Type Constants
+//-----------------------------------------------------------------------------
+
+// Session-related message types
+const (
+	OutMsgTypeSessionUpdate              OutMsgType = "session.update"
+	OutMsgTypeTranscriptionSessionUpdate OutMsgType = "transcription_session.update"
+)
+
+// Audio buffer-related message types
+const (
+	OutMsgTypeAudioBufferAppend OutMsgType = "input_audio_buffer.append"
+	OutMsgTypeAudioBufferCommit OutMsgType = "input_audio_buffer.commit"
+	OutMsgTypeAudioBufferClear  OutMsgType = "input_audio_buffer.clear"
+)
+
+// Conversation-related message types
+const (
+	OutMsgTypeConversationCreate   OutMsgType = "conversation.item.create"
+	OutMsgTypeConversationTruncate OutMsgType = "conversation.item.truncate"
+	OutMsgTypeConversationDelete   OutMsgType = "conversation.item.delete"
+	OutMsgTypeConversationRetrieve OutMsgType = "conversation.item.retrieve"
+)
+
+// Response-related message types
+const (
+	OutMsgTypeResponseCreate OutMsgType = "response.create"
+	OutMsgTypeResponseCancel OutMsgType = "response.cancel"
+)
@@ -0,0 +1,211 @@
+// Package schema validates raw server events against JSON Schemas for the realtime API.
+//
+// The schemas for every server event are embedded in the package under events/, one
+// file per event type. They describe the published wire format independently of the
+// Go structs in the incoming package, so validating live traffic catches fields the
+// server sends that the structs do not model, missing required fields, and type changes.
+//
+// Only the subset of JSON Schema used by the embedded schemas is supported:
+// type, properties, required, items, enum and additionalProperties.
+package schema
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"path"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+)
+
+//go:embed events/*.json
+var eventFS embed.FS
+
+// Schema is a JSON Schema document or subschema
+type Schema struct {
+	// Title is the event type for top-level event schemas
+	Title string `json:"title,omitempty"`
+
+	// Type is the allowed JSON type or list of types
+	Type TypeList `json:"type,omitempty"`
+
+	// Properties describes known object members
+	Properties map[string]*Schema `json:"properties,omitempty"`
+
+	// Required lists object members that must be present
+	Required []string `json:"required,omitempty"`
+
+	// Items describes array elements
+	Items *Schema `json:"items,omitempty"`
+
+	// Enum lists the allowed values
+	Enum []any `json:"enum,omitempty"`
+
+	// AdditionalProperties rejects unknown object members when set to false
+	AdditionalProperties *bool `json:"additionalProperties,omitempty"`
+}
+
+// TypeList is the value of the "type" keyword, which may be a single type or a list
+type TypeList []string
+
+// UnmarshalJSON accepts both "string" and ["string", "null"]
+func (t *TypeList) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*t = TypeList{single}
+		return nil
+	}
+
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return fmt.Errorf("type must be a string or an array of strings: %w", err)
+	}
+	*t = list
+	return nil
+}
+
+// Validator checks raw server events against the schema for their type
+type Validator struct {
+	schemas map[string]*Schema
+}
+
+// NewValidator creates a Validator with the embedded schemas for every server event
+func NewValidator() (*Validator, error) {
+	entries, err := eventFS.ReadDir("events")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list embedded schemas: %w", err)
+	}
+
+	v := &Validator{schemas: make(map[string]*Schema, len(entries))}
+	for _, entry := range entries {
+		data, err := eventFS.ReadFile(path.Join("events", entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read schema %s: %w", entry.Name(), err)
+		}
+
+		var s Schema
+		if err := json.Unmarshal(data, &s); err != nil {
+			return nil, fmt.Errorf("failed to parse schema %s: %w", entry.Name(), err)
+		}
+		v.schemas[strings.TrimSuffix(entry.Name(), ".json")] = &s
+	}
+	return v, nil
+}
+
+// EventTypes returns the event types the validator has schemas for, sorted
+func (v *Validator) EventTypes() []string {
+	types := make([]string, 0, len(v.schemas))
+	for t := range v.schemas {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// Schema returns the schema for an event type, or nil if there is none
+func (v *Validator) Schema(eventType string) *Schema {
+	return v.schemas[eventType]
+}
+
+// Validate checks a raw server event against the schema for its type and returns
+// one description per mismatch, using dotted paths such as "$.response.output[0].id".
+// Events without a schema are reported as a single mismatch. An empty result means
+// the event conforms to the schema.
+func (v *Validator) Validate(data []byte) []string {
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return []string{fmt.Sprintf("$: invalid JSON: %v", err)}
+	}
+
+	obj, ok := doc.(map[string]any)
+	if !ok {
+		return []string{"$: expected object"}
+	}
+	eventType, _ := obj["type"].(string)
+
+	s := v.schemas[eventType]
+	if s == nil {
+		return []string{fmt.Sprintf("$.type: no schema for event type %q", eventType)}
+	}
+
+	var mismatches []string
+	s.validate("$", doc, &mismatches)
+	return mismatches
+}
+
+// validate recursively checks value against the schema and records mismatches
+func (s *Schema) validate(at string, value any, mismatches *[]string) {
+	if len(s.Type) > 0 && !slices.ContainsFunc(s.Type, func(t string) bool { return matchesType(t, value) }) {
+		*mismatches = append(*mismatches, fmt.Sprintf("%s: expected %s, got %s", at, strings.Join(s.Type, " or "), jsonType(value)))
+		return
+	}
+
+	if len(s.Enum) > 0 && !slices.ContainsFunc(s.Enum, func(e any) bool { return reflect.DeepEqual(e, value) }) {
+		*mismatches = append(*mismatches, fmt.Sprintf("%s: value %v is not allowed", at, value))
+	}
+
+	switch x := value.(type) {
+	case map[string]any:
+		for _, name := range s.Required {
+			if _, ok := x[name]; !ok {
+				*mismatches = append(*mismatches, fmt.Sprintf("%s.%s: required field is missing", at, name))
+			}
+		}
+
+		names := make([]string, 0, len(x))
+		for name := range x {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			if prop, ok := s.Properties[name]; ok {
+				prop.validate(at+"."+name, x[name], mismatches)
+			} else if s.AdditionalProperties != nil && !*s.AdditionalProperties {
+				*mismatches = append(*mismatches, fmt.Sprintf("%s.%s: unknown field", at, name))
+			}
+		}
+	case []any:
+		if s.Items != nil {
+			for i, item := range x {
+				s.Items.validate(fmt.Sprintf("%s[%d]", at, i), item, mismatches)
+			}
+		}
+	}
+}
+
+// matchesType reports whether a decoded JSON value has the given JSON Schema type
+func matchesType(t string, value any) bool {
+	switch t {
+	case "integer":
+		n, ok := value.(float64)
+		return ok && n == float64(int64(n))
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	default:
+		return jsonType(value) == t
+	}
+}
+
+// jsonType returns the JSON Schema type name of a decoded JSON value
+func jsonType(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
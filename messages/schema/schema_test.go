@@ -0,0 +1,100 @@
+package schema
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+)
+
+func newTestValidator(t *testing.T) *Validator {
+	t.Helper()
+	v, err := NewValidator()
+	if err != nil {
+		t.Fatalf("Failed to create validator: %v", err)
+	}
+	return v
+}
+
+func TestEveryIncomingTypeHasSchema(t *testing.T) {
+	v := newTestValidator(t)
+
+	for msgType := range incoming.MessageTypeRegistry {
+		if v.Schema(string(msgType)) == nil {
+			t.Errorf("Expected a schema for %s", msgType)
+		}
+	}
+}
+
+func TestGoldenFixturesMatchSchemas(t *testing.T) {
+	v := newTestValidator(t)
+
+	paths, err := filepath.Glob(filepath.Join("..", "messagestest", "testdata", "incoming", "*.json"))
+	if err != nil || len(paths) == 0 {
+		t.Fatalf("Failed to find golden fixtures: %v", err)
+	}
+
+	for _, path := range paths {
+		t.Run(filepath.Base(path), func(t *testing.T) {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatalf("Failed to read fixture: %v", err)
+			}
+			for _, mismatch := range v.Validate(data) {
+				t.Errorf("Unexpected mismatch: %s", mismatch)
+			}
+		})
+	}
+}
+
+func TestValidateMismatches(t *testing.T) {
+	v := newTestValidator(t)
+
+	tests := []struct {
+		name     string
+		data     string
+		expected []string
+	}{
+		{
+			name:     "UnknownField",
+			data:     `{"type":"input_audio_buffer.cleared","event_id":"evt_1","new_field":true}`,
+			expected: []string{"$.new_field: unknown field"},
+		},
+		{
+			name:     "MissingRequiredField",
+			data:     `{"type":"conversation.item.deleted","event_id":"evt_1"}`,
+			expected: []string{"$.item_id: required field is missing"},
+		},
+		{
+			name:     "WrongType",
+			data:     `{"type":"input_audio_buffer.speech_started","event_id":"evt_1","audio_start_ms":"100","item_id":"item_1"}`,
+			expected: []string{"$.audio_start_ms: expected integer, got string"},
+		},
+		{
+			name:     "DisabledTranscription",
+			data:     `{"type":"session.updated","session":{"id":"sess_1","input_audio_transcription":null}}`,
+			expected: nil,
+		},
+		{
+			name:     "UnknownEvent",
+			data:     `{"type":"experimental.event"}`,
+			expected: []string{`$.type: no schema for event type "experimental.event"`},
+		},
+		{
+			name:     "InvalidJSON",
+			data:     `{`,
+			expected: []string{"$: invalid JSON: unexpected end of JSON input"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := v.Validate([]byte(tt.data))
+			if strings.Join(got, "\n") != strings.Join(tt.expected, "\n") {
+				t.Errorf("Expected mismatches %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package types
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/Mliviu79/openai-realtime-go/session"
+)
+
+//-----------------------------------------------------------------------------
+// Cloning and Equality
+//-----------------------------------------------------------------------------
+
+// Clone returns a deep copy of the item.
+// Modifying the copy, including its content parts, does not affect the original.
+func (m MessageItem) Clone() MessageItem {
+	m.Content = slices.Clone(m.Content)
+	return m
+}
+
+// Equal reports whether two items hold the same values.
+// Nil and empty content are considered equal, matching their JSON encoding.
+func (m MessageItem) Equal(other MessageItem) bool {
+	return m.ID == other.ID &&
+		m.Object == other.Object &&
+		m.Type == other.Type &&
+		m.Status == other.Status &&
+		m.Role == other.Role &&
+		slices.Equal(m.Content, other.Content) &&
+		m.CallID == other.CallID &&
+		m.Name == other.Name &&
+		m.Arguments == other.Arguments &&
+		m.Output == other.Output
+}
+
+// Clone returns a deep copy of the item
+func (m ResponseMessageItem) Clone() ResponseMessageItem {
+	m.MessageItem = m.MessageItem.Clone()
+	return m
+}
+
+// Equal reports whether two items hold the same values
+func (m ResponseMessageItem) Equal(other ResponseMessageItem) bool {
+	return m.Object == other.Object && m.MessageItem.Equal(other.MessageItem)
+}
+
+// Clone returns a deep copy of the item
+func (o OutputItem) Clone() OutputItem {
+	o.Content = slices.Clone(o.Content)
+	return o
+}
+
+// Equal reports whether two items hold the same values.
+// Nil and empty content are considered equal, matching their JSON encoding.
+func (o OutputItem) Equal(other OutputItem) bool {
+	return o.ID == other.ID &&
+		o.Type == other.Type &&
+		o.Object == other.Object &&
+		o.Status == other.Status &&
+		o.Role == other.Role &&
+		slices.Equal(o.Content, other.Content) &&
+		o.CallID == other.CallID &&
+		o.Name == other.Name &&
+		o.Arguments == other.Arguments &&
+		o.Output == other.Output
+}
+
+// Clone returns a deep copy of the response.
+// Modifying the copy, including values behind its pointers, does not affect the original.
+func (r Response) Clone() Response {
+	if r.StatusDetails != nil {
+		details := *r.StatusDetails
+		details.Error = clonePtr(details.Error)
+		r.StatusDetails = &details
+	}
+	if r.Output != nil {
+		output := make([]OutputItem, len(r.Output))
+		for i, item := range r.Output {
+			output[i] = item.Clone()
+		}
+		r.Output = output
+	}
+	r.Metadata = maps.Clone(r.Metadata)
+	r.Usage = clonePtr(r.Usage)
+	r.Modalities = slices.Clone(r.Modalities)
+	return r
+}
+
+// Equal reports whether two responses hold the same values.
+// Pointer fields are compared by the values they point to, and nil and empty
+// slices and maps are considered equal, matching their JSON encoding.
+func (r Response) Equal(other Response) bool {
+	return r.ID == other.ID &&
+		r.Object == other.Object &&
+		r.Status == other.Status &&
+		equalStatusDetails(r.StatusDetails, other.StatusDetails) &&
+		slices.EqualFunc(r.Output, other.Output, OutputItem.Equal) &&
+		maps.Equal(r.Metadata, other.Metadata) &&
+		equalPtr(r.Usage, other.Usage) &&
+		r.ConversationID == other.ConversationID &&
+		r.Voice == other.Voice &&
+		slices.Equal(r.Modalities, other.Modalities) &&
+		r.OutputAudioFormat == other.OutputAudioFormat &&
+		r.Temperature == other.Temperature &&
+		r.MaxOutputTokens == other.MaxOutputTokens
+}
+
+// Clone returns a deep copy of the response configuration, including its response
+// parameters. Modifying the copy, including values behind its pointers, does not
+// affect the original.
+func (c ResponseConfig) Clone() ResponseConfig {
+	c.Modalities = slices.Clone(c.Modalities)
+	c.ResponseParams = c.ResponseParams.Clone()
+	if c.Tools != nil {
+		tools := make([]session.Tool, len(c.Tools))
+		for i, tool := range c.Tools {
+			tool.Parameters = slices.Clone(tool.Parameters)
+			tools[i] = tool
+		}
+		c.Tools = tools
+	}
+	c.MaxResponseOutputTokens = clonePtr(c.MaxResponseOutputTokens)
+	c.Conversation = clonePtr(c.Conversation)
+	c.Metadata = maps.Clone(c.Metadata)
+	if c.Input != nil {
+		input := make([]ConversationItem, len(c.Input))
+		for i, item := range c.Input {
+			item.Role = clonePtr(item.Role)
+			item.Content = slices.Clone(item.Content)
+			input[i] = item
+		}
+		c.Input = input
+	}
+	return c
+}
+
+// equalStatusDetails compares status details by value
+func equalStatusDetails(a, b *ResponseStatusDetails) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Type == b.Type && a.Reason == b.Reason && equalPtr(a.Error, b.Error)
+}
+
+// equalPtr reports whether both pointers are nil or point to equal values
+func equalPtr[T comparable](a, b *T) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// clonePtr returns a pointer to a shallow copy of the value behind p, or nil if p is nil
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
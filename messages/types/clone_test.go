@@ -0,0 +1,231 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/Mliviu79/openai-realtime-go/apierrs"
+	"github.com/Mliviu79/openai-realtime-go/session"
+)
+
+func sampleResponse() Response {
+	return Response{
+		ID:     "resp_1",
+		Object: "realtime.response",
+		Status: ResponseStatusFailed,
+		StatusDetails: &ResponseStatusDetails{
+			Type:  ResponseErrorTypeFailed,
+			Error: &ResponseError{Type: apierrs.ErrorTypeServer},
+		},
+		Output: []OutputItem{
+			{
+				ID:      "item_1",
+				Type:    MessageItemTypeMessage,
+				Role:    MessageRoleAssistant,
+				Content: []MessageContentPart{{Type: MessageContentTypeText, Text: "Hello"}},
+			},
+		},
+		Metadata:   map[string]string{"topic": "greeting"},
+		Usage:      &Usage{TotalTokens: 10, InputTokens: 4, OutputTokens: 6},
+		Modalities: []session.Modality{session.ModalityText},
+	}
+}
+
+func TestResponseClone(t *testing.T) {
+	original := sampleResponse()
+	clone := original.Clone()
+
+	if !clone.Equal(original) {
+		t.Fatalf("Expected clone to equal original, got %+v", clone)
+	}
+
+	clone.StatusDetails.Error.Type = apierrs.ErrorTypeInvalidRequest
+	clone.Output[0].Content[0].Text = "Changed"
+	clone.Metadata["topic"] = "changed"
+	clone.Usage.TotalTokens = 99
+	clone.Modalities[0] = session.ModalityAudio
+
+	if original.StatusDetails.Error.Type != apierrs.ErrorTypeServer {
+		t.Errorf("Expected original error type %s, got %s", apierrs.ErrorTypeServer, original.StatusDetails.Error.Type)
+	}
+	if original.Output[0].Content[0].Text != "Hello" {
+		t.Errorf("Expected original text %q, got %q", "Hello", original.Output[0].Content[0].Text)
+	}
+	if original.Metadata["topic"] != "greeting" {
+		t.Errorf("Expected original metadata %q, got %q", "greeting", original.Metadata["topic"])
+	}
+	if original.Usage.TotalTokens != 10 {
+		t.Errorf("Expected original total tokens 10, got %d", original.Usage.TotalTokens)
+	}
+	if original.Modalities[0] != session.ModalityText {
+		t.Errorf("Expected original modality %s, got %s", session.ModalityText, original.Modalities[0])
+	}
+	if clone.Equal(original) {
+		t.Error("Expected modified clone to differ from original")
+	}
+}
+
+func TestResponseEqual(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(r *Response)
+		expected bool
+	}{
+		{
+			name:     "identical",
+			modify:   func(r *Response) {},
+			expected: true,
+		},
+		{
+			name: "equal pointers to different values",
+			modify: func(r *Response) {
+				r.Usage = &Usage{TotalTokens: 10, InputTokens: 4, OutputTokens: 6}
+			},
+			expected: true,
+		},
+		{
+			name: "missing metadata",
+			modify: func(r *Response) {
+				r.Metadata = nil
+			},
+			expected: false,
+		},
+		{
+			name: "different usage",
+			modify: func(r *Response) {
+				r.Usage = &Usage{TotalTokens: 11}
+			},
+			expected: false,
+		},
+		{
+			name: "nil status details",
+			modify: func(r *Response) {
+				r.StatusDetails = nil
+			},
+			expected: false,
+		},
+		{
+			name: "different status reason",
+			modify: func(r *Response) {
+				r.StatusDetails = &ResponseStatusDetails{
+					Type:   ResponseErrorTypeFailed,
+					Reason: "other",
+					Error:  &ResponseError{Type: apierrs.ErrorTypeServer},
+				}
+			},
+			expected: false,
+		},
+		{
+			name: "different output content",
+			modify: func(r *Response) {
+				r.Output = []OutputItem{{ID: "item_1", Type: MessageItemTypeMessage, Role: MessageRoleAssistant}}
+			},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			other := sampleResponse()
+			tt.modify(&other)
+			if got := sampleResponse().Equal(other); got != tt.expected {
+				t.Errorf("Expected Equal to be %v, got %v", tt.expected, got)
+			}
+		})
+	}
+
+	empty := Response{Output: []OutputItem{}, Metadata: map[string]string{}}
+	if !empty.Equal(Response{}) {
+		t.Error("Expected empty and nil slices and maps to be equal")
+	}
+}
+
+func TestMessageItemCloneAndEqual(t *testing.T) {
+	original := MessageItem{
+		ID:      "item_1",
+		Type:    MessageItemTypeMessage,
+		Role:    MessageRoleUser,
+		Content: []MessageContentPart{{Type: MessageContentTypeInputText, Text: "Hi"}},
+	}
+	clone := original.Clone()
+
+	if !clone.Equal(original) {
+		t.Fatalf("Expected clone to equal original, got %+v", clone)
+	}
+
+	clone.Content[0].Text = "Changed"
+	if original.Content[0].Text != "Hi" {
+		t.Errorf("Expected original text %q, got %q", "Hi", original.Content[0].Text)
+	}
+	if clone.Equal(original) {
+		t.Error("Expected modified clone to differ from original")
+	}
+
+	if !(MessageItem{Content: []MessageContentPart{}}).Equal(MessageItem{}) {
+		t.Error("Expected empty and nil content to be equal")
+	}
+
+	respItem := ResponseMessageItem{MessageItem: original, Object: "realtime.item"}
+	respClone := respItem.Clone()
+	if !respClone.Equal(respItem) {
+		t.Errorf("Expected response item clone to equal original, got %+v", respClone)
+	}
+	respClone.Object = ""
+	if respClone.Equal(respItem) {
+		t.Error("Expected response items with different objects to differ")
+	}
+}
+
+func TestResponseConfigClone(t *testing.T) {
+	conversation := "none"
+	tokens := session.IntOrInf(100)
+	role := MessageRoleUser
+	instructions := "Be brief"
+	original := ResponseConfig{
+		Modalities:              []session.Modality{session.ModalityText},
+		ResponseParams:          session.ResponseParams{Instructions: &instructions},
+		Tools:                   []session.Tool{{Type: "function", Name: "lookup", Parameters: []byte(`{"type":"object"}`)}},
+		MaxResponseOutputTokens: &tokens,
+		Conversation:            &conversation,
+		Metadata:                map[string]string{"topic": "greeting"},
+		Input:                   []ConversationItem{{Type: MessageItemTypeMessage, Role: &role, Content: []MessageContentPart{{Type: MessageContentTypeInputText, Text: "Hi"}}}},
+	}
+
+	// The whole configuration is copied, not only the embedded parameters
+	clone := original.Clone()
+	clone.Modalities[0] = session.ModalityAudio
+	*clone.Instructions = "Be verbose"
+	clone.Tools[0].Parameters[0] = '['
+	*clone.MaxResponseOutputTokens = 1
+	*clone.Conversation = "auto"
+	clone.Metadata["topic"] = "farewell"
+	*clone.Input[0].Role = MessageRoleAssistant
+	clone.Input[0].Content[0].Text = "Bye"
+
+	if original.Modalities[0] != session.ModalityText || *original.Instructions != "Be brief" ||
+		original.Tools[0].Parameters[0] != '{' || *original.MaxResponseOutputTokens != 100 ||
+		*original.Conversation != "none" || original.Metadata["topic"] != "greeting" ||
+		*original.Input[0].Role != MessageRoleUser || original.Input[0].Content[0].Text != "Hi" {
+		t.Errorf("Expected modifying the clone not to affect the original, got %+v", original)
+	}
+}
+
+func TestResponseConfigDiff(t *testing.T) {
+	instructions := "Be brief"
+	current := ResponseConfig{
+		Modalities:     []session.Modality{session.ModalityText},
+		ResponseParams: session.ResponseParams{Instructions: &instructions},
+		Metadata:       map[string]string{"topic": "greeting"},
+	}
+	desired := current.Clone()
+	desired.Metadata["topic"] = "farewell"
+	conversation := "none"
+	desired.Conversation = &conversation
+
+	diff := current.Diff(desired)
+	if diff.Modalities != nil || diff.Instructions != nil {
+		t.Errorf("Expected unchanged fields to be left out, got %+v", diff)
+	}
+	if diff.Metadata["topic"] != "farewell" || diff.Conversation == nil || *diff.Conversation != "none" {
+		t.Errorf("Expected the changed fields, got %+v", diff)
+	}
+}
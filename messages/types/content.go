@@ -69,3 +69,19 @@ type Usage struct {
 	// OutputTokenDetails contains detailed information about output token usage
 	OutputTokenDetails OutputTokenDetails `json:"output_token_details,omitempty"`
 }
+
+// Add adds every counter of other to u
+func (u *Usage) Add(other Usage) {
+	u.TotalTokens += other.TotalTokens
+	u.InputTokens += other.InputTokens
+	u.OutputTokens += other.OutputTokens
+
+	u.InputTokenDetails.CachedTokens += other.InputTokenDetails.CachedTokens
+	u.InputTokenDetails.TextTokens += other.InputTokenDetails.TextTokens
+	u.InputTokenDetails.AudioTokens += other.InputTokenDetails.AudioTokens
+	u.InputTokenDetails.CachedTokensDetails.TextTokens += other.InputTokenDetails.CachedTokensDetails.TextTokens
+	u.InputTokenDetails.CachedTokensDetails.AudioTokens += other.InputTokenDetails.CachedTokensDetails.AudioTokens
+
+	u.OutputTokenDetails.TextTokens += other.OutputTokenDetails.TextTokens
+	u.OutputTokenDetails.AudioTokens += other.OutputTokenDetails.AudioTokens
+}
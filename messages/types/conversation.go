@@ -17,6 +17,10 @@ const (
 	// MessageItemTypeFunctionCallOutput represents a function call output
 	MessageItemTypeFunctionCallOutput MessageItemType = "function_call_output"
 
+	// MessageItemTypeItemReference references an existing conversation item by ID.
+	// It is used in response.create input arrays to avoid duplicating the item's content.
+	MessageItemTypeItemReference MessageItemType = "item_reference"
+
 	// MessageItemTypeFunctionResponse is an alias for MessageItemTypeFunctionCallOutput
 	// Deprecated: Use MessageItemTypeFunctionCallOutput instead
 	MessageItemTypeFunctionResponse = MessageItemTypeFunctionCallOutput
@@ -41,6 +45,25 @@ const (
 	ItemStatusIncomplete ItemStatus = "incomplete"
 )
 
+// ItemStatuses returns every item status, in lifecycle order
+func ItemStatuses() []ItemStatus {
+	return []ItemStatus{ItemStatusInProgress, ItemStatusCompleted, ItemStatusIncomplete}
+}
+
+// IsValid reports whether s is one of the known item statuses
+func (s ItemStatus) IsValid() bool {
+	switch s {
+	case ItemStatusInProgress, ItemStatusCompleted, ItemStatusIncomplete:
+		return true
+	}
+	return false
+}
+
+// IsTerminal reports whether the item has finished, whether or not it was complete
+func (s ItemStatus) IsTerminal() bool {
+	return s == ItemStatusCompleted || s == ItemStatusIncomplete
+}
+
 // MessageItem represents an item in a message
 type MessageItem struct {
 	// ID is an optional identifier for this item
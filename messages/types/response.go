@@ -1,6 +1,8 @@
 package types
 
 import (
+	"reflect"
+
 	"github.com/Mliviu79/openai-realtime-go/apierrs"
 	"github.com/Mliviu79/openai-realtime-go/session"
 )
@@ -29,6 +31,43 @@ const (
 	ResponseStatusIncomplete ResponseStatus = "incomplete"
 )
 
+// ResponseStatuses returns every response status, in lifecycle order
+func ResponseStatuses() []ResponseStatus {
+	return []ResponseStatus{
+		ResponseStatusInProgress,
+		ResponseStatusCompleted,
+		ResponseStatusFailed,
+		ResponseStatusCancelled,
+		ResponseStatusIncomplete,
+	}
+}
+
+// IsValid reports whether s is one of the known response statuses
+func (s ResponseStatus) IsValid() bool {
+	switch s {
+	case ResponseStatusInProgress, ResponseStatusCompleted, ResponseStatusFailed,
+		ResponseStatusCancelled, ResponseStatusIncomplete:
+		return true
+	}
+	return false
+}
+
+// IsTerminal reports whether the response has finished, whether or not it completed;
+// response.done always carries a terminal status
+func (s ResponseStatus) IsTerminal() bool {
+	switch s {
+	case ResponseStatusCompleted, ResponseStatusFailed, ResponseStatusCancelled, ResponseStatusIncomplete:
+		return true
+	}
+	return false
+}
+
+// IsError reports whether the response failed because of an error. Cancelled and
+// incomplete responses are not errors; see ResponseStatusDetails for why they stopped.
+func (s ResponseStatus) IsError() bool {
+	return s == ResponseStatusFailed
+}
+
 //-----------------------------------------------------------------------------
 // Response Error Types
 //-----------------------------------------------------------------------------
@@ -58,7 +97,8 @@ type ResponseMessageItem struct {
 	Object string `json:"object,omitempty"`
 }
 
-// ResponseStatusDetails provides additional information about the response status
+// ResponseStatusDetails provides additional information about the response status.
+// Detail, Cancelled, Incomplete and Failed return the typed variant for the type.
 type ResponseStatusDetails struct {
 	// Type is the type of error that caused the response to fail
 	// Values: "completed", "cancelled", "incomplete", "failed"
@@ -73,6 +113,120 @@ type ResponseStatusDetails struct {
 	Error *ResponseError `json:"error,omitempty"`
 }
 
+// CancelReason is why a response was cancelled
+type CancelReason string
+
+const (
+	// CancelReasonTurnDetected indicates the user started speaking
+	CancelReasonTurnDetected CancelReason = "turn_detected"
+
+	// CancelReasonClientCancelled indicates the client sent response.cancel
+	CancelReasonClientCancelled CancelReason = "client_cancelled"
+)
+
+// IncompleteReason is why a response was cut short
+type IncompleteReason string
+
+const (
+	// IncompleteReasonMaxOutputTokens indicates the response reached its output token limit
+	IncompleteReasonMaxOutputTokens IncompleteReason = "max_output_tokens"
+
+	// IncompleteReasonContentFilter indicates the response was stopped by the content filter
+	IncompleteReasonContentFilter IncompleteReason = "content_filter"
+)
+
+// StatusDetail is why a response ended without completing. It is one of
+// CancelledDetail, IncompleteDetail or FailedDetail, so callers can switch on it:
+//
+//	switch detail := resp.StatusDetails.Detail().(type) {
+//	case types.CancelledDetail:
+//		// detail.Reason
+//	case types.IncompleteDetail:
+//		// detail.Reason
+//	case types.FailedDetail:
+//		// detail.Error
+//	}
+type StatusDetail interface {
+	// Status returns the response status the detail belongs to
+	Status() ResponseStatus
+
+	isStatusDetail()
+}
+
+// CancelledDetail describes a cancelled response
+type CancelledDetail struct {
+	Reason CancelReason
+}
+
+// Status returns ResponseStatusCancelled
+func (CancelledDetail) Status() ResponseStatus { return ResponseStatusCancelled }
+func (CancelledDetail) isStatusDetail()        {}
+
+// IncompleteDetail describes a response that was cut short
+type IncompleteDetail struct {
+	Reason IncompleteReason
+}
+
+// Status returns ResponseStatusIncomplete
+func (IncompleteDetail) Status() ResponseStatus { return ResponseStatusIncomplete }
+func (IncompleteDetail) isStatusDetail()        {}
+
+// FailedDetail describes a failed response
+type FailedDetail struct {
+	// Error is the error reported by the server; it is zero if none was reported
+	Error ResponseError
+}
+
+// Status returns ResponseStatusFailed
+func (FailedDetail) Status() ResponseStatus { return ResponseStatusFailed }
+func (FailedDetail) isStatusDetail()        {}
+
+// Detail returns the typed variant of the details, or nil if the response completed
+// or the type is not known. Details with an error but no type are treated as failed.
+// It is safe to call on nil details.
+func (d *ResponseStatusDetails) Detail() StatusDetail {
+	if d == nil {
+		return nil
+	}
+	switch d.Type {
+	case ResponseErrorTypeCancelled:
+		return CancelledDetail{Reason: CancelReason(d.Reason)}
+	case ResponseErrorTypeIncomplete:
+		return IncompleteDetail{Reason: IncompleteReason(d.Reason)}
+	case ResponseErrorTypeFailed, "":
+		if d.Type == "" && d.Error == nil {
+			return nil
+		}
+		detail := FailedDetail{}
+		if d.Error != nil {
+			detail.Error = *d.Error
+		}
+		return detail
+	}
+	return nil
+}
+
+// Cancelled returns the details of a cancelled response; the second return value is
+// false if the response was not cancelled
+func (d *ResponseStatusDetails) Cancelled() (CancelledDetail, bool) {
+	detail, ok := d.Detail().(CancelledDetail)
+	return detail, ok
+}
+
+// Incomplete returns the details of a response that was cut short; the second return
+// value is false if the response was not incomplete
+func (d *ResponseStatusDetails) Incomplete() (IncompleteDetail, bool) {
+	detail, ok := d.Detail().(IncompleteDetail)
+	return detail, ok
+}
+
+// Failed returns the details of a failed response; the second return value is false
+// if the response did not fail
+func (d *ResponseStatusDetails) Failed() (FailedDetail, bool) {
+	detail, ok := d.Detail().(FailedDetail)
+	return detail, ok
+}
+
 // ResponseError describes an error that caused a response to fail
 type ResponseError struct {
 	// Type is the type of error
@@ -178,28 +332,12 @@ type ResponseConfig struct {
 	// Example: [session.ModalityText, session.ModalityAudio]
 	Modalities []session.Modality `json:"modalities,omitempty"`
 
-	// Instructions provides system instructions to guide the model
-	Instructions *string `json:"instructions,omitempty"`
-
-	// Voice specifies which voice to use for audio responses
-	// Options: VoiceAlloy, VoiceAsh, VoiceBallad, VoiceCoral, VoiceEcho, VoiceSage, VoiceShimmer, VoiceVerse
-	Voice *session.Voice `json:"voice,omitempty"`
-
-	// OutputAudioFormat specifies the format for audio responses
-	// Options: AudioFormatPCM16, AudioFormatG711ULaw, AudioFormatG711ALaw
-	OutputAudioFormat *session.AudioFormat `json:"output_audio_format,omitempty"`
+	// ResponseParams override the session's response parameters for this response
+	session.ResponseParams
 
 	// Tools specifies the available functions the model can call
 	Tools []session.Tool `json:"tools,omitempty"`
 
-	// ToolChoice controls how the model selects tools
-	// Options: ToolChoiceAuto, ToolChoiceNone, ToolChoiceRequired
-	ToolChoice *session.ToolChoiceObj `json:"tool_choice,omitempty"`
-
-	// Temperature controls the randomness of the model's output
-	// Range: [0.6, 1.2], default 0.8
-	Temperature *float64 `json:"temperature,omitempty"`
-
 	// MaxResponseOutputTokens limits the length of the response
 	// Range: 1-4096 or "inf", default "inf"
 	MaxResponseOutputTokens *session.IntOrInf `json:"max_output_tokens,omitempty"`
@@ -215,3 +353,29 @@ type ResponseConfig struct {
 	// Input provides additional items for model context
 	Input []ConversationItem `json:"input,omitempty"`
 }
+
+// Diff returns a ResponseConfig containing only the fields of desired that differ from c,
+// like session.Diff does for a SessionRequest. Fields that are unset in desired are never
+// included. It shadows the Diff of the embedded ResponseParams, which would only compare
+// the parameters.
+func (c ResponseConfig) Diff(desired ResponseConfig) ResponseConfig {
+	return ResponseConfig{
+		Modalities:              diffValue(c.Modalities, desired.Modalities),
+		ResponseParams:          c.ResponseParams.Diff(desired.ResponseParams),
+		Tools:                   diffValue(c.Tools, desired.Tools),
+		MaxResponseOutputTokens: diffValue(c.MaxResponseOutputTokens, desired.MaxResponseOutputTokens),
+		Conversation:            diffValue(c.Conversation, desired.Conversation),
+		Metadata:                diffValue(c.Metadata, desired.Metadata),
+		Input:                   diffValue(c.Input, desired.Input),
+	}
+}
+
+// diffValue returns desired if it is set and differs from current, or its zero value
+// otherwise. It compares pointers, slices and maps by the values they hold.
+func diffValue[T any](current, desired T) T {
+	var zero T
+	if reflect.ValueOf(desired).IsNil() || reflect.DeepEqual(current, desired) {
+		return zero
+	}
+	return desired
+}
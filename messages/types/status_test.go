@@ -0,0 +1,122 @@
+package types
+
+import "testing"
+
+func TestResponseStatus(t *testing.T) {
+	tests := []struct {
+		status   ResponseStatus
+		terminal bool
+		isError  bool
+	}{
+		{status: ResponseStatusInProgress},
+		{status: ResponseStatusCompleted, terminal: true},
+		{status: ResponseStatusFailed, terminal: true, isError: true},
+		{status: ResponseStatusCancelled, terminal: true},
+		{status: ResponseStatusIncomplete, terminal: true},
+	}
+
+	if len(tests) != len(ResponseStatuses()) {
+		t.Fatalf("Expected a case for each of %v", ResponseStatuses())
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			if !tt.status.IsValid() {
+				t.Error("Expected the status to be valid")
+			}
+			if got := tt.status.IsTerminal(); got != tt.terminal {
+				t.Errorf("Expected IsTerminal %v, got %v", tt.terminal, got)
+			}
+			if got := tt.status.IsError(); got != tt.isError {
+				t.Errorf("Expected IsError %v, got %v", tt.isError, got)
+			}
+		})
+	}
+
+	if unknown := ResponseStatus("paused"); unknown.IsValid() || unknown.IsTerminal() || unknown.IsError() {
+		t.Errorf("Expected an unknown status to be invalid and not terminal")
+	}
+}
+
+func TestItemStatus(t *testing.T) {
+	tests := []struct {
+		status   ItemStatus
+		terminal bool
+	}{
+		{status: ItemStatusInProgress},
+		{status: ItemStatusCompleted, terminal: true},
+		{status: ItemStatusIncomplete, terminal: true},
+	}
+
+	if len(tests) != len(ItemStatuses()) {
+		t.Fatalf("Expected a case for each of %v", ItemStatuses())
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			if !tt.status.IsValid() {
+				t.Error("Expected the status to be valid")
+			}
+			if got := tt.status.IsTerminal(); got != tt.terminal {
+				t.Errorf("Expected IsTerminal %v, got %v", tt.terminal, got)
+			}
+		})
+	}
+
+	if ItemStatus("").IsValid() {
+		t.Error("Expected an empty status to be invalid")
+	}
+}
+
+func TestResponseStatusDetailsDetail(t *testing.T) {
+	tests := []struct {
+		name     string
+		details  *ResponseStatusDetails
+		expected StatusDetail
+	}{
+		{name: "nil details"},
+		{name: "completed", details: &ResponseStatusDetails{Type: ResponseErrorTypeCompleted}},
+		{
+			name:     "cancelled",
+			details:  &ResponseStatusDetails{Type: ResponseErrorTypeCancelled, Reason: "turn_detected"},
+			expected: CancelledDetail{Reason: CancelReasonTurnDetected},
+		},
+		{
+			name:     "incomplete",
+			details:  &ResponseStatusDetails{Type: ResponseErrorTypeIncomplete, Reason: "max_output_tokens"},
+			expected: IncompleteDetail{Reason: IncompleteReasonMaxOutputTokens},
+		},
+		{
+			name:     "failed",
+			details:  &ResponseStatusDetails{Type: ResponseErrorTypeFailed, Error: &ResponseError{Type: "server_error", Code: "internal"}},
+			expected: FailedDetail{Error: ResponseError{Type: "server_error", Code: "internal"}},
+		},
+		{name: "failed without error", details: &ResponseStatusDetails{Type: ResponseErrorTypeFailed}, expected: FailedDetail{}},
+		{
+			name:     "error without type",
+			details:  &ResponseStatusDetails{Error: &ResponseError{Code: "internal"}},
+			expected: FailedDetail{Error: ResponseError{Code: "internal"}},
+		},
+		{name: "unknown type", details: &ResponseStatusDetails{Type: "paused"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			detail := tt.details.Detail()
+			if detail != tt.expected {
+				t.Fatalf("Expected %#v, got %#v", tt.expected, detail)
+			}
+
+			_, cancelled := tt.details.Cancelled()
+			_, incomplete := tt.details.Incomplete()
+			_, failed := tt.details.Failed()
+			var expectedStatus ResponseStatus
+			if tt.expected != nil {
+				expectedStatus = tt.expected.Status()
+			}
+			if cancelled != (expectedStatus == ResponseStatusCancelled) ||
+				incomplete != (expectedStatus == ResponseStatusIncomplete) ||
+				failed != (expectedStatus == ResponseStatusFailed) {
+				t.Errorf("Expected only the %q accessor to match, got cancelled=%v incomplete=%v failed=%v", expectedStatus, cancelled, incomplete, failed)
+			}
+		})
+	}
+}
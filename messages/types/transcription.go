@@ -24,6 +24,9 @@ const (
 
 	// TranscriptionModelGPT4oMiniTranscribe is the GPT-4o mini transcription model
 	TranscriptionModelGPT4oMiniTranscribe TranscriptionModel = session.TranscriptionModelGPT4oMiniTranscribe
+
+	// TranscriptionModelGPT4oTranscribeLatest always points to the latest GPT-4o transcription snapshot
+	TranscriptionModelGPT4oTranscribeLatest TranscriptionModel = session.TranscriptionModelGPT4oTranscribeLatest
 )
 
 // InputAudioTranscription represents configuration for audio transcription
@@ -0,0 +1,71 @@
+package types
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/Mliviu79/openai-realtime-go/apierrs"
+	"github.com/Mliviu79/openai-realtime-go/session"
+)
+
+//-----------------------------------------------------------------------------
+// Response Validation
+//-----------------------------------------------------------------------------
+
+const (
+	// MaxMetadataPairs is the maximum number of key-value pairs in response metadata
+	MaxMetadataPairs = 16
+
+	// MaxMetadataKeyLength is the maximum length of a metadata key in characters
+	MaxMetadataKeyLength = 64
+
+	// MaxMetadataValueLength is the maximum length of a metadata value in characters
+	MaxMetadataValueLength = 512
+)
+
+// Validate checks the response configuration against the API limits that the server
+// would otherwise reject mid-conversation. It returns an *apierrs.APIError identifying
+// the offending field, or nil if the configuration is valid.
+func (c *ResponseConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+	if err := session.ValidateModalities("modalities", c.Modalities); err != nil {
+		return err
+	}
+	if limits, ok := session.Model("").Limits(); ok {
+		if err := c.ResponseParams.Validate(limits); err != nil {
+			return err
+		}
+	}
+	return ValidateMetadata("metadata", c.Metadata)
+}
+
+// ValidateMetadata checks a metadata map against the API limits of at most 16 pairs,
+// keys of at most 64 characters and values of at most 512 characters.
+// The field name is used to identify the map in the returned *apierrs.APIError.
+func ValidateMetadata(field string, metadata map[string]string) error {
+	if len(metadata) > MaxMetadataPairs {
+		return apierrs.NewInvalidField(
+			field,
+			fmt.Sprintf("metadata is limited to %d key-value pairs, got %d", MaxMetadataPairs, len(metadata)),
+		)
+	}
+
+	for key, value := range metadata {
+		if n := utf8.RuneCountInString(key); n > MaxMetadataKeyLength {
+			return apierrs.NewInvalidField(
+				field,
+				fmt.Sprintf("metadata keys are limited to %d characters, got %d for key %q", MaxMetadataKeyLength, n, key),
+			)
+		}
+		if n := utf8.RuneCountInString(value); n > MaxMetadataValueLength {
+			return apierrs.NewInvalidField(
+				field+"."+key,
+				fmt.Sprintf("metadata values are limited to %d characters, got %d", MaxMetadataValueLength, n),
+			)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Mliviu79/openai-realtime-go/apierrs"
+	"github.com/Mliviu79/openai-realtime-go/session"
+)
+
+func TestResponseConfigValidate(t *testing.T) {
+	tooMany := make(map[string]string)
+	for i := 0; i <= MaxMetadataPairs; i++ {
+		tooMany[fmt.Sprintf("key_%d", i)] = "value"
+	}
+	maxPairs := make(map[string]string)
+	for i := 0; i < MaxMetadataPairs; i++ {
+		maxPairs[fmt.Sprintf("key_%d", i)] = "value"
+	}
+
+	temperature := 2.0
+
+	tests := []struct {
+		name          string
+		config        *ResponseConfig
+		expectedParam string
+	}{
+		{
+			name:   "Nil",
+			config: nil,
+		},
+		{
+			name:   "NoMetadata",
+			config: &ResponseConfig{},
+		},
+		{
+			name:   "MaxPairs",
+			config: &ResponseConfig{Metadata: maxPairs},
+		},
+		{
+			name:          "TooManyPairs",
+			config:        &ResponseConfig{Metadata: tooMany},
+			expectedParam: "metadata",
+		},
+		{
+			name:   "MaxKeyLength",
+			config: &ResponseConfig{Metadata: map[string]string{strings.Repeat("k", MaxMetadataKeyLength): "value"}},
+		},
+		{
+			name:          "KeyTooLong",
+			config:        &ResponseConfig{Metadata: map[string]string{strings.Repeat("k", MaxMetadataKeyLength+1): "value"}},
+			expectedParam: "metadata",
+		},
+		{
+			name:   "MultibyteValueAtLimit",
+			config: &ResponseConfig{Metadata: map[string]string{"topic": strings.Repeat("é", MaxMetadataValueLength)}},
+		},
+		{
+			name:   "AudioOnly",
+			config: &ResponseConfig{Modalities: session.AudioOnly},
+		},
+		{
+			name:          "InvalidModality",
+			config:        &ResponseConfig{Modalities: []session.Modality{"invalid_modality"}},
+			expectedParam: "modalities",
+		},
+		{
+			name:          "TemperatureOutOfRange",
+			config:        &ResponseConfig{ResponseParams: session.ResponseParams{Temperature: &temperature}},
+			expectedParam: "temperature",
+		},
+		{
+			name:          "ValueTooLong",
+			config:        &ResponseConfig{Metadata: map[string]string{"topic": strings.Repeat("v", MaxMetadataValueLength+1)}},
+			expectedParam: "metadata.topic",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.expectedParam == "" {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				return
+			}
+
+			var apiErr *apierrs.APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("Expected *apierrs.APIError, got %v", err)
+			}
+			if apiErr.Response.Error.Param == nil || *apiErr.Response.Error.Param != tt.expectedParam {
+				t.Errorf("Expected param %q, got %v", tt.expectedParam, apiErr.Response.Error.Param)
+			}
+		})
+	}
+}
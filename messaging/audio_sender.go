@@ -0,0 +1,136 @@
+package messaging
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/clock"
+	"github.com/Mliviu79/openai-realtime-go/session"
+)
+
+//-----------------------------------------------------------------------------
+// Audio Sender
+//-----------------------------------------------------------------------------
+
+// AudioSenderOption configures an AudioSender
+type AudioSenderOption func(*AudioSender)
+
+// WithRealtimePacing throttles input_audio_buffer.append so audio is sent no faster
+// than real time, based on the byte rate of the sender's audio format.
+// The sender may run ahead of real time by at most lead, which lets a small
+// amount of audio be buffered on the server to absorb network jitter.
+//
+// Pacing prevents server-side buffer overruns when streaming from a file or
+// another source that produces audio much faster than 1x.
+func WithRealtimePacing(lead time.Duration) AudioSenderOption {
+	return func(s *AudioSender) {
+		s.pacing = true
+		s.lead = lead
+	}
+}
+
+// WithSenderClock sets the clock used for pacing, e.g. a clock.Fake in tests.
+// The default is the system clock.
+func WithSenderClock(c clock.Clock) AudioSenderOption {
+	return func(s *AudioSender) {
+		if c != nil {
+			s.clock = c
+		}
+	}
+}
+
+// AudioSender sends raw audio to the input audio buffer, base64-encoding it and
+// optionally pacing it to real time.
+//
+// Example:
+//
+//	sender := messaging.NewAudioSender(msgClient, session.AudioFormatPCM16, messaging.WithRealtimePacing(200*time.Millisecond))
+//	for chunk := range chunks {
+//		if err := sender.Send(ctx, chunk); err != nil {
+//			return err
+//		}
+//	}
+type AudioSender struct {
+	mu     sync.Mutex
+	client *Client
+	format session.AudioFormat
+	pacing bool
+	lead   time.Duration
+
+	// started is when the first paced chunk was sent, sent is the audio duration sent since then
+	started time.Time
+	sent    time.Duration
+
+	clock clock.Clock
+}
+
+// NewAudioSender creates an AudioSender that appends audio in the given format
+// to the input audio buffer through client.
+func NewAudioSender(client *Client, format session.AudioFormat, opts ...AudioSenderOption) *AudioSender {
+	s := &AudioSender{
+		client: client,
+		format: format,
+		clock:  clock.Real(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Send appends raw audio to the input audio buffer.
+// With pacing enabled, Send blocks until sending the chunk would not put the
+// stream further ahead of real time than the configured lead.
+func (s *AudioSender) Send(ctx context.Context, audio []byte) error {
+	if len(audio) == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.pacing {
+		if !s.format.IsValid() {
+			return fmt.Errorf("cannot pace unknown audio format %q", s.format)
+		}
+		if err := s.wait(ctx); err != nil {
+			return err
+		}
+	}
+
+	if err := s.client.SendAudioBufferAppend(ctx, base64.StdEncoding.EncodeToString(audio)); err != nil {
+		return err
+	}
+
+	if s.pacing {
+		s.sent += s.format.DurationForBytes(len(audio))
+	}
+	return nil
+}
+
+// Reset restarts the pacing clock, e.g. after committing or clearing the buffer
+// before a pause in the stream
+func (s *AudioSender) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.started = time.Time{}
+	s.sent = 0
+}
+
+// wait blocks until the audio already sent is within lead of the elapsed wall-clock time
+func (s *AudioSender) wait(ctx context.Context) error {
+	now := s.clock.Now()
+	if s.started.IsZero() {
+		s.started = now
+		return nil
+	}
+
+	ahead := s.sent - now.Sub(s.started) - s.lead
+	if ahead <= 0 {
+		return nil
+	}
+	return clock.Sleep(ctx, s.clock, ahead)
+}
@@ -0,0 +1,103 @@
+package messaging
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/clock"
+	"github.com/Mliviu79/openai-realtime-go/session"
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+func TestAudioSenderPacing(t *testing.T) {
+	var sentCount atomic.Int32
+	mockConn := &MockConn{
+		WriteMessageFunc: func(ctx context.Context, messageType ws.MessageType, data []byte) error {
+			sentCount.Add(1)
+			return nil
+		},
+	}
+	client := NewClient(ws.NewConn(mockConn))
+
+	start := time.Unix(0, 0)
+	fake := clock.NewFake(start)
+	sender := NewAudioSender(client, session.AudioFormatPCM16, WithRealtimePacing(0), WithSenderClock(fake))
+
+	// 100ms of pcm16 audio at 24kHz
+	chunk := make([]byte, session.AudioFormatPCM16.BytesForDuration(100*time.Millisecond))
+	done := make(chan error, 1)
+	go func() {
+		for range 3 {
+			if err := sender.Send(context.Background(), chunk); err != nil {
+				done <- err
+				return
+			}
+		}
+		done <- nil
+	}()
+
+	// Each chunk after the first waits until the previous one has played
+	for i := 1; i < 3; i++ {
+		fake.BlockUntil(1)
+		if n := sentCount.Load(); n != int32(i) {
+			t.Errorf("Expected %d messages before the clock advances, got %d", i, n)
+		}
+		fake.Advance(100 * time.Millisecond)
+	}
+	if err := <-done; err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if n := sentCount.Load(); n != 3 {
+		t.Errorf("Expected 3 messages to be sent, got %d", n)
+	}
+	if elapsed := fake.Now().Sub(start); elapsed != 200*time.Millisecond {
+		t.Errorf("Expected to wait 200ms, got %v", elapsed)
+	}
+}
+
+func TestAudioSenderWithoutPacing(t *testing.T) {
+	client := NewClient(ws.NewConn(&MockConn{}))
+	fake := clock.NewFake(time.Unix(0, 0))
+	sender := NewAudioSender(client, session.AudioFormatPCM16, WithSenderClock(fake))
+
+	// The fake clock never advances, so any wait would block
+	done := make(chan error, 1)
+	go func() {
+		chunk := make([]byte, 4800)
+		for range 3 {
+			if err := sender.Send(context.Background(), chunk); err != nil {
+				done <- err
+				return
+			}
+		}
+		done <- nil
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected no waiting without pacing")
+	}
+}
+
+func TestAudioSenderPacingCancelled(t *testing.T) {
+	client := NewClient(ws.NewConn(&MockConn{}))
+	sender := NewAudioSender(client, session.AudioFormatPCM16, WithRealtimePacing(0))
+
+	chunk := make([]byte, session.AudioFormatPCM16.BytesForDuration(time.Second))
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := sender.Send(ctx, chunk); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	cancel()
+	if err := sender.Send(ctx, chunk); err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
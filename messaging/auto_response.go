@@ -0,0 +1,89 @@
+package messaging
+
+import (
+	"context"
+
+	"github.com/Mliviu79/openai-realtime-go/messages/types"
+)
+
+//-----------------------------------------------------------------------------
+// Automatic Responses
+//-----------------------------------------------------------------------------
+
+// SetAutoResponse makes SendText and SendAudio send a response.create with config after
+// the user item, so the model replies without a separate SendResponseCreate call.
+// A nil config disables automatic responses, which is the default. WithAutoResponse
+// overrides it for a single call.
+//
+// The config is validated when it is set and is shared by every automatic response; it
+// must not be modified afterwards. Use an empty ResponseConfig to respond with the
+// session's settings.
+//
+// Example:
+//
+//	if err := msgClient.SetAutoResponse(&types.ResponseConfig{}); err != nil {
+//		log.Fatal(err)
+//	}
+//	err = msgClient.SendText(ctx, "Hello, how are you?") // the model replies
+func (c *Client) SetAutoResponse(config *types.ResponseConfig) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.autoResponse = config
+	return nil
+}
+
+// UserItemOption configures a single SendText or SendAudio call
+type UserItemOption func(*userItemOptions)
+
+// userItemOptions holds the configuration for a single SendText or SendAudio call
+type userItemOptions struct {
+	autoResponse    *types.ResponseConfig
+	hasAutoResponse bool
+}
+
+// WithAutoResponse requests a response with config after this message instead of the
+// one set by SetAutoResponse, e.g. to reply to one message out of band or with other
+// instructions. A nil config sends no response for this message. The config is
+// validated before the message is sent.
+//
+// Example:
+//
+//	// Answer this message even though automatic responses are disabled
+//	err := msgClient.SendText(ctx, "What time is it?", messaging.WithAutoResponse(&types.ResponseConfig{}))
+func WithAutoResponse(config *types.ResponseConfig) UserItemOption {
+	return func(o *userItemOptions) {
+		o.autoResponse = config
+		o.hasAutoResponse = true
+	}
+}
+
+// sendUserItem sends a user item and, if automatic responses are enabled for the client
+// or the call, requests a response to it
+func (c *Client) sendUserItem(ctx context.Context, item *types.MessageItem, opts []UserItemOption) error {
+	var options userItemOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if err := options.autoResponse.Validate(); err != nil {
+		return err
+	}
+
+	if err := c.SendConversationItemCreate(ctx, item, nil); err != nil {
+		return err
+	}
+
+	config := options.autoResponse
+	if !options.hasAutoResponse {
+		c.mu.RLock()
+		config = c.autoResponse
+		c.mu.RUnlock()
+	}
+
+	if config == nil {
+		return nil
+	}
+	return c.SendResponseCreate(ctx, config)
+}
@@ -0,0 +1,99 @@
+package messaging
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Mliviu79/openai-realtime-go/messages/types"
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+func TestAutoResponse(t *testing.T) {
+	conn, sent, _ := recordingConn()
+	client := NewClient(ws.NewConn(conn))
+	ctx := context.Background()
+
+	if err := client.SendText(ctx, "Hello"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := sent(); len(got) != 1 {
+		t.Fatalf("Expected no response without auto response, got %v", got)
+	}
+
+	conversation := "none"
+	if err := client.SetAutoResponse(&types.ResponseConfig{Conversation: &conversation}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := client.SendText(ctx, "Hello again"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := client.SendAudio(ctx, "AAAA", ""); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	got := sent()
+	if len(got) != 5 {
+		t.Fatalf("Expected each item to be followed by a response, got %v", got)
+	}
+	for _, i := range []int{2, 4} {
+		if got[i] != `{"type":"response.create","response":{"conversation":"none"}}` {
+			t.Errorf("Expected the configured response.create, got %s", got[i])
+		}
+	}
+	if !strings.Contains(got[3], `"input_audio"`) {
+		t.Errorf("Expected the audio item before its response, got %s", got[3])
+	}
+
+	if err := client.SetAutoResponse(nil); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := client.SendText(ctx, "Quiet"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := sent(); len(got) != 6 {
+		t.Errorf("Expected auto response to be disabled, got %v", got)
+	}
+}
+
+func TestSetAutoResponseValidates(t *testing.T) {
+	client := NewClient(ws.NewConn(&MockConn{}))
+	metadata := map[string]string{strings.Repeat("k", 65): "v"}
+	if err := client.SetAutoResponse(&types.ResponseConfig{Metadata: metadata}); err == nil {
+		t.Error("Expected an invalid config to be rejected")
+	}
+}
+
+func TestWithAutoResponse(t *testing.T) {
+	conn, sent, _ := recordingConn()
+	client := NewClient(ws.NewConn(conn))
+	ctx := context.Background()
+
+	conversation := "none"
+	if err := client.SendText(ctx, "Hello", WithAutoResponse(&types.ResponseConfig{Conversation: &conversation})); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	got := sent()
+	if len(got) != 2 || got[1] != `{"type":"response.create","response":{"conversation":"none"}}` {
+		t.Fatalf("Expected the call's response without a client-wide one, got %v", got)
+	}
+
+	if err := client.SetAutoResponse(&types.ResponseConfig{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := client.SendAudio(ctx, "AAAA", "", WithAutoResponse(nil)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := sent(); len(got) != 3 {
+		t.Fatalf("Expected no response for the call, got %v", got)
+	}
+
+	// An invalid config is rejected before the item is sent
+	metadata := map[string]string{strings.Repeat("k", 65): "v"}
+	if err := client.SendText(ctx, "Hi", WithAutoResponse(&types.ResponseConfig{Metadata: metadata})); err == nil {
+		t.Error("Expected an invalid config to be rejected")
+	}
+	if got := sent(); len(got) != 3 {
+		t.Errorf("Expected nothing sent for an invalid config, got %v", got)
+	}
+}
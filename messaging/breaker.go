@@ -0,0 +1,220 @@
+package messaging
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/clock"
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+//-----------------------------------------------------------------------------
+// Circuit Breaker
+//-----------------------------------------------------------------------------
+
+const (
+	// DefaultFailureThreshold is the number of consecutive failures that opens the circuit
+	DefaultFailureThreshold = 5
+
+	// DefaultOpenTimeout is how long the circuit stays open before a probe is allowed
+	DefaultOpenTimeout = 30 * time.Second
+)
+
+// ErrCircuitOpen is returned when the circuit breaker rejects a connection attempt
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// CircuitState is the state of a CircuitBreaker
+type CircuitState string
+
+const (
+	// CircuitClosed lets every attempt through
+	CircuitClosed CircuitState = "closed"
+	// CircuitOpen rejects every attempt until the open timeout has passed
+	CircuitOpen CircuitState = "open"
+	// CircuitHalfOpen lets a single probe through; its outcome closes or reopens the circuit
+	CircuitHalfOpen CircuitState = "half_open"
+)
+
+// CircuitBreakerOption configures a CircuitBreaker
+type CircuitBreakerOption func(*CircuitBreaker)
+
+// WithFailureThreshold sets the number of consecutive failures that opens the circuit
+func WithFailureThreshold(failures int) CircuitBreakerOption {
+	return func(b *CircuitBreaker) {
+		b.threshold = failures
+	}
+}
+
+// WithOpenTimeout sets how long the circuit stays open before a probe is allowed
+func WithOpenTimeout(timeout time.Duration) CircuitBreakerOption {
+	return func(b *CircuitBreaker) {
+		b.openTimeout = timeout
+	}
+}
+
+// WithOnStateChange sets a function that is called on every state transition.
+// It is called synchronously and must not block.
+func WithOnStateChange(onChange func(from, to CircuitState)) CircuitBreakerOption {
+	return func(b *CircuitBreaker) {
+		b.onChange = onChange
+	}
+}
+
+// WithBreakerMetricsHook reports state transitions as circuit_open, circuit_half_open
+// and circuit_closed connection events
+func WithBreakerMetricsHook(hook ws.MetricsHook) CircuitBreakerOption {
+	return func(b *CircuitBreaker) {
+		b.metrics = hook
+	}
+}
+
+// WithBreakerClock sets the clock used for the open timeout, e.g. a clock.Fake in tests.
+// The default is the system clock.
+func WithBreakerClock(c clock.Clock) CircuitBreakerOption {
+	return func(b *CircuitBreaker) {
+		if c != nil {
+			b.clock = c
+		}
+	}
+}
+
+// CircuitBreaker stops connection attempts after consecutive failures so a failing API
+// is not hammered. After the open timeout it lets a single probe through (half-open):
+// a success closes the circuit, a failure opens it again.
+//
+// Callers ask Allow before each attempt and report the outcome with Success or Failure.
+// A CircuitBreaker is safe for concurrent use and can be shared by many sessions.
+type CircuitBreaker struct {
+	threshold   int
+	openTimeout time.Duration
+	onChange    func(from, to CircuitState)
+	metrics     ws.MetricsHook
+
+	mu       sync.Mutex
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	probing  bool
+
+	clock clock.Clock
+}
+
+// NewCircuitBreaker creates a closed CircuitBreaker
+func NewCircuitBreaker(opts ...CircuitBreakerOption) *CircuitBreaker {
+	b := &CircuitBreaker{
+		threshold:   DefaultFailureThreshold,
+		openTimeout: DefaultOpenTimeout,
+		state:       CircuitClosed,
+		clock:       clock.Real(),
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// State returns the current state. An open circuit whose timeout has passed is reported
+// as open until the next call to Allow moves it to half-open.
+func (b *CircuitBreaker) State() CircuitState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// Allow reports whether an attempt may be made now. It returns ErrCircuitOpen while the
+// circuit is open, or while a half-open probe is already in flight.
+func (b *CircuitBreaker) Allow() error {
+	b.mu.Lock()
+	switch b.state {
+	case CircuitOpen:
+		if b.clock.Now().Sub(b.openedAt) < b.openTimeout {
+			b.mu.Unlock()
+			return ErrCircuitOpen
+		}
+		b.probing = true
+		notify := b.transition(CircuitHalfOpen, nil)
+		b.mu.Unlock()
+		notify()
+		return nil
+	case CircuitHalfOpen:
+		defer b.mu.Unlock()
+		if b.probing {
+			return ErrCircuitOpen
+		}
+		b.probing = true
+		return nil
+	default:
+		b.mu.Unlock()
+		return nil
+	}
+}
+
+// RetryAfter returns how long until the open circuit allows a probe, or zero if it allows attempts now
+func (b *CircuitBreaker) RetryAfter() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.state != CircuitOpen {
+		return 0
+	}
+	if wait := b.openTimeout - b.clock.Now().Sub(b.openedAt); wait > 0 {
+		return wait
+	}
+	return 0
+}
+
+// Success reports a successful attempt, closing the circuit
+func (b *CircuitBreaker) Success() {
+	b.mu.Lock()
+	b.failures = 0
+	b.probing = false
+	notify := func() {}
+	if b.state != CircuitClosed {
+		notify = b.transition(CircuitClosed, nil)
+	}
+	b.mu.Unlock()
+	notify()
+}
+
+// Failure reports a failed attempt. It opens the circuit after the failure threshold is
+// reached, or immediately when a half-open probe fails.
+func (b *CircuitBreaker) Failure(err error) {
+	b.mu.Lock()
+	b.failures++
+	b.probing = false
+	notify := func() {}
+	if b.state == CircuitHalfOpen || (b.state == CircuitClosed && b.failures >= b.threshold) {
+		b.openedAt = b.clock.Now()
+		notify = b.transition(CircuitOpen, err)
+	}
+	b.mu.Unlock()
+	notify()
+}
+
+// transition moves to a new state with the lock held. It returns a function that reports
+// the transition, to be called after the lock is released so callbacks may use the breaker.
+func (b *CircuitBreaker) transition(to CircuitState, err error) func() {
+	from := b.state
+	b.state = to
+	return func() { b.report(from, to, err) }
+}
+
+// report notifies the metrics hook and state change function of a transition
+func (b *CircuitBreaker) report(from, to CircuitState, err error) {
+	if b.metrics != nil {
+		event := ws.ConnEvent{Err: err}
+		switch to {
+		case CircuitOpen:
+			event.Kind = ws.ConnEventCircuitOpen
+			event.Reason = ws.DialFailureReason(err)
+		case CircuitHalfOpen:
+			event.Kind = ws.ConnEventCircuitHalfOpen
+		case CircuitClosed:
+			event.Kind = ws.ConnEventCircuitClosed
+		}
+		b.metrics.RecordConnEvent(event)
+	}
+	if b.onChange != nil {
+		b.onChange(from, to)
+	}
+}
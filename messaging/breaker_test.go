@@ -0,0 +1,181 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/clock"
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+func TestCircuitBreakerTransitions(t *testing.T) {
+	fake := clock.NewFake(time.Unix(0, 0))
+	var transitions []string
+	counters := ws.NewConnCounters()
+
+	var b *CircuitBreaker
+	b = NewCircuitBreaker(
+		WithFailureThreshold(2),
+		WithOpenTimeout(10*time.Second),
+		WithBreakerMetricsHook(counters),
+		WithBreakerClock(fake),
+		WithOnStateChange(func(from, to CircuitState) {
+			// The callback may use the breaker
+			if b.State() != to {
+				t.Errorf("Expected state %s in callback, got %s", to, b.State())
+			}
+			transitions = append(transitions, string(from)+"->"+string(to))
+		}),
+	)
+
+	dialErr := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+
+	b.Failure(dialErr)
+	if b.State() != CircuitClosed {
+		t.Errorf("Expected state %s after one failure, got %s", CircuitClosed, b.State())
+	}
+	b.Failure(dialErr)
+	if b.State() != CircuitOpen {
+		t.Fatalf("Expected state %s after threshold, got %s", CircuitOpen, b.State())
+	}
+	if err := b.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Expected ErrCircuitOpen, got %v", err)
+	}
+
+	fake.Advance(4 * time.Second)
+	if wait := b.RetryAfter(); wait != 6*time.Second {
+		t.Errorf("Expected retry after 6s, got %v", wait)
+	}
+
+	fake.Advance(6 * time.Second)
+	if err := b.Allow(); err != nil {
+		t.Fatalf("Expected probe to be allowed, got %v", err)
+	}
+	if b.State() != CircuitHalfOpen {
+		t.Errorf("Expected state %s, got %s", CircuitHalfOpen, b.State())
+	}
+	if err := b.Allow(); !errors.Is(err, ErrCircuitOpen) {
+		t.Errorf("Expected a second probe to be rejected, got %v", err)
+	}
+
+	// A failed probe reopens the circuit immediately
+	b.Failure(dialErr)
+	if b.State() != CircuitOpen {
+		t.Fatalf("Expected state %s after failed probe, got %s", CircuitOpen, b.State())
+	}
+
+	fake.Advance(10 * time.Second)
+	if err := b.Allow(); err != nil {
+		t.Fatalf("Expected probe to be allowed, got %v", err)
+	}
+	b.Success()
+	if b.State() != CircuitClosed {
+		t.Errorf("Expected state %s after successful probe, got %s", CircuitClosed, b.State())
+	}
+
+	expected := []string{"closed->open", "open->half_open", "half_open->open", "open->half_open", "half_open->closed"}
+	if len(transitions) != len(expected) {
+		t.Fatalf("Expected transitions %v, got %v", expected, transitions)
+	}
+	for i := range expected {
+		if transitions[i] != expected[i] {
+			t.Errorf("Expected transition %d to be %s, got %s", i, expected[i], transitions[i])
+		}
+	}
+
+	if got := counters.Count(ws.ConnEventCircuitOpen, ws.ReasonNetwork); got != 2 {
+		t.Errorf("Expected 2 circuit_open events with reason %s, got %d", ws.ReasonNetwork, got)
+	}
+	if got := counters.Total(ws.ConnEventCircuitHalfOpen); got != 2 {
+		t.Errorf("Expected 2 circuit_half_open events, got %d", got)
+	}
+	if got := counters.Total(ws.ConnEventCircuitClosed); got != 1 {
+		t.Errorf("Expected 1 circuit_closed event, got %d", got)
+	}
+}
+
+func TestCircuitBreakerSuccessResetsFailures(t *testing.T) {
+	b := NewCircuitBreaker(WithFailureThreshold(2))
+
+	b.Failure(errors.New("failed"))
+	b.Success()
+	b.Failure(errors.New("failed"))
+	if b.State() != CircuitClosed {
+		t.Errorf("Expected state %s, got %s", CircuitClosed, b.State())
+	}
+	if err := b.Allow(); err != nil {
+		t.Errorf("Expected attempt to be allowed, got %v", err)
+	}
+}
+
+func TestSupervisorWaitsForCircuitBreaker(t *testing.T) {
+	start := time.Unix(0, 0)
+	fake := clock.NewFake(start)
+	breaker := NewCircuitBreaker(WithFailureThreshold(2), WithOpenTimeout(time.Minute), WithBreakerClock(fake))
+
+	dials := 0
+	var dialedWhileOpen bool
+	var circuitEvents int
+	sup := NewSupervisor(func(ctx context.Context) (*ws.Conn, error) {
+		dials++
+		if breaker.State() == CircuitOpen {
+			dialedWhileOpen = true
+		}
+		if dials <= 3 {
+			return nil, errors.New("dial tcp: connection refused")
+		}
+		return ws.NewConn(ackingConn()), nil
+	}, func(ctx context.Context, client *Client) error {
+		return nil
+	},
+		WithRestartPolicy(RestartPolicy{MaxRestarts: 3, Delay: time.Second}),
+		WithCircuitBreaker(breaker),
+		WithSupervisorClock(fake),
+		WithOnLifecycleEvent(func(event LifecycleEvent) {
+			if event.Kind == LifecycleCircuitOpen {
+				circuitEvents++
+				if event.Delay <= 0 {
+					t.Errorf("Expected a positive circuit wait, got %v", event.Delay)
+				}
+			}
+		}),
+	)
+
+	done := make(chan error, 1)
+	go func() { done <- sup.Run(context.Background()) }()
+
+	// Move the fake time on in one second steps whenever the supervisor is waiting
+	for running := true; running; {
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			running = false
+		case <-time.After(time.Millisecond):
+			if fake.Timers() > 0 {
+				fake.Advance(time.Second)
+			}
+		}
+	}
+
+	// The first restart delay, then the circuit is open twice for the full timeout
+	if elapsed := fake.Now().Sub(start); elapsed != 2*time.Minute+time.Second {
+		t.Errorf("Expected the session to start after 2m1s, got %v", elapsed)
+	}
+	if dials != 4 {
+		t.Errorf("Expected 4 dials, got %d", dials)
+	}
+	if dialedWhileOpen {
+		t.Error("Expected no dial while the circuit is open")
+	}
+	if circuitEvents != 2 {
+		t.Errorf("Expected 2 circuit_open lifecycle events, got %d", circuitEvents)
+	}
+	if breaker.State() != CircuitClosed {
+		t.Errorf("Expected state %s, got %s", CircuitClosed, breaker.State())
+	}
+}
@@ -0,0 +1,312 @@
+package messaging
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/audio"
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+	"github.com/Mliviu79/openai-realtime-go/messages/types"
+	"github.com/Mliviu79/openai-realtime-go/session"
+)
+
+//-----------------------------------------------------------------------------
+// Session Bridging
+//-----------------------------------------------------------------------------
+
+// DefaultBridgeTrailingSilence is the silence appended after each forwarded turn so that
+// server VAD on the receiving session detects the end of speech. It is longer than the
+// default VAD silence duration of 500ms.
+const DefaultBridgeTrailingSilence = 800 * time.Millisecond
+
+// BridgeDirection identifies a leg of an AudioBridge
+type BridgeDirection string
+
+const (
+	// BridgeAToB is the leg carrying session A's output into session B
+	BridgeAToB BridgeDirection = "a->b"
+	// BridgeBToA is the leg carrying session B's output into session A
+	BridgeBToA BridgeDirection = "b->a"
+)
+
+// AudioBridgeOption configures an AudioBridge
+type AudioBridgeOption func(*AudioBridge)
+
+// WithBridgeOneWay only forwards session A's output into session B, e.g. to feed an
+// agent's speech to an evaluation session whose own responses should not be heard
+func WithBridgeOneWay() AudioBridgeOption {
+	return func(b *AudioBridge) {
+		b.oneWay = true
+	}
+}
+
+// WithBridgeManualCommit commits the forwarded audio and requests a response on the
+// receiving session when each turn is done, for sessions without turn detection.
+// By default the receiving session's server VAD is relied on, and trailing silence is
+// appended instead.
+func WithBridgeManualCommit() AudioBridgeOption {
+	return func(b *AudioBridge) {
+		b.manualCommit = true
+	}
+}
+
+// WithBridgeTrailingSilence sets the silence appended after each forwarded turn when
+// relying on server VAD; the default is DefaultBridgeTrailingSilence
+func WithBridgeTrailingSilence(d time.Duration) AudioBridgeOption {
+	return func(b *AudioBridge) {
+		if d >= 0 {
+			b.trailingSilence = d
+		}
+	}
+}
+
+// WithBridgeAudioFormat sets the audio format both sessions use for input and output;
+// the default is session.AudioFormatPCM16
+func WithBridgeAudioFormat(format session.AudioFormat) AudioBridgeOption {
+	return func(b *AudioBridge) {
+		b.format = format
+	}
+}
+
+// WithOnBridgeTranscript sets a function called with the transcript or text of every
+// turn forwarded on a leg, e.g. to log an agent-to-agent conversation
+func WithOnBridgeTranscript(onTranscript func(direction BridgeDirection, text string)) AudioBridgeOption {
+	return func(b *AudioBridge) {
+		b.onTranscript = onTranscript
+	}
+}
+
+// WithOnBargeIn sets a function called when the receiving session starts responding
+// while a turn is still being forwarded to it, after the interrupted response is cancelled
+func WithOnBargeIn(onBargeIn func(direction BridgeDirection, responseID string)) AudioBridgeOption {
+	return func(b *AudioBridge) {
+		b.onBargeIn = onBargeIn
+	}
+}
+
+// bridgeLeg is the state of one direction of an AudioBridge
+type bridgeLeg struct {
+	direction BridgeDirection
+	from, to  *Client
+
+	// active is the response being forwarded, muted a response dropped after a barge-in
+	active string
+	muted  string
+
+	audioBytes int
+	text       strings.Builder
+}
+
+// AudioBridge pipes the output of one realtime session into another as input, for
+// agent-to-agent conversations or for feeding an agent to an evaluation session.
+//
+// Output audio is appended to the other session's input audio buffer as it arrives.
+// When a response is done, the turn is closed with trailing silence for server VAD, or
+// with a commit and response.create with WithBridgeManualCommit. Text-only responses
+// are forwarded as user text messages. If the receiving session starts responding while
+// a turn is still being forwarded, the forwarding response is cancelled and the rest of
+// its audio is dropped, so either side can barge in on the other.
+//
+// Both sessions must use the same audio format. Register HandleA with session A's
+// Handler and HandleB with session B's.
+//
+// Example:
+//
+//	bridge := messaging.NewAudioBridge(agentA, agentB)
+//	handlerA := messaging.NewHandler(ctx, agentA, bridge.HandleA)
+//	handlerB := messaging.NewHandler(ctx, agentB, bridge.HandleB)
+type AudioBridge struct {
+	oneWay          bool
+	manualCommit    bool
+	trailingSilence time.Duration
+	format          session.AudioFormat
+	onTranscript    func(direction BridgeDirection, text string)
+	onBargeIn       func(direction BridgeDirection, responseID string)
+
+	mu     sync.Mutex
+	aToB   *bridgeLeg
+	bToA   *bridgeLeg
+	closed bool
+}
+
+// NewAudioBridge creates an AudioBridge between sessions a and b
+func NewAudioBridge(a, b *Client, opts ...AudioBridgeOption) *AudioBridge {
+	bridge := &AudioBridge{
+		trailingSilence: DefaultBridgeTrailingSilence,
+		format:          session.AudioFormatPCM16,
+		aToB:            &bridgeLeg{direction: BridgeAToB, from: a, to: b},
+		bToA:            &bridgeLeg{direction: BridgeBToA, from: b, to: a},
+	}
+	for _, opt := range opts {
+		opt(bridge)
+	}
+	return bridge
+}
+
+// HandleA processes a message from session A. It has the MessageHandler signature.
+func (b *AudioBridge) HandleA(ctx context.Context, msg incoming.RcvdMsg) {
+	b.handle(ctx, msg, b.aToB, b.bToA)
+}
+
+// HandleB processes a message from session B. It has the MessageHandler signature.
+func (b *AudioBridge) HandleB(ctx context.Context, msg incoming.RcvdMsg) {
+	b.handle(ctx, msg, b.bToA, b.aToB)
+}
+
+// Close stops forwarding; messages handled afterwards are ignored
+func (b *AudioBridge) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.closed = true
+}
+
+// handle processes a message from the session that is the source of out and the target of in.
+// Sends and callbacks run after the lock is released.
+func (b *AudioBridge) handle(ctx context.Context, msg incoming.RcvdMsg, out, in *bridgeLeg) {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return
+	}
+	var actions []func()
+	if !b.oneWay || out.direction == BridgeAToB {
+		actions = append(actions, b.forward(ctx, msg, out)...)
+	}
+	if !b.oneWay || in.direction == BridgeAToB {
+		actions = append(actions, b.bargeIn(ctx, msg, in)...)
+	}
+	b.mu.Unlock()
+
+	for _, action := range actions {
+		action()
+	}
+}
+
+// forward handles the source side of a leg; the lock must be held
+func (b *AudioBridge) forward(ctx context.Context, msg incoming.RcvdMsg, leg *bridgeLeg) []func() {
+	switch m := msg.(type) {
+	case *incoming.ResponseCreatedMessage:
+		if leg.active == "" {
+			leg.active = m.Response.ID
+			leg.audioBytes = 0
+			leg.text.Reset()
+		}
+	case *incoming.ResponseOutputAudioDeltaMessage:
+		if m.ResponseID != leg.active || m.ResponseID == leg.muted {
+			return nil
+		}
+		leg.audioBytes += m.AudioLen()
+		delta := m.EncodedAudio()
+		return []func(){func() {
+			b.send(leg, "forward audio", leg.to.SendAudioBufferAppend(ctx, delta))
+		}}
+	case *incoming.ResponseOutputTextDoneMessage:
+		if m.ResponseID == leg.active && m.ResponseID != leg.muted {
+			leg.text.WriteString(m.Text)
+		}
+	case *incoming.ResponseOutputAudioTranscriptDoneMessage:
+		if m.ResponseID == leg.active && m.ResponseID != leg.muted && b.onTranscript != nil {
+			transcript := m.Transcript
+			return []func(){func() { b.onTranscript(leg.direction, transcript) }}
+		}
+	case *incoming.ResponseDoneMessage:
+		if m.Response.ID != leg.active {
+			return nil
+		}
+		muted := leg.active == leg.muted
+		leg.active, leg.muted = "", ""
+		if muted || m.Response.Status == types.ResponseStatusCancelled || m.Response.Status == types.ResponseStatusFailed {
+			return nil
+		}
+		if leg.audioBytes > 0 {
+			return []func(){b.closeAudioTurn(ctx, leg, leg.audioBytes)}
+		}
+		if text := leg.text.String(); text != "" {
+			return []func(){b.forwardText(ctx, leg, text)}
+		}
+	}
+	return nil
+}
+
+// bargeIn handles the target side of a leg: a response starting on the target while a
+// turn is being forwarded to it interrupts the source; the lock must be held
+func (b *AudioBridge) bargeIn(ctx context.Context, msg incoming.RcvdMsg, leg *bridgeLeg) []func() {
+	if _, ok := msg.(*incoming.ResponseCreatedMessage); !ok {
+		return nil
+	}
+	if leg.active == "" || leg.active == leg.muted {
+		return nil
+	}
+
+	responseID := leg.active
+	leg.muted = responseID
+	return []func(){func() {
+		b.send(leg, "cancel interrupted response", leg.from.SendResponseCancel(ctx, responseID))
+		if b.onBargeIn != nil {
+			b.onBargeIn(leg.direction, responseID)
+		}
+	}}
+}
+
+// closeAudioTurn ends a forwarded audio turn on the target
+func (b *AudioBridge) closeAudioTurn(ctx context.Context, leg *bridgeLeg, sent int) func() {
+	return func() {
+		if !b.manualCommit {
+			if silence := b.silence(b.format.BytesForDuration(b.trailingSilence)); len(silence) > 0 {
+				b.send(leg, "append trailing silence", leg.to.SendAudioBufferAppend(ctx, base64.StdEncoding.EncodeToString(silence)))
+			}
+			return
+		}
+
+		// Pad short turns so the commit is not rejected as empty
+		if pad := b.format.BytesForDuration(audio.MinCommitDuration) - sent; pad > 0 {
+			b.send(leg, "pad turn", leg.to.SendAudioBufferAppend(ctx, base64.StdEncoding.EncodeToString(b.silence(pad))))
+		}
+		if b.send(leg, "commit turn", leg.to.SendAudioBufferCommit(ctx, "")) {
+			b.send(leg, "request response", leg.to.SendResponseCreate(ctx, &types.ResponseConfig{}))
+		}
+	}
+}
+
+// forwardText sends a text-only turn to the target as a user message and requests a
+// response, since text input does not trigger turn detection
+func (b *AudioBridge) forwardText(ctx context.Context, leg *bridgeLeg, text string) func() {
+	return func() {
+		if b.onTranscript != nil {
+			b.onTranscript(leg.direction, text)
+		}
+		if b.send(leg, "forward text", leg.to.SendText(ctx, text)) {
+			b.send(leg, "request response", leg.to.SendResponseCreate(ctx, &types.ResponseConfig{}))
+		}
+	}
+}
+
+// silence returns n bytes of silence in the bridge's audio format
+func (b *AudioBridge) silence(n int) []byte {
+	if n <= 0 {
+		return nil
+	}
+	switch b.format {
+	case session.AudioFormatG711ULaw:
+		return bytes.Repeat([]byte{0xFF}, n)
+	case session.AudioFormatG711ALaw:
+		return bytes.Repeat([]byte{0xD5}, n)
+	default:
+		return make([]byte, n)
+	}
+}
+
+// send logs a failed send on the leg's target and reports whether it succeeded
+func (b *AudioBridge) send(leg *bridgeLeg, action string, err error) bool {
+	if err == nil {
+		return true
+	}
+	if leg.to.logger != nil {
+		leg.to.logger.Warnf("Audio bridge %s failed to %s: %v", leg.direction, action, err)
+	}
+	return false
+}
@@ -0,0 +1,184 @@
+package messaging
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+// sentEvent is the part of a client event checked by the bridge tests
+type sentEvent struct {
+	Type       string `json:"type"`
+	Audio      string `json:"audio"`
+	ResponseID string `json:"response_id"`
+}
+
+func parseSent(t *testing.T, messages []string) []sentEvent {
+	t.Helper()
+	events := make([]sentEvent, len(messages))
+	for i, msg := range messages {
+		if err := json.Unmarshal([]byte(msg), &events[i]); err != nil {
+			t.Fatalf("Failed to parse %s: %v", msg, err)
+		}
+	}
+	return events
+}
+
+func eventTypes(events []sentEvent) []string {
+	types := make([]string, len(events))
+	for i, event := range events {
+		types[i] = event.Type
+	}
+	return types
+}
+
+func audioLen(t *testing.T, event sentEvent) int {
+	t.Helper()
+	data, err := base64.StdEncoding.DecodeString(event.Audio)
+	if err != nil {
+		t.Fatalf("Failed to decode audio: %v", err)
+	}
+	return len(data)
+}
+
+func TestAudioBridgeForwardsTurns(t *testing.T) {
+	tests := []struct {
+		name          string
+		opts          []AudioBridgeOption
+		expectTypes   []string
+		expectPadding int
+	}{
+		{
+			name:          "server vad",
+			expectTypes:   []string{"input_audio_buffer.append", "input_audio_buffer.append"},
+			expectPadding: 38400, // 800ms of pcm16
+		},
+		{
+			name:          "manual commit",
+			opts:          []AudioBridgeOption{WithBridgeManualCommit()},
+			expectTypes:   []string{"input_audio_buffer.append", "input_audio_buffer.append", "input_audio_buffer.commit", "response.create"},
+			expectPadding: 4800 - 3, // up to 100ms of pcm16
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			connA, sentA, _ := recordingConn()
+			connB, sentB, _ := recordingConn()
+			var transcripts []string
+			opts := append(tt.opts, WithOnBridgeTranscript(func(direction BridgeDirection, text string) {
+				transcripts = append(transcripts, string(direction)+":"+text)
+			}))
+			bridge := NewAudioBridge(NewClient(ws.NewConn(connA)), NewClient(ws.NewConn(connB)), opts...)
+
+			ctx := context.Background()
+			for _, msg := range []string{
+				`{"type":"response.created","response":{"id":"resp_a","status":"in_progress"}}`,
+				`{"type":"response.output_audio.delta","response_id":"resp_a","item_id":"item_1","output_index":0,"content_index":0,"delta":"AAAA"}`,
+				`{"type":"response.output_audio.delta","response_id":"resp_other","item_id":"item_2","output_index":0,"content_index":0,"delta":"AAAA"}`,
+				`{"type":"response.output_audio_transcript.done","response_id":"resp_a","item_id":"item_1","output_index":0,"content_index":0,"transcript":"Hello there."}`,
+				`{"type":"response.done","response":{"id":"resp_a","status":"completed"}}`,
+			} {
+				bridge.HandleA(ctx, mustParse(t, msg))
+			}
+
+			if len(sentA()) != 0 {
+				t.Errorf("Expected nothing sent to session A, got %v", sentA())
+			}
+			events := parseSent(t, sentB())
+			if !slices.Equal(eventTypes(events), tt.expectTypes) {
+				t.Fatalf("Expected events %v, got %v", tt.expectTypes, eventTypes(events))
+			}
+			if events[0].Audio != "AAAA" {
+				t.Errorf("Expected the output audio to be forwarded, got %q", events[0].Audio)
+			}
+			if n := audioLen(t, events[1]); n != tt.expectPadding {
+				t.Errorf("Expected %d bytes of silence, got %d", tt.expectPadding, n)
+			}
+			if !slices.Equal(transcripts, []string{"a->b:Hello there."}) {
+				t.Errorf("Expected the transcript of the turn, got %v", transcripts)
+			}
+		})
+	}
+}
+
+func TestAudioBridgeBargeIn(t *testing.T) {
+	connA, sentA, _ := recordingConn()
+	connB, sentB, _ := recordingConn()
+	var interrupted []string
+	bridge := NewAudioBridge(NewClient(ws.NewConn(connA)), NewClient(ws.NewConn(connB)),
+		WithOnBargeIn(func(direction BridgeDirection, responseID string) {
+			interrupted = append(interrupted, string(direction)+":"+responseID)
+		}),
+	)
+
+	ctx := context.Background()
+	bridge.HandleA(ctx, mustParse(t, `{"type":"response.created","response":{"id":"resp_a","status":"in_progress"}}`))
+	bridge.HandleA(ctx, mustParse(t, `{"type":"response.output_audio.delta","response_id":"resp_a","item_id":"item_1","output_index":0,"content_index":0,"delta":"AAAA"}`))
+
+	// Session B starts answering before A is done
+	bridge.HandleB(ctx, mustParse(t, `{"type":"response.created","response":{"id":"resp_b","status":"in_progress"}}`))
+
+	bridge.HandleA(ctx, mustParse(t, `{"type":"response.output_audio.delta","response_id":"resp_a","item_id":"item_1","output_index":0,"content_index":0,"delta":"BBBB"}`))
+	bridge.HandleA(ctx, mustParse(t, `{"type":"response.done","response":{"id":"resp_a","status":"cancelled"}}`))
+
+	eventsA := parseSent(t, sentA())
+	if len(eventsA) != 1 || eventsA[0].Type != "response.cancel" || eventsA[0].ResponseID != "resp_a" {
+		t.Errorf("Expected the interrupted response to be cancelled, got %v", sentA())
+	}
+	if len(sentB()) != 1 {
+		t.Errorf("Expected only audio from before the barge-in to reach B, got %v", sentB())
+	}
+	if !slices.Equal(interrupted, []string{"a->b:resp_a"}) {
+		t.Errorf("Expected a barge-in on a->b, got %v", interrupted)
+	}
+
+	// B's response is forwarded to A as usual, and A can take the next turn
+	bridge.HandleB(ctx, mustParse(t, `{"type":"response.output_audio.delta","response_id":"resp_b","item_id":"item_2","output_index":0,"content_index":0,"delta":"CCCC"}`))
+	if events := parseSent(t, sentA()); len(events) != 2 || events[1].Audio != "CCCC" {
+		t.Errorf("Expected B's audio to be forwarded to A, got %v", sentA())
+	}
+}
+
+func TestAudioBridgeTextAndOneWay(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        []AudioBridgeOption
+		expectTypes []string
+	}{
+		{
+			name:        "two way",
+			expectTypes: []string{"conversation.item.create", "response.create"},
+		},
+		{
+			name:        "one way",
+			opts:        []AudioBridgeOption{WithBridgeOneWay()},
+			expectTypes: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			connA, sentA, _ := recordingConn()
+			connB, _, _ := recordingConn()
+			bridge := NewAudioBridge(NewClient(ws.NewConn(connA)), NewClient(ws.NewConn(connB)), tt.opts...)
+
+			ctx := context.Background()
+			for _, msg := range []string{
+				`{"type":"response.created","response":{"id":"resp_b","status":"in_progress"}}`,
+				`{"type":"response.output_text.done","response_id":"resp_b","item_id":"item_1","output_index":0,"content_index":0,"text":"Score: 4/5"}`,
+				`{"type":"response.done","response":{"id":"resp_b","status":"completed"}}`,
+			} {
+				bridge.HandleB(ctx, mustParse(t, msg))
+			}
+
+			if types := eventTypes(parseSent(t, sentA())); !slices.Equal(types, tt.expectTypes) {
+				t.Errorf("Expected events %v, got %v", tt.expectTypes, types)
+			}
+		})
+	}
+}
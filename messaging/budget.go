@@ -0,0 +1,261 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+	"github.com/Mliviu79/openai-realtime-go/messages/types"
+	"github.com/Mliviu79/openai-realtime-go/session"
+)
+
+//-----------------------------------------------------------------------------
+// Budget Guard
+//-----------------------------------------------------------------------------
+
+// DefaultWrapUpTimeout is how long a BudgetGuard waits for the wrap-up response before closing
+const DefaultWrapUpTimeout = 10 * time.Second
+
+// budgetGuardMetadataKey tags the wrap-up response so the guard can recognize it
+const budgetGuardMetadataKey = "budget_guard"
+
+// BudgetLimit identifies the limit a BudgetGuard enforced
+type BudgetLimit string
+
+const (
+	// BudgetLimitTokens is the limit on total tokens
+	BudgetLimitTokens BudgetLimit = "tokens"
+
+	// BudgetLimitCost is the limit on estimated cost in US dollars
+	BudgetLimitCost BudgetLimit = "cost"
+)
+
+// BudgetExceededError is the reason a BudgetGuard stopped a session
+type BudgetExceededError struct {
+	// Limit is the limit that was exceeded
+	Limit BudgetLimit
+
+	// Used is the amount used when the limit was exceeded (tokens or US dollars)
+	Used float64
+
+	// Max is the configured maximum (tokens or US dollars)
+	Max float64
+}
+
+// Error implements the error interface
+func (e *BudgetExceededError) Error() string {
+	if e.Limit == BudgetLimitCost {
+		return fmt.Sprintf("session budget exceeded: estimated cost $%.4f exceeds $%.4f", e.Used, e.Max)
+	}
+	return fmt.Sprintf("session budget exceeded: %.0f tokens exceeds %.0f", e.Used, e.Max)
+}
+
+// BudgetGuardOption configures a BudgetGuard
+type BudgetGuardOption func(*BudgetGuard)
+
+// WithMaxTokens stops the session once the total tokens used exceed maxTokens
+func WithMaxTokens(maxTokens int) BudgetGuardOption {
+	return func(g *BudgetGuard) {
+		g.maxTokens = maxTokens
+	}
+}
+
+// WithMaxCost stops the session once the estimated cost, computed with pricing, exceeds maxUSD
+func WithMaxCost(maxUSD float64, pricing Pricing) BudgetGuardOption {
+	return func(g *BudgetGuard) {
+		g.maxCost = maxUSD
+		g.pricing = pricing
+	}
+}
+
+// WithWrapUpInstructions asks the model for one last response with the given instructions
+// (for example, to tell the caller the session is ending) before the session is closed.
+// The guard waits up to timeout for it to finish; zero or less uses DefaultWrapUpTimeout.
+func WithWrapUpInstructions(instructions string, timeout time.Duration) BudgetGuardOption {
+	return func(g *BudgetGuard) {
+		g.wrapUp = instructions
+		if timeout <= 0 {
+			timeout = DefaultWrapUpTimeout
+		}
+		g.wrapUpTimeout = timeout
+	}
+}
+
+// WithOnBudgetExceeded sets a function that is called with the reason once the session is closed
+func WithOnBudgetExceeded(onExceeded func(reason *BudgetExceededError)) BudgetGuardOption {
+	return func(g *BudgetGuard) {
+		g.onExceeded = onExceeded
+	}
+}
+
+// BudgetGuard stops a session when its token usage or estimated cost exceeds a limit.
+// When the limit is exceeded it cancels active responses, optionally sends a wrap-up
+// instruction and waits for it to finish, and then closes the client.
+//
+// Usage is read from a UsageAggregator, which is updated on every response.done.
+// The guard's Handle must be registered so it can track active responses.
+//
+// Example:
+//
+//	usage := messaging.NewUsageAggregator()
+//	guard := messaging.NewBudgetGuard(msgClient, usage,
+//		messaging.WithMaxTokens(50_000),
+//		messaging.WithWrapUpInstructions("Tell the caller the session has ended.", 0),
+//	)
+//	handler := messaging.NewHandler(ctx, msgClient, usage.Handle, guard.Handle)
+//	// ...
+//	<-guard.Done()
+//	log.Print(guard.Reason())
+type BudgetGuard struct {
+	mu            sync.Mutex
+	client        *Client
+	maxTokens     int
+	maxCost       float64
+	pricing       Pricing
+	wrapUp        string
+	wrapUpTimeout time.Duration
+	onExceeded    func(*BudgetExceededError)
+
+	active     map[string]bool
+	reason     *BudgetExceededError
+	wrapUpID   string
+	wrapUpDone chan struct{}
+	done       chan struct{}
+}
+
+// NewBudgetGuard creates a BudgetGuard that enforces the configured limits on the usage
+// reported by usage. Limits that are not configured are not enforced.
+func NewBudgetGuard(client *Client, usage *UsageAggregator, opts ...BudgetGuardOption) *BudgetGuard {
+	g := &BudgetGuard{
+		client:     client,
+		active:     make(map[string]bool),
+		wrapUpDone: make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	usage.OnUpdate(g.check)
+	return g
+}
+
+// Handle tracks the responses in progress, which are cancelled when the budget is
+// exceeded, and the wrap-up response the guard waits for before closing the client.
+func (g *BudgetGuard) Handle(ctx context.Context, msg incoming.RcvdMsg) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	switch m := msg.(type) {
+	case *incoming.ResponseCreatedMessage:
+		if m.Response.Metadata[budgetGuardMetadataKey] == "wrap_up" {
+			g.wrapUpID = m.Response.ID
+			return
+		}
+		g.active[m.Response.ID] = true
+	case *incoming.ResponseDoneMessage:
+		delete(g.active, m.Response.ID)
+		if g.wrapUpID != "" && m.Response.ID == g.wrapUpID {
+			close(g.wrapUpDone)
+			g.wrapUpID = ""
+		}
+	}
+}
+
+// Reason returns why the guard stopped the session, or nil if the budget has not been exceeded
+func (g *BudgetGuard) Reason() *BudgetExceededError {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.reason
+}
+
+// Done returns a channel that is closed once the guard has closed the session
+func (g *BudgetGuard) Done() <-chan struct{} {
+	return g.done
+}
+
+// check compares the totals with the limits and stops the session the first time one is exceeded
+func (g *BudgetGuard) check(totals types.Usage) {
+	var reason *BudgetExceededError
+	if g.maxTokens > 0 && totals.TotalTokens > g.maxTokens {
+		reason = &BudgetExceededError{Limit: BudgetLimitTokens, Used: float64(totals.TotalTokens), Max: float64(g.maxTokens)}
+	} else if cost := g.pricing.Cost(totals); g.maxCost > 0 && cost > g.maxCost {
+		reason = &BudgetExceededError{Limit: BudgetLimitCost, Used: cost, Max: g.maxCost}
+	}
+	if reason == nil {
+		return
+	}
+
+	g.mu.Lock()
+	if g.reason != nil {
+		g.mu.Unlock()
+		return
+	}
+	g.reason = reason
+	active := make([]string, 0, len(g.active))
+	for id := range g.active {
+		active = append(active, id)
+	}
+	g.mu.Unlock()
+
+	// Stop in the background: waiting for the wrap-up response needs the reader to keep running
+	go g.stop(reason, active)
+}
+
+// stop cancels the active responses, runs the optional wrap-up and closes the client
+func (g *BudgetGuard) stop(reason *BudgetExceededError, active []string) {
+	defer close(g.done)
+	defer g.client.recoverPanic("budget guard")
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultCancelTimeout)
+	for _, id := range active {
+		if err := g.client.SendResponseCancel(ctx, id); err != nil {
+			if log := g.client.getLogger(); log != nil {
+				log.Warnf("Failed to cancel response %s after budget was exceeded: %v", id, err)
+			}
+		}
+	}
+	cancel()
+
+	if g.wrapUp != "" {
+		g.runWrapUp()
+	}
+
+	if err := g.client.Close(); err != nil {
+		if log := g.client.getLogger(); log != nil {
+			log.Warnf("Failed to close session after budget was exceeded: %v", err)
+		}
+	}
+	if g.onExceeded != nil {
+		g.onExceeded(reason)
+	}
+}
+
+// runWrapUp requests the wrap-up response and waits for it to finish or time out
+func (g *BudgetGuard) runWrapUp() {
+	ctx, cancel := context.WithTimeout(context.Background(), g.wrapUpTimeout)
+	defer cancel()
+
+	instructions := g.wrapUp
+	err := g.client.SendResponseCreate(ctx, &types.ResponseConfig{
+		ResponseParams: session.ResponseParams{
+			Instructions: &instructions,
+		},
+		Metadata: map[string]string{budgetGuardMetadataKey: "wrap_up"},
+	})
+	if err != nil {
+		if log := g.client.getLogger(); log != nil {
+			log.Warnf("Failed to send wrap-up response after budget was exceeded: %v", err)
+		}
+		return
+	}
+
+	select {
+	case <-g.wrapUpDone:
+	case <-ctx.Done():
+		if log := g.client.getLogger(); log != nil {
+			log.Warnf("Timed out waiting for wrap-up response after budget was exceeded")
+		}
+	}
+}
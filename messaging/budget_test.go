@@ -0,0 +1,133 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/messages/types"
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+// recordingConn returns a MockConn that records sent messages and whether it was closed
+func recordingConn() (*MockConn, func() []string, func() bool) {
+	var mu sync.Mutex
+	var sent []string
+	closed := false
+	conn := &MockConn{
+		WriteMessageFunc: func(ctx context.Context, messageType ws.MessageType, data []byte) error {
+			mu.Lock()
+			defer mu.Unlock()
+			sent = append(sent, string(data))
+			return nil
+		},
+		CloseFunc: func() error {
+			mu.Lock()
+			defer mu.Unlock()
+			closed = true
+			return nil
+		},
+	}
+	return conn, func() []string {
+			mu.Lock()
+			defer mu.Unlock()
+			return append([]string(nil), sent...)
+		}, func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			return closed
+		}
+}
+
+func TestBudgetGuardTokens(t *testing.T) {
+	conn, sent, closed := recordingConn()
+	client := NewClient(ws.NewConn(conn))
+	usage := NewUsageAggregator()
+
+	var reason *BudgetExceededError
+	guard := NewBudgetGuard(client, usage,
+		WithMaxTokens(100),
+		WithOnBudgetExceeded(func(r *BudgetExceededError) { reason = r }),
+	)
+
+	ctx := context.Background()
+	guard.Handle(ctx, mustParse(t, `{"type":"response.created","response":{"id":"resp_1","status":"in_progress"}}`))
+	guard.Handle(ctx, mustParse(t, `{"type":"response.created","response":{"id":"resp_2","status":"in_progress"}}`))
+	guard.Handle(ctx, mustParse(t, `{"type":"response.done","response":{"id":"resp_1","status":"completed"}}`))
+
+	usage.Add(types.Usage{TotalTokens: 60})
+	if guard.Reason() != nil {
+		t.Fatalf("Expected budget not to be exceeded, got %v", guard.Reason())
+	}
+	usage.Add(types.Usage{TotalTokens: 60})
+
+	select {
+	case <-guard.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for the guard to stop the session")
+	}
+
+	if !closed() {
+		t.Error("Expected the client to be closed")
+	}
+	messages := sent()
+	if len(messages) != 1 || !strings.Contains(messages[0], `"response.cancel"`) || !strings.Contains(messages[0], `"resp_2"`) {
+		t.Errorf("Expected a single cancel for resp_2, got %v", messages)
+	}
+	if reason == nil || reason.Limit != BudgetLimitTokens || reason.Used != 120 || reason.Max != 100 {
+		t.Errorf("Expected tokens reason 120/100, got %+v", reason)
+	}
+	var err error = guard.Reason()
+	var budgetErr *BudgetExceededError
+	if !errors.As(err, &budgetErr) {
+		t.Errorf("Expected Reason to be a *BudgetExceededError, got %T", err)
+	}
+}
+
+func TestBudgetGuardCostWithWrapUp(t *testing.T) {
+	conn, sent, closed := recordingConn()
+	client := NewClient(ws.NewConn(conn))
+	usage := NewUsageAggregator()
+	guard := NewBudgetGuard(client, usage,
+		WithMaxCost(0.01, Pricing{TextOutput: 10}),
+		WithWrapUpInstructions("Say goodbye.", time.Second),
+	)
+
+	usage.Add(types.Usage{OutputTokenDetails: types.TokenDetails{TextTokens: 2000}})
+
+	// Wait for the wrap-up request
+	deadline := time.After(time.Second)
+	for len(sent()) == 0 {
+		select {
+		case <-deadline:
+			t.Fatal("Timed out waiting for the wrap-up response.create")
+		case <-time.After(time.Millisecond):
+		}
+	}
+	messages := sent()
+	if !strings.Contains(messages[0], `"response.create"`) || !strings.Contains(messages[0], "Say goodbye.") {
+		t.Fatalf("Expected wrap-up response.create, got %v", messages)
+	}
+	if closed() {
+		t.Fatal("Expected the client to stay open until the wrap-up finishes")
+	}
+
+	ctx := context.Background()
+	guard.Handle(ctx, mustParse(t, `{"type":"response.created","response":{"id":"resp_wrap","status":"in_progress","metadata":{"budget_guard":"wrap_up"}}}`))
+	guard.Handle(ctx, mustParse(t, `{"type":"response.done","response":{"id":"resp_wrap","status":"completed","metadata":{"budget_guard":"wrap_up"}}}`))
+
+	select {
+	case <-guard.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for the guard to stop the session")
+	}
+	if !closed() {
+		t.Error("Expected the client to be closed")
+	}
+	if reason := guard.Reason(); reason == nil || reason.Limit != BudgetLimitCost {
+		t.Errorf("Expected cost reason, got %+v", reason)
+	}
+}
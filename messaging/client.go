@@ -49,12 +49,17 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"sync"
+	"time"
 
+	"github.com/Mliviu79/openai-realtime-go/apierrs"
+	"github.com/Mliviu79/openai-realtime-go/clock"
 	"github.com/Mliviu79/openai-realtime-go/logger"
 	"github.com/Mliviu79/openai-realtime-go/messages/factory"
 	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
 	"github.com/Mliviu79/openai-realtime-go/messages/outgoing"
+	"github.com/Mliviu79/openai-realtime-go/messages/schema"
 	"github.com/Mliviu79/openai-realtime-go/messages/types"
 	"github.com/Mliviu79/openai-realtime-go/session"
 	"github.com/Mliviu79/openai-realtime-go/ws"
@@ -62,11 +67,114 @@ import (
 
 // Client is a client for the OpenAI Realtime API that handles message serialization/deserialization.
 // It provides high-level methods for sending different types of messages and processing responses.
-// All methods are thread-safe and can be called from multiple goroutines.
+//
+// All methods are thread-safe, with these guarantees:
+//   - Send methods can be called from any number of goroutines; writes are serialized
+//     and each frame is written whole.
+//   - Messages must have a single consumer: one goroutine calling ReadMessage, or one
+//     Handler, but not both.
+//   - Close can be called from any goroutine, at any time and more than once. Once the
+//     client is closed or its connection has failed, Done is closed and sends return
+//     the cause reported by Err without writing.
 type Client struct {
 	mu     sync.RWMutex
 	conn   *ws.Conn
 	logger logger.Logger
+
+	// onPanic is called with panics recovered from handlers, which panic again if
+	// rethrowPanics is set
+	onPanic       ws.PanicHandler
+	rethrowPanics bool
+
+	// done is closed once the client has ended, with doneErr as the cause
+	done     chan struct{}
+	doneOnce sync.Once
+	doneErr  error
+
+	// gate serializes writes and lets interruptions bypass queued messages
+	gate sendGate
+
+	// sendTimeout, if positive, bounds sends made with a context that has no deadline
+	sendTimeout time.Duration
+
+	// validator, if set, checks every incoming frame against the published event schemas
+	validator  *schema.Validator
+	onMismatch SchemaMismatchFunc
+
+	// dumper, if set, records every raw frame read and, optionally, written
+	dumper *EventDumper
+
+	// sendObservers and receiveObservers are called with every frame written and read
+	sendObservers    []WireObserver
+	receiveObservers []WireObserver
+
+	// receipts, if set, tracks the server confirmations of outgoing events
+	receipts *ReceiptTracker
+
+	// ids, if set, replaces the random event and item IDs the client assigns
+	ids IDGenerator
+
+	// ordering, if enabled, fills in the previous item ID of items sent without one
+	ordering itemOrdering
+
+	// autoResponse, if set, is requested after every SendText and SendAudio
+	autoResponse *types.ResponseConfig
+
+	// guardrails check the text of outgoing items and instructions
+	guardrails []Guardrail
+
+	// normalizer, if set, normalizes received text; pending holds the messages it
+	// produced that ReadMessage has not returned yet
+	normalizer *TextNormalizer
+	pending    []incoming.RcvdMsg
+
+	// inlineAudioLimit, if positive, is the size beyond which received item audio is dropped
+	inlineAudioLimit int
+
+	// audioPool, if set, receives the decoded audio of audio deltas
+	audioPool *incoming.AudioPool
+
+	// session is the latest session reported by the server via session.created or session.updated
+	session *session.Session
+
+	// locale is the user's locale set with SetLocale
+	locale session.Locale
+
+	// conversationID is the ID reported by the server via conversation.created
+	conversationID string
+
+	// inputAudio tracks the audio appended but not yet committed
+	inputAudio inputAudioBuffer
+
+	// activeResponseID is the ID of the response reported by response.created that has
+	// not been reported done yet
+	activeResponseID string
+
+	// responsePolicy decides what happens to a response.create while a response is in
+	// progress; responseRequested is set from sending a response.create, with the event
+	// ID responseEventID, until the server reports it created or an error for it, and
+	// turnMu serializes the requests. responseReset is closed when a handoff forgets the
+	// responses of the old session.
+	responsePolicy    ResponsePolicy
+	responseRequested bool
+	responseEventID   string
+	responseReset     chan struct{}
+	turnMu            sync.Mutex
+
+	// retryPolicy, if set, decides whether failed responses are created again; retries
+	// holds the stamped requests by request ID, numbered by retrySeq
+	retryPolicy *ResponseRetryPolicy
+	retries     map[string]*responseRetry
+	retrySeq    int
+
+	// rateLimits are the latest limits reported via rate_limits.updated, by name;
+	// clock times their resets
+	rateLimits map[string]RateLimitSnapshot
+	clock      clock.Clock
+
+	// waiters are notified of incoming messages matching their predicate
+	waitersMu sync.Mutex
+	waiters   map[*waiter]struct{}
 }
 
 // NewClient creates a new messaging client that wraps a WebSocket connection.
@@ -74,13 +182,20 @@ type Client struct {
 //
 // Parameters:
 //   - conn: A WebSocket connection wrapper (usually obtained from openai.Connect)
+//   - opts: Options such as WithSendTimeout
 //
 // Returns:
 //   - A new Client instance that can be used to send and receive messages
-func NewClient(conn *ws.Conn) *Client {
-	return &Client{
-		conn: conn,
+func NewClient(conn *ws.Conn, opts ...ClientOption) *Client {
+	c := &Client{
+		conn:  conn,
+		clock: clock.Real(),
+		done:  make(chan struct{}),
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
 }
 
 // SetLogger sets the logger for the client.
@@ -94,41 +209,156 @@ func (c *Client) SetLogger(logger logger.Logger) {
 	c.conn.SetLogger(logger)
 }
 
+// getLogger returns the client's logger, or nil if none is set
+func (c *Client) getLogger() logger.Logger {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.logger
+}
+
 // Close closes the underlying connection.
-// After closing, no more messages can be sent or received.
-// This method is thread-safe and can be called from any goroutine.
+// After closing, no more messages can be sent or received: Done is closed and sends
+// return ErrClientClosed. This method is thread-safe and can be called from any goroutine.
 func (c *Client) Close() error {
-	return c.conn.Close()
+	c.finish(ErrClientClosed)
+	return c.connection().Close()
 }
 
 // Ping sends a ping to the server to keep the connection alive.
 // This can be useful for long-lived connections to prevent timeouts.
 // This method is thread-safe and can be called from any goroutine.
 func (c *Client) Ping(ctx context.Context) error {
-	return c.conn.Ping(ctx)
+	return c.connection().Ping(ctx)
 }
 
 // SendMessage sends a message to the server.
 // This is a low-level method that takes any message implementing the OutMsg interface.
 // Most users should use higher-level methods like SendText, SendAudio, etc.
 //
+// Writes are serialized. When several goroutines are waiting to send, response.cancel
+// and input_audio_buffer.clear are written before any other waiting message, so an
+// interruption is not stuck behind queued audio appends.
+//
+// Conversation items and instructions are checked by the guardrails set with
+// SetGuardrails before they are sent; a blocked message returns a *PolicyError.
+// A response.create sent while another response is in progress is handled according
+// to SetResponsePolicy.
+//
 // Parameters:
-//   - ctx: A context for cancellation and timeouts
+//   - ctx: A context for cancellation and timeouts; without a deadline, the send is
+//     bounded by the timeout set with WithSendTimeout
 //   - msg: The message to send, must implement outgoing.OutMsg
 //
 // Returns:
 //   - An error if the message could not be sent
 func (c *Client) SendMessage(ctx context.Context, msg outgoing.OutMsg) error {
+	ctx, cancel := c.sendContext(ctx)
+	defer cancel()
+
+	msg, err := c.applyGuardrails(ctx, msg)
+	if err != nil {
+		return err
+	}
+	msg = c.withResponseEventID(msg)
+
 	data, err := json.Marshal(msg)
 	if err != nil {
 		return fmt.Errorf("failed to marshal message: %w", err)
 	}
 
-	if c.logger != nil {
-		c.logger.Debugf("sending message: type=%s data=%s", msg.OutMsgType(), string(data))
+	if log := c.getLogger(); log != nil {
+		log.Debugf("sending message: type=%s data=%s", msg.OutMsgType(), string(data))
+	}
+
+	admitted, err := c.admitResponse(ctx, msg)
+	if err != nil {
+		return err
 	}
 
-	return c.conn.SendRaw(ctx, ws.MessageText, data)
+	c.checkInputAudio(msg)
+	undo := c.trackSentInputAudio(msg)
+	if err := c.write(ctx, outgoing.OutMsgType(msg.OutMsgType()), data); err != nil {
+		undo()
+		admitted(false)
+		return err
+	}
+	admitted(true)
+	return nil
+}
+
+// SendRaw sends a pre-encoded JSON client event to the server.
+// It is intended for experimental or newly released events that do not have a typed
+// message in the outgoing package yet; prefer SendMessage and the typed helpers otherwise.
+// The data must be a JSON object with a non-empty "type" field.
+//
+// Example:
+//
+//	err := msgClient.SendRaw(ctx, []byte(`{"type":"experimental.event","value":1}`))
+//
+// Server events without a typed message can be read through Conn().ReadRaw.
+// A context without a deadline is bounded by WithSendTimeout.
+func (c *Client) SendRaw(ctx context.Context, data []byte) error {
+	var base struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(data, &base); err != nil {
+		return fmt.Errorf("raw event must be a JSON object: %w", err)
+	}
+	if base.Type == "" {
+		return fmt.Errorf("raw event must have a type")
+	}
+
+	if log := c.getLogger(); log != nil {
+		log.Debugf("sending raw message: type=%s data=%s", base.Type, string(data))
+	}
+
+	ctx, cancel := c.sendContext(ctx)
+	defer cancel()
+	return c.write(ctx, outgoing.OutMsgType(base.Type), data)
+}
+
+// write sends a text frame once the send gate admits it.
+// Urgent frames are written before any queued non-urgent frames.
+func (c *Client) write(ctx context.Context, msgType outgoing.OutMsgType, data []byte) error {
+	if err := c.checkOpen(); err != nil {
+		return err
+	}
+	if err := c.gate.acquire(ctx, isUrgent(msgType)); err != nil {
+		return err
+	}
+	defer c.gate.release()
+
+	// The receipt is recorded before writing so a fast confirmation cannot be missed
+	tracker := c.receiptTracker()
+	var eventID string
+	if tracker != nil {
+		data, eventID = tracker.track(msgType, data, c.newID)
+	}
+
+	if err := c.connection().SendRaw(ctx, ws.MessageText, data); err != nil {
+		if eventID != "" {
+			tracker.discard(eventID)
+		}
+		return err
+	}
+	c.dumpFrame(DumpDirectionOutgoing, data)
+	c.notifySent(string(msgType), data)
+	return nil
+}
+
+// Conn returns the WebSocket connection used by the client.
+// It is an escape hatch for reading or writing frames the typed API does not cover.
+// Messages read directly from the connection are not seen by the client's state tracking.
+// After a handoff (see Handler.Handoff) it returns the connection to the new session.
+func (c *Client) Conn() *ws.Conn {
+	return c.connection()
+}
+
+// connection returns the current connection, which changes when the conversation is handed off
+func (c *Client) connection() *ws.Conn {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.conn
 }
 
 // ReadMessage reads a message from the server.
@@ -140,33 +370,334 @@ func (c *Client) SendMessage(ctx context.Context, msg outgoing.OutMsg) error {
 //
 // Returns:
 //   - A message implementing the incoming.RcvdMsg interface
-//   - An error if the message could not be read or deserialized
+//   - An error if the message could not be read or deserialized. A message larger than
+//     the connection's read limit is discarded and reported as a *ws.FrameTooLargeError
+//     naming its event type and size; reading can continue with the next message.
 func (c *Client) ReadMessage(ctx context.Context) (incoming.RcvdMsg, error) {
-	messageType, data, err := c.conn.ReadRaw(ctx)
-	if err != nil {
-		return nil, err
+	for {
+		if msg, ok := c.nextPending(); ok {
+			c.observe(msg)
+			return msg, nil
+		}
+
+		conn := c.connection()
+		messageType, data, err := conn.ReadRaw(ctx)
+		if err != nil {
+			c.readFailed(ctx, conn, err)
+			return nil, err
+		}
+
+		if messageType != ws.MessageText {
+			return nil, fmt.Errorf("expected text message, got %s", messageType.String())
+		}
+
+		c.dumpFrame(DumpDirectionIncoming, data)
+		c.notifyReceived(data)
+		c.validateFrame(data)
+
+		msg, err := c.unmarshal(data)
+		if err != nil {
+			return nil, err
+		}
+
+		msgs := c.normalizeText(msg)
+		c.mu.Lock()
+		c.pending = append(c.pending, msgs...)
+		c.mu.Unlock()
 	}
+}
 
-	if messageType != ws.MessageText {
-		return nil, fmt.Errorf("expected text message, got %s", messageType.String())
+// nextPending removes and returns the first message waiting to be returned by ReadMessage
+func (c *Client) nextPending() (incoming.RcvdMsg, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.pending) == 0 {
+		return nil, false
 	}
+	msg := c.pending[0]
+	c.pending = c.pending[1:]
+	return msg, true
+}
+
+// SetTextNormalizer makes the client normalize and sanitize the text of received text
+// and transcript deltas before they are delivered by ReadMessage or a Handler. Passing
+// nil turns normalization off.
+//
+// Example:
+//
+//	msgClient.SetTextNormalizer(messaging.NewTextNormalizer())
+func (c *Client) SetTextNormalizer(normalizer *TextNormalizer) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.normalizer = normalizer
+}
+
+// SetAudioPool makes the client decode the audio of response.output_audio.delta
+// straight into buffers from pool, skipping the base64 string that is otherwise
+// allocated for every audio frame. Passing nil turns pooling off.
+//
+// With a pool set, audio delta messages carry their audio in the Audio field and
+// Delta is empty; use AudioLen, DecodeAudio or EncodedAudio to read either form. A
+// Handler releases each buffer after its handlers return, so handlers must copy the
+// audio to keep it. Callers of ReadMessage should call Release once done with the
+// audio; buffers that are not released are reclaimed by the garbage collector.
+//
+// Example:
+//
+//	msgClient.SetAudioPool(incoming.NewAudioPool())
+func (c *Client) SetAudioPool(pool *incoming.AudioPool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.audioPool = pool
+}
+
+// unmarshal decodes a received frame, into a pooled buffer if an audio pool is set,
+// stamps it with the time it was received and applies the inline audio limit
+func (c *Client) unmarshal(data []byte) (incoming.RcvdMsg, error) {
+	c.mu.RLock()
+	pool := c.audioPool
+	receivedAt := c.clock.Now()
+	c.mu.RUnlock()
 
-	msg, err := incoming.UnmarshalRcvdMsg(data)
+	msg, err := incoming.UnmarshalRcvdMsgPooled(data, pool)
 	if err != nil {
-		return nil, err
+		return msg, err
 	}
-
+	if stamped, ok := msg.(incoming.Timestamped); ok {
+		stamped.SetReceivedAt(receivedAt)
+	}
+	c.dropInlineAudio(msg)
 	return msg, nil
 }
 
+// normalizeText returns the messages to deliver for msg, normalized if a text
+// normalizer is set
+func (c *Client) normalizeText(msg incoming.RcvdMsg) []incoming.RcvdMsg {
+	c.mu.RLock()
+	normalizer := c.normalizer
+	c.mu.RUnlock()
+
+	if normalizer == nil {
+		return []incoming.RcvdMsg{msg}
+	}
+	return normalizer.Normalize(msg)
+}
+
+// SchemaMismatchFunc is called with the event type and the mismatches found when an
+// incoming frame does not conform to its schema
+type SchemaMismatchFunc func(eventType string, mismatches []string)
+
+// SetSchemaValidation enables validation of every incoming frame against the published
+// event schemas, which catches fields silently dropped because the typed messages have
+// drifted from the server. Validation is diagnostic only: frames are still decoded
+// and delivered when they do not conform.
+//
+// Mismatches are passed to onMismatch, which can forward them to metrics; if onMismatch
+// is nil they are logged as warnings. Passing a nil validator disables validation.
+//
+// Example:
+//
+//	validator, err := schema.NewValidator()
+//	if err != nil {
+//		return err
+//	}
+//	msgClient.SetSchemaValidation(validator, nil)
+func (c *Client) SetSchemaValidation(validator *schema.Validator, onMismatch SchemaMismatchFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.validator = validator
+	c.onMismatch = onMismatch
+}
+
+// validateFrame checks a raw incoming frame against its schema if validation is enabled
+func (c *Client) validateFrame(data []byte) {
+	c.mu.RLock()
+	validator, onMismatch, log := c.validator, c.onMismatch, c.logger
+	c.mu.RUnlock()
+
+	if validator == nil {
+		return
+	}
+
+	mismatches := validator.Validate(data)
+	if len(mismatches) == 0 {
+		return
+	}
+
+	var base struct {
+		Type string `json:"type"`
+	}
+	_ = json.Unmarshal(data, &base)
+
+	if onMismatch != nil {
+		onMismatch(base.Type, mismatches)
+		return
+	}
+	if log != nil {
+		log.Warnf("Incoming %s event does not match schema: %s", base.Type, strings.Join(mismatches, "; "))
+	}
+}
+
+// SetEventDumper makes the client record every raw frame it reads, and the frames it
+// writes if the dumper was created with WithDumpOutgoing. Frames are recorded before
+// they are decoded. Passing nil stops recording.
+//
+// Frames are only seen for messages received through ReadMessage or a Handler.
+func (c *Client) SetEventDumper(dumper *EventDumper) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.dumper = dumper
+}
+
+// dumpFrame records a raw frame if an event dumper is set
+func (c *Client) dumpFrame(direction string, data []byte) {
+	c.mu.RLock()
+	dumper := c.dumper
+	c.mu.RUnlock()
+
+	if dumper != nil {
+		dumper.Dump(direction, data)
+	}
+}
+
+// observe updates the client's tracked state from an incoming message.
+// It is called for every message read through ReadMessage or dispatched by a Handler.
+func (c *Client) observe(msg incoming.RcvdMsg) {
+	defer c.notifyWaiters(msg)
+
+	if tracker := c.receiptTracker(); tracker != nil {
+		tracker.observe(msg)
+	}
+
+	switch m := msg.(type) {
+	case *incoming.SessionCreatedMessage:
+		c.setSession(m.Session)
+	case *incoming.SessionUpdatedMessage:
+		c.setSession(m.Session)
+	case *incoming.ConversationCreatedMessage:
+		c.mu.Lock()
+		c.conversationID = m.Conversation.ID
+		c.mu.Unlock()
+	case *incoming.RateLimitsUpdatedMessage:
+		c.setRateLimits(m)
+	case *incoming.AudioBufferCommittedMessage, *incoming.AudioBufferClearedMessage:
+		c.observeInputAudio(m)
+	case *incoming.ResponseCreatedMessage:
+		c.mu.Lock()
+		// Out-of-band responses are not the response a pending request is waiting for
+		if inConversation(m.Response) {
+			c.activeResponseID = m.Response.ID
+			c.clearResponseRequest()
+		}
+		c.mu.Unlock()
+	case *incoming.ErrorMessage:
+		// A failed response.create never reports the response created
+		c.mu.Lock()
+		c.observeResponseRequest(m)
+		c.mu.Unlock()
+	case *incoming.ResponseDoneMessage:
+		c.mu.Lock()
+		if c.activeResponseID == m.Response.ID {
+			c.activeResponseID = ""
+		}
+		c.mu.Unlock()
+	}
+	c.observeResponseAttempt(msg)
+	c.observeItemOrdering(msg)
+}
+
+// Session returns a copy of the latest session reported by the server through
+// session.created or session.updated. The second return value is false if no
+// session has been observed yet.
+//
+// The session is only tracked for messages received through ReadMessage or a Handler.
+func (c *Client) Session() (session.Session, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.session == nil {
+		return session.Session{}, false
+	}
+	return c.session.Clone(), true
+}
+
+// SessionExpiresAt returns when the server will end the session, as reported through
+// the expires_at field of session.created or session.updated. The second return value
+// is false if no session has been observed yet or the server did not report an expiry.
+func (c *Client) SessionExpiresAt() (time.Time, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	if c.session == nil || c.session.ExpiresAt == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(c.session.ExpiresAt, 0), true
+}
+
+// ConversationID returns the ID of the conversation reported by the server through
+// conversation.created, or an empty string if none has been observed yet.
+//
+// The conversation is only tracked for messages received through ReadMessage or a Handler.
+func (c *Client) ConversationID() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.conversationID
+}
+
+// setSession stores the latest session reported by the server
+func (c *Client) setSession(s session.Session) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.session = &s
+}
+
 // Convenience methods for sending specific types of messages
 
 // SendSessionUpdate sends a session update message.
+// The request is validated with session.SessionRequest.Validate before anything is sent.
 func (c *Client) SendSessionUpdate(ctx context.Context, sessionReq session.SessionRequest) error {
+	if err := sessionReq.Validate(); err != nil {
+		return err
+	}
 	msg := outgoing.NewSessionUpdateMessage(sessionReq)
 	return c.SendMessage(ctx, msg)
 }
 
+// UpdateSession applies mutate to a copy of the current session configuration and sends
+// a session update containing only the fields that changed.
+//
+// The current configuration is the latest session reported by the server through
+// session.created or session.updated, as observed by ReadMessage or a Handler.
+// If no session has been observed yet, every field set by mutate is sent.
+// If mutate changes nothing, no message is sent.
+//
+// Example:
+//
+//	err := msgClient.UpdateSession(ctx, func(req *session.SessionRequest) {
+//		voice := session.VoiceCoral
+//		req.Voice = &voice
+//	})
+func (c *Client) UpdateSession(ctx context.Context, mutate func(*session.SessionRequest)) error {
+	if mutate == nil {
+		return fmt.Errorf("mutate function cannot be nil")
+	}
+
+	var current session.SessionRequest
+	if s, ok := c.Session(); ok {
+		current = s.SessionRequest
+	}
+
+	desired := current.Clone()
+	mutate(&desired)
+
+	update := session.Diff(current, desired)
+	if update.IsEmpty() {
+		return nil
+	}
+
+	return c.SendSessionUpdate(ctx, update)
+}
+
 // SendAudioBufferAppend sends an audio buffer append message.
 func (c *Client) SendAudioBufferAppend(ctx context.Context, audioData string) error {
 	msg := outgoing.NewAudioBufferAppendMessage(audioData)
@@ -186,7 +717,14 @@ func (c *Client) SendAudioBufferClear(ctx context.Context) error {
 }
 
 // SendConversationItemCreate sends a conversation item create message.
+// With a nil previousItemID the item is appended, or chained after the previous item
+// if SetItemOrdering is enabled.
 func (c *Client) SendConversationItemCreate(ctx context.Context, item *types.MessageItem, previousItemID *string) error {
+	if previousItemID == nil {
+		if tracker := c.orderingTracker(); tracker != nil {
+			return c.sendOrderedItem(ctx, tracker, *item)
+		}
+	}
 	prevID := ""
 	if previousItemID != nil {
 		prevID = *previousItemID
@@ -196,35 +734,118 @@ func (c *Client) SendConversationItemCreate(ctx context.Context, item *types.Mes
 }
 
 // SendResponseCreate sends a response create message.
+// The configuration is validated first, and a response asking for audio is rejected if
+// the session was last reported as text only. See SetResponseRetry for retrying
+// responses that fail.
 func (c *Client) SendResponseCreate(ctx context.Context, config *types.ResponseConfig) error {
 	if config == nil {
 		return fmt.Errorf("response config cannot be nil")
 	}
-	msg := outgoing.NewResponseCreateMessage(*config)
-	return c.SendMessage(ctx, msg)
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	if err := c.checkResponseModalities(config); err != nil {
+		return err
+	}
+	msg, discard := c.stampResponseAttempt(config)
+	if err := c.SendMessage(ctx, msg); err != nil {
+		discard()
+		return err
+	}
+	return nil
+}
+
+// checkResponseModalities rejects a response that asks for audio from a session the
+// server reported as text only, which the server would otherwise reject mid-conversation
+func (c *Client) checkResponseModalities(config *types.ResponseConfig) error {
+	if !session.HasModality(config.Modalities, session.ModalityAudio) {
+		return nil
+	}
+	s, ok := c.Session()
+	if !ok || s.Modalities == nil || session.HasModality(*s.Modalities, session.ModalityAudio) {
+		return nil
+	}
+	return apierrs.NewInvalidField("modalities", "audio output requires the audio modality in the session")
 }
 
 // SendResponseCancel sends a response cancel message.
+// An empty responseID is omitted, which cancels the response in progress.
 func (c *Client) SendResponseCancel(ctx context.Context, responseID string) error {
 	msg := outgoing.NewResponseCancelMessage(responseID)
 	return c.SendMessage(ctx, msg)
 }
 
+// CancelCurrentResponse cancels the response in progress. It names the active
+// response if one has been observed, so a response that finished in the meantime is
+// not mistaken for a newer one; otherwise it omits the ID and lets the server cancel
+// its default in-progress response.
+func (c *Client) CancelCurrentResponse(ctx context.Context) error {
+	responseID, _ := c.ActiveResponseID()
+	return c.SendResponseCancel(ctx, responseID)
+}
+
+// ActiveResponseID returns the ID of the response reported by response.created that
+// has not been reported done yet. The second return value is false if no response is
+// in progress. Out-of-band responses, which are not added to the conversation, are not
+// tracked, so they are never mistaken for the assistant's turn.
+//
+// The active response is only tracked for messages received through ReadMessage or
+// a Handler.
+func (c *Client) ActiveResponseID() (string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.activeResponseID, c.activeResponseID != ""
+}
+
+// inConversation reports whether response is added to a conversation. The server
+// reports no conversation for out-of-band responses.
+func inConversation(response types.Response) bool {
+	return response.ConversationID != "" && response.ConversationID != "none"
+}
+
 // SendText sends a text message from the user.
-func (c *Client) SendText(ctx context.Context, text string) error {
+// If SetAutoResponse is enabled, a response is requested after the message;
+// WithAutoResponse overrides it for this message.
+func (c *Client) SendText(ctx context.Context, text string, opts ...UserItemOption) error {
 	content := []types.MessageContentPart{
 		factory.InputTextContent(text),
 	}
 	item := factory.MessageItem(types.MessageRoleUser, content)
-	return c.SendConversationItemCreate(ctx, &item, nil)
+	return c.sendUserItem(ctx, &item, opts)
 }
 
 // SendAudio sends an audio message from the user.
-func (c *Client) SendAudio(ctx context.Context, audioBase64 string, transcript string) error {
+// If SetAutoResponse is enabled, a response is requested after the message;
+// WithAutoResponse overrides it for this message.
+func (c *Client) SendAudio(ctx context.Context, audioBase64 string, transcript string, opts ...UserItemOption) error {
+	content := []types.MessageContentPart{
+		factory.InputAudioContent(audioBase64, transcript),
+	}
+	item := factory.MessageItem(types.MessageRoleUser, content)
+	return c.sendUserItem(ctx, &item, opts)
+}
+
+// SendUserAudioItem sends an audio message from the user with an optional transcript,
+// inserted after previousItemID. An empty previousItemID appends it to the conversation.
+func (c *Client) SendUserAudioItem(ctx context.Context, audioBase64 string, transcript string, previousItemID string) error {
 	content := []types.MessageContentPart{
 		factory.InputAudioContent(audioBase64, transcript),
 	}
 	item := factory.MessageItem(types.MessageRoleUser, content)
+	var prevID *string
+	if previousItemID != "" {
+		prevID = &previousItemID
+	}
+	return c.SendConversationItemCreate(ctx, &item, prevID)
+}
+
+// SendAssistantMessage sends a text message from the assistant.
+// This is useful for seeding prior assistant turns, for example when restoring a conversation.
+func (c *Client) SendAssistantMessage(ctx context.Context, text string) error {
+	content := []types.MessageContentPart{
+		factory.TextContent(text),
+	}
+	item := factory.MessageItem(types.MessageRoleAssistant, content)
 	return c.SendConversationItemCreate(ctx, &item, nil)
 }
 
@@ -253,14 +874,22 @@ func (c *Client) SendConversationItemDelete(ctx context.Context, itemID string)
 
 // SendTranscriptionSessionUpdate sends a transcription session update message.
 // This updates the configuration of the current transcription session.
+// The request is validated for model-specific restrictions before anything is sent.
 func (c *Client) SendTranscriptionSessionUpdate(ctx context.Context, sessionReq session.TranscriptionSessionRequest) error {
+	if err := sessionReq.Validate(); err != nil {
+		return err
+	}
 	msg := outgoing.NewTranscriptionSessionUpdateMessage(sessionReq)
 	return c.SendMessage(ctx, msg)
 }
 
 // SendTranscriptionSessionUpdateWithID sends a transcription session update message with a custom event ID.
 // This updates the configuration of the current transcription session and allows tracking the update request.
+// The request is validated for model-specific restrictions before anything is sent.
 func (c *Client) SendTranscriptionSessionUpdateWithID(ctx context.Context, id string, sessionReq session.TranscriptionSessionRequest) error {
+	if err := sessionReq.Validate(); err != nil {
+		return err
+	}
 	msg := outgoing.NewTranscriptionSessionUpdateMessageWithID(id, sessionReq)
 	return c.SendMessage(ctx, msg)
 }
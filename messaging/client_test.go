@@ -1,12 +1,20 @@
 package messaging
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/Mliviu79/openai-realtime-go/apierrs"
+	"github.com/Mliviu79/openai-realtime-go/clock"
 	"github.com/Mliviu79/openai-realtime-go/logger"
 	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+	"github.com/Mliviu79/openai-realtime-go/messages/schema"
+	"github.com/Mliviu79/openai-realtime-go/messages/types"
+	"github.com/Mliviu79/openai-realtime-go/session"
 	"github.com/Mliviu79/openai-realtime-go/ws"
 )
 
@@ -192,6 +200,66 @@ func TestSendText(t *testing.T) {
 	}
 }
 
+func TestSendAssistantMessage(t *testing.T) {
+	var sent string
+	mockConn := &MockConn{
+		WriteMessageFunc: func(ctx context.Context, messageType ws.MessageType, data []byte) error {
+			sent = string(data)
+			return nil
+		},
+	}
+	client := NewClient(ws.NewConn(mockConn))
+
+	if err := client.SendAssistantMessage(context.Background(), "How can I help?"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if !strings.Contains(sent, `"type":"conversation.item.create"`) ||
+		!strings.Contains(sent, `"role":"assistant"`) ||
+		!strings.Contains(sent, `"content":[{"type":"text","text":"How can I help?"}]`) {
+		t.Errorf("Expected an assistant text item, got %s", sent)
+	}
+	if strings.Contains(sent, "previous_item_id") {
+		t.Errorf("Expected no previous_item_id, got %s", sent)
+	}
+}
+
+func TestSendUserAudioItem(t *testing.T) {
+	tests := []struct {
+		name           string
+		previousItemID string
+		expectPrevious bool
+	}{
+		{name: "appended", previousItemID: ""},
+		{name: "after item", previousItemID: "item_1", expectPrevious: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent string
+			mockConn := &MockConn{
+				WriteMessageFunc: func(ctx context.Context, messageType ws.MessageType, data []byte) error {
+					sent = string(data)
+					return nil
+				},
+			}
+			client := NewClient(ws.NewConn(mockConn))
+
+			if err := client.SendUserAudioItem(context.Background(), "AAAA", "hello", tt.previousItemID); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if !strings.Contains(sent, `"role":"user"`) ||
+				!strings.Contains(sent, `{"type":"input_audio","audio":"AAAA","transcript":"hello"}`) {
+				t.Errorf("Expected a user audio item, got %s", sent)
+			}
+			if got := strings.Contains(sent, `"previous_item_id":"item_1"`); got != tt.expectPrevious {
+				t.Errorf("Expected previous_item_id present %v, got %s", tt.expectPrevious, sent)
+			}
+		})
+	}
+}
+
 func TestClose(t *testing.T) {
 	// Create a mock connection that verifies Close is called
 	closeWasCalled := false
@@ -217,3 +285,391 @@ func TestClose(t *testing.T) {
 		t.Error("Expected Close to be called on the underlying connection, but it wasn't")
 	}
 }
+
+func TestSendTranscriptionSessionUpdateValidation(t *testing.T) {
+	// Create a mock connection that records whether anything was written
+	writeCalled := false
+	mockConn := &MockConn{
+		WriteMessageFunc: func(ctx context.Context, messageType ws.MessageType, data []byte) error {
+			writeCalled = true
+			return nil
+		},
+	}
+	client := NewClient(ws.NewConn(mockConn))
+
+	// Logprobs are not supported by whisper-1
+	includes := []session.TranscriptionSessionInclude{session.TranscriptionSessionIncludeLogprobs}
+	req := session.TranscriptionSessionRequest{
+		InputAudioTranscription: &session.InputAudioTranscription{Model: session.TranscriptionModelWhisper1},
+		Include:                 &includes,
+	}
+
+	err := client.SendTranscriptionSessionUpdate(context.Background(), req)
+	if !apierrs.IsAPIError(err) {
+		t.Fatalf("Expected validation error, got %v", err)
+	}
+
+	if writeCalled {
+		t.Error("Expected invalid request not to be sent")
+	}
+}
+
+func TestUpdateSessionSendsOnlyChanges(t *testing.T) {
+	// Create a mock connection that reports a session and records written messages
+	var written []string
+	mockConn := &MockConn{
+		ReadMessageFunc: func(ctx context.Context) (ws.MessageType, []byte, error) {
+			return ws.MessageText, []byte(`{"type":"session.created","event_id":"evt_1","session":{"id":"sess_1","voice":"alloy","instructions":"Be helpful","temperature":0.8}}`), nil
+		},
+		WriteMessageFunc: func(ctx context.Context, messageType ws.MessageType, data []byte) error {
+			written = append(written, string(data))
+			return nil
+		},
+	}
+	client := NewClient(ws.NewConn(mockConn))
+	ctx := context.Background()
+
+	if _, err := client.ReadMessage(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Setting the same voice should not send anything
+	err := client.UpdateSession(ctx, func(req *session.SessionRequest) {
+		voice := session.VoiceAlloy
+		req.Voice = &voice
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(written) != 0 {
+		t.Fatalf("Expected no message to be sent, got %v", written)
+	}
+
+	// Changing the voice should send only the voice
+	err = client.UpdateSession(ctx, func(req *session.SessionRequest) {
+		voice := session.VoiceCoral
+		req.Voice = &voice
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(written) != 1 {
+		t.Fatalf("Expected 1 message to be sent, got %d", len(written))
+	}
+	if !strings.Contains(written[0], `"voice":"coral"`) {
+		t.Errorf("Expected update to contain the new voice, got %s", written[0])
+	}
+	if strings.Contains(written[0], "instructions") || strings.Contains(written[0], "temperature") {
+		t.Errorf("Expected unchanged fields to be omitted, got %s", written[0])
+	}
+}
+
+func TestSessionTracking(t *testing.T) {
+	responses := []string{
+		`{"type":"session.created","event_id":"evt_1","session":{"id":"sess_1","voice":"alloy","modalities":["text","audio"]}}`,
+		`{"type":"session.updated","event_id":"evt_2","session":{"id":"sess_1","voice":"coral","modalities":["text"]}}`,
+	}
+	mockConn := &MockConn{
+		ReadMessageFunc: func(ctx context.Context) (ws.MessageType, []byte, error) {
+			data := responses[0]
+			responses = responses[1:]
+			return ws.MessageText, []byte(data), nil
+		},
+	}
+	client := NewClient(ws.NewConn(mockConn))
+	ctx := context.Background()
+
+	if _, ok := client.Session(); ok {
+		t.Fatal("Expected no session before any message is read")
+	}
+
+	for range 2 {
+		if _, err := client.ReadMessage(ctx); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	sess, ok := client.Session()
+	if !ok {
+		t.Fatal("Expected session to be tracked")
+	}
+	if sess.ID != "sess_1" {
+		t.Errorf("Expected session ID sess_1, got %s", sess.ID)
+	}
+	if sess.Voice == nil || *sess.Voice != session.VoiceCoral {
+		t.Errorf("Expected voice coral, got %v", sess.Voice)
+	}
+
+	// Modifying the returned copy must not affect the tracked session
+	(*sess.Modalities)[0] = session.ModalityAudio
+	again, _ := client.Session()
+	if (*again.Modalities)[0] != session.ModalityText {
+		t.Errorf("Expected tracked modalities to be unchanged, got %v", *again.Modalities)
+	}
+}
+
+func TestConversationIDTracking(t *testing.T) {
+	mockConn := &MockConn{
+		ReadMessageFunc: func(ctx context.Context) (ws.MessageType, []byte, error) {
+			return ws.MessageText, []byte(`{"type":"conversation.created","event_id":"evt_1","conversation":{"id":"conv_123","object":"realtime.conversation"}}`), nil
+		},
+	}
+	client := NewClient(ws.NewConn(mockConn))
+
+	if id := client.ConversationID(); id != "" {
+		t.Fatalf("Expected empty conversation ID, got %s", id)
+	}
+
+	if _, err := client.ReadMessage(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if id := client.ConversationID(); id != "conv_123" {
+		t.Errorf("Expected conversation ID conv_123, got %s", id)
+	}
+}
+
+func TestSendRaw(t *testing.T) {
+	var written []byte
+	mockConn := &MockConn{
+		WriteMessageFunc: func(ctx context.Context, messageType ws.MessageType, data []byte) error {
+			written = data
+			return nil
+		},
+	}
+	client := NewClient(ws.NewConn(mockConn))
+	ctx := context.Background()
+
+	raw := `{"type":"experimental.event","value":1}`
+	if err := client.SendRaw(ctx, []byte(raw)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(written) != raw {
+		t.Errorf("Expected %s to be sent, got %s", raw, written)
+	}
+
+	invalid := []string{`not json`, `{"value":1}`, `[1,2]`}
+	for _, data := range invalid {
+		if err := client.SendRaw(ctx, []byte(data)); err == nil {
+			t.Errorf("Expected error for %s", data)
+		}
+	}
+}
+
+func TestSchemaValidation(t *testing.T) {
+	mockConn := &MockConn{
+		ReadMessageFunc: func(ctx context.Context) (ws.MessageType, []byte, error) {
+			return ws.MessageText, []byte(`{"type":"input_audio_buffer.cleared","event_id":"evt_1","new_field":1}`), nil
+		},
+	}
+	client := NewClient(ws.NewConn(mockConn))
+
+	validator, err := schema.NewValidator()
+	if err != nil {
+		t.Fatalf("Failed to create validator: %v", err)
+	}
+
+	var gotType string
+	var gotMismatches []string
+	client.SetSchemaValidation(validator, func(eventType string, mismatches []string) {
+		gotType = eventType
+		gotMismatches = mismatches
+	})
+
+	msg, err := client.ReadMessage(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The frame is still delivered
+	if msg.RcvdMsgType() != incoming.RcvdMsgTypeAudioBufferCleared {
+		t.Errorf("Expected input_audio_buffer.cleared, got %s", msg.RcvdMsgType())
+	}
+	if gotType != "input_audio_buffer.cleared" {
+		t.Errorf("Expected mismatch for input_audio_buffer.cleared, got %q", gotType)
+	}
+	if len(gotMismatches) != 1 || gotMismatches[0] != "$.new_field: unknown field" {
+		t.Errorf("Expected unknown field mismatch, got %v", gotMismatches)
+	}
+}
+
+func TestSendResponseCreateValidatesMetadata(t *testing.T) {
+	conn, sent, _ := recordingConn()
+	client := NewClient(ws.NewConn(conn))
+
+	config := &types.ResponseConfig{
+		Metadata: map[string]string{"topic": strings.Repeat("v", types.MaxMetadataValueLength+1)},
+	}
+	err := client.SendResponseCreate(context.Background(), config)
+
+	var apiErr *apierrs.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected *apierrs.APIError, got %v", err)
+	}
+	if len(sent()) != 0 {
+		t.Errorf("Expected no message to be sent, got %d", len(sent()))
+	}
+}
+
+func TestSendResponseCreateChecksSessionModalities(t *testing.T) {
+	conn, sent, _ := recordingConn()
+	conn.ReadMessageFunc = func(ctx context.Context) (ws.MessageType, []byte, error) {
+		return ws.MessageText, []byte(`{"type":"session.created","session":{"id":"sess_1","modalities":["text"]}}`), nil
+	}
+	client := NewClient(ws.NewConn(conn))
+	ctx := context.Background()
+	if _, err := client.ReadMessage(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	err := client.SendResponseCreate(ctx, &types.ResponseConfig{Modalities: session.AudioOnly})
+	var apiErr *apierrs.APIError
+	if !errors.As(err, &apiErr) || *apiErr.Response.Error.Param != "modalities" {
+		t.Fatalf("Expected audio to be rejected by a text only session, got %v", err)
+	}
+	if len(sent()) != 0 {
+		t.Errorf("Expected no message to be sent, got %d", len(sent()))
+	}
+
+	if err := client.SendResponseCreate(ctx, &types.ResponseConfig{Modalities: session.TextOnly}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(sent()) != 1 {
+		t.Errorf("Expected the text response to be sent, got %d", len(sent()))
+	}
+}
+
+func TestReadMessageStampsReceiveTime(t *testing.T) {
+	client := NewClient(ws.NewConn(&MockConn{
+		ReadMessageFunc: func(ctx context.Context) (ws.MessageType, []byte, error) {
+			return ws.MessageText, []byte(`{"type":"response.created","response":{"id":"resp_1"}}`), nil
+		},
+	}))
+	fake := clock.NewFake(time.Unix(1700000000, 0))
+	client.SetClock(fake)
+
+	first, err := client.ReadMessage(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	fake.Advance(250 * time.Millisecond)
+	second, err := client.ReadMessage(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	at, ok := incoming.ReceivedAt(first)
+	if !ok || !at.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("Expected the first message to be stamped, got %v", at)
+	}
+	later, _ := incoming.ReceivedAt(second)
+	if gap := later.Sub(at); gap != 250*time.Millisecond {
+		t.Errorf("Expected the messages to be received 250ms apart, got %v", gap)
+	}
+}
+
+func TestAudioPool(t *testing.T) {
+	frame := []byte(`{"type":"response.output_audio.delta","response_id":"resp_1","item_id":"item_1","delta":"AAECAw=="}`)
+	client := NewClient(ws.NewConn(&MockConn{
+		ReadMessageFunc: func(ctx context.Context) (ws.MessageType, []byte, error) {
+			return ws.MessageText, frame, nil
+		},
+	}))
+	client.SetAudioPool(incoming.NewAudioPool())
+
+	msg, err := client.ReadMessage(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	delta, ok := msg.(*incoming.ResponseOutputAudioDeltaMessage)
+	if !ok || !bytes.Equal(delta.Audio, []byte{0, 1, 2, 3}) || delta.Delta != "" {
+		t.Fatalf("Expected the audio to be decoded into a pooled buffer, got %+v", msg)
+	}
+	delta.Release()
+
+	// A Handler releases the buffer once its handlers return
+	var handled *incoming.ResponseOutputAudioDeltaMessage
+	var audio []byte
+	handler := NewHandler(context.Background(), client, func(ctx context.Context, msg incoming.RcvdMsg) {
+		handled = msg.(*incoming.ResponseOutputAudioDeltaMessage)
+		audio = bytes.Clone(handled.Audio)
+	})
+	handler.handleRawMessage(context.Background(), ws.MessageText, frame)
+	if !bytes.Equal(audio, []byte{0, 1, 2, 3}) {
+		t.Errorf("Expected the handler to see the audio, got %v", audio)
+	}
+	if handled.Audio != nil {
+		t.Errorf("Expected the audio to be released, got %v", handled.Audio)
+	}
+}
+
+func TestCancelCurrentResponse(t *testing.T) {
+	tests := []struct {
+		name     string
+		received []string
+		expected string
+	}{
+		{
+			name:     "no response observed",
+			expected: `{"type":"response.cancel"}`,
+		},
+		{
+			name:     "active response",
+			received: []string{`{"type":"response.created","response":{"id":"resp_1","status":"in_progress","conversation_id":"conv_1"}}`},
+			expected: `{"type":"response.cancel","response_id":"resp_1"}`,
+		},
+		{
+			name: "response already done",
+			received: []string{
+				`{"type":"response.created","response":{"id":"resp_1","status":"in_progress","conversation_id":"conv_1"}}`,
+				`{"type":"response.done","response":{"id":"resp_1","status":"completed"}}`,
+			},
+			expected: `{"type":"response.cancel"}`,
+		},
+		{
+			name: "out-of-band response overlapping",
+			received: []string{
+				`{"type":"response.created","response":{"id":"resp_1","status":"in_progress","conversation_id":"conv_1"}}`,
+				`{"type":"response.created","response":{"id":"resp_2","status":"in_progress","metadata":{"purpose":"summary"}}}`,
+				`{"type":"response.done","response":{"id":"resp_2","status":"completed"}}`,
+			},
+			expected: `{"type":"response.cancel","response_id":"resp_1"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, sent, _ := recordingConn()
+			client := NewClient(ws.NewConn(conn))
+			for _, msg := range tt.received {
+				client.observe(mustParse(t, msg))
+			}
+
+			if err := client.CancelCurrentResponse(context.Background()); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got := sent(); len(got) != 1 || got[0] != tt.expected {
+				t.Errorf("Expected %s, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestActiveResponseIgnoresOutOfBand(t *testing.T) {
+	client := NewClient(ws.NewConn(&MockConn{}))
+
+	client.observe(mustParse(t, `{"type":"response.created","response":{"id":"resp_1","status":"in_progress"}}`))
+	if id, ok := client.ActiveResponseID(); ok {
+		t.Errorf("Expected an out-of-band response not to be active, got %s", id)
+	}
+
+	client.observe(mustParse(t, `{"type":"response.created","response":{"id":"resp_2","status":"in_progress","conversation_id":"conv_1"}}`))
+	client.observe(mustParse(t, `{"type":"response.created","response":{"id":"resp_3","status":"in_progress"}}`))
+	if id, ok := client.ActiveResponseID(); !ok || id != "resp_2" {
+		t.Errorf("Expected resp_2 to stay active while an out-of-band response overlaps, got %q", id)
+	}
+	client.observe(mustParse(t, `{"type":"response.done","response":{"id":"resp_3","status":"completed"}}`))
+	if id, ok := client.ActiveResponseID(); !ok || id != "resp_2" {
+		t.Errorf("Expected resp_2 to stay active after the out-of-band response is done, got %q", id)
+	}
+}
@@ -0,0 +1,179 @@
+package messaging
+
+import (
+	"bytes"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/clock"
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+//-----------------------------------------------------------------------------
+// Audio Delta Coalescing
+//-----------------------------------------------------------------------------
+
+// DefaultAudioCoalesceInterval is the default cadence at which coalesced audio is delivered
+const DefaultAudioCoalesceInterval = 100 * time.Millisecond
+
+// AudioChunk is a run of decoded output audio belonging to a single content part
+type AudioChunk struct {
+	// ResponseID identifies the response the audio belongs to
+	ResponseID string
+
+	// ItemID identifies the output item the audio belongs to
+	ItemID string
+
+	// OutputIndex is the position of the output item within the response
+	OutputIndex int
+
+	// ContentIndex is the position of the content part within the item
+	ContentIndex int
+
+	// Audio is the decoded audio data in the session's output audio format
+	Audio []byte
+}
+
+// AudioCoalescer merges consecutive response.output_audio.delta payloads into larger
+// chunks and delivers them at a fixed cadence. This reduces per-delta overhead for
+// playback pipelines that prefer fewer, bigger writes.
+//
+// Audio is delivered when the interval since the first buffered delta elapses, when
+// a delta for a different content part arrives, when the audio or response is done,
+// or when Flush is called. Deliveries are serialized and in order.
+//
+// Example:
+//
+//	coalescer := messaging.NewAudioCoalescer(100*time.Millisecond, func(chunk messaging.AudioChunk) {
+//		player.Write(chunk.Audio)
+//	})
+//	defer coalescer.Flush()
+//	handler := messaging.NewHandler(ctx, msgClient, coalescer.Handle)
+type AudioCoalescer struct {
+	mu        sync.Mutex
+	deliverMu sync.Mutex
+	interval  time.Duration
+	deliver   func(AudioChunk)
+	pending   *AudioChunk
+	clock     clock.Clock
+	timer     clock.Timer
+	panics    panicReporter
+}
+
+// AudioCoalescerOption configures an AudioCoalescer
+type AudioCoalescerOption func(*AudioCoalescer)
+
+// WithAudioCoalescerClock sets the clock used for the delivery interval, e.g. a clock.Fake
+// in tests. The default is the system clock.
+func WithAudioCoalescerClock(c clock.Clock) AudioCoalescerOption {
+	return func(a *AudioCoalescer) {
+		if c != nil {
+			a.clock = c
+		}
+	}
+}
+
+// NewAudioCoalescer creates an AudioCoalescer that calls deliver with coalesced audio.
+// An interval of zero or less uses DefaultAudioCoalesceInterval.
+func NewAudioCoalescer(interval time.Duration, deliver func(AudioChunk), opts ...AudioCoalescerOption) *AudioCoalescer {
+	if interval <= 0 {
+		interval = DefaultAudioCoalesceInterval
+	}
+	a := &AudioCoalescer{
+		interval: interval,
+		deliver:  deliver,
+		clock:    clock.Real(),
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	return a
+}
+
+// Handle decodes audio deltas into the coalescing buffer, and flushes it when the audio
+// or the response is done. Other messages are ignored, and deltas that are not valid
+// base64 are dropped.
+func (a *AudioCoalescer) Handle(ctx context.Context, msg incoming.RcvdMsg) {
+	switch m := msg.(type) {
+	case *incoming.ResponseOutputAudioDeltaMessage:
+		audio, err := m.DecodeAudio()
+		if err != nil {
+			return
+		}
+		if m.Audio != nil {
+			// A pooled buffer is reused once the handlers return
+			audio = bytes.Clone(audio)
+		}
+		a.add(AudioChunk{
+			ResponseID:   m.ResponseID,
+			ItemID:       m.ItemID,
+			OutputIndex:  m.OutputIndex,
+			ContentIndex: m.ContentIndex,
+			Audio:        audio,
+		})
+	case *incoming.ResponseOutputAudioDoneMessage, *incoming.ResponseDoneMessage:
+		a.Flush()
+	}
+}
+
+// Flush immediately delivers any buffered audio.
+// It should be called when the consumer is done to avoid losing the tail of a response.
+func (a *AudioCoalescer) Flush() {
+	a.deliverMu.Lock()
+	defer a.deliverMu.Unlock()
+
+	a.mu.Lock()
+	chunk := a.pending
+	a.pending = nil
+	if a.timer != nil {
+		a.timer.Stop()
+		a.timer = nil
+	}
+	a.mu.Unlock()
+
+	if chunk != nil && a.deliver != nil {
+		a.deliver(*chunk)
+	}
+}
+
+// SetPanicHandler sets a function that is called when deliver panics on the interval timer.
+// The panic is recovered and reported as a *ws.PanicError. Panics in deliver called from
+// Handle or Flush propagate to the caller as usual.
+func (a *AudioCoalescer) SetPanicHandler(onPanic ws.PanicHandler) {
+	a.panics.set(onPanic)
+}
+
+// flushReported flushes the buffered audio on the interval timer, reporting a panic in deliver
+func (a *AudioCoalescer) flushReported() {
+	defer a.panics.recoverPanic("audio coalescer flush")
+	a.Flush()
+}
+
+// add appends a decoded delta to the pending chunk, flushing first if it belongs to
+// a different content part
+func (a *AudioCoalescer) add(chunk AudioChunk) {
+	a.mu.Lock()
+	if a.pending != nil && !a.pending.sameContent(chunk) {
+		a.mu.Unlock()
+		a.Flush()
+		a.mu.Lock()
+	}
+
+	if a.pending == nil {
+		a.pending = &chunk
+		a.timer = a.clock.AfterFunc(a.interval, a.flushReported)
+	} else {
+		a.pending.Audio = append(a.pending.Audio, chunk.Audio...)
+	}
+	a.mu.Unlock()
+}
+
+// sameContent reports whether two chunks belong to the same content part
+func (c *AudioChunk) sameContent(other AudioChunk) bool {
+	return c.ResponseID == other.ResponseID &&
+		c.ItemID == other.ItemID &&
+		c.OutputIndex == other.OutputIndex &&
+		c.ContentIndex == other.ContentIndex
+}
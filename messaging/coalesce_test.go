@@ -0,0 +1,85 @@
+package messaging
+
+import (
+	"context"
+	"encoding/base64"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/clock"
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+)
+
+func audioDelta(itemID string, data []byte) *incoming.ResponseOutputAudioDeltaMessage {
+	return &incoming.ResponseOutputAudioDeltaMessage{
+		ResponseID: "resp_1",
+		ItemID:     itemID,
+		Delta:      base64.StdEncoding.EncodeToString(data),
+	}
+}
+
+func TestAudioCoalescerMergesDeltas(t *testing.T) {
+	var mu sync.Mutex
+	var chunks []AudioChunk
+	coalescer := NewAudioCoalescer(time.Hour, func(chunk AudioChunk) {
+		mu.Lock()
+		defer mu.Unlock()
+		chunks = append(chunks, chunk)
+	})
+	ctx := context.Background()
+
+	coalescer.Handle(ctx, audioDelta("item_1", []byte{1, 2}))
+	coalescer.Handle(ctx, audioDelta("item_1", []byte{3, 4}))
+	// A new item flushes the previous one
+	coalescer.Handle(ctx, audioDelta("item_2", []byte{5}))
+	coalescer.Handle(ctx, &incoming.ResponseOutputAudioDoneMessage{ItemID: "item_2"})
+
+	if len(chunks) != 2 {
+		t.Fatalf("Expected 2 chunks, got %d", len(chunks))
+	}
+	if chunks[0].ItemID != "item_1" || string(chunks[0].Audio) != string([]byte{1, 2, 3, 4}) {
+		t.Errorf("Expected merged audio for item_1, got %+v", chunks[0])
+	}
+	if chunks[1].ItemID != "item_2" || string(chunks[1].Audio) != string([]byte{5}) {
+		t.Errorf("Expected audio for item_2, got %+v", chunks[1])
+	}
+}
+
+func TestAudioCoalescerInterval(t *testing.T) {
+	fake := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	delivered := make(chan AudioChunk, 1)
+	coalescer := NewAudioCoalescer(100*time.Millisecond, func(chunk AudioChunk) {
+		delivered <- chunk
+	}, WithAudioCoalescerClock(fake))
+
+	coalescer.Handle(context.Background(), audioDelta("item_1", []byte{1, 2}))
+	fake.Advance(99 * time.Millisecond)
+	coalescer.Handle(context.Background(), audioDelta("item_1", []byte{3}))
+	if fake.Timers() != 1 {
+		t.Fatal("Expected the audio to stay buffered before the interval")
+	}
+	fake.Advance(time.Millisecond)
+
+	select {
+	case chunk := <-delivered:
+		if len(chunk.Audio) != 3 {
+			t.Errorf("Expected 3 bytes of audio, got %d", len(chunk.Audio))
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected audio to be delivered after the interval")
+	}
+}
+
+func TestAudioCoalescerFlushEmpty(t *testing.T) {
+	called := false
+	coalescer := NewAudioCoalescer(0, func(chunk AudioChunk) {
+		called = true
+	})
+
+	coalescer.Flush()
+
+	if called {
+		t.Error("Expected no delivery when nothing is buffered")
+	}
+}
@@ -0,0 +1,401 @@
+package messaging
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/clock"
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+	"github.com/Mliviu79/openai-realtime-go/messages/types"
+	"github.com/Mliviu79/openai-realtime-go/store"
+)
+
+//-----------------------------------------------------------------------------
+// Conversation Tracking
+//-----------------------------------------------------------------------------
+
+// ConversationStats summarizes the turns and timing of a conversation
+type ConversationStats struct {
+	// UserTurns is the number of user message items added to the conversation
+	UserTurns int
+
+	// AssistantTurns is the number of completed or cancelled responses that produced a message
+	AssistantTurns int
+
+	// UserSpeakingTime is the total duration of user speech detected by the server VAD
+	UserSpeakingTime time.Duration
+
+	// AssistantSpeakingTime is the total duration of assistant audio, excluding audio
+	// removed by truncation after an interruption
+	AssistantSpeakingTime time.Duration
+
+	// Interruptions is the number of responses the user started speaking over
+	Interruptions int
+
+	// AverageResponseLatency is the mean time from the end of a user turn to the first
+	// output of the response that follows it
+	AverageResponseLatency time.Duration
+}
+
+// TrackedItem is a conversation item as seen by a ConversationTracker
+type TrackedItem struct {
+	// ID identifies the item
+	ID string
+
+	// Type is the item type, such as message or function_call
+	Type types.MessageItemType
+
+	// Role is the role of message items
+	Role types.MessageRole
+
+	// Metadata holds the application values attached with SetItemMetadata. It is never
+	// sent to the server and must not be modified.
+	Metadata map[string]any
+}
+
+// trackedAudio is the assistant audio generated for an item
+type trackedAudio struct {
+	bytes     int
+	truncated time.Duration
+	cut       bool
+}
+
+// ConversationTrackerOption configures a ConversationTracker
+type ConversationTrackerOption func(*ConversationTracker)
+
+// WithConversationStore persists the conversation to s under conversationID: items as they
+// are created and completed (including input audio transcripts), and finished responses
+// with their token usage. Deleted items are kept in the store. Use a conversation ID that
+// is stable across restarts, such as a call ID, and load the conversation back with
+// s.LoadConversation.
+//
+// The store is written synchronously from Handle, so it should be fast or buffer its writes.
+func WithConversationStore(s store.Store, conversationID string) ConversationTrackerOption {
+	return func(c *ConversationTracker) {
+		c.store = s
+		c.storeID = conversationID
+	}
+}
+
+// WithOnStoreError sets a function that is called when writing to the conversation store
+// fails; without it store errors are ignored. It is called from Handle and must not block.
+func WithOnStoreError(onError func(error)) ConversationTrackerOption {
+	return func(c *ConversationTracker) {
+		c.onStoreError = onError
+	}
+}
+
+// WithMaxTrackedItems keeps at most n items, forgetting the oldest when a new item is
+// created, so very long sessions do not grow memory without bound. The audio accounted
+// for forgotten items still counts towards Stats. With WithConversationStore the same
+// limit bounds the items kept for the store. Zero, the default, keeps every item.
+func WithMaxTrackedItems(n int) ConversationTrackerOption {
+	return func(c *ConversationTracker) {
+		c.maxItems = n
+	}
+}
+
+// WithOnItemEvicted sets a function that is called with every item forgotten to stay
+// within WithMaxTrackedItems. It is called from Handle and must not block.
+func WithOnItemEvicted(onEvicted func(TrackedItem)) ConversationTrackerOption {
+	return func(c *ConversationTracker) {
+		c.onEvicted = onEvicted
+	}
+}
+
+// WithConversationClock sets the clock used to time turns and response latency; the
+// default is the real clock
+func WithConversationClock(clk clock.Clock) ConversationTrackerOption {
+	return func(c *ConversationTracker) {
+		if clk != nil {
+			c.clock = clk
+		}
+	}
+}
+
+// ConversationTracker follows the conversation from server events, keeping the ordered
+// item list and accounting for turns, speaking time, interruptions and response latency.
+// With WithConversationStore it also persists the conversation.
+//
+// Example:
+//
+//	tracker := messaging.NewConversationTracker()
+//	handler := messaging.NewHandler(ctx, msgClient, tracker.Handle)
+//	// ...
+//	stats := tracker.Stats()
+//	log.Printf("%d interruptions, %v average latency", stats.Interruptions, stats.AverageResponseLatency)
+type ConversationTracker struct {
+	mu          sync.Mutex
+	items       []TrackedItem
+	audio       map[string]*trackedAudio
+	outputAudio outputAudioFormat
+	stats       ConversationStats
+	metadata    map[string]any
+
+	speechStartMs  int64
+	turnEndedAt    time.Time
+	latencyTotal   time.Duration
+	latencyCount   int
+	active         map[string]bool
+	interrupted    map[string]bool
+	awaitingOutput map[string]bool
+
+	// maxItems bounds items; evictedSpeaking is the assistant audio of evicted items
+	maxItems        int
+	onEvicted       func(TrackedItem)
+	evictedSpeaking time.Duration
+
+	// store, if set, persists the conversation; history keeps the full items for it
+	store        store.Store
+	storeID      string
+	onStoreError func(error)
+	history      *ConversationHistory
+
+	clock clock.Clock
+}
+
+// NewConversationTracker creates an empty ConversationTracker
+func NewConversationTracker(opts ...ConversationTrackerOption) *ConversationTracker {
+	c := &ConversationTracker{
+		audio:          make(map[string]*trackedAudio),
+		active:         make(map[string]bool),
+		interrupted:    make(map[string]bool),
+		awaitingOutput: make(map[string]bool),
+		clock:          clock.Real(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if c.store != nil {
+		c.history = NewConversationHistory(WithHistoryMaxItems(c.maxItems))
+	}
+	return c
+}
+
+// Handle updates the item list and the turn, speaking-time, interruption and latency
+// accounting, and writes the conversation to the store if one is configured.
+func (c *ConversationTracker) Handle(ctx context.Context, msg incoming.RcvdMsg) {
+	if c.store != nil {
+		c.persist(ctx, msg)
+	}
+
+	now := c.clock.Now()
+	c.mu.Lock()
+	var evicted []TrackedItem
+	defer func() {
+		onEvicted := c.onEvicted
+		c.mu.Unlock()
+		if onEvicted != nil {
+			for _, item := range evicted {
+				onEvicted(item)
+			}
+		}
+	}()
+
+	switch m := msg.(type) {
+	case *incoming.SessionCreatedMessage, *incoming.SessionUpdatedMessage:
+		c.outputAudio.observe(msg)
+	case *incoming.ConversationItemCreatedMessage:
+		c.insert(m.PreviousItemID, TrackedItem{ID: m.Item.ID, Type: m.Item.Type, Role: m.Item.Role})
+		evicted = c.evict()
+		if m.Item.Role == types.MessageRoleUser && m.Item.Type == types.MessageItemTypeMessage {
+			c.stats.UserTurns++
+			if c.turnEndedAt.IsZero() {
+				c.turnEndedAt = now
+			}
+		}
+	case *incoming.ConversationItemDeletedMessage:
+		c.remove(m.ItemID)
+	case *incoming.AudioBufferSpeechStartedMessage:
+		c.speechStartMs = m.AudioStartMs
+		c.turnEndedAt = time.Time{}
+		for id := range c.active {
+			if !c.interrupted[id] {
+				c.interrupted[id] = true
+				c.stats.Interruptions++
+			}
+		}
+	case *incoming.AudioBufferSpeechStoppedMessage:
+		if m.AudioEndMs > c.speechStartMs {
+			c.stats.UserSpeakingTime += time.Duration(m.AudioEndMs-c.speechStartMs) * time.Millisecond
+		}
+		c.turnEndedAt = now
+	case *incoming.ResponseCreatedMessage:
+		c.active[m.Response.ID] = true
+		if !c.turnEndedAt.IsZero() {
+			c.awaitingOutput[m.Response.ID] = true
+		}
+	case *incoming.ResponseOutputAudioDeltaMessage:
+		audio, ok := c.audio[m.ItemID]
+		if !ok {
+			audio = &trackedAudio{}
+			c.audio[m.ItemID] = audio
+		}
+		audio.bytes += m.AudioLen()
+		c.firstOutput(m.ResponseID, now)
+	case *incoming.ResponseOutputTextDeltaMessage:
+		c.firstOutput(m.ResponseID, now)
+	case *incoming.ResponseOutputAudioTranscriptDeltaMessage:
+		c.firstOutput(m.ResponseID, now)
+	case *incoming.ResponseFunctionCallArgumentsDeltaMessage:
+		c.firstOutput(m.ResponseID, now)
+	case *incoming.ConversationItemTruncatedMessage:
+		audio, ok := c.audio[m.ItemID]
+		if !ok {
+			audio = &trackedAudio{}
+			c.audio[m.ItemID] = audio
+		}
+		audio.cut = true
+		audio.truncated = time.Duration(m.AudioEndMs) * time.Millisecond
+	case *incoming.ResponseDoneMessage:
+		delete(c.active, m.Response.ID)
+		delete(c.interrupted, m.Response.ID)
+		delete(c.awaitingOutput, m.Response.ID)
+		for _, item := range m.Response.Output {
+			if item.Type == types.MessageItemTypeMessage {
+				c.stats.AssistantTurns++
+				break
+			}
+		}
+	}
+}
+
+// Items returns the conversation items in order
+func (c *ConversationTracker) Items() []TrackedItem {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]TrackedItem(nil), c.items...)
+}
+
+// LastItemID returns the ID of the last item in the conversation. The second return value
+// is false if the conversation has no items.
+func (c *ConversationTracker) LastItemID() (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.items) == 0 {
+		return "", false
+	}
+	return c.items[len(c.items)-1].ID, true
+}
+
+// contains reports whether the item with the given ID is in the conversation
+func (c *ConversationTracker) contains(id string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.indexOf(id) >= 0
+}
+
+// Stats returns the turn counts, speaking time, interruptions and average response
+// latency observed so far
+func (c *ConversationTracker) Stats() ConversationStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats := c.stats
+	stats.AssistantSpeakingTime = c.evictedSpeaking
+	for _, audio := range c.audio {
+		stats.AssistantSpeakingTime += c.spokenDuration(audio)
+	}
+	if c.latencyCount > 0 {
+		stats.AverageResponseLatency = c.latencyTotal / time.Duration(c.latencyCount)
+	}
+	return stats
+}
+
+// spokenDuration returns the assistant audio played for an item, excluding audio
+// removed by truncation. The caller must hold c.mu.
+func (c *ConversationTracker) spokenDuration(audio *trackedAudio) time.Duration {
+	generated := c.outputAudio.duration(audio.bytes)
+	if audio.cut && audio.truncated < generated {
+		generated = audio.truncated
+	}
+	return generated
+}
+
+// evict forgets the oldest items beyond maxItems and returns them, keeping the audio
+// accounted for them in the stats. The caller must hold c.mu.
+func (c *ConversationTracker) evict() []TrackedItem {
+	if c.maxItems <= 0 || len(c.items) <= c.maxItems {
+		return nil
+	}
+	excess := len(c.items) - c.maxItems
+	evicted := slices.Clone(c.items[:excess])
+	c.items = slices.Delete(c.items, 0, excess)
+	for _, item := range evicted {
+		if audio, ok := c.audio[item.ID]; ok {
+			c.evictedSpeaking += c.spokenDuration(audio)
+			delete(c.audio, item.ID)
+		}
+	}
+	return evicted
+}
+
+// persist writes the items and responses completed by msg to the store
+func (c *ConversationTracker) persist(ctx context.Context, msg incoming.RcvdMsg) {
+	c.history.Handle(ctx, msg)
+
+	var err error
+	switch m := msg.(type) {
+	case *incoming.ConversationItemCreatedMessage:
+		err = c.saveItem(ctx, m.PreviousItemID, m.Item.ID)
+	case *incoming.ResponseOutputItemDoneMessage:
+		err = c.saveItem(ctx, "", m.Item.ID)
+	case *incoming.ConversationItemTranscriptionCompletedMessage:
+		err = c.saveItem(ctx, "", m.ItemID)
+	case *incoming.ResponseDoneMessage:
+		err = c.store.SaveResponse(ctx, c.storeID, m.Response)
+		if err == nil && m.Response.Usage != nil {
+			err = c.store.SaveUsage(ctx, c.storeID, m.Response.ID, *m.Response.Usage)
+		}
+	}
+	if err != nil && c.onStoreError != nil {
+		c.onStoreError(err)
+	}
+}
+
+// saveItem writes the recorded item with the given ID to the store, if it is in the conversation
+func (c *ConversationTracker) saveItem(ctx context.Context, previousItemID, id string) error {
+	item, ok := c.history.item(id)
+	if !ok {
+		return nil
+	}
+	return c.store.SaveItem(ctx, c.storeID, previousItemID, item)
+}
+
+// firstOutput records the response latency for the first output of a response that
+// follows a user turn. The caller must hold c.mu.
+func (c *ConversationTracker) firstOutput(responseID string, now time.Time) {
+	if !c.awaitingOutput[responseID] {
+		return
+	}
+	delete(c.awaitingOutput, responseID)
+	if !c.turnEndedAt.IsZero() {
+		c.latencyTotal += now.Sub(c.turnEndedAt)
+		c.latencyCount++
+		c.turnEndedAt = time.Time{}
+	}
+}
+
+// insert adds item after the item with previousID, or at the end if it is not tracked.
+// The caller must hold c.mu.
+func (c *ConversationTracker) insert(previousID string, item TrackedItem) {
+	for i, existing := range c.items {
+		if existing.ID == previousID {
+			c.items = append(c.items[:i+1], append([]TrackedItem{item}, c.items[i+1:]...)...)
+			return
+		}
+	}
+	c.items = append(c.items, item)
+}
+
+// remove deletes the item with the given ID. The caller must hold c.mu.
+func (c *ConversationTracker) remove(id string) {
+	for i, item := range c.items {
+		if item.ID == id {
+			c.items = append(c.items[:i], c.items[i+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package messaging
+
+import (
+	"maps"
+)
+
+//-----------------------------------------------------------------------------
+// Conversation Metadata
+//-----------------------------------------------------------------------------
+
+// SetMetadata attaches an application value to the conversation under key, replacing
+// any previous value. Metadata is kept by the tracker only and is never sent to the
+// server; a nil value removes the key.
+func (c *ConversationTracker) SetMetadata(key string, value any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.metadata = setMetadata(c.metadata, key, value)
+}
+
+// Metadata returns the application value attached to the conversation under key
+func (c *ConversationTracker) Metadata(key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	value, ok := c.metadata[key]
+	return value, ok
+}
+
+// SetItemMetadata attaches an application value to a tracked item under key, such as a
+// moderation flag or a speaker label, replacing any previous value; a nil value removes
+// the key. It returns false if the item is not in the conversation. The metadata is
+// returned with the item by Items and is forgotten when the item is deleted or evicted.
+func (c *ConversationTracker) SetItemMetadata(itemID, key string, value any) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	i := c.indexOf(itemID)
+	if i < 0 {
+		return false
+	}
+	c.items[i].Metadata = setMetadata(c.items[i].Metadata, key, value)
+	return true
+}
+
+// ItemMetadata returns the application value attached to a tracked item under key
+func (c *ConversationTracker) ItemMetadata(itemID, key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	i := c.indexOf(itemID)
+	if i < 0 {
+		return nil, false
+	}
+	value, ok := c.items[i].Metadata[key]
+	return value, ok
+}
+
+// MetadataAs returns the conversation metadata under key as a T. It returns false if the
+// key is not set or holds a value of another type.
+//
+// Example:
+//
+//	tracker.SetMetadata("customer_tier", "gold")
+//	tier, _ := messaging.MetadataAs[string](tracker, "customer_tier")
+func MetadataAs[T any](c *ConversationTracker, key string) (T, bool) {
+	value, _ := c.Metadata(key)
+	typed, ok := value.(T)
+	return typed, ok
+}
+
+// ItemMetadataAs returns the metadata of a tracked item under key as a T. It returns
+// false if the item or key is unknown or the value has another type.
+//
+// Example:
+//
+//	tracker.SetItemMetadata(itemID, "flagged", true)
+//	if flagged, _ := messaging.ItemMetadataAs[bool](tracker, itemID, "flagged"); flagged {
+//		hideItem(itemID)
+//	}
+func ItemMetadataAs[T any](c *ConversationTracker, itemID, key string) (T, bool) {
+	value, _ := c.ItemMetadata(itemID, key)
+	typed, ok := value.(T)
+	return typed, ok
+}
+
+// setMetadata returns a copy of metadata with key set to value, or removed if value is
+// nil. Maps are copied so values already returned by Items are not changed.
+func setMetadata(metadata map[string]any, key string, value any) map[string]any {
+	if value == nil {
+		if _, ok := metadata[key]; !ok {
+			return metadata
+		}
+		metadata = maps.Clone(metadata)
+		delete(metadata, key)
+		if len(metadata) == 0 {
+			return nil
+		}
+		return metadata
+	}
+	metadata = maps.Clone(metadata)
+	if metadata == nil {
+		metadata = make(map[string]any, 1)
+	}
+	metadata[key] = value
+	return metadata
+}
+
+// indexOf returns the position of the item with the given ID, or -1 if it is not
+// tracked. The caller must hold c.mu.
+func (c *ConversationTracker) indexOf(id string) int {
+	for i, item := range c.items {
+		if item.ID == id {
+			return i
+		}
+	}
+	return -1
+}
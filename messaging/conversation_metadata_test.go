@@ -0,0 +1,52 @@
+package messaging
+
+import (
+	"context"
+	"testing"
+)
+
+func TestConversationTrackerMetadata(t *testing.T) {
+	tracker := NewConversationTracker(WithMaxTrackedItems(2))
+	ctx := context.Background()
+	handle := func(msg string) { tracker.Handle(ctx, mustParse(t, msg)) }
+
+	tracker.SetMetadata("customer_tier", "gold")
+	if tier, ok := MetadataAs[string](tracker, "customer_tier"); !ok || tier != "gold" {
+		t.Errorf("Expected the conversation tier, got %q, %v", tier, ok)
+	}
+	if _, ok := MetadataAs[int](tracker, "customer_tier"); ok {
+		t.Error("Expected a value of another type not to be returned")
+	}
+
+	if tracker.SetItemMetadata("item_1", "speaker", "Ana") {
+		t.Error("Expected metadata for an unknown item to be rejected")
+	}
+	handle(`{"type":"conversation.item.created","item":{"id":"item_1","type":"message","role":"user"}}`)
+	if !tracker.SetItemMetadata("item_1", "speaker", "Ana") || !tracker.SetItemMetadata("item_1", "flagged", true) {
+		t.Fatal("Expected metadata to be attached to a tracked item")
+	}
+	before := tracker.Items()
+
+	tracker.SetItemMetadata("item_1", "flagged", nil)
+	if flagged, ok := ItemMetadataAs[bool](tracker, "item_1", "flagged"); ok {
+		t.Errorf("Expected a nil value to remove the key, got %v", flagged)
+	}
+	if flagged, _ := before[0].Metadata["flagged"].(bool); !flagged {
+		t.Error("Expected items already returned to keep their metadata")
+	}
+	if speaker, _ := ItemMetadataAs[string](tracker, "item_1", "speaker"); speaker != "Ana" {
+		t.Errorf("Expected the speaker label, got %q", speaker)
+	}
+
+	// Metadata goes with the item when it is evicted
+	var evicted []TrackedItem
+	tracker.onEvicted = func(item TrackedItem) { evicted = append(evicted, item) }
+	handle(`{"type":"conversation.item.created","previous_item_id":"item_1","item":{"id":"item_2","type":"message","role":"assistant"}}`)
+	handle(`{"type":"conversation.item.created","previous_item_id":"item_2","item":{"id":"item_3","type":"message","role":"user"}}`)
+	if len(evicted) != 1 || evicted[0].Metadata["speaker"] != "Ana" {
+		t.Errorf("Expected the evicted item with its metadata, got %+v", evicted)
+	}
+	if _, ok := tracker.ItemMetadata("item_1", "speaker"); ok {
+		t.Error("Expected the metadata of an evicted item to be forgotten")
+	}
+}
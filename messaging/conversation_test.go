@@ -0,0 +1,157 @@
+package messaging
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/clock"
+	"github.com/Mliviu79/openai-realtime-go/store"
+)
+
+func TestConversationTrackerStats(t *testing.T) {
+	fake := clock.NewFake(time.Unix(1700000000, 0))
+	tracker := NewConversationTracker(WithConversationClock(fake))
+	advance := fake.Advance
+
+	// two seconds of pcm16 at 24kHz mono
+	twoSeconds := base64.StdEncoding.EncodeToString(make([]byte, 96000))
+
+	ctx := context.Background()
+	handle := func(msg string) { tracker.Handle(ctx, mustParse(t, msg)) }
+
+	// First voice turn, answered after 300ms with two seconds of audio
+	handle(`{"type":"input_audio_buffer.speech_started","audio_start_ms":1000,"item_id":"item_1"}`)
+	handle(`{"type":"input_audio_buffer.speech_stopped","audio_end_ms":2500,"item_id":"item_1"}`)
+	handle(`{"type":"conversation.item.created","item":{"id":"item_1","type":"message","role":"user"}}`)
+	handle(`{"type":"response.created","response":{"id":"resp_1","status":"in_progress"}}`)
+	advance(300 * time.Millisecond)
+	handle(`{"type":"conversation.item.created","previous_item_id":"item_1","item":{"id":"item_2","type":"message","role":"assistant"}}`)
+	handle(`{"type":"response.output_audio.delta","response_id":"resp_1","item_id":"item_2","delta":"` + twoSeconds + `"}`)
+	handle(`{"type":"response.done","response":{"id":"resp_1","status":"completed","output":[{"id":"item_2","type":"message","role":"assistant"}]}}`)
+
+	// Second voice turn, answered after 500ms and interrupted after 800ms of playback
+	handle(`{"type":"input_audio_buffer.speech_started","audio_start_ms":6000,"item_id":"item_3"}`)
+	handle(`{"type":"input_audio_buffer.speech_stopped","audio_end_ms":6500,"item_id":"item_3"}`)
+	handle(`{"type":"conversation.item.created","previous_item_id":"item_2","item":{"id":"item_3","type":"message","role":"user"}}`)
+	handle(`{"type":"response.created","response":{"id":"resp_2","status":"in_progress"}}`)
+	advance(500 * time.Millisecond)
+	handle(`{"type":"conversation.item.created","previous_item_id":"item_3","item":{"id":"item_4","type":"message","role":"assistant"}}`)
+	handle(`{"type":"response.output_audio.delta","response_id":"resp_2","item_id":"item_4","delta":"` + twoSeconds + `"}`)
+	handle(`{"type":"input_audio_buffer.speech_started","audio_start_ms":8000,"item_id":"item_5"}`)
+	handle(`{"type":"input_audio_buffer.speech_started","audio_start_ms":8000,"item_id":"item_5"}`)
+	handle(`{"type":"conversation.item.truncated","item_id":"item_4","content_index":0,"audio_end_ms":800}`)
+	handle(`{"type":"response.done","response":{"id":"resp_2","status":"cancelled","status_details":{"type":"cancelled","reason":"turn_detected"},"output":[{"id":"item_4","type":"message","role":"assistant"}]}}`)
+
+	stats := tracker.Stats()
+	if stats.UserTurns != 2 {
+		t.Errorf("Expected 2 user turns, got %d", stats.UserTurns)
+	}
+	if stats.AssistantTurns != 2 {
+		t.Errorf("Expected 2 assistant turns, got %d", stats.AssistantTurns)
+	}
+	if stats.UserSpeakingTime != 2*time.Second {
+		t.Errorf("Expected 2s of user speech, got %v", stats.UserSpeakingTime)
+	}
+	if stats.AssistantSpeakingTime != 2800*time.Millisecond {
+		t.Errorf("Expected 2.8s of assistant speech, got %v", stats.AssistantSpeakingTime)
+	}
+	if stats.Interruptions != 1 {
+		t.Errorf("Expected 1 interruption, got %d", stats.Interruptions)
+	}
+	if stats.AverageResponseLatency != 400*time.Millisecond {
+		t.Errorf("Expected 400ms average latency, got %v", stats.AverageResponseLatency)
+	}
+}
+
+func TestConversationTrackerItems(t *testing.T) {
+	tracker := NewConversationTracker()
+	ctx := context.Background()
+	for _, msg := range []string{
+		`{"type":"conversation.item.created","item":{"id":"item_1","type":"message","role":"user"}}`,
+		`{"type":"conversation.item.created","previous_item_id":"item_1","item":{"id":"item_3","type":"message","role":"assistant"}}`,
+		`{"type":"conversation.item.created","previous_item_id":"item_1","item":{"id":"item_2","type":"function_call"}}`,
+		`{"type":"conversation.item.deleted","item_id":"item_1"}`,
+	} {
+		tracker.Handle(ctx, mustParse(t, msg))
+	}
+
+	items := tracker.Items()
+	if len(items) != 2 || items[0].ID != "item_2" || items[1].ID != "item_3" {
+		t.Errorf("Expected items [item_2 item_3], got %+v", items)
+	}
+}
+
+func TestConversationTrackerStore(t *testing.T) {
+	s := store.NewMemory()
+	var storeErrs []error
+	tracker := NewConversationTracker(
+		WithConversationStore(s, "call-1"),
+		WithOnStoreError(func(err error) { storeErrs = append(storeErrs, err) }),
+	)
+
+	ctx := context.Background()
+	handle := func(msg string) { tracker.Handle(ctx, mustParse(t, msg)) }
+
+	handle(`{"type":"conversation.item.created","item":{"id":"item_1","type":"message","role":"user","content":[{"type":"input_audio"}]}}`)
+	handle(`{"type":"conversation.item.input_audio_transcription.completed","item_id":"item_1","content_index":0,"transcript":"What time is it?"}`)
+	handle(`{"type":"response.created","response":{"id":"resp_1","status":"in_progress"}}`)
+	handle(`{"type":"conversation.item.created","previous_item_id":"item_1","item":{"id":"item_2","type":"message","role":"assistant","content":[]}}`)
+	handle(`{"type":"response.output_item.done","response_id":"resp_1","output_index":0,"item":{"id":"item_2","type":"message","role":"assistant","status":"completed","content":[{"type":"audio","transcript":"It is noon."}]}}`)
+	handle(`{"type":"response.done","response":{"id":"resp_1","status":"completed","usage":{"total_tokens":42,"input_tokens":30,"output_tokens":12}}}`)
+
+	if len(storeErrs) != 0 {
+		t.Fatalf("Unexpected store errors: %v", storeErrs)
+	}
+	conv, err := s.LoadConversation(ctx, "call-1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	items := conv.ImportableItems()
+	if len(items) != 2 || items[0].Content[0].Text != "What time is it?" || items[1].Content[0].Text != "It is noon." {
+		t.Errorf("Expected both transcripts in order, got %+v", items)
+	}
+	if len(conv.Responses) != 1 || conv.Responses[0].Status != "completed" {
+		t.Errorf("Expected the finished response, got %+v", conv.Responses)
+	}
+	if total := conv.TotalUsage(); total.TotalTokens != 42 {
+		t.Errorf("Expected 42 tokens, got %d", total.TotalTokens)
+	}
+	if stats := tracker.Stats(); stats.UserTurns != 1 {
+		t.Errorf("Expected the stats to be tracked as well, got %+v", stats)
+	}
+}
+
+func TestConversationTrackerLimits(t *testing.T) {
+	var evicted []string
+	tracker := NewConversationTracker(
+		WithMaxTrackedItems(2),
+		WithOnItemEvicted(func(item TrackedItem) { evicted = append(evicted, item.ID) }),
+	)
+	ctx := context.Background()
+	handle := func(msg string) { tracker.Handle(ctx, mustParse(t, msg)) }
+
+	handle(`{"type":"conversation.item.created","item":{"id":"item_1","type":"message","role":"assistant"}}`)
+	handle(`{"type":"response.output_audio.delta","response_id":"resp_1","item_id":"item_1","output_index":0,"content_index":0,"delta":"` + strings.Repeat("AAAA", 1200) + `"}`)
+	speaking := tracker.Stats().AssistantSpeakingTime
+	if speaking == 0 {
+		t.Fatal("Expected assistant audio to be tracked")
+	}
+
+	handle(`{"type":"conversation.item.created","previous_item_id":"item_1","item":{"id":"item_2","type":"message","role":"user"}}`)
+	handle(`{"type":"conversation.item.created","previous_item_id":"item_2","item":{"id":"item_3","type":"message","role":"user"}}`)
+
+	items := tracker.Items()
+	if len(items) != 2 || items[0].ID != "item_2" || items[1].ID != "item_3" {
+		t.Errorf("Expected the oldest item to be evicted, got %+v", items)
+	}
+	if len(evicted) != 1 || evicted[0] != "item_1" {
+		t.Errorf("Expected item_1 to be reported evicted, got %v", evicted)
+	}
+	if got := tracker.Stats().AssistantSpeakingTime; got != speaking {
+		t.Errorf("Expected evicted audio to still count, got %v want %v", got, speaking)
+	}
+}
@@ -0,0 +1,202 @@
+package messaging
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/clock"
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+//-----------------------------------------------------------------------------
+// Text Delta Debouncing
+//-----------------------------------------------------------------------------
+
+// DefaultTextDebounceInterval is the default maximum delay before buffered text is delivered
+const DefaultTextDebounceInterval = 100 * time.Millisecond
+
+// TextChunk is a run of text or audio transcript belonging to a single content part
+type TextChunk struct {
+	// Type is DeltaTypeText for text output or DeltaTypeTranscript for audio transcripts
+	Type DeltaType
+
+	// ResponseID identifies the response the text belongs to
+	ResponseID string
+
+	// ItemID identifies the output item the text belongs to
+	ItemID string
+
+	// OutputIndex is the position of the output item within the response
+	OutputIndex int
+
+	// ContentIndex is the position of the content part within the item
+	ContentIndex int
+
+	// Text is the buffered text
+	Text string
+}
+
+// TextDebouncer buffers response.output_text.delta and response.output_audio_transcript.delta
+// fragments and delivers them in larger pieces, so UIs re-render at a sane rate
+// instead of once per token.
+//
+// Buffered text is delivered when the interval since the first buffered fragment elapses,
+// when a fragment ends a sentence, when a fragment for a different content part arrives,
+// when the text, transcript or response is done, or when Flush is called.
+// Deliveries are serialized and in order.
+//
+// Example:
+//
+//	debouncer := messaging.NewTextDebouncer(150*time.Millisecond, func(chunk messaging.TextChunk) {
+//		ui.Append(chunk.ItemID, chunk.Text)
+//	})
+//	defer debouncer.Flush()
+//	handler := messaging.NewHandler(ctx, msgClient, debouncer.Handle)
+type TextDebouncer struct {
+	mu        sync.Mutex
+	deliverMu sync.Mutex
+	interval  time.Duration
+	deliver   func(TextChunk)
+	pending   *TextChunk
+	clock     clock.Clock
+	timer     clock.Timer
+	panics    panicReporter
+}
+
+// TextDebouncerOption configures a TextDebouncer
+type TextDebouncerOption func(*TextDebouncer)
+
+// WithTextDebouncerClock sets the clock used for the delivery interval, e.g. a clock.Fake
+// in tests. The default is the system clock.
+func WithTextDebouncerClock(c clock.Clock) TextDebouncerOption {
+	return func(d *TextDebouncer) {
+		if c != nil {
+			d.clock = c
+		}
+	}
+}
+
+// NewTextDebouncer creates a TextDebouncer that calls deliver with buffered text.
+// An interval of zero or less uses DefaultTextDebounceInterval.
+func NewTextDebouncer(interval time.Duration, deliver func(TextChunk), opts ...TextDebouncerOption) *TextDebouncer {
+	if interval <= 0 {
+		interval = DefaultTextDebounceInterval
+	}
+	d := &TextDebouncer{
+		interval: interval,
+		deliver:  deliver,
+		clock:    clock.Real(),
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// Handle buffers text and transcript deltas, and flushes everything buffered when a text
+// or transcript is done or the response is done. Other messages are ignored.
+func (d *TextDebouncer) Handle(ctx context.Context, msg incoming.RcvdMsg) {
+	switch m := msg.(type) {
+	case *incoming.ResponseOutputTextDeltaMessage:
+		d.add(TextChunk{
+			Type:         DeltaTypeText,
+			ResponseID:   m.ResponseID,
+			ItemID:       m.ItemID,
+			OutputIndex:  m.OutputIndex,
+			ContentIndex: m.ContentIndex,
+			Text:         m.Delta,
+		})
+	case *incoming.ResponseOutputAudioTranscriptDeltaMessage:
+		d.add(TextChunk{
+			Type:         DeltaTypeTranscript,
+			ResponseID:   m.ResponseID,
+			ItemID:       m.ItemID,
+			OutputIndex:  m.OutputIndex,
+			ContentIndex: m.ContentIndex,
+			Text:         m.Delta,
+		})
+	case *incoming.ResponseOutputTextDoneMessage,
+		*incoming.ResponseOutputAudioTranscriptDoneMessage,
+		*incoming.ResponseDoneMessage:
+		d.Flush()
+	}
+}
+
+// Flush immediately delivers any buffered text.
+// It should be called when the consumer is done to avoid losing the tail of a response.
+func (d *TextDebouncer) Flush() {
+	d.deliverMu.Lock()
+	defer d.deliverMu.Unlock()
+
+	d.mu.Lock()
+	chunk := d.pending
+	d.pending = nil
+	if d.timer != nil {
+		d.timer.Stop()
+		d.timer = nil
+	}
+	d.mu.Unlock()
+
+	if chunk != nil && chunk.Text != "" && d.deliver != nil {
+		d.deliver(*chunk)
+	}
+}
+
+// SetPanicHandler sets a function that is called when deliver panics on the interval timer.
+// The panic is recovered and reported as a *ws.PanicError. Panics in deliver called from
+// Handle or Flush propagate to the caller as usual.
+func (d *TextDebouncer) SetPanicHandler(onPanic ws.PanicHandler) {
+	d.panics.set(onPanic)
+}
+
+// flushReported flushes the buffered text on the interval timer, reporting a panic in deliver
+func (d *TextDebouncer) flushReported() {
+	defer d.panics.recoverPanic("text debouncer flush")
+	d.Flush()
+}
+
+// add appends a fragment to the pending chunk, flushing first if it belongs to a
+// different content part and afterwards if it ends a sentence
+func (d *TextDebouncer) add(chunk TextChunk) {
+	d.mu.Lock()
+	if d.pending != nil && !d.pending.sameContent(chunk) {
+		d.mu.Unlock()
+		d.Flush()
+		d.mu.Lock()
+	}
+
+	if d.pending == nil {
+		d.pending = &chunk
+		d.timer = d.clock.AfterFunc(d.interval, d.flushReported)
+	} else {
+		d.pending.Text += chunk.Text
+	}
+	d.mu.Unlock()
+
+	if endsSentence(chunk.Text) {
+		d.Flush()
+	}
+}
+
+// sameContent reports whether two chunks belong to the same content part
+func (c *TextChunk) sameContent(other TextChunk) bool {
+	return c.Type == other.Type &&
+		c.ResponseID == other.ResponseID &&
+		c.ItemID == other.ItemID &&
+		c.OutputIndex == other.OutputIndex &&
+		c.ContentIndex == other.ContentIndex
+}
+
+// endsSentence reports whether a fragment ends with sentence-terminating punctuation or a newline
+func endsSentence(text string) bool {
+	if strings.HasSuffix(text, "\n") {
+		return true
+	}
+	trimmed := strings.TrimRight(text, " \t\"')")
+	return strings.HasSuffix(trimmed, ".") ||
+		strings.HasSuffix(trimmed, "!") ||
+		strings.HasSuffix(trimmed, "?")
+}
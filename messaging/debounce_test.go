@@ -0,0 +1,105 @@
+package messaging
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/clock"
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+)
+
+func textDelta(itemID, text string) *incoming.ResponseOutputTextDeltaMessage {
+	return &incoming.ResponseOutputTextDeltaMessage{
+		ResponseID: "resp_1",
+		ItemID:     itemID,
+		Delta:      text,
+	}
+}
+
+func TestTextDebouncerSentenceBoundary(t *testing.T) {
+	var chunks []string
+	debouncer := NewTextDebouncer(time.Hour, func(chunk TextChunk) {
+		chunks = append(chunks, chunk.Text)
+	})
+	ctx := context.Background()
+
+	for _, delta := range []string{"Hello", " there", ".", " How", " are", " you"} {
+		debouncer.Handle(ctx, textDelta("item_1", delta))
+	}
+	debouncer.Handle(ctx, &incoming.ResponseOutputTextDoneMessage{ItemID: "item_1"})
+
+	expected := []string{"Hello there.", " How are you"}
+	if len(chunks) != len(expected) {
+		t.Fatalf("Expected %d chunks, got %v", len(expected), chunks)
+	}
+	for i := range expected {
+		if chunks[i] != expected[i] {
+			t.Errorf("Expected chunk %d to be %q, got %q", i, expected[i], chunks[i])
+		}
+	}
+}
+
+func TestTextDebouncerSeparatesContent(t *testing.T) {
+	var chunks []TextChunk
+	debouncer := NewTextDebouncer(time.Hour, func(chunk TextChunk) {
+		chunks = append(chunks, chunk)
+	})
+	ctx := context.Background()
+
+	debouncer.Handle(ctx, textDelta("item_1", "a"))
+	debouncer.Handle(ctx, &incoming.ResponseOutputAudioTranscriptDeltaMessage{ResponseID: "resp_1", ItemID: "item_1", Delta: "b"})
+	debouncer.Flush()
+
+	if len(chunks) != 2 {
+		t.Fatalf("Expected 2 chunks, got %d", len(chunks))
+	}
+	if chunks[0].Type != DeltaTypeText || chunks[1].Type != DeltaTypeTranscript {
+		t.Errorf("Expected text then transcript, got %s then %s", chunks[0].Type, chunks[1].Type)
+	}
+}
+
+func TestTextDebouncerInterval(t *testing.T) {
+	fake := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	delivered := make(chan TextChunk, 1)
+	debouncer := NewTextDebouncer(100*time.Millisecond, func(chunk TextChunk) {
+		delivered <- chunk
+	}, WithTextDebouncerClock(fake))
+
+	debouncer.Handle(context.Background(), textDelta("item_1", "part"))
+	fake.Advance(99 * time.Millisecond)
+	debouncer.Handle(context.Background(), textDelta("item_1", "ial"))
+	if fake.Timers() != 1 {
+		t.Fatal("Expected the text to stay buffered before the interval")
+	}
+	fake.Advance(time.Millisecond)
+
+	select {
+	case chunk := <-delivered:
+		if chunk.Text != "partial" {
+			t.Errorf("Expected 'partial', got %q", chunk.Text)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected text to be delivered after the interval")
+	}
+}
+
+func TestEndsSentence(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected bool
+	}{
+		{"Hello.", true},
+		{"Really?\" ", true},
+		{"Wow!", true},
+		{"line\n", true},
+		{"3.5", false},
+		{"hello", false},
+	}
+
+	for _, tt := range tests {
+		if got := endsSentence(tt.text); got != tt.expected {
+			t.Errorf("Expected endsSentence(%q) to be %v, got %v", tt.text, tt.expected, got)
+		}
+	}
+}
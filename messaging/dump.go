@@ -0,0 +1,154 @@
+package messaging
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/clock"
+)
+
+//-----------------------------------------------------------------------------
+// Event Dumper
+//-----------------------------------------------------------------------------
+
+// DumpFormat selects how an EventDumper writes events
+type DumpFormat string
+
+const (
+	// DumpFormatJSONL writes one compact JSON record per line, suitable for tools like jq
+	DumpFormatJSONL DumpFormat = "jsonl"
+	// DumpFormatPretty writes indented JSON records separated by blank lines, for reading
+	DumpFormatPretty DumpFormat = "pretty"
+)
+
+// Dump directions
+const (
+	// DumpDirectionIncoming marks events received from the server
+	DumpDirectionIncoming = "in"
+	// DumpDirectionOutgoing marks events sent by the client
+	DumpDirectionOutgoing = "out"
+)
+
+// DumpRecord is a single event written by an EventDumper
+type DumpRecord struct {
+	// Time is when the frame was read or written
+	Time time.Time `json:"time"`
+	// Direction is DumpDirectionIncoming or DumpDirectionOutgoing
+	Direction string `json:"direction"`
+	// Event is the raw JSON event, exactly as it was on the wire
+	Event json.RawMessage `json:"event"`
+}
+
+// EventDumperOption configures an EventDumper
+type EventDumperOption func(*EventDumper)
+
+// WithDumpFormat sets the output format; the default is DumpFormatJSONL
+func WithDumpFormat(format DumpFormat) EventDumperOption {
+	return func(d *EventDumper) {
+		d.format = format
+	}
+}
+
+// WithDumpClock sets the clock that records are timestamped with; the default is the
+// real clock
+func WithDumpClock(c clock.Clock) EventDumperOption {
+	return func(d *EventDumper) {
+		if c != nil {
+			d.clock = c
+		}
+	}
+}
+
+// WithDumpOutgoing also dumps the events sent by the client
+func WithDumpOutgoing() EventDumperOption {
+	return func(d *EventDumper) {
+		d.outgoing = true
+	}
+}
+
+// EventDumper writes every raw event seen by a Client to a writer with a timestamp,
+// for protocol debugging and for capturing payloads to use as golden fixtures.
+// Events are dumped before they are decoded, so events the typed messages do not
+// know are captured too. Install it with Client.SetEventDumper.
+//
+// Example:
+//
+//	f, _ := os.Create("events.jsonl")
+//	defer f.Close()
+//	msgClient.SetEventDumper(messaging.NewEventDumper(f, messaging.WithDumpOutgoing()))
+type EventDumper struct {
+	format   DumpFormat
+	outgoing bool
+
+	mu    sync.Mutex
+	w     io.Writer
+	err   error
+	clock clock.Clock
+}
+
+// NewEventDumper creates an EventDumper writing to w
+func NewEventDumper(w io.Writer, opts ...EventDumperOption) *EventDumper {
+	d := &EventDumper{
+		w:      w,
+		format: DumpFormatJSONL,
+		clock:  clock.Real(),
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+	return d
+}
+
+// Dump writes one event. Each record is written with a single Write call, so writers
+// can process records one at a time. Frames that are not valid JSON are recorded as
+// JSON strings. After a write fails, further events are dropped and Err reports the failure.
+func (d *EventDumper) Dump(direction string, data []byte) {
+	if direction == DumpDirectionOutgoing && !d.outgoing {
+		return
+	}
+
+	event := json.RawMessage(data)
+	if !json.Valid(data) {
+		event, _ = json.Marshal(string(data))
+	}
+	record := DumpRecord{Time: d.clock.Now().UTC(), Direction: direction, Event: event}
+
+	var out []byte
+	var err error
+	if d.format == DumpFormatPretty {
+		var buf bytes.Buffer
+		if out, err = json.Marshal(record); err == nil {
+			err = json.Indent(&buf, out, "", "  ")
+			buf.WriteString("\n\n")
+			out = buf.Bytes()
+		}
+	} else {
+		if out, err = json.Marshal(record); err == nil {
+			out = append(out, '\n')
+		}
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.err != nil {
+		return
+	}
+	if err != nil {
+		d.err = fmt.Errorf("failed to encode event: %w", err)
+		return
+	}
+	if _, err := d.w.Write(out); err != nil {
+		d.err = fmt.Errorf("failed to write event: %w", err)
+	}
+}
+
+// Err returns the first error that stopped the dumper, if any
+func (d *EventDumper) Err() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.err
+}
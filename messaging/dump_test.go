@@ -0,0 +1,137 @@
+package messaging
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/clock"
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+func TestEventDumperRecordsFrames(t *testing.T) {
+	mockConn := newScriptedConn(
+		`{"type":"session.created","session":{"id":"sess_1"}}`,
+		`{"type":"experimental.unknown","value":1}`,
+	)
+	mockConn.WriteMessageFunc = func(ctx context.Context, messageType ws.MessageType, data []byte) error {
+		return nil
+	}
+	client := NewClient(ws.NewConn(mockConn))
+
+	var buf bytes.Buffer
+	dumper := NewEventDumper(&buf, WithDumpOutgoing(), WithDumpClock(clock.NewFake(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC))))
+	client.SetEventDumper(dumper)
+
+	if _, err := client.ReadMessage(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := client.SendText(context.Background(), "Hi"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// Unknown events fail to decode but are still dumped
+	if _, err := client.ReadMessage(context.Background()); err == nil {
+		t.Fatal("Expected an error for an unknown event type")
+	}
+
+	var records []DumpRecord
+	scanner := bufio.NewScanner(&buf)
+	for scanner.Scan() {
+		var record DumpRecord
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatalf("Expected a JSON line, got %q: %v", scanner.Text(), err)
+		}
+		records = append(records, record)
+	}
+
+	expected := []struct {
+		direction string
+		eventType string
+	}{
+		{DumpDirectionIncoming, "session.created"},
+		{DumpDirectionOutgoing, "conversation.item.create"},
+		{DumpDirectionIncoming, "experimental.unknown"},
+	}
+	if len(records) != len(expected) {
+		t.Fatalf("Expected %d records, got %d: %s", len(expected), len(records), buf.String())
+	}
+	for i, want := range expected {
+		var base struct {
+			Type string `json:"type"`
+		}
+		if err := json.Unmarshal(records[i].Event, &base); err != nil {
+			t.Fatalf("Record %d: unexpected error: %v", i, err)
+		}
+		if records[i].Direction != want.direction || base.Type != want.eventType {
+			t.Errorf("Record %d: expected %s %s, got %s %s", i, want.direction, want.eventType, records[i].Direction, base.Type)
+		}
+		if !records[i].Time.Equal(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)) {
+			t.Errorf("Record %d: expected fixed time, got %v", i, records[i].Time)
+		}
+	}
+}
+
+func TestEventDumperFormats(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []EventDumperOption
+		data     string
+		expected string
+	}{
+		{
+			name:     "jsonl",
+			data:     `{"type":"error"}`,
+			expected: `{"time":"2025-01-02T03:04:05Z","direction":"in","event":{"type":"error"}}` + "\n",
+		},
+		{
+			name:     "pretty",
+			opts:     []EventDumperOption{WithDumpFormat(DumpFormatPretty)},
+			data:     `{"type":"error"}`,
+			expected: "{\n  \"time\": \"2025-01-02T03:04:05Z\",\n  \"direction\": \"in\",\n  \"event\": {\n    \"type\": \"error\"\n  }\n}\n\n",
+		},
+		{
+			name:     "invalid json",
+			data:     `not json`,
+			expected: `{"time":"2025-01-02T03:04:05Z","direction":"in","event":"not json"}` + "\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			opts := append(tt.opts, WithDumpClock(clock.NewFake(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC))))
+			dumper := NewEventDumper(&buf, opts...)
+			dumper.Dump(DumpDirectionIncoming, []byte(tt.data))
+			if buf.String() != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, buf.String())
+			}
+		})
+	}
+}
+
+// failingWriter fails every write
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("disk full")
+}
+
+func TestEventDumperSkipsOutgoingAndReportsErrors(t *testing.T) {
+	var buf bytes.Buffer
+	dumper := NewEventDumper(&buf)
+	dumper.Dump(DumpDirectionOutgoing, []byte(`{"type":"response.create"}`))
+	if buf.Len() != 0 {
+		t.Errorf("Expected outgoing events to be skipped by default, got %q", buf.String())
+	}
+
+	failing := NewEventDumper(failingWriter{})
+	failing.Dump(DumpDirectionIncoming, []byte(`{"type":"error"}`))
+	if err := failing.Err(); err == nil || !strings.Contains(err.Error(), "disk full") {
+		t.Errorf("Expected write error, got %v", err)
+	}
+}
@@ -0,0 +1,192 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/Mliviu79/openai-realtime-go/messages/outgoing"
+	"github.com/Mliviu79/openai-realtime-go/messages/types"
+)
+
+//-----------------------------------------------------------------------------
+// Content Guardrails
+//-----------------------------------------------------------------------------
+
+// ErrContentBlocked is wrapped by the errors of guardrails that block content
+var ErrContentBlocked = errors.New("content blocked")
+
+// GuardrailTarget identifies the kind of text passed to a Guardrail
+type GuardrailTarget string
+
+const (
+	// GuardrailTargetItem is the text of a conversation item: message text, audio
+	// transcripts and function call outputs
+	GuardrailTargetItem GuardrailTarget = "item"
+
+	// GuardrailTargetInstructions is the instructions of a session update or response
+	GuardrailTargetInstructions GuardrailTarget = "instructions"
+)
+
+// GuardedText is a piece of outbound text checked by a Guardrail
+type GuardedText struct {
+	// Target is the kind of text
+	Target GuardrailTarget
+
+	// EventType is the client event that carries the text
+	EventType outgoing.OutMsgType
+
+	// Role is the role of the item the text belongs to; empty for instructions
+	Role types.MessageRole
+
+	// Text is the text to check
+	Text string
+}
+
+// Guardrail checks outbound text before it is sent. It returns the text to send, which
+// may be transformed (for example with personal data redacted), or an error to block
+// the event. Guardrails can run regular expressions, call a moderation API or scrub PII.
+type Guardrail func(ctx context.Context, text GuardedText) (string, error)
+
+// PolicyError is returned by the send methods when a guardrail blocks an event.
+// Err is the error returned by the guardrail.
+type PolicyError struct {
+	// EventType is the client event that was blocked
+	EventType outgoing.OutMsgType
+
+	// Target is the kind of text that was blocked
+	Target GuardrailTarget
+
+	// Err is the reason given by the guardrail
+	Err error
+}
+
+// Error implements the error interface
+func (e *PolicyError) Error() string {
+	return fmt.Sprintf("%s blocked by content policy (%s): %v", e.EventType, e.Target, e.Err)
+}
+
+// Unwrap returns the guardrail's error
+func (e *PolicyError) Unwrap() error {
+	return e.Err
+}
+
+// RedactPattern returns a Guardrail that replaces every match of pattern with
+// replacement, which can refer to submatches as in regexp.Regexp.ReplaceAllString.
+//
+// Example:
+//
+//	cardNumbers := regexp.MustCompile(`\b(?:\d[ -]?){13,16}\b`)
+//	msgClient.SetGuardrails(messaging.RedactPattern(cardNumbers, "[card number]"))
+func RedactPattern(pattern *regexp.Regexp, replacement string) Guardrail {
+	return func(ctx context.Context, text GuardedText) (string, error) {
+		return pattern.ReplaceAllString(text.Text, replacement), nil
+	}
+}
+
+// BlockPattern returns a Guardrail that blocks text matching pattern with an error
+// wrapping ErrContentBlocked and giving reason
+func BlockPattern(pattern *regexp.Regexp, reason string) Guardrail {
+	return func(ctx context.Context, text GuardedText) (string, error) {
+		if pattern.MatchString(text.Text) {
+			return "", fmt.Errorf("%w: %s", ErrContentBlocked, reason)
+		}
+		return text.Text, nil
+	}
+}
+
+// SetGuardrails sets the guardrails that check the text of conversation items and the
+// instructions of session updates and responses before they are sent. The guardrails run
+// in order, each on the text returned by the previous one. If one returns an error, the
+// event is not sent and the send method returns a *PolicyError. Calling SetGuardrails
+// with no guardrails removes them.
+//
+// The caller's messages are not modified; guardrails apply to a copy. Events sent with
+// SendRaw are not checked.
+//
+// Example:
+//
+//	msgClient.SetGuardrails(
+//		messaging.BlockPattern(regexp.MustCompile(`(?i)internal use only`), "confidential"),
+//		messaging.RedactPattern(regexp.MustCompile(`[\w.+-]+@[\w-]+\.[\w.]+`), "[email]"),
+//	)
+//	err := msgClient.SendText(ctx, text)
+//	var policyErr *messaging.PolicyError
+//	if errors.As(err, &policyErr) {
+//		log.Printf("not sent: %v", policyErr.Err)
+//	}
+func (c *Client) SetGuardrails(guardrails ...Guardrail) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.guardrails = guardrails
+}
+
+// applyGuardrails returns msg with its text checked by the guardrails, or a *PolicyError
+func (c *Client) applyGuardrails(ctx context.Context, msg outgoing.OutMsg) (outgoing.OutMsg, error) {
+	c.mu.RLock()
+	guardrails := c.guardrails
+	c.mu.RUnlock()
+
+	if len(guardrails) == 0 {
+		return msg, nil
+	}
+
+	eventType := outgoing.OutMsgType(msg.OutMsgType())
+	check := func(target GuardrailTarget, role types.MessageRole, text *string) error {
+		if *text == "" {
+			return nil
+		}
+		for _, guardrail := range guardrails {
+			checked, err := guardrail(ctx, GuardedText{Target: target, EventType: eventType, Role: role, Text: *text})
+			if err != nil {
+				return &PolicyError{EventType: eventType, Target: target, Err: err}
+			}
+			*text = checked
+		}
+		return nil
+	}
+	checkItem := func(item *types.MessageItem) error {
+		for i := range item.Content {
+			if err := check(GuardrailTargetItem, item.Role, &item.Content[i].Text); err != nil {
+				return err
+			}
+			if err := check(GuardrailTargetItem, item.Role, &item.Content[i].Transcript); err != nil {
+				return err
+			}
+		}
+		return check(GuardrailTargetItem, item.Role, &item.Output)
+	}
+	checkInstructions := func(instructions **string) error {
+		if *instructions == nil {
+			return nil
+		}
+		text := **instructions
+		if err := check(GuardrailTargetInstructions, "", &text); err != nil {
+			return err
+		}
+		*instructions = &text
+		return nil
+	}
+
+	switch m := msg.(type) {
+	case outgoing.ConversationCreateMessage:
+		m.Item = m.Item.Clone()
+		return m, checkItem(&m.Item)
+	case *outgoing.ConversationCreateMessage:
+		checked := *m
+		checked.Item = m.Item.Clone()
+		return &checked, checkItem(&checked.Item)
+	case outgoing.SessionUpdateMessage:
+		return m, checkInstructions(&m.Session.Instructions)
+	case *outgoing.SessionUpdateMessage:
+		checked := *m
+		return &checked, checkInstructions(&checked.Session.Instructions)
+	case outgoing.ResponseCreateMessage:
+		return m, checkInstructions(&m.Response.Instructions)
+	case *outgoing.ResponseCreateMessage:
+		checked := *m
+		return &checked, checkInstructions(&checked.Response.Instructions)
+	}
+	return msg, nil
+}
@@ -0,0 +1,133 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/Mliviu79/openai-realtime-go/messages/outgoing"
+	"github.com/Mliviu79/openai-realtime-go/messages/types"
+	"github.com/Mliviu79/openai-realtime-go/session"
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+func TestGuardrails(t *testing.T) {
+	email := regexp.MustCompile(`[\w.+-]+@[\w-]+\.[\w.]+`)
+	confidential := regexp.MustCompile(`(?i)internal use only`)
+	instructions := "Reply to bob@example.com"
+
+	tests := []struct {
+		name           string
+		send           func(ctx context.Context, c *Client) error
+		expectedSent   string
+		expectedTarget GuardrailTarget
+	}{
+		{
+			name:         "item text is redacted",
+			send:         func(ctx context.Context, c *Client) error { return c.SendText(ctx, "Mail me at alice@example.com") },
+			expectedSent: `"text":"Mail me at [email]"`,
+		},
+		{
+			name: "function call output is redacted",
+			send: func(ctx context.Context, c *Client) error {
+				item := &types.MessageItem{Type: types.MessageItemTypeFunctionCallOutput, CallID: "call_1", Output: `{"owner":"carol@example.com"}`}
+				return c.SendConversationItemCreate(ctx, item, nil)
+			},
+			expectedSent: `"output":"{\"owner\":\"[email]\"}"`,
+		},
+		{
+			name: "session instructions are redacted",
+			send: func(ctx context.Context, c *Client) error {
+				return c.SendSessionUpdate(ctx, session.SessionRequest{ResponseParams: session.ResponseParams{Instructions: &instructions}})
+			},
+			expectedSent: `"instructions":"Reply to [email]"`,
+		},
+		{
+			name: "response instructions are blocked",
+			send: func(ctx context.Context, c *Client) error {
+				blocked := "This is for INTERNAL USE ONLY"
+				return c.SendResponseCreate(ctx, &types.ResponseConfig{ResponseParams: session.ResponseParams{Instructions: &blocked}})
+			},
+			expectedTarget: GuardrailTargetInstructions,
+		},
+		{
+			name:           "item text is blocked",
+			send:           func(ctx context.Context, c *Client) error { return c.SendText(ctx, "Internal use only: the code") },
+			expectedTarget: GuardrailTargetItem,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, sent, _ := recordingConn()
+			client := NewClient(ws.NewConn(conn))
+			client.SetGuardrails(BlockPattern(confidential, "confidential"), RedactPattern(email, "[email]"))
+
+			err := tt.send(context.Background(), client)
+			if tt.expectedTarget != "" {
+				var policyErr *PolicyError
+				if !errors.As(err, &policyErr) || policyErr.Target != tt.expectedTarget {
+					t.Fatalf("Expected a policy error for %s, got %v", tt.expectedTarget, err)
+				}
+				if !errors.Is(err, ErrContentBlocked) {
+					t.Errorf("Expected the error to wrap ErrContentBlocked, got %v", err)
+				}
+				if len(sent()) != 0 {
+					t.Errorf("Expected nothing to be sent, got %v", sent())
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if frames := sent(); len(frames) != 1 || !strings.Contains(frames[0], tt.expectedSent) {
+				t.Errorf("Expected a frame containing %s, got %v", tt.expectedSent, frames)
+			}
+		})
+	}
+
+	if instructions != "Reply to bob@example.com" {
+		t.Errorf("Expected the caller's instructions to be unchanged, got %q", instructions)
+	}
+}
+
+func TestGuardrailsDoNotModifyMessage(t *testing.T) {
+	conn, sent, _ := recordingConn()
+	client := NewClient(ws.NewConn(conn))
+	var seen []GuardedText
+	client.SetGuardrails(func(ctx context.Context, text GuardedText) (string, error) {
+		seen = append(seen, text)
+		return strings.ToUpper(text.Text), nil
+	})
+
+	msg := outgoing.NewConversationCreateMessage("", types.MessageItem{
+		Type:    types.MessageItemTypeMessage,
+		Role:    types.MessageRoleUser,
+		Content: []types.MessageContentPart{{Type: types.MessageContentTypeInputText, Text: "hello"}},
+	})
+	if err := client.SendMessage(context.Background(), &msg); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if msg.Item.Content[0].Text != "hello" {
+		t.Errorf("Expected the caller's message to be unchanged, got %q", msg.Item.Content[0].Text)
+	}
+	if frames := sent(); len(frames) != 1 || !strings.Contains(frames[0], `"text":"HELLO"`) {
+		t.Errorf("Expected the transformed text to be sent, got %v", frames)
+	}
+	if len(seen) != 1 || seen[0].Role != types.MessageRoleUser || seen[0].EventType != outgoing.OutMsgTypeConversationCreate {
+		t.Errorf("Expected one user item text, got %+v", seen)
+	}
+
+	// Other events are not checked
+	seen = nil
+	if err := client.SendResponseCancel(context.Background(), "resp_1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(seen) != 0 {
+		t.Errorf("Expected no checks for response.cancel, got %+v", seen)
+	}
+}
@@ -2,6 +2,8 @@ package messaging
 
 import (
 	"context"
+	"fmt"
+	"sync"
 
 	"github.com/Mliviu79/openai-realtime-go/logger"
 	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
@@ -15,13 +17,18 @@ type MessageHandler func(ctx context.Context, event incoming.RcvdMsg)
 // It reads messages in a standalone goroutine and calls the registered handlers.
 // It is the responsibility of the caller to call Start and Stop.
 type Handler struct {
-	ctx       context.Context
-	cancel    context.CancelFunc
-	client    *Client
+	ctx      context.Context
+	cancel   context.CancelFunc
+	client   *Client
+	handlers []MessageHandler
+	logger   logger.Logger
+
+	// wsHandler reads the current connection; it is replaced when the conversation is handed off.
+	// errCh reports the error of whichever reader is current when it exits, and done is set then.
+	mu        sync.Mutex
 	wsHandler *ws.ConnHandler
-	handlers  []MessageHandler
-	logger    logger.Logger
 	errCh     chan error
+	done      bool
 }
 
 // NewHandler creates a new Handler for the OpenAI Realtime API.
@@ -41,7 +48,7 @@ func NewHandler(parentCtx context.Context, client *Client, handlers ...MessageHa
 	}
 
 	// Create a WebSocket handler that will decode raw messages into OpenAI messages
-	wsHandler := ws.NewConnHandler(ctx, client.conn, h.handleRawMessage)
+	wsHandler := ws.NewConnHandler(ctx, client.Conn(), h.handleRawMessage)
 	h.wsHandler = wsHandler
 
 	return h
@@ -57,14 +64,43 @@ func (h *Handler) Start() {
 	if h.logger != nil {
 		h.logger.Debugf("Starting message handler")
 	}
-	h.wsHandler.Start()
+	h.mu.Lock()
+	wsHandler := h.wsHandler
+	h.mu.Unlock()
+
+	wsHandler.Start()
+	go h.watch(wsHandler)
 }
 
-// Err returns a channel that receives errors from the handler.
+// Err returns a channel that receives the error that stopped the reader, if any,
+// and is closed when the reader exits. A reader replaced by a handoff does not count
+// as exiting.
 func (h *Handler) Err() <-chan error {
 	return h.errCh
 }
 
+// watch forwards the exit of a reader to Err if it is still the current reader
+func (h *Handler) watch(wsHandler *ws.ConnHandler) {
+	var err error
+	for e := range wsHandler.Err() {
+		err = e
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.wsHandler != wsHandler || h.done {
+		return
+	}
+	h.done = true
+	if err != nil {
+		if h.ctx.Err() == nil {
+			h.client.finish(err)
+		}
+		h.errCh <- err
+	}
+	close(h.errCh)
+}
+
 // AddHandler adds a message handler.
 // This is safe to call before Start() but not after.
 func (h *Handler) AddHandler(handler MessageHandler) {
@@ -82,7 +118,11 @@ func (h *Handler) Stop() {
 	if h.logger != nil {
 		h.logger.Debugf("Stopping message handler")
 	}
-	h.wsHandler.Stop()
+	h.mu.Lock()
+	wsHandler := h.wsHandler
+	h.mu.Unlock()
+
+	wsHandler.Stop()
 	if h.cancel != nil {
 		h.cancel()
 	}
@@ -99,8 +139,12 @@ func (h *Handler) handleRawMessage(ctx context.Context, messageType ws.MessageTy
 		return
 	}
 
+	h.client.dumpFrame(DumpDirectionIncoming, data)
+	h.client.notifyReceived(data)
+	h.client.validateFrame(data)
+
 	// Decode the message
-	msg, err := incoming.UnmarshalRcvdMsg(data)
+	msg, err := h.client.unmarshal(data)
 	if err != nil {
 		if h.logger != nil {
 			h.logger.Errorf("Failed to unmarshal message: %v", err)
@@ -112,6 +156,22 @@ func (h *Handler) handleRawMessage(ctx context.Context, messageType ws.MessageTy
 		h.logger.Debugf("Received message of type: %s", msg.RcvdMsgType())
 	}
 
+	for _, msg := range h.client.normalizeText(msg) {
+		h.dispatch(ctx, msg)
+	}
+
+	// Every handler has returned, so a pooled audio buffer can be reused
+	if m, ok := msg.(*incoming.ResponseOutputAudioDeltaMessage); ok {
+		m.Release()
+	}
+}
+
+// dispatch updates the client's tracked state with a decoded message and calls the handlers
+func (h *Handler) dispatch(ctx context.Context, msg incoming.RcvdMsg) {
+	// Keep the client's tracked state up to date
+	h.client.observe(msg)
+	ctx = withReader(ctx, h.client)
+
 	// Call the handlers
 	for i, handler := range h.handlers {
 		if handler == nil {
@@ -127,6 +187,7 @@ func (h *Handler) handleRawMessage(ctx context.Context, messageType ws.MessageTy
 					if h.logger != nil {
 						h.logger.Errorf("Handler %d panicked: %v", i, r)
 					}
+					h.client.reportPanic(fmt.Sprintf("message handler %d", i), r)
 				}
 			}()
 			handler(ctx, msg)
@@ -0,0 +1,272 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+	"github.com/Mliviu79/openai-realtime-go/session"
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+//-----------------------------------------------------------------------------
+// Conversation Handoff
+//-----------------------------------------------------------------------------
+
+// ErrHandlerStopped is returned by Handoff when the handler's reader has already exited
+var ErrHandlerStopped = errors.New("handler stopped")
+
+// HandoffEventKind identifies a step of a conversation handoff
+type HandoffEventKind string
+
+const (
+	// HandoffConnected is emitted when the new session is connected and configured
+	HandoffConnected HandoffEventKind = "connected"
+	// HandoffImported is emitted when the conversation history was imported into the new session
+	HandoffImported HandoffEventKind = "imported"
+	// HandoffSwapped is emitted when the client has switched to the new session
+	HandoffSwapped HandoffEventKind = "swapped"
+	// HandoffFailed is emitted when the handoff fails; the client keeps using the old session
+	HandoffFailed HandoffEventKind = "failed"
+)
+
+// HandoffEvent describes a step of a conversation handoff
+type HandoffEvent struct {
+	// Kind is the type of event
+	Kind HandoffEventKind
+	// Items is the number of imported items, for HandoffImported and HandoffSwapped events
+	Items int
+	// Err is the error that stopped the handoff, for HandoffFailed events
+	Err error
+}
+
+// HandoffOption configures Handoff
+type HandoffOption func(*handoffOptions)
+
+// handoffOptions holds the configuration for Handoff
+type handoffOptions struct {
+	session    *session.SessionRequest
+	ackTimeout time.Duration
+	onEvent    func(HandoffEvent)
+}
+
+// WithHandoffSession sends a session update to the new session before the history is
+// imported, e.g. to set its voice or carry over instructions and tools
+func WithHandoffSession(req session.SessionRequest) HandoffOption {
+	return func(o *handoffOptions) {
+		o.session = &req
+	}
+}
+
+// WithHandoffAckTimeout sets how long to wait for the session update and each imported
+// item to be acknowledged; the default is DefaultHistoryAckTimeout
+func WithHandoffAckTimeout(timeout time.Duration) HandoffOption {
+	return func(o *handoffOptions) {
+		if timeout > 0 {
+			o.ackTimeout = timeout
+		}
+	}
+}
+
+// WithOnHandoffEvent sets a function that is called with each step of the handoff.
+// It is called synchronously from Handoff and must not block.
+func WithOnHandoffEvent(onEvent func(HandoffEvent)) HandoffOption {
+	return func(o *handoffOptions) {
+		o.onEvent = onEvent
+	}
+}
+
+// Handoff transfers the conversation to a new session, e.g. one with a different model
+// or voice, without stopping the handler. It connects the new session with dial, applies
+// WithHandoffSession, imports the items recorded by history, and then atomically swaps
+// the connection used by the handler's client, so sends made after Handoff returns go to
+// the new session and the registered handlers receive its events. The old connection is
+// closed.
+//
+// Events of the new session received before the swap, such as the acknowledgements of the
+// imported items, are tracked by the client but not passed to the handlers. Responses in
+// progress and input audio not yet committed on the old session are abandoned, and the
+// receipts of events it never confirmed fail with ErrConnectionLost, so Handoff is best
+// called between turns. If any step fails, the new connection is closed and the old
+// session stays in use.
+//
+// Example:
+//
+//	history := messaging.NewConversationHistory()
+//	handler := messaging.NewHandler(ctx, msgClient, history.Handle, onMessage)
+//	handler.Start()
+//	...
+//	err := handler.Handoff(ctx, func(ctx context.Context) (*ws.Conn, error) {
+//		return client.Connect(ctx, openaiClient.WithModel(session.GPTRealtime))
+//	}, history, messaging.WithHandoffSession(req))
+func (h *Handler) Handoff(ctx context.Context, dial DialFunc, history *ConversationHistory, opts ...HandoffOption) error {
+	o := handoffOptions{ackTimeout: DefaultHistoryAckTimeout}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	err := h.handoff(ctx, dial, history, o)
+	if err != nil {
+		o.emit(HandoffEvent{Kind: HandoffFailed, Err: err})
+	}
+	return err
+}
+
+// handoff performs the steps of Handoff
+func (h *Handler) handoff(ctx context.Context, dial DialFunc, history *ConversationHistory, o handoffOptions) error {
+	items := history.Items()
+
+	conn, err := dial(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to connect new session: %w", err)
+	}
+
+	// The staging client tracks the new session until the swap; its reader becomes the
+	// handler's reader afterwards, so the new connection is read without interruption
+	staging := NewClient(conn)
+	h.client.mu.RLock()
+	staging.logger = h.client.logger
+	staging.ids = h.client.ids
+	h.client.mu.RUnlock()
+	var swapped atomic.Bool
+	reader := ws.NewConnHandler(h.ctx, conn, func(ctx context.Context, messageType ws.MessageType, data []byte) {
+		if swapped.Load() {
+			h.handleRawMessage(ctx, messageType, data)
+			return
+		}
+		staging.observeFrame(messageType, data)
+	})
+	reader.Start()
+
+	abort := func(err error) error {
+		reader.Stop()
+		conn.Close()
+		return err
+	}
+
+	if o.session != nil {
+		if err := staging.updateSessionAndWait(ctx, *o.session, o.ackTimeout); err != nil {
+			return abort(fmt.Errorf("failed to configure new session: %w", err))
+		}
+	}
+	o.emit(HandoffEvent{Kind: HandoffConnected})
+
+	if len(items) > 0 {
+		if _, err := staging.SendConversationItems(ctx, items, WithWaitForAck(o.ackTimeout)); err != nil {
+			return abort(fmt.Errorf("failed to import history: %w", err))
+		}
+		o.emit(HandoffEvent{Kind: HandoffImported, Items: len(items)})
+	}
+
+	// Holding the send gate keeps writes off both connections until the swap is complete
+	if err := h.client.gate.acquire(ctx, true); err != nil {
+		return abort(err)
+	}
+	h.mu.Lock()
+	if h.done {
+		h.mu.Unlock()
+		h.client.gate.release()
+		return abort(ErrHandlerStopped)
+	}
+	old := h.wsHandler
+	h.wsHandler = reader
+	h.mu.Unlock()
+
+	oldConn := h.client.swapConn(staging)
+	swapped.Store(true)
+	h.client.gate.release()
+	go h.watch(reader)
+
+	old.Stop()
+	oldConn.Close()
+	o.emit(HandoffEvent{Kind: HandoffSwapped, Items: len(items)})
+	return nil
+}
+
+// emit calls the handoff event function, if any
+func (o handoffOptions) emit(event HandoffEvent) {
+	if o.onEvent != nil {
+		o.onEvent(event)
+	}
+}
+
+// swapConn replaces the client's connection and tracked session with those of staging
+// and returns the old connection. State that belongs to the old session is forgotten,
+// since the new session will never confirm it: the responses in progress, the input
+// audio pending and awaiting commit, the last item sent with ordering and the response
+// retries. Receipts still pending fail with ErrConnectionLost. The caller must hold the
+// send gate so no frame is written during the swap.
+func (c *Client) swapConn(staging *Client) *ws.Conn {
+	staging.mu.RLock()
+	conn, current, conversationID := staging.conn, staging.session, staging.conversationID
+	staging.mu.RUnlock()
+
+	c.mu.Lock()
+	old := c.conn
+	c.conn = conn
+	c.session = current
+	c.conversationID = conversationID
+	c.resetResponseState()
+	c.inputAudio.bytes, c.inputAudio.commits, c.inputAudio.clears = 0, 0, 0
+	c.ordering.tail, c.ordering.tailEventID = "", ""
+	// Failed responses of the old session are not retried in the new one
+	clear(c.retries)
+	if c.logger != nil {
+		conn.SetLogger(c.logger)
+	}
+	conn.SetPanicHandler(c.onPanic)
+	conn.SetRethrowPanics(c.rethrowPanics)
+	receipts := c.receipts
+	c.mu.Unlock()
+
+	if receipts != nil {
+		receipts.abandon(ErrConnectionLost)
+	}
+	return old
+}
+
+// observeFrame decodes a raw frame and updates the client's tracked state with it
+func (c *Client) observeFrame(messageType ws.MessageType, data []byte) {
+	if messageType != ws.MessageText {
+		return
+	}
+	msg, err := incoming.UnmarshalRcvdMsg(data)
+	if err != nil {
+		if log := c.getLogger(); log != nil {
+			log.Warnf("Failed to unmarshal message: %v", err)
+		}
+		return
+	}
+	c.observe(msg)
+}
+
+// updateSessionAndWait sends a session update and waits for session.updated or an error
+func (c *Client) updateSessionAndWait(ctx context.Context, req session.SessionRequest, timeout time.Duration) error {
+	w := c.addWaiter(func(m incoming.RcvdMsg) bool {
+		switch m.(type) {
+		case *incoming.SessionUpdatedMessage, *incoming.ErrorMessage:
+			return true
+		}
+		return false
+	})
+	defer c.removeWaiter(w)
+
+	if err := c.SendSessionUpdate(ctx, req); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	select {
+	case m := <-w.ch:
+		if errMsg, ok := m.(*incoming.ErrorMessage); ok {
+			return errMsg.AsAPIError()
+		}
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("waiting for session.updated: %w", ctx.Err())
+	}
+}
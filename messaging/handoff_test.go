@@ -0,0 +1,242 @@
+package messaging
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+	"github.com/Mliviu79/openai-realtime-go/session"
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+// fakeSession stands in for a realtime session: it acknowledges session updates and
+// conversation items, records what was written, and delivers pushed server events
+type fakeSession struct {
+	events chan []byte
+	closed chan struct{}
+	once   sync.Once
+
+	mu   sync.Mutex
+	sent []string
+}
+
+func newFakeSession() *fakeSession {
+	return &fakeSession{events: make(chan []byte, 16), closed: make(chan struct{})}
+}
+
+func (s *fakeSession) push(event string) {
+	s.events <- []byte(event)
+}
+
+func (s *fakeSession) written() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.sent...)
+}
+
+func (s *fakeSession) conn() *ws.Conn {
+	return ws.NewConn(&MockConn{
+		ReadMessageFunc: func(ctx context.Context) (ws.MessageType, []byte, error) {
+			select {
+			case data := <-s.events:
+				return ws.MessageText, data, nil
+			case <-s.closed:
+				return ws.MessageText, nil, errors.New("use of closed network connection")
+			case <-ctx.Done():
+				return ws.MessageText, nil, ctx.Err()
+			}
+		},
+		WriteMessageFunc: func(ctx context.Context, messageType ws.MessageType, data []byte) error {
+			s.mu.Lock()
+			s.sent = append(s.sent, string(data))
+			s.mu.Unlock()
+
+			var msg struct {
+				Type    string          `json:"type"`
+				Session json.RawMessage `json:"session"`
+				sentItemCreate
+			}
+			if err := json.Unmarshal(data, &msg); err != nil {
+				return err
+			}
+			switch msg.Type {
+			case "session.update":
+				s.push(fmt.Sprintf(`{"type":"session.updated","session":%s}`, msg.Session))
+			case "conversation.item.create":
+				s.push(fmt.Sprintf(`{"type":"conversation.item.created","previous_item_id":%q,"item":{"id":%q,"type":"message"}}`,
+					msg.PreviousItemID, msg.Item.ID))
+			}
+			return nil
+		},
+		CloseFunc: func() error {
+			s.once.Do(func() { close(s.closed) })
+			return nil
+		},
+	})
+}
+
+func (s *fakeSession) isClosed() bool {
+	select {
+	case <-s.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestHandlerHandoff(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	oldSession, newSession := newFakeSession(), newFakeSession()
+	client := NewClient(oldSession.conn())
+
+	received := make(chan incoming.RcvdMsg, 16)
+	history := NewConversationHistory()
+	handler := NewHandler(ctx, client, history.Handle, func(ctx context.Context, msg incoming.RcvdMsg) {
+		received <- msg
+	})
+	handler.Start()
+	defer handler.Stop()
+
+	oldSession.push(`{"type":"conversation.item.created","item":{"id":"item_1","type":"message","role":"user","content":[{"type":"input_text","text":"Hi"}]}}`)
+	oldSession.push(`{"type":"conversation.item.created","previous_item_id":"item_1","item":{"id":"item_2","type":"message","role":"assistant","content":[{"type":"text","text":"Hello"}]}}`)
+	for i := 0; i < 2; i++ {
+		<-received
+	}
+
+	// State of the old session that the new session will never confirm
+	receipts := NewReceiptTracker()
+	client.SetReceiptTracker(receipts)
+	client.SetItemOrdering(NewConversationTracker())
+	client.setOrderingTail("item_old", "evt_old")
+	if err := client.SendConversationItemDelete(ctx, "item_1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	audio := base64.StdEncoding.EncodeToString(make([]byte, 4800))
+	for _, send := range []func() error{
+		func() error { return client.SendAudioBufferAppend(ctx, audio) },
+		func() error { return client.SendAudioBufferCommit(ctx, "") },
+		func() error { return client.SendAudioBufferAppend(ctx, audio) },
+	} {
+		if err := send(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	pending := receipts.Pending()
+	if len(pending) != 2 || client.PendingAudioDuration() == 0 {
+		t.Fatalf("Expected a pending delete and commit and pending audio, got %d receipts and %v", len(pending), client.PendingAudioDuration())
+	}
+	oldWrites := len(oldSession.written())
+
+	var kinds []string
+	voice := session.VoiceCoral
+	err := handler.Handoff(ctx, func(ctx context.Context) (*ws.Conn, error) {
+		return newSession.conn(), nil
+	}, history,
+		WithHandoffSession(session.SessionRequest{ResponseParams: session.ResponseParams{Voice: &voice}}),
+		WithOnHandoffEvent(func(event HandoffEvent) { kinds = append(kinds, string(event.Kind)) }),
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if strings.Join(kinds, ",") != "connected,imported,swapped" {
+		t.Errorf("Expected events connected,imported,swapped, got %v", kinds)
+	}
+	imported := newSession.written()
+	if len(imported) != 3 || !strings.Contains(imported[1], `"id":"item_1"`) || !strings.Contains(imported[2], `"previous_item_id":"item_1"`) {
+		t.Errorf("Expected a session update and both items in order, got %v", imported)
+	}
+	if s, ok := client.Session(); !ok || s.Voice == nil || *s.Voice != voice {
+		t.Errorf("Expected the client to track the new session, got %+v", s)
+	}
+	if !oldSession.isClosed() {
+		t.Error("Expected the old connection to be closed")
+	}
+	for _, r := range pending {
+		if receipt, err := receipts.Wait(ctx, r.EventID); !errors.Is(err, ErrConnectionLost) || receipt.Status != ReceiptFailed {
+			t.Errorf("Expected the pending %s to fail with ErrConnectionLost, got %s, %v", r.Type, receipt.Status, err)
+		}
+	}
+	client.mu.RLock()
+	commits, tail := client.inputAudio.commits, client.ordering.tail
+	client.mu.RUnlock()
+	if d := client.PendingAudioDuration(); d != 0 || commits != 0 {
+		t.Errorf("Expected no input audio pending or awaiting commit, got %v and %d commits", d, commits)
+	}
+	if tail != "" {
+		t.Errorf("Expected the ordering tail to be forgotten, got %q", tail)
+	}
+	select {
+	case msg := <-received:
+		t.Errorf("Expected events before the swap not to reach the handlers, got %s", msg.RcvdMsgType())
+	default:
+	}
+
+	// Sends and events now go through the new session
+	if err := client.SendText(ctx, "Still there?"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if n := len(oldSession.written()) - oldWrites; n != 0 {
+		t.Errorf("Expected nothing sent to the old session, got %d messages", n)
+	}
+	if written := newSession.written(); strings.Contains(written[len(written)-1], "item_old") {
+		t.Errorf("Expected the item not to be ordered after an item of the old session, got %s", written[len(written)-1])
+	}
+	newSession.push(`{"type":"response.done","response":{"id":"resp_1","status":"completed"}}`)
+	for done := false; !done; {
+		select {
+		case msg := <-received:
+			done = msg.RcvdMsgType() == incoming.RcvdMsgTypeResponseDone
+		case <-ctx.Done():
+			t.Fatal("Expected events of the new session to reach the handlers")
+		}
+	}
+	select {
+	case err := <-handler.Err():
+		t.Errorf("Expected the handler to keep running after the handoff, got %v", err)
+	default:
+	}
+}
+
+func TestHandlerHandoffFailure(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	oldSession := newFakeSession()
+	client := NewClient(oldSession.conn())
+	handler := NewHandler(ctx, client)
+	handler.Start()
+	defer handler.Stop()
+
+	var failed []error
+	dialErr := errors.New("dial refused")
+	err := handler.Handoff(ctx, func(ctx context.Context) (*ws.Conn, error) {
+		return nil, dialErr
+	}, NewConversationHistory(), WithOnHandoffEvent(func(event HandoffEvent) {
+		if event.Kind == HandoffFailed {
+			failed = append(failed, event.Err)
+		}
+	}))
+
+	if !errors.Is(err, dialErr) {
+		t.Errorf("Expected the dial error, got %v", err)
+	}
+	if len(failed) != 1 || !errors.Is(failed[0], dialErr) {
+		t.Errorf("Expected a failed event, got %v", failed)
+	}
+	if err := client.SendText(ctx, "Hi"); err != nil || len(oldSession.written()) != 1 {
+		t.Errorf("Expected the client to keep using the old session, got %v", err)
+	}
+	if oldSession.isClosed() {
+		t.Error("Expected the old connection to stay open")
+	}
+}
@@ -0,0 +1,210 @@
+package messaging
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+	"github.com/Mliviu79/openai-realtime-go/messages/types"
+	"github.com/Mliviu79/openai-realtime-go/store"
+)
+
+//-----------------------------------------------------------------------------
+// Conversation History
+//-----------------------------------------------------------------------------
+
+// EvictionReason identifies the limit that caused a ConversationHistory eviction
+type EvictionReason string
+
+const (
+	// EvictionMaxItems is reported when the oldest item is removed to stay within
+	// WithHistoryMaxItems
+	EvictionMaxItems EvictionReason = "max_items"
+
+	// EvictionMaxAudioBytes is reported when the audio of the oldest item holding audio
+	// is dropped to stay within WithHistoryMaxAudioBytes; the item and its transcript
+	// are kept
+	EvictionMaxAudioBytes EvictionReason = "max_audio_bytes"
+)
+
+// Eviction describes an item removed from a ConversationHistory, or whose audio was dropped
+type Eviction struct {
+	// Item is the item as it was recorded before the eviction
+	Item types.MessageItem
+
+	// Reason is the limit that caused the eviction
+	Reason EvictionReason
+}
+
+// ConversationHistoryOption configures a ConversationHistory
+type ConversationHistoryOption func(*ConversationHistory)
+
+// WithHistoryMaxItems keeps at most n items, removing the oldest when a new item is
+// recorded. Zero, the default, keeps every item.
+func WithHistoryMaxItems(n int) ConversationHistoryOption {
+	return func(h *ConversationHistory) {
+		h.maxItems = n
+	}
+}
+
+// WithHistoryMaxAudioBytes keeps at most n bytes of base64 audio in the recorded items,
+// dropping the audio of the oldest items first. Items keep their transcripts, so they
+// can still be exported with Items. Zero, the default, keeps all audio.
+func WithHistoryMaxAudioBytes(n int) ConversationHistoryOption {
+	return func(h *ConversationHistory) {
+		h.maxAudioBytes = n
+	}
+}
+
+// WithOnEvict sets a function that is called for every item removed, or whose audio is
+// dropped, to stay within the history's limits, for example to archive it. It is called
+// from Handle after the history is updated and must not block.
+func WithOnEvict(onEvict func(Eviction)) ConversationHistoryOption {
+	return func(h *ConversationHistory) {
+		h.onEvict = onEvict
+	}
+}
+
+// ConversationHistory records the items of a conversation in server order, so the
+// conversation can be re-imported into a new session with SendConversationItems.
+//
+// The history learns the conversation from the server events passed to Handle,
+// so Handle must be registered with the Handler. By default it grows with the
+// conversation; long sessions can bound it with WithHistoryMaxItems and
+// WithHistoryMaxAudioBytes.
+type ConversationHistory struct {
+	mu    sync.Mutex
+	items []types.MessageItem
+
+	maxItems      int
+	maxAudioBytes int
+	onEvict       func(Eviction)
+}
+
+// NewConversationHistory creates an empty ConversationHistory
+func NewConversationHistory(opts ...ConversationHistoryOption) *ConversationHistory {
+	h := &ConversationHistory{}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// Handle records created items, fills in their final content and input transcripts,
+// drops deleted items and then applies the item and audio limits.
+func (h *ConversationHistory) Handle(ctx context.Context, msg incoming.RcvdMsg) {
+	h.mu.Lock()
+	switch m := msg.(type) {
+	case *incoming.ConversationItemCreatedMessage:
+		h.insert(m.PreviousItemID, m.Item.MessageItem.Clone())
+	case *incoming.ResponseOutputItemDoneMessage:
+		// Only items that were added to the conversation are updated; out-of-band
+		// responses do not create conversation items
+		if i := h.indexOf(m.Item.ID); i >= 0 {
+			h.items[i].Status = m.Item.Status
+			h.items[i].Content = slices.Clone(m.Item.Content)
+			h.items[i].Arguments = m.Item.Arguments
+		}
+	case *incoming.ConversationItemTranscriptionCompletedMessage:
+		if i := h.indexOf(m.ItemID); i >= 0 && m.ContentIndex < len(h.items[i].Content) {
+			h.items[i].Content[m.ContentIndex].Transcript = m.Transcript
+		}
+	case *incoming.ConversationItemDeletedMessage:
+		if i := h.indexOf(m.ItemID); i >= 0 {
+			h.items = slices.Delete(h.items, i, i+1)
+		}
+	}
+	evicted := h.enforceLimits()
+	onEvict := h.onEvict
+	h.mu.Unlock()
+
+	if onEvict != nil {
+		for _, eviction := range evicted {
+			onEvict(eviction)
+		}
+	}
+}
+
+// enforceLimits removes the oldest items and audio beyond the history's limits and
+// returns what was evicted. The caller must hold h.mu.
+func (h *ConversationHistory) enforceLimits() []Eviction {
+	var evicted []Eviction
+	if h.maxItems > 0 && len(h.items) > h.maxItems {
+		excess := len(h.items) - h.maxItems
+		for _, item := range h.items[:excess] {
+			evicted = append(evicted, Eviction{Item: item, Reason: EvictionMaxItems})
+		}
+		h.items = slices.Delete(h.items, 0, excess)
+	}
+
+	if h.maxAudioBytes > 0 {
+		total := 0
+		for _, item := range h.items {
+			total += itemAudioBytes(item)
+		}
+		for i := 0; i < len(h.items) && total > h.maxAudioBytes; i++ {
+			size := itemAudioBytes(h.items[i])
+			if size == 0 {
+				continue
+			}
+			evicted = append(evicted, Eviction{Item: h.items[i].Clone(), Reason: EvictionMaxAudioBytes})
+			for j := range h.items[i].Content {
+				h.items[i].Content[j].Audio = ""
+			}
+			total -= size
+		}
+	}
+	return evicted
+}
+
+// itemAudioBytes returns the size of the base64 audio held by an item's content
+func itemAudioBytes(item types.MessageItem) int {
+	size := 0
+	for _, part := range item.Content {
+		size += len(part.Audio)
+	}
+	return size
+}
+
+// insert adds an item after previousItemID, or at the end if it is not known.
+// An item that is already recorded is replaced in place.
+func (h *ConversationHistory) insert(previousItemID string, item types.MessageItem) {
+	h.items = store.InsertItem(h.items, previousItemID, item)
+}
+
+// indexOf returns the position of the item with the given ID, or -1
+func (h *ConversationHistory) indexOf(id string) int {
+	if id == "" {
+		return -1
+	}
+	return slices.IndexFunc(h.items, func(item types.MessageItem) bool {
+		return item.ID == id
+	})
+}
+
+// item returns a copy of the recorded item with the given ID
+func (h *ConversationHistory) item(id string) (types.MessageItem, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if i := h.indexOf(id); i >= 0 {
+		return h.items[i].Clone(), true
+	}
+	return types.MessageItem{}, false
+}
+
+// Len returns the number of recorded items
+func (h *ConversationHistory) Len() int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.items)
+}
+
+// Items returns the recorded items in a form that can be sent with conversation.item.create.
+// Audio cannot be re-imported, so audio content is replaced by its transcript and
+// messages left without content are skipped.
+func (h *ConversationHistory) Items() []types.MessageItem {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return store.ImportableItems(h.items)
+}
@@ -0,0 +1,94 @@
+package messaging
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Mliviu79/openai-realtime-go/messages/types"
+)
+
+func TestConversationHistory(t *testing.T) {
+	history := NewConversationHistory()
+	ctx := context.Background()
+
+	for _, msg := range []string{
+		`{"type":"conversation.item.created","item":{"id":"item_1","object":"realtime.item","type":"message","status":"completed","role":"user","content":[{"type":"input_audio"}]}}`,
+		`{"type":"conversation.item.created","previous_item_id":"item_1","item":{"id":"item_3","type":"message","role":"assistant","content":[]}}`,
+		`{"type":"conversation.item.created","previous_item_id":"item_1","item":{"id":"item_2","type":"message","role":"system","content":[{"type":"input_text","text":"Be brief"}]}}`,
+		`{"type":"conversation.item.input_audio_transcription.completed","item_id":"item_1","content_index":0,"transcript":"Hello there"}`,
+		`{"type":"response.output_item.done","response_id":"resp_1","item":{"id":"item_3","type":"message","status":"completed","role":"assistant","content":[{"type":"audio","transcript":"Hi!"}]}}`,
+		`{"type":"response.output_item.done","response_id":"resp_oob","item":{"id":"item_oob","type":"message","role":"assistant","content":[{"type":"text","text":"out of band"}]}}`,
+		`{"type":"conversation.item.created","previous_item_id":"item_3","item":{"id":"item_4","type":"function_call","name":"lookup","call_id":"call_1","arguments":"{}"}}`,
+		`{"type":"conversation.item.created","previous_item_id":"item_4","item":{"id":"item_5","type":"message","role":"user","content":[{"type":"input_audio"}]}}`,
+		`{"type":"conversation.item.created","previous_item_id":"item_5","item":{"id":"item_6","type":"message","role":"user","content":[{"type":"input_text","text":"Deleted"}]}}`,
+		`{"type":"conversation.item.deleted","item_id":"item_6"}`,
+	} {
+		history.Handle(ctx, mustParse(t, msg))
+	}
+
+	if history.Len() != 5 {
+		t.Errorf("Expected 5 recorded items, got %d", history.Len())
+	}
+
+	items := history.Items()
+	expected := []types.MessageItem{
+		{ID: "item_1", Type: types.MessageItemTypeMessage, Role: types.MessageRoleUser,
+			Content: []types.MessageContentPart{{Type: types.MessageContentTypeInputText, Text: "Hello there"}}},
+		{ID: "item_2", Type: types.MessageItemTypeMessage, Role: types.MessageRoleSystem,
+			Content: []types.MessageContentPart{{Type: types.MessageContentTypeInputText, Text: "Be brief"}}},
+		{ID: "item_3", Type: types.MessageItemTypeMessage, Role: types.MessageRoleAssistant,
+			Content: []types.MessageContentPart{{Type: types.MessageContentTypeText, Text: "Hi!"}}},
+		{ID: "item_4", Type: types.MessageItemTypeFunctionCall, Name: "lookup", CallID: "call_1", Arguments: "{}"},
+	}
+	if len(items) != len(expected) {
+		t.Fatalf("Expected %d importable items, got %d: %+v", len(expected), len(items), items)
+	}
+	for i := range expected {
+		if !items[i].Equal(expected[i]) {
+			t.Errorf("Expected item %d to be %+v, got %+v", i, expected[i], items[i])
+		}
+	}
+
+	// Converting to importable items does not change the recorded history
+	items[0].Content[0].Text = "changed"
+	if again := history.Items(); again[0].Content[0].Text != "Hello there" {
+		t.Errorf("Expected recorded transcript to be unchanged, got %q", again[0].Content[0].Text)
+	}
+}
+
+func TestConversationHistoryLimits(t *testing.T) {
+	var evictions []Eviction
+	history := NewConversationHistory(
+		WithHistoryMaxItems(3),
+		WithHistoryMaxAudioBytes(8),
+		WithOnEvict(func(eviction Eviction) { evictions = append(evictions, eviction) }),
+	)
+	ctx := context.Background()
+
+	for _, msg := range []string{
+		`{"type":"conversation.item.created","item":{"id":"item_1","type":"message","role":"user","content":[{"type":"input_text","text":"First"}]}}`,
+		`{"type":"conversation.item.created","previous_item_id":"item_1","item":{"id":"item_2","type":"message","role":"user","content":[{"type":"input_audio","audio":"AAAAAAAA","transcript":"Second"}]}}`,
+		`{"type":"conversation.item.created","previous_item_id":"item_2","item":{"id":"item_3","type":"message","role":"user","content":[{"type":"input_audio","audio":"BBBB","transcript":"Third"}]}}`,
+		`{"type":"conversation.item.created","previous_item_id":"item_3","item":{"id":"item_4","type":"message","role":"user","content":[{"type":"input_text","text":"Fourth"}]}}`,
+	} {
+		history.Handle(ctx, mustParse(t, msg))
+	}
+
+	if len(evictions) != 2 {
+		t.Fatalf("Expected 2 evictions, got %+v", evictions)
+	}
+	if evictions[0].Reason != EvictionMaxAudioBytes || evictions[0].Item.ID != "item_2" || evictions[0].Item.Content[0].Audio != "AAAAAAAA" {
+		t.Errorf("Expected the audio of item_2 to be evicted first, got %+v", evictions[0])
+	}
+	if evictions[1].Reason != EvictionMaxItems || evictions[1].Item.ID != "item_1" {
+		t.Errorf("Expected item_1 to be evicted for the item limit, got %+v", evictions[1])
+	}
+
+	items := history.Items()
+	if len(items) != 3 || items[0].ID != "item_2" || items[0].Content[0].Text != "Second" {
+		t.Fatalf("Expected item_2 to keep its transcript, got %+v", items)
+	}
+	if history.Len() != 3 {
+		t.Errorf("Expected 3 recorded items, got %d", history.Len())
+	}
+}
@@ -0,0 +1,86 @@
+package messaging
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+	"sync"
+)
+
+//-----------------------------------------------------------------------------
+// Client-side IDs
+//-----------------------------------------------------------------------------
+
+// IDGenerator creates the event and item IDs the client assigns to outgoing events,
+// e.g. by SendConversationItems and the ReceiptTracker
+type IDGenerator interface {
+	// NewID returns a new ID starting with prefix ("evt_" or "item_")
+	NewID(prefix string) string
+}
+
+// RandomIDs returns the default IDGenerator, which creates random IDs
+func RandomIDs() IDGenerator {
+	return randomIDs{}
+}
+
+// randomIDs creates random IDs
+type randomIDs struct{}
+
+// NewID returns a random identifier with the given prefix, short enough for item IDs
+func (randomIDs) NewID(prefix string) string {
+	b := make([]byte, 12)
+	_, _ = rand.Read(b)
+	return prefix + hex.EncodeToString(b)
+}
+
+// SequentialIDs is an IDGenerator that numbers the IDs of each prefix from 1 (evt_1,
+// evt_2, …, item_1, …), so golden-file tests and replays of recorded sessions produce
+// the same output on every run. It is safe for concurrent use, but IDs are only stable
+// when the events are sent in a stable order.
+//
+// Example:
+//
+//	msgClient.SetIDGenerator(messaging.NewSequentialIDs())
+type SequentialIDs struct {
+	mu   sync.Mutex
+	next map[string]int
+}
+
+// NewSequentialIDs creates a SequentialIDs generator
+func NewSequentialIDs() *SequentialIDs {
+	return &SequentialIDs{next: make(map[string]int)}
+}
+
+// NewID returns the next ID with the given prefix
+func (s *SequentialIDs) NewID(prefix string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.next[prefix]++
+	return prefix + strconv.Itoa(s.next[prefix])
+}
+
+// Reset starts every prefix from 1 again
+func (s *SequentialIDs) Reset() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	clear(s.next)
+}
+
+// SetIDGenerator sets the generator of the event and item IDs the client assigns.
+// Passing nil restores the default random IDs.
+func (c *Client) SetIDGenerator(ids IDGenerator) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ids = ids
+}
+
+// newID returns a new ID with the given prefix from the client's generator
+func (c *Client) newID(prefix string) string {
+	c.mu.RLock()
+	ids := c.ids
+	c.mu.RUnlock()
+	if ids == nil {
+		ids = RandomIDs()
+	}
+	return ids.NewID(prefix)
+}
@@ -0,0 +1,47 @@
+package messaging
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+func TestSequentialIDs(t *testing.T) {
+	conn, sent, _ := recordingConn()
+	client := NewClient(ws.NewConn(conn))
+	ids := NewSequentialIDs()
+	client.SetIDGenerator(ids)
+	client.SetReceiptTracker(NewReceiptTracker())
+
+	ctx := context.Background()
+	if _, err := client.SendConversationItems(ctx, historyItems()[:2]); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := client.SendAudioBufferCommit(ctx, ""); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{
+		`"event_id":"evt_1"`, `"id":"item_1"`,
+		`"event_id":"evt_2"`, `"id":"item_2"`, `"previous_item_id":"item_1"`,
+		`"event_id":"evt_3"`,
+	}
+	output := strings.Join(sent(), "\n")
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("Expected the sent events to contain %s, got %s", want, output)
+		}
+	}
+
+	ids.Reset()
+	if id := ids.NewID("evt_"); id != "evt_1" {
+		t.Errorf("Expected evt_1 after a reset, got %s", id)
+	}
+
+	client.SetIDGenerator(nil)
+	if id := client.newID("item_"); !strings.HasPrefix(id, "item_") || len(id) != len("item_")+24 {
+		t.Errorf("Expected a random item ID without a generator, got %s", id)
+	}
+}
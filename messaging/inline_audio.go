@@ -0,0 +1,85 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+	"github.com/Mliviu79/openai-realtime-go/messages/outgoing"
+	"github.com/Mliviu79/openai-realtime-go/messages/types"
+)
+
+//-----------------------------------------------------------------------------
+// Inline Audio
+//-----------------------------------------------------------------------------
+
+// SetInlineAudioLimit makes the client drop the base64 audio of received conversation
+// items and content parts longer than maxBytes, keeping their transcripts, so long
+// audio-heavy calls do not keep every item's audio in memory. Handlers, trackers and
+// histories see the items without the dropped audio; fetch it when needed with
+// RetrieveConversationItem. Zero, the default, keeps all audio.
+//
+// Audio deltas are not affected. See incoming.DropInlineAudio for the messages the
+// limit applies to.
+func (c *Client) SetInlineAudioLimit(maxBytes int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.inlineAudioLimit = maxBytes
+}
+
+// dropInlineAudio applies the inline audio limit to a decoded message
+func (c *Client) dropInlineAudio(msg incoming.RcvdMsg) {
+	c.mu.RLock()
+	limit := c.inlineAudioLimit
+	c.mu.RUnlock()
+
+	if dropped := incoming.DropInlineAudio(msg, limit); dropped > 0 {
+		if log := c.getLogger(); log != nil {
+			log.Debugf("dropped %d bytes of inline audio from %s", dropped, msg.RcvdMsgType())
+		}
+	}
+}
+
+// SendConversationItemRetrieve sends a conversation item retrieve message.
+// The server replies with conversation.item.retrieved.
+func (c *Client) SendConversationItemRetrieve(ctx context.Context, itemID string) error {
+	msg := outgoing.NewConversationRetrieveMessage(itemID)
+	return c.SendMessage(ctx, msg)
+}
+
+// RetrieveConversationItem fetches a conversation item from the server, including any
+// audio dropped by SetInlineAudioLimit, and waits for the reply. Use a context with a
+// timeout to bound the wait.
+//
+// A Handler or ReadMessage loop must be running so that the conversation.item.retrieved
+// event is received. An error event for the request is returned as an *apierrs.APIError.
+func (c *Client) RetrieveConversationItem(ctx context.Context, itemID string) (types.ResponseMessageItem, error) {
+	msg := outgoing.NewConversationRetrieveMessage(itemID)
+	msg.ID = c.newID("evt_")
+
+	eventID := msg.ID
+	w := c.addWaiter(func(m incoming.RcvdMsg) bool {
+		switch m := m.(type) {
+		case *incoming.ConversationItemRetrievedMessage:
+			return m.Item.ID == itemID
+		case *incoming.ErrorMessage:
+			return m.Error.EventID == eventID
+		}
+		return false
+	})
+	defer c.removeWaiter(w)
+
+	if err := c.SendMessage(ctx, msg); err != nil {
+		return types.ResponseMessageItem{}, err
+	}
+
+	select {
+	case m := <-w.ch:
+		if errMsg, ok := m.(*incoming.ErrorMessage); ok {
+			return types.ResponseMessageItem{}, errMsg.AsAPIError()
+		}
+		return m.(*incoming.ConversationItemRetrievedMessage).Item, nil
+	case <-ctx.Done():
+		return types.ResponseMessageItem{}, fmt.Errorf("waiting for conversation.item.retrieved: %w", ctx.Err())
+	}
+}
@@ -0,0 +1,53 @@
+package messaging
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+func TestInlineAudioLimit(t *testing.T) {
+	frames := []string{
+		`{"type":"conversation.item.created","item":{"id":"item_1","type":"message","role":"user","content":[{"type":"input_audio","audio":"AAAAAAAA","transcript":"Hello"}]}}`,
+		`{"type":"conversation.item.retrieved","item":{"id":"item_1","type":"message","role":"user","content":[{"type":"input_audio","audio":"AAAAAAAA","transcript":"Hello"}]}}`,
+	}
+	retrieveSent := make(chan struct{})
+	client := NewClient(ws.NewConn(&MockConn{
+		ReadMessageFunc: func(ctx context.Context) (ws.MessageType, []byte, error) {
+			frame := frames[0]
+			frames = frames[1:]
+			return ws.MessageText, []byte(frame), nil
+		},
+		WriteMessageFunc: func(ctx context.Context, messageType ws.MessageType, data []byte) error {
+			close(retrieveSent)
+			return nil
+		},
+	}))
+	client.SetInlineAudioLimit(4)
+	ctx := context.Background()
+
+	msg, err := client.ReadMessage(ctx)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	part := msg.(*incoming.ConversationItemCreatedMessage).Item.Content[0]
+	if part.Audio != "" || part.Transcript != "Hello" {
+		t.Errorf("Expected the audio to be dropped and the transcript kept, got %+v", part)
+	}
+
+	go func() {
+		<-retrieveSent
+		if _, err := client.ReadMessage(ctx); err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	}()
+	item, err := client.RetrieveConversationItem(ctx, "item_1")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if item.Content[0].Audio != "AAAAAAAA" {
+		t.Errorf("Expected the retrieved item to keep its audio, got %+v", item)
+	}
+}
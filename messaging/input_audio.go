@@ -0,0 +1,184 @@
+package messaging
+
+import (
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/audio"
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+	"github.com/Mliviu79/openai-realtime-go/messages/outgoing"
+	"github.com/Mliviu79/openai-realtime-go/session"
+)
+
+//-----------------------------------------------------------------------------
+// Input Audio Buffer
+//-----------------------------------------------------------------------------
+
+// InputAudioWarningKind identifies a suspicious use of the input audio buffer
+type InputAudioWarningKind string
+
+const (
+	// InputAudioWarningShortCommit is reported when a commit is sent with less than
+	// audio.MinCommitDuration of audio, which the server rejects
+	InputAudioWarningShortCommit InputAudioWarningKind = "short_commit"
+
+	// InputAudioWarningClearNonEmpty is reported when a clear is sent while audio is
+	// pending, discarding it
+	InputAudioWarningClearNonEmpty InputAudioWarningKind = "clear_non_empty"
+)
+
+// InputAudioWarning describes a suspicious use of the input audio buffer
+type InputAudioWarning struct {
+	// Kind is the kind of warning
+	Kind InputAudioWarningKind
+
+	// Pending is the audio in the buffer when the commit or clear was sent
+	Pending time.Duration
+}
+
+// inputAudioBuffer tracks the audio appended to the server's input buffer since the
+// last commit or clear
+type inputAudioBuffer struct {
+	// bytes is the decoded size of the pending audio
+	bytes int
+
+	// commits and clears count the commits and clears sent whose confirmation has not
+	// been received, so the confirmations are not mistaken for server VAD commits
+	commits int
+	clears  int
+
+	onWarning func(InputAudioWarning)
+}
+
+// PendingAudioDuration returns how much audio has been appended to the input audio
+// buffer since the last commit or clear, in the session's input audio format (PCM16
+// until the server reports the session).
+//
+// The duration is tracked client-side from the appends, commits and clears sent and
+// the commits the server makes on its own, such as with server VAD. Audio appended
+// while such a commit is in flight may be counted as committed.
+func (c *Client) PendingAudioDuration() time.Duration {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.inputAudioFormat().DurationForBytes(c.inputAudio.bytes)
+}
+
+// SetInputAudioWarnings sets a function that is called when a commit carries less than
+// audio.MinCommitDuration of audio or a clear discards pending audio. The message is
+// still sent. Warnings are also logged if a logger is set.
+func (c *Client) SetInputAudioWarnings(onWarning func(InputAudioWarning)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.inputAudio.onWarning = onWarning
+}
+
+// checkInputAudio warns about a commit or clear that is about to be sent
+func (c *Client) checkInputAudio(msg outgoing.OutMsg) {
+	c.mu.RLock()
+	pending := c.inputAudioFormat().DurationForBytes(c.inputAudio.bytes)
+	onWarning := c.inputAudio.onWarning
+	c.mu.RUnlock()
+
+	var warning InputAudioWarning
+	switch outgoing.OutMsgType(msg.OutMsgType()) {
+	case outgoing.OutMsgTypeAudioBufferCommit:
+		if pending >= audio.MinCommitDuration {
+			return
+		}
+		warning = InputAudioWarning{Kind: InputAudioWarningShortCommit, Pending: pending}
+	case outgoing.OutMsgTypeAudioBufferClear:
+		if pending == 0 {
+			return
+		}
+		warning = InputAudioWarning{Kind: InputAudioWarningClearNonEmpty, Pending: pending}
+	default:
+		return
+	}
+
+	if log := c.getLogger(); log != nil {
+		log.Warnf("Input audio buffer %s with %s of audio pending", warning.Kind, warning.Pending)
+	}
+	if onWarning != nil {
+		onWarning(warning)
+	}
+}
+
+// trackSentInputAudio records an append, commit or clear that is about to be sent. It
+// is recorded beforehand so the server's confirmation cannot arrive first; the returned
+// function undoes it if the message could not be sent.
+func (c *Client) trackSentInputAudio(msg outgoing.OutMsg) (undo func()) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	appended := 0
+	switch m := msg.(type) {
+	case outgoing.AudioBufferAppendMessage:
+		appended = decodedLen(m.Audio)
+	case *outgoing.AudioBufferAppendMessage:
+		appended = decodedLen(m.Audio)
+	case outgoing.AudioBufferCommitMessage, *outgoing.AudioBufferCommitMessage:
+		return c.resetInputAudio(&c.inputAudio.commits)
+	case outgoing.AudioBufferClearMessage, *outgoing.AudioBufferClearMessage:
+		return c.resetInputAudio(&c.inputAudio.clears)
+	default:
+		return func() {}
+	}
+
+	c.inputAudio.bytes += appended
+	return func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.inputAudio.bytes = max(0, c.inputAudio.bytes-appended)
+	}
+}
+
+// resetInputAudio empties the pending audio for a commit or clear and counts it as
+// awaiting confirmation. The caller must hold c.mu.
+func (c *Client) resetInputAudio(awaiting *int) (undo func()) {
+	pending := c.inputAudio.bytes
+	c.inputAudio.bytes = 0
+	*awaiting++
+	return func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.inputAudio.bytes += pending
+		*awaiting--
+	}
+}
+
+// observeInputAudio resets the pending audio when the server commits or clears the
+// buffer on its own, such as a server VAD commit at the end of speech
+func (c *Client) observeInputAudio(msg incoming.RcvdMsg) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch msg.(type) {
+	case *incoming.AudioBufferCommittedMessage:
+		if c.inputAudio.commits > 0 {
+			c.inputAudio.commits--
+			return
+		}
+		c.inputAudio.bytes = 0
+	case *incoming.AudioBufferClearedMessage:
+		if c.inputAudio.clears > 0 {
+			c.inputAudio.clears--
+			return
+		}
+		c.inputAudio.bytes = 0
+	}
+}
+
+// inputAudioFormat returns the session's input audio format, defaulting to PCM16.
+// The caller must hold c.mu.
+func (c *Client) inputAudioFormat() session.AudioFormat {
+	if c.session != nil && c.session.InputAudioFormat != nil && c.session.InputAudioFormat.IsValid() {
+		return *c.session.InputAudioFormat
+	}
+	return session.AudioFormatPCM16
+}
+
+// decodedLen returns the number of bytes encoded by a base64 string without decoding it
+func decodedLen(data string) int {
+	return base64.StdEncoding.DecodedLen(len(data)) - strings.Count(data[max(0, len(data)-2):], "=")
+}
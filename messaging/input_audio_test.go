@@ -0,0 +1,115 @@
+package messaging
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+// pcm16 returns base64-encoded PCM16 silence of the given duration
+func pcm16(d time.Duration) string {
+	return base64.StdEncoding.EncodeToString(make([]byte, int(d.Seconds()*48000)))
+}
+
+func TestPendingAudioDuration(t *testing.T) {
+	conn, _, _ := recordingConn()
+	client := NewClient(ws.NewConn(conn))
+	ctx := context.Background()
+
+	var warnings []InputAudioWarning
+	client.SetInputAudioWarnings(func(warning InputAudioWarning) {
+		warnings = append(warnings, warning)
+	})
+
+	for i := 0; i < 3; i++ {
+		if err := client.SendAudioBufferAppend(ctx, pcm16(20*time.Millisecond)); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if got := client.PendingAudioDuration(); got != 60*time.Millisecond {
+		t.Errorf("Expected 60ms pending, got %v", got)
+	}
+
+	// Too little audio for the server
+	if err := client.SendAudioBufferCommit(ctx, ""); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := client.PendingAudioDuration(); got != 0 {
+		t.Errorf("Expected no audio pending after a commit, got %v", got)
+	}
+	// The confirmation of our own commit does not reset audio appended since
+	if err := client.SendAudioBufferAppend(ctx, pcm16(200*time.Millisecond)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	client.observe(mustParse(t, `{"type":"input_audio_buffer.committed","item_id":"item_1"}`))
+	if got := client.PendingAudioDuration(); got != 200*time.Millisecond {
+		t.Errorf("Expected 200ms pending, got %v", got)
+	}
+
+	// A server VAD commit resets it
+	client.observe(mustParse(t, `{"type":"input_audio_buffer.committed","item_id":"item_2"}`))
+	if got := client.PendingAudioDuration(); got != 0 {
+		t.Errorf("Expected no audio pending after a server commit, got %v", got)
+	}
+
+	if err := client.SendAudioBufferAppend(ctx, pcm16(150*time.Millisecond)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := client.SendAudioBufferCommit(ctx, ""); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := client.SendAudioBufferAppend(ctx, pcm16(50*time.Millisecond)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := client.SendAudioBufferClear(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// A clear with nothing pending is not reported
+	if err := client.SendAudioBufferClear(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []InputAudioWarning{
+		{Kind: InputAudioWarningShortCommit, Pending: 60 * time.Millisecond},
+		{Kind: InputAudioWarningClearNonEmpty, Pending: 50 * time.Millisecond},
+	}
+	if len(warnings) != len(expected) {
+		t.Fatalf("Expected warnings %v, got %v", expected, warnings)
+	}
+	for i := range expected {
+		if warnings[i] != expected[i] {
+			t.Errorf("Expected warning %+v, got %+v", expected[i], warnings[i])
+		}
+	}
+}
+
+func TestPendingAudioDurationSendFailure(t *testing.T) {
+	fail := false
+	client := NewClient(ws.NewConn(&MockConn{
+		WriteMessageFunc: func(ctx context.Context, messageType ws.MessageType, data []byte) error {
+			if fail {
+				return errors.New("connection closed")
+			}
+			return nil
+		},
+	}))
+	ctx := context.Background()
+
+	if err := client.SendAudioBufferAppend(ctx, pcm16(100*time.Millisecond)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	fail = true
+	if err := client.SendAudioBufferAppend(ctx, pcm16(100*time.Millisecond)); err == nil {
+		t.Fatal("Expected the append to fail")
+	}
+	if err := client.SendAudioBufferCommit(ctx, ""); err == nil {
+		t.Fatal("Expected the commit to fail")
+	}
+	if got := client.PendingAudioDuration(); got != 100*time.Millisecond {
+		t.Errorf("Expected only the sent audio to be pending, got %v", got)
+	}
+}
@@ -0,0 +1,41 @@
+package messaging
+
+import (
+	"context"
+
+	"github.com/Mliviu79/openai-realtime-go/session"
+)
+
+//-----------------------------------------------------------------------------
+// Input Devices
+//-----------------------------------------------------------------------------
+
+// SetInputDevice sets the input audio noise reduction of the session to suit the device
+// the user speaks into, chosen by session.NoiseReductionFor, with a session update. No
+// update is sent if the session already uses that noise reduction.
+//
+// Example:
+//
+//	// The user switched from a headset to the speakerphone
+//	err := msgClient.SetInputDevice(ctx, session.InputDeviceSpeakerphone)
+func (c *Client) SetInputDevice(ctx context.Context, device session.InputDevice) error {
+	noiseReduction, err := session.NoiseReductionFor(device)
+	if err != nil {
+		return err
+	}
+	return c.UpdateSession(ctx, func(req *session.SessionRequest) {
+		req.InputAudioNoiseReduction = &noiseReduction
+	})
+}
+
+// SetTranscriptionInputDevice is SetInputDevice for transcription sessions. It always
+// sends a transcription session update, since transcription sessions are not tracked.
+func (c *Client) SetTranscriptionInputDevice(ctx context.Context, device session.InputDevice) error {
+	noiseReduction, err := session.NoiseReductionFor(device)
+	if err != nil {
+		return err
+	}
+	return c.SendTranscriptionSessionUpdate(ctx, session.TranscriptionSessionRequest{
+		InputAudioNoiseReduction: &noiseReduction,
+	})
+}
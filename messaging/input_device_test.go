@@ -0,0 +1,58 @@
+package messaging
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Mliviu79/openai-realtime-go/apierrs"
+	"github.com/Mliviu79/openai-realtime-go/session"
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+func TestSetInputDevice(t *testing.T) {
+	var written []string
+	mockConn := &MockConn{
+		ReadMessageFunc: func(ctx context.Context) (ws.MessageType, []byte, error) {
+			return ws.MessageText, []byte(`{"type":"session.created","session":{"id":"sess_1","input_audio_noise_reduction":{"type":"near_field"}}}`), nil
+		},
+		WriteMessageFunc: func(ctx context.Context, messageType ws.MessageType, data []byte) error {
+			written = append(written, string(data))
+			return nil
+		},
+	}
+	client := NewClient(ws.NewConn(mockConn))
+	ctx := context.Background()
+	if _, err := client.ReadMessage(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// A headset keeps the near field noise reduction already in use
+	if err := client.SetInputDevice(ctx, session.InputDeviceHeadset); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(written) != 0 {
+		t.Fatalf("Expected no update, got %v", written)
+	}
+
+	if err := client.SetInputDevice(ctx, session.InputDeviceSpeakerphone); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(written) != 1 || !strings.Contains(written[0], `"input_audio_noise_reduction":{"type":"far_field"}`) {
+		t.Fatalf("Expected a far field update, got %v", written)
+	}
+
+	if err := client.SetTranscriptionInputDevice(ctx, session.InputDeviceTelephony); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(written) != 2 || !strings.Contains(written[1], `"type":"transcription_session.update"`) || !strings.Contains(written[1], `"near_field"`) {
+		t.Fatalf("Expected a near field transcription update, got %v", written)
+	}
+
+	if err := client.SetInputDevice(ctx, "walkie_talkie"); !apierrs.IsAPIError(err) {
+		t.Errorf("Expected an unknown device to be rejected, got %v", err)
+	}
+	if len(written) != 2 {
+		t.Errorf("Expected nothing to be sent for an unknown device, got %v", written)
+	}
+}
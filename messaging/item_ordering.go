@@ -0,0 +1,116 @@
+package messaging
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+	"github.com/Mliviu79/openai-realtime-go/messages/outgoing"
+	"github.com/Mliviu79/openai-realtime-go/messages/types"
+)
+
+//-----------------------------------------------------------------------------
+// Item Ordering
+//-----------------------------------------------------------------------------
+
+// itemOrdering chains the items sent without a previous item ID after each other
+type itemOrdering struct {
+	// sendMu serializes ordered sends so items are written in the order they are chained
+	sendMu sync.Mutex
+
+	// tracker supplies the last confirmed item; tail is the last item sent by the client,
+	// created by the event tailEventID, until the tracker has seen it
+	tracker     *ConversationTracker
+	tail        string
+	tailEventID string
+}
+
+// SetItemOrdering makes the client fill in the previous_item_id of items sent without
+// one, so items sent in quick succession keep the order they were sent in instead of the
+// order the server happens to process them. Each item is inserted after the last item the
+// client sent that tracker has not seen yet, or else after the last item in tracker. An
+// explicit previous item ID, including an empty one passed to SendConversationItemCreate,
+// is sent unchanged. Items without an ID are assigned one. Passing nil turns ordering off.
+//
+// Ordering applies to SendConversationItemCreate, SendConversationItems and the helpers
+// built on them, such as SendText. The tracker must be fed by the Handler reading the
+// client's messages.
+//
+// Example:
+//
+//	tracker := messaging.NewConversationTracker()
+//	handler := messaging.NewHandler(ctx, msgClient, tracker.Handle)
+//	msgClient.SetItemOrdering(tracker)
+func (c *Client) SetItemOrdering(tracker *ConversationTracker) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ordering.tracker = tracker
+	c.ordering.tail = ""
+	c.ordering.tailEventID = ""
+}
+
+// orderingTracker returns the tracker set with SetItemOrdering, or nil if ordering is off
+func (c *Client) orderingTracker() *ConversationTracker {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.ordering.tracker
+}
+
+// sendOrderedItem sends item after the last item sent or confirmed
+func (c *Client) sendOrderedItem(ctx context.Context, tracker *ConversationTracker, item types.MessageItem) error {
+	c.ordering.sendMu.Lock()
+	defer c.ordering.sendMu.Unlock()
+
+	if item.ID == "" {
+		item.ID = c.newID("item_")
+	}
+	msg := outgoing.NewConversationCreateMessage(c.previousOrderedItem(tracker), item)
+	msg.ID = c.newID("evt_")
+	if err := c.SendMessage(ctx, msg); err != nil {
+		return err
+	}
+	c.setOrderingTail(item.ID, msg.ID)
+	return nil
+}
+
+// previousOrderedItem returns the item the next ordered item goes after: the last item
+// the client sent if the tracker has not seen it yet, or else the tracker's last item
+func (c *Client) previousOrderedItem(tracker *ConversationTracker) string {
+	c.mu.RLock()
+	tail := c.ordering.tail
+	c.mu.RUnlock()
+
+	if tail != "" && !tracker.contains(tail) {
+		return tail
+	}
+	last, _ := tracker.LastItemID()
+	return last
+}
+
+// setOrderingTail records the last item sent with ordering and the event that created it
+func (c *Client) setOrderingTail(itemID, eventID string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ordering.tail = itemID
+	c.ordering.tailEventID = eventID
+}
+
+// observeItemOrdering forgets the last item sent when the server rejects or deletes it,
+// so later items are not inserted after an item that does not exist
+func (c *Client) observeItemOrdering(msg incoming.RcvdMsg) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.ordering.tail == "" {
+		return
+	}
+	switch m := msg.(type) {
+	case *incoming.ErrorMessage:
+		if m.Error.EventID == c.ordering.tailEventID {
+			c.ordering.tail = ""
+		}
+	case *incoming.ConversationItemDeletedMessage:
+		if m.ItemID == c.ordering.tail {
+			c.ordering.tail = ""
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+// parseItemCreates decodes the conversation.item.create messages that were sent
+func parseItemCreates(t *testing.T, raw []string) []sentItemCreate {
+	t.Helper()
+	msgs := make([]sentItemCreate, len(raw))
+	for i, data := range raw {
+		if err := json.Unmarshal([]byte(data), &msgs[i]); err != nil {
+			t.Fatalf("Failed to unmarshal %s: %v", data, err)
+		}
+	}
+	return msgs
+}
+
+func TestItemOrdering(t *testing.T) {
+	conn, sent, _ := recordingConn()
+	client := NewClient(ws.NewConn(conn))
+	client.SetIDGenerator(NewSequentialIDs())
+	ctx := context.Background()
+
+	tracker := NewConversationTracker()
+	tracker.Handle(ctx, mustParse(t, `{"type":"conversation.item.created","item":{"id":"item_server","type":"message","role":"assistant"}}`))
+	client.SetItemOrdering(tracker)
+
+	// Items sent before any confirmation are chained after each other
+	if err := client.SendText(ctx, "one"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := client.SendText(ctx, "two"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	msgs := parseItemCreates(t, sent())
+	if msgs[0].PreviousItemID != "item_server" {
+		t.Errorf("Expected the first item after the tracked item, got %q", msgs[0].PreviousItemID)
+	}
+	if msgs[1].PreviousItemID != msgs[0].Item.ID {
+		t.Errorf("Expected the second item after %s, got %q", msgs[0].Item.ID, msgs[1].PreviousItemID)
+	}
+
+	// Once the tracker has seen the items, the next one follows the tracker's last item
+	for _, msg := range msgs {
+		tracker.Handle(ctx, mustParse(t, fmt.Sprintf(`{"type":"conversation.item.created","previous_item_id":%q,"item":{"id":%q,"type":"message","role":"user"}}`,
+			msg.PreviousItemID, msg.Item.ID)))
+	}
+	tracker.Handle(ctx, mustParse(t, `{"type":"conversation.item.created","item":{"id":"item_reply","type":"message","role":"assistant"}}`))
+	if err := client.SendText(ctx, "three"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// An explicit previous item ID is not overridden
+	explicit := "root"
+	item := historyItems()[0]
+	if err := client.SendConversationItemCreate(ctx, &item, &explicit); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	msgs = parseItemCreates(t, sent())
+	if msgs[2].PreviousItemID != "item_reply" {
+		t.Errorf("Expected the item after the tracker's last item, got %q", msgs[2].PreviousItemID)
+	}
+	if msgs[3].PreviousItemID != "root" {
+		t.Errorf("Expected the explicit previous item ID, got %q", msgs[3].PreviousItemID)
+	}
+	if item.ID != "" {
+		t.Errorf("Expected the caller's item to be unchanged, got ID %s", item.ID)
+	}
+}
+
+func TestItemOrderingForgetsRejectedItems(t *testing.T) {
+	conn, sent, _ := recordingConn()
+	client := NewClient(ws.NewConn(conn))
+	ctx := context.Background()
+
+	tracker := NewConversationTracker()
+	client.SetItemOrdering(tracker)
+
+	if err := client.SendText(ctx, "rejected"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	rejected := parseItemCreates(t, sent())[0]
+	client.observe(mustParse(t, fmt.Sprintf(`{"type":"error","error":{"type":"invalid_request_error","message":"bad item","event_id":%q}}`, rejected.EventID)))
+
+	if _, err := client.SendConversationItems(ctx, historyItems()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	msgs := parseItemCreates(t, sent())
+	if msgs[1].PreviousItemID != "" {
+		t.Errorf("Expected the batch to be appended after the rejected item, got %q", msgs[1].PreviousItemID)
+	}
+
+	// The batch becomes the tail for the next item
+	if err := client.SendText(ctx, "after batch"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	msgs = parseItemCreates(t, sent())
+	if msgs[4].PreviousItemID != msgs[3].Item.ID {
+		t.Errorf("Expected the item after the batch, got %q", msgs[4].PreviousItemID)
+	}
+
+	client.observe(mustParse(t, fmt.Sprintf(`{"type":"conversation.item.deleted","item_id":%q}`, msgs[4].Item.ID)))
+	if err := client.SendText(ctx, "after delete"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := parseItemCreates(t, sent())[5].PreviousItemID; got != "" {
+		t.Errorf("Expected a deleted item not to be referenced, got %q", got)
+	}
+}
@@ -0,0 +1,123 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+	"github.com/Mliviu79/openai-realtime-go/messages/outgoing"
+	"github.com/Mliviu79/openai-realtime-go/messages/types"
+)
+
+//-----------------------------------------------------------------------------
+// Batch Conversation Items
+//-----------------------------------------------------------------------------
+
+// ConversationItemsOption configures SendConversationItems
+type ConversationItemsOption func(*conversationItemsOptions)
+
+// conversationItemsOptions holds the settings for SendConversationItems
+type conversationItemsOptions struct {
+	previousItemID string
+	waitForAck     bool
+	ackTimeout     time.Duration
+}
+
+// WithPreviousItemID inserts the first item after the given item instead of appending it
+func WithPreviousItemID(previousItemID string) ConversationItemsOption {
+	return func(o *conversationItemsOptions) {
+		o.previousItemID = previousItemID
+	}
+}
+
+// WithWaitForAck waits for the conversation.item.created event of each item before
+// sending the next one. A timeout of zero waits until the context is done.
+func WithWaitForAck(timeout time.Duration) ConversationItemsOption {
+	return func(o *conversationItemsOptions) {
+		o.waitForAck = true
+		o.ackTimeout = timeout
+	}
+}
+
+// SendConversationItems sends items in order, inserting each one after the item before it
+// so the server-side conversation keeps the given order, for example when importing history.
+// Items without an ID are assigned one. It returns the IDs of the items that were sent.
+// Without WithPreviousItemID the first item is appended, or chained after the previous
+// item if SetItemOrdering is enabled.
+//
+// When waiting for acks, a Handler or ReadMessage loop must be running so that the
+// conversation.item.created events are received. An error event for an item stops the batch.
+func (c *Client) SendConversationItems(ctx context.Context, items []types.MessageItem, opts ...ConversationItemsOption) ([]string, error) {
+	var options conversationItemsOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	ids := make([]string, 0, len(items))
+	previousItemID := options.previousItemID
+	tracker := c.orderingTracker()
+	if previousItemID == "" && tracker != nil {
+		c.ordering.sendMu.Lock()
+		defer c.ordering.sendMu.Unlock()
+		previousItemID = c.previousOrderedItem(tracker)
+	} else {
+		tracker = nil
+	}
+	for i, item := range items {
+		if item.ID == "" {
+			item.ID = c.newID("item_")
+		}
+		msg := outgoing.NewConversationCreateMessage(previousItemID, item)
+		msg.ID = c.newID("evt_")
+
+		if err := c.sendItem(ctx, msg, options); err != nil {
+			return ids, fmt.Errorf("conversation item %d (%s): %w", i, item.ID, err)
+		}
+		ids = append(ids, item.ID)
+		previousItemID = item.ID
+		if tracker != nil {
+			c.setOrderingTail(item.ID, msg.ID)
+		}
+	}
+	return ids, nil
+}
+
+// sendItem sends a conversation.item.create message and optionally waits for its ack
+func (c *Client) sendItem(ctx context.Context, msg outgoing.ConversationCreateMessage, options conversationItemsOptions) error {
+	if !options.waitForAck {
+		return c.SendMessage(ctx, msg)
+	}
+
+	itemID, eventID := msg.Item.ID, msg.ID
+	w := c.addWaiter(func(m incoming.RcvdMsg) bool {
+		switch m := m.(type) {
+		case *incoming.ConversationItemCreatedMessage:
+			return m.Item.ID == itemID
+		case *incoming.ErrorMessage:
+			return m.Error.EventID == eventID
+		}
+		return false
+	})
+	defer c.removeWaiter(w)
+
+	if err := c.SendMessage(ctx, msg); err != nil {
+		return err
+	}
+
+	if options.ackTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, options.ackTimeout)
+		defer cancel()
+	}
+
+	select {
+	case m := <-w.ch:
+		if errMsg, ok := m.(*incoming.ErrorMessage); ok {
+			return errMsg.AsAPIError()
+		}
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("waiting for conversation.item.created: %w", ctx.Err())
+	}
+}
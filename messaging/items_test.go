@@ -0,0 +1,173 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/messages/factory"
+	"github.com/Mliviu79/openai-realtime-go/messages/types"
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+// sentItemCreate is the part of a conversation.item.create message inspected by the tests
+type sentItemCreate struct {
+	EventID        string `json:"event_id"`
+	PreviousItemID string `json:"previous_item_id"`
+	Item           struct {
+		ID string `json:"id"`
+	} `json:"item"`
+}
+
+func historyItems() []types.MessageItem {
+	return []types.MessageItem{
+		factory.MessageItem(types.MessageRoleUser, []types.MessageContentPart{factory.InputTextContent("Hi")}),
+		factory.MessageItem(types.MessageRoleAssistant, []types.MessageContentPart{factory.TextContent("Hello!")}),
+		factory.MessageItem(types.MessageRoleUser, []types.MessageContentPart{factory.InputTextContent("How are you?")}),
+	}
+}
+
+func TestSendConversationItemsChaining(t *testing.T) {
+	conn, sent, _ := recordingConn()
+	client := NewClient(ws.NewConn(conn))
+
+	items := historyItems()
+	items[1].ID = "item_assistant"
+
+	ids, err := client.SendConversationItems(context.Background(), items, WithPreviousItemID("item_root"))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(ids) != 3 {
+		t.Fatalf("Expected 3 IDs, got %d", len(ids))
+	}
+	if ids[1] != "item_assistant" {
+		t.Errorf("Expected existing ID to be kept, got %s", ids[1])
+	}
+	for _, id := range ids {
+		if len(id) > 32 {
+			t.Errorf("Expected ID of at most 32 characters, got %s", id)
+		}
+	}
+
+	msgs := sent()
+	if len(msgs) != 3 {
+		t.Fatalf("Expected 3 messages, got %d", len(msgs))
+	}
+	previous := "item_root"
+	for i, raw := range msgs {
+		var msg sentItemCreate
+		if err := json.Unmarshal([]byte(raw), &msg); err != nil {
+			t.Fatalf("Failed to unmarshal %s: %v", raw, err)
+		}
+		if msg.Item.ID != ids[i] {
+			t.Errorf("Expected item ID %s, got %s", ids[i], msg.Item.ID)
+		}
+		if msg.PreviousItemID != previous {
+			t.Errorf("Expected previous_item_id %s, got %s", previous, msg.PreviousItemID)
+		}
+		previous = msg.Item.ID
+	}
+
+	// The caller's items are not modified
+	if items[0].ID != "" {
+		t.Errorf("Expected caller's item to be unchanged, got ID %s", items[0].ID)
+	}
+}
+
+func TestSendConversationItemsWaitForAck(t *testing.T) {
+	var client *Client
+	conn := &MockConn{
+		WriteMessageFunc: func(ctx context.Context, messageType ws.MessageType, data []byte) error {
+			var msg sentItemCreate
+			if err := json.Unmarshal(data, &msg); err != nil {
+				return err
+			}
+			ack := fmt.Sprintf(`{"type":"conversation.item.created","event_id":"evt_srv","previous_item_id":%q,"item":{"id":%q,"type":"message","role":"user"}}`,
+				msg.PreviousItemID, msg.Item.ID)
+			go client.observe(mustParse(t, ack))
+			return nil
+		},
+	}
+	client = NewClient(ws.NewConn(conn))
+
+	ids, err := client.SendConversationItems(context.Background(), historyItems(), WithWaitForAck(time.Second))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if len(ids) != 3 {
+		t.Errorf("Expected 3 IDs, got %d", len(ids))
+	}
+}
+
+func TestSendConversationItemsErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		reply   func(msg sentItemCreate) string
+		wantErr string
+	}{
+		{
+			name: "error event",
+			reply: func(msg sentItemCreate) string {
+				return fmt.Sprintf(`{"type":"error","event_id":"evt_srv","error":{"type":"invalid_request_error","message":"bad item","event_id":%q}}`, msg.EventID)
+			},
+			wantErr: "bad item",
+		},
+		{
+			name:    "ack timeout",
+			reply:   func(msg sentItemCreate) string { return "" },
+			wantErr: context.DeadlineExceeded.Error(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var client *Client
+			conn := &MockConn{
+				WriteMessageFunc: func(ctx context.Context, messageType ws.MessageType, data []byte) error {
+					var msg sentItemCreate
+					if err := json.Unmarshal(data, &msg); err != nil {
+						return err
+					}
+					if reply := tt.reply(msg); reply != "" {
+						go client.observe(mustParse(t, reply))
+					}
+					return nil
+				},
+			}
+			client = NewClient(ws.NewConn(conn))
+
+			ids, err := client.SendConversationItems(context.Background(), historyItems(), WithWaitForAck(50*time.Millisecond))
+			if err == nil {
+				t.Fatal("Expected error, got nil")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.wantErr, err)
+			}
+			if len(ids) != 0 {
+				t.Errorf("Expected no IDs, got %v", ids)
+			}
+			if len(client.waiters) != 0 {
+				t.Errorf("Expected waiters to be removed, got %d", len(client.waiters))
+			}
+		})
+	}
+}
+
+func TestSendConversationItemsSendError(t *testing.T) {
+	sendErr := errors.New("write failed")
+	client := NewClient(ws.NewConn(&MockConn{
+		WriteMessageFunc: func(ctx context.Context, messageType ws.MessageType, data []byte) error {
+			return sendErr
+		},
+	}))
+
+	_, err := client.SendConversationItems(context.Background(), historyItems())
+	if !errors.Is(err, sendErr) {
+		t.Errorf("Expected %v, got %v", sendErr, err)
+	}
+}
@@ -0,0 +1,73 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+//-----------------------------------------------------------------------------
+// Client Lifecycle
+//-----------------------------------------------------------------------------
+
+// ErrClientClosed is returned by Err, and by sends made afterwards, once Close was called
+var ErrClientClosed = errors.New("messaging client closed")
+
+// Done returns a channel that is closed when the client reaches its terminal state:
+// Close was called, or reading the connection failed with an error that ends it, as seen
+// by ReadMessage or a Handler. Err then reports the cause. A read stopped by its own
+// context being cancelled, an oversized message that was skipped, and the old connection
+// closing after a handoff do not end the client.
+//
+// Example:
+//
+//	select {
+//	case <-msgClient.Done():
+//		log.Printf("session ended: %v", msgClient.Err())
+//	case <-ctx.Done():
+//	}
+func (c *Client) Done() <-chan struct{} {
+	return c.done
+}
+
+// Err returns why the client ended: ErrClientClosed after Close, or the read error that
+// ended the connection. It returns nil until Done is closed.
+func (c *Client) Err() error {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.doneErr
+}
+
+// finish moves the client to its terminal state with err as the cause, unless it is
+// already there
+func (c *Client) finish(err error) {
+	c.doneOnce.Do(func() {
+		c.mu.Lock()
+		c.doneErr = err
+		// An ended client sends no more retries
+		clear(c.retries)
+		c.mu.Unlock()
+		close(c.done)
+	})
+}
+
+// checkOpen returns the cause of the terminal state if the client has ended
+func (c *Client) checkOpen() error {
+	select {
+	case <-c.done:
+		return c.Err()
+	default:
+		return nil
+	}
+}
+
+// readFailed ends the client if err, returned by reading conn with ctx, ends the
+// connection the client is using
+func (c *Client) readFailed(ctx context.Context, conn *ws.Conn, err error) {
+	var tooLarge *ws.FrameTooLargeError
+	if ctx.Err() != nil || errors.As(err, &tooLarge) || conn != c.connection() {
+		return
+	}
+	c.finish(err)
+}
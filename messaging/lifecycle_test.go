@@ -0,0 +1,187 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+// pipeConn is a MockConn whose reads return the frames sent on frames until it is closed
+func pipeConn() (*MockConn, chan<- string) {
+	frames := make(chan string)
+	closed := make(chan struct{})
+	var once sync.Once
+	return &MockConn{
+		ReadMessageFunc: func(ctx context.Context) (ws.MessageType, []byte, error) {
+			select {
+			case frame := <-frames:
+				return ws.MessageText, []byte(frame), nil
+			case <-closed:
+				return 0, nil, errors.New("use of closed network connection")
+			case <-ctx.Done():
+				return 0, nil, ctx.Err()
+			}
+		},
+		CloseFunc: func() error {
+			once.Do(func() { close(closed) })
+			return nil
+		},
+	}, frames
+}
+
+func TestClientConcurrentUse(t *testing.T) {
+	conn, frames := pipeConn()
+	client := NewClient(ws.NewConn(conn))
+	ctx := context.Background()
+
+	readerDone := make(chan error, 1)
+	go func() {
+		for {
+			if _, err := client.ReadMessage(ctx); err != nil {
+				readerDone <- err
+				return
+			}
+		}
+	}()
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 50; i++ {
+			select {
+			case frames <- fmt.Sprintf(`{"type":"conversation.item.created","item":{"id":"item_%d","type":"message","role":"user"}}`, i):
+			case <-client.Done():
+				return
+			}
+		}
+	}()
+	for g := 0; g < 8; g++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				for _, err := range []error{
+					client.SendText(ctx, "Hello"),
+					client.SendAudioBufferAppend(ctx, "AAAA"),
+					client.CancelCurrentResponse(ctx),
+				} {
+					if err != nil && !errors.Is(err, ErrClientClosed) {
+						t.Errorf("Unexpected send error: %v", err)
+					}
+				}
+				client.SetLogger(nil)
+				client.Session()
+			}
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		time.Sleep(5 * time.Millisecond)
+		_ = client.Close()
+		_ = client.Close()
+	}()
+	wg.Wait()
+
+	select {
+	case <-client.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Expected Done to be closed after Close")
+	}
+	if !errors.Is(client.Err(), ErrClientClosed) {
+		t.Errorf("Expected ErrClientClosed, got %v", client.Err())
+	}
+	if err := client.SendText(ctx, "Too late"); !errors.Is(err, ErrClientClosed) {
+		t.Errorf("Expected a send after Close to fail with ErrClientClosed, got %v", err)
+	}
+	select {
+	case <-readerDone:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the reader to exit after Close")
+	}
+}
+
+func TestClientDoneOnReadFailure(t *testing.T) {
+	failure := errors.New("connection reset by peer")
+	var reads int
+	conn := &MockConn{
+		ReadMessageFunc: func(ctx context.Context) (ws.MessageType, []byte, error) {
+			reads++
+			switch reads {
+			case 1:
+				return 0, nil, &ws.FrameTooLargeError{EventType: "response.done", Size: 10, Limit: 1}
+			case 2:
+				<-ctx.Done()
+				return 0, nil, ctx.Err()
+			default:
+				return 0, nil, failure
+			}
+		},
+	}
+	client := NewClient(ws.NewConn(conn))
+
+	// Skipped messages and cancelled reads do not end the client
+	if _, err := client.ReadMessage(context.Background()); err == nil {
+		t.Fatal("Expected the oversized message to be reported")
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := client.ReadMessage(ctx); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected the read to be cancelled, got %v", err)
+	}
+	select {
+	case <-client.Done():
+		t.Fatalf("Expected the client to be running, got %v", client.Err())
+	default:
+	}
+	if client.Err() != nil {
+		t.Errorf("Expected no error before Done, got %v", client.Err())
+	}
+
+	if _, err := client.ReadMessage(context.Background()); !errors.Is(err, failure) {
+		t.Fatalf("Expected the read failure, got %v", err)
+	}
+	select {
+	case <-client.Done():
+	default:
+		t.Fatal("Expected Done to be closed after the connection failed")
+	}
+	if !errors.Is(client.Err(), failure) {
+		t.Errorf("Expected the read failure as the cause, got %v", client.Err())
+	}
+}
+
+func TestClientDoneOnHandlerFailure(t *testing.T) {
+	conn, _ := pipeConn()
+	client := NewClient(ws.NewConn(conn))
+
+	// Stopping a handler does not end the client
+	handler := NewHandler(context.Background(), client)
+	handler.Start()
+	handler.Stop()
+	<-handler.Err()
+	select {
+	case <-client.Done():
+		t.Fatalf("Expected the client to be running, got %v", client.Err())
+	default:
+	}
+
+	handler = NewHandler(context.Background(), client)
+	handler.Start()
+	_ = conn.Close()
+	<-handler.Err()
+	select {
+	case <-client.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Expected Done to be closed after the handler's connection failed")
+	}
+	if client.Err() == nil || errors.Is(client.Err(), ErrClientClosed) {
+		t.Errorf("Expected the read failure as the cause, got %v", client.Err())
+	}
+}
@@ -0,0 +1,69 @@
+package messaging
+
+import (
+	"context"
+
+	"github.com/Mliviu79/openai-realtime-go/session"
+)
+
+//-----------------------------------------------------------------------------
+// Locale
+//-----------------------------------------------------------------------------
+
+// SetLocale records the user's locale, returned by Locale for formatting text shown to
+// the user, and sets the transcription language of the session from it with a session
+// update. No update is sent if the session does not transcribe input audio or already
+// transcribes that language. An invalid locale returns an *apierrs.APIError and is not
+// recorded.
+//
+// SetLocale only updates conversation sessions; use SetTranscriptionLocale for a
+// transcription session.
+//
+// Example:
+//
+//	err := msgClient.SetLocale(ctx, "pt-BR")
+func (c *Client) SetLocale(ctx context.Context, locale session.Locale) error {
+	locale, err := session.ParseLocale(string(locale))
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.locale = locale
+	c.mu.Unlock()
+
+	return c.UpdateSession(ctx, session.WithLocale(locale))
+}
+
+// SetTranscriptionLocale is SetLocale for transcription sessions. Transcription sessions
+// are not tracked, so the language is set on a copy of transcription, the session's
+// current transcription configuration, and a transcription session update is always sent.
+//
+// Example:
+//
+//	err := msgClient.SetTranscriptionLocale(ctx, "pt-BR", session.InputAudioTranscription{
+//		Model: session.TranscriptionModelGPT4oTranscribe,
+//	})
+func (c *Client) SetTranscriptionLocale(ctx context.Context, locale session.Locale, transcription session.InputAudioTranscription) error {
+	locale, err := session.ParseLocale(string(locale))
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	c.locale = locale
+	c.mu.Unlock()
+
+	locale.Apply(&transcription)
+	return c.SendTranscriptionSessionUpdate(ctx, session.TranscriptionSessionRequest{
+		InputAudioTranscription: &transcription,
+	})
+}
+
+// Locale returns the locale set with SetLocale. The second return value is false if no
+// locale has been set.
+func (c *Client) Locale() (session.Locale, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.locale, c.locale != ""
+}
@@ -0,0 +1,84 @@
+package messaging
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/Mliviu79/openai-realtime-go/apierrs"
+	"github.com/Mliviu79/openai-realtime-go/session"
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+func TestSetLocale(t *testing.T) {
+	conn, sent, _ := recordingConn()
+	client := NewClient(ws.NewConn(conn))
+	ctx := context.Background()
+
+	if _, ok := client.Locale(); ok {
+		t.Error("Expected no locale before SetLocale")
+	}
+
+	// Without transcription only the locale is recorded
+	if err := client.SetLocale(ctx, "EN_us"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if locale, _ := client.Locale(); locale != "en-US" {
+		t.Errorf("Expected the canonical locale en-US, got %q", locale)
+	}
+	if got := sent(); len(got) != 0 {
+		t.Fatalf("Expected no update without transcription, got %v", got)
+	}
+
+	client.observe(mustParse(t, `{"type":"session.updated","session":{"id":"sess_1","input_audio_transcription":{"model":"gpt-4o-transcribe","language":"en"}}}`))
+	if err := client.SetLocale(ctx, "en-GB"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := sent(); len(got) != 0 {
+		t.Fatalf("Expected no update for the language in use, got %v", got)
+	}
+
+	if err := client.SetLocale(ctx, "de-DE"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	got := sent()
+	if len(got) != 1 || !strings.Contains(got[0], `"input_audio_transcription":{"model":"gpt-4o-transcribe","language":"de"}`) {
+		t.Fatalf("Expected a transcription language update, got %v", got)
+	}
+
+	if err := client.SetLocale(ctx, "not a locale"); !apierrs.IsAPIError(err) {
+		t.Errorf("Expected an invalid locale to be rejected, got %v", err)
+	}
+	if locale, _ := client.Locale(); locale != "de-DE" {
+		t.Errorf("Expected the invalid locale not to be recorded, got %q", locale)
+	}
+}
+
+func TestSetTranscriptionLocale(t *testing.T) {
+	conn, sent, _ := recordingConn()
+	client := NewClient(ws.NewConn(conn))
+	ctx := context.Background()
+
+	transcription := session.InputAudioTranscription{Model: session.TranscriptionModelGPT4oTranscribe}
+	if err := client.SetTranscriptionLocale(ctx, "pt-BR", transcription); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	got := sent()
+	if len(got) != 1 || !strings.Contains(got[0], `"type":"transcription_session.update"`) ||
+		!strings.Contains(got[0], `"input_audio_transcription":{"model":"gpt-4o-transcribe","language":"pt"}`) {
+		t.Fatalf("Expected a transcription session update, got %v", got)
+	}
+	if locale, _ := client.Locale(); locale != "pt-BR" {
+		t.Errorf("Expected the locale to be recorded, got %q", locale)
+	}
+	if transcription.Language != "" {
+		t.Error("Expected the caller's transcription configuration not to be changed")
+	}
+
+	if err := client.SetTranscriptionLocale(ctx, "not a locale", transcription); !apierrs.IsAPIError(err) {
+		t.Errorf("Expected an invalid locale to be rejected, got %v", err)
+	}
+	if got := sent(); len(got) != 1 {
+		t.Errorf("Expected nothing more to be sent for an invalid locale, got %v", got)
+	}
+}
@@ -0,0 +1,320 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+	"github.com/Mliviu79/openai-realtime-go/messages/types"
+	"github.com/Mliviu79/openai-realtime-go/session"
+)
+
+//-----------------------------------------------------------------------------
+// Summarizing Memory
+//-----------------------------------------------------------------------------
+
+const (
+	// DefaultMemoryMaxItems is the number of conversation items that triggers summarization
+	DefaultMemoryMaxItems = 40
+
+	// DefaultMemoryKeepRecent is the number of most recent items kept verbatim
+	DefaultMemoryKeepRecent = 10
+
+	// DefaultSummaryInstructions are the instructions for the out-of-band summary response
+	DefaultSummaryInstructions = "Summarize the conversation transcript you are given in a few sentences. " +
+		"Keep names, facts, decisions and open questions needed to continue the conversation."
+
+	// SummaryPrefix starts the text of the system item that holds the summary
+	SummaryPrefix = "Summary of the earlier conversation: "
+)
+
+// memoryMetadataKey tags the summary response so the memory can recognize it
+const memoryMetadataKey = "memory"
+
+// ErrSummaryInProgress is returned by Summarize when a summary is already being generated
+var ErrSummaryInProgress = errors.New("summary already in progress")
+
+// MemoryOption configures a SummarizingMemory
+type MemoryOption func(*SummarizingMemory)
+
+// WithMemoryThreshold summarizes once the conversation holds more than maxItems items,
+// keeping the keepRecent most recent items verbatim. Both must be positive and keepRecent
+// must be less than maxItems; otherwise the option is ignored.
+func WithMemoryThreshold(maxItems, keepRecent int) MemoryOption {
+	return func(m *SummarizingMemory) {
+		if keepRecent > 0 && keepRecent < maxItems {
+			m.maxItems = maxItems
+			m.keepRecent = keepRecent
+		}
+	}
+}
+
+// WithSummaryInstructions sets the instructions for the summary response
+func WithSummaryInstructions(instructions string) MemoryOption {
+	return func(m *SummarizingMemory) {
+		m.instructions = instructions
+	}
+}
+
+// WithOnSummarized sets a function that is called after a summary has been inserted,
+// with the summary text and the IDs of the items that were deleted
+func WithOnSummarized(onSummarized func(summary string, removed []string)) MemoryOption {
+	return func(m *SummarizingMemory) {
+		m.onSummarized = onSummarized
+	}
+}
+
+// memoryItem is a conversation item tracked by SummarizingMemory
+type memoryItem struct {
+	id   string
+	role types.MessageRole
+	text string
+}
+
+// SummarizingMemory keeps long conversations within the model's context. When the
+// conversation grows past a threshold, it asks the model for an out-of-band summary of
+// the older turns, inserts the summary as a system item and deletes the summarized items.
+// Earlier summaries are folded into later ones, so continuity is preserved.
+//
+// The memory learns the conversation from the server events passed to Handle, including
+// transcripts of audio turns, so Handle must be registered with the Handler.
+//
+// Example:
+//
+//	memory := messaging.NewSummarizingMemory(msgClient, messaging.WithMemoryThreshold(40, 10))
+//	handler := messaging.NewHandler(ctx, msgClient, memory.Handle)
+type SummarizingMemory struct {
+	mu           sync.Mutex
+	client       *Client
+	maxItems     int
+	keepRecent   int
+	instructions string
+	onSummarized func(summary string, removed []string)
+
+	items      []memoryItem
+	pending    []string
+	summarized string
+}
+
+// NewSummarizingMemory creates a SummarizingMemory for the client's conversation
+func NewSummarizingMemory(client *Client, opts ...MemoryOption) *SummarizingMemory {
+	m := &SummarizingMemory{
+		client:       client,
+		maxItems:     DefaultMemoryMaxItems,
+		keepRecent:   DefaultMemoryKeepRecent,
+		instructions: DefaultSummaryInstructions,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// Handle tracks conversation items and their text, including transcripts of audio turns,
+// and starts a summary after a response completes if the conversation is over the
+// threshold. It also applies the summary when the summary response is done.
+func (m *SummarizingMemory) Handle(ctx context.Context, msg incoming.RcvdMsg) {
+	switch e := msg.(type) {
+	case *incoming.ConversationItemCreatedMessage:
+		m.mu.Lock()
+		m.insert(e.PreviousItemID, memoryItem{id: e.Item.ID, role: e.Item.Role, text: contentText(e.Item.Content)})
+		m.mu.Unlock()
+	case *incoming.ConversationItemDeletedMessage:
+		m.mu.Lock()
+		m.remove(e.ItemID)
+		m.mu.Unlock()
+	case *incoming.ConversationItemTranscriptionCompletedMessage:
+		m.appendText(e.ItemID, e.Transcript)
+	case *incoming.ResponseOutputAudioTranscriptDoneMessage:
+		m.appendText(e.ItemID, e.Transcript)
+	case *incoming.ResponseOutputTextDoneMessage:
+		m.appendText(e.ItemID, e.Text)
+	case *incoming.ResponseDoneMessage:
+		if e.Response.Metadata[memoryMetadataKey] == "summary" {
+			m.finish(ctx, e.Response)
+			return
+		}
+		m.mu.Lock()
+		over := m.pending == nil && len(m.items) > m.maxItems
+		m.mu.Unlock()
+		if over {
+			if err := m.Summarize(ctx); err != nil && !errors.Is(err, ErrSummaryInProgress) {
+				if log := m.client.getLogger(); log != nil {
+					log.Warnf("Failed to request conversation summary: %v", err)
+				}
+			}
+		}
+	}
+}
+
+// Summarize requests a summary of all but the most recent items, regardless of the threshold.
+// The summary is applied when its response completes.
+func (m *SummarizingMemory) Summarize(ctx context.Context) error {
+	m.mu.Lock()
+	if m.pending != nil {
+		m.mu.Unlock()
+		return ErrSummaryInProgress
+	}
+	cut := len(m.items) - m.keepRecent
+	if cut <= 0 {
+		m.mu.Unlock()
+		return nil
+	}
+	older := m.items[:cut]
+	m.pending = make([]string, len(older))
+	var transcript strings.Builder
+	for i, item := range older {
+		m.pending[i] = item.id
+		if item.text != "" {
+			fmt.Fprintf(&transcript, "%s: %s\n", item.role, item.text)
+		}
+	}
+	m.mu.Unlock()
+
+	none := "none"
+	instructions := m.instructions
+	role := types.MessageRoleUser
+	err := m.client.SendResponseCreate(ctx, &types.ResponseConfig{
+		Modalities: []session.Modality{session.ModalityText},
+		ResponseParams: session.ResponseParams{
+			Instructions: &instructions,
+		},
+		Conversation: &none,
+		Metadata:     map[string]string{memoryMetadataKey: "summary"},
+		Input: []types.ConversationItem{{
+			Type: types.MessageItemTypeMessage,
+			Role: &role,
+			Content: []types.MessageContentPart{{
+				Type: types.MessageContentTypeInputText,
+				Text: transcript.String(),
+			}},
+		}},
+	})
+	if err != nil {
+		m.mu.Lock()
+		m.pending = nil
+		m.mu.Unlock()
+	}
+	return err
+}
+
+// Summary returns the most recent summary, or an empty string if none has been made
+func (m *SummarizingMemory) Summary() string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.summarized
+}
+
+// Len returns the number of conversation items currently tracked
+func (m *SummarizingMemory) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.items)
+}
+
+// finish inserts the summary and deletes the summarized items
+func (m *SummarizingMemory) finish(ctx context.Context, response types.Response) {
+	m.mu.Lock()
+	pending := m.pending
+	m.pending = nil
+	m.mu.Unlock()
+
+	summary := ""
+	for _, item := range response.Output {
+		summary += contentText(item.Content)
+	}
+	if pending == nil || response.Status != types.ResponseStatusCompleted || summary == "" {
+		if log := m.client.getLogger(); log != nil {
+			log.Warnf("Conversation summary did not complete (status %s), keeping items", response.Status)
+		}
+		return
+	}
+
+	// Sending and deleting must not block the reader, which delivers the acknowledgements
+	go m.apply(context.WithoutCancel(ctx), summary, pending)
+}
+
+// apply inserts the summary after the last summarized item and deletes the summarized items
+func (m *SummarizingMemory) apply(ctx context.Context, summary string, pending []string) {
+	defer m.client.recoverPanic("conversation summary")
+
+	ctx, cancel := context.WithTimeout(ctx, DefaultCancelTimeout)
+	defer cancel()
+
+	last := pending[len(pending)-1]
+	item := &types.MessageItem{
+		Type: types.MessageItemTypeMessage,
+		Role: types.MessageRoleSystem,
+		Content: []types.MessageContentPart{{
+			Type: types.MessageContentTypeInputText,
+			Text: SummaryPrefix + summary,
+		}},
+	}
+	if err := m.client.SendConversationItemCreate(ctx, item, &last); err != nil {
+		if log := m.client.getLogger(); log != nil {
+			log.Warnf("Failed to insert conversation summary: %v", err)
+		}
+		return
+	}
+	for _, id := range pending {
+		if err := m.client.SendConversationItemDelete(ctx, id); err != nil {
+			if log := m.client.getLogger(); log != nil {
+				log.Warnf("Failed to delete summarized item %s: %v", id, err)
+			}
+		}
+	}
+
+	m.mu.Lock()
+	m.summarized = summary
+	m.mu.Unlock()
+	if m.onSummarized != nil {
+		m.onSummarized(summary, pending)
+	}
+}
+
+// insert adds item after the item with previousID, or at the end if it is not tracked.
+// The caller must hold m.mu.
+func (m *SummarizingMemory) insert(previousID string, item memoryItem) {
+	for i, existing := range m.items {
+		if existing.id == previousID {
+			m.items = append(m.items[:i+1], append([]memoryItem{item}, m.items[i+1:]...)...)
+			return
+		}
+	}
+	m.items = append(m.items, item)
+}
+
+// remove deletes the item with the given ID. The caller must hold m.mu.
+func (m *SummarizingMemory) remove(id string) {
+	for i, item := range m.items {
+		if item.id == id {
+			m.items = append(m.items[:i], m.items[i+1:]...)
+			return
+		}
+	}
+}
+
+// appendText adds text, such as a transcript, to the tracked item with the given ID
+func (m *SummarizingMemory) appendText(id, text string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := range m.items {
+		if m.items[i].id == id {
+			m.items[i].text += text
+			return
+		}
+	}
+}
+
+// contentText concatenates the text and transcripts of content parts
+func contentText(parts []types.MessageContentPart) string {
+	var text strings.Builder
+	for _, part := range parts {
+		text.WriteString(part.Text)
+		text.WriteString(part.Transcript)
+	}
+	return text.String()
+}
@@ -0,0 +1,138 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+func TestSummarizingMemory(t *testing.T) {
+	conn, sent, _ := recordingConn()
+	client := NewClient(ws.NewConn(conn))
+
+	summarized := make(chan []string, 1)
+	memory := NewSummarizingMemory(client,
+		WithMemoryThreshold(3, 1),
+		WithOnSummarized(func(summary string, removed []string) { summarized <- removed }),
+	)
+
+	ctx := context.Background()
+	for _, msg := range []string{
+		`{"type":"conversation.item.created","item":{"id":"item_1","type":"message","role":"user","content":[{"type":"input_text","text":"Hi, I'm Ana."}]}}`,
+		`{"type":"conversation.item.created","previous_item_id":"item_1","item":{"id":"item_2","type":"message","role":"assistant","content":[]}}`,
+		`{"type":"response.output_audio_transcript.done","response_id":"resp_1","item_id":"item_2","transcript":"Hello Ana!"}`,
+		`{"type":"response.done","response":{"id":"resp_1","status":"completed"}}`,
+		`{"type":"conversation.item.created","previous_item_id":"item_2","item":{"id":"item_3","type":"message","role":"user","content":[{"type":"input_audio"}]}}`,
+		`{"type":"conversation.item.input_audio_transcription.completed","item_id":"item_3","content_index":0,"transcript":"Book a table for two."}`,
+		`{"type":"conversation.item.created","previous_item_id":"item_3","item":{"id":"item_4","type":"message","role":"assistant","content":[{"type":"text","text":"Done."}]}}`,
+	} {
+		memory.Handle(ctx, mustParse(t, msg))
+	}
+	if len(sent()) != 0 {
+		t.Fatalf("Expected no summary below the threshold, got %v", sent())
+	}
+
+	memory.Handle(ctx, mustParse(t, `{"type":"response.done","response":{"id":"resp_2","status":"completed"}}`))
+
+	messages := sent()
+	if len(messages) != 1 {
+		t.Fatalf("Expected a summary request, got %v", messages)
+	}
+	var request struct {
+		Type     string `json:"type"`
+		Response struct {
+			Conversation string            `json:"conversation"`
+			Metadata     map[string]string `json:"metadata"`
+			Input        []struct {
+				Content []struct {
+					Text string `json:"text"`
+				} `json:"content"`
+			} `json:"input"`
+		} `json:"response"`
+	}
+	if err := json.Unmarshal([]byte(messages[0]), &request); err != nil {
+		t.Fatalf("Failed to parse summary request: %v", err)
+	}
+	if request.Type != "response.create" || request.Response.Conversation != "none" {
+		t.Errorf("Expected an out-of-band response.create, got %s", messages[0])
+	}
+	transcript := request.Response.Input[0].Content[0].Text
+	expected := "user: Hi, I'm Ana.\nassistant: Hello Ana!\nuser: Book a table for two.\n"
+	if transcript != expected {
+		t.Errorf("Expected transcript %q, got %q", expected, transcript)
+	}
+
+	if err := memory.Summarize(ctx); err != ErrSummaryInProgress {
+		t.Errorf("Expected ErrSummaryInProgress, got %v", err)
+	}
+
+	memory.Handle(ctx, mustParse(t, `{"type":"response.done","response":{"id":"resp_sum","status":"completed","metadata":{"memory":"summary"},"output":[{"id":"item_sum","type":"message","role":"assistant","content":[{"type":"text","text":"Ana asked to book a table for two."}]}]}}`))
+
+	select {
+	case removed := <-summarized:
+		if strings.Join(removed, ",") != "item_1,item_2,item_3" {
+			t.Errorf("Expected items 1-3 to be removed, got %v", removed)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for the summary to be applied")
+	}
+
+	messages = sent()[1:]
+	if len(messages) != 4 {
+		t.Fatalf("Expected an insert and three deletes, got %v", messages)
+	}
+	if !strings.Contains(messages[0], `"conversation.item.create"`) ||
+		!strings.Contains(messages[0], `"previous_item_id":"item_3"`) ||
+		!strings.Contains(messages[0], SummaryPrefix+"Ana asked to book a table for two.") {
+		t.Errorf("Expected the summary to be inserted after item_3, got %s", messages[0])
+	}
+	for i, id := range []string{"item_1", "item_2", "item_3"} {
+		if !strings.Contains(messages[i+1], `"conversation.item.delete"`) || !strings.Contains(messages[i+1], id) {
+			t.Errorf("Expected delete of %s, got %s", id, messages[i+1])
+		}
+	}
+	if memory.Summary() != "Ana asked to book a table for two." {
+		t.Errorf("Expected summary to be stored, got %q", memory.Summary())
+	}
+}
+
+func TestSummarizingMemoryTracksDeletes(t *testing.T) {
+	conn, sent, _ := recordingConn()
+	memory := NewSummarizingMemory(NewClient(ws.NewConn(conn)), WithMemoryThreshold(2, 1))
+
+	ctx := context.Background()
+	memory.Handle(ctx, mustParse(t, `{"type":"conversation.item.created","item":{"id":"item_1","type":"message","role":"user"}}`))
+	memory.Handle(ctx, mustParse(t, `{"type":"conversation.item.created","previous_item_id":"item_1","item":{"id":"item_2","type":"message","role":"user"}}`))
+	memory.Handle(ctx, mustParse(t, `{"type":"conversation.item.created","previous_item_id":"item_2","item":{"id":"item_3","type":"message","role":"user"}}`))
+	memory.Handle(ctx, mustParse(t, `{"type":"conversation.item.deleted","item_id":"item_2"}`))
+	memory.Handle(ctx, mustParse(t, `{"type":"response.done","response":{"id":"resp_1","status":"completed"}}`))
+
+	if memory.Len() != 2 {
+		t.Errorf("Expected 2 items, got %d", memory.Len())
+	}
+	if len(sent()) != 0 {
+		t.Errorf("Expected no summary at the threshold, got %v", sent())
+	}
+}
+
+func TestWithMemoryThresholdIgnoresInvalidValues(t *testing.T) {
+	conn, _, _ := recordingConn()
+	client := NewClient(ws.NewConn(conn))
+	for _, threshold := range [][2]int{{0, 0}, {-1, 1}, {10, -1}, {10, 0}, {5, 5}, {5, 8}} {
+		memory := NewSummarizingMemory(client, WithMemoryThreshold(threshold[0], threshold[1]))
+		if memory.maxItems != DefaultMemoryMaxItems || memory.keepRecent != DefaultMemoryKeepRecent {
+			t.Errorf("Expected threshold %v to be ignored, got %d and %d", threshold, memory.maxItems, memory.keepRecent)
+		}
+	}
+
+	// Summarize no longer panics on a negative keepRecent
+	memory := NewSummarizingMemory(client, WithMemoryThreshold(10, -1))
+	memory.Handle(context.Background(), mustParse(t, `{"type":"conversation.item.created","item":{"id":"item_1","type":"message","role":"user"}}`))
+	if err := memory.Summarize(context.Background()); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,173 @@
+package messaging
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unicode"
+
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+)
+
+//-----------------------------------------------------------------------------
+// Text Normalization
+//-----------------------------------------------------------------------------
+
+// TextNormalizerOption configures a TextNormalizer
+type TextNormalizerOption func(*TextNormalizer)
+
+// WithNormalizationForm sets the Unicode normalization form; the default is norm.NFC
+func WithNormalizationForm(form norm.Form) TextNormalizerOption {
+	return func(n *TextNormalizer) {
+		n.form = form
+	}
+}
+
+// textStream is the text held back from the deltas of one content part
+type textStream struct {
+	responseID string
+	tail       string
+
+	// flush creates a delta of the same type as the last one carrying the given text
+	flush func(text string) incoming.RcvdMsg
+}
+
+// TextNormalizer normalizes and sanitizes the text of received text, audio transcript
+// and input transcription deltas and their final events. Invalid UTF-8 and control
+// characters other than newlines and tabs are removed, and the text is normalized to
+// NFC, so text compares and renders the same however the server composed it.
+//
+// A delta can end in the middle of a sequence that a combining character in the next
+// delta completes. Normalizing each delta on its own would then deliver the base
+// character and the combining character separately, so the normalizer holds back the
+// end of each delta until it knows the sequence is complete. What is held back is
+// delivered in an extra delta just before the event that ends the text, so the deltas
+// still add up to the final text.
+//
+// Register it with Client.SetTextNormalizer.
+type TextNormalizer struct {
+	form norm.Form
+
+	mu      sync.Mutex
+	streams map[string]*textStream
+}
+
+// NewTextNormalizer creates a TextNormalizer
+func NewTextNormalizer(opts ...TextNormalizerOption) *TextNormalizer {
+	n := &TextNormalizer{
+		form:    norm.NFC,
+		streams: make(map[string]*textStream),
+	}
+	for _, opt := range opts {
+		opt(n)
+	}
+	return n
+}
+
+// Normalize normalizes the text of msg in place and returns the messages to deliver in
+// its place: none when all of a delta is held back, and a flushed delta followed by msg
+// when msg ends a text that has text held back. Other messages are returned unchanged.
+func (n *TextNormalizer) Normalize(msg incoming.RcvdMsg) []incoming.RcvdMsg {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	switch m := msg.(type) {
+	case *incoming.ResponseOutputTextDeltaMessage:
+		return n.delta(streamKey("text", m.ItemID, m.ContentIndex), m.ResponseID, &m.Delta, msg, func(text string) incoming.RcvdMsg {
+			flushed := *m
+			flushed.Delta = text
+			return &flushed
+		})
+	case *incoming.ResponseOutputAudioTranscriptDeltaMessage:
+		return n.delta(streamKey("audio", m.ItemID, m.ContentIndex), m.ResponseID, &m.Delta, msg, func(text string) incoming.RcvdMsg {
+			flushed := *m
+			flushed.Delta = text
+			return &flushed
+		})
+	case *incoming.ConversationItemTranscriptionDeltaMessage:
+		return n.delta(streamKey("input", m.ItemID, m.ContentIndex), "", &m.Delta, msg, func(text string) incoming.RcvdMsg {
+			flushed := *m
+			flushed.Delta = text
+			return &flushed
+		})
+	case *incoming.ResponseOutputTextDoneMessage:
+		m.Text = n.clean(m.Text)
+		return n.done(msg, streamKey("text", m.ItemID, m.ContentIndex))
+	case *incoming.ResponseOutputAudioTranscriptDoneMessage:
+		m.Transcript = n.clean(m.Transcript)
+		return n.done(msg, streamKey("audio", m.ItemID, m.ContentIndex))
+	case *incoming.ConversationItemTranscriptionCompletedMessage:
+		m.Transcript = n.clean(m.Transcript)
+		return n.done(msg, streamKey("input", m.ItemID, m.ContentIndex))
+	case *incoming.ResponseDoneMessage:
+		// Flush the texts of the response that did not end, e.g. when it failed
+		var keys []string
+		for key, s := range n.streams {
+			if s.responseID != "" && s.responseID == m.Response.ID {
+				keys = append(keys, key)
+			}
+		}
+		return n.done(msg, keys...)
+	}
+	return []incoming.RcvdMsg{msg}
+}
+
+// delta normalizes the complete part of a delta, holding back the end that a later
+// delta could still change. The caller must hold n.mu.
+func (n *TextNormalizer) delta(key, responseID string, delta *string, msg incoming.RcvdMsg, flush func(string) incoming.RcvdMsg) []incoming.RcvdMsg {
+	s, ok := n.streams[key]
+	if !ok {
+		s = &textStream{responseID: responseID}
+		n.streams[key] = s
+	}
+	s.flush = flush
+
+	text := s.tail + sanitize(*delta)
+	i := n.form.LastBoundary([]byte(text))
+	if i <= 0 {
+		s.tail = text
+		return nil
+	}
+	*delta = n.form.String(text[:i])
+	s.tail = text[i:]
+	return []incoming.RcvdMsg{msg}
+}
+
+// done returns msg preceded by the held-back text of the given streams, and forgets
+// them. The caller must hold n.mu.
+func (n *TextNormalizer) done(msg incoming.RcvdMsg, keys ...string) []incoming.RcvdMsg {
+	var out []incoming.RcvdMsg
+	for _, key := range keys {
+		s, ok := n.streams[key]
+		if !ok {
+			continue
+		}
+		if s.tail != "" {
+			out = append(out, s.flush(n.form.String(s.tail)))
+		}
+		delete(n.streams, key)
+	}
+	return append(out, msg)
+}
+
+// clean sanitizes and normalizes a complete text
+func (n *TextNormalizer) clean(text string) string {
+	return n.form.String(sanitize(text))
+}
+
+// streamKey identifies the text of a content part
+func streamKey(kind, itemID string, contentIndex int) string {
+	return fmt.Sprintf("%s:%s:%d", kind, itemID, contentIndex)
+}
+
+// sanitize removes invalid UTF-8 and control characters other than newlines and tabs
+func sanitize(text string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) && r != '\n' && r != '\r' && r != '\t' {
+			return -1
+		}
+		return r
+	}, strings.ToValidUTF8(text, ""))
+}
@@ -0,0 +1,161 @@
+package messaging
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/unicode/norm"
+
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+// deltaText returns the delta or final text carried by a text message
+func deltaText(msg incoming.RcvdMsg) string {
+	switch m := msg.(type) {
+	case *incoming.ResponseOutputTextDeltaMessage:
+		return m.Delta
+	case *incoming.ResponseOutputAudioTranscriptDeltaMessage:
+		return m.Delta
+	case *incoming.ConversationItemTranscriptionDeltaMessage:
+		return m.Delta
+	case *incoming.ResponseOutputTextDoneMessage:
+		return m.Text
+	case *incoming.ResponseOutputAudioTranscriptDoneMessage:
+		return m.Transcript
+	case *incoming.ConversationItemTranscriptionCompletedMessage:
+		return m.Transcript
+	}
+	return ""
+}
+
+func TestTextNormalizer(t *testing.T) {
+	tests := []struct {
+		name           string
+		deltas         []string
+		done           string
+		expectedDeltas []string
+		expectedText   string
+	}{
+		{
+			name:           "combining character in the next delta",
+			deltas:         []string{`Cafe`, `\u0301 au lait`},
+			done:           `Cafe\u0301 au lait`,
+			expectedDeltas: []string{"Caf", "é au lai", "t"},
+			expectedText:   "Café au lait",
+		},
+		{
+			name:           "delta held back entirely",
+			deltas:         []string{`n`, `\u0303o`},
+			done:           `n\u0303o`,
+			expectedDeltas: []string{"ñ", "o"},
+			expectedText:   "ño",
+		},
+		{
+			name:           "emoji sequences are kept",
+			deltas:         []string{`Hi 👨‍`, `👩‍👧 👍🏽`},
+			done:           `Hi 👨‍👩‍👧 👍🏽`,
+			expectedDeltas: []string{"Hi \U0001F468‍", "\U0001F469‍\U0001F467 \U0001F44D\U0001F3FD"},
+			expectedText:   "Hi \U0001F468‍\U0001F469‍\U0001F467 \U0001F44D\U0001F3FD",
+		},
+		{
+			name:           "control characters are removed",
+			deltas:         []string{`a\u0000b\u0007`, `c\n\td`},
+			done:           `a\u0000b\u0007c\n\td`,
+			expectedDeltas: []string{"a", "bc\n\t", "d"},
+			expectedText:   "abc\n\td",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			normalizer := NewTextNormalizer()
+			var deltas []string
+			var text string
+			deliver := func(msgs []incoming.RcvdMsg) {
+				for _, msg := range msgs {
+					if msg.RcvdMsgType() == incoming.RcvdMsgTypeResponseOutputTextDone {
+						text = deltaText(msg)
+					} else {
+						deltas = append(deltas, deltaText(msg))
+					}
+				}
+			}
+
+			for _, delta := range tt.deltas {
+				deliver(normalizer.Normalize(mustParse(t, `{"type":"response.output_text.delta","response_id":"resp_1","item_id":"item_1","output_index":0,"content_index":0,"delta":"`+delta+`"}`)))
+			}
+			deliver(normalizer.Normalize(mustParse(t, `{"type":"response.output_text.done","response_id":"resp_1","item_id":"item_1","output_index":0,"content_index":0,"text":"`+tt.done+`"}`)))
+
+			if len(deltas) != len(tt.expectedDeltas) {
+				t.Fatalf("Expected deltas %q, got %q", tt.expectedDeltas, deltas)
+			}
+			for i, want := range tt.expectedDeltas {
+				if deltas[i] != want {
+					t.Errorf("Expected delta %d to be %q, got %q", i, want, deltas[i])
+				}
+			}
+			if text != tt.expectedText {
+				t.Errorf("Expected text %q, got %q", tt.expectedText, text)
+			}
+			if joined := strings.Join(deltas, ""); joined != text {
+				t.Errorf("Expected the deltas to add up to the text, got %q", joined)
+			}
+		})
+	}
+}
+
+func TestTextNormalizerFlushesOnResponseDone(t *testing.T) {
+	normalizer := NewTextNormalizer(WithNormalizationForm(norm.NFC))
+	normalizer.Normalize(mustParse(t, `{"type":"response.output_audio_transcript.delta","response_id":"resp_1","item_id":"item_1","output_index":0,"content_index":0,"delta":"Ole"}`))
+
+	msgs := normalizer.Normalize(mustParse(t, `{"type":"response.done","response":{"id":"resp_1","status":"failed"}}`))
+	if len(msgs) != 2 || deltaText(msgs[0]) != "e" || msgs[1].RcvdMsgType() != incoming.RcvdMsgTypeResponseDone {
+		t.Fatalf("Expected the held-back text before response.done, got %+v", msgs)
+	}
+	if delta, ok := msgs[0].(*incoming.ResponseOutputAudioTranscriptDeltaMessage); !ok || delta.ItemID != "item_1" {
+		t.Errorf("Expected a transcript delta for item_1, got %+v", msgs[0])
+	}
+
+	// Nothing is left to flush
+	if msgs := normalizer.Normalize(mustParse(t, `{"type":"response.done","response":{"id":"resp_1"}}`)); len(msgs) != 1 {
+		t.Errorf("Expected only response.done, got %+v", msgs)
+	}
+}
+
+func TestReadMessageNormalizesText(t *testing.T) {
+	frames := []string{
+		`{"type":"conversation.item.input_audio_transcription.delta","item_id":"item_1","content_index":0,"delta":"e"}`,
+		`{"type":"conversation.item.input_audio_transcription.delta","item_id":"item_1","content_index":0,"delta":"\u0301té"}`,
+		`{"type":"conversation.item.input_audio_transcription.completed","item_id":"item_1","content_index":0,"transcript":"e\u0301te\u0301"}`,
+	}
+	mockConn := &MockConn{
+		ReadMessageFunc: func(ctx context.Context) (ws.MessageType, []byte, error) {
+			data := frames[0]
+			frames = frames[1:]
+			return ws.MessageText, []byte(data), nil
+		},
+	}
+	client := NewClient(ws.NewConn(mockConn))
+	client.SetTextNormalizer(NewTextNormalizer())
+
+	// The first delta is held back until the second completes it
+	var got []string
+	for range 3 {
+		msg, err := client.ReadMessage(context.Background())
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		got = append(got, deltaText(msg))
+	}
+	expected := []string{"ét", "é", "été"}
+	for i, want := range expected {
+		if got[i] != want {
+			t.Errorf("Expected message %d to be %q, got %q", i, want, got[i])
+		}
+	}
+	if len(frames) != 0 {
+		t.Errorf("Expected every frame to be read, got %d left", len(frames))
+	}
+}
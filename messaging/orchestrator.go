@@ -0,0 +1,276 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/Mliviu79/openai-realtime-go/logger"
+	"github.com/Mliviu79/openai-realtime-go/messages/types"
+	"github.com/Mliviu79/openai-realtime-go/session"
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+//-----------------------------------------------------------------------------
+// Session Orchestrator
+//-----------------------------------------------------------------------------
+
+// DialFunc opens a new realtime connection, typically by calling openaiClient.Client.Connect
+type DialFunc func(ctx context.Context) (*ws.Conn, error)
+
+// SessionFunc drives a single orchestrated session. The session is closed when it returns.
+// The context is cancelled when the orchestrator's context is done or the session is cancelled.
+type SessionFunc func(ctx context.Context, s *OrchestratedSession) error
+
+// OrchestratedSession is a session run by an Orchestrator
+type OrchestratedSession struct {
+	// Index identifies the session within its Run, from 0 to n-1
+	Index int
+
+	// Client sends messages on the session. A Handler is already reading from it.
+	Client *Client
+
+	// Usage sums the token usage of this session
+	Usage *UsageAggregator
+
+	cancel context.CancelFunc
+}
+
+// Cancel cancels the session's context
+func (s *OrchestratedSession) Cancel() {
+	s.cancel()
+}
+
+// OrchestratorMetrics are aggregate metrics over all sessions run by an Orchestrator
+type OrchestratorMetrics struct {
+	// Started is the number of sessions started
+	Started int
+
+	// Active is the number of sessions currently running
+	Active int
+
+	// Succeeded is the number of sessions whose SessionFunc returned nil
+	Succeeded int
+
+	// Failed is the number of sessions that failed to connect, configure or run
+	Failed int
+
+	// Usage is the token usage summed over all sessions
+	Usage types.Usage
+
+	// Responses is the number of responses across all sessions
+	Responses int
+
+	// EstimatedCost is the estimated cost in US dollars, using the orchestrator's pricing
+	EstimatedCost float64
+}
+
+// OrchestratorOption configures an Orchestrator
+type OrchestratorOption func(*Orchestrator)
+
+// WithSharedTools offers the tools of registry to every session and answers their function calls
+func WithSharedTools(registry *ToolRegistry) OrchestratorOption {
+	return func(o *Orchestrator) {
+		o.tools = registry
+	}
+}
+
+// WithSharedPricing sets the pricing used to estimate the cost in the metrics
+func WithSharedPricing(pricing Pricing) OrchestratorOption {
+	return func(o *Orchestrator) {
+		o.pricing = pricing
+	}
+}
+
+// WithSharedLogger sets the logger used by every session's client and handler
+func WithSharedLogger(logger logger.Logger) OrchestratorOption {
+	return func(o *Orchestrator) {
+		o.logger = logger
+	}
+}
+
+// WithSessionConfig sets the configuration sent as a session.update when each session starts.
+// The shared tools are added unless the configuration sets its own.
+func WithSessionConfig(config session.SessionRequest) OrchestratorOption {
+	return func(o *Orchestrator) {
+		o.config = config
+	}
+}
+
+// Orchestrator runs many sessions concurrently, sharing a tool registry, pricing table and
+// logger between them and aggregating their metrics. It is a building block for agent farms
+// and load tests.
+//
+// Example:
+//
+//	orch := messaging.NewOrchestrator(func(ctx context.Context) (*ws.Conn, error) {
+//		return client.Connect(ctx, openaiClient.WithModel(session.GPTRealtime))
+//	}, messaging.WithSharedTools(tools))
+//	err := orch.Run(ctx, 10, func(ctx context.Context, s *messaging.OrchestratedSession) error {
+//		return s.Client.SendText(ctx, "Hello")
+//	})
+type Orchestrator struct {
+	dial    DialFunc
+	tools   *ToolRegistry
+	pricing Pricing
+	logger  logger.Logger
+	config  session.SessionRequest
+
+	usage *UsageAggregator
+
+	mu        sync.Mutex
+	sessions  map[*OrchestratedSession]struct{}
+	started   int
+	succeeded int
+	failed    int
+}
+
+// NewOrchestrator creates an Orchestrator that opens connections with dial
+func NewOrchestrator(dial DialFunc, opts ...OrchestratorOption) *Orchestrator {
+	o := &Orchestrator{
+		dial:     dial,
+		usage:    NewUsageAggregator(),
+		sessions: make(map[*OrchestratedSession]struct{}),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// Run runs n sessions concurrently, calling fn for each, and waits for all of them to finish.
+// A failing session does not stop the others. The returned error joins the errors of all
+// failed sessions, each prefixed with the session index. A session function that panics
+// fails its session with a *ws.PanicError, which errors.As finds in the returned error.
+// It returns an error if n is negative.
+func (o *Orchestrator) Run(ctx context.Context, n int, fn SessionFunc) error {
+	if n < 0 {
+		return fmt.Errorf("session count must not be negative, got %d", n)
+	}
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(index int) {
+			defer wg.Done()
+			if err := o.runSession(ctx, index, fn); err != nil {
+				errs[index] = fmt.Errorf("session %d: %w", index, err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// runSession connects, configures and runs one session, then closes it
+func (o *Orchestrator) runSession(ctx context.Context, index int, fn SessionFunc) (err error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	s := &OrchestratedSession{
+		Index:  index,
+		Usage:  NewUsageAggregator(),
+		cancel: cancel,
+	}
+	o.begin(s)
+	defer func() { o.end(s, err) }()
+
+	conn, err := o.dial(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to connect: %w", err)
+	}
+
+	s.Client = NewClient(conn)
+	defer s.Client.Close()
+
+	handlers := []MessageHandler{s.Usage.Handle, o.usage.Handle}
+	if o.tools != nil {
+		handlers = append(handlers, o.tools.Handler(s.Client))
+	}
+	handler := NewHandler(ctx, s.Client, handlers...)
+	if o.logger != nil {
+		s.Client.SetLogger(o.logger)
+		handler.SetLogger(o.logger)
+	}
+	handler.Start()
+	defer handler.Stop()
+
+	if config := o.sessionConfig(); !config.IsEmpty() {
+		if err := s.Client.SendSessionUpdate(ctx, config); err != nil {
+			return fmt.Errorf("failed to configure session: %w", err)
+		}
+	}
+
+	return runSessionFunc(ctx, s, fn)
+}
+
+// runSessionFunc calls fn, returning a panic as a *ws.PanicError so it fails only this session
+func runSessionFunc(ctx context.Context, s *OrchestratedSession, fn SessionFunc) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = ws.NewPanicError("session", r)
+		}
+	}()
+	return fn(ctx, s)
+}
+
+// sessionConfig returns the session.update sent when a session starts
+func (o *Orchestrator) sessionConfig() session.SessionRequest {
+	config := o.config.Clone()
+	if config.Tools == nil && o.tools != nil && o.tools.Len() > 0 {
+		tools := o.tools.Tools()
+		config.Tools = &tools
+	}
+	return config
+}
+
+// begin records a started session
+func (o *Orchestrator) begin(s *OrchestratedSession) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.started++
+	o.sessions[s] = struct{}{}
+}
+
+// end records a finished session
+func (o *Orchestrator) end(s *OrchestratedSession, err error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	delete(o.sessions, s)
+	if err != nil {
+		o.failed++
+	} else {
+		o.succeeded++
+	}
+}
+
+// Cancel cancels the running session with the given index, reporting whether one was found
+func (o *Orchestrator) Cancel(index int) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for s := range o.sessions {
+		if s.Index == index {
+			s.cancel()
+			return true
+		}
+	}
+	return false
+}
+
+// Metrics returns the aggregate metrics of all sessions run so far
+func (o *Orchestrator) Metrics() OrchestratorMetrics {
+	o.mu.Lock()
+	metrics := OrchestratorMetrics{
+		Started:   o.started,
+		Active:    len(o.sessions),
+		Succeeded: o.succeeded,
+		Failed:    o.failed,
+	}
+	o.mu.Unlock()
+
+	metrics.Usage = o.usage.Totals()
+	metrics.Responses = o.usage.Responses()
+	metrics.EstimatedCost = o.pricing.Cost(metrics.Usage)
+	return metrics
+}
@@ -0,0 +1,181 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/session"
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+// orchestratorConn replays a response.done with usage and then blocks until the context is done
+func orchestratorConn(sent *[]string, mu *sync.Mutex) *MockConn {
+	replied := false
+	return &MockConn{
+		ReadMessageFunc: func(ctx context.Context) (ws.MessageType, []byte, error) {
+			mu.Lock()
+			first := !replied
+			replied = true
+			mu.Unlock()
+			if first {
+				return ws.MessageText, []byte(`{"type":"response.done","response":{"id":"resp_1","status":"completed","usage":{"total_tokens":30,"input_tokens":10,"output_tokens":20,"input_token_details":{"text_tokens":10},"output_token_details":{"text_tokens":20}}}}`), nil
+			}
+			<-ctx.Done()
+			return ws.MessageText, nil, ctx.Err()
+		},
+		WriteMessageFunc: func(ctx context.Context, messageType ws.MessageType, data []byte) error {
+			mu.Lock()
+			defer mu.Unlock()
+			*sent = append(*sent, string(data))
+			return nil
+		},
+	}
+}
+
+func TestOrchestratorRun(t *testing.T) {
+	var mu sync.Mutex
+	var sent []string
+
+	tools := NewToolRegistry()
+	tools.Register(session.Tool{Name: "lookup"}, func(ctx context.Context, arguments string) (string, error) {
+		return "ok", nil
+	})
+
+	dialErr := errors.New("dial refused")
+	var dials int
+	orch := NewOrchestrator(func(ctx context.Context) (*ws.Conn, error) {
+		mu.Lock()
+		dials++
+		n := dials
+		mu.Unlock()
+		if n == 3 {
+			return nil, dialErr
+		}
+		return ws.NewConn(orchestratorConn(&sent, &mu)), nil
+	}, WithSharedTools(tools), WithSharedPricing(Pricing{TextInput: 1_000_000, TextOutput: 1_000_000}))
+
+	err := orch.Run(context.Background(), 3, func(ctx context.Context, s *OrchestratedSession) error {
+		// Wait for the session's response.done to be handled
+		deadline := time.Now().Add(time.Second)
+		for s.Usage.Responses() == 0 && time.Now().Before(deadline) {
+			time.Sleep(time.Millisecond)
+		}
+		if s.Usage.Totals().TotalTokens != 30 {
+			return errors.New("usage not tracked")
+		}
+		return nil
+	})
+
+	if !errors.Is(err, dialErr) {
+		t.Errorf("Expected %v, got %v", dialErr, err)
+	}
+	if err != nil && !strings.Contains(err.Error(), "session ") {
+		t.Errorf("Expected error to name the session, got %v", err)
+	}
+
+	metrics := orch.Metrics()
+	if metrics.Started != 3 || metrics.Active != 0 || metrics.Succeeded != 2 || metrics.Failed != 1 {
+		t.Errorf("Unexpected session counts: %+v", metrics)
+	}
+	if metrics.Usage.TotalTokens != 60 || metrics.Responses != 2 {
+		t.Errorf("Expected 60 tokens over 2 responses, got %d over %d", metrics.Usage.TotalTokens, metrics.Responses)
+	}
+	if metrics.EstimatedCost != 60 {
+		t.Errorf("Expected estimated cost 60, got %v", metrics.EstimatedCost)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(sent) != 2 {
+		t.Fatalf("Expected one session.update per connected session, got %v", sent)
+	}
+	for _, msg := range sent {
+		if !strings.Contains(msg, `"type":"session.update"`) || !strings.Contains(msg, `"name":"lookup"`) {
+			t.Errorf("Expected session.update with shared tools, got %s", msg)
+		}
+	}
+}
+
+func TestOrchestratorCancel(t *testing.T) {
+	var mu sync.Mutex
+	var sent []string
+	orch := NewOrchestrator(func(ctx context.Context) (*ws.Conn, error) {
+		return ws.NewConn(orchestratorConn(&sent, &mu)), nil
+	})
+
+	started := make(chan struct{}, 2)
+	done := make(chan error, 1)
+	go func() {
+		done <- orch.Run(context.Background(), 2, func(ctx context.Context, s *OrchestratedSession) error {
+			started <- struct{}{}
+			if s.Index == 0 {
+				<-ctx.Done()
+				return ctx.Err()
+			}
+			return nil
+		})
+	}()
+
+	<-started
+	<-started
+	deadline := time.Now().Add(time.Second)
+	for !orch.Cancel(0) {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected session 0 to be running")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected %v, got %v", context.Canceled, err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for Run")
+	}
+
+	if orch.Cancel(0) {
+		t.Error("Expected no running session after Run returned")
+	}
+	metrics := orch.Metrics()
+	if metrics.Succeeded != 1 || metrics.Failed != 1 {
+		t.Errorf("Unexpected session counts: %+v", metrics)
+	}
+	if len(sent) != 0 {
+		t.Errorf("Expected no session.update without configuration, got %v", sent)
+	}
+}
+
+func TestOrchestratorSessionPanic(t *testing.T) {
+	var mu sync.Mutex
+	var sent []string
+	orch := NewOrchestrator(func(ctx context.Context) (*ws.Conn, error) {
+		return ws.NewConn(orchestratorConn(&sent, &mu)), nil
+	})
+
+	err := orch.Run(context.Background(), 2, func(ctx context.Context, s *OrchestratedSession) error {
+		if s.Index == 1 {
+			panic("boom")
+		}
+		return nil
+	})
+	var panicErr *ws.PanicError
+	if !errors.As(err, &panicErr) || !strings.Contains(err.Error(), "session 1: panic in session: boom") {
+		t.Fatalf("Expected the panic to fail session 1, got %v", err)
+	}
+	if panicErr.Value != "boom" || len(panicErr.Stack) == 0 {
+		t.Errorf("Expected the panic value and stack, got %+v", panicErr)
+	}
+	if metrics := orch.Metrics(); metrics.Succeeded != 1 || metrics.Failed != 1 || metrics.Active != 0 {
+		t.Errorf("Unexpected session counts: %+v", metrics)
+	}
+
+	if err := orch.Run(context.Background(), -1, nil); err == nil {
+		t.Error("Expected a negative session count to be rejected")
+	}
+}
@@ -0,0 +1,43 @@
+package messaging
+
+import (
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+	"github.com/Mliviu79/openai-realtime-go/session"
+)
+
+//-----------------------------------------------------------------------------
+// Output Audio Format
+//-----------------------------------------------------------------------------
+
+// outputAudioFormat follows the session's output audio format, so the recorders that
+// count output audio bytes can convert them to durations. The zero value assumes pcm16,
+// the server's default. It is not safe for concurrent use; callers guard it with their
+// own lock.
+type outputAudioFormat struct {
+	format session.AudioFormat
+}
+
+// observe records the output audio format reported by a session.created or
+// session.updated event. Other events, and events without a valid format, are ignored.
+func (f *outputAudioFormat) observe(msg incoming.RcvdMsg) {
+	var format *session.AudioFormat
+	switch m := msg.(type) {
+	case *incoming.SessionCreatedMessage:
+		format = m.Session.OutputAudioFormat
+	case *incoming.SessionUpdatedMessage:
+		format = m.Session.OutputAudioFormat
+	}
+	if format != nil && format.IsValid() {
+		f.format = *format
+	}
+}
+
+// duration returns how long n bytes of output audio play for
+func (f *outputAudioFormat) duration(n int) time.Duration {
+	if f.format == "" {
+		return session.AudioFormatPCM16.DurationForBytes(n)
+	}
+	return f.format.DurationForBytes(n)
+}
@@ -0,0 +1,30 @@
+package messaging
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOutputAudioFormat(t *testing.T) {
+	var format outputAudioFormat
+	if d := format.duration(48000); d != time.Second {
+		t.Errorf("Expected pcm16 by default, got %v for 48000 bytes", d)
+	}
+
+	format.observe(mustParse(t, `{"type":"session.created","session":{"id":"sess_1","output_audio_format":"g711_ulaw"}}`))
+	if d := format.duration(8000); d != time.Second {
+		t.Errorf("Expected g711_ulaw after session.created, got %v for 8000 bytes", d)
+	}
+
+	// Unknown formats and other events leave the format unchanged
+	format.observe(mustParse(t, `{"type":"session.updated","session":{"id":"sess_1","output_audio_format":"opus"}}`))
+	format.observe(mustParse(t, `{"type":"response.created","response":{"id":"resp_1"}}`))
+	if d := format.duration(8000); d != time.Second {
+		t.Errorf("Expected g711_ulaw to be kept, got %v for 8000 bytes", d)
+	}
+
+	format.observe(mustParse(t, `{"type":"session.updated","session":{"id":"sess_1","output_audio_format":"pcm16"}}`))
+	if d := format.duration(48000); d != time.Second {
+		t.Errorf("Expected pcm16 after session.updated, got %v for 48000 bytes", d)
+	}
+}
@@ -0,0 +1,93 @@
+package messaging
+
+import (
+	"sync"
+
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+//-----------------------------------------------------------------------------
+// Panic Recovery
+//-----------------------------------------------------------------------------
+
+// SetPanicHandler sets the function that is called with panics recovered from callbacks
+// run on background goroutines: the MessageHandlers called by a Handler, and the
+// callbacks run by the connection's read loop and keep-alive (see ws.Conn.SetPanicHandler),
+// and the goroutines and timers started by the client and the helpers bound to it, such
+// as tool calls, response retries, summaries, watchdogs and budget guards.
+// A panicking handler is skipped for that message and the next handlers and messages
+// are still processed. If nil, panics are only logged.
+//
+// Example:
+//
+//	msgClient.SetPanicHandler(func(err *ws.PanicError) {
+//		log.Printf("%v\n%s", err, err.Stack)
+//	})
+func (c *Client) SetPanicHandler(onPanic ws.PanicHandler) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.onPanic = onPanic
+	c.conn.SetPanicHandler(onPanic)
+}
+
+// SetRethrowPanics makes recovered panics panic again once they have been reported,
+// crashing the process as if they had not been recovered, to fail fast in tests and
+// development. It applies to the same callbacks as SetPanicHandler.
+func (c *Client) SetRethrowPanics(rethrow bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.rethrowPanics = rethrow
+	c.conn.SetRethrowPanics(rethrow)
+}
+
+// reportPanic passes a value returned by recover to the panic handler and panics again
+// if configured to. It must be called from the deferred function that recovered.
+func (c *Client) reportPanic(where string, value any) {
+	c.mu.RLock()
+	onPanic, rethrow := c.onPanic, c.rethrowPanics
+	c.mu.RUnlock()
+
+	if onPanic != nil {
+		onPanic(ws.NewPanicError(where, value))
+	}
+	if rethrow {
+		panic(value)
+	}
+}
+
+// recoverPanic reports a panic on a goroutine or timer started for the client, like a
+// panic in a message handler. It must be deferred directly.
+func (c *Client) recoverPanic(where string) {
+	if r := recover(); r != nil {
+		if log := c.getLogger(); log != nil {
+			log.Errorf("Panic in %s: %v", where, r)
+		}
+		c.reportPanic(where, r)
+	}
+}
+
+// panicReporter reports the panics recovered from the goroutines and timers of a helper
+// that is not bound to a Client. The zero value drops them.
+type panicReporter struct {
+	mu      sync.Mutex
+	onPanic ws.PanicHandler
+}
+
+// set sets the function that is called with recovered panics
+func (p *panicReporter) set(onPanic ws.PanicHandler) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.onPanic = onPanic
+}
+
+// recoverPanic reports a panic to the panic handler. It must be deferred directly.
+func (p *panicReporter) recoverPanic(where string) {
+	if r := recover(); r != nil {
+		p.mu.Lock()
+		onPanic := p.onPanic
+		p.mu.Unlock()
+		if onPanic != nil {
+			onPanic(ws.NewPanicError(where, r))
+		}
+	}
+}
@@ -0,0 +1,148 @@
+package messaging
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/clock"
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+	"github.com/Mliviu79/openai-realtime-go/session"
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+func TestHandlerReportsPanics(t *testing.T) {
+	conn, frames := pipeConn()
+	client := NewClient(ws.NewConn(conn))
+	panics := make(chan *ws.PanicError, 1)
+	client.SetPanicHandler(func(err *ws.PanicError) { panics <- err })
+
+	handled := make(chan incoming.RcvdMsg, 1)
+	handler := NewHandler(context.Background(), client,
+		func(ctx context.Context, msg incoming.RcvdMsg) {},
+		func(ctx context.Context, msg incoming.RcvdMsg) { panic("boom") },
+		func(ctx context.Context, msg incoming.RcvdMsg) { handled <- msg },
+	)
+	handler.Start()
+	defer handler.Stop()
+
+	frames <- `{"type":"input_audio_buffer.cleared","event_id":"evt_1"}`
+	select {
+	case err := <-panics:
+		if err.Where != "message handler 1" || err.Value != "boom" {
+			t.Errorf("Unexpected panic error %+v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the panic to be reported")
+	}
+	select {
+	case <-handled:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the next handler to be called after a panic")
+	}
+}
+
+func TestSetRethrowPanics(t *testing.T) {
+	client := NewClient(ws.NewConn(&MockConn{}))
+	client.SetRethrowPanics(true)
+
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Errorf("Expected the panic to be rethrown, got %v", r)
+		}
+	}()
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				client.reportPanic("test", r)
+			}
+		}()
+		panic("boom")
+	}()
+	t.Error("Expected the recovered panic to panic again")
+}
+
+func TestToolCallReportsPanics(t *testing.T) {
+	registry := NewToolRegistry()
+	registry.Register(session.Tool{Name: "explode"}, func(ctx context.Context, arguments string) (string, error) {
+		panic("boom")
+	})
+
+	client := NewClient(ws.NewConn(&MockConn{}))
+	panics := make(chan *ws.PanicError, 1)
+	client.SetPanicHandler(func(err *ws.PanicError) { panics <- err })
+
+	registry.Handler(client)(context.Background(), mustParse(t, `{"type":"response.done","response":{"id":"resp_1","status":"completed","output":[{"type":"function_call","name":"explode","call_id":"call_1","arguments":"{}"}]}}`))
+
+	select {
+	case err := <-panics:
+		if err.Where != "tool call" || err.Value != "boom" || len(err.Stack) == 0 {
+			t.Errorf("Unexpected panic error %+v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the tool panic to be reported")
+	}
+}
+
+func TestTimerFlushReportsPanics(t *testing.T) {
+	panics := make(chan *ws.PanicError, 2)
+	onPanic := func(err *ws.PanicError) { panics <- err }
+
+	fake := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	coalescer := NewAudioCoalescer(time.Second, func(chunk AudioChunk) { panic("audio") }, WithAudioCoalescerClock(fake))
+	coalescer.SetPanicHandler(onPanic)
+	coalescer.Handle(context.Background(), audioDelta("item_1", []byte{1}))
+
+	debouncer := NewTextDebouncer(time.Second, func(chunk TextChunk) { panic("text") }, WithTextDebouncerClock(fake))
+	debouncer.SetPanicHandler(onPanic)
+	debouncer.Handle(context.Background(), textDelta("item_1", "Hi"))
+	fake.Advance(time.Second)
+
+	reported := map[string]any{}
+	for range 2 {
+		select {
+		case err := <-panics:
+			reported[err.Where] = err.Value
+		case <-time.After(time.Second):
+			t.Fatalf("Expected both timer panics to be reported, got %v", reported)
+		}
+	}
+	if reported["audio coalescer flush"] != "audio" || reported["text debouncer flush"] != "text" {
+		t.Errorf("Unexpected panics %v", reported)
+	}
+}
+
+func TestWebhookReportsPanics(t *testing.T) {
+	server := newWebhookServer(t)
+	panics := make(chan *ws.PanicError, 1)
+	webhook := NewWebhookEmitter(server.URL,
+		WithWebhookPayload(func(event WebhookEvent) any {
+			if event.Kind == WebhookEventError {
+				panic("boom")
+			}
+			return event
+		}),
+		WithWebhookPanicHandler(func(err *ws.PanicError) { panics <- err }),
+	)
+
+	ctx := context.Background()
+	webhook.Handle(ctx, mustParse(t, `{"type":"error","error":{"type":"server_error","message":"oops"}}`))
+	webhook.Handle(ctx, mustParse(t, `{"type":"response.done","response":{"id":"resp_1","status":"completed"}}`))
+	if err := webhook.Close(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	select {
+	case err := <-panics:
+		if err.Where != "webhook delivery" || err.Value != "boom" {
+			t.Errorf("Unexpected panic error %+v", err)
+		}
+	default:
+		t.Fatal("Expected the payload panic to be reported")
+	}
+	// Delivery continues with the next event
+	if bodies, _ := server.requests(); len(bodies) != 1 {
+		t.Errorf("Expected the next event to be delivered, got %q", bodies)
+	}
+}
@@ -0,0 +1,152 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+)
+
+//-----------------------------------------------------------------------------
+// Event Publishing
+//-----------------------------------------------------------------------------
+
+// DefaultSubjectPrefix is the first token of the subjects an EventPublisher publishes to
+const DefaultSubjectPrefix = "realtime"
+
+// Publisher sends data to a subject of a message bus, such as a NATS subject or a Kafka
+// topic. contrib/nats provides a NATS and JetStream implementation; other buses need
+// only this method.
+type Publisher interface {
+	Publish(ctx context.Context, subject string, data []byte) error
+}
+
+// PublisherFunc adapts a function to the Publisher interface
+type PublisherFunc func(ctx context.Context, subject string, data []byte) error
+
+// Publish calls f
+func (f PublisherFunc) Publish(ctx context.Context, subject string, data []byte) error {
+	return f(ctx, subject, data)
+}
+
+// EventPublisherOption configures an EventPublisher
+type EventPublisherOption func(*EventPublisher)
+
+// WithPublishedEvents only publishes server events of the given types; by default every
+// event is published
+func WithPublishedEvents(msgTypes ...incoming.RcvdMsgType) EventPublisherOption {
+	return func(p *EventPublisher) {
+		p.msgTypes = msgTypes
+	}
+}
+
+// WithSubjectPrefix sets the first token of the default subjects; the default is DefaultSubjectPrefix
+func WithSubjectPrefix(prefix string) EventPublisherOption {
+	return func(p *EventPublisher) {
+		p.prefix = prefix
+	}
+}
+
+// WithPublishSubject sets the function that chooses the subject of each event, replacing
+// the default "<prefix>.<session ID>.<event type>". The session ID is empty until the
+// session.created event has been seen.
+func WithPublishSubject(subject func(sessionID string, msgType incoming.RcvdMsgType) string) EventPublisherOption {
+	return func(p *EventPublisher) {
+		p.subject = subject
+	}
+}
+
+// WithOnPublishError sets a function that is called when an event cannot be published;
+// without it publish errors are ignored. It is called from Handle and must not block.
+func WithOnPublishError(onError func(msgType incoming.RcvdMsgType, err error)) EventPublisherOption {
+	return func(p *EventPublisher) {
+		p.onError = onError
+	}
+}
+
+// EventPublisher publishes server events to a message bus as they arrive, so call events
+// can be fanned out to analytics and compliance consumers in real time. Each event is
+// published as its JSON encoding to a subject that, by default, names the session and the
+// event type, e.g. "realtime.sess_123.response.done", so NATS consumers can subscribe to
+// "realtime.*.response.done" or "realtime.sess_123.>".
+//
+// Events are published synchronously from Handle, so Publish should be fast or buffer.
+//
+// Example:
+//
+//	nc, _ := nats.Connect(nats.DefaultURL)
+//	publisher := messaging.NewEventPublisher(realtimenats.NewPublisher(nc),
+//		messaging.WithPublishedEvents(incoming.RcvdMsgTypeResponseDone, incoming.RcvdMsgTypeConversationItemInputAudioTranscriptionCompleted),
+//	)
+//	handler := messaging.NewHandler(ctx, msgClient, publisher.Handle)
+type EventPublisher struct {
+	publisher Publisher
+	msgTypes  []incoming.RcvdMsgType
+	prefix    string
+	subject   func(sessionID string, msgType incoming.RcvdMsgType) string
+	onError   func(incoming.RcvdMsgType, error)
+
+	mu        sync.Mutex
+	sessionID string
+}
+
+// NewEventPublisher creates an EventPublisher that publishes through publisher
+func NewEventPublisher(publisher Publisher, opts ...EventPublisherOption) *EventPublisher {
+	p := &EventPublisher{
+		publisher: publisher,
+		prefix:    DefaultSubjectPrefix,
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	return p
+}
+
+// Handle publishes the event to its subject if its type is selected, remembering the
+// session ID from session.created for the subjects. Failures are reported to the error
+// callback and do not stop later events.
+func (p *EventPublisher) Handle(ctx context.Context, msg incoming.RcvdMsg) {
+	msgType := msg.RcvdMsgType()
+
+	p.mu.Lock()
+	if created, ok := msg.(*incoming.SessionCreatedMessage); ok {
+		p.sessionID = created.Session.ID
+	}
+	sessionID := p.sessionID
+	p.mu.Unlock()
+
+	if len(p.msgTypes) > 0 && !slices.Contains(p.msgTypes, msgType) {
+		return
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		p.fail(msgType, fmt.Errorf("failed to marshal %s: %w", msgType, err))
+		return
+	}
+	if err := p.publisher.Publish(ctx, p.subjectFor(sessionID, msgType), data); err != nil {
+		p.fail(msgType, fmt.Errorf("failed to publish %s: %w", msgType, err))
+	}
+}
+
+// subjectFor returns the subject of an event
+func (p *EventPublisher) subjectFor(sessionID string, msgType incoming.RcvdMsgType) string {
+	if p.subject != nil {
+		return p.subject(sessionID, msgType)
+	}
+	if sessionID == "" {
+		// Subject tokens cannot be empty
+		sessionID = "unknown"
+	}
+	return p.prefix + "." + sessionID + "." + string(msgType)
+}
+
+// fail reports a publish error, if an error function is set
+func (p *EventPublisher) fail(msgType incoming.RcvdMsgType, err error) {
+	if p.onError != nil {
+		p.onError(msgType, err)
+	}
+}
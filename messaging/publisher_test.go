@@ -0,0 +1,104 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+)
+
+// published is a message recorded by a test Publisher
+type published struct {
+	subject string
+	data    []byte
+}
+
+func TestEventPublisher(t *testing.T) {
+	tests := []struct {
+		name             string
+		opts             []EventPublisherOption
+		expectedSubjects []string
+	}{
+		{
+			name:             "all events",
+			expectedSubjects: []string{"realtime.sess_1.session.created", "realtime.sess_1.response.created", "realtime.sess_1.response.done"},
+		},
+		{
+			name:             "selected events",
+			opts:             []EventPublisherOption{WithPublishedEvents(incoming.RcvdMsgTypeResponseDone), WithSubjectPrefix("calls")},
+			expectedSubjects: []string{"calls.sess_1.response.done"},
+		},
+		{
+			name: "custom subject",
+			opts: []EventPublisherOption{WithPublishSubject(func(sessionID string, msgType incoming.RcvdMsgType) string {
+				return "analytics." + string(msgType)
+			})},
+			expectedSubjects: []string{"analytics.session.created", "analytics.response.created", "analytics.response.done"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []published
+			publisher := NewEventPublisher(PublisherFunc(func(ctx context.Context, subject string, data []byte) error {
+				got = append(got, published{subject, data})
+				return nil
+			}), tt.opts...)
+
+			ctx := context.Background()
+			publisher.Handle(ctx, mustParse(t, `{"type":"session.created","session":{"id":"sess_1"}}`))
+			publisher.Handle(ctx, mustParse(t, `{"type":"response.created","response":{"id":"resp_1"}}`))
+			publisher.Handle(ctx, mustParse(t, `{"type":"response.done","response":{"id":"resp_1","status":"completed"}}`))
+
+			if len(got) != len(tt.expectedSubjects) {
+				t.Fatalf("Expected %d messages, got %d", len(tt.expectedSubjects), len(got))
+			}
+			for i, subject := range tt.expectedSubjects {
+				if got[i].subject != subject {
+					t.Errorf("Expected subject %s, got %s", subject, got[i].subject)
+				}
+			}
+
+			// The published data is the event itself
+			last := got[len(got)-1]
+			var event struct {
+				Type     string `json:"type"`
+				Response struct {
+					ID string `json:"id"`
+				} `json:"response"`
+			}
+			if err := json.Unmarshal(last.data, &event); err != nil {
+				t.Fatalf("Failed to parse %s: %v", last.data, err)
+			}
+			if event.Type != "response.done" || event.Response.ID != "resp_1" {
+				t.Errorf("Expected the response.done event, got %s", last.data)
+			}
+		})
+	}
+}
+
+func TestEventPublisherErrors(t *testing.T) {
+	var subjects []string
+	var failed []incoming.RcvdMsgType
+	publisher := NewEventPublisher(
+		PublisherFunc(func(ctx context.Context, subject string, data []byte) error {
+			subjects = append(subjects, subject)
+			return errors.New("disconnected")
+		}),
+		WithOnPublishError(func(msgType incoming.RcvdMsgType, err error) {
+			failed = append(failed, msgType)
+		}),
+	)
+
+	// Events before session.created still get a valid subject
+	publisher.Handle(context.Background(), mustParse(t, `{"type":"error","error":{"type":"server_error","message":"oops"}}`))
+
+	if len(subjects) != 1 || subjects[0] != "realtime.unknown.error" {
+		t.Errorf("Expected subject realtime.unknown.error, got %v", subjects)
+	}
+	if len(failed) != 1 || failed[0] != incoming.RcvdMsgTypeError {
+		t.Errorf("Expected the error event to be reported, got %v", failed)
+	}
+}
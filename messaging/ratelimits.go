@@ -0,0 +1,127 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/clock"
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+)
+
+//-----------------------------------------------------------------------------
+// Rate Limits
+//-----------------------------------------------------------------------------
+
+const (
+	// RateLimitRequests is the name of the rate limit on requests
+	RateLimitRequests = "requests"
+
+	// RateLimitTokens is the name of the rate limit on tokens
+	RateLimitTokens = "tokens"
+)
+
+// RateLimitSnapshot is the latest state of a rate limit reported by rate_limits.updated
+type RateLimitSnapshot struct {
+	// Name identifies the limit, such as RateLimitRequests or RateLimitTokens
+	Name string
+
+	// Limit is the maximum allowed value
+	Limit int
+
+	// Remaining is what was left when the update was received
+	Remaining int
+
+	// ResetAt is when the limit resets, from reset_seconds and the time the update was received
+	ResetAt time.Time
+
+	// UpdatedAt is when the update was received
+	UpdatedAt time.Time
+}
+
+// SetClock sets the clock used to time rate limit resets and response retry delays, e.g. a
+// clock.Fake in tests. The default is the system clock.
+func (c *Client) SetClock(clk clock.Clock) {
+	if clk == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.clock = clk
+}
+
+// RateLimitFor returns the latest state of the named rate limit. The second return value
+// is false if the server has not reported the limit yet.
+//
+// Rate limits are only tracked for messages received through ReadMessage or a Handler.
+func (c *Client) RateLimitFor(name string) (RateLimitSnapshot, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	snapshot, ok := c.rateLimits[name]
+	return snapshot, ok
+}
+
+// WaitUntilReset blocks until the named rate limit resets, or until ctx is done. It
+// returns immediately if the limit has not been reported or has already reset. A
+// rate_limits.updated received while waiting replaces the reset time, so the wait ends
+// early if the server reports the limit reset sooner.
+//
+// Like WaitFor it relies on a running Handler or ReadMessage loop.
+//
+// Example:
+//
+//	if limit, ok := msgClient.RateLimitFor(messaging.RateLimitTokens); ok && limit.Remaining < 1000 {
+//		if err := msgClient.WaitUntilReset(ctx, messaging.RateLimitTokens); err != nil {
+//			return err
+//		}
+//	}
+func (c *Client) WaitUntilReset(ctx context.Context, name string) error {
+	for {
+		// Register before reading the snapshot so an update in between is not missed
+		w := c.addWaiter(MessageOfType(incoming.RcvdMsgTypeRateLimitsUpdated))
+
+		c.mu.RLock()
+		snapshot, ok := c.rateLimits[name]
+		clk := c.clock
+		c.mu.RUnlock()
+
+		wait := snapshot.ResetAt.Sub(clk.Now())
+		if !ok || wait <= 0 {
+			c.removeWaiter(w)
+			return nil
+		}
+
+		timer := clk.NewTimer(wait)
+		select {
+		case <-timer.C():
+			c.removeWaiter(w)
+			return nil
+		case <-w.ch:
+			timer.Stop()
+		case <-ctx.Done():
+			timer.Stop()
+			c.removeWaiter(w)
+			return fmt.Errorf("waiting for %s rate limit reset: %w", name, ctx.Err())
+		}
+	}
+}
+
+// setRateLimits records the rate limits reported by rate_limits.updated
+func (c *Client) setRateLimits(m *incoming.RateLimitsUpdatedMessage) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	if c.rateLimits == nil {
+		c.rateLimits = make(map[string]RateLimitSnapshot)
+	}
+	for _, limit := range m.RateLimits {
+		c.rateLimits[limit.Name] = RateLimitSnapshot{
+			Name:      limit.Name,
+			Limit:     limit.Limit,
+			Remaining: limit.Remaining,
+			ResetAt:   now.Add(time.Duration(limit.ResetSeconds * float64(time.Second))),
+			UpdatedAt: now,
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/clock"
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+// rateLimitsUpdated returns a rate_limits.updated event for the tokens limit
+func rateLimitsUpdated(remaining int, resetSeconds string) string {
+	return `{"type":"rate_limits.updated","rate_limits":[` +
+		`{"name":"requests","limit":1000,"remaining":999,"reset_seconds":0.06},` +
+		`{"name":"tokens","limit":50000,"remaining":` + strconv.Itoa(remaining) + `,"reset_seconds":` + resetSeconds + `}]}`
+}
+
+func TestRateLimitFor(t *testing.T) {
+	fake := clock.NewFake(time.Unix(1000, 0))
+	client := NewClient(ws.NewConn(&MockConn{}))
+	client.SetClock(fake)
+
+	if _, ok := client.RateLimitFor(RateLimitTokens); ok {
+		t.Error("Expected no rate limit before any update")
+	}
+
+	client.observe(mustParse(t, rateLimitsUpdated(1200, "2.5")))
+	limit, ok := client.RateLimitFor(RateLimitTokens)
+	if !ok {
+		t.Fatal("Expected the tokens rate limit to be tracked")
+	}
+	if limit.Limit != 50000 || limit.Remaining != 1200 {
+		t.Errorf("Expected 1200 of 50000 tokens remaining, got %+v", limit)
+	}
+	if !limit.ResetAt.Equal(time.Unix(1002, 500000000)) || !limit.UpdatedAt.Equal(time.Unix(1000, 0)) {
+		t.Errorf("Expected a reset 2.5s after the update, got %+v", limit)
+	}
+	if requests, ok := client.RateLimitFor(RateLimitRequests); !ok || requests.Remaining != 999 {
+		t.Errorf("Expected the requests rate limit to be tracked, got %+v", requests)
+	}
+}
+
+func TestWaitUntilReset(t *testing.T) {
+	tests := []struct {
+		name string
+		// wake ends the wait once it has started
+		wake        func(fake *clock.Fake, client *Client, cancel context.CancelFunc)
+		expectedErr bool
+	}{
+		{
+			name: "reset time passes",
+			wake: func(fake *clock.Fake, client *Client, cancel context.CancelFunc) {
+				fake.Advance(10 * time.Second)
+			},
+		},
+		{
+			name: "update reports an earlier reset",
+			wake: func(fake *clock.Fake, client *Client, cancel context.CancelFunc) {
+				client.observe(mustParse(t, rateLimitsUpdated(50000, "0")))
+			},
+		},
+		{
+			name: "context canceled",
+			wake: func(fake *clock.Fake, client *Client, cancel context.CancelFunc) {
+				cancel()
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := clock.NewFake(time.Unix(1000, 0))
+			client := NewClient(ws.NewConn(&MockConn{}))
+			client.SetClock(fake)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			// Nothing to wait for before the limit is reported
+			if err := client.WaitUntilReset(ctx, RateLimitTokens); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			client.observe(mustParse(t, rateLimitsUpdated(10, "10")))
+			done := make(chan error, 1)
+			go func() { done <- client.WaitUntilReset(ctx, RateLimitTokens) }()
+
+			fake.BlockUntil(1)
+			tt.wake(fake, client, cancel)
+			select {
+			case err := <-done:
+				if got := err != nil; got != tt.expectedErr {
+					t.Errorf("Expected error %v, got %v", tt.expectedErr, err)
+				}
+				if tt.expectedErr && !errors.Is(err, context.Canceled) {
+					t.Errorf("Expected context.Canceled, got %v", err)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("Expected the wait to end")
+			}
+		})
+	}
+}
@@ -0,0 +1,452 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/clock"
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+	"github.com/Mliviu79/openai-realtime-go/messages/outgoing"
+)
+
+//-----------------------------------------------------------------------------
+// Send Receipts
+//-----------------------------------------------------------------------------
+
+// DefaultReceiptHistory is the number of completed receipts a ReceiptTracker keeps
+const DefaultReceiptHistory = 256
+
+// ReceiptStatus is the state of a tracked outgoing event
+type ReceiptStatus string
+
+const (
+	// ReceiptPending means the event was sent and its confirmation has not arrived yet
+	ReceiptPending ReceiptStatus = "pending"
+	// ReceiptAcked means the server confirmed the event
+	ReceiptAcked ReceiptStatus = "acked"
+	// ReceiptFailed means the server answered the event with an error, or the connection
+	// was lost before it was confirmed and the event could not be resent
+	ReceiptFailed ReceiptStatus = "failed"
+)
+
+// Receipt records the delivery of an outgoing event that the server confirms
+type Receipt struct {
+	// EventID is the event_id of the outgoing event, assigned by the tracker if it had none
+	EventID string
+	// Type is the type of the outgoing event
+	Type outgoing.OutMsgType
+	// ItemID is the conversation item the event refers to, for item create, delete and
+	// truncate events. The tracker assigns an ID to created items that have none.
+	ItemID string
+	// Status is the delivery state
+	Status ReceiptStatus
+	// SentAt is when the event was written
+	SentAt time.Time
+	// DoneAt is when the confirmation or error arrived, zero while pending
+	DoneAt time.Time
+	// Err is the server error or ErrConnectionLost, for failed receipts
+	Err error
+	// Data is the event as it was sent, for resending with Client.Resend
+	Data []byte
+}
+
+// ackKinds maps each tracked outgoing event type to the server event that confirms it
+var ackKinds = map[outgoing.OutMsgType]incoming.RcvdMsgType{
+	outgoing.OutMsgTypeSessionUpdate:              incoming.RcvdMsgTypeSessionUpdated,
+	outgoing.OutMsgTypeTranscriptionSessionUpdate: incoming.RcvdMsgTypeTranscriptionSessionUpdated,
+	outgoing.OutMsgTypeAudioBufferCommit:          incoming.RcvdMsgTypeAudioBufferCommitted,
+	outgoing.OutMsgTypeAudioBufferClear:           incoming.RcvdMsgTypeAudioBufferCleared,
+	outgoing.OutMsgTypeConversationCreate:         incoming.RcvdMsgTypeConversationItemCreated,
+	outgoing.OutMsgTypeConversationDelete:         incoming.RcvdMsgTypeConversationItemDeleted,
+	outgoing.OutMsgTypeConversationTruncate:       incoming.RcvdMsgTypeConversationItemTruncated,
+}
+
+// ReceiptOption configures a ReceiptTracker
+type ReceiptOption func(*ReceiptTracker)
+
+// WithReceiptHistory sets how many completed receipts are kept for Status and Receipt;
+// the default is DefaultReceiptHistory
+func WithReceiptHistory(n int) ReceiptOption {
+	return func(t *ReceiptTracker) {
+		if n >= 0 {
+			t.historySize = n
+		}
+	}
+}
+
+// WithOnReceipt sets a function called when a receipt is acked or failed, which reports
+// errors for events sent without waiting for a reply. It is called from the reader and
+// must not block.
+func WithOnReceipt(onReceipt func(Receipt)) ReceiptOption {
+	return func(t *ReceiptTracker) {
+		t.onReceipt = onReceipt
+	}
+}
+
+// WithReceiptClock sets the clock used for the SentAt and DoneAt times and by Overdue,
+// e.g. a clock.Fake in tests. The default is the system clock.
+func WithReceiptClock(c clock.Clock) ReceiptOption {
+	return func(t *ReceiptTracker) {
+		if c != nil {
+			t.clock = c
+		}
+	}
+}
+
+// ReceiptTracker tracks which outgoing events have been confirmed by the server.
+//
+// Session updates, audio buffer commits and clears, and conversation item creates,
+// deletes and truncates are tracked; other events are not confirmed by a dedicated
+// server event. Each tracked event is given an event_id if it has none, so an error
+// event can be matched to it, and item creates are given an item ID.
+//
+// Items, deletes and truncates are matched by item ID; the other events are matched
+// in the order they were sent. With server VAD, input_audio_buffer.committed is also
+// sent for commits the server makes itself, so manual commits may be acked early.
+//
+// Example:
+//
+//	receipts := messaging.NewReceiptTracker(messaging.WithOnReceipt(func(r messaging.Receipt) {
+//		if r.Status == messaging.ReceiptFailed {
+//			log.Printf("%s %s failed: %v", r.Type, r.EventID, r.Err)
+//		}
+//	}))
+//	msgClient.SetReceiptTracker(receipts)
+type ReceiptTracker struct {
+	historySize int
+	onReceipt   func(Receipt)
+	clock       clock.Clock
+
+	mu      sync.Mutex
+	pending []*Receipt
+	done    map[string]*Receipt
+	order   []string
+	waiters map[string][]chan Receipt
+}
+
+// NewReceiptTracker creates a ReceiptTracker
+func NewReceiptTracker(opts ...ReceiptOption) *ReceiptTracker {
+	t := &ReceiptTracker{
+		historySize: DefaultReceiptHistory,
+		clock:       clock.Real(),
+		done:        make(map[string]*Receipt),
+		waiters:     make(map[string][]chan Receipt),
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// Pending returns the receipts still waiting for a confirmation, oldest first
+func (t *ReceiptTracker) Pending() []Receipt {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	receipts := make([]Receipt, len(t.pending))
+	for i, r := range t.pending {
+		receipts[i] = *r
+	}
+	return receipts
+}
+
+// Overdue returns the pending receipts sent more than timeout ago, oldest first.
+// Resending them with Client.Resend gives at-least-once delivery: a resent item
+// create reuses the item ID, so the server rejects a duplicate instead of adding it twice.
+func (t *ReceiptTracker) Overdue(timeout time.Duration) []Receipt {
+	cutoff := t.clock.Now().Add(-timeout)
+	var receipts []Receipt
+	for _, r := range t.Pending() {
+		if r.SentAt.Before(cutoff) {
+			receipts = append(receipts, r)
+		}
+	}
+	return receipts
+}
+
+// Receipt returns the receipt of the event with the given ID. The second return value
+// is false if the event is not tracked or its receipt is no longer kept.
+func (t *ReceiptTracker) Receipt(eventID string) (Receipt, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if r := t.find(eventID); r != nil {
+		return *r, true
+	}
+	return Receipt{}, false
+}
+
+// Status returns the status of the event with the given ID, or an empty status if it is unknown
+func (t *ReceiptTracker) Status(eventID string) ReceiptStatus {
+	r, ok := t.Receipt(eventID)
+	if !ok {
+		return ""
+	}
+	return r.Status
+}
+
+// Wait blocks until the event with the given ID is acked or failed, or ctx is done.
+// A failed receipt is returned together with its error.
+func (t *ReceiptTracker) Wait(ctx context.Context, eventID string) (Receipt, error) {
+	t.mu.Lock()
+	r := t.find(eventID)
+	if r == nil {
+		t.mu.Unlock()
+		return Receipt{}, fmt.Errorf("unknown event %q", eventID)
+	}
+	if r.Status != ReceiptPending {
+		t.mu.Unlock()
+		return *r, r.Err
+	}
+	ch := make(chan Receipt, 1)
+	t.waiters[eventID] = append(t.waiters[eventID], ch)
+	t.mu.Unlock()
+
+	select {
+	case receipt := <-ch:
+		return receipt, receipt.Err
+	case <-ctx.Done():
+		t.mu.Lock()
+		t.waiters[eventID] = slices.DeleteFunc(t.waiters[eventID], func(c chan Receipt) bool { return c == ch })
+		if len(t.waiters[eventID]) == 0 {
+			delete(t.waiters, eventID)
+		}
+		t.mu.Unlock()
+		return Receipt{}, fmt.Errorf("waiting for receipt: %w", ctx.Err())
+	}
+}
+
+// find returns the pending or completed receipt with the given event ID; the lock must be held
+func (t *ReceiptTracker) find(eventID string) *Receipt {
+	if i := slices.IndexFunc(t.pending, func(r *Receipt) bool { return r.EventID == eventID }); i >= 0 {
+		return t.pending[i]
+	}
+	return t.done[eventID]
+}
+
+// track records a tracked outgoing event as pending, returning the event with the IDs
+// assigned with newID and the event ID of the new receipt. Untracked events are returned
+// unchanged, and no ID is returned for them or for a resent event that is still pending.
+func (t *ReceiptTracker) track(msgType outgoing.OutMsgType, data []byte, newID func(prefix string) string) ([]byte, string) {
+	if _, ok := ackKinds[msgType]; !ok {
+		return data, ""
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return data, ""
+	}
+	r := &Receipt{Type: msgType, Status: ReceiptPending}
+	changed := false
+
+	if raw, ok := fields["event_id"]; !ok || json.Unmarshal(raw, &r.EventID) != nil || r.EventID == "" {
+		r.EventID = newID("evt_")
+		fields["event_id"], _ = json.Marshal(r.EventID)
+		changed = true
+	}
+
+	switch msgType {
+	case outgoing.OutMsgTypeConversationCreate:
+		var item map[string]json.RawMessage
+		if err := json.Unmarshal(fields["item"], &item); err == nil {
+			if raw, ok := item["id"]; !ok || json.Unmarshal(raw, &r.ItemID) != nil || r.ItemID == "" {
+				r.ItemID = newID("item_")
+				item["id"], _ = json.Marshal(r.ItemID)
+				fields["item"], _ = json.Marshal(item)
+				changed = true
+			}
+		}
+	case outgoing.OutMsgTypeConversationDelete, outgoing.OutMsgTypeConversationTruncate:
+		_ = json.Unmarshal(fields["item_id"], &r.ItemID)
+	}
+
+	if changed {
+		if encoded, err := json.Marshal(fields); err == nil {
+			data = encoded
+		}
+	}
+	r.Data = data
+	r.SentAt = t.clock.Now()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	// A resent event keeps its original receipt
+	if slices.ContainsFunc(t.pending, func(p *Receipt) bool { return p.EventID == r.EventID }) {
+		return data, ""
+	}
+	t.pending = append(t.pending, r)
+	return data, r.EventID
+}
+
+// discard forgets a receipt whose event could not be written
+func (t *ReceiptTracker) discard(eventID string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.pending = slices.DeleteFunc(t.pending, func(r *Receipt) bool { return r.EventID == eventID })
+}
+
+// observe completes the receipt confirmed or failed by an incoming message
+func (t *ReceiptTracker) observe(msg incoming.RcvdMsg) {
+	t.mu.Lock()
+	r, err := t.match(msg)
+	if r == nil {
+		t.mu.Unlock()
+		return
+	}
+	receipt, waiters := t.complete(r, err)
+	t.mu.Unlock()
+
+	t.notify(receipt, waiters)
+}
+
+// reconnected is called when the client is connected to a new session. It fails the
+// pending receipts of events that cannot be resent with ErrConnectionLost, and returns
+// the pending conversation item creates and session updates, oldest first.
+func (t *ReceiptTracker) reconnected() []Receipt {
+	t.mu.Lock()
+	var resendable, lost []*Receipt
+	for _, r := range t.pending {
+		switch r.Type {
+		case outgoing.OutMsgTypeConversationCreate, outgoing.OutMsgTypeSessionUpdate, outgoing.OutMsgTypeTranscriptionSessionUpdate:
+			resendable = append(resendable, r)
+		default:
+			lost = append(lost, r)
+		}
+	}
+
+	notify := t.fail(lost, ErrConnectionLost)
+	receipts := make([]Receipt, len(resendable))
+	for i, r := range resendable {
+		receipts[i] = *r
+	}
+	t.mu.Unlock()
+
+	notify()
+	return receipts
+}
+
+// abandon fails every pending receipt with err, for events sent to a session that has
+// been replaced and will never confirm them
+func (t *ReceiptTracker) abandon(err error) {
+	t.mu.Lock()
+	notify := t.fail(slices.Clone(t.pending), err)
+	t.mu.Unlock()
+
+	notify()
+}
+
+// fail completes the receipts with err and returns a function that notifies their
+// waiters, to be called once the lock is released; the lock must be held
+func (t *ReceiptTracker) fail(receipts []*Receipt, err error) (notify func()) {
+	type completion struct {
+		receipt Receipt
+		waiters []chan Receipt
+	}
+	completions := make([]completion, len(receipts))
+	for i, r := range receipts {
+		completions[i].receipt, completions[i].waiters = t.complete(r, err)
+	}
+	return func() {
+		for _, c := range completions {
+			t.notify(c.receipt, c.waiters)
+		}
+	}
+}
+
+// notify delivers a completed receipt to its waiters and the receipt function
+func (t *ReceiptTracker) notify(receipt Receipt, waiters []chan Receipt) {
+	for _, ch := range waiters {
+		ch <- receipt
+	}
+	if t.onReceipt != nil {
+		t.onReceipt(receipt)
+	}
+}
+
+// match returns the pending receipt completed by msg and the error it failed with, if any;
+// the lock must be held
+func (t *ReceiptTracker) match(msg incoming.RcvdMsg) (*Receipt, error) {
+	var itemID string
+	switch m := msg.(type) {
+	case *incoming.ErrorMessage:
+		if m.Error.EventID == "" {
+			return nil, nil
+		}
+		i := slices.IndexFunc(t.pending, func(r *Receipt) bool { return r.EventID == m.Error.EventID })
+		if i < 0 {
+			return nil, nil
+		}
+		return t.pending[i], m.AsAPIError()
+	case *incoming.ConversationItemCreatedMessage:
+		itemID = m.Item.ID
+	case *incoming.ConversationItemDeletedMessage:
+		itemID = m.ItemID
+	case *incoming.ConversationItemTruncatedMessage:
+		itemID = m.ItemID
+	}
+
+	msgType := msg.RcvdMsgType()
+	i := slices.IndexFunc(t.pending, func(r *Receipt) bool {
+		return ackKinds[r.Type] == msgType && r.ItemID == itemID
+	})
+	if i < 0 {
+		return nil, nil
+	}
+	return t.pending[i], nil
+}
+
+// complete moves a receipt from pending to the completed history and detaches its waiters;
+// the lock must be held
+func (t *ReceiptTracker) complete(r *Receipt, err error) (Receipt, []chan Receipt) {
+	t.pending = slices.DeleteFunc(t.pending, func(p *Receipt) bool { return p == r })
+	r.DoneAt = t.clock.Now()
+	r.Status = ReceiptAcked
+	if err != nil {
+		r.Status = ReceiptFailed
+		r.Err = err
+	}
+
+	if t.historySize > 0 {
+		t.done[r.EventID] = r
+		t.order = append(t.order, r.EventID)
+		for len(t.order) > t.historySize {
+			delete(t.done, t.order[0])
+			t.order = t.order[1:]
+		}
+	}
+
+	waiters := t.waiters[r.EventID]
+	delete(t.waiters, r.EventID)
+	return *r, waiters
+}
+
+// SetReceiptTracker makes the client track the confirmations of the events it sends.
+// Passing nil stops tracking.
+//
+// Confirmations are only seen for messages received through ReadMessage or a Handler.
+func (c *Client) SetReceiptTracker(tracker *ReceiptTracker) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.receipts = tracker
+}
+
+// receiptTracker returns the receipt tracker, if any
+func (c *Client) receiptTracker() *ReceiptTracker {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.receipts
+}
+
+// Resend sends the event of a receipt again, e.g. one returned by ReceiptTracker.Overdue.
+// The event keeps its event_id, so the tracker completes the original receipt when the
+// server confirms either copy.
+func (c *Client) Resend(ctx context.Context, receipt Receipt) error {
+	if len(receipt.Data) == 0 {
+		return fmt.Errorf("receipt %q has no event data", receipt.EventID)
+	}
+	return c.write(ctx, receipt.Type, receipt.Data)
+}
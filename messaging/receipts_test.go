@@ -0,0 +1,116 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/apierrs"
+	"github.com/Mliviu79/openai-realtime-go/clock"
+	"github.com/Mliviu79/openai-realtime-go/session"
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+func TestReceiptTracker(t *testing.T) {
+	conn, sent, _ := recordingConn()
+	client := NewClient(ws.NewConn(conn))
+	var completed []Receipt
+	receipts := NewReceiptTracker(WithOnReceipt(func(r Receipt) { completed = append(completed, r) }))
+	client.SetReceiptTracker(receipts)
+
+	ctx := context.Background()
+	if err := client.SendAudioBufferAppend(ctx, "AAAA"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := client.SendText(ctx, "Hi"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := client.SendAudioBufferCommit(ctx, ""); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := client.SendSessionUpdate(ctx, session.SessionRequest{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	pending := receipts.Pending()
+	if len(pending) != 3 {
+		t.Fatalf("Expected 3 pending receipts without the append, got %d", len(pending))
+	}
+	var sentItem sentItemCreate
+	if err := json.Unmarshal([]byte(sent()[1]), &sentItem); err != nil {
+		t.Fatalf("Failed to parse %s: %v", sent()[1], err)
+	}
+	item := pending[0]
+	if sentItem.EventID != item.EventID || sentItem.Item.ID != item.ItemID || item.ItemID == "" {
+		t.Errorf("Expected the sent item to carry the assigned IDs %s/%s, got %s", item.EventID, item.ItemID, sent()[1])
+	}
+
+	// Acks arrive out of order; the commit fails
+	client.observe(mustParse(t, `{"type":"session.updated","session":{}}`))
+	client.observe(mustParse(t, fmt.Sprintf(`{"type":"conversation.item.created","item":{"id":%q,"type":"message"}}`, item.ItemID)))
+	client.observe(mustParse(t, fmt.Sprintf(`{"type":"error","error":{"type":"invalid_request_error","code":"input_audio_buffer_commit_empty","message":"buffer too small","event_id":%q}}`, pending[1].EventID)))
+
+	if len(receipts.Pending()) != 0 {
+		t.Errorf("Expected no pending receipts, got %v", receipts.Pending())
+	}
+	if len(completed) != 3 {
+		t.Fatalf("Expected 3 completed receipts, got %d", len(completed))
+	}
+	if completed[0].EventID != pending[2].EventID || completed[0].Status != ReceiptAcked {
+		t.Errorf("Expected the session update to be acked first, got %+v", completed[0])
+	}
+	if status := receipts.Status(item.EventID); status != ReceiptAcked {
+		t.Errorf("Expected the item to be acked, got %q", status)
+	}
+
+	r, err := receipts.Wait(ctx, pending[1].EventID)
+	if apiErr := apierrs.GetAPIError(err); r.Status != ReceiptFailed || apiErr == nil || !apiErr.IsInvalidRequest() {
+		t.Errorf("Expected the commit to fail with the server error, got %s %v", r.Status, err)
+	}
+}
+
+func TestReceiptResend(t *testing.T) {
+	conn, sent, _ := recordingConn()
+	client := NewClient(ws.NewConn(conn))
+	fake := clock.NewFake(time.Unix(0, 0))
+	receipts := NewReceiptTracker(WithReceiptClock(fake))
+	client.SetReceiptTracker(receipts)
+
+	ctx := context.Background()
+	if err := client.SendConversationItemDelete(ctx, "item_1"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if overdue := receipts.Overdue(5 * time.Second); len(overdue) != 0 {
+		t.Errorf("Expected nothing overdue yet, got %v", overdue)
+	}
+	fake.Advance(6 * time.Second)
+
+	overdue := receipts.Overdue(5 * time.Second)
+	if len(overdue) != 1 || overdue[0].ItemID != "item_1" {
+		t.Fatalf("Expected the delete to be overdue, got %v", overdue)
+	}
+	if err := client.Resend(ctx, overdue[0]); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if messages := sent(); len(messages) != 2 || messages[0] != messages[1] {
+		t.Errorf("Expected the same event to be sent twice, got %v", messages)
+	}
+	if n := len(receipts.Pending()); n != 1 {
+		t.Errorf("Expected the resend to keep one receipt, got %d", n)
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, time.Second)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		_, err := receipts.Wait(waitCtx, overdue[0].EventID)
+		done <- err
+	}()
+	client.observe(mustParse(t, `{"type":"conversation.item.deleted","item_id":"item_other"}`))
+	client.observe(mustParse(t, `{"type":"conversation.item.deleted","item_id":"item_1"}`))
+	if err := <-done; err != nil {
+		t.Errorf("Expected the delete to be acked, got %v", err)
+	}
+}
@@ -0,0 +1,255 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/apierrs"
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+	"github.com/Mliviu79/openai-realtime-go/messages/outgoing"
+	"github.com/Mliviu79/openai-realtime-go/messages/types"
+)
+
+//-----------------------------------------------------------------------------
+// Response Retries
+//-----------------------------------------------------------------------------
+
+// ResponseAttemptMetadataKey is the response metadata key that identifies the request
+// and attempt of a response created while a ResponseRetryPolicy is set. Its value has
+// the form "<request ID>:<attempt>"; ParseResponseAttempt reads it.
+const ResponseAttemptMetadataKey = "response_attempt"
+
+// ResponseRetryPolicy decides whether a response that failed is created again with the
+// same configuration
+type ResponseRetryPolicy struct {
+	// MaxRetries is how many times a failed response is created again; zero means once
+	MaxRetries int
+
+	// Delay is the time to wait before creating the response again
+	Delay time.Duration
+
+	// Retryable reports whether a failure is worth retrying. If nil,
+	// IsTransientResponseError is used.
+	Retryable func(types.ResponseError) bool
+}
+
+// IsTransientResponseError reports whether a response failed because of a server error
+// or a rate limit, which may not happen again
+func IsTransientResponseError(err types.ResponseError) bool {
+	switch err.Type {
+	case apierrs.ErrorTypeServer, apierrs.ErrorTypeRateLimit:
+		return true
+	}
+	switch err.Code {
+	case apierrs.ErrorCodeInternalError, apierrs.ErrorCodeServiceDown, apierrs.ErrorCodeRateLimitExceeded:
+		return true
+	}
+	return false
+}
+
+// ParseResponseAttempt returns the request ID and attempt number stamped on a response
+// created while a ResponseRetryPolicy is set. The first attempt is 1. The last return
+// value is false for responses without the stamp.
+func ParseResponseAttempt(response types.Response) (string, int, bool) {
+	value, ok := response.Metadata[ResponseAttemptMetadataKey]
+	if !ok {
+		return "", 0, false
+	}
+	requestID, attempt, ok := strings.Cut(value, ":")
+	if !ok {
+		return "", 0, false
+	}
+	n, err := strconv.Atoi(attempt)
+	if err != nil || n < 1 {
+		return "", 0, false
+	}
+	return requestID, n, true
+}
+
+// responseRetry is a response.create that may be sent again
+type responseRetry struct {
+	config  types.ResponseConfig
+	seq     int
+	attempt int
+	created bool
+
+	// eventID is the event ID of the latest response.create, to recognize an error for it
+	eventID string
+}
+
+// SetResponseRetry sets the policy for retrying responses that fail, or turns retries
+// off if policy is nil. While a policy is set, every response.create sent through
+// SendResponseCreate is stamped with ResponseAttemptMetadataKey, and a response that
+// ends with status failed and a retryable error is created again with the original
+// configuration, up to MaxRetries times. Every attempt is a separate response, so each
+// is reported by a ResponseRegistry, which groups them with Attempts.
+//
+// Responses whose metadata is already full cannot be stamped and are not retried.
+// Retries are only made for messages received through ReadMessage or a Handler.
+func (c *Client) SetResponseRetry(policy *ResponseRetryPolicy) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if policy == nil {
+		c.retryPolicy = nil
+		c.retries = nil
+		return
+	}
+	p := *policy
+	if p.MaxRetries <= 0 {
+		p.MaxRetries = 1
+	}
+	if p.Retryable == nil {
+		p.Retryable = IsTransientResponseError
+	}
+	c.retryPolicy = &p
+	if c.retries == nil {
+		c.retries = make(map[string]*responseRetry)
+	}
+}
+
+// stampResponseAttempt returns the message to send for a new response.create, stamped
+// with a new request ID and event ID if a retry policy is set, and a function to call if
+// sending fails
+func (c *Client) stampResponseAttempt(config *types.ResponseConfig) (outgoing.ResponseCreateMessage, func()) {
+	c.mu.RLock()
+	stamp := c.retryPolicy != nil && len(config.Metadata) < types.MaxMetadataPairs
+	c.mu.RUnlock()
+	if !stamp {
+		return outgoing.NewResponseCreateMessage(*config), func() {}
+	}
+	eventID := c.newID("evt_")
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.retryPolicy == nil {
+		return outgoing.NewResponseCreateMessage(*config), func() {}
+	}
+	c.retrySeq++
+	requestID := "req_" + strconv.Itoa(c.retrySeq)
+	c.retries[requestID] = &responseRetry{config: config.Clone(), seq: c.retrySeq, attempt: 1, eventID: eventID}
+	msg := outgoing.NewResponseCreateMessage(*withResponseAttempt(config, requestID, 1))
+	msg.ID = eventID
+	return msg, func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		delete(c.retries, requestID)
+	}
+}
+
+// withResponseAttempt returns a copy of config stamped with the request ID and attempt
+func withResponseAttempt(config *types.ResponseConfig, requestID string, attempt int) *types.ResponseConfig {
+	stamped := *config
+	stamped.Metadata = maps.Clone(config.Metadata)
+	if stamped.Metadata == nil {
+		stamped.Metadata = make(map[string]string, 1)
+	}
+	stamped.Metadata[ResponseAttemptMetadataKey] = fmt.Sprintf("%s:%d", requestID, attempt)
+	return &stamped
+}
+
+// observeResponseAttempt tracks stamped responses and creates a failed one again if the
+// policy allows. A response.create the server rejects with an error is forgotten.
+func (c *Client) observeResponseAttempt(msg incoming.RcvdMsg) {
+	switch m := msg.(type) {
+	case *incoming.ErrorMessage:
+		if m.Error.EventID == "" {
+			return
+		}
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		for id, retry := range c.retries {
+			if retry.eventID == m.Error.EventID {
+				delete(c.retries, id)
+			}
+		}
+	case *incoming.ResponseCreatedMessage:
+		requestID, _, ok := ParseResponseAttempt(m.Response)
+		if !ok {
+			return
+		}
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		retry, ok := c.retries[requestID]
+		if !ok {
+			return
+		}
+		retry.created = true
+		// Responses are created in the order they are requested, so earlier requests
+		// that were never created were rejected
+		for id, other := range c.retries {
+			if !other.created && other.seq < retry.seq {
+				delete(c.retries, id)
+			}
+		}
+	case *incoming.ResponseDoneMessage:
+		requestID, attempt, ok := ParseResponseAttempt(m.Response)
+		if !ok {
+			return
+		}
+		c.mu.Lock()
+		retry, tracked := c.retries[requestID]
+		policy := c.retryPolicy
+		if !tracked || attempt != retry.attempt {
+			c.mu.Unlock()
+			return
+		}
+		failed, isFailed := m.Response.StatusDetails.Failed()
+		if !isFailed || m.Response.Status != types.ResponseStatusFailed || policy == nil ||
+			attempt > policy.MaxRetries || !policy.Retryable(failed.Error) {
+			delete(c.retries, requestID)
+			c.mu.Unlock()
+			return
+		}
+		retry.attempt++
+		retry.created = false
+		config := withResponseAttempt(&retry.config, requestID, retry.attempt)
+		clk := c.clock
+		c.mu.Unlock()
+
+		// The retry must not block the reader, which delivers the response that is
+		// in progress when a response policy makes it wait
+		if policy.Delay <= 0 {
+			go c.sendResponseRetry(requestID, config)
+			return
+		}
+		clk.AfterFunc(policy.Delay, func() {
+			c.sendResponseRetry(requestID, config)
+		})
+	}
+}
+
+// sendResponseRetry sends a response.create for another attempt of a failed response
+func (c *Client) sendResponseRetry(requestID string, config *types.ResponseConfig) {
+	defer c.recoverPanic("response retry")
+
+	ctx, cancel := context.WithTimeout(context.Background(), DefaultCancelTimeout)
+	defer cancel()
+
+	// Number the retry as a new request, so it is not mistaken for a rejected one when
+	// a response requested before it is created
+	msg := outgoing.NewResponseCreateMessage(*config)
+	msg.ID = c.newID("evt_")
+	c.mu.Lock()
+	retry, ok := c.retries[requestID]
+	if !ok {
+		c.mu.Unlock()
+		return
+	}
+	c.retrySeq++
+	retry.seq = c.retrySeq
+	retry.eventID = msg.ID
+	c.mu.Unlock()
+
+	if err := c.SendMessage(ctx, msg); err != nil {
+		c.mu.Lock()
+		delete(c.retries, requestID)
+		c.mu.Unlock()
+		if log := c.getLogger(); log != nil {
+			log.Warnf("Failed to retry response %s: %v", requestID, err)
+		}
+	}
+}
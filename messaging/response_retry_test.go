@@ -0,0 +1,230 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/clock"
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+	"github.com/Mliviu79/openai-realtime-go/messages/types"
+	"github.com/Mliviu79/openai-realtime-go/session"
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+// failingConn answers response.create with a response that fails with errorType until
+// failures responses have failed, and completes the rest
+func failingConn(t *testing.T, errorType string, failures int, sent func(metadata map[string]string)) *MockConn {
+	replies := make(chan string, 16)
+	var mu sync.Mutex
+	n := 0
+	return &MockConn{
+		ReadMessageFunc: func(ctx context.Context) (ws.MessageType, []byte, error) {
+			select {
+			case data := <-replies:
+				return ws.MessageText, []byte(data), nil
+			case <-ctx.Done():
+				return ws.MessageText, nil, ctx.Err()
+			}
+		},
+		WriteMessageFunc: func(ctx context.Context, messageType ws.MessageType, data []byte) error {
+			var event struct {
+				Response struct {
+					Metadata map[string]string `json:"metadata"`
+				} `json:"response"`
+			}
+			if err := json.Unmarshal(data, &event); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+			sent(event.Response.Metadata)
+
+			mu.Lock()
+			n++
+			id := fmt.Sprintf("resp_%d", n)
+			failed := n <= failures
+			mu.Unlock()
+
+			metadata, _ := json.Marshal(event.Response.Metadata)
+			replies <- fmt.Sprintf(`{"type":"response.created","response":{"id":%q,"status":"in_progress","metadata":%s}}`, id, metadata)
+			if failed {
+				replies <- fmt.Sprintf(`{"type":"response.done","response":{"id":%q,"status":"failed","metadata":%s,"status_details":{"type":"failed","error":{"type":%q,"code":"internal_error"}}}}`, id, metadata, errorType)
+			} else {
+				replies <- fmt.Sprintf(`{"type":"response.done","response":{"id":%q,"status":"completed","metadata":%s}}`, id, metadata)
+			}
+			return nil
+		},
+	}
+}
+
+func TestResponseRetry(t *testing.T) {
+	var mu sync.Mutex
+	var sent []map[string]string
+	conn := failingConn(t, "server_error", 1, func(metadata map[string]string) {
+		mu.Lock()
+		defer mu.Unlock()
+		sent = append(sent, metadata)
+	})
+	client := NewClient(ws.NewConn(conn))
+	client.SetResponseRetry(&ResponseRetryPolicy{})
+	registry := NewResponseRegistry()
+	completed := make(chan struct{})
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	handler := NewHandler(ctx, client, registry.Handle, func(ctx context.Context, msg incoming.RcvdMsg) {
+		if done, ok := msg.(*incoming.ResponseDoneMessage); ok && done.Response.Status == types.ResponseStatusCompleted {
+			close(completed)
+		}
+	})
+	handler.Start()
+	defer handler.Stop()
+
+	config := &types.ResponseConfig{Metadata: map[string]string{"topic": "billing"}}
+	if err := client.SendResponseCreate(ctx, config); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	select {
+	case <-completed:
+	case <-time.After(2 * time.Second):
+		t.Fatalf("Expected the failed response to be retried, got %+v", registry.Attempts("req_1"))
+	}
+	attempts := registry.Attempts("req_1")
+	if len(attempts) != 2 {
+		t.Fatalf("Expected 2 attempts, got %+v", attempts)
+	}
+
+	if attempts[0].Attempt != 1 || attempts[0].Response.Status != types.ResponseStatusFailed {
+		t.Errorf("Expected the first attempt to have failed, got %+v", attempts[0])
+	}
+	if attempts[1].Attempt != 2 || attempts[1].Response.Status != types.ResponseStatusCompleted {
+		t.Errorf("Expected the second attempt to have completed, got %+v", attempts[1])
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(sent) != 2 || sent[1]["topic"] != "billing" || sent[1][ResponseAttemptMetadataKey] != "req_1:2" {
+		t.Errorf("Expected the retry to keep the original config, got %v", sent)
+	}
+	if _, ok := config.Metadata[ResponseAttemptMetadataKey]; ok {
+		t.Error("Expected the caller's config not to be modified")
+	}
+}
+
+func TestResponseRetrySkipsPermanentFailures(t *testing.T) {
+	var mu sync.Mutex
+	var sent int
+	conn := failingConn(t, "invalid_request_error", 2, func(map[string]string) {
+		mu.Lock()
+		defer mu.Unlock()
+		sent++
+	})
+	fake := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	client := NewClient(ws.NewConn(conn))
+	client.SetClock(fake)
+	client.SetResponseRetry(&ResponseRetryPolicy{
+		Delay:     time.Second,
+		Retryable: func(err types.ResponseError) bool { return err.Type == "server_error" },
+	})
+	ctx := context.Background()
+
+	if err := client.SendResponseCreate(ctx, &types.ResponseConfig{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := client.ReadMessage(ctx); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	// A retry would have been scheduled on the clock when the failure was read
+	if fake.Timers() != 0 {
+		t.Error("Expected no retry to be scheduled")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if sent != 1 {
+		t.Errorf("Expected a permanent failure not to be retried, got %d sends", sent)
+	}
+	client.mu.RLock()
+	defer client.mu.RUnlock()
+	if len(client.retries) != 0 {
+		t.Errorf("Expected the finished request to be forgotten, got %v", client.retries)
+	}
+}
+
+func TestResponseRetryKeepsOwnConfig(t *testing.T) {
+	conn, _, _ := recordingConn()
+	client := NewClient(ws.NewConn(conn))
+	client.SetResponseRetry(&ResponseRetryPolicy{})
+
+	instructions := "Be brief"
+	config := &types.ResponseConfig{
+		Modalities: []session.Modality{session.ModalityText},
+		Metadata:   map[string]string{"topic": "billing"},
+	}
+	config.Instructions = &instructions
+	_, _ = client.stampResponseAttempt(config)
+
+	// The caller reuses its configuration after sending
+	config.Modalities[0] = session.ModalityAudio
+	config.Metadata["topic"] = "sales"
+	instructions = "Be verbose"
+
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	retry := client.retries["req_1"]
+	if retry == nil {
+		t.Fatal("Expected the attempt to be tracked")
+	}
+	if retry.config.Modalities[0] != session.ModalityText || retry.config.Metadata["topic"] != "billing" || *retry.config.Instructions != "Be brief" {
+		t.Errorf("Expected the retry to keep the configuration as sent, got %+v", retry.config)
+	}
+}
+
+func TestResponseRetryForgetsAbandonedRequests(t *testing.T) {
+	newClient := func() *Client {
+		conn, _, _ := recordingConn()
+		client := NewClient(ws.NewConn(conn))
+		client.SetIDGenerator(NewSequentialIDs())
+		client.SetResponseRetry(&ResponseRetryPolicy{})
+		for i := 0; i < 2; i++ {
+			if err := client.SendResponseCreate(context.Background(), &types.ResponseConfig{}); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+		}
+		return client
+	}
+	tracked := func(client *Client) []string {
+		client.mu.RLock()
+		defer client.mu.RUnlock()
+		var ids []string
+		for id := range client.retries {
+			ids = append(ids, id)
+		}
+		slices.Sort(ids)
+		return ids
+	}
+
+	// A rejected response.create is forgotten, and the other request is kept
+	client := newClient()
+	client.observe(mustParse(t, `{"type":"error","error":{"type":"invalid_request_error","message":"Invalid response","event_id":"evt_1"}}`))
+	if ids := tracked(client); len(ids) != 1 || ids[0] != "req_2" {
+		t.Errorf("Expected only req_2 to be tracked after req_1 was rejected, got %v", ids)
+	}
+
+	client = newClient()
+	client.swapConn(NewClient(ws.NewConn(&MockConn{})))
+	if ids := tracked(client); len(ids) != 0 {
+		t.Errorf("Expected no requests to be tracked after a handoff, got %v", ids)
+	}
+
+	client = newClient()
+	client.Close()
+	if ids := tracked(client); len(ids) != 0 {
+		t.Errorf("Expected no requests to be tracked after close, got %v", ids)
+	}
+}
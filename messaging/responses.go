@@ -0,0 +1,287 @@
+package messaging
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/clock"
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+	"github.com/Mliviu79/openai-realtime-go/messages/types"
+)
+
+//-----------------------------------------------------------------------------
+// Response Registry
+//-----------------------------------------------------------------------------
+
+// DefaultResponseRegistrySize is the number of completed responses a ResponseRegistry keeps
+const DefaultResponseRegistrySize = 100
+
+// ResponseStats is the latency and throughput report for a completed response
+type ResponseStats struct {
+	// ResponseID identifies the response
+	ResponseID string
+
+	// Status is the final status of the response
+	Status types.ResponseStatus
+
+	// CreatedAt is when response.created was received
+	CreatedAt time.Time
+
+	// FirstDeltaAt is when the first text, audio, transcript or function call delta was
+	// received; zero if the response produced no output
+	FirstDeltaAt time.Time
+
+	// DoneAt is when response.done was received
+	DoneAt time.Time
+
+	// TTFB is the time from response.created to the first delta
+	TTFB time.Duration
+
+	// Duration is the time from response.created to response.done
+	Duration time.Duration
+
+	// OutputTokens is the number of output tokens reported in the response usage
+	OutputTokens int
+
+	// TokensPerSecond is the output token rate from the first delta to response.done
+	TokensPerSecond float64
+
+	// AudioDuration is the duration of the audio generated, based on the session's output audio format
+	AudioDuration time.Duration
+
+	// CancellationReason is the reason reported for a cancelled response, such as
+	// "turn_detected" or "client_cancelled"; empty if the response was not cancelled
+	CancellationReason string
+}
+
+// ResponseRecord is the state a ResponseRegistry keeps for a response
+type ResponseRecord struct {
+	// Response is the latest state of the response reported by the server
+	Response types.Response
+
+	// Done is true once response.done has been received
+	Done bool
+
+	// Stats is the latency and throughput report; only set once Done is true
+	Stats ResponseStats
+
+	// RequestID and Attempt identify the response.create the response answers and which
+	// attempt it was, if it was stamped by a ResponseRetryPolicy; see ParseResponseAttempt
+	RequestID string
+	Attempt   int
+}
+
+// ResponseRegistryOption configures a ResponseRegistry
+type ResponseRegistryOption func(*ResponseRegistry)
+
+// WithOnResponseStats sets a function that is called with the stats of every completed response
+func WithOnResponseStats(onStats func(stats ResponseStats)) ResponseRegistryOption {
+	return func(r *ResponseRegistry) {
+		r.onStats = onStats
+	}
+}
+
+// WithResponseRegistryClock sets the clock used to time events that were not stamped with
+// the time they were received, which messages read through a Client are; the default is
+// the real clock
+func WithResponseRegistryClock(c clock.Clock) ResponseRegistryOption {
+	return func(r *ResponseRegistry) {
+		if c != nil {
+			r.clock = c
+		}
+	}
+}
+
+// WithResponseRegistrySize sets how many completed responses are kept; older ones are evicted
+func WithResponseRegistrySize(size int) ResponseRegistryOption {
+	return func(r *ResponseRegistry) {
+		if size > 0 {
+			r.size = size
+		}
+	}
+}
+
+// responseEntry is the bookkeeping for a response in the registry
+type responseEntry struct {
+	record     ResponseRecord
+	audioBytes int
+}
+
+// ResponseRegistry tracks responses by ID and produces a ResponseStats report for each
+// completed response, for quality dashboards and latency monitoring.
+//
+// Example:
+//
+//	registry := messaging.NewResponseRegistry(messaging.WithOnResponseStats(func(s messaging.ResponseStats) {
+//		metrics.Observe("ttfb", s.TTFB)
+//	}))
+//	handler := messaging.NewHandler(ctx, msgClient, registry.Handle)
+type ResponseRegistry struct {
+	mu          sync.Mutex
+	entries     map[string]*responseEntry
+	completed   []string
+	size        int
+	outputAudio outputAudioFormat
+	onStats     func(ResponseStats)
+	clock       clock.Clock
+}
+
+// NewResponseRegistry creates an empty ResponseRegistry
+func NewResponseRegistry(opts ...ResponseRegistryOption) *ResponseRegistry {
+	r := &ResponseRegistry{
+		entries: make(map[string]*responseEntry),
+		size:    DefaultResponseRegistrySize,
+		clock:   clock.Real(),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Handle opens a record on response.created, times the first output delta and counts
+// output audio, and completes the record and its stats on response.done.
+func (r *ResponseRegistry) Handle(ctx context.Context, msg incoming.RcvdMsg) {
+	// Time events by when they were received, so the stats do not include the time
+	// spent in earlier handlers
+	now, ok := incoming.ReceivedAt(msg)
+	if !ok {
+		now = r.clock.Now()
+	}
+
+	switch m := msg.(type) {
+	case *incoming.SessionCreatedMessage, *incoming.SessionUpdatedMessage:
+		r.mu.Lock()
+		r.outputAudio.observe(msg)
+		r.mu.Unlock()
+	case *incoming.ResponseCreatedMessage:
+		record := ResponseRecord{
+			Response: m.Response,
+			Stats:    ResponseStats{ResponseID: m.Response.ID, CreatedAt: now},
+		}
+		record.RequestID, record.Attempt, _ = ParseResponseAttempt(m.Response)
+		r.mu.Lock()
+		r.entries[m.Response.ID] = &responseEntry{record: record}
+		r.mu.Unlock()
+	case *incoming.ResponseOutputAudioDeltaMessage:
+		r.delta(m.ResponseID, now, m.AudioLen())
+	case *incoming.ResponseOutputTextDeltaMessage:
+		r.delta(m.ResponseID, now, 0)
+	case *incoming.ResponseOutputAudioTranscriptDeltaMessage:
+		r.delta(m.ResponseID, now, 0)
+	case *incoming.ResponseFunctionCallArgumentsDeltaMessage:
+		r.delta(m.ResponseID, now, 0)
+	case *incoming.ResponseDoneMessage:
+		if stats, ok := r.done(m.Response, now); ok && r.onStats != nil {
+			r.onStats(stats)
+		}
+	}
+}
+
+// Get returns the record for the response with the given ID
+func (r *ResponseRegistry) Get(responseID string) (ResponseRecord, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entry, ok := r.entries[responseID]
+	if !ok {
+		return ResponseRecord{}, false
+	}
+	return entry.record, true
+}
+
+// Stats returns the stats of a completed response
+func (r *ResponseRegistry) Stats(responseID string) (ResponseStats, bool) {
+	record, ok := r.Get(responseID)
+	if !ok || !record.Done {
+		return ResponseStats{}, false
+	}
+	return record.Stats, true
+}
+
+// Attempts returns the records of every attempt at the request with the given ID, in
+// attempt order, so a response that was retried after failing can be followed through
+// each try. Request IDs are stamped on responses while a ResponseRetryPolicy is set.
+func (r *ResponseRegistry) Attempts(requestID string) []ResponseRecord {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var attempts []ResponseRecord
+	for _, entry := range r.entries {
+		if requestID != "" && entry.record.RequestID == requestID {
+			attempts = append(attempts, entry.record)
+		}
+	}
+	slices.SortFunc(attempts, func(a, b ResponseRecord) int { return a.Attempt - b.Attempt })
+	return attempts
+}
+
+// Active returns the IDs of the responses that have been created but are not done
+func (r *ResponseRegistry) Active() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var active []string
+	for id, entry := range r.entries {
+		if !entry.record.Done {
+			active = append(active, id)
+		}
+	}
+	return active
+}
+
+// delta records output for a response
+func (r *ResponseRegistry) delta(responseID string, now time.Time, audioBytes int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	entry, ok := r.entries[responseID]
+	if !ok || entry.record.Done {
+		return
+	}
+	if entry.record.Stats.FirstDeltaAt.IsZero() {
+		entry.record.Stats.FirstDeltaAt = now
+	}
+	entry.audioBytes += audioBytes
+}
+
+// done completes a response and computes its stats
+func (r *ResponseRegistry) done(response types.Response, now time.Time) (ResponseStats, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, ok := r.entries[response.ID]
+	if !ok {
+		// response.created was missed; report what response.done carries
+		entry = &responseEntry{record: ResponseRecord{Stats: ResponseStats{ResponseID: response.ID, CreatedAt: now}}}
+		r.entries[response.ID] = entry
+	}
+	if entry.record.Done {
+		return ResponseStats{}, false
+	}
+
+	stats := entry.record.Stats
+	stats.Status = response.Status
+	stats.DoneAt = now
+	stats.Duration = now.Sub(stats.CreatedAt)
+	stats.AudioDuration = r.outputAudio.duration(entry.audioBytes)
+	if !stats.FirstDeltaAt.IsZero() {
+		stats.TTFB = stats.FirstDeltaAt.Sub(stats.CreatedAt)
+	}
+	if response.Usage != nil {
+		stats.OutputTokens = response.Usage.OutputTokens
+		if generating := now.Sub(stats.FirstDeltaAt); !stats.FirstDeltaAt.IsZero() && generating > 0 {
+			stats.TokensPerSecond = float64(stats.OutputTokens) / generating.Seconds()
+		}
+	}
+	if cancelled, ok := response.StatusDetails.Cancelled(); ok {
+		stats.CancellationReason = string(cancelled.Reason)
+	}
+
+	entry.record = ResponseRecord{Response: response, Done: true, Stats: stats}
+	entry.record.RequestID, entry.record.Attempt, _ = ParseResponseAttempt(response)
+	r.completed = append(r.completed, response.ID)
+	for len(r.completed) > r.size {
+		delete(r.entries, r.completed[0])
+		r.completed = r.completed[1:]
+	}
+	return stats, true
+}
@@ -0,0 +1,103 @@
+package messaging
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/clock"
+	"github.com/Mliviu79/openai-realtime-go/messages/types"
+)
+
+func TestResponseRegistryStats(t *testing.T) {
+	var reported []ResponseStats
+	fake := clock.NewFake(time.Unix(1700000000, 0))
+	registry := NewResponseRegistry(WithOnResponseStats(func(s ResponseStats) { reported = append(reported, s) }), WithResponseRegistryClock(fake))
+
+	// 48000 bytes of pcm16 at 24kHz mono is one second of audio
+	audio := base64.StdEncoding.EncodeToString(make([]byte, 24000))
+
+	ctx := context.Background()
+	for _, msg := range []string{
+		`{"type":"response.created","response":{"id":"resp_1","status":"in_progress"}}`,                                                             // t=0
+		`{"type":"response.output_audio.delta","response_id":"resp_1","item_id":"item_1","delta":"` + audio + `"}`,                                  // t=100ms
+		`{"type":"response.output_audio_transcript.delta","response_id":"resp_1","item_id":"item_1","delta":"Hi"}`,                                  // t=200ms
+		`{"type":"response.output_audio.delta","response_id":"resp_1","item_id":"item_1","delta":"` + audio + `"}`,                                  // t=300ms
+		`{"type":"response.done","response":{"id":"resp_1","status":"completed","usage":{"total_tokens":70,"input_tokens":50,"output_tokens":20}}}`, // t=400ms
+	} {
+		registry.Handle(ctx, mustParse(t, msg))
+		fake.Advance(100 * time.Millisecond)
+	}
+
+	stats, ok := registry.Stats("resp_1")
+	if !ok {
+		t.Fatal("Expected stats for resp_1")
+	}
+	if stats.TTFB != 100*time.Millisecond {
+		t.Errorf("Expected TTFB 100ms, got %v", stats.TTFB)
+	}
+	if stats.Duration != 400*time.Millisecond {
+		t.Errorf("Expected duration 400ms, got %v", stats.Duration)
+	}
+	if stats.AudioDuration != time.Second {
+		t.Errorf("Expected 1s of audio, got %v", stats.AudioDuration)
+	}
+	if stats.OutputTokens != 20 || stats.TokensPerSecond != 20/0.3 {
+		t.Errorf("Expected 20 tokens at %.2f/s, got %d at %.2f/s", 20/0.3, stats.OutputTokens, stats.TokensPerSecond)
+	}
+	if stats.Status != types.ResponseStatusCompleted || stats.CancellationReason != "" {
+		t.Errorf("Expected completed without cancellation, got %s %q", stats.Status, stats.CancellationReason)
+	}
+	if len(reported) != 1 || reported[0] != stats {
+		t.Errorf("Expected stats to be reported once, got %+v", reported)
+	}
+	if len(registry.Active()) != 0 {
+		t.Errorf("Expected no active responses, got %v", registry.Active())
+	}
+}
+
+func TestResponseRegistryCancellation(t *testing.T) {
+	registry := NewResponseRegistry()
+	ctx := context.Background()
+	registry.Handle(ctx, mustParse(t, `{"type":"response.created","response":{"id":"resp_1","status":"in_progress"}}`))
+	if active := registry.Active(); len(active) != 1 || active[0] != "resp_1" {
+		t.Errorf("Expected resp_1 to be active, got %v", active)
+	}
+	if _, ok := registry.Stats("resp_1"); ok {
+		t.Error("Expected no stats before response.done")
+	}
+
+	registry.Handle(ctx, mustParse(t, `{"type":"response.done","response":{"id":"resp_1","status":"cancelled","status_details":{"type":"cancelled","reason":"turn_detected"}}}`))
+
+	stats, ok := registry.Stats("resp_1")
+	if !ok {
+		t.Fatal("Expected stats for resp_1")
+	}
+	if stats.CancellationReason != "turn_detected" {
+		t.Errorf("Expected cancellation reason turn_detected, got %q", stats.CancellationReason)
+	}
+	if !stats.FirstDeltaAt.IsZero() || stats.TTFB != 0 {
+		t.Errorf("Expected no first delta, got %v", stats.FirstDeltaAt)
+	}
+	record, _ := registry.Get("resp_1")
+	if !record.Done || record.Response.Status != types.ResponseStatusCancelled {
+		t.Errorf("Expected final response to be recorded, got %+v", record)
+	}
+}
+
+func TestResponseRegistryEvictsOldResponses(t *testing.T) {
+	registry := NewResponseRegistry(WithResponseRegistrySize(2))
+	ctx := context.Background()
+	for i := 1; i <= 3; i++ {
+		registry.Handle(ctx, mustParse(t, fmt.Sprintf(`{"type":"response.done","response":{"id":"resp_%d","status":"completed"}}`, i)))
+	}
+
+	if _, ok := registry.Get("resp_1"); ok {
+		t.Error("Expected resp_1 to be evicted")
+	}
+	if _, ok := registry.Get("resp_3"); !ok {
+		t.Error("Expected resp_3 to be kept")
+	}
+}
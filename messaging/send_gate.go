@@ -0,0 +1,110 @@
+package messaging
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/Mliviu79/openai-realtime-go/messages/outgoing"
+)
+
+//-----------------------------------------------------------------------------
+// Prioritized Send Queue
+//-----------------------------------------------------------------------------
+
+// sendGate serializes writes to the connection and lets urgent messages jump ahead
+// of writers that are already waiting. Waiters are served in FIFO order within
+// each priority, and every urgent waiter is served before any normal waiter.
+//
+// This guarantees that an interruption such as response.cancel is written as soon as
+// the frame currently on the wire completes, rather than after every queued audio append.
+type sendGate struct {
+	mu     sync.Mutex
+	busy   bool
+	urgent []chan struct{}
+	normal []chan struct{}
+}
+
+// isUrgent reports whether a message type interrupts the conversation and should
+// bypass queued messages
+func isUrgent(msgType outgoing.OutMsgType) bool {
+	switch msgType {
+	case outgoing.OutMsgTypeResponseCancel, outgoing.OutMsgTypeAudioBufferClear:
+		return true
+	default:
+		return false
+	}
+}
+
+// acquire blocks until the caller may write or ctx is done
+func (g *sendGate) acquire(ctx context.Context, urgent bool) error {
+	g.mu.Lock()
+	if !g.busy {
+		g.busy = true
+		g.mu.Unlock()
+		return nil
+	}
+
+	ready := make(chan struct{})
+	if urgent {
+		g.urgent = append(g.urgent, ready)
+	} else {
+		g.normal = append(g.normal, ready)
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-ready:
+		return nil
+	case <-ctx.Done():
+		g.mu.Lock()
+		if g.remove(ready) {
+			g.mu.Unlock()
+			return ctx.Err()
+		}
+		g.mu.Unlock()
+
+		// The gate was handed to us concurrently with cancellation; pass it on
+		g.release()
+		return ctx.Err()
+	}
+}
+
+// release hands the gate to the next waiter, urgent waiters first
+func (g *sendGate) release() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	var next chan struct{}
+	switch {
+	case len(g.urgent) > 0:
+		next, g.urgent = g.urgent[0], g.urgent[1:]
+	case len(g.normal) > 0:
+		next, g.normal = g.normal[0], g.normal[1:]
+	default:
+		g.busy = false
+		return
+	}
+	close(next)
+}
+
+// remove drops a waiter that gave up. It returns false if the waiter was already served.
+// The caller must hold g.mu.
+func (g *sendGate) remove(ready chan struct{}) bool {
+	if i := slices.Index(g.urgent, ready); i >= 0 {
+		g.urgent = slices.Delete(g.urgent, i, i+1)
+		return true
+	}
+	if i := slices.Index(g.normal, ready); i >= 0 {
+		g.normal = slices.Delete(g.normal, i, i+1)
+		return true
+	}
+	return false
+}
+
+// waiting returns the number of writers waiting for the gate
+func (g *sendGate) waiting() int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return len(g.urgent) + len(g.normal)
+}
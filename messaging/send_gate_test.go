@@ -0,0 +1,170 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+// slowLink is a MockConn that holds its first write until firstWrite is closed and records
+// the order of frames
+type slowLink struct {
+	MockConn
+	mu      sync.Mutex
+	written []string
+}
+
+func newSlowLink(firstWrite <-chan struct{}) *slowLink {
+	link := &slowLink{}
+	first := true
+	link.WriteMessageFunc = func(ctx context.Context, messageType ws.MessageType, data []byte) error {
+		var base struct {
+			Type string `json:"type"`
+		}
+		_ = json.Unmarshal(data, &base)
+
+		link.mu.Lock()
+		block := first
+		first = false
+		link.mu.Unlock()
+
+		if block && firstWrite != nil {
+			<-firstWrite
+		}
+
+		link.mu.Lock()
+		link.written = append(link.written, base.Type)
+		link.mu.Unlock()
+		return nil
+	}
+	return link
+}
+
+// waitForWaiters blocks until n writers are queued on the client's send gate
+func waitForWaiters(t *testing.T, client *Client, n int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for client.gate.waiting() < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected %d queued writers, got %d", n, client.gate.waiting())
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestPriorityCancelBypassesQueuedAudio(t *testing.T) {
+	release := make(chan struct{})
+	link := newSlowLink(release)
+	client := NewClient(ws.NewConn(link))
+	ctx := context.Background()
+
+	const appends = 5
+	var wg sync.WaitGroup
+
+	// The first append occupies the link until released
+	for i := range appends {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = client.SendAudioBufferAppend(ctx, "AAAA")
+		}()
+		waitForWaiters(t, client, i)
+	}
+	waitForWaiters(t, client, appends-1)
+
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_ = client.SendResponseCancel(ctx, "resp_1")
+	}()
+	waitForWaiters(t, client, appends)
+	go func() {
+		defer wg.Done()
+		_ = client.SendAudioBufferClear(ctx)
+	}()
+	waitForWaiters(t, client, appends+1)
+
+	close(release)
+	wg.Wait()
+
+	expected := []string{"input_audio_buffer.append", "response.cancel", "input_audio_buffer.clear"}
+	for i, msgType := range expected {
+		if link.written[i] != msgType {
+			t.Errorf("Expected frame %d to be %s, got %v", i, msgType, link.written)
+		}
+	}
+	if len(link.written) != appends+2 {
+		t.Errorf("Expected %d frames, got %d", appends+2, len(link.written))
+	}
+}
+
+func TestInterruptionLatencyOnSlowLink(t *testing.T) {
+	const appends = 10
+
+	release := make(chan struct{})
+	link := newSlowLink(release)
+	client := NewClient(ws.NewConn(link))
+	ctx := context.Background()
+
+	// The first append occupies the link until released and the rest queue behind it
+	var wg sync.WaitGroup
+	for range appends {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = client.SendAudioBufferAppend(ctx, "AAAA")
+		}()
+	}
+	waitForWaiters(t, client, appends-1)
+
+	cancelled := make(chan error, 1)
+	go func() { cancelled <- client.SendResponseCancel(ctx, "resp_1") }()
+	waitForWaiters(t, client, appends)
+
+	close(release)
+	if err := <-cancelled; err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	wg.Wait()
+
+	// The cancel waits for the frame already on the wire, not the backlog of appends
+	link.mu.Lock()
+	defer link.mu.Unlock()
+	if len(link.written) != appends+1 {
+		t.Fatalf("Expected %d frames, got %v", appends+1, link.written)
+	}
+	if link.written[0] != "input_audio_buffer.append" || link.written[1] != "response.cancel" {
+		t.Errorf("Expected the cancel to follow the append on the wire, got %v", link.written)
+	}
+	for _, msgType := range link.written[2:] {
+		if msgType != "input_audio_buffer.append" {
+			t.Errorf("Expected the queued appends after the cancel, got %v", link.written)
+			break
+		}
+	}
+}
+
+func TestSendGateContextCancelled(t *testing.T) {
+	var gate sendGate
+	if err := gate.acquire(context.Background(), false); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := gate.acquire(ctx, true); err != context.Canceled {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+	if gate.waiting() != 0 {
+		t.Errorf("Expected abandoned waiter to be removed, got %d waiting", gate.waiting())
+	}
+
+	gate.release()
+	if err := gate.acquire(context.Background(), false); err != nil {
+		t.Errorf("Expected gate to be free after release, got %v", err)
+	}
+}
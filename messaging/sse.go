@@ -0,0 +1,257 @@
+package messaging
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/Mliviu79/openai-realtime-go/messages/types"
+	"github.com/Mliviu79/openai-realtime-go/session"
+)
+
+//-----------------------------------------------------------------------------
+// Server-Sent Events Bridge
+//-----------------------------------------------------------------------------
+
+// DefaultSSEMaxPromptBytes is the largest prompt an SSEBridge accepts by default
+const DefaultSSEMaxPromptBytes = 32 << 10
+
+// SSE event names written by an SSEBridge
+const (
+	// SSEEventDelta carries a fragment of the response text as {"delta":"..."}
+	SSEEventDelta = "delta"
+	// SSEEventDone ends a successful stream as {"response_id":"...","status":"...","usage":{...}}
+	SSEEventDone = "done"
+	// SSEEventError ends a failed stream as {"error":"..."}
+	SSEEventError = "error"
+)
+
+// SSEBridgeOption configures an SSEBridge
+type SSEBridgeOption func(*SSEBridge)
+
+// WithSSEResponseConfig sets the configuration every response is created with, e.g. to set
+// instructions or a token limit. The prompt and, unless WithSSEHistory is used, the
+// conversation and input fields are filled in per request. The default requests text only.
+func WithSSEResponseConfig(config types.ResponseConfig) SSEBridgeOption {
+	return func(b *SSEBridge) {
+		b.config = config
+	}
+}
+
+// WithSSEHistory adds each prompt to the session's conversation, so later prompts see the
+// earlier exchanges. By default every prompt is answered out of band and the conversation
+// is left untouched, which keeps requests from different users independent.
+func WithSSEHistory() SSEBridgeOption {
+	return func(b *SSEBridge) {
+		b.history = true
+	}
+}
+
+// WithSSEMaxPromptBytes sets the largest prompt accepted; larger prompts are rejected
+// with 413 Request Entity Too Large. The default is DefaultSSEMaxPromptBytes.
+func WithSSEMaxPromptBytes(n int) SSEBridgeOption {
+	return func(b *SSEBridge) {
+		if n > 0 {
+			b.maxPromptBytes = n
+		}
+	}
+}
+
+// SSEBridge is an http.Handler that answers a text prompt with a realtime response and
+// streams the text to the caller as Server-Sent Events, so browsers can read streamed
+// output with EventSource or fetch instead of a WebSocket to the API.
+//
+// The prompt is read from the "prompt" query or form value, or from a JSON body of the
+// form {"prompt":"..."}. The stream consists of SSEEventDelta events followed by a
+// single SSEEventDone or SSEEventError event. The response is cancelled on the server
+// when the caller disconnects.
+//
+// The bridge reads from the client itself, so the client must not be used by a Handler
+// or other reader. Requests share the session and are answered one at a time; a request
+// waiting for its turn gives up when its context is done.
+//
+// Example:
+//
+//	bridge := messaging.NewSSEBridge(msgClient, messaging.WithSSEResponseConfig(types.ResponseConfig{
+//		Instructions: &instructions,
+//	}))
+//	http.Handle("/stream", bridge)
+type SSEBridge struct {
+	client         *Client
+	config         types.ResponseConfig
+	history        bool
+	maxPromptBytes int
+
+	// turn holds a token while a request is streaming
+	turn chan struct{}
+}
+
+// NewSSEBridge creates an SSEBridge that answers prompts on client's session
+func NewSSEBridge(client *Client, opts ...SSEBridgeOption) *SSEBridge {
+	b := &SSEBridge{
+		client:         client,
+		config:         types.ResponseConfig{Modalities: []session.Modality{session.ModalityText}},
+		maxPromptBytes: DefaultSSEMaxPromptBytes,
+		turn:           make(chan struct{}, 1),
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// ServeHTTP implements http.Handler
+func (b *SSEBridge) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		w.Header().Set("Allow", "GET, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	prompt, status, err := b.readPrompt(w, r)
+	if err != nil {
+		http.Error(w, err.Error(), status)
+		return
+	}
+
+	ctx := r.Context()
+	select {
+	case b.turn <- struct{}{}:
+		defer func() { <-b.turn }()
+	case <-ctx.Done():
+		http.Error(w, "request cancelled", http.StatusServiceUnavailable)
+		return
+	}
+
+	config, err := b.responseConfig(r, prompt)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+
+	header := w.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	for delta, err := range b.client.StreamResponse(ctx, config, WithCancelOnContextDone(0)) {
+		if err != nil {
+			if ctx.Err() == nil {
+				writeSSE(w, SSEEventError, map[string]string{"error": err.Error()})
+				flusher.Flush()
+			}
+			return
+		}
+
+		switch delta.Type {
+		case DeltaTypeText, DeltaTypeTranscript:
+			writeSSE(w, SSEEventDelta, map[string]string{"delta": delta.Data})
+		case DeltaTypeDone:
+			if delta.Response.Status.IsError() {
+				writeSSE(w, SSEEventError, map[string]string{"error": "response failed"})
+			} else {
+				writeSSE(w, SSEEventDone, sseDone{
+					ResponseID: delta.Response.ID,
+					Status:     delta.Response.Status,
+					Usage:      delta.Response.Usage,
+				})
+			}
+		default:
+			continue
+		}
+		flusher.Flush()
+	}
+}
+
+// sseDone is the payload of the SSEEventDone event
+type sseDone struct {
+	ResponseID string               `json:"response_id"`
+	Status     types.ResponseStatus `json:"status"`
+	Usage      *types.Usage         `json:"usage,omitempty"`
+}
+
+// readPrompt extracts the prompt from the request, returning the HTTP status to reply
+// with when it is missing or invalid
+func (b *SSEBridge) readPrompt(w http.ResponseWriter, r *http.Request) (string, int, error) {
+	// Leave room for the JSON or form encoding around the prompt
+	r.Body = http.MaxBytesReader(w, r.Body, int64(b.maxPromptBytes)+1024)
+
+	var prompt string
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if r.Method == http.MethodPost && mediaType == "application/json" {
+		var body struct {
+			Prompt string `json:"prompt"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				return "", http.StatusRequestEntityTooLarge, errors.New("prompt is too large")
+			}
+			if !errors.Is(err, io.EOF) {
+				return "", http.StatusBadRequest, fmt.Errorf("invalid request body: %w", err)
+			}
+		}
+		prompt = body.Prompt
+	} else {
+		if err := r.ParseForm(); err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				return "", http.StatusRequestEntityTooLarge, errors.New("prompt is too large")
+			}
+			return "", http.StatusBadRequest, fmt.Errorf("invalid request: %w", err)
+		}
+		prompt = r.FormValue("prompt")
+	}
+
+	if strings.TrimSpace(prompt) == "" {
+		return "", http.StatusBadRequest, errors.New("prompt is required")
+	}
+	if len(prompt) > b.maxPromptBytes {
+		return "", http.StatusRequestEntityTooLarge, errors.New("prompt is too large")
+	}
+	return prompt, http.StatusOK, nil
+}
+
+// responseConfig builds the response configuration for a prompt. With history the prompt
+// is added to the conversation first; otherwise it is passed as out-of-band input.
+func (b *SSEBridge) responseConfig(r *http.Request, prompt string) (*types.ResponseConfig, error) {
+	config := b.config
+	if b.history {
+		if err := b.client.SendText(r.Context(), prompt); err != nil {
+			return nil, fmt.Errorf("failed to send prompt: %w", err)
+		}
+		return &config, nil
+	}
+
+	none := "none"
+	role := types.MessageRoleUser
+	config.Conversation = &none
+	config.Input = []types.ConversationItem{{
+		Type: types.MessageItemTypeMessage,
+		Role: &role,
+		Content: []types.MessageContentPart{{
+			Type: types.MessageContentTypeInputText,
+			Text: prompt,
+		}},
+	}}
+	return &config, nil
+}
+
+// writeSSE writes a single event with a JSON payload. JSON encoding keeps the payload on
+// one line, so newlines in the text cannot break the event framing.
+func writeSSE(w io.Writer, event string, payload any) {
+	data, _ := json.Marshal(payload)
+	fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+}
@@ -0,0 +1,144 @@
+package messaging
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+// newSSETestClient returns a client replaying the given server messages and the frames it sent
+func newSSETestClient(messages ...string) (*Client, *[]string) {
+	mockConn := newScriptedConn(messages...)
+	var sent []string
+	mockConn.WriteMessageFunc = func(ctx context.Context, messageType ws.MessageType, data []byte) error {
+		sent = append(sent, string(data))
+		return nil
+	}
+	return NewClient(ws.NewConn(mockConn)), &sent
+}
+
+func TestSSEBridgeStreamsText(t *testing.T) {
+	client, sent := newSSETestClient(
+		`{"type":"response.created","response":{"id":"resp_1","status":"in_progress"}}`,
+		`{"type":"response.output_text.delta","response_id":"resp_1","item_id":"item_1","delta":"Hello\n"}`,
+		`{"type":"response.output_text.delta","response_id":"resp_1","item_id":"item_1","delta":"world"}`,
+		`{"type":"response.done","response":{"id":"resp_1","status":"completed","usage":{"total_tokens":7}}}`,
+	)
+	bridge := NewSSEBridge(client)
+
+	rec := httptest.NewRecorder()
+	bridge.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stream?prompt=Say+hello", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("Expected content type text/event-stream, got %s", ct)
+	}
+	events := strings.Split(strings.TrimSuffix(rec.Body.String(), "\n\n"), "\n\n")
+	if len(events) != 3 {
+		t.Fatalf("Expected 3 events, got %q", rec.Body.String())
+	}
+	if events[0] != "event: delta\ndata: {\"delta\":\"Hello\\n\"}" {
+		t.Errorf("Expected the first delta with an escaped newline, got %q", events[0])
+	}
+	if events[1] != "event: delta\ndata: {\"delta\":\"world\"}" {
+		t.Errorf("Expected the second delta, got %q", events[1])
+	}
+	if !strings.HasPrefix(events[2], "event: done\ndata: {\"response_id\":\"resp_1\",\"status\":\"completed\",\"usage\":{\"total_tokens\":7") {
+		t.Errorf("Expected a done event with usage, got %q", events[2])
+	}
+
+	if len(*sent) != 1 {
+		t.Fatalf("Expected only response.create to be sent, got %v", *sent)
+	}
+	for _, want := range []string{`"type":"response.create"`, `"conversation":"none"`, `"text":"Say hello"`} {
+		if !strings.Contains((*sent)[0], want) {
+			t.Errorf("Expected response.create to contain %s, got %s", want, (*sent)[0])
+		}
+	}
+}
+
+func TestSSEBridgeHistory(t *testing.T) {
+	client, sent := newSSETestClient(
+		`{"type":"response.created","response":{"id":"resp_1","status":"in_progress"}}`,
+		`{"type":"response.done","response":{"id":"resp_1","status":"completed"}}`,
+	)
+	bridge := NewSSEBridge(client, WithSSEHistory())
+
+	req := httptest.NewRequest(http.MethodPost, "/stream", strings.NewReader(`{"prompt":"Remember me"}`))
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	rec := httptest.NewRecorder()
+	bridge.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if len(*sent) != 2 {
+		t.Fatalf("Expected 2 messages to be sent, got %v", *sent)
+	}
+	if !strings.Contains((*sent)[0], `"type":"conversation.item.create"`) || !strings.Contains((*sent)[0], "Remember me") {
+		t.Errorf("Expected the prompt to be added to the conversation, got %s", (*sent)[0])
+	}
+	if strings.Contains((*sent)[1], `"conversation"`) {
+		t.Errorf("Expected an in-band response, got %s", (*sent)[1])
+	}
+}
+
+func TestSSEBridgeServerError(t *testing.T) {
+	client, _ := newSSETestClient(
+		`{"type":"error","error":{"type":"invalid_request_error","message":"bad request"}}`,
+	)
+	bridge := NewSSEBridge(client)
+
+	rec := httptest.NewRecorder()
+	bridge.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stream?prompt=hi", nil))
+
+	body := rec.Body.String()
+	if !strings.HasPrefix(body, "event: error\n") || !strings.Contains(body, "bad request") {
+		t.Errorf("Expected an error event, got %q", body)
+	}
+}
+
+func TestSSEBridgeRejectsRequests(t *testing.T) {
+	tests := []struct {
+		name        string
+		method      string
+		target      string
+		body        string
+		contentType string
+		status      int
+	}{
+		{name: "method", method: http.MethodPut, target: "/stream?prompt=hi", status: http.StatusMethodNotAllowed},
+		{name: "missing prompt", method: http.MethodGet, target: "/stream", status: http.StatusBadRequest},
+		{name: "blank prompt", method: http.MethodGet, target: "/stream?prompt=+", status: http.StatusBadRequest},
+		{name: "invalid json", method: http.MethodPost, target: "/stream", body: "{", contentType: "application/json", status: http.StatusBadRequest},
+		{name: "prompt too large", method: http.MethodGet, target: "/stream?prompt=" + strings.Repeat("a", 11), status: http.StatusRequestEntityTooLarge},
+		{name: "body too large", method: http.MethodPost, target: "/stream", body: `{"prompt":"` + strings.Repeat("a", 2048) + `"}`, contentType: "application/json", status: http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, sent := newSSETestClient()
+			bridge := NewSSEBridge(client, WithSSEMaxPromptBytes(10))
+
+			req := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			rec := httptest.NewRecorder()
+			bridge.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Errorf("Expected status %d, got %d", tt.status, rec.Code)
+			}
+			if len(*sent) != 0 {
+				t.Errorf("Expected nothing to be sent, got %v", *sent)
+			}
+		})
+	}
+}
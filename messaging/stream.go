@@ -0,0 +1,233 @@
+package messaging
+
+import (
+	"context"
+	"iter"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+	"github.com/Mliviu79/openai-realtime-go/messages/types"
+)
+
+//-----------------------------------------------------------------------------
+// Response Streaming
+//-----------------------------------------------------------------------------
+
+// DeltaType identifies the kind of fragment carried by a Delta
+type DeltaType string
+
+const (
+	// DeltaTypeText is a fragment of text output (response.output_text.delta)
+	DeltaTypeText DeltaType = "text"
+
+	// DeltaTypeAudio is a fragment of base64-encoded audio output (response.output_audio.delta)
+	DeltaTypeAudio DeltaType = "audio"
+
+	// DeltaTypeTranscript is a fragment of the audio output transcript (response.output_audio_transcript.delta)
+	DeltaTypeTranscript DeltaType = "transcript"
+
+	// DeltaTypeFunctionArguments is a fragment of function call arguments (response.function_call_arguments.delta)
+	DeltaTypeFunctionArguments DeltaType = "function_arguments"
+
+	// DeltaTypeDone is yielded once when the response completes and carries the final response
+	DeltaTypeDone DeltaType = "done"
+)
+
+// Delta is a single fragment of a streamed response
+type Delta struct {
+	// Type identifies what kind of fragment this is
+	Type DeltaType
+
+	// ResponseID identifies the response this fragment belongs to
+	ResponseID string
+
+	// ItemID identifies the output item this fragment belongs to
+	ItemID string
+
+	// OutputIndex is the position of the output item within the response
+	OutputIndex int
+
+	// ContentIndex is the position of the content part within the item.
+	// It is zero for function call arguments.
+	ContentIndex int
+
+	// CallID identifies the function call for DeltaTypeFunctionArguments
+	CallID string
+
+	// Data is the fragment itself: text, transcript, JSON arguments,
+	// or base64-encoded audio depending on Type
+	Data string
+
+	// Response is the final state of the response for DeltaTypeDone, nil otherwise
+	Response *types.Response
+}
+
+// DefaultCancelTimeout bounds how long StreamResponse waits to send response.cancel
+// after the caller's context is cancelled
+const DefaultCancelTimeout = 5 * time.Second
+
+// StreamOption configures StreamResponse
+type StreamOption func(*streamOptions)
+
+// streamOptions holds the configuration for a single StreamResponse call
+type streamOptions struct {
+	cancelOnContextDone bool
+	cancelTimeout       time.Duration
+}
+
+// WithCancelOnContextDone makes StreamResponse send response.cancel for the in-flight
+// response when the context passed to it is cancelled, so the server stops generating
+// (and billing for) output nobody will read. The cancel is sent on a detached context
+// bounded by timeout; a timeout of zero uses DefaultCancelTimeout. If the context is
+// cancelled before response.created names the response, the cancel omits the ID and the
+// server cancels the default conversation's in-progress response. An ID-less cancel
+// could hit an unrelated turn, so no cancel is sent for an out-of-band response (a
+// conversation of "none") whose ID is not known yet.
+func WithCancelOnContextDone(timeout time.Duration) StreamOption {
+	return func(o *streamOptions) {
+		o.cancelOnContextDone = true
+		if timeout > 0 {
+			o.cancelTimeout = timeout
+		}
+	}
+}
+
+// StreamResponse sends a response.create message with the given configuration and
+// returns an iterator over the deltas of the resulting response.
+//
+// The iterator reads messages from the connection itself, so it must not be used
+// while another goroutine (such as a Handler) is reading from the same client.
+// Deltas belonging to other responses are skipped. Iteration ends after a
+// DeltaTypeDone delta, when the server reports an error, when reading fails,
+// or when the caller stops ranging. Server errors are yielded as *apierrs.APIError.
+//
+// By default a cancelled context only stops reading; use WithCancelOnContextDone
+// to also cancel the response on the server.
+//
+// Example:
+//
+//	for delta, err := range msgClient.StreamResponse(ctx, &types.ResponseConfig{}) {
+//		if err != nil {
+//			return err
+//		}
+//		if delta.Type == messaging.DeltaTypeText {
+//			fmt.Print(delta.Data)
+//		}
+//	}
+func (c *Client) StreamResponse(ctx context.Context, config *types.ResponseConfig, opts ...StreamOption) iter.Seq2[Delta, error] {
+	options := streamOptions{cancelTimeout: DefaultCancelTimeout}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	return func(yield func(Delta, error) bool) {
+		if err := c.SendResponseCreate(ctx, config); err != nil {
+			yield(Delta{}, err)
+			return
+		}
+
+		var responseID string
+		for {
+			msg, err := c.ReadMessage(ctx)
+			if err != nil {
+				if ctx.Err() != nil && options.cancelOnContextDone && (responseID != "" || !outOfBand(config)) {
+					c.cancelAbandonedResponse(ctx, responseID, options.cancelTimeout)
+				}
+				yield(Delta{}, err)
+				return
+			}
+
+			if errMsg, ok := msg.(*incoming.ErrorMessage); ok {
+				yield(Delta{}, errMsg.AsAPIError())
+				return
+			}
+
+			if created, ok := msg.(*incoming.ResponseCreatedMessage); ok {
+				if responseID == "" {
+					responseID = created.Response.ID
+				}
+				continue
+			}
+
+			// Anything before our response.created belongs to an earlier response
+			if responseID == "" {
+				continue
+			}
+
+			delta, ok := deltaFromMessage(msg)
+			if !ok || delta.ResponseID != responseID {
+				continue
+			}
+
+			if !yield(delta, nil) || delta.Type == DeltaTypeDone {
+				return
+			}
+		}
+	}
+}
+
+// cancelAbandonedResponse sends response.cancel for a response whose caller has gone away.
+// The parent context is already done, so the cancel is sent on a detached context. An
+// empty responseID cancels the server's in-progress response.
+func (c *Client) cancelAbandonedResponse(parent context.Context, responseID string, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(parent), timeout)
+	defer cancel()
+
+	if err := c.SendResponseCancel(ctx, responseID); err != nil {
+		if log := c.getLogger(); log != nil {
+			log.Warnf("Failed to cancel response %q after context cancellation: %v", responseID, err)
+		}
+	}
+}
+
+// deltaFromMessage converts a response streaming message into a Delta.
+// It returns false for messages that do not carry a delta.
+func deltaFromMessage(msg incoming.RcvdMsg) (Delta, bool) {
+	switch m := msg.(type) {
+	case *incoming.ResponseOutputTextDeltaMessage:
+		return Delta{
+			Type:         DeltaTypeText,
+			ResponseID:   m.ResponseID,
+			ItemID:       m.ItemID,
+			OutputIndex:  m.OutputIndex,
+			ContentIndex: m.ContentIndex,
+			Data:         m.Delta,
+		}, true
+	case *incoming.ResponseOutputAudioDeltaMessage:
+		return Delta{
+			Type:         DeltaTypeAudio,
+			ResponseID:   m.ResponseID,
+			ItemID:       m.ItemID,
+			OutputIndex:  m.OutputIndex,
+			ContentIndex: m.ContentIndex,
+			Data:         m.EncodedAudio(),
+		}, true
+	case *incoming.ResponseOutputAudioTranscriptDeltaMessage:
+		return Delta{
+			Type:         DeltaTypeTranscript,
+			ResponseID:   m.ResponseID,
+			ItemID:       m.ItemID,
+			OutputIndex:  m.OutputIndex,
+			ContentIndex: m.ContentIndex,
+			Data:         m.Delta,
+		}, true
+	case *incoming.ResponseFunctionCallArgumentsDeltaMessage:
+		return Delta{
+			Type:        DeltaTypeFunctionArguments,
+			ResponseID:  m.ResponseID,
+			ItemID:      m.ItemID,
+			OutputIndex: m.OutputIndex,
+			CallID:      m.CallID,
+			Data:        m.Delta,
+		}, true
+	case *incoming.ResponseDoneMessage:
+		response := m.Response
+		return Delta{
+			Type:       DeltaTypeDone,
+			ResponseID: m.Response.ID,
+			Response:   &response,
+		}, true
+	default:
+		return Delta{}, false
+	}
+}
@@ -0,0 +1,248 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/apierrs"
+	"github.com/Mliviu79/openai-realtime-go/messages/types"
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+// newScriptedConn returns a MockConn that replays the given server messages in order
+// and fails with an error once they are exhausted
+func newScriptedConn(messages ...string) *MockConn {
+	return &MockConn{
+		ReadMessageFunc: func(ctx context.Context) (ws.MessageType, []byte, error) {
+			if len(messages) == 0 {
+				return ws.MessageText, nil, errors.New("no more messages")
+			}
+			data := messages[0]
+			messages = messages[1:]
+			return ws.MessageText, []byte(data), nil
+		},
+	}
+}
+
+func TestStreamResponse(t *testing.T) {
+	mockConn := newScriptedConn(
+		`{"type":"response.output_text.delta","response_id":"resp_old","item_id":"item_0","delta":"stale"}`,
+		`{"type":"response.created","response":{"id":"resp_1","status":"in_progress"}}`,
+		`{"type":"response.output_text.delta","response_id":"resp_1","item_id":"item_1","delta":"Hello"}`,
+		`{"type":"response.output_text.delta","response_id":"resp_other","item_id":"item_2","delta":"ignored"}`,
+		`{"type":"response.function_call_arguments.delta","response_id":"resp_1","item_id":"item_3","call_id":"call_1","delta":"{\"a\":"}`,
+		`{"type":"response.output_text.delta","response_id":"resp_1","item_id":"item_1","delta":" world"}`,
+		`{"type":"response.done","response":{"id":"resp_1","status":"completed"}}`,
+	)
+	var sent []string
+	mockConn.WriteMessageFunc = func(ctx context.Context, messageType ws.MessageType, data []byte) error {
+		sent = append(sent, string(data))
+		return nil
+	}
+	client := NewClient(ws.NewConn(mockConn))
+
+	var text strings.Builder
+	var args string
+	var done bool
+	for delta, err := range client.StreamResponse(context.Background(), &types.ResponseConfig{}) {
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		switch delta.Type {
+		case DeltaTypeText:
+			text.WriteString(delta.Data)
+		case DeltaTypeFunctionArguments:
+			if delta.CallID != "call_1" {
+				t.Errorf("Expected call ID call_1, got %s", delta.CallID)
+			}
+			args += delta.Data
+		case DeltaTypeDone:
+			done = true
+			if delta.Response == nil || delta.Response.ID != "resp_1" {
+				t.Errorf("Expected final response resp_1, got %+v", delta.Response)
+			}
+		}
+	}
+
+	if len(sent) != 1 || !strings.Contains(sent[0], `"type":"response.create"`) {
+		t.Errorf("Expected a single response.create to be sent, got %v", sent)
+	}
+	if text.String() != "Hello world" {
+		t.Errorf("Expected text 'Hello world', got %q", text.String())
+	}
+	if args != `{"a":` {
+		t.Errorf("Expected arguments fragment, got %q", args)
+	}
+	if !done {
+		t.Error("Expected a done delta")
+	}
+}
+
+func TestStreamResponseServerError(t *testing.T) {
+	mockConn := newScriptedConn(
+		`{"type":"error","event_id":"evt_1","error":{"type":"invalid_request_error","message":"bad request"}}`,
+	)
+	client := NewClient(ws.NewConn(mockConn))
+
+	var gotErr error
+	for _, err := range client.StreamResponse(context.Background(), &types.ResponseConfig{}) {
+		gotErr = err
+	}
+
+	if !apierrs.IsAPIError(gotErr) {
+		t.Fatalf("Expected API error, got %v", gotErr)
+	}
+	if !strings.Contains(gotErr.Error(), "bad request") {
+		t.Errorf("Expected error message to be preserved, got %v", gotErr)
+	}
+}
+
+func TestStreamResponseEarlyBreak(t *testing.T) {
+	mockConn := newScriptedConn(
+		`{"type":"response.created","response":{"id":"resp_1"}}`,
+		`{"type":"response.output_text.delta","response_id":"resp_1","delta":"a"}`,
+		`{"type":"response.output_text.delta","response_id":"resp_1","delta":"b"}`,
+	)
+	client := NewClient(ws.NewConn(mockConn))
+
+	count := 0
+	for _, err := range client.StreamResponse(context.Background(), &types.ResponseConfig{}) {
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		count++
+		break
+	}
+
+	if count != 1 {
+		t.Errorf("Expected 1 delta, got %d", count)
+	}
+}
+
+func TestStreamResponseCancelOnContextDone(t *testing.T) {
+	tests := []struct {
+		name           string
+		opts           []StreamOption
+		expectedCancel bool
+	}{
+		{name: "WithOption", opts: []StreamOption{WithCancelOnContextDone(time.Second)}, expectedCancel: true},
+		{name: "WithoutOption", opts: nil, expectedCancel: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			reads := 0
+			mockConn := &MockConn{
+				ReadMessageFunc: func(ctx context.Context) (ws.MessageType, []byte, error) {
+					reads++
+					switch reads {
+					case 1:
+						return ws.MessageText, []byte(`{"type":"response.created","response":{"id":"resp_1"}}`), nil
+					case 2:
+						return ws.MessageText, []byte(`{"type":"response.output_text.delta","response_id":"resp_1","delta":"partial"}`), nil
+					default:
+						<-ctx.Done()
+						return ws.MessageText, nil, ctx.Err()
+					}
+				},
+			}
+			var sent []string
+			mockConn.WriteMessageFunc = func(ctx context.Context, messageType ws.MessageType, data []byte) error {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				sent = append(sent, string(data))
+				return nil
+			}
+			client := NewClient(ws.NewConn(mockConn))
+
+			var gotErr error
+			for delta, err := range client.StreamResponse(ctx, &types.ResponseConfig{}, tt.opts...) {
+				if err != nil {
+					gotErr = err
+					continue
+				}
+				if delta.Type == DeltaTypeText {
+					// The caller gives up after the first fragment
+					cancel()
+				}
+			}
+
+			if !errors.Is(gotErr, context.Canceled) {
+				t.Errorf("Expected context.Canceled, got %v", gotErr)
+			}
+
+			cancelled := len(sent) == 2 && strings.Contains(sent[1], `"type":"response.cancel"`) && strings.Contains(sent[1], "resp_1")
+			if cancelled != tt.expectedCancel {
+				t.Errorf("Expected cancel sent to be %v, got messages %v", tt.expectedCancel, sent)
+			}
+		})
+	}
+}
+
+func TestStreamResponseCancelBeforeCreated(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mockConn := &MockConn{
+		ReadMessageFunc: func(ctx context.Context) (ws.MessageType, []byte, error) {
+			<-ctx.Done()
+			return ws.MessageText, nil, ctx.Err()
+		},
+	}
+	var sent []string
+	mockConn.WriteMessageFunc = func(ctx context.Context, messageType ws.MessageType, data []byte) error {
+		sent = append(sent, string(data))
+		if len(sent) == 1 {
+			// The caller gives up before the server reports the response created
+			cancel()
+		}
+		return nil
+	}
+	client := NewClient(ws.NewConn(mockConn))
+
+	var gotErr error
+	for _, err := range client.StreamResponse(ctx, &types.ResponseConfig{}, WithCancelOnContextDone(time.Second)) {
+		gotErr = err
+	}
+
+	if !errors.Is(gotErr, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", gotErr)
+	}
+	if len(sent) != 2 || sent[1] != `{"type":"response.cancel"}` {
+		t.Errorf("Expected a response.cancel without an ID, got %v", sent)
+	}
+}
+
+func TestStreamResponseOutOfBandCancelBeforeCreated(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mockConn := &MockConn{
+		ReadMessageFunc: func(ctx context.Context) (ws.MessageType, []byte, error) {
+			<-ctx.Done()
+			return ws.MessageText, nil, ctx.Err()
+		},
+	}
+	var sent []string
+	mockConn.WriteMessageFunc = func(ctx context.Context, messageType ws.MessageType, data []byte) error {
+		sent = append(sent, string(data))
+		cancel()
+		return nil
+	}
+	client := NewClient(ws.NewConn(mockConn))
+
+	// An ID-less cancel would hit the default conversation's response instead
+	none := "none"
+	for range client.StreamResponse(ctx, &types.ResponseConfig{Conversation: &none}, WithCancelOnContextDone(time.Second)) {
+	}
+	if len(sent) != 1 {
+		t.Errorf("Expected no cancel for an out-of-band response without an ID, got %v", sent)
+	}
+}
@@ -0,0 +1,199 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+)
+
+//-----------------------------------------------------------------------------
+// Subtitle Export
+//-----------------------------------------------------------------------------
+
+// DefaultMaxCueChars is the default maximum length of a subtitle cue, one comfortable line
+const DefaultMaxCueChars = 42
+
+// SubtitleCue is a caption shown from Start to End of the recorded output audio
+type SubtitleCue struct {
+	Start time.Duration
+	End   time.Duration
+	Text  string
+}
+
+// subtitleItem is the audio and transcript of one output item
+type subtitleItem struct {
+	audioBytes int
+	transcript strings.Builder
+	done       bool
+}
+
+// SubtitleExporterOption configures a SubtitleExporter
+type SubtitleExporterOption func(*SubtitleExporter)
+
+// WithMaxCueChars sets the maximum length of a cue; the default is DefaultMaxCueChars.
+// A single word longer than the maximum gets a cue of its own.
+func WithMaxCueChars(n int) SubtitleExporterOption {
+	return func(e *SubtitleExporter) {
+		if n > 0 {
+			e.maxCueChars = n
+		}
+	}
+}
+
+// SubtitleExporter converts assistant audio transcripts into SRT or WebVTT subtitles for
+// the recorded output audio, for producing captioned recordings of calls.
+//
+// The subtitles are timed against a recording made by appending every
+// response.output_audio.delta in the order received: each item starts where the audio
+// of the previous item ended, and its transcript is spread over its audio in proportion
+// to length. Cues break at sentence ends and before they exceed the maximum length.
+//
+// Example:
+//
+//	subtitles := messaging.NewSubtitleExporter()
+//	handler := messaging.NewHandler(ctx, msgClient, subtitles.Handle, recordAudio)
+//	// ...
+//	err := subtitles.WriteVTT(file)
+type SubtitleExporter struct {
+	maxCueChars int
+
+	mu          sync.Mutex
+	outputAudio outputAudioFormat
+	items       []*subtitleItem
+	byID        map[string]*subtitleItem
+}
+
+// NewSubtitleExporter creates an empty SubtitleExporter
+func NewSubtitleExporter(opts ...SubtitleExporterOption) *SubtitleExporter {
+	e := &SubtitleExporter{
+		maxCueChars: DefaultMaxCueChars,
+		byID:        make(map[string]*subtitleItem),
+	}
+	for _, opt := range opts {
+		opt(e)
+	}
+	return e
+}
+
+// Handle collects the assistant's audio transcripts and counts their audio, which sets
+// the cue timing. The final transcript of an item replaces its deltas.
+func (e *SubtitleExporter) Handle(ctx context.Context, msg incoming.RcvdMsg) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	switch m := msg.(type) {
+	case *incoming.SessionCreatedMessage, *incoming.SessionUpdatedMessage:
+		e.outputAudio.observe(msg)
+	case *incoming.ResponseOutputAudioDeltaMessage:
+		e.item(m.ItemID).audioBytes += m.AudioLen()
+	case *incoming.ResponseOutputAudioTranscriptDeltaMessage:
+		if item := e.item(m.ItemID); !item.done {
+			item.transcript.WriteString(m.Delta)
+		}
+	case *incoming.ResponseOutputAudioTranscriptDoneMessage:
+		// The final transcript replaces the deltas
+		item := e.item(m.ItemID)
+		item.transcript.Reset()
+		item.transcript.WriteString(m.Transcript)
+		item.done = true
+	}
+}
+
+// Cues returns the subtitle cues for the audio received so far
+func (e *SubtitleExporter) Cues() []SubtitleCue {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	var cues []SubtitleCue
+	var offset time.Duration
+	for _, item := range e.items {
+		duration := e.outputAudio.duration(item.audioBytes)
+		if duration > 0 {
+			cues = append(cues, e.itemCues(item.transcript.String(), offset, duration)...)
+		}
+		offset += duration
+	}
+	return cues
+}
+
+// WriteSRT writes the cues in SubRip (.srt) format
+func (e *SubtitleExporter) WriteSRT(w io.Writer) error {
+	var b strings.Builder
+	for i, cue := range e.Cues() {
+		fmt.Fprintf(&b, "%d\n%s --> %s\n%s\n\n", i+1, subtitleTime(cue.Start, ','), subtitleTime(cue.End, ','), cue.Text)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteVTT writes the cues in WebVTT (.vtt) format
+func (e *SubtitleExporter) WriteVTT(w io.Writer) error {
+	var b strings.Builder
+	b.WriteString("WEBVTT\n\n")
+	for _, cue := range e.Cues() {
+		fmt.Fprintf(&b, "%s --> %s\n%s\n\n", subtitleTime(cue.Start, '.'), subtitleTime(cue.End, '.'), cue.Text)
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// itemCues splits the transcript of an item into cues spread over its audio.
+// The caller must hold e.mu.
+func (e *SubtitleExporter) itemCues(transcript string, start, duration time.Duration) []SubtitleCue {
+	var lines []string
+	var line string
+	for _, word := range strings.Fields(transcript) {
+		if line != "" && len(line)+1+len(word) > e.maxCueChars {
+			lines = append(lines, line)
+			line = ""
+		}
+		if line == "" {
+			line = word
+		} else {
+			line += " " + word
+		}
+		if strings.ContainsAny(word[len(word)-1:], ".?!") {
+			lines = append(lines, line)
+			line = ""
+		}
+	}
+	if line != "" {
+		lines = append(lines, line)
+	}
+
+	total := 0
+	for _, line := range lines {
+		total += len(line)
+	}
+	cues := make([]SubtitleCue, 0, len(lines))
+	chars := 0
+	for _, line := range lines {
+		cue := SubtitleCue{Start: start + duration*time.Duration(chars)/time.Duration(total), Text: line}
+		chars += len(line)
+		cue.End = start + duration*time.Duration(chars)/time.Duration(total)
+		cues = append(cues, cue)
+	}
+	return cues
+}
+
+// item returns the item with the given ID, adding it if needed. The caller must hold e.mu.
+func (e *SubtitleExporter) item(id string) *subtitleItem {
+	item, ok := e.byID[id]
+	if !ok {
+		item = &subtitleItem{}
+		e.byID[id] = item
+		e.items = append(e.items, item)
+	}
+	return item
+}
+
+// subtitleTime formats d as HH:MM:SS followed by sep and milliseconds
+func subtitleTime(d time.Duration, sep byte) string {
+	ms := d.Milliseconds()
+	return fmt.Sprintf("%02d:%02d:%02d%c%03d", ms/3600000, ms/60000%60, ms/1000%60, sep, ms%1000)
+}
@@ -0,0 +1,103 @@
+package messaging
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+)
+
+// subtitledCall is a session with two assistant replies of one and two seconds
+func subtitledCall(t *testing.T, opts ...SubtitleExporterOption) *SubtitleExporter {
+	subtitles := NewSubtitleExporter(opts...)
+	oneSecond := base64.StdEncoding.EncodeToString(make([]byte, 48000))
+
+	ctx := context.Background()
+	handle := func(msg string) { subtitles.Handle(ctx, mustParse(t, msg)) }
+	handle(`{"type":"response.output_audio_transcript.delta","response_id":"resp_1","item_id":"item_1","output_index":0,"content_index":0,"delta":"Hello there. "}`)
+	handle(`{"type":"response.output_audio.delta","response_id":"resp_1","item_id":"item_1","delta":"` + oneSecond + `"}`)
+	handle(`{"type":"response.output_audio_transcript.delta","response_id":"resp_1","item_id":"item_1","output_index":0,"content_index":0,"delta":"How can I help?"}`)
+	handle(`{"type":"response.output_audio_transcript.done","response_id":"resp_1","item_id":"item_1","output_index":0,"content_index":0,"transcript":"Hello there. How can I help?"}`)
+
+	handle(`{"type":"response.output_audio_transcript.delta","response_id":"resp_2","item_id":"item_2","output_index":0,"content_index":0,"delta":"Sure"}`)
+	handle(`{"type":"response.output_audio.delta","response_id":"resp_2","item_id":"item_2","delta":"` + oneSecond + `"}`)
+	handle(`{"type":"response.output_audio.delta","response_id":"resp_2","item_id":"item_2","delta":"` + oneSecond + `"}`)
+	return subtitles
+}
+
+func TestSubtitleExporterCues(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []SubtitleExporterOption
+		expected []SubtitleCue
+	}{
+		{
+			name: "sentences",
+			expected: []SubtitleCue{
+				{Start: 0, End: 1000 * time.Millisecond * 12 / 27, Text: "Hello there."},
+				{Start: 1000 * time.Millisecond * 12 / 27, End: time.Second, Text: "How can I help?"},
+				{Start: time.Second, End: 3 * time.Second, Text: "Sure"},
+			},
+		},
+		{
+			name: "short cues",
+			opts: []SubtitleExporterOption{WithMaxCueChars(8)},
+			expected: []SubtitleCue{
+				{Start: 0, End: 1000 * time.Millisecond * 5 / 25, Text: "Hello"},
+				{Start: 1000 * time.Millisecond * 5 / 25, End: 1000 * time.Millisecond * 11 / 25, Text: "there."},
+				{Start: 1000 * time.Millisecond * 11 / 25, End: 1000 * time.Millisecond * 18 / 25, Text: "How can"},
+				{Start: 1000 * time.Millisecond * 18 / 25, End: time.Second, Text: "I help?"},
+				{Start: time.Second, End: 3 * time.Second, Text: "Sure"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cues := subtitledCall(t, tt.opts...).Cues()
+			if len(cues) != len(tt.expected) {
+				t.Fatalf("Expected %d cues, got %+v", len(tt.expected), cues)
+			}
+			for i, want := range tt.expected {
+				if cues[i] != want {
+					t.Errorf("Expected cue %d to be %+v, got %+v", i, want, cues[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSubtitleExporterFormats(t *testing.T) {
+	subtitles := subtitledCall(t)
+
+	var srt strings.Builder
+	if err := subtitles.WriteSRT(&srt); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expectedSRT := "1\n00:00:00,000 --> 00:00:00,444\nHello there.\n\n" +
+		"2\n00:00:00,444 --> 00:00:01,000\nHow can I help?\n\n" +
+		"3\n00:00:01,000 --> 00:00:03,000\nSure\n\n"
+	if srt.String() != expectedSRT {
+		t.Errorf("Expected SRT %q, got %q", expectedSRT, srt.String())
+	}
+
+	var vtt strings.Builder
+	if err := subtitles.WriteVTT(&vtt); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expectedVTT := "WEBVTT\n\n" +
+		"00:00:00.000 --> 00:00:00.444\nHello there.\n\n" +
+		"00:00:00.444 --> 00:00:01.000\nHow can I help?\n\n" +
+		"00:00:01.000 --> 00:00:03.000\nSure\n\n"
+	if vtt.String() != expectedVTT {
+		t.Errorf("Expected VTT %q, got %q", expectedVTT, vtt.String())
+	}
+}
+
+func TestSubtitleTime(t *testing.T) {
+	got := subtitleTime(time.Hour+2*time.Minute+3*time.Second+45*time.Millisecond, ',')
+	if got != "01:02:03,045" {
+		t.Errorf("Expected 01:02:03,045, got %s", got)
+	}
+}
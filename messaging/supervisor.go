@@ -0,0 +1,454 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/apierrs"
+	"github.com/Mliviu79/openai-realtime-go/clock"
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+//-----------------------------------------------------------------------------
+// Session Supervisor
+//-----------------------------------------------------------------------------
+
+// DefaultHistoryAckTimeout is how long the supervisor waits for each re-imported item to be acknowledged
+const DefaultHistoryAckTimeout = 10 * time.Second
+
+// circuitPollInterval is how often the supervisor checks a half-open circuit whose probe is in flight
+const circuitPollInterval = 100 * time.Millisecond
+
+// ErrConnectionLost is returned when a supervised session's connection stops without an error
+var ErrConnectionLost = errors.New("connection lost")
+
+// LifecycleEventKind identifies a supervised session lifecycle event
+type LifecycleEventKind string
+
+const (
+	// LifecycleStarted is emitted when a session is connected
+	LifecycleStarted LifecycleEventKind = "started"
+	// LifecycleRestored is emitted when the conversation history was re-imported into a new session
+	LifecycleRestored LifecycleEventKind = "restored"
+	// LifecycleResent is emitted when unacknowledged events were resent to a new session
+	LifecycleResent LifecycleEventKind = "resent"
+	// LifecycleFailed is emitted when a session fails, panics or loses its connection
+	LifecycleFailed LifecycleEventKind = "failed"
+	// LifecycleRestarting is emitted before waiting to restart a failed session
+	LifecycleRestarting LifecycleEventKind = "restarting"
+	// LifecycleStopped is emitted when the session function returns without an error
+	LifecycleStopped LifecycleEventKind = "stopped"
+	// LifecycleGaveUp is emitted when the restart policy does not allow another restart
+	LifecycleGaveUp LifecycleEventKind = "gave_up"
+	// LifecycleCircuitOpen is emitted before waiting for an open circuit breaker to allow a connection
+	LifecycleCircuitOpen LifecycleEventKind = "circuit_open"
+)
+
+// LifecycleEvent describes a supervised session lifecycle event
+type LifecycleEvent struct {
+	// Kind is the type of event
+	Kind LifecycleEventKind
+	// Restarts is the number of restarts so far, zero for the first session
+	Restarts int
+	// Delay is the wait before the restart, for LifecycleRestarting and LifecycleCircuitOpen events
+	Delay time.Duration
+	// Items is the number of re-imported items, for LifecycleRestored events, or of
+	// resent events, for LifecycleResent events
+	Items int
+	// Err is the error that ended the session, if any
+	Err error
+}
+
+// RestartPolicy controls how often and how quickly a failed session is restarted
+type RestartPolicy struct {
+	MaxRestarts int           // Maximum number of restarts; negative means no limit
+	Delay       time.Duration // Delay before the first restart, doubled for each further restart
+	MaxDelay    time.Duration // Maximum delay between restarts
+
+	// ResetAfter is how long a session must run before its failure no longer counts
+	// against MaxRestarts: the restart count and the delay start over, so a session
+	// that fails once a day is not given up on after a week. Zero never resets them.
+	ResetAfter time.Duration
+}
+
+// DefaultRestartPolicy returns a restart policy with sensible defaults
+func DefaultRestartPolicy() RestartPolicy {
+	return RestartPolicy{
+		MaxRestarts: 5,
+		Delay:       1 * time.Second,
+		MaxDelay:    30 * time.Second,
+		ResetAfter:  5 * time.Minute,
+	}
+}
+
+// delay returns the wait before the given restart, starting at 1
+func (p RestartPolicy) delay(restart int) time.Duration {
+	delay := p.Delay
+	for i := 1; i < restart && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if p.MaxDelay > 0 && delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	return delay
+}
+
+// SupervisedFunc drives a supervised session. Returning nil stops the supervisor;
+// returning an error, panicking or losing the connection restarts the session.
+type SupervisedFunc func(ctx context.Context, client *Client) error
+
+// SupervisorOption configures a Supervisor
+type SupervisorOption func(*Supervisor)
+
+// WithRestartPolicy sets the restart policy
+func WithRestartPolicy(policy RestartPolicy) SupervisorOption {
+	return func(s *Supervisor) {
+		s.policy = policy
+	}
+}
+
+// WithOnLifecycleEvent sets a function that is called with each lifecycle event.
+// It is called synchronously from the supervisor and must not block.
+func WithOnLifecycleEvent(onEvent func(LifecycleEvent)) SupervisorOption {
+	return func(s *Supervisor) {
+		s.onEvent = onEvent
+	}
+}
+
+// WithSupervisorHandlers registers message handlers with the Handler of every session
+func WithSupervisorHandlers(handlers ...MessageHandler) SupervisorOption {
+	return func(s *Supervisor) {
+		s.handlers = append(s.handlers, handlers...)
+	}
+}
+
+// WithSupervisorMetricsHook reports restarts as reconnect_attempt and reconnect_give_up events
+func WithSupervisorMetricsHook(hook ws.MetricsHook) SupervisorOption {
+	return func(s *Supervisor) {
+		s.metrics = hook
+	}
+}
+
+// WithCircuitBreaker guards connection attempts with a circuit breaker. While the circuit
+// is open the supervisor waits instead of dialing; the wait does not count as a restart.
+// The breaker may be shared with other supervisors connecting to the same API.
+func WithCircuitBreaker(breaker *CircuitBreaker) SupervisorOption {
+	return func(s *Supervisor) {
+		s.breaker = breaker
+	}
+}
+
+// WithResendUnacked tracks the confirmations of outgoing events with tracker and, after
+// a restart, resends the conversation item creates and session updates the previous
+// session never confirmed, so a brief disconnect does not drop a user turn. Events keep
+// their event_id and item ID, so a resent item the server did receive is rejected rather
+// than added twice. Audio appends are never resent, and other unconfirmed events fail
+// with ErrConnectionLost.
+func WithResendUnacked(tracker *ReceiptTracker) SupervisorOption {
+	return func(s *Supervisor) {
+		s.receipts = tracker
+	}
+}
+
+// WithSupervisorIDGenerator sets the generator of the event and item IDs assigned by the
+// clients of every session, e.g. NewSequentialIDs for stable output in tests
+func WithSupervisorIDGenerator(ids IDGenerator) SupervisorOption {
+	return func(s *Supervisor) {
+		s.ids = ids
+	}
+}
+
+// WithSupervisorClock sets the clock used for restart delays and circuit breaker waits,
+// e.g. a clock.Fake in tests. The default is the system clock.
+func WithSupervisorClock(c clock.Clock) SupervisorOption {
+	return func(s *Supervisor) {
+		if c != nil {
+			s.clock = c
+		}
+	}
+}
+
+// Supervisor keeps a long-lived session running. When the session function fails, panics
+// or the connection is lost, it opens a new session with dial, re-imports the conversation
+// history recorded so far and runs the session function again, according to its restart policy.
+// Permanent errors (see apierrs.Permanent) are not restarted.
+//
+// Transcription sessions are supervised the same way. Their history is not re-imported,
+// since transcription sessions do not accept conversation items; unacknowledged
+// transcription session updates are still resent with WithResendUnacked.
+//
+// The dial function typically creates a session and connects to it:
+//
+//	sup := messaging.NewSupervisor(func(ctx context.Context) (*ws.Conn, error) {
+//		created, err := client.CreateSession(ctx, req)
+//		if err != nil {
+//			return nil, err
+//		}
+//		return client.Connect(ctx, openaiClient.WithModel(model), openaiClient.WithSessionID(created.ID))
+//	}, assistant)
+//	err := sup.Run(ctx)
+type Supervisor struct {
+	dial     DialFunc
+	run      SupervisedFunc
+	policy   RestartPolicy
+	onEvent  func(LifecycleEvent)
+	handlers []MessageHandler
+	metrics  ws.MetricsHook
+	breaker  *CircuitBreaker
+	history  *ConversationHistory
+	receipts *ReceiptTracker
+	ids      IDGenerator
+	clock    clock.Clock
+
+	mu     sync.Mutex
+	client *Client
+
+	// transcription is set once a session reports itself as a transcription session,
+	// whose history cannot be re-imported
+	transcription bool
+}
+
+// NewSupervisor creates a Supervisor that opens sessions with dial and drives them with run
+func NewSupervisor(dial DialFunc, run SupervisedFunc, opts ...SupervisorOption) *Supervisor {
+	s := &Supervisor{
+		dial:    dial,
+		run:     run,
+		policy:  DefaultRestartPolicy(),
+		history: NewConversationHistory(),
+		clock:   clock.Real(),
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// History returns the conversation history that is re-imported on restart
+func (s *Supervisor) History() *ConversationHistory {
+	return s.history
+}
+
+// Client returns the client of the current session, or nil if no session is running
+func (s *Supervisor) Client() *Client {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.client
+}
+
+// Run runs the session until the session function returns nil, the context is done,
+// a permanent error occurs or the restart policy gives up. It returns the last error.
+func (s *Supervisor) Run(ctx context.Context) error {
+	// failures counts the restarts since the last session that ran for ResetAfter
+	failures := 0
+	for restarts := 0; ; restarts++ {
+		ran, err := s.runSession(ctx, restarts)
+		if err == nil {
+			s.emit(LifecycleEvent{Kind: LifecycleStopped, Restarts: restarts})
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		s.emit(LifecycleEvent{Kind: LifecycleFailed, Restarts: restarts, Err: err})
+
+		if s.policy.ResetAfter > 0 && ran >= s.policy.ResetAfter {
+			failures = 0
+		}
+		if apierrs.IsPermanent(err) || (s.policy.MaxRestarts >= 0 && failures >= s.policy.MaxRestarts) {
+			s.emit(LifecycleEvent{Kind: LifecycleGaveUp, Restarts: restarts, Err: err})
+			s.record(ws.ConnEvent{Kind: ws.ConnEventReconnectGiveUp, Reason: restartReason(err), Attempt: failures, Err: err})
+			return err
+		}
+
+		failures++
+		delay := s.policy.delay(failures)
+		s.emit(LifecycleEvent{Kind: LifecycleRestarting, Restarts: restarts + 1, Delay: delay, Err: err})
+		if err := clock.Sleep(ctx, s.clock, delay); err != nil {
+			return err
+		}
+		s.record(ws.ConnEvent{Kind: ws.ConnEventReconnectAttempt, Reason: restartReason(err), Attempt: failures, Err: err})
+	}
+}
+
+// runSession connects a new session, restores the history and runs the session function.
+// It returns how long the session ran, zero if it could not connect.
+func (s *Supervisor) runSession(ctx context.Context, restarts int) (ran time.Duration, err error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	conn, err := s.connect(ctx, restarts)
+	if err != nil {
+		return 0, err
+	}
+	started := s.clock.Now()
+	defer func() {
+		ran = s.clock.Now().Sub(started)
+	}()
+
+	client := NewClient(conn)
+	defer client.Close()
+	client.SetIDGenerator(s.ids)
+	if s.receipts != nil {
+		client.SetReceiptTracker(s.receipts)
+	}
+
+	handlers := append([]MessageHandler{s.history.Handle, s.observeSessionKind}, s.handlers...)
+	handler := NewHandler(ctx, client, handlers...)
+	handler.Start()
+	defer handler.Stop()
+
+	s.setClient(client)
+	defer s.setClient(nil)
+	s.emit(LifecycleEvent{Kind: LifecycleStarted, Restarts: restarts})
+
+	if restarts > 0 {
+		if items := s.history.Items(); len(items) > 0 && !s.isTranscription() {
+			if _, err := client.SendConversationItems(ctx, items, WithWaitForAck(DefaultHistoryAckTimeout)); err != nil {
+				return 0, fmt.Errorf("failed to restore history: %w", err)
+			}
+			s.emit(LifecycleEvent{Kind: LifecycleRestored, Restarts: restarts, Items: len(items)})
+		}
+		if s.receipts != nil {
+			if err := s.resendUnacked(ctx, client, restarts); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- ws.NewPanicError("supervised session", r)
+			}
+		}()
+		done <- s.run(ctx, client)
+	}()
+
+	select {
+	case err := <-done:
+		return 0, err
+	case err := <-handler.Err():
+		// The reader stopped, so the connection is gone; stop the session function too
+		cancel()
+		<-done
+		if err == nil {
+			return 0, ErrConnectionLost
+		}
+		return 0, fmt.Errorf("%w: %w", ErrConnectionLost, err)
+	}
+}
+
+// observeSessionKind records whether the supervised session is a transcription session
+func (s *Supervisor) observeSessionKind(ctx context.Context, msg incoming.RcvdMsg) {
+	switch msg.(type) {
+	case *incoming.TranscriptionSessionCreatedMessage, *incoming.TranscriptionSessionUpdatedMessage:
+		s.mu.Lock()
+		s.transcription = true
+		s.mu.Unlock()
+	case *incoming.SessionCreatedMessage:
+		s.mu.Lock()
+		s.transcription = false
+		s.mu.Unlock()
+	}
+}
+
+// isTranscription reports whether the supervised session is a transcription session
+func (s *Supervisor) isTranscription() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.transcription
+}
+
+// resendUnacked resends the events the previous session did not confirm
+func (s *Supervisor) resendUnacked(ctx context.Context, client *Client, restarts int) error {
+	receipts := s.receipts.reconnected()
+	if len(receipts) == 0 {
+		return nil
+	}
+	for _, receipt := range receipts {
+		if err := client.Resend(ctx, receipt); err != nil {
+			return fmt.Errorf("failed to resend %s %s: %w", receipt.Type, receipt.EventID, err)
+		}
+	}
+	s.emit(LifecycleEvent{Kind: LifecycleResent, Restarts: restarts, Items: len(receipts)})
+	return nil
+}
+
+// connect dials a new connection, waiting for the circuit breaker to allow it if there is one
+func (s *Supervisor) connect(ctx context.Context, restarts int) (*ws.Conn, error) {
+	if s.breaker != nil {
+		if err := s.waitForCircuit(ctx, restarts); err != nil {
+			return nil, err
+		}
+	}
+
+	conn, err := s.dial(ctx)
+	if s.breaker != nil {
+		if err != nil {
+			s.breaker.Failure(err)
+		} else {
+			s.breaker.Success()
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect: %w", err)
+	}
+	return conn, nil
+}
+
+// waitForCircuit waits until the circuit breaker allows a connection attempt
+func (s *Supervisor) waitForCircuit(ctx context.Context, restarts int) error {
+	for {
+		err := s.breaker.Allow()
+		if err == nil {
+			return nil
+		}
+
+		// A zero wait means another caller's half-open probe is in flight; poll until it finishes
+		delay := s.breaker.RetryAfter()
+		if delay <= 0 {
+			delay = circuitPollInterval
+		}
+		s.emit(LifecycleEvent{Kind: LifecycleCircuitOpen, Restarts: restarts, Delay: delay, Err: err})
+
+		if err := clock.Sleep(ctx, s.clock, delay); err != nil {
+			return err
+		}
+	}
+}
+
+// setClient sets the client of the current session
+func (s *Supervisor) setClient(client *Client) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.client = client
+}
+
+// emit calls the lifecycle event function, if any
+func (s *Supervisor) emit(event LifecycleEvent) {
+	if s.onEvent != nil {
+		s.onEvent(event)
+	}
+}
+
+// restartReason returns a metric label for the error that caused a restart
+func restartReason(err error) string {
+	if errors.Is(err, ErrConnectionLost) {
+		if reason, ok := ws.AbnormalCloseReason(err); ok {
+			return reason
+		}
+	}
+	return ws.ReasonOther
+}
+
+// record reports a connection event to the metrics hook, if any
+func (s *Supervisor) record(event ws.ConnEvent) {
+	if s.metrics != nil {
+		s.metrics.RecordConnEvent(event)
+	}
+}
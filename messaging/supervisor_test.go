@@ -0,0 +1,434 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/apierrs"
+	"github.com/Mliviu79/openai-realtime-go/clock"
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+// ackingConn acknowledges every conversation.item.create and blocks reads until the context is done
+func ackingConn() *MockConn {
+	acks := make(chan []byte, 16)
+	return &MockConn{
+		ReadMessageFunc: func(ctx context.Context) (ws.MessageType, []byte, error) {
+			select {
+			case data := <-acks:
+				return ws.MessageText, data, nil
+			case <-ctx.Done():
+				return ws.MessageText, nil, ctx.Err()
+			}
+		},
+		WriteMessageFunc: func(ctx context.Context, messageType ws.MessageType, data []byte) error {
+			var msg sentItemCreate
+			if err := json.Unmarshal(data, &msg); err != nil {
+				return err
+			}
+			acks <- []byte(fmt.Sprintf(`{"type":"conversation.item.created","previous_item_id":%q,"item":{"id":%q,"type":"message"}}`,
+				msg.PreviousItemID, msg.Item.ID))
+			return nil
+		},
+	}
+}
+
+func TestSupervisorRestartsAndRestoresHistory(t *testing.T) {
+	var mu sync.Mutex
+	var events []LifecycleEvent
+	var restored []string
+	counters := ws.NewConnCounters()
+
+	dials := 0
+	dial := func(ctx context.Context) (*ws.Conn, error) {
+		dials++
+		if dials == 1 {
+			conn := newScriptedConn(
+				`{"type":"conversation.item.created","item":{"id":"item_1","type":"message","role":"user","content":[{"type":"input_text","text":"Hi"}]}}`,
+				`{"type":"conversation.item.created","previous_item_id":"item_1","item":{"id":"item_2","type":"message","role":"assistant","content":[{"type":"audio","transcript":"Hello"}]}}`,
+			)
+			read := conn.ReadMessageFunc
+			conn.ReadMessageFunc = func(ctx context.Context) (ws.MessageType, []byte, error) {
+				messageType, data, err := read(ctx)
+				if err != nil {
+					return messageType, nil, net.ErrClosed
+				}
+				return messageType, data, nil
+			}
+			return ws.NewConn(conn), nil
+		}
+
+		conn := ackingConn()
+		write := conn.WriteMessageFunc
+		conn.WriteMessageFunc = func(ctx context.Context, messageType ws.MessageType, data []byte) error {
+			mu.Lock()
+			restored = append(restored, string(data))
+			mu.Unlock()
+			return write(ctx, messageType, data)
+		}
+		return ws.NewConn(conn), nil
+	}
+
+	runs := 0
+	sup := NewSupervisor(dial, func(ctx context.Context, client *Client) error {
+		runs++
+		if runs == 1 {
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		return nil
+	},
+		WithRestartPolicy(RestartPolicy{MaxRestarts: 2, Delay: time.Millisecond}),
+		WithOnLifecycleEvent(func(event LifecycleEvent) {
+			mu.Lock()
+			defer mu.Unlock()
+			events = append(events, event)
+		}),
+		WithSupervisorMetricsHook(counters),
+	)
+
+	if err := sup.Run(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	var kinds []string
+	for _, event := range events {
+		kinds = append(kinds, string(event.Kind))
+	}
+	expected := "started,failed,restarting,started,restored,stopped"
+	if strings.Join(kinds, ",") != expected {
+		t.Errorf("Expected events %s, got %s", expected, strings.Join(kinds, ","))
+	}
+	if !errors.Is(events[1].Err, ErrConnectionLost) {
+		t.Errorf("Expected failure to be %v, got %v", ErrConnectionLost, events[1].Err)
+	}
+	if events[4].Items != 2 {
+		t.Errorf("Expected 2 restored items, got %d", events[4].Items)
+	}
+
+	if len(restored) != 2 {
+		t.Fatalf("Expected 2 re-imported items, got %v", restored)
+	}
+	if !strings.Contains(restored[0], `"id":"item_1"`) || !strings.Contains(restored[1], `"previous_item_id":"item_1"`) {
+		t.Errorf("Expected history to be re-imported in order, got %v", restored)
+	}
+	if !strings.Contains(restored[1], `"text":"Hello"`) {
+		t.Errorf("Expected assistant audio to be re-imported as its transcript, got %s", restored[1])
+	}
+
+	if counters.Count(ws.ConnEventReconnectAttempt, ws.ReasonNetwork) != 1 {
+		t.Errorf("Expected 1 reconnect attempt, got %v", counters.Snapshot())
+	}
+	if sup.Client() != nil {
+		t.Error("Expected no current client after Run returned")
+	}
+}
+
+func TestSupervisorResendsUnacked(t *testing.T) {
+	var mu sync.Mutex
+	var firstSent, resent []string
+	var kinds []string
+	var lost []Receipt
+
+	dropped := make(chan struct{})
+	dials := 0
+	dial := func(ctx context.Context) (*ws.Conn, error) {
+		dials++
+		if dials == 1 {
+			// The first session never confirms anything and drops once the turn is sent
+			return ws.NewConn(&MockConn{
+				ReadMessageFunc: func(ctx context.Context) (ws.MessageType, []byte, error) {
+					select {
+					case <-dropped:
+						return ws.MessageText, nil, net.ErrClosed
+					case <-ctx.Done():
+						return ws.MessageText, nil, ctx.Err()
+					}
+				},
+				WriteMessageFunc: func(ctx context.Context, messageType ws.MessageType, data []byte) error {
+					mu.Lock()
+					defer mu.Unlock()
+					firstSent = append(firstSent, string(data))
+					return nil
+				},
+			}), nil
+		}
+
+		conn := ackingConn()
+		write := conn.WriteMessageFunc
+		conn.WriteMessageFunc = func(ctx context.Context, messageType ws.MessageType, data []byte) error {
+			mu.Lock()
+			resent = append(resent, string(data))
+			mu.Unlock()
+			return write(ctx, messageType, data)
+		}
+		return ws.NewConn(conn), nil
+	}
+
+	runs := 0
+	sup := NewSupervisor(dial, func(ctx context.Context, client *Client) error {
+		runs++
+		if runs > 1 {
+			return nil
+		}
+		if err := client.SendText(ctx, "Book a table for two"); err != nil {
+			return err
+		}
+		if err := client.SendAudioBufferAppend(ctx, "AAAA"); err != nil {
+			return err
+		}
+		if err := client.SendAudioBufferCommit(ctx, ""); err != nil {
+			return err
+		}
+		close(dropped)
+		<-ctx.Done()
+		return ctx.Err()
+	},
+		WithRestartPolicy(RestartPolicy{MaxRestarts: 1, Delay: time.Millisecond}),
+		WithResendUnacked(NewReceiptTracker(WithOnReceipt(func(r Receipt) {
+			mu.Lock()
+			defer mu.Unlock()
+			lost = append(lost, r)
+		}))),
+		WithOnLifecycleEvent(func(event LifecycleEvent) {
+			mu.Lock()
+			defer mu.Unlock()
+			kinds = append(kinds, string(event.Kind))
+		}),
+	)
+
+	if err := sup.Run(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	expected := "started,failed,restarting,started,resent,stopped"
+	if strings.Join(kinds, ",") != expected {
+		t.Errorf("Expected events %s, got %s", expected, strings.Join(kinds, ","))
+	}
+	if len(resent) != 1 || resent[0] != firstSent[0] {
+		t.Errorf("Expected only the user turn to be resent unchanged, got %v", resent)
+	}
+	var commitLost bool
+	for _, r := range lost {
+		if r.Type == "input_audio_buffer.commit" && r.Status == ReceiptFailed && errors.Is(r.Err, ErrConnectionLost) {
+			commitLost = true
+		}
+	}
+	if !commitLost {
+		t.Errorf("Expected the commit to fail with ErrConnectionLost, got %v", lost)
+	}
+}
+
+func TestSupervisorGivesUp(t *testing.T) {
+	tests := []struct {
+		name         string
+		policy       RestartPolicy
+		dialErr      error
+		run          SupervisedFunc
+		expectedRuns int
+		expectedErr  string
+	}{
+		{
+			name:         "permanent error",
+			policy:       RestartPolicy{MaxRestarts: 3, Delay: time.Millisecond},
+			dialErr:      apierrs.Permanent(errors.New("invalid api key")),
+			expectedRuns: 0,
+			expectedErr:  "invalid api key",
+		},
+		{
+			name:   "max restarts",
+			policy: RestartPolicy{MaxRestarts: 2, Delay: time.Millisecond},
+			run: func(ctx context.Context, client *Client) error {
+				return errors.New("session failed")
+			},
+			expectedRuns: 3,
+			expectedErr:  "session failed",
+		},
+		{
+			name:   "panic",
+			policy: RestartPolicy{MaxRestarts: 1, Delay: time.Millisecond},
+			run: func(ctx context.Context, client *Client) error {
+				panic("boom")
+			},
+			expectedRuns: 2,
+			expectedErr:  "panic in supervised session: boom",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gaveUp *LifecycleEvent
+			runs := 0
+			sup := NewSupervisor(func(ctx context.Context) (*ws.Conn, error) {
+				if tt.dialErr != nil {
+					return nil, tt.dialErr
+				}
+				return ws.NewConn(ackingConn()), nil
+			}, func(ctx context.Context, client *Client) error {
+				runs++
+				return tt.run(ctx, client)
+			},
+				WithRestartPolicy(tt.policy),
+				WithOnLifecycleEvent(func(event LifecycleEvent) {
+					if event.Kind == LifecycleGaveUp {
+						gaveUp = &event
+					}
+				}),
+			)
+
+			err := sup.Run(context.Background())
+			if err == nil || !strings.Contains(err.Error(), tt.expectedErr) {
+				t.Errorf("Expected error containing %q, got %v", tt.expectedErr, err)
+			}
+			if runs != tt.expectedRuns {
+				t.Errorf("Expected %d runs, got %d", tt.expectedRuns, runs)
+			}
+			if gaveUp == nil {
+				t.Error("Expected a gave_up event")
+			}
+		})
+	}
+}
+
+func TestSupervisorResetsAfterStableSession(t *testing.T) {
+	fake := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	var delays []time.Duration
+	var gaveUp bool
+	runs := 0
+	sup := NewSupervisor(func(ctx context.Context) (*ws.Conn, error) {
+		return ws.NewConn(ackingConn()), nil
+	}, func(ctx context.Context, client *Client) error {
+		runs++
+		if runs == 3 {
+			// The third session stays up for longer than the stability window
+			fake.Advance(2 * time.Minute)
+		}
+		return errors.New("session failed")
+	},
+		WithRestartPolicy(RestartPolicy{MaxRestarts: 2, Delay: time.Second, MaxDelay: time.Minute, ResetAfter: time.Minute}),
+		WithSupervisorClock(fake),
+		WithOnLifecycleEvent(func(event LifecycleEvent) {
+			switch event.Kind {
+			case LifecycleRestarting:
+				delays = append(delays, event.Delay)
+			case LifecycleGaveUp:
+				gaveUp = true
+			}
+		}),
+	)
+
+	done := make(chan error, 1)
+	go func() { done <- sup.Run(context.Background()) }()
+
+	// Each restart waits on one timer; the supervisor gives up on the fifth failure
+	for range 4 {
+		fake.BlockUntil(1)
+		fake.Advance(time.Minute)
+	}
+	if err := <-done; err == nil || err.Error() != "session failed" {
+		t.Errorf("Expected the last session error, got %v", err)
+	}
+
+	// The stable third session resets both the restart count and the delay
+	expected := []time.Duration{time.Second, 2 * time.Second, time.Second, 2 * time.Second}
+	if runs != 5 || !gaveUp || fmt.Sprint(delays) != fmt.Sprint(expected) {
+		t.Errorf("Expected 5 runs with delays %v before giving up, got %d runs with delays %v", expected, runs, delays)
+	}
+}
+
+func TestRestartPolicyDelay(t *testing.T) {
+	policy := RestartPolicy{Delay: time.Second, MaxDelay: 5 * time.Second}
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, want := range expected {
+		if got := policy.delay(i + 1); got != want {
+			t.Errorf("Expected delay %v before restart %d, got %v", want, i+1, got)
+		}
+	}
+}
+
+func TestSupervisorTranscriptionSkipsHistory(t *testing.T) {
+	var mu sync.Mutex
+	var events []LifecycleEvent
+	var written []string
+
+	dials := 0
+	dial := func(ctx context.Context) (*ws.Conn, error) {
+		dials++
+		if dials == 1 {
+			conn := newScriptedConn(
+				`{"type":"transcription_session.created","session":{"id":"sess_1"}}`,
+				`{"type":"conversation.item.created","item":{"id":"item_1","type":"message","role":"user","content":[{"type":"input_audio","transcript":"Hi"}]}}`,
+			)
+			read := conn.ReadMessageFunc
+			conn.ReadMessageFunc = func(ctx context.Context) (ws.MessageType, []byte, error) {
+				messageType, data, err := read(ctx)
+				if err != nil {
+					return messageType, nil, net.ErrClosed
+				}
+				return messageType, data, nil
+			}
+			return ws.NewConn(conn), nil
+		}
+		conn := ackingConn()
+		conn.WriteMessageFunc = func(ctx context.Context, messageType ws.MessageType, data []byte) error {
+			mu.Lock()
+			defer mu.Unlock()
+			written = append(written, string(data))
+			return nil
+		}
+		return ws.NewConn(conn), nil
+	}
+
+	runs := 0
+	sup := NewSupervisor(dial, func(ctx context.Context, client *Client) error {
+		runs++
+		if runs == 1 {
+			<-ctx.Done()
+			return ctx.Err()
+		}
+		return nil
+	},
+		WithRestartPolicy(RestartPolicy{MaxRestarts: 1, Delay: time.Millisecond}),
+		WithOnLifecycleEvent(func(event LifecycleEvent) {
+			mu.Lock()
+			defer mu.Unlock()
+			events = append(events, event)
+		}),
+	)
+
+	done := make(chan error, 1)
+	go func() { done <- sup.Run(context.Background()) }()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Expected no error, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Expected the supervisor to restart after the transcription connection dropped")
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	var kinds []string
+	for _, event := range events {
+		kinds = append(kinds, string(event.Kind))
+	}
+	if expected := "started,failed,restarting,started,stopped"; strings.Join(kinds, ",") != expected {
+		t.Errorf("Expected events %s, got %s", expected, strings.Join(kinds, ","))
+	}
+	if len(written) != 0 {
+		t.Errorf("Expected no history to be re-imported into a transcription session, got %v", written)
+	}
+}
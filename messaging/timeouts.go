@@ -0,0 +1,46 @@
+package messaging
+
+import (
+	"context"
+	"time"
+)
+
+//-----------------------------------------------------------------------------
+// Client Options and Default Timeouts
+//-----------------------------------------------------------------------------
+
+// ClientOption configures a Client
+type ClientOption func(*Client)
+
+// WithSendTimeout bounds every send made with a context that has no deadline, including
+// the wait for the send gate and any response queued by SetResponsePolicy, so a stalled
+// connection cannot block a caller that passed context.Background. A context with its
+// own deadline is used as it is. Zero, the default, leaves sends unbounded.
+//
+// Example:
+//
+//	msgClient := messaging.NewClient(conn, messaging.WithSendTimeout(10*time.Second))
+func WithSendTimeout(timeout time.Duration) ClientOption {
+	return func(c *Client) {
+		c.sendTimeout = timeout
+	}
+}
+
+// SetSendTimeout changes the timeout set with WithSendTimeout. Zero turns it off.
+func (c *Client) SetSendTimeout(timeout time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sendTimeout = timeout
+}
+
+// sendContext returns ctx bounded by the send timeout if it has no deadline of its own
+func (c *Client) sendContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	c.mu.RLock()
+	timeout := c.sendTimeout
+	c.mu.RUnlock()
+
+	if _, ok := ctx.Deadline(); ok || timeout <= 0 {
+		return ctx, func() {}
+	}
+	return context.WithTimeout(ctx, timeout)
+}
@@ -0,0 +1,60 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+func TestSendTimeout(t *testing.T) {
+	var deadlines []time.Time
+	conn := &MockConn{
+		WriteMessageFunc: func(ctx context.Context, messageType ws.MessageType, data []byte) error {
+			deadline, _ := ctx.Deadline()
+			deadlines = append(deadlines, deadline)
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	}
+	client := NewClient(ws.NewConn(conn), WithSendTimeout(20*time.Millisecond))
+
+	if err := client.SendText(context.Background(), "Hello"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected the send to time out, got %v", err)
+	}
+	if err := client.SendRaw(context.Background(), []byte(`{"type":"experimental.event"}`)); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected the raw send to time out, got %v", err)
+	}
+
+	// A caller's own deadline is kept
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	own, _ := ctx.Deadline()
+	if err := client.SendText(ctx, "Hello"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected the send to time out, got %v", err)
+	}
+	if !deadlines[2].Equal(own) {
+		t.Errorf("Expected the caller's deadline %v, got %v", own, deadlines[2])
+	}
+}
+
+func TestSetSendTimeoutOff(t *testing.T) {
+	var hasDeadline bool
+	conn := &MockConn{
+		WriteMessageFunc: func(ctx context.Context, messageType ws.MessageType, data []byte) error {
+			_, hasDeadline = ctx.Deadline()
+			return nil
+		},
+	}
+	client := NewClient(ws.NewConn(conn), WithSendTimeout(time.Second))
+	client.SetSendTimeout(0)
+
+	if err := client.SendText(context.Background(), "Hello"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if hasDeadline {
+		t.Error("Expected no deadline with the send timeout turned off")
+	}
+}
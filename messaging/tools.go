@@ -0,0 +1,158 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/Mliviu79/openai-realtime-go/messages/factory"
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+	"github.com/Mliviu79/openai-realtime-go/messages/types"
+	"github.com/Mliviu79/openai-realtime-go/session"
+)
+
+//-----------------------------------------------------------------------------
+// Tool Registry
+//-----------------------------------------------------------------------------
+
+// ErrToolNotFound is returned by ToolRegistry.Call when no tool is registered under the name
+var ErrToolNotFound = errors.New("tool not found")
+
+// ToolFunc executes a function call with the JSON arguments chosen by the model and
+// returns the output that is sent back to the model
+type ToolFunc func(ctx context.Context, arguments string) (string, error)
+
+// registeredTool is a tool definition with its implementation
+type registeredTool struct {
+	tool session.Tool
+	fn   ToolFunc
+}
+
+// ToolRegistry maps the tools offered to the model to their implementations.
+// A registry is safe for concurrent use and can be shared by many sessions.
+//
+// Example:
+//
+//	tools := messaging.NewToolRegistry()
+//	tools.Register(session.Tool{Name: "get_time", Description: "Returns the time"}, getTime)
+//	handler := messaging.NewHandler(ctx, msgClient, tools.Handler(msgClient))
+type ToolRegistry struct {
+	mu    sync.RWMutex
+	tools map[string]registeredTool
+	order []string
+}
+
+// NewToolRegistry creates an empty ToolRegistry
+func NewToolRegistry() *ToolRegistry {
+	return &ToolRegistry{
+		tools: make(map[string]registeredTool),
+	}
+}
+
+// Register adds a tool, replacing any tool with the same name.
+// The tool type defaults to "function".
+func (r *ToolRegistry) Register(tool session.Tool, fn ToolFunc) {
+	if tool.Type == "" {
+		tool.Type = "function"
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.tools[tool.Name]; !exists {
+		r.order = append(r.order, tool.Name)
+	}
+	r.tools[tool.Name] = registeredTool{tool: tool, fn: fn}
+}
+
+// Tools returns the tool definitions in registration order, for use in a session.update
+func (r *ToolRegistry) Tools() []session.Tool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	tools := make([]session.Tool, len(r.order))
+	for i, name := range r.order {
+		tools[i] = r.tools[name].tool
+	}
+	return tools
+}
+
+// Len returns the number of registered tools
+func (r *ToolRegistry) Len() int {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return len(r.order)
+}
+
+// Call runs the tool registered under name with the given JSON arguments
+func (r *ToolRegistry) Call(ctx context.Context, name string, arguments string) (string, error) {
+	r.mu.RLock()
+	registered, ok := r.tools[name]
+	r.mu.RUnlock()
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrToolNotFound, name)
+	}
+	return registered.fn(ctx, arguments)
+}
+
+// Handler returns a MessageHandler that answers the function calls of each completed
+// response on client. It runs the requested tools, sends their outputs as
+// function_call_output items and then asks for a new response. Tool errors are sent to
+// the model as a JSON object with an "error" field so it can recover.
+//
+// The tools run in a background goroutine so the handler does not block reading.
+func (r *ToolRegistry) Handler(client *Client) MessageHandler {
+	return func(ctx context.Context, msg incoming.RcvdMsg) {
+		done, ok := msg.(*incoming.ResponseDoneMessage)
+		if !ok || done.Response.Status != types.ResponseStatusCompleted {
+			return
+		}
+
+		var calls []types.OutputItem
+		for _, item := range done.Response.Output {
+			if item.Type == types.MessageItemTypeFunctionCall {
+				calls = append(calls, item)
+			}
+		}
+		if len(calls) == 0 {
+			return
+		}
+
+		go r.answer(offReader(ctx), client, calls)
+	}
+}
+
+// answer runs the function calls and sends their outputs followed by a response.create
+func (r *ToolRegistry) answer(ctx context.Context, client *Client, calls []types.OutputItem) {
+	defer client.recoverPanic("tool call")
+
+	for _, call := range calls {
+		output, err := r.Call(ctx, call.Name, call.Arguments)
+		if err != nil {
+			if log := client.getLogger(); log != nil {
+				log.Warnf("Tool %s failed: %v", call.Name, err)
+			}
+			output = toolErrorOutput(err)
+		}
+
+		item := factory.FunctionResponseItem(call.CallID, output)
+		if err := client.SendConversationItemCreate(ctx, &item, nil); err != nil {
+			if log := client.getLogger(); log != nil {
+				log.Errorf("Failed to send output of tool %s: %v", call.Name, err)
+			}
+			return
+		}
+	}
+
+	if err := client.SendResponseCreate(ctx, &types.ResponseConfig{}); err != nil {
+		if log := client.getLogger(); log != nil {
+			log.Errorf("Failed to request a response after tool calls: %v", err)
+		}
+	}
+}
+
+// toolErrorOutput encodes a tool error as the function call output
+func toolErrorOutput(err error) string {
+	data, _ := json.Marshal(map[string]string{"error": err.Error()})
+	return string(data)
+}
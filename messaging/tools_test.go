@@ -0,0 +1,104 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/session"
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+// waitForSent polls until at least n messages have been sent and returns them
+func waitForSent(t *testing.T, sent func() []string, n int) []string {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for time.Now().Before(deadline) {
+		if msgs := sent(); len(msgs) >= n {
+			return msgs
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("Timed out waiting for %d messages, got %v", n, sent())
+	return nil
+}
+
+func TestToolRegistry(t *testing.T) {
+	registry := NewToolRegistry()
+	registry.Register(session.Tool{Name: "echo"}, func(ctx context.Context, arguments string) (string, error) {
+		return arguments, nil
+	})
+	registry.Register(session.Tool{Name: "fail", Type: "function"}, func(ctx context.Context, arguments string) (string, error) {
+		return "", errors.New("boom")
+	})
+	registry.Register(session.Tool{Name: "echo", Description: "Echoes"}, func(ctx context.Context, arguments string) (string, error) {
+		return "replaced", nil
+	})
+
+	if registry.Len() != 2 {
+		t.Errorf("Expected 2 tools, got %d", registry.Len())
+	}
+	tools := registry.Tools()
+	if tools[0].Name != "echo" || tools[0].Description != "Echoes" || tools[0].Type != "function" {
+		t.Errorf("Expected replaced echo tool first, got %+v", tools[0])
+	}
+	if tools[1].Name != "fail" {
+		t.Errorf("Expected fail tool second, got %+v", tools[1])
+	}
+
+	output, err := registry.Call(context.Background(), "echo", `{}`)
+	if err != nil || output != "replaced" {
+		t.Errorf("Expected output %q, got %q (%v)", "replaced", output, err)
+	}
+	if _, err := registry.Call(context.Background(), "missing", `{}`); !errors.Is(err, ErrToolNotFound) {
+		t.Errorf("Expected %v, got %v", ErrToolNotFound, err)
+	}
+}
+
+func TestToolRegistryHandler(t *testing.T) {
+	registry := NewToolRegistry()
+	registry.Register(session.Tool{Name: "get_weather"}, func(ctx context.Context, arguments string) (string, error) {
+		return `{"temperature":21}`, nil
+	})
+
+	conn, sent, _ := recordingConn()
+	client := NewClient(ws.NewConn(conn))
+	handle := registry.Handler(client)
+
+	// Responses without function calls and incomplete responses are ignored
+	handle(context.Background(), mustParse(t, `{"type":"response.done","response":{"id":"resp_0","status":"completed","output":[{"type":"message","role":"assistant"}]}}`))
+	handle(context.Background(), mustParse(t, `{"type":"response.done","response":{"id":"resp_1","status":"cancelled","output":[{"type":"function_call","name":"get_weather","call_id":"call_0","arguments":"{}"}]}}`))
+
+	handle(context.Background(), mustParse(t, `{"type":"response.done","response":{"id":"resp_2","status":"completed","output":[
+		{"type":"function_call","name":"get_weather","call_id":"call_1","arguments":"{\"city\":\"Paris\"}"},
+		{"type":"function_call","name":"unknown","call_id":"call_2","arguments":"{}"}
+	]}}`))
+
+	msgs := waitForSent(t, sent, 3)
+	if len(msgs) != 3 {
+		t.Fatalf("Expected 3 messages, got %v", msgs)
+	}
+
+	var first struct {
+		Item struct {
+			Type   string `json:"type"`
+			CallID string `json:"call_id"`
+			Output string `json:"output"`
+		} `json:"item"`
+	}
+	if err := json.Unmarshal([]byte(msgs[0]), &first); err != nil {
+		t.Fatalf("Failed to unmarshal %s: %v", msgs[0], err)
+	}
+	if first.Item.Type != "function_call_output" || first.Item.CallID != "call_1" || first.Item.Output != `{"temperature":21}` {
+		t.Errorf("Unexpected first output: %s", msgs[0])
+	}
+	if !strings.Contains(msgs[1], `"call_id":"call_2"`) || !strings.Contains(msgs[1], "tool not found") {
+		t.Errorf("Expected error output for unknown tool, got %s", msgs[1])
+	}
+	if !strings.Contains(msgs[2], `"type":"response.create"`) {
+		t.Errorf("Expected response.create, got %s", msgs[2])
+	}
+}
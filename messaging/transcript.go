@@ -0,0 +1,278 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/clock"
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+	"github.com/Mliviu79/openai-realtime-go/messages/types"
+)
+
+//-----------------------------------------------------------------------------
+// Transcript Documents
+//-----------------------------------------------------------------------------
+
+// TranscriptSegment is one turn of a transcript document
+type TranscriptSegment struct {
+	// ItemID identifies the conversation item the segment was taken from
+	ItemID string `json:"item_id"`
+
+	// Speaker names who spoke; by default the role
+	Speaker string `json:"speaker"`
+
+	// Role is the role of the item, user or assistant
+	Role types.MessageRole `json:"role"`
+
+	// Text is the transcript of audio, or the text of text messages
+	Text string `json:"text"`
+
+	// StartMs and EndMs are the offsets of the turn from the start of the session, in milliseconds
+	StartMs int64 `json:"start_ms"`
+	EndMs   int64 `json:"end_ms"`
+}
+
+// TranscriptDocument is a speaker-tagged transcript of a session
+type TranscriptDocument struct {
+	// SessionID identifies the session the transcript was taken from
+	SessionID string `json:"session_id,omitempty"`
+
+	// StartedAt is when the first event of the session was received
+	StartedAt time.Time `json:"started_at"`
+
+	// Segments are the turns of the conversation in order
+	Segments []TranscriptSegment `json:"segments"`
+}
+
+// WriteJSON writes the document as indented JSON
+func (d TranscriptDocument) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(d)
+}
+
+// segmentTiming is what a TranscriptBuilder knows about when an item was spoken
+type segmentTiming struct {
+	start time.Duration
+	end   time.Duration
+
+	// vad is set when the start and end were reported by server VAD
+	vad bool
+
+	// audioBytes is the generated audio; truncated is where it was cut, if cut
+	audioBytes int
+	truncated  time.Duration
+	cut        bool
+
+	// done is when the output item of an assistant text message completed
+	done time.Duration
+}
+
+// TranscriptBuilderOption configures a TranscriptBuilder
+type TranscriptBuilderOption func(*TranscriptBuilder)
+
+// WithSpeakerNames sets the speaker names of user and assistant segments, such as
+// "Caller" and "Agent"; by default the speaker is the role
+func WithSpeakerNames(user, assistant string) TranscriptBuilderOption {
+	return func(b *TranscriptBuilder) {
+		b.userSpeaker = user
+		b.assistantSpeaker = assistant
+	}
+}
+
+// WithTranscriptClock sets the clock used to time events that were not stamped with the
+// time they were received, which messages read through a Client are; the default is the
+// real clock
+func WithTranscriptClock(c clock.Clock) TranscriptBuilderOption {
+	return func(b *TranscriptBuilder) {
+		if c != nil {
+			b.clock = c
+		}
+	}
+}
+
+// TranscriptBuilder builds a speaker-tagged transcript document of a session from server
+// events, for compliance and QA review.
+//
+// Timestamps are offsets from the first event of the session. User turns are timed by
+// server VAD speech events, which count input audio from the start of the session, so
+// they line up with the other turns when audio is streamed in real time. Assistant turns
+// start when their item is created, or when the previous assistant turn finishes playing,
+// and last as long as their audio, excluding audio removed by truncation after an
+// interruption. Items without audio or VAD events are timed by when they were created
+// and completed.
+//
+// The document follows the conversation, so items deleted from it are left out.
+//
+// Example:
+//
+//	transcript := messaging.NewTranscriptBuilder(messaging.WithSpeakerNames("Caller", "Agent"))
+//	handler := messaging.NewHandler(ctx, msgClient, transcript.Handle)
+//	// ...
+//	err := transcript.Document().WriteJSON(file)
+type TranscriptBuilder struct {
+	clock            clock.Clock
+	userSpeaker      string
+	assistantSpeaker string
+	history          *ConversationHistory
+
+	mu            sync.Mutex
+	sessionID     string
+	startedAt     time.Time
+	outputAudio   outputAudioFormat
+	timing        map[string]*segmentTiming
+	lastAssistant string
+}
+
+// NewTranscriptBuilder creates an empty TranscriptBuilder
+func NewTranscriptBuilder(opts ...TranscriptBuilderOption) *TranscriptBuilder {
+	b := &TranscriptBuilder{
+		clock:            clock.Real(),
+		userSpeaker:      string(types.MessageRoleUser),
+		assistantSpeaker: string(types.MessageRoleAssistant),
+		history:          NewConversationHistory(),
+		timing:           make(map[string]*segmentTiming),
+	}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// Handle records the session ID, the speech timing of user turns and the playback of
+// assistant audio, and passes the event to the history the transcript text is read from.
+func (b *TranscriptBuilder) Handle(ctx context.Context, msg incoming.RcvdMsg) {
+	b.history.Handle(ctx, msg)
+
+	now, ok := incoming.ReceivedAt(msg)
+	if !ok {
+		now = b.clock.Now()
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.startedAt.IsZero() {
+		b.startedAt = now
+	}
+	offset := now.Sub(b.startedAt)
+
+	switch m := msg.(type) {
+	case *incoming.SessionCreatedMessage:
+		b.sessionID = m.Session.ID
+		b.outputAudio.observe(msg)
+	case *incoming.SessionUpdatedMessage:
+		b.outputAudio.observe(msg)
+	case *incoming.AudioBufferSpeechStartedMessage:
+		t := b.timingOf(m.ItemID)
+		t.vad = true
+		t.start = time.Duration(m.AudioStartMs) * time.Millisecond
+		t.end = t.start
+	case *incoming.AudioBufferSpeechStoppedMessage:
+		t := b.timingOf(m.ItemID)
+		t.vad = true
+		t.end = time.Duration(m.AudioEndMs) * time.Millisecond
+	case *incoming.ConversationItemCreatedMessage:
+		if _, ok := b.timing[m.Item.ID]; ok {
+			break
+		}
+		t := b.timingOf(m.Item.ID)
+		t.start = offset
+		if m.Item.Role == types.MessageRoleAssistant {
+			// Assistant audio is played in turn
+			if last, ok := b.timing[b.lastAssistant]; ok {
+				t.start = max(t.start, b.endOf(last))
+			}
+			b.lastAssistant = m.Item.ID
+		}
+	case *incoming.ResponseOutputAudioDeltaMessage:
+		b.timingOf(m.ItemID).audioBytes += m.AudioLen()
+	case *incoming.ConversationItemTruncatedMessage:
+		t := b.timingOf(m.ItemID)
+		t.cut = true
+		t.truncated = time.Duration(m.AudioEndMs) * time.Millisecond
+	case *incoming.ResponseOutputItemDoneMessage:
+		b.timingOf(m.Item.ID).done = offset
+	}
+}
+
+// Document returns the transcript of the conversation so far
+func (b *TranscriptBuilder) Document() TranscriptDocument {
+	items := b.history.Items()
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	doc := TranscriptDocument{
+		SessionID: b.sessionID,
+		StartedAt: b.startedAt,
+		Segments:  []TranscriptSegment{},
+	}
+	for _, item := range items {
+		if item.Type != types.MessageItemTypeMessage {
+			continue
+		}
+		var speaker string
+		switch item.Role {
+		case types.MessageRoleUser:
+			speaker = b.userSpeaker
+		case types.MessageRoleAssistant:
+			speaker = b.assistantSpeaker
+		default:
+			continue
+		}
+
+		var texts []string
+		for _, part := range item.Content {
+			if part.Text != "" {
+				texts = append(texts, part.Text)
+			}
+		}
+		if len(texts) == 0 {
+			continue
+		}
+
+		segment := TranscriptSegment{
+			ItemID:  item.ID,
+			Speaker: speaker,
+			Role:    item.Role,
+			Text:    strings.Join(texts, " "),
+		}
+		if t, ok := b.timing[item.ID]; ok {
+			segment.StartMs = t.start.Milliseconds()
+			segment.EndMs = b.endOf(t).Milliseconds()
+		}
+		doc.Segments = append(doc.Segments, segment)
+	}
+	return doc
+}
+
+// endOf returns when a timed item finished. The caller must hold b.mu.
+func (b *TranscriptBuilder) endOf(t *segmentTiming) time.Duration {
+	switch {
+	case t.audioBytes > 0:
+		played := b.outputAudio.duration(t.audioBytes)
+		if t.cut && t.truncated < played {
+			played = t.truncated
+		}
+		return t.start + played
+	case t.vad:
+		return max(t.start, t.end)
+	default:
+		return max(t.start, t.done)
+	}
+}
+
+// timingOf returns the timing of the item with the given ID, creating it if needed.
+// The caller must hold b.mu.
+func (b *TranscriptBuilder) timingOf(id string) *segmentTiming {
+	t, ok := b.timing[id]
+	if !ok {
+		t = &segmentTiming{}
+		b.timing[id] = t
+	}
+	return t
+}
@@ -0,0 +1,93 @@
+package messaging
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/clock"
+)
+
+func TestTranscriptBuilder(t *testing.T) {
+	fake := clock.NewFake(time.Unix(1700000000, 0))
+	transcript := NewTranscriptBuilder(WithSpeakerNames("Caller", "Agent"), WithTranscriptClock(fake))
+	oneSecond := base64.StdEncoding.EncodeToString(make([]byte, 48000))
+	twoSeconds := base64.StdEncoding.EncodeToString(make([]byte, 96000))
+
+	ctx := context.Background()
+	handle := func(msg string) { transcript.Handle(ctx, mustParse(t, msg)) }
+	handle(`{"type":"session.created","session":{"id":"sess_1"}}`)
+
+	// The user speaks from 1s to 3s
+	handle(`{"type":"input_audio_buffer.speech_started","audio_start_ms":1000,"item_id":"item_1"}`)
+	fake.Advance(3 * time.Second)
+	handle(`{"type":"input_audio_buffer.speech_stopped","audio_end_ms":3000,"item_id":"item_1"}`)
+	handle(`{"type":"conversation.item.created","previous_item_id":"","item":{"id":"item_1","type":"message","role":"user","content":[{"type":"input_audio"}]}}`)
+
+	// One second of reply audio at 3.5s
+	fake.Advance(500 * time.Millisecond)
+	handle(`{"type":"conversation.item.created","previous_item_id":"item_1","item":{"id":"item_2","type":"message","role":"assistant","content":[]}}`)
+	handle(`{"type":"response.output_audio.delta","response_id":"resp_1","item_id":"item_2","delta":"` + oneSecond + `"}`)
+	handle(`{"type":"response.output_item.done","response_id":"resp_1","output_index":0,"item":{"id":"item_2","type":"message","role":"assistant","content":[{"type":"audio","transcript":"For how many?"}]}}`)
+	handle(`{"type":"conversation.item.input_audio_transcription.completed","item_id":"item_1","content_index":0,"transcript":"Book a table"}`)
+
+	// The next reply is created while the first still plays and is cut after half a second
+	fake.Advance(500 * time.Millisecond)
+	handle(`{"type":"conversation.item.created","previous_item_id":"item_2","item":{"id":"item_3","type":"message","role":"assistant","content":[]}}`)
+	handle(`{"type":"response.output_audio.delta","response_id":"resp_2","item_id":"item_3","delta":"` + twoSeconds + `"}`)
+	handle(`{"type":"response.output_item.done","response_id":"resp_2","output_index":0,"item":{"id":"item_3","type":"message","role":"assistant","content":[{"type":"audio","transcript":"Sure, I can"}]}}`)
+	handle(`{"type":"conversation.item.truncated","item_id":"item_3","content_index":0,"audio_end_ms":500}`)
+
+	// A typed message and a function call at 6s
+	fake.Advance(2 * time.Second)
+	handle(`{"type":"conversation.item.created","previous_item_id":"item_3","item":{"id":"item_4","type":"message","role":"user","content":[{"type":"input_text","text":"Four people"}]}}`)
+	handle(`{"type":"conversation.item.created","previous_item_id":"item_4","item":{"id":"item_5","type":"function_call","name":"book"}}`)
+
+	doc := transcript.Document()
+	if doc.SessionID != "sess_1" || !doc.StartedAt.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("Expected session sess_1 started at the first event, got %s %v", doc.SessionID, doc.StartedAt)
+	}
+	expected := []TranscriptSegment{
+		{ItemID: "item_1", Speaker: "Caller", Role: "user", Text: "Book a table", StartMs: 1000, EndMs: 3000},
+		{ItemID: "item_2", Speaker: "Agent", Role: "assistant", Text: "For how many?", StartMs: 3500, EndMs: 4500},
+		{ItemID: "item_3", Speaker: "Agent", Role: "assistant", Text: "Sure, I can", StartMs: 4500, EndMs: 5000},
+		{ItemID: "item_4", Speaker: "Caller", Role: "user", Text: "Four people", StartMs: 6000, EndMs: 6000},
+	}
+	if len(doc.Segments) != len(expected) {
+		t.Fatalf("Expected %d segments, got %+v", len(expected), doc.Segments)
+	}
+	for i, want := range expected {
+		if doc.Segments[i] != want {
+			t.Errorf("Expected segment %d to be %+v, got %+v", i, want, doc.Segments[i])
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := doc.WriteJSON(&buf); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var decoded TranscriptDocument
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Failed to parse %s: %v", buf.String(), err)
+	}
+	if len(decoded.Segments) != 4 || decoded.Segments[2] != expected[2] {
+		t.Errorf("Expected the segments to round trip, got %s", buf.String())
+	}
+}
+
+func TestTranscriptBuilderEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	if err := NewTranscriptBuilder().Document().WriteJSON(&buf); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var decoded map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Failed to parse %s: %v", buf.String(), err)
+	}
+	if segments, ok := decoded["segments"].([]any); !ok || len(segments) != 0 {
+		t.Errorf("Expected an empty segments list, got %s", buf.String())
+	}
+}
@@ -0,0 +1,287 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+	"github.com/Mliviu79/openai-realtime-go/messages/outgoing"
+	"github.com/Mliviu79/openai-realtime-go/messages/types"
+)
+
+//-----------------------------------------------------------------------------
+// Turn Taking
+//-----------------------------------------------------------------------------
+
+// ErrResponseInProgress is returned by ResponsePolicyReject when a response.create is
+// sent while another response is in progress
+var ErrResponseInProgress = errors.New("response already in progress")
+
+// ErrWaitInHandler is returned by ResponsePolicyQueue and ResponsePolicyCancel when a
+// response.create sent from a message handler would wait for the response in progress.
+// The handler blocks the reader, so the response.done it waits for would never arrive.
+var ErrWaitInHandler = errors.New("cannot wait for the response in progress from a message handler")
+
+// ResponsePolicy decides what happens to a response.create sent while another response
+// is in progress
+type ResponsePolicy int
+
+const (
+	// ResponsePolicyAllow sends every response.create, leaving overlapping responses to
+	// the server. This is the default.
+	ResponsePolicyAllow ResponsePolicy = iota
+
+	// ResponsePolicyReject returns ErrResponseInProgress instead of sending
+	ResponsePolicyReject
+
+	// ResponsePolicyQueue waits for the response in progress to finish before sending
+	ResponsePolicyQueue
+
+	// ResponsePolicyCancel cancels the response in progress and waits for the server to
+	// report it done before sending
+	ResponsePolicyCancel
+)
+
+// String returns the policy name
+func (p ResponsePolicy) String() string {
+	switch p {
+	case ResponsePolicyAllow:
+		return "allow"
+	case ResponsePolicyReject:
+		return "reject"
+	case ResponsePolicyQueue:
+		return "queue"
+	case ResponsePolicyCancel:
+		return "cancel"
+	default:
+		return fmt.Sprintf("ResponsePolicy(%d)", int(p))
+	}
+}
+
+// SetResponsePolicy sets how a response.create is handled while another response is in
+// progress, so a double-triggered response does not interleave its output with the
+// first.
+//
+// A response is in progress from the moment a response.create is sent until the
+// server reports it done, or reports an error for the request. A response.create sent
+// under a policy other than ResponsePolicyAllow is given an event ID, if it has none, so
+// its error can be told apart from errors for other events. Progress is observed
+// through ReadMessage or a Handler, which must be running for ResponsePolicyQueue and
+// ResponsePolicyCancel to make progress. Out-of-band responses, created with a
+// conversation of "none", are always sent and never count as in progress. A handoff to
+// a new session (see Handler.Handoff) ends the response in progress.
+//
+// A message handler blocks the Handler's reader until it returns, so under
+// ResponsePolicyQueue and ResponsePolicyCancel a response.create sent with the handler's
+// context while a response is in progress fails with ErrWaitInHandler instead of
+// waiting forever. Send it from another goroutine, as ToolRegistry does, to wait.
+//
+// Example:
+//
+//	msgClient.SetResponsePolicy(messaging.ResponsePolicyCancel)
+func (c *Client) SetResponsePolicy(policy ResponsePolicy) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.responsePolicy = policy
+}
+
+// responseBusy reports whether a response has been requested or is in progress
+func (c *Client) responseBusy() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.responseRequested || c.activeResponseID != ""
+}
+
+// admitResponse applies the response policy to a message about to be sent.
+// For a response.create it returns a function that must be called with whether the
+// message was sent; other messages are admitted immediately.
+func (c *Client) admitResponse(ctx context.Context, msg outgoing.OutMsg) (func(sent bool), error) {
+	noop := func(bool) {}
+	if !isConversationResponse(msg) {
+		return noop, nil
+	}
+
+	c.mu.RLock()
+	policy := c.responsePolicy
+	c.mu.RUnlock()
+	if policy == ResponsePolicyAllow {
+		return noop, nil
+	}
+
+	// Serialize requests so queued responses are sent one at a time
+	c.turnMu.Lock()
+	switch policy {
+	case ResponsePolicyReject:
+		if c.responseBusy() {
+			c.turnMu.Unlock()
+			return nil, ErrResponseInProgress
+		}
+	case ResponsePolicyQueue:
+		if onReader(ctx, c) && c.responseBusy() {
+			c.turnMu.Unlock()
+			return nil, ErrWaitInHandler
+		}
+		if err := c.waitResponseIdle(ctx); err != nil {
+			c.turnMu.Unlock()
+			return nil, err
+		}
+	case ResponsePolicyCancel:
+		if c.responseBusy() {
+			if onReader(ctx, c) {
+				c.turnMu.Unlock()
+				return nil, ErrWaitInHandler
+			}
+			if err := c.CancelCurrentResponse(ctx); err != nil {
+				c.turnMu.Unlock()
+				return nil, fmt.Errorf("cancelling response in progress: %w", err)
+			}
+			// Output of the cancelled response keeps arriving until it is done
+			if err := c.waitResponseIdle(ctx); err != nil {
+				c.turnMu.Unlock()
+				return nil, err
+			}
+		}
+	}
+
+	m, _ := responseCreate(msg)
+	c.mu.Lock()
+	c.responseRequested = true
+	c.responseEventID = m.ID
+	c.mu.Unlock()
+
+	return func(sent bool) {
+		if !sent {
+			c.mu.Lock()
+			c.clearResponseRequest()
+			c.mu.Unlock()
+		}
+		c.turnMu.Unlock()
+	}, nil
+}
+
+// withResponseEventID returns msg with a new event ID if it is a response.create subject
+// to the response policy and has none, so an error for it can be recognized
+func (c *Client) withResponseEventID(msg outgoing.OutMsg) outgoing.OutMsg {
+	m, ok := responseCreate(msg)
+	if !ok || m.ID != "" || !isConversationResponse(msg) {
+		return msg
+	}
+	c.mu.RLock()
+	policy := c.responsePolicy
+	c.mu.RUnlock()
+	if policy == ResponsePolicyAllow {
+		return msg
+	}
+	m.ID = c.newID("evt_")
+	return m
+}
+
+// observeResponseRequest ends the pending response.create when the server reports an
+// error for it. Errors for other events leave it pending. c.mu must be held.
+func (c *Client) observeResponseRequest(m *incoming.ErrorMessage) {
+	if c.responseRequested && m.Error.EventID != "" && m.Error.EventID == c.responseEventID {
+		c.clearResponseRequest()
+	}
+}
+
+// clearResponseRequest marks no response.create as pending; c.mu must be held
+func (c *Client) clearResponseRequest() {
+	c.responseRequested = false
+	c.responseEventID = ""
+}
+
+// resetResponseState forgets the pending and active responses, which belong to a
+// session that has been replaced, and wakes the requests queued behind them.
+// c.mu must be held.
+func (c *Client) resetResponseState() {
+	c.clearResponseRequest()
+	c.activeResponseID = ""
+	if c.responseReset != nil {
+		close(c.responseReset)
+		c.responseReset = nil
+	}
+}
+
+// responseResetCh returns a channel that is closed when resetResponseState is called
+func (c *Client) responseResetCh() <-chan struct{} {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.responseReset == nil {
+		c.responseReset = make(chan struct{})
+	}
+	return c.responseReset
+}
+
+// waitResponseIdle blocks until no response is requested or in progress, or ctx is done
+func (c *Client) waitResponseIdle(ctx context.Context) error {
+	for {
+		// The waiter is registered before checking so the end of the response cannot be missed
+		reset := c.responseResetCh()
+		w := c.addWaiter(func(msg incoming.RcvdMsg) bool {
+			switch msg.(type) {
+			case *incoming.ResponseDoneMessage, *incoming.ErrorMessage:
+				return true
+			default:
+				return false
+			}
+		})
+		if !c.responseBusy() {
+			c.removeWaiter(w)
+			return nil
+		}
+
+		select {
+		case <-w.ch:
+		case <-reset:
+			c.removeWaiter(w)
+		case <-ctx.Done():
+			c.removeWaiter(w)
+			return fmt.Errorf("waiting for response in progress: %w", ctx.Err())
+		}
+	}
+}
+
+// readerKey marks the contexts passed to message handlers with the client whose reader
+// is blocked until they return
+type readerKey struct{}
+
+// withReader returns ctx marked as running on the reader of client
+func withReader(ctx context.Context, client *Client) context.Context {
+	return context.WithValue(ctx, readerKey{}, client)
+}
+
+// offReader returns ctx for work moved off the reader, such as a goroutine started by a
+// message handler
+func offReader(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readerKey{}, (*Client)(nil))
+}
+
+// onReader reports whether ctx runs on the reader of client
+func onReader(ctx context.Context, client *Client) bool {
+	reader, _ := ctx.Value(readerKey{}).(*Client)
+	return reader == client
+}
+
+// isConversationResponse reports whether msg is a response.create for the default conversation
+func isConversationResponse(msg outgoing.OutMsg) bool {
+	m, ok := responseCreate(msg)
+	return ok && !outOfBand(&m.Response)
+}
+
+// outOfBand reports whether config creates a response outside the default conversation
+func outOfBand(config *types.ResponseConfig) bool {
+	return config != nil && config.Conversation != nil && *config.Conversation == "none"
+}
+
+// responseCreate returns a copy of msg if it is a response.create
+func responseCreate(msg outgoing.OutMsg) (outgoing.ResponseCreateMessage, bool) {
+	switch v := msg.(type) {
+	case outgoing.ResponseCreateMessage:
+		return v, true
+	case *outgoing.ResponseCreateMessage:
+		return *v, true
+	default:
+		return outgoing.ResponseCreateMessage{}, false
+	}
+}
@@ -0,0 +1,258 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+	"github.com/Mliviu79/openai-realtime-go/messages/types"
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+func TestResponsePolicyReject(t *testing.T) {
+	conn, sent, _ := recordingConn()
+	client := NewClient(ws.NewConn(conn))
+	client.SetResponsePolicy(ResponsePolicyReject)
+	client.SetIDGenerator(NewSequentialIDs())
+	ctx := context.Background()
+
+	if err := client.SendResponseCreate(ctx, &types.ResponseConfig{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// The response is in progress before the server reports it created
+	if err := client.SendResponseCreate(ctx, &types.ResponseConfig{}); !errors.Is(err, ErrResponseInProgress) {
+		t.Fatalf("Expected ErrResponseInProgress, got %v", err)
+	}
+
+	outOfBand := "none"
+	if err := client.SendResponseCreate(ctx, &types.ResponseConfig{Conversation: &outOfBand}); err != nil {
+		t.Fatalf("Expected out-of-band responses to be sent, got %v", err)
+	}
+
+	client.observe(mustParse(t, `{"type":"response.created","response":{"id":"resp_1","status":"in_progress","conversation_id":"conv_1"}}`))
+	if err := client.SendResponseCreate(ctx, &types.ResponseConfig{}); !errors.Is(err, ErrResponseInProgress) {
+		t.Fatalf("Expected ErrResponseInProgress, got %v", err)
+	}
+
+	client.observe(mustParse(t, `{"type":"response.done","response":{"id":"resp_1","status":"completed"}}`))
+	if err := client.SendResponseCreate(ctx, &types.ResponseConfig{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Errors for other events leave the request pending
+	client.observe(mustParse(t, `{"type":"error","error":{"type":"invalid_request_error","message":"bad","event_id":"evt_other"}}`))
+	client.observe(mustParse(t, `{"type":"error","error":{"type":"invalid_request_error","message":"bad"}}`))
+	if err := client.SendResponseCreate(ctx, &types.ResponseConfig{}); !errors.Is(err, ErrResponseInProgress) {
+		t.Fatalf("Expected ErrResponseInProgress, got %v", err)
+	}
+
+	// A rejected request does not leave the client busy
+	got := sent()
+	if len(got) != 3 || !strings.Contains(got[2], `"event_id":"evt_4"`) {
+		t.Fatalf("Expected the request to be sent with an event ID, got %v", got)
+	}
+	client.observe(mustParse(t, `{"type":"error","error":{"type":"invalid_request_error","message":"bad","event_id":"evt_4"}}`))
+	if err := client.SendResponseCreate(ctx, &types.ResponseConfig{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if got := sent(); len(got) != 4 {
+		t.Errorf("Expected 4 messages sent, got %v", got)
+	}
+}
+
+func TestResponsePolicyQueue(t *testing.T) {
+	conn, sent, _ := recordingConn()
+	client := NewClient(ws.NewConn(conn))
+	client.SetResponsePolicy(ResponsePolicyQueue)
+	ctx := context.Background()
+
+	client.observe(mustParse(t, `{"type":"response.created","response":{"id":"resp_1","status":"in_progress","conversation_id":"conv_1"}}`))
+
+	done := make(chan error, 1)
+	go func() { done <- client.SendResponseCreate(ctx, &types.ResponseConfig{}) }()
+
+	select {
+	case err := <-done:
+		t.Fatalf("Expected the response to be queued, got %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	if got := sent(); len(got) != 0 {
+		t.Fatalf("Expected nothing sent while queued, got %v", got)
+	}
+
+	client.observe(mustParse(t, `{"type":"response.done","response":{"id":"resp_1","status":"completed"}}`))
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the queued response to be sent")
+	}
+
+	timeout, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	if err := client.SendResponseCreate(timeout, &types.ResponseConfig{}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected the queued response to time out, got %v", err)
+	}
+}
+
+func TestResponsePolicyCancel(t *testing.T) {
+	conn, sent, _ := recordingConn()
+	client := NewClient(ws.NewConn(conn))
+	client.SetResponsePolicy(ResponsePolicyCancel)
+	ctx := context.Background()
+
+	client.SetIDGenerator(NewSequentialIDs())
+	client.observe(mustParse(t, `{"type":"response.created","response":{"id":"resp_1","status":"in_progress","conversation_id":"conv_1"}}`))
+
+	done := make(chan error, 1)
+	go func() { done <- client.SendResponseCreate(ctx, &types.ResponseConfig{}) }()
+
+	// The new response waits for the cancelled one to be done
+	deadline := time.Now().Add(time.Second)
+	for len(sent()) == 0 && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	select {
+	case err := <-done:
+		t.Fatalf("Expected the response to wait for the cancellation, got %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	if got := sent(); len(got) != 1 || got[0] != `{"type":"response.cancel","response_id":"resp_1"}` {
+		t.Fatalf("Expected only the cancel to be sent, got %v", got)
+	}
+
+	client.observe(mustParse(t, `{"type":"response.done","response":{"id":"resp_1","status":"cancelled"}}`))
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the response to be sent once the cancelled one was done")
+	}
+	if got := sent(); len(got) != 2 || got[1] != `{"event_id":"evt_1","type":"response.create","response":{}}` {
+		t.Errorf("Expected the response to be sent after the cancel, got %v", got)
+	}
+}
+
+func TestResponsePolicyHandoffReset(t *testing.T) {
+	conn, sent, _ := recordingConn()
+	client := NewClient(ws.NewConn(conn))
+	client.SetResponsePolicy(ResponsePolicyQueue)
+	ctx := context.Background()
+
+	client.observe(mustParse(t, `{"type":"response.created","response":{"id":"resp_1","status":"in_progress","conversation_id":"conv_1"}}`))
+	done := make(chan error, 1)
+	go func() { done <- client.SendResponseCreate(ctx, &types.ResponseConfig{}) }()
+	select {
+	case err := <-done:
+		t.Fatalf("Expected the response to be queued, got %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// The new session never reports the old response done
+	stagingConn, stagingSent, _ := recordingConn()
+	staging := NewClient(ws.NewConn(stagingConn))
+	client.swapConn(staging)
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the queued response to be sent after the handoff")
+	}
+	if _, ok := client.ActiveResponseID(); ok {
+		t.Error("Expected no active response after the handoff")
+	}
+	if len(sent()) != 0 || len(stagingSent()) != 1 {
+		t.Errorf("Expected the response to be sent on the new session, got %v and %v", sent(), stagingSent())
+	}
+}
+
+func TestResponsePolicyIgnoresOutOfBand(t *testing.T) {
+	conn, _, _ := recordingConn()
+	client := NewClient(ws.NewConn(conn))
+	client.SetResponsePolicy(ResponsePolicyReject)
+	ctx := context.Background()
+
+	// An out-of-band response alone does not make the conversation busy
+	client.observe(mustParse(t, `{"type":"response.created","response":{"id":"resp_1","status":"in_progress"}}`))
+	if err := client.SendResponseCreate(ctx, &types.ResponseConfig{}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// Nor does it end the pending in-band request
+	client.observe(mustParse(t, `{"type":"response.created","response":{"id":"resp_2","status":"in_progress"}}`))
+	client.observe(mustParse(t, `{"type":"response.done","response":{"id":"resp_2","status":"completed"}}`))
+	if err := client.SendResponseCreate(ctx, &types.ResponseConfig{}); !errors.Is(err, ErrResponseInProgress) {
+		t.Fatalf("Expected ErrResponseInProgress, got %v", err)
+	}
+
+	client.observe(mustParse(t, `{"type":"response.created","response":{"id":"resp_3","status":"in_progress","conversation_id":"conv_1"}}`))
+	client.observe(mustParse(t, `{"type":"response.done","response":{"id":"resp_3","status":"completed"}}`))
+	if err := client.SendResponseCreate(ctx, &types.ResponseConfig{}); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestResponsePolicyWaitInHandler(t *testing.T) {
+	reads := make(chan string, 1)
+	reads <- `{"type":"response.created","response":{"id":"resp_1","status":"in_progress","conversation_id":"conv_1"}}`
+	conn := &MockConn{
+		ReadMessageFunc: func(ctx context.Context) (ws.MessageType, []byte, error) {
+			select {
+			case data := <-reads:
+				return ws.MessageText, []byte(data), nil
+			case <-ctx.Done():
+				return ws.MessageText, nil, ctx.Err()
+			}
+		},
+		WriteMessageFunc: func(ctx context.Context, messageType ws.MessageType, data []byte) error {
+			return nil
+		},
+	}
+	client := NewClient(ws.NewConn(conn))
+
+	for _, policy := range []ResponsePolicy{ResponsePolicyQueue, ResponsePolicyCancel} {
+		client.SetResponsePolicy(policy)
+		client.observe(mustParse(t, `{"type":"response.created","response":{"id":"resp_1","status":"in_progress","conversation_id":"conv_1"}}`))
+		if err := client.SendResponseCreate(withReader(context.Background(), client), &types.ResponseConfig{}); !errors.Is(err, ErrWaitInHandler) {
+			t.Errorf("%s: expected ErrWaitInHandler, got %v", policy, err)
+		}
+		client.observe(mustParse(t, `{"type":"response.done","response":{"id":"resp_1","status":"completed"}}`))
+	}
+
+	// A handler's response.create fails instead of blocking the reader
+	client.SetResponsePolicy(ResponsePolicyQueue)
+	result := make(chan error, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	handler := NewHandler(ctx, client, func(ctx context.Context, msg incoming.RcvdMsg) {
+		if _, ok := msg.(*incoming.ResponseCreatedMessage); ok {
+			result <- client.SendResponseCreate(ctx, &types.ResponseConfig{})
+		}
+	})
+	handler.Start()
+	defer handler.Stop()
+
+	select {
+	case err := <-result:
+		if !errors.Is(err, ErrWaitInHandler) {
+			t.Errorf("Expected ErrWaitInHandler, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the handler's response.create to return")
+	}
+
+	// Work moved off the reader still waits for the response in progress
+	if onReader(offReader(withReader(ctx, client)), client) {
+		t.Error("Expected offReader to unmark the context")
+	}
+}
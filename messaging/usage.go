@@ -0,0 +1,251 @@
+package messaging
+
+import (
+	"context"
+	"maps"
+	"sync"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/clock"
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+	"github.com/Mliviu79/openai-realtime-go/messages/types"
+)
+
+//-----------------------------------------------------------------------------
+// Usage Aggregation
+//-----------------------------------------------------------------------------
+
+// Pricing holds per-token prices used to estimate the cost of a session.
+// Prices are in US dollars per one million tokens. Cached input tokens are
+// charged at the cached rate instead of the regular input rate.
+type Pricing struct {
+	TextInput        float64
+	CachedTextInput  float64
+	AudioInput       float64
+	CachedAudioInput float64
+	TextOutput       float64
+	AudioOutput      float64
+}
+
+// Cost returns the estimated cost in US dollars of the given usage
+func (p Pricing) Cost(usage types.Usage) float64 {
+	in := usage.InputTokenDetails
+	cachedText := in.CachedTokensDetails.TextTokens
+	cachedAudio := in.CachedTokensDetails.AudioTokens
+
+	cost := float64(in.TextTokens-cachedText)*p.TextInput +
+		float64(cachedText)*p.CachedTextInput +
+		float64(in.AudioTokens-cachedAudio)*p.AudioInput +
+		float64(cachedAudio)*p.CachedAudioInput +
+		float64(usage.OutputTokenDetails.TextTokens)*p.TextOutput +
+		float64(usage.OutputTokenDetails.AudioTokens)*p.AudioOutput
+	return cost / 1_000_000
+}
+
+// UsageAggregator sums the token usage reported by response.done across a session.
+//
+// Example:
+//
+//	usage := messaging.NewUsageAggregator()
+//	handler := messaging.NewHandler(ctx, msgClient, usage.Handle)
+//	// ...
+//	log.Printf("used %d tokens", usage.Totals().TotalTokens)
+type UsageAggregator struct {
+	mu        sync.Mutex
+	totals    types.Usage
+	responses int
+	listeners []func(types.Usage)
+}
+
+// NewUsageAggregator creates an empty UsageAggregator
+func NewUsageAggregator() *UsageAggregator {
+	return &UsageAggregator{}
+}
+
+// Handle adds the usage of each response.done to the totals. Other messages are ignored.
+func (a *UsageAggregator) Handle(ctx context.Context, msg incoming.RcvdMsg) {
+	if m, ok := msg.(*incoming.ResponseDoneMessage); ok && m.Response.Usage != nil {
+		a.Add(*m.Response.Usage)
+	}
+}
+
+// Add adds usage to the totals and notifies the listeners registered with OnUpdate
+func (a *UsageAggregator) Add(usage types.Usage) {
+	a.mu.Lock()
+	a.totals.Add(usage)
+	a.responses++
+	totals := a.totals
+	listeners := a.listeners
+	a.mu.Unlock()
+
+	for _, listener := range listeners {
+		listener(totals)
+	}
+}
+
+// OnUpdate registers a function that is called with the new totals whenever usage is added.
+// Listeners are called synchronously from Add and must not block.
+func (a *UsageAggregator) OnUpdate(listener func(totals types.Usage)) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.listeners = append(a.listeners, listener)
+}
+
+// Totals returns the usage summed over all responses so far
+func (a *UsageAggregator) Totals() types.Usage {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.totals
+}
+
+// Responses returns the number of responses whose usage has been added
+func (a *UsageAggregator) Responses() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.responses
+}
+
+// EstimatedCost returns the estimated cost in US dollars of the usage so far
+func (a *UsageAggregator) EstimatedCost(pricing Pricing) float64 {
+	return pricing.Cost(a.Totals())
+}
+
+//-----------------------------------------------------------------------------
+// Usage Reporting
+//-----------------------------------------------------------------------------
+
+// UsageReport is the usage of one response, reported as soon as the response is done
+type UsageReport struct {
+	// SessionID identifies the session, once session.created has been seen
+	SessionID string
+
+	// ResponseID identifies the response
+	ResponseID string
+
+	// Status is the final status of the response; cancelled and failed responses can use tokens too
+	Status types.ResponseStatus
+
+	// Metadata is the metadata the response was created with
+	Metadata map[string]string
+
+	// Labels are the labels set with WithUsageLabels, such as a tenant or customer ID
+	Labels map[string]string
+
+	// Usage is the token usage of the response, including the cached input token details
+	Usage types.Usage
+
+	// EstimatedCost is the cost in US dollars estimated with the pricing set with
+	// WithUsagePricing, or zero without one
+	EstimatedCost float64
+
+	// Time is when response.done was received
+	Time time.Time
+}
+
+// UsageReporter receives the usage of each response, e.g. to meter customers in real time
+type UsageReporter interface {
+	ReportUsage(ctx context.Context, report UsageReport)
+}
+
+// UsageReporterFunc adapts a function to the UsageReporter interface
+type UsageReporterFunc func(ctx context.Context, report UsageReport)
+
+// ReportUsage calls f
+func (f UsageReporterFunc) ReportUsage(ctx context.Context, report UsageReport) {
+	f(ctx, report)
+}
+
+// UsageReportingOption configures a UsageReporting
+type UsageReportingOption func(*UsageReporting)
+
+// WithUsageLabels attaches labels, such as a tenant or customer ID, to every report
+func WithUsageLabels(labels map[string]string) UsageReportingOption {
+	return func(r *UsageReporting) {
+		r.labels = maps.Clone(labels)
+	}
+}
+
+// WithUsagePricing sets the pricing used for the EstimatedCost of each report
+func WithUsagePricing(pricing Pricing) UsageReportingOption {
+	return func(r *UsageReporting) {
+		r.pricing = &pricing
+	}
+}
+
+// WithUsageClock sets the clock used for the Time of each report; the default is the system clock
+func WithUsageClock(c clock.Clock) UsageReportingOption {
+	return func(r *UsageReporting) {
+		if c != nil {
+			r.clock = c
+		}
+	}
+}
+
+// UsageReporting passes the usage of every response to a UsageReporter as the responses
+// finish, so multi-tenant platforms can meter customers without post-processing logs.
+// Responses without usage are not reported.
+//
+// The reporter is called synchronously from Handle, so it should be fast or buffer.
+//
+// Example:
+//
+//	reporting := messaging.NewUsageReporting(
+//		messaging.UsageReporterFunc(func(ctx context.Context, report messaging.UsageReport) {
+//			meter.Record(report.Labels["tenant"], report.Usage.TotalTokens)
+//		}),
+//		messaging.WithUsageLabels(map[string]string{"tenant": tenantID}),
+//	)
+//	handler := messaging.NewHandler(ctx, msgClient, reporting.Handle)
+type UsageReporting struct {
+	reporter UsageReporter
+	labels   map[string]string
+	pricing  *Pricing
+	clock    clock.Clock
+
+	mu        sync.Mutex
+	sessionID string
+}
+
+// NewUsageReporting creates a UsageReporting that reports to reporter
+func NewUsageReporting(reporter UsageReporter, opts ...UsageReportingOption) *UsageReporting {
+	r := &UsageReporting{
+		reporter: reporter,
+		clock:    clock.Real(),
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
+}
+
+// Handle remembers the session ID from session.created and reports the usage of each
+// response.done that carries usage. Other messages are ignored.
+func (r *UsageReporting) Handle(ctx context.Context, msg incoming.RcvdMsg) {
+	switch m := msg.(type) {
+	case *incoming.SessionCreatedMessage:
+		r.mu.Lock()
+		r.sessionID = m.Session.ID
+		r.mu.Unlock()
+	case *incoming.ResponseDoneMessage:
+		if m.Response.Usage == nil {
+			return
+		}
+		r.mu.Lock()
+		sessionID := r.sessionID
+		r.mu.Unlock()
+
+		report := UsageReport{
+			SessionID:  sessionID,
+			ResponseID: m.Response.ID,
+			Status:     m.Response.Status,
+			Metadata:   maps.Clone(m.Response.Metadata),
+			Labels:     maps.Clone(r.labels),
+			Usage:      *m.Response.Usage,
+			Time:       r.clock.Now(),
+		}
+		if r.pricing != nil {
+			report.EstimatedCost = r.pricing.Cost(report.Usage)
+		}
+		r.reporter.ReportUsage(ctx, report)
+	}
+}
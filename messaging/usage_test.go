@@ -0,0 +1,120 @@
+package messaging
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/clock"
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+	"github.com/Mliviu79/openai-realtime-go/messages/types"
+)
+
+// mustParse unmarshals a server message or fails the test
+func mustParse(t *testing.T, data string) incoming.RcvdMsg {
+	t.Helper()
+	msg, err := incoming.UnmarshalRcvdMsg([]byte(data))
+	if err != nil {
+		t.Fatalf("Failed to parse %s: %v", data, err)
+	}
+	return msg
+}
+
+func TestUsageAggregator(t *testing.T) {
+	usage := NewUsageAggregator()
+	var updates []int
+	usage.OnUpdate(func(totals types.Usage) {
+		updates = append(updates, totals.TotalTokens)
+	})
+
+	ctx := context.Background()
+	usage.Handle(ctx, mustParse(t, `{"type":"response.done","response":{"id":"resp_1","status":"completed","usage":{"total_tokens":30,"input_tokens":20,"output_tokens":10,"input_token_details":{"cached_tokens":5,"text_tokens":15,"audio_tokens":5,"cached_tokens_details":{"text_tokens":5}},"output_token_details":{"text_tokens":4,"audio_tokens":6}}}}`))
+	usage.Handle(ctx, mustParse(t, `{"type":"response.created","response":{"id":"resp_2","status":"in_progress"}}`))
+	usage.Handle(ctx, mustParse(t, `{"type":"response.done","response":{"id":"resp_2","status":"completed","usage":{"total_tokens":12,"input_tokens":10,"output_tokens":2,"input_token_details":{"text_tokens":10},"output_token_details":{"text_tokens":2}}}}`))
+
+	totals := usage.Totals()
+	if totals.TotalTokens != 42 {
+		t.Errorf("Expected 42 total tokens, got %d", totals.TotalTokens)
+	}
+	if totals.InputTokenDetails.TextTokens != 25 {
+		t.Errorf("Expected 25 input text tokens, got %d", totals.InputTokenDetails.TextTokens)
+	}
+	if totals.OutputTokenDetails.AudioTokens != 6 {
+		t.Errorf("Expected 6 output audio tokens, got %d", totals.OutputTokenDetails.AudioTokens)
+	}
+	if usage.Responses() != 2 {
+		t.Errorf("Expected 2 responses, got %d", usage.Responses())
+	}
+	if len(updates) != 2 || updates[1] != 42 {
+		t.Errorf("Expected updates [30 42], got %v", updates)
+	}
+}
+
+func TestPricingCost(t *testing.T) {
+	pricing := Pricing{
+		TextInput:       4,
+		CachedTextInput: 0.5,
+		AudioInput:      40,
+		TextOutput:      16,
+		AudioOutput:     80,
+	}
+	usage := types.Usage{
+		InputTokenDetails: types.InputTokenDetails{
+			TextTokens:          1_000_000,
+			AudioTokens:         100_000,
+			CachedTokensDetails: types.TokenDetails{TextTokens: 500_000},
+		},
+		OutputTokenDetails: types.TokenDetails{TextTokens: 100_000, AudioTokens: 50_000},
+	}
+
+	// 0.5M text at $4 + 0.5M cached at $0.5 + 0.1M audio at $40 + 0.1M text out at $16 + 0.05M audio out at $80
+	expected := 2 + 0.25 + 4 + 1.6 + 4.0
+	if got := pricing.Cost(usage); math.Abs(got-expected) > 1e-9 {
+		t.Errorf("Expected cost %.4f, got %.4f", expected, got)
+	}
+}
+
+func TestUsageReporting(t *testing.T) {
+	fake := clock.NewFake(time.Unix(1700000000, 0))
+	var reports []UsageReport
+	reporting := NewUsageReporting(
+		UsageReporterFunc(func(ctx context.Context, report UsageReport) {
+			reports = append(reports, report)
+		}),
+		WithUsageLabels(map[string]string{"tenant": "acme"}),
+		WithUsagePricing(Pricing{TextInput: 4, CachedTextInput: 0.5}),
+		WithUsageClock(fake),
+	)
+
+	ctx := context.Background()
+	reporting.Handle(ctx, mustParse(t, `{"type":"session.created","session":{"id":"sess_1"}}`))
+	reporting.Handle(ctx, mustParse(t, `{"type":"response.done","response":{"id":"resp_1","status":"completed","metadata":{"topic":"billing"},"usage":{"total_tokens":1000000,"input_tokens":1000000,"input_token_details":{"cached_tokens":500000,"text_tokens":1000000,"cached_tokens_details":{"text_tokens":500000}}}}}`))
+	// Responses without usage are not reported
+	reporting.Handle(ctx, mustParse(t, `{"type":"response.done","response":{"id":"resp_2","status":"failed"}}`))
+	reporting.Handle(ctx, mustParse(t, `{"type":"response.done","response":{"id":"resp_3","status":"cancelled","usage":{"total_tokens":12}}}`))
+
+	if len(reports) != 2 {
+		t.Fatalf("Expected 2 reports, got %d", len(reports))
+	}
+	report := reports[0]
+	if report.SessionID != "sess_1" || report.ResponseID != "resp_1" || report.Status != types.ResponseStatusCompleted {
+		t.Errorf("Expected resp_1 of sess_1 completed, got %+v", report)
+	}
+	if report.Labels["tenant"] != "acme" || report.Metadata["topic"] != "billing" {
+		t.Errorf("Expected the labels and metadata, got %v %v", report.Labels, report.Metadata)
+	}
+	if report.Usage.InputTokenDetails.CachedTokensDetails.TextTokens != 500000 {
+		t.Errorf("Expected the cached token details, got %+v", report.Usage.InputTokenDetails)
+	}
+	// 0.5M text at $4 + 0.5M cached at $0.5
+	if math.Abs(report.EstimatedCost-2.25) > 1e-9 {
+		t.Errorf("Expected cost 2.25, got %.4f", report.EstimatedCost)
+	}
+	if !report.Time.Equal(fake.Now()) {
+		t.Errorf("Expected the report time %v, got %v", fake.Now(), report.Time)
+	}
+	if reports[1].Status != types.ResponseStatusCancelled || reports[1].Usage.TotalTokens != 12 {
+		t.Errorf("Expected the cancelled response to be reported, got %+v", reports[1])
+	}
+}
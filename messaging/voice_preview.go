@@ -0,0 +1,135 @@
+package messaging
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/Mliviu79/openai-realtime-go/messages/types"
+	"github.com/Mliviu79/openai-realtime-go/session"
+)
+
+//-----------------------------------------------------------------------------
+// Voice Previews
+//-----------------------------------------------------------------------------
+
+// DefaultPreviewSentence is the sentence PreviewVoices has each voice say by default
+const DefaultPreviewSentence = "Hi there! This is how I sound. How can I help you today?"
+
+// previewInstructions tell the model to read the preview sentence and nothing else
+const previewInstructions = "Read the user's text aloud exactly as written, in a natural, friendly tone. Do not add anything."
+
+// VoicePreview is a sample of a voice saying the preview sentence
+type VoicePreview struct {
+	// Voice is the voice that was sampled
+	Voice session.Voice
+
+	// Audio is the decoded audio, in the session's output audio format
+	Audio []byte
+
+	// Transcript is the transcript of the audio, which may differ slightly from the sentence
+	Transcript string
+
+	// ResponseID identifies the out-of-band response that produced the sample
+	ResponseID string
+}
+
+// VoicePreviewOption configures PreviewVoices
+type VoicePreviewOption func(*voicePreviewOptions)
+
+// voicePreviewOptions holds the configuration for a PreviewVoices call
+type voicePreviewOptions struct {
+	sentence string
+}
+
+// WithPreviewSentence sets the sentence each voice says; the default is DefaultPreviewSentence
+func WithPreviewSentence(sentence string) VoicePreviewOption {
+	return func(o *voicePreviewOptions) {
+		if sentence != "" {
+			o.sentence = sentence
+		}
+	}
+}
+
+// PreviewVoices has each voice say a sample sentence in an out-of-band, audio-only
+// response and returns the audio, in the order of voices, so products can offer a voice
+// picker. The responses are not added to the conversation and the session's voice is
+// left unchanged.
+//
+// Responses are requested one at a time with StreamResponse, so, like StreamResponse,
+// PreviewVoices reads from the connection itself and must not be used while another
+// goroutine (such as a Handler) is reading from the same client. It stops at the first
+// voice that fails and returns the previews made so far with the error.
+//
+// Example:
+//
+//	previews, err := msgClient.PreviewVoices(ctx, []session.Voice{session.VoiceAlloy, session.VoiceCoral})
+//	for _, preview := range previews {
+//		play(preview.Audio)
+//	}
+func (c *Client) PreviewVoices(ctx context.Context, voices []session.Voice, opts ...VoicePreviewOption) ([]VoicePreview, error) {
+	options := voicePreviewOptions{sentence: DefaultPreviewSentence}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	previews := make([]VoicePreview, 0, len(voices))
+	for _, voice := range voices {
+		preview, err := c.previewVoice(ctx, voice, options.sentence)
+		if err != nil {
+			return previews, fmt.Errorf("previewing voice %s: %w", voice, err)
+		}
+		previews = append(previews, preview)
+	}
+	return previews, nil
+}
+
+// previewVoice streams one out-of-band response in voice and collects its audio
+func (c *Client) previewVoice(ctx context.Context, voice session.Voice, sentence string) (VoicePreview, error) {
+	none := "none"
+	instructions := previewInstructions
+	role := types.MessageRoleUser
+	config := &types.ResponseConfig{
+		Modalities: []session.Modality{session.ModalityAudio},
+		ResponseParams: session.ResponseParams{
+			Instructions: &instructions,
+			Voice:        &voice,
+		},
+		Conversation: &none,
+		Metadata:     map[string]string{"purpose": "voice_preview"},
+		Input: []types.ConversationItem{{
+			Type: types.MessageItemTypeMessage,
+			Role: &role,
+			Content: []types.MessageContentPart{{
+				Type: types.MessageContentTypeInputText,
+				Text: sentence,
+			}},
+		}},
+	}
+
+	preview := VoicePreview{Voice: voice}
+	var transcript strings.Builder
+	for delta, err := range c.StreamResponse(ctx, config, WithCancelOnContextDone(0)) {
+		if err != nil {
+			return preview, err
+		}
+		switch delta.Type {
+		case DeltaTypeAudio:
+			data, err := base64.StdEncoding.DecodeString(delta.Data)
+			if err != nil {
+				return preview, fmt.Errorf("decoding audio: %w", err)
+			}
+			preview.Audio = append(preview.Audio, data...)
+		case DeltaTypeTranscript:
+			transcript.WriteString(delta.Data)
+		case DeltaTypeDone:
+			preview.ResponseID = delta.Response.ID
+			if delta.Response.Status != types.ResponseStatusCompleted {
+				return preview, fmt.Errorf("response %s ended with status %s", delta.Response.ID, delta.Response.Status)
+			}
+		}
+	}
+	preview.Transcript = transcript.String()
+	return preview, nil
+}
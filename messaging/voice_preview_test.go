@@ -0,0 +1,98 @@
+package messaging
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Mliviu79/openai-realtime-go/session"
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+// voiceConn answers every response.create with a short audio response in the requested
+// voice, whose audio is the voice name
+func voiceConn(t *testing.T, requests *[]string) *MockConn {
+	replies := make(chan string, 16)
+	return &MockConn{
+		ReadMessageFunc: func(ctx context.Context) (ws.MessageType, []byte, error) {
+			select {
+			case data := <-replies:
+				return ws.MessageText, []byte(data), nil
+			case <-ctx.Done():
+				return ws.MessageText, nil, ctx.Err()
+			}
+		},
+		WriteMessageFunc: func(ctx context.Context, messageType ws.MessageType, data []byte) error {
+			*requests = append(*requests, string(data))
+			var event struct {
+				Response struct {
+					Voice string `json:"voice"`
+				} `json:"response"`
+			}
+			if err := json.Unmarshal(data, &event); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			voice := event.Response.Voice
+			id := "resp_" + voice
+			status := "completed"
+			if voice == "ash" {
+				status = "failed"
+			}
+			audio := base64.StdEncoding.EncodeToString([]byte(voice))
+			replies <- fmt.Sprintf(`{"type":"response.created","response":{"id":%q,"status":"in_progress"}}`, id)
+			replies <- fmt.Sprintf(`{"type":"response.output_audio.delta","response_id":%q,"item_id":"item_1","delta":%q}`, id, audio)
+			replies <- fmt.Sprintf(`{"type":"response.output_audio_transcript.delta","response_id":%q,"item_id":"item_1","delta":"Hi"}`, id)
+			replies <- fmt.Sprintf(`{"type":"response.done","response":{"id":%q,"status":%q}}`, id, status)
+			return nil
+		},
+	}
+}
+
+func TestPreviewVoices(t *testing.T) {
+	var requests []string
+	client := NewClient(ws.NewConn(voiceConn(t, &requests)))
+
+	previews, err := client.PreviewVoices(context.Background(),
+		[]session.Voice{session.VoiceAlloy, session.VoiceCoral},
+		WithPreviewSentence("Welcome aboard."),
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(previews) != 2 {
+		t.Fatalf("Expected 2 previews, got %d", len(previews))
+	}
+	for i, voice := range []session.Voice{session.VoiceAlloy, session.VoiceCoral} {
+		if previews[i].Voice != voice || string(previews[i].Audio) != string(voice) || previews[i].Transcript != "Hi" {
+			t.Errorf("Expected the %s preview, got %+v", voice, previews[i])
+		}
+	}
+
+	request := requests[0]
+	for _, expected := range []string{`"conversation":"none"`, `"modalities":["audio"]`, `"voice":"alloy"`, `"text":"Welcome aboard."`} {
+		if !strings.Contains(request, expected) {
+			t.Errorf("Expected the request to contain %s, got %s", expected, request)
+		}
+	}
+}
+
+func TestPreviewVoicesStopsAtFailure(t *testing.T) {
+	var requests []string
+	client := NewClient(ws.NewConn(voiceConn(t, &requests)))
+
+	previews, err := client.PreviewVoices(context.Background(),
+		[]session.Voice{session.VoiceAlloy, session.VoiceAsh, session.VoiceCoral},
+	)
+	if err == nil || !strings.Contains(err.Error(), "ash") {
+		t.Fatalf("Expected the ash preview to fail, got %v", err)
+	}
+	if len(previews) != 1 || len(requests) != 2 {
+		t.Errorf("Expected to stop after the failure, got %d previews and %d requests", len(previews), len(requests))
+	}
+	if !strings.Contains(requests[0], DefaultPreviewSentence) {
+		t.Errorf("Expected the default sentence, got %s", requests[0])
+	}
+}
@@ -0,0 +1,80 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+)
+
+//-----------------------------------------------------------------------------
+// Message Waiters
+//-----------------------------------------------------------------------------
+
+// WaitFor blocks until an incoming message matches predicate and returns it, or until
+// ctx is done. A nil predicate matches the next message. Use a context with a timeout
+// to bound the wait.
+//
+// WaitFor does not read from the connection; it observes the messages read by a running
+// Handler or ReadMessage loop, so it can be used alongside the dispatcher. Only messages
+// read after WaitFor is called are considered.
+func (c *Client) WaitFor(ctx context.Context, predicate func(incoming.RcvdMsg) bool) (incoming.RcvdMsg, error) {
+	if predicate == nil {
+		predicate = func(incoming.RcvdMsg) bool { return true }
+	}
+	w := c.addWaiter(predicate)
+	defer c.removeWaiter(w)
+
+	select {
+	case msg := <-w.ch:
+		return msg, nil
+	case <-ctx.Done():
+		return nil, fmt.Errorf("waiting for message: %w", ctx.Err())
+	}
+}
+
+// MessageOfType returns a WaitFor predicate matching messages of the given type
+func MessageOfType(msgType incoming.RcvdMsgType) func(incoming.RcvdMsg) bool {
+	return func(msg incoming.RcvdMsg) bool {
+		return msg.RcvdMsgType() == msgType
+	}
+}
+
+// waiter receives the first incoming message matching its predicate
+type waiter struct {
+	match func(incoming.RcvdMsg) bool
+	ch    chan incoming.RcvdMsg
+}
+
+// addWaiter registers a waiter for the first message matching match.
+// It must be registered before the message that triggers the reply is sent,
+// and removed with removeWaiter when it is no longer needed.
+func (c *Client) addWaiter(match func(incoming.RcvdMsg) bool) *waiter {
+	w := &waiter{match: match, ch: make(chan incoming.RcvdMsg, 1)}
+	c.waitersMu.Lock()
+	defer c.waitersMu.Unlock()
+	if c.waiters == nil {
+		c.waiters = make(map[*waiter]struct{})
+	}
+	c.waiters[w] = struct{}{}
+	return w
+}
+
+// removeWaiter unregisters a waiter
+func (c *Client) removeWaiter(w *waiter) {
+	c.waitersMu.Lock()
+	defer c.waitersMu.Unlock()
+	delete(c.waiters, w)
+}
+
+// notifyWaiters delivers msg to every waiter it matches; each waiter receives at most one message
+func (c *Client) notifyWaiters(msg incoming.RcvdMsg) {
+	c.waitersMu.Lock()
+	defer c.waitersMu.Unlock()
+	for w := range c.waiters {
+		if w.match(msg) {
+			w.ch <- msg
+			delete(c.waiters, w)
+		}
+	}
+}
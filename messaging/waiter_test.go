@@ -0,0 +1,126 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+// waitForWaiter blocks until a waiter is registered on the client
+func waitForWaiter(client *Client) {
+	for {
+		client.waitersMu.Lock()
+		n := len(client.waiters)
+		client.waitersMu.Unlock()
+		if n > 0 {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestWaitForWithHandler(t *testing.T) {
+	release := make(chan struct{})
+	mockConn := newScriptedConn(
+		`{"type":"session.created","session":{"id":"sess_1"}}`,
+		`{"type":"session.updated","session":{"id":"sess_2"}}`,
+	)
+	read := mockConn.ReadMessageFunc
+	mockConn.ReadMessageFunc = func(ctx context.Context) (ws.MessageType, []byte, error) {
+		select {
+		case <-release:
+		case <-ctx.Done():
+			return ws.MessageText, nil, ctx.Err()
+		}
+		return read(ctx)
+	}
+	client := NewClient(ws.NewConn(mockConn))
+
+	var handled []incoming.RcvdMsgType
+	handledCh := make(chan struct{}, 2)
+	handler := NewHandler(context.Background(), client, func(ctx context.Context, msg incoming.RcvdMsg) {
+		handled = append(handled, msg.RcvdMsgType())
+		handledCh <- struct{}{}
+	})
+	handler.Start()
+	defer handler.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	go func() {
+		waitForWaiter(client)
+		close(release)
+	}()
+	msg, err := client.WaitFor(ctx, MessageOfType(incoming.RcvdMsgTypeSessionUpdated))
+	if err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	updated, ok := msg.(*incoming.SessionUpdatedMessage)
+	if !ok {
+		t.Fatalf("Expected *incoming.SessionUpdatedMessage, got %T", msg)
+	}
+	if updated.Session.ID != "sess_2" {
+		t.Errorf("Expected session ID %q, got %q", "sess_2", updated.Session.ID)
+	}
+
+	// The dispatcher still receives every message
+	for i := 0; i < 2; i++ {
+		select {
+		case <-handledCh:
+		case <-time.After(time.Second):
+			t.Fatal("Timed out waiting for the handler")
+		}
+	}
+	if len(handled) != 2 {
+		t.Errorf("Expected 2 handled messages, got %d", len(handled))
+	}
+}
+
+func TestWaitForTimeout(t *testing.T) {
+	client := NewClient(ws.NewConn(&MockConn{}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	msg, err := client.WaitFor(ctx, MessageOfType(incoming.RcvdMsgTypeSessionUpdated))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected %v, got %v", context.DeadlineExceeded, err)
+	}
+	if msg != nil {
+		t.Errorf("Expected nil message, got %v", msg)
+	}
+	if len(client.waiters) != 0 {
+		t.Errorf("Expected waiters to be removed, got %d", len(client.waiters))
+	}
+}
+
+func TestWaitForNilPredicate(t *testing.T) {
+	client := NewClient(ws.NewConn(newScriptedConn(
+		`{"type":"input_audio_buffer.cleared","event_id":"event_1"}`,
+	)))
+
+	result := make(chan incoming.RcvdMsg, 1)
+	go func() {
+		msg, _ := client.WaitFor(context.Background(), nil)
+		result <- msg
+	}()
+
+	waitForWaiter(client)
+	if _, err := client.ReadMessage(context.Background()); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+
+	select {
+	case msg := <-result:
+		if msg.RcvdMsgType() != incoming.RcvdMsgTypeAudioBufferCleared {
+			t.Errorf("Expected %s, got %s", incoming.RcvdMsgTypeAudioBufferCleared, msg.RcvdMsgType())
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for WaitFor")
+	}
+}
@@ -0,0 +1,295 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/clock"
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+)
+
+//-----------------------------------------------------------------------------
+// Session Duration Watchdog
+//-----------------------------------------------------------------------------
+
+const (
+	// DefaultWatchdogWarning is how long before the deadline a SessionWatchdog warns
+	DefaultWatchdogWarning = 30 * time.Second
+
+	// DefaultDrainTimeout is how long a SessionWatchdog waits for active responses to finish before closing
+	DefaultDrainTimeout = 10 * time.Second
+)
+
+// SessionTimeoutError is the reason a SessionWatchdog closed a session
+type SessionTimeoutError struct {
+	// Deadline is when the session had to end
+	Deadline time.Time
+
+	// SessionExpiry is true if the deadline was the server-reported session expiry
+	// rather than the configured maximum duration
+	SessionExpiry bool
+}
+
+// Error implements the error interface
+func (e *SessionTimeoutError) Error() string {
+	if e.SessionExpiry {
+		return fmt.Sprintf("session closed: session expires at %s", e.Deadline.Format(time.RFC3339))
+	}
+	return fmt.Sprintf("session closed: maximum duration reached at %s", e.Deadline.Format(time.RFC3339))
+}
+
+// WatchdogOption configures a SessionWatchdog
+type WatchdogOption func(*SessionWatchdog)
+
+// WithWatchdogWarning calls warn once, the given duration before the deadline, with the time remaining.
+// Use it to tell the user the session is about to end. Zero or less uses DefaultWatchdogWarning.
+func WithWatchdogWarning(before time.Duration, warn func(remaining time.Duration)) WatchdogOption {
+	return func(w *SessionWatchdog) {
+		if before <= 0 {
+			before = DefaultWatchdogWarning
+		}
+		w.warnBefore = before
+		w.warn = warn
+	}
+}
+
+// WithDrainTimeout sets how long to wait for active responses to finish at the deadline
+// before closing. Zero or less uses DefaultDrainTimeout.
+func WithDrainTimeout(timeout time.Duration) WatchdogOption {
+	return func(w *SessionWatchdog) {
+		if timeout <= 0 {
+			timeout = DefaultDrainTimeout
+		}
+		w.drainTimeout = timeout
+	}
+}
+
+// WithOnSessionTimeout sets a function that is called with the reason once the session is closed
+func WithOnSessionTimeout(onTimeout func(reason *SessionTimeoutError)) WatchdogOption {
+	return func(w *SessionWatchdog) {
+		w.onTimeout = onTimeout
+	}
+}
+
+// WithWatchdogClock sets the clock used for the deadline, the warning and the drain timeout,
+// e.g. a clock.Fake in tests. The default is the system clock. The server-reported session
+// expiry is compared against this clock too.
+func WithWatchdogClock(c clock.Clock) WatchdogOption {
+	return func(w *SessionWatchdog) {
+		if c != nil {
+			w.clock = c
+		}
+	}
+}
+
+// SessionWatchdog enforces a wall-clock limit on a session. It warns shortly before the
+// deadline, and at the deadline it waits for active responses to finish (up to a drain
+// timeout) and then closes the client.
+//
+// The deadline is the earlier of the start time plus the maximum duration and the
+// session expiry reported by the server (see Client.SessionExpiresAt), so sessions are
+// closed cleanly before the server ends them. The watchdog's Handle must be registered
+// so it can track active responses and expiry changes.
+//
+// Example:
+//
+//	watchdog := messaging.NewSessionWatchdog(msgClient, 10*time.Minute,
+//		messaging.WithWatchdogWarning(30*time.Second, func(remaining time.Duration) {
+//			msgClient.SendSystemMessage(ctx, "Let the caller know the call is about to end.")
+//		}),
+//	)
+//	handler := messaging.NewHandler(ctx, msgClient, watchdog.Handle)
+//	watchdog.Start(ctx)
+type SessionWatchdog struct {
+	mu           sync.Mutex
+	client       *Client
+	maxDuration  time.Duration
+	warnBefore   time.Duration
+	warn         func(time.Duration)
+	drainTimeout time.Duration
+	onTimeout    func(*SessionTimeoutError)
+	clock        clock.Clock
+
+	active  map[string]bool
+	idle    chan struct{}
+	changed chan struct{}
+	start   time.Time
+	reason  *SessionTimeoutError
+	done    chan struct{}
+
+	// exited is closed when the watchdog goroutine returns, so tests can wait for it
+	exited chan struct{}
+}
+
+// NewSessionWatchdog creates a SessionWatchdog that closes the session after maxDuration.
+// A maxDuration of zero or less only enforces the server-reported session expiry.
+func NewSessionWatchdog(client *Client, maxDuration time.Duration, opts ...WatchdogOption) *SessionWatchdog {
+	w := &SessionWatchdog{
+		client:       client,
+		maxDuration:  maxDuration,
+		drainTimeout: DefaultDrainTimeout,
+		clock:        clock.Real(),
+		active:       make(map[string]bool),
+		changed:      make(chan struct{}, 1),
+		done:         make(chan struct{}),
+		exited:       make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	return w
+}
+
+// Start starts enforcing the limit; the maximum duration is measured from this call.
+// Canceling ctx stops the watchdog without closing the session.
+func (w *SessionWatchdog) Start(ctx context.Context) {
+	w.mu.Lock()
+	w.start = w.clock.Now()
+	w.mu.Unlock()
+	go w.run(ctx)
+}
+
+// Handle tracks the responses in progress, which are drained at the deadline, and
+// recomputes the deadline when session.created or session.updated may have changed the
+// session expiry.
+func (w *SessionWatchdog) Handle(ctx context.Context, msg incoming.RcvdMsg) {
+	switch m := msg.(type) {
+	case *incoming.ResponseCreatedMessage:
+		w.mu.Lock()
+		w.active[m.Response.ID] = true
+		w.mu.Unlock()
+	case *incoming.ResponseDoneMessage:
+		w.mu.Lock()
+		delete(w.active, m.Response.ID)
+		if len(w.active) == 0 && w.idle != nil {
+			close(w.idle)
+			w.idle = nil
+		}
+		w.mu.Unlock()
+	case *incoming.SessionCreatedMessage, *incoming.SessionUpdatedMessage:
+		select {
+		case w.changed <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Reason returns why the watchdog closed the session, or nil if it has not
+func (w *SessionWatchdog) Reason() *SessionTimeoutError {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.reason
+}
+
+// Done returns a channel that is closed once the watchdog has closed the session
+func (w *SessionWatchdog) Done() <-chan struct{} {
+	return w.done
+}
+
+// deadline returns the current deadline and whether it is the server-reported expiry.
+// The zero time means there is no deadline.
+func (w *SessionWatchdog) deadline() (time.Time, bool) {
+	w.mu.Lock()
+	var deadline time.Time
+	if w.maxDuration > 0 {
+		deadline = w.start.Add(w.maxDuration)
+	}
+	w.mu.Unlock()
+
+	if expiresAt, ok := w.client.SessionExpiresAt(); ok && (deadline.IsZero() || expiresAt.Before(deadline)) {
+		return expiresAt, true
+	}
+	return deadline, false
+}
+
+// run waits for the warning and the deadline, recomputing them when the session expiry changes
+func (w *SessionWatchdog) run(ctx context.Context) {
+	defer close(w.exited)
+	defer w.client.recoverPanic("session watchdog")
+
+	warned := w.warn == nil
+	for {
+		deadline, expiry := w.deadline()
+		var timer clock.Timer
+		var next <-chan time.Time
+		if !deadline.IsZero() {
+			at := deadline
+			if !warned {
+				at = deadline.Add(-w.warnBefore)
+			}
+			timer = w.clock.NewTimer(at.Sub(w.clock.Now()))
+			next = timer.C()
+		}
+
+		fired := false
+		select {
+		case <-ctx.Done():
+		case <-w.changed:
+		case <-next:
+			fired = true
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if ctx.Err() != nil {
+			return
+		}
+		if !fired {
+			continue
+		}
+
+		if !warned {
+			warned = true
+			if remaining := deadline.Sub(w.clock.Now()); remaining > 0 {
+				w.callWarn(remaining)
+				continue
+			}
+		}
+
+		w.stop(&SessionTimeoutError{Deadline: deadline, SessionExpiry: expiry})
+		return
+	}
+}
+
+// callWarn calls the warning callback; a panic in it does not stop the watchdog
+func (w *SessionWatchdog) callWarn(remaining time.Duration) {
+	defer w.client.recoverPanic("session watchdog warning")
+	w.warn(remaining)
+}
+
+// stop waits for active responses to finish and closes the client
+func (w *SessionWatchdog) stop(reason *SessionTimeoutError) {
+	defer close(w.done)
+
+	w.mu.Lock()
+	w.reason = reason
+	var idle chan struct{}
+	if len(w.active) > 0 {
+		idle = make(chan struct{})
+		w.idle = idle
+	}
+	w.mu.Unlock()
+
+	if idle != nil {
+		timer := w.clock.NewTimer(w.drainTimeout)
+		select {
+		case <-idle:
+		case <-timer.C():
+			if log := w.client.getLogger(); log != nil {
+				log.Warnf("Timed out draining active responses before closing the session")
+			}
+		}
+		timer.Stop()
+	}
+
+	if err := w.client.Close(); err != nil {
+		if log := w.client.getLogger(); log != nil {
+			log.Warnf("Failed to close session at its deadline: %v", err)
+		}
+	}
+	if w.onTimeout != nil {
+		w.onTimeout(reason)
+	}
+}
@@ -0,0 +1,132 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/clock"
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+func TestSessionWatchdogWarnsAndCloses(t *testing.T) {
+	conn, _, closed := recordingConn()
+	client := NewClient(ws.NewConn(conn))
+
+	fake := clock.NewFake(time.Unix(0, 0))
+	warnings := make(chan time.Duration, 1)
+	var reason *SessionTimeoutError
+	watchdog := NewSessionWatchdog(client, 10*time.Minute,
+		WithWatchdogWarning(30*time.Second, func(remaining time.Duration) { warnings <- remaining }),
+		WithOnSessionTimeout(func(r *SessionTimeoutError) { reason = r }),
+		WithWatchdogClock(fake),
+	)
+	watchdog.Start(context.Background())
+
+	fake.BlockUntil(1)
+	fake.Advance(9*time.Minute + 30*time.Second)
+	select {
+	case remaining := <-warnings:
+		if remaining != 30*time.Second {
+			t.Errorf("Expected 30s remaining at the warning, got %v", remaining)
+		}
+		if closed() {
+			t.Error("Expected the session to stay open after the warning")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for the warning")
+	}
+
+	fake.BlockUntil(1)
+	fake.Advance(30 * time.Second)
+	select {
+	case <-watchdog.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for the watchdog to close the session")
+	}
+	if !closed() {
+		t.Error("Expected the client to be closed")
+	}
+	if reason == nil || reason.SessionExpiry || !reason.Deadline.Equal(time.Unix(600, 0)) {
+		t.Errorf("Expected a maximum duration reason at 10m, got %+v", reason)
+	}
+	if watchdog.Reason() != reason {
+		t.Error("Expected Reason to match the reported reason")
+	}
+}
+
+func TestSessionWatchdogDrainsActiveResponses(t *testing.T) {
+	conn, _, closed := recordingConn()
+	client := NewClient(ws.NewConn(conn))
+	fake := clock.NewFake(time.Unix(0, 0))
+	watchdog := NewSessionWatchdog(client, time.Minute, WithDrainTimeout(10*time.Second), WithWatchdogClock(fake))
+
+	ctx := context.Background()
+	watchdog.Handle(ctx, mustParse(t, `{"type":"response.created","response":{"id":"resp_1","status":"in_progress"}}`))
+	watchdog.Start(ctx)
+
+	// Past the deadline the watchdog waits for the drain timeout
+	fake.BlockUntil(1)
+	fake.Advance(time.Minute)
+	fake.BlockUntil(1)
+	if closed() {
+		t.Fatal("Expected the session to stay open while a response is active")
+	}
+
+	watchdog.Handle(ctx, mustParse(t, `{"type":"response.done","response":{"id":"resp_1","status":"completed"}}`))
+	select {
+	case <-watchdog.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for the watchdog to close the session")
+	}
+	if !closed() {
+		t.Error("Expected the client to be closed")
+	}
+}
+
+func TestSessionWatchdogUsesSessionExpiry(t *testing.T) {
+	expiresAt := time.Now().Add(-time.Second).Unix()
+	conn := newScriptedConn(fmt.Sprintf(`{"type":"session.created","session":{"id":"sess_1","expires_at":%d}}`, expiresAt))
+	client := NewClient(ws.NewConn(conn))
+	if _, err := client.ReadMessage(context.Background()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got, ok := client.SessionExpiresAt(); !ok || got.Unix() != expiresAt {
+		t.Fatalf("Expected session expiry %d, got %v (%v)", expiresAt, got, ok)
+	}
+
+	watchdog := NewSessionWatchdog(client, time.Hour)
+	watchdog.Start(context.Background())
+
+	select {
+	case <-watchdog.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for the watchdog to close the session")
+	}
+	if reason := watchdog.Reason(); reason == nil || !reason.SessionExpiry {
+		t.Errorf("Expected a session expiry reason, got %+v", reason)
+	}
+}
+
+func TestSessionWatchdogStopsWithContext(t *testing.T) {
+	conn, _, closed := recordingConn()
+	client := NewClient(ws.NewConn(conn))
+	fake := clock.NewFake(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	watchdog := NewSessionWatchdog(client, time.Minute, WithWatchdogClock(fake))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	watchdog.Start(ctx)
+	fake.BlockUntil(1)
+	cancel()
+	// Passing the deadline after the watchdog was stopped must not close the session
+	fake.Advance(time.Hour)
+	<-watchdog.exited
+
+	if closed() {
+		t.Error("Expected the session to stay open after the watchdog was stopped")
+	}
+	if watchdog.Reason() != nil {
+		t.Errorf("Expected no reason, got %v", watchdog.Reason())
+	}
+}
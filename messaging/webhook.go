@@ -0,0 +1,461 @@
+package messaging
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/clock"
+	"github.com/Mliviu79/openai-realtime-go/httpClient"
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+	"github.com/Mliviu79/openai-realtime-go/messages/types"
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+//-----------------------------------------------------------------------------
+// Webhook Emitter
+//-----------------------------------------------------------------------------
+
+const (
+	// DefaultWebhookQueueSize is the number of events a WebhookEmitter buffers for delivery
+	DefaultWebhookQueueSize = 256
+
+	// DefaultWebhookTimeout is the timeout of each webhook request
+	DefaultWebhookTimeout = 10 * time.Second
+
+	// WebhookSignatureHeader carries the HMAC-SHA256 signature of signed webhook requests
+	WebhookSignatureHeader = "X-Realtime-Signature"
+
+	// WebhookTimestampHeader carries the Unix time a signed webhook request was signed at
+	WebhookTimestampHeader = "X-Realtime-Timestamp"
+)
+
+// ErrWebhookQueueFull is reported when an event is dropped because the delivery queue is full
+var ErrWebhookQueueFull = errors.New("webhook queue is full")
+
+// WebhookEventKind identifies what a webhook event reports
+type WebhookEventKind string
+
+const (
+	// WebhookEventResponseDone reports a finished response with a summary of its output
+	WebhookEventResponseDone WebhookEventKind = "response.done"
+	// WebhookEventTranscript reports the final transcript of a user or assistant audio turn
+	WebhookEventTranscript WebhookEventKind = "transcript"
+	// WebhookEventError reports an error event sent by the server
+	WebhookEventError WebhookEventKind = "error"
+)
+
+// WebhookEvent is the default JSON payload of a webhook request
+type WebhookEvent struct {
+	// Kind is what the event reports
+	Kind WebhookEventKind `json:"event"`
+	// SessionID is the realtime session the event belongs to, once known
+	SessionID string `json:"session_id,omitempty"`
+	// Time is when the emitter received the event
+	Time time.Time `json:"time"`
+	// Response is set for WebhookEventResponseDone
+	Response *WebhookResponse `json:"response,omitempty"`
+	// Transcript is set for WebhookEventTranscript
+	Transcript *WebhookTranscript `json:"transcript,omitempty"`
+	// Error is set for WebhookEventError
+	Error *incoming.ErrorInfo `json:"error,omitempty"`
+}
+
+// WebhookResponse summarizes a finished response
+type WebhookResponse struct {
+	// ID identifies the response
+	ID string `json:"id"`
+	// Status is completed, cancelled, incomplete or failed
+	Status types.ResponseStatus `json:"status"`
+	// Reason explains a response that did not complete, e.g. turn_detected
+	Reason string `json:"reason,omitempty"`
+	// Text is the text, or audio transcript, of the response's messages
+	Text string `json:"text,omitempty"`
+	// FunctionCalls are the names of the functions the response called
+	FunctionCalls []string `json:"function_calls,omitempty"`
+	// Usage is the token usage of the response
+	Usage *types.Usage `json:"usage,omitempty"`
+}
+
+// WebhookTranscript is the final transcript of an audio turn
+type WebhookTranscript struct {
+	// ItemID identifies the conversation item the audio belongs to
+	ItemID string `json:"item_id"`
+	// ResponseID identifies the response of an assistant transcript
+	ResponseID string `json:"response_id,omitempty"`
+	// Role is user for transcribed input audio and assistant for response audio
+	Role types.MessageRole `json:"role"`
+	// Text is the transcript
+	Text string `json:"text"`
+}
+
+// WebhookOption configures a WebhookEmitter
+type WebhookOption func(*WebhookEmitter)
+
+// WithWebhookEvents only delivers events of the given kinds; by default all kinds are delivered
+func WithWebhookEvents(kinds ...WebhookEventKind) WebhookOption {
+	return func(w *WebhookEmitter) {
+		w.kinds = kinds
+	}
+}
+
+// WithWebhookPayload sets a function that turns an event into the value sent as the JSON
+// request body, to match the payload an existing backend expects. Returning nil skips the event.
+func WithWebhookPayload(payload func(WebhookEvent) any) WebhookOption {
+	return func(w *WebhookEmitter) {
+		w.payload = payload
+	}
+}
+
+// WithWebhookSecret signs every request with HMAC-SHA256. The WebhookTimestampHeader
+// holds the Unix time and the WebhookSignatureHeader holds "sha256=" followed by the hex
+// HMAC of the timestamp, a dot and the body. Receivers check it with VerifyWebhookSignature.
+func WithWebhookSecret(secret []byte) WebhookOption {
+	return func(w *WebhookEmitter) {
+		w.secret = secret
+	}
+}
+
+// WithWebhookRetry sets how failed deliveries are retried. Network errors and the
+// retryable status codes are retried with exponential backoff; the default is
+// httpClient.DefaultRetryConfig.
+func WithWebhookRetry(config httpClient.RetryConfig) WebhookOption {
+	return func(w *WebhookEmitter) {
+		w.retry = config
+	}
+}
+
+// WithWebhookHTTPClient sets the HTTP client used for delivery.
+// The default client has a timeout of DefaultWebhookTimeout.
+func WithWebhookHTTPClient(client *http.Client) WebhookOption {
+	return func(w *WebhookEmitter) {
+		if client != nil {
+			w.client = client
+		}
+	}
+}
+
+// WithWebhookHeader adds a header to every request, e.g. an API key of the receiving backend
+func WithWebhookHeader(key, value string) WebhookOption {
+	return func(w *WebhookEmitter) {
+		w.headers.Add(key, value)
+	}
+}
+
+// WithWebhookQueueSize sets how many events are buffered for delivery; the default is
+// DefaultWebhookQueueSize. Events arriving while the queue is full are dropped.
+func WithWebhookQueueSize(n int) WebhookOption {
+	return func(w *WebhookEmitter) {
+		if n > 0 {
+			w.queueSize = n
+		}
+	}
+}
+
+// WithOnWebhookError sets a function that is called when an event is dropped because the
+// queue is full, from Emit, or when its delivery fails after all retries, from the
+// delivery goroutine. It must not block.
+func WithOnWebhookError(onError func(event WebhookEvent, err error)) WebhookOption {
+	return func(w *WebhookEmitter) {
+		w.onError = onError
+	}
+}
+
+// WithWebhookPanicHandler sets a function that is called when the payload function or the
+// error callback panics on the delivery goroutine. The panic is recovered and reported as
+// a *ws.PanicError, and delivery continues with the next event.
+func WithWebhookPanicHandler(onPanic ws.PanicHandler) WebhookOption {
+	return func(w *WebhookEmitter) {
+		w.panics.set(onPanic)
+	}
+}
+
+// WithWebhookClock sets the clock used for timestamps and retry delays, e.g. a clock.Fake
+// in tests. The default is the system clock.
+func WithWebhookClock(c clock.Clock) WebhookOption {
+	return func(w *WebhookEmitter) {
+		if c != nil {
+			w.clock = c
+		}
+	}
+}
+
+// WebhookEmitter POSTs response summaries, final transcripts and errors to a webhook URL,
+// for integrating realtime sessions into event-driven backends. Events are delivered in
+// order by a background goroutine, so Handle never blocks the reader; failed requests are
+// retried and can be signed with an HMAC secret.
+//
+// Example:
+//
+//	webhook := messaging.NewWebhookEmitter("https://example.com/hooks/realtime",
+//		messaging.WithWebhookSecret([]byte(os.Getenv("WEBHOOK_SECRET"))),
+//		messaging.WithWebhookEvents(messaging.WebhookEventResponseDone, messaging.WebhookEventTranscript),
+//	)
+//	defer webhook.Close(context.Background())
+//	handler := messaging.NewHandler(ctx, msgClient, webhook.Handle)
+type WebhookEmitter struct {
+	url       string
+	kinds     []WebhookEventKind
+	payload   func(WebhookEvent) any
+	secret    []byte
+	retry     httpClient.RetryConfig
+	client    *http.Client
+	headers   http.Header
+	queueSize int
+	onError   func(WebhookEvent, error)
+	clock     clock.Clock
+	panics    panicReporter
+
+	mu        sync.Mutex
+	sessionID string
+	closed    bool
+	queue     chan WebhookEvent
+	done      chan struct{}
+
+	// ctx aborts deliveries in flight when Close gives up waiting
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+// NewWebhookEmitter creates a WebhookEmitter that delivers events to url and starts its
+// delivery goroutine. Close must be called to deliver the remaining events and stop it.
+func NewWebhookEmitter(url string, opts ...WebhookOption) *WebhookEmitter {
+	w := &WebhookEmitter{
+		url:       url,
+		retry:     httpClient.DefaultRetryConfig(),
+		client:    &http.Client{Timeout: DefaultWebhookTimeout},
+		headers:   http.Header{},
+		queueSize: DefaultWebhookQueueSize,
+		clock:     clock.Real(),
+		done:      make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	w.queue = make(chan WebhookEvent, w.queueSize)
+	w.ctx, w.cancel = context.WithCancel(context.Background())
+	go w.run()
+	return w
+}
+
+// Handle emits webhook events for completed responses, user and assistant transcripts
+// and errors, remembering the session ID from session.created to stamp them with.
+func (w *WebhookEmitter) Handle(ctx context.Context, msg incoming.RcvdMsg) {
+	switch m := msg.(type) {
+	case *incoming.SessionCreatedMessage:
+		w.mu.Lock()
+		w.sessionID = m.Session.ID
+		w.mu.Unlock()
+	case *incoming.ResponseDoneMessage:
+		w.Emit(WebhookEvent{Kind: WebhookEventResponseDone, Response: summarizeResponse(m.Response)})
+	case *incoming.ConversationItemTranscriptionCompletedMessage:
+		w.Emit(WebhookEvent{Kind: WebhookEventTranscript, Transcript: &WebhookTranscript{
+			ItemID: m.ItemID,
+			Role:   types.MessageRoleUser,
+			Text:   m.Transcript,
+		}})
+	case *incoming.ResponseOutputAudioTranscriptDoneMessage:
+		w.Emit(WebhookEvent{Kind: WebhookEventTranscript, Transcript: &WebhookTranscript{
+			ItemID:     m.ItemID,
+			ResponseID: m.ResponseID,
+			Role:       types.MessageRoleAssistant,
+			Text:       m.Transcript,
+		}})
+	case *incoming.ErrorMessage:
+		errInfo := m.Error
+		w.Emit(WebhookEvent{Kind: WebhookEventError, Error: &errInfo})
+	}
+}
+
+// Emit queues an event for delivery, filling in the session ID and time if they are
+// not set. Events of kinds that are not delivered, and events emitted after Close,
+// are ignored.
+func (w *WebhookEmitter) Emit(event WebhookEvent) {
+	if len(w.kinds) > 0 && !slices.Contains(w.kinds, event.Kind) {
+		return
+	}
+
+	w.mu.Lock()
+	if w.closed {
+		w.mu.Unlock()
+		return
+	}
+	if event.SessionID == "" {
+		event.SessionID = w.sessionID
+	}
+	if event.Time.IsZero() {
+		event.Time = w.clock.Now()
+	}
+	queued := false
+	select {
+	case w.queue <- event:
+		queued = true
+	default:
+	}
+	w.mu.Unlock()
+
+	if !queued && w.onError != nil {
+		w.onError(event, ErrWebhookQueueFull)
+	}
+}
+
+// Close stops accepting events and waits until the queued events are delivered or ctx
+// is done, in which case the remaining deliveries are abandoned and ctx's error is returned
+func (w *WebhookEmitter) Close(ctx context.Context) error {
+	w.mu.Lock()
+	if !w.closed {
+		w.closed = true
+		close(w.queue)
+	}
+	w.mu.Unlock()
+
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		w.cancel()
+		<-w.done
+		return ctx.Err()
+	}
+}
+
+// run delivers the queued events in order until the queue is closed
+func (w *WebhookEmitter) run() {
+	defer close(w.done)
+	defer w.cancel()
+
+	for event := range w.queue {
+		if w.ctx.Err() != nil {
+			continue
+		}
+		w.deliverReported(event)
+	}
+}
+
+// deliverReported delivers one event, reporting a panic in the payload or error callbacks
+// instead of stopping delivery
+func (w *WebhookEmitter) deliverReported(event WebhookEvent) {
+	defer w.panics.recoverPanic("webhook delivery")
+	if err := w.deliver(w.ctx, event); err != nil && w.onError != nil {
+		w.onError(event, err)
+	}
+}
+
+// deliver sends one event, retrying failed requests
+func (w *WebhookEmitter) deliver(ctx context.Context, event WebhookEvent) error {
+	var payload any = event
+	if w.payload != nil {
+		payload = w.payload(event)
+		if payload == nil {
+			return nil
+		}
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	delay := w.retry.RetryDelay
+	for attempt := 0; ; attempt++ {
+		retryable, err := w.post(ctx, body)
+		if err == nil {
+			return nil
+		}
+		if !retryable || attempt >= w.retry.MaxRetries {
+			return err
+		}
+
+		if err := clock.Sleep(ctx, w.clock, delay); err != nil {
+			return err
+		}
+		delay *= 2
+		if w.retry.MaxDelay > 0 && delay > w.retry.MaxDelay {
+			delay = w.retry.MaxDelay
+		}
+	}
+}
+
+// post sends one request and reports whether a failure may be retried
+func (w *WebhookEmitter) post(ctx context.Context, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	for key, values := range w.headers {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if w.secret != nil {
+		timestamp := strconv.FormatInt(w.clock.Now().Unix(), 10)
+		req.Header.Set(WebhookTimestampHeader, timestamp)
+		req.Header.Set(WebhookSignatureHeader, signWebhook(w.secret, timestamp, body))
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, fmt.Errorf("webhook request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	return slices.Contains(w.retry.RetryableStatusCodes, resp.StatusCode),
+		fmt.Errorf("webhook request failed with status %d", resp.StatusCode)
+}
+
+// VerifyWebhookSignature reports whether signature is the signature a WebhookEmitter with
+// secret creates for timestamp and body, the values of the WebhookSignatureHeader and
+// WebhookTimestampHeader headers and the raw request body. Receivers should also reject
+// timestamps that are too old, so captured requests cannot be replayed.
+func VerifyWebhookSignature(secret []byte, timestamp string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(signWebhook(secret, timestamp, body)), []byte(signature))
+}
+
+// signWebhook returns the signature header value for a request
+func signWebhook(secret []byte, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// summarizeResponse builds the webhook summary of a finished response
+func summarizeResponse(response types.Response) *WebhookResponse {
+	summary := &WebhookResponse{ID: response.ID, Status: response.Status, Usage: response.Usage}
+	if response.StatusDetails != nil {
+		summary.Reason = response.StatusDetails.Reason
+	}
+
+	var text []string
+	for _, item := range response.Output {
+		switch item.Type {
+		case types.MessageItemTypeMessage:
+			for _, part := range item.Content {
+				if part.Text != "" {
+					text = append(text, part.Text)
+				} else if part.Transcript != "" {
+					text = append(text, part.Transcript)
+				}
+			}
+		case types.MessageItemTypeFunctionCall:
+			summary.FunctionCalls = append(summary.FunctionCalls, item.Name)
+		}
+	}
+	summary.Text = strings.Join(text, "\n")
+	return summary
+}
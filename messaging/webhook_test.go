@@ -0,0 +1,183 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/clock"
+	"github.com/Mliviu79/openai-realtime-go/httpClient"
+)
+
+// webhookServer records the bodies and headers of the requests it receives
+type webhookServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	bodies   [][]byte
+	headers  []http.Header
+	statuses []int
+}
+
+// newWebhookServer creates a server that answers with the given statuses in turn, then 200
+func newWebhookServer(t *testing.T, statuses ...int) *webhookServer {
+	s := &webhookServer{statuses: statuses}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		s.mu.Lock()
+		s.bodies = append(s.bodies, body)
+		s.headers = append(s.headers, r.Header.Clone())
+		status := http.StatusOK
+		if len(s.statuses) > 0 {
+			status, s.statuses = s.statuses[0], s.statuses[1:]
+		}
+		s.mu.Unlock()
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *webhookServer) requests() ([][]byte, []http.Header) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([][]byte(nil), s.bodies...), append([]http.Header(nil), s.headers...)
+}
+
+func TestWebhookEmitter(t *testing.T) {
+	server := newWebhookServer(t)
+	secret := []byte("shh")
+	webhook := NewWebhookEmitter(server.URL, WithWebhookSecret(secret), WithWebhookHeader("X-Api-Key", "backend-key"))
+
+	ctx := context.Background()
+	handle := func(msg string) { webhook.Handle(ctx, mustParse(t, msg)) }
+	handle(`{"type":"session.created","session":{"id":"sess_1"}}`)
+	handle(`{"type":"conversation.item.input_audio_transcription.completed","item_id":"item_1","content_index":0,"transcript":"Book a table"}`)
+	handle(`{"type":"response.output_audio_transcript.done","response_id":"resp_1","item_id":"item_2","output_index":0,"content_index":0,"transcript":"For how many?"}`)
+	handle(`{"type":"response.done","response":{"id":"resp_1","status":"completed","output":[{"id":"item_2","type":"message","role":"assistant","content":[{"type":"audio","transcript":"For how many?"}]},{"id":"item_3","type":"function_call","name":"check_availability"}],"usage":{"total_tokens":20}}}`)
+	handle(`{"type":"error","error":{"type":"invalid_request_error","message":"bad event"}}`)
+
+	if err := webhook.Close(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	bodies, headers := server.requests()
+	if len(bodies) != 4 {
+		t.Fatalf("Expected 4 requests, got %d", len(bodies))
+	}
+	var events []WebhookEvent
+	for i, body := range bodies {
+		var event WebhookEvent
+		if err := json.Unmarshal(body, &event); err != nil {
+			t.Fatalf("Failed to parse %s: %v", body, err)
+		}
+		events = append(events, event)
+
+		h := headers[i]
+		if !VerifyWebhookSignature(secret, h.Get(WebhookTimestampHeader), body, h.Get(WebhookSignatureHeader)) {
+			t.Errorf("Expected request %d to be signed, got %q", i, h.Get(WebhookSignatureHeader))
+		}
+		if h.Get("X-Api-Key") != "backend-key" || h.Get("Content-Type") != "application/json" {
+			t.Errorf("Expected the configured headers, got %v", h)
+		}
+	}
+	if VerifyWebhookSignature([]byte("other"), headers[0].Get(WebhookTimestampHeader), bodies[0], headers[0].Get(WebhookSignatureHeader)) {
+		t.Error("Expected the signature not to verify with another secret")
+	}
+
+	if e := events[0]; e.Kind != WebhookEventTranscript || e.SessionID != "sess_1" || e.Transcript.Role != "user" || e.Transcript.Text != "Book a table" {
+		t.Errorf("Expected the user transcript, got %+v", e)
+	}
+	if e := events[1]; e.Kind != WebhookEventTranscript || e.Transcript.Role != "assistant" || e.Transcript.ResponseID != "resp_1" {
+		t.Errorf("Expected the assistant transcript, got %+v", e)
+	}
+	if r := events[2].Response; events[2].Kind != WebhookEventResponseDone || r.Text != "For how many?" ||
+		len(r.FunctionCalls) != 1 || r.FunctionCalls[0] != "check_availability" || r.Usage.TotalTokens != 20 {
+		t.Errorf("Expected the response summary, got %+v", r)
+	}
+	if e := events[3]; e.Kind != WebhookEventError || e.Error.Message != "bad event" {
+		t.Errorf("Expected the error, got %+v", e)
+	}
+}
+
+func TestWebhookEmitterRetries(t *testing.T) {
+	tests := []struct {
+		name             string
+		statuses         []int
+		expectedRequests int
+		expectedFailed   bool
+	}{
+		{name: "retried until delivered", statuses: []int{503, 502}, expectedRequests: 3},
+		{name: "gives up after max retries", statuses: []int{503, 503, 503}, expectedRequests: 3, expectedFailed: true},
+		{name: "client error is not retried", statuses: []int{400}, expectedRequests: 1, expectedFailed: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newWebhookServer(t, tt.statuses...)
+			fake := clock.NewFake(time.Unix(0, 0))
+			var failed atomic.Int32
+			webhook := NewWebhookEmitter(server.URL,
+				WithWebhookClock(fake),
+				WithWebhookRetry(httpClient.RetryConfig{MaxRetries: 2, RetryDelay: time.Second, MaxDelay: time.Minute, RetryableStatusCodes: []int{502, 503}}),
+				WithOnWebhookError(func(event WebhookEvent, err error) { failed.Add(1) }),
+			)
+
+			webhook.Emit(WebhookEvent{Kind: WebhookEventError})
+			done := make(chan error, 1)
+			go func() { done <- webhook.Close(context.Background()) }()
+
+			// Each retry waits on the clock before the request is sent again
+			start := fake.Now()
+			for range tt.expectedRequests - 1 {
+				fake.BlockUntil(1)
+				fake.Advance(time.Minute)
+			}
+			if err := <-done; err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if bodies, _ := server.requests(); len(bodies) != tt.expectedRequests {
+				t.Errorf("Expected %d requests, got %d", tt.expectedRequests, len(bodies))
+			}
+			if got := failed.Load() == 1; got != tt.expectedFailed {
+				t.Errorf("Expected failed %v, got %d failures", tt.expectedFailed, failed.Load())
+			}
+			if tt.expectedRequests == 3 && fake.Now().Sub(start) != 2*time.Minute {
+				t.Errorf("Expected two retry waits, got %v", fake.Now().Sub(start))
+			}
+		})
+	}
+}
+
+func TestWebhookEmitterPayload(t *testing.T) {
+	server := newWebhookServer(t)
+	webhook := NewWebhookEmitter(server.URL,
+		WithWebhookEvents(WebhookEventResponseDone),
+		WithWebhookPayload(func(event WebhookEvent) any {
+			if event.Response.Status != "completed" {
+				return nil
+			}
+			return map[string]string{"call": event.SessionID, "reply": event.Response.Text}
+		}),
+	)
+
+	ctx := context.Background()
+	webhook.Handle(ctx, mustParse(t, `{"type":"session.created","session":{"id":"sess_1"}}`))
+	webhook.Handle(ctx, mustParse(t, `{"type":"error","error":{"type":"server_error","message":"oops"}}`))
+	webhook.Handle(ctx, mustParse(t, `{"type":"response.done","response":{"id":"resp_1","status":"cancelled"}}`))
+	webhook.Handle(ctx, mustParse(t, `{"type":"response.done","response":{"id":"resp_2","status":"completed","output":[{"type":"message","role":"assistant","content":[{"type":"text","text":"Done."}]}]}}`))
+	if err := webhook.Close(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	bodies, _ := server.requests()
+	if len(bodies) != 1 || string(bodies[0]) != `{"call":"sess_1","reply":"Done."}` {
+		t.Errorf("Expected only the custom completed payload, got %q", bodies)
+	}
+}
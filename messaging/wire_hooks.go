@@ -0,0 +1,94 @@
+package messaging
+
+import (
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+//-----------------------------------------------------------------------------
+// Wire Observers
+//-----------------------------------------------------------------------------
+
+// WireEvent is a frame that crossed the wire, as passed to OnSend and OnReceive
+// observers
+type WireEvent struct {
+	// Time is when the frame was written or read, from the client's clock
+	Time time.Time
+
+	// Type is the event type, such as "response.create"; it is empty for a received
+	// frame without a type
+	Type string
+
+	// Data is the frame exactly as it was sent or received. It must not be modified
+	// and must be copied to be kept after the observer returns.
+	Data []byte
+}
+
+// WireObserver is called with every frame sent or received
+type WireObserver func(event WireEvent)
+
+// OnSend registers an observer that is called after every frame is written, including
+// frames sent with SendRaw and the event IDs added by a ReceiptTracker. It is lighter
+// than an EventDumper for audit logs that must capture exactly what was sent.
+// Observers are called in the sending goroutine, in registration order, and should not
+// block.
+//
+// Example:
+//
+//	msgClient.OnSend(func(event messaging.WireEvent) {
+//		auditLog.Printf("%s out %s %s", event.Time.Format(time.RFC3339Nano), event.Type, event.Data)
+//	})
+func (c *Client) OnSend(observer WireObserver) {
+	if observer == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.sendObservers = append(c.sendObservers, observer)
+}
+
+// OnReceive registers an observer that is called with every text frame read through
+// ReadMessage or a Handler, before it is decoded, so frames that fail to decode are
+// observed too. Observers are called in the reading goroutine, in registration order,
+// and should not block.
+func (c *Client) OnReceive(observer WireObserver) {
+	if observer == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.receiveObservers = append(c.receiveObservers, observer)
+}
+
+// notifySent calls the send observers with a written frame
+func (c *Client) notifySent(msgType string, data []byte) {
+	c.mu.RLock()
+	observers := c.sendObservers
+	clk := c.clock
+	c.mu.RUnlock()
+
+	if len(observers) == 0 {
+		return
+	}
+	event := WireEvent{Time: clk.Now(), Type: msgType, Data: data}
+	for _, observer := range observers {
+		observer(event)
+	}
+}
+
+// notifyReceived calls the receive observers with a read frame
+func (c *Client) notifyReceived(data []byte) {
+	c.mu.RLock()
+	observers := c.receiveObservers
+	clk := c.clock
+	c.mu.RUnlock()
+
+	if len(observers) == 0 {
+		return
+	}
+	event := WireEvent{Time: clk.Now(), Type: ws.SniffEventType(data), Data: data}
+	for _, observer := range observers {
+		observer(event)
+	}
+}
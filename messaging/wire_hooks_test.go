@@ -0,0 +1,59 @@
+package messaging
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/clock"
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+func TestWireObservers(t *testing.T) {
+	frames := []string{
+		`{"type":"session.created","session":{"id":"sess_1"}}`,
+		`{"type":"unknown.event"}`,
+	}
+	client := NewClient(ws.NewConn(&MockConn{
+		ReadMessageFunc: func(ctx context.Context) (ws.MessageType, []byte, error) {
+			frame := frames[0]
+			frames = frames[1:]
+			return ws.MessageText, []byte(frame), nil
+		},
+	}))
+	fake := clock.NewFake(time.Unix(1700000000, 0))
+	client.SetClock(fake)
+
+	var sent, received []WireEvent
+	client.OnSend(func(event WireEvent) { sent = append(sent, event) })
+	client.OnReceive(func(event WireEvent) { received = append(received, event) })
+	client.OnSend(nil)
+
+	ctx := context.Background()
+	if err := client.SendText(ctx, "Hello"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := client.SendRaw(ctx, []byte(`{"type":"experimental.event"}`)); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := client.ReadMessage(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	// Frames that fail to decode are still observed
+	if _, err := client.ReadMessage(ctx); err == nil {
+		t.Fatal("Expected the unknown event to fail to decode")
+	}
+
+	if len(sent) != 2 || sent[0].Type != "conversation.item.create" || sent[1].Type != "experimental.event" {
+		t.Fatalf("Expected the text item and the raw event to be observed, got %+v", sent)
+	}
+	if string(sent[1].Data) != `{"type":"experimental.event"}` || !sent[1].Time.Equal(fake.Now()) {
+		t.Errorf("Expected the raw bytes and the clock's time, got %+v", sent[1])
+	}
+	if len(received) != 2 || received[0].Type != "session.created" || received[1].Type != "unknown.event" {
+		t.Fatalf("Expected both received frames to be observed, got %+v", received)
+	}
+	if string(received[0].Data) != `{"type":"session.created","session":{"id":"sess_1"}}` {
+		t.Errorf("Expected the received bytes, got %s", received[0].Data)
+	}
+}
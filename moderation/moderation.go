@@ -0,0 +1,209 @@
+// Package moderation checks conversation text with the OpenAI Moderations endpoint, so
+// voice products can meet trust and safety requirements without separate plumbing.
+//
+// A Guard blocks outgoing user text that the moderation model flags, through the
+// messaging guardrail hook, and checks the final transcripts of what users said, which
+// cannot be blocked because the server already has them, reporting flags to a callback.
+//
+// Example:
+//
+//	guard := moderation.NewGuard(openaiClient.NewClient(apiKey),
+//		moderation.WithOnFlagged(func(ctx context.Context, text messaging.GuardedText, flagged *moderation.FlaggedError) {
+//			log.Printf("flagged %s text: %v", text.Target, flagged.Categories)
+//		}),
+//	)
+//	msgClient.SetGuardrails(guard.Guardrail)
+//	handler := messaging.NewHandler(ctx, msgClient, guard.Handle)
+package moderation
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+	"github.com/Mliviu79/openai-realtime-go/messages/types"
+	"github.com/Mliviu79/openai-realtime-go/messaging"
+	"github.com/Mliviu79/openai-realtime-go/openaiClient"
+)
+
+// TargetTranscript is the target of GuardedText for the final transcript of user audio
+const TargetTranscript messaging.GuardrailTarget = "transcript"
+
+// Client calls the Moderations endpoint; *openaiClient.Client implements it
+type Client interface {
+	CreateModeration(ctx context.Context, req *openaiClient.ModerationRequest) (*openaiClient.ModerationResponse, error)
+}
+
+// FlaggedError is the error of text the moderation model flagged. It wraps
+// messaging.ErrContentBlocked, so the send methods return it inside a
+// *messaging.PolicyError.
+type FlaggedError struct {
+	// Categories are the flagged categories that caused the block, in order
+	Categories []string
+
+	// Result is the full classification
+	Result openaiClient.ModerationResult
+}
+
+// Error implements the error interface
+func (e *FlaggedError) Error() string {
+	return fmt.Sprintf("flagged by moderation: %s", strings.Join(e.Categories, ", "))
+}
+
+// Unwrap returns messaging.ErrContentBlocked
+func (e *FlaggedError) Unwrap() error {
+	return messaging.ErrContentBlocked
+}
+
+// GuardOption configures a Guard
+type GuardOption func(*Guard)
+
+// WithModel sets the moderation model; the default is openaiClient.DefaultModerationModel
+func WithModel(model string) GuardOption {
+	return func(g *Guard) {
+		g.model = model
+	}
+}
+
+// WithCategories only acts on the given categories, such as "harassment/threatening";
+// by default text is blocked whenever the model flags it
+func WithCategories(categories ...string) GuardOption {
+	return func(g *Guard) {
+		g.categories = categories
+	}
+}
+
+// WithRoles sets the roles of the outgoing items that are checked; the default is
+// user only. Instructions are never checked.
+func WithRoles(roles ...types.MessageRole) GuardOption {
+	return func(g *Guard) {
+		g.roles = roles
+	}
+}
+
+// WithFailOpen sends text when the Moderations endpoint cannot be reached; by default
+// such text is blocked with the request's error
+func WithFailOpen() GuardOption {
+	return func(g *Guard) {
+		g.failOpen = true
+	}
+}
+
+// WithOnFlagged sets a function that is called for every flagged text, whether it was
+// blocked or, for transcripts, only reported
+func WithOnFlagged(onFlagged func(ctx context.Context, text messaging.GuardedText, flagged *FlaggedError)) GuardOption {
+	return func(g *Guard) {
+		g.onFlagged = onFlagged
+	}
+}
+
+// WithOnError sets a function that is called when a transcript cannot be checked
+func WithOnError(onError func(err error)) GuardOption {
+	return func(g *Guard) {
+		g.onError = onError
+	}
+}
+
+// Guard checks text with the Moderations endpoint
+type Guard struct {
+	client     Client
+	model      string
+	categories []string
+	roles      []types.MessageRole
+	failOpen   bool
+	onFlagged  func(context.Context, messaging.GuardedText, *FlaggedError)
+	onError    func(error)
+}
+
+// NewGuard creates a Guard that calls the Moderations endpoint through client
+func NewGuard(client Client, opts ...GuardOption) *Guard {
+	g := &Guard{
+		client: client,
+		roles:  []types.MessageRole{types.MessageRoleUser},
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+	return g
+}
+
+// Guardrail checks the text of outgoing items of the configured roles, returning a
+// *FlaggedError for flagged text. It has the messaging.Guardrail signature, so it can be
+// passed to Client.SetGuardrails.
+func (g *Guard) Guardrail(ctx context.Context, text messaging.GuardedText) (string, error) {
+	if text.Target != messaging.GuardrailTargetItem || !slices.Contains(g.roles, text.Role) {
+		return text.Text, nil
+	}
+
+	flagged, err := g.Check(ctx, text.Text)
+	if err != nil {
+		if g.failOpen {
+			return text.Text, nil
+		}
+		return "", err
+	}
+	if flagged != nil {
+		g.flag(ctx, text, flagged)
+		return "", flagged
+	}
+	return text.Text, nil
+}
+
+// Handle checks the final transcripts of user audio in the background. It has the
+// MessageHandler signature so it can be registered directly with a Handler.
+func (g *Guard) Handle(ctx context.Context, msg incoming.RcvdMsg) {
+	m, ok := msg.(*incoming.ConversationItemTranscriptionCompletedMessage)
+	if !ok || m.Transcript == "" {
+		return
+	}
+	text := messaging.GuardedText{Target: TargetTranscript, Role: types.MessageRoleUser, Text: m.Transcript}
+
+	// The request would hold up the handler
+	go func() {
+		flagged, err := g.Check(ctx, text.Text)
+		if err != nil {
+			if g.onError != nil {
+				g.onError(fmt.Errorf("failed to moderate transcript of %s: %w", m.ItemID, err))
+			}
+			return
+		}
+		if flagged != nil {
+			g.flag(ctx, text, flagged)
+		}
+	}()
+}
+
+// Check classifies text, returning a *FlaggedError if it is flagged in a configured
+// category and nil if it is not
+func (g *Guard) Check(ctx context.Context, text string) (*FlaggedError, error) {
+	resp, err := g.client.CreateModeration(ctx, &openaiClient.ModerationRequest{Input: text, Model: g.model})
+	if err != nil {
+		return nil, fmt.Errorf("moderation request failed: %w", err)
+	}
+
+	for _, result := range resp.Results {
+		if !result.Flagged {
+			continue
+		}
+		var categories []string
+		for category, violated := range result.Categories {
+			if violated && (len(g.categories) == 0 || slices.Contains(g.categories, category)) {
+				categories = append(categories, category)
+			}
+		}
+		if len(categories) > 0 {
+			slices.Sort(categories)
+			return &FlaggedError{Categories: categories, Result: result}, nil
+		}
+	}
+	return nil, nil
+}
+
+// flag reports flagged text, if a function is set
+func (g *Guard) flag(ctx context.Context, text messaging.GuardedText, flagged *FlaggedError) {
+	if g.onFlagged != nil {
+		g.onFlagged(ctx, text, flagged)
+	}
+}
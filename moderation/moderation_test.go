@@ -0,0 +1,175 @@
+package moderation
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+	"github.com/Mliviu79/openai-realtime-go/messages/types"
+	"github.com/Mliviu79/openai-realtime-go/messaging"
+	"github.com/Mliviu79/openai-realtime-go/openaiClient"
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+// MockClient is a mock implementation of Client
+type MockClient struct {
+	CreateModerationFunc func(ctx context.Context, req *openaiClient.ModerationRequest) (*openaiClient.ModerationResponse, error)
+}
+
+func (m *MockClient) CreateModeration(ctx context.Context, req *openaiClient.ModerationRequest) (*openaiClient.ModerationResponse, error) {
+	return m.CreateModerationFunc(ctx, req)
+}
+
+// keywordModeration flags text containing "threat" as harassment/threatening and text
+// containing "fight" as violence
+func keywordModeration() *MockClient {
+	return &MockClient{
+		CreateModerationFunc: func(ctx context.Context, req *openaiClient.ModerationRequest) (*openaiClient.ModerationResponse, error) {
+			result := openaiClient.ModerationResult{Categories: map[string]bool{}}
+			if strings.Contains(req.Input, "threat") {
+				result.Categories["harassment"] = true
+				result.Categories["harassment/threatening"] = true
+			}
+			if strings.Contains(req.Input, "fight") {
+				result.Categories["violence"] = true
+			}
+			result.Flagged = len(result.Categories) > 0
+			return &openaiClient.ModerationResponse{Results: []openaiClient.ModerationResult{result}}, nil
+		},
+	}
+}
+
+// MockConn is a mock implementation of ws.WebSocketConn that records written frames
+type MockConn struct {
+	written []string
+}
+
+func (m *MockConn) WriteMessage(ctx context.Context, messageType ws.MessageType, data []byte) error {
+	m.written = append(m.written, string(data))
+	return nil
+}
+
+func (m *MockConn) ReadMessage(ctx context.Context) (ws.MessageType, []byte, error) {
+	<-ctx.Done()
+	return 0, nil, ctx.Err()
+}
+
+func (m *MockConn) Close() error                   { return nil }
+func (m *MockConn) Ping(ctx context.Context) error { return nil }
+
+func TestGuardrail(t *testing.T) {
+	tests := []struct {
+		name               string
+		opts               []GuardOption
+		role               types.MessageRole
+		text               string
+		expectedCategories []string
+	}{
+		{name: "clean text", role: types.MessageRoleUser, text: "Book a table"},
+		{name: "flagged text", role: types.MessageRoleUser, text: "a threat", expectedCategories: []string{"harassment", "harassment/threatening"}},
+		{name: "other roles are not checked", role: types.MessageRoleAssistant, text: "a threat"},
+		{name: "configured roles", opts: []GuardOption{WithRoles(types.MessageRoleAssistant)}, role: types.MessageRoleAssistant, text: "a fight", expectedCategories: []string{"violence"}},
+		{name: "other categories are allowed", opts: []GuardOption{WithCategories("violence")}, role: types.MessageRoleUser, text: "a threat"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var reported []*FlaggedError
+			opts := append(tt.opts, WithOnFlagged(func(ctx context.Context, text messaging.GuardedText, flagged *FlaggedError) {
+				reported = append(reported, flagged)
+			}))
+			guard := NewGuard(keywordModeration(), opts...)
+
+			text := messaging.GuardedText{Target: messaging.GuardrailTargetItem, Role: tt.role, Text: tt.text}
+			got, err := guard.Guardrail(context.Background(), text)
+			if tt.expectedCategories == nil {
+				if err != nil || got != tt.text {
+					t.Errorf("Expected the text to pass, got %q %v", got, err)
+				}
+				return
+			}
+
+			var flagged *FlaggedError
+			if !errors.As(err, &flagged) {
+				t.Fatalf("Expected a FlaggedError, got %v", err)
+			}
+			if strings.Join(flagged.Categories, ",") != strings.Join(tt.expectedCategories, ",") {
+				t.Errorf("Expected categories %v, got %v", tt.expectedCategories, flagged.Categories)
+			}
+			if len(reported) != 1 {
+				t.Errorf("Expected the flag to be reported once, got %d", len(reported))
+			}
+		})
+	}
+}
+
+func TestGuardrailBlocksSend(t *testing.T) {
+	conn := &MockConn{}
+	client := messaging.NewClient(ws.NewConn(conn))
+	client.SetGuardrails(NewGuard(keywordModeration()).Guardrail)
+
+	err := client.SendText(context.Background(), "this is a threat")
+	var policyErr *messaging.PolicyError
+	if !errors.As(err, &policyErr) || !errors.Is(err, messaging.ErrContentBlocked) {
+		t.Fatalf("Expected a policy error, got %v", err)
+	}
+	var flagged *FlaggedError
+	if !errors.As(err, &flagged) {
+		t.Errorf("Expected the policy error to carry the FlaggedError, got %v", err)
+	}
+
+	if err := client.SendText(context.Background(), "hello"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(conn.written) != 1 {
+		t.Errorf("Expected only the clean text to be sent, got %v", conn.written)
+	}
+}
+
+func TestGuardrailRequestErrors(t *testing.T) {
+	failing := &MockClient{
+		CreateModerationFunc: func(ctx context.Context, req *openaiClient.ModerationRequest) (*openaiClient.ModerationResponse, error) {
+			return nil, errors.New("unavailable")
+		},
+	}
+	text := messaging.GuardedText{Target: messaging.GuardrailTargetItem, Role: types.MessageRoleUser, Text: "hello"}
+
+	if _, err := NewGuard(failing).Guardrail(context.Background(), text); err == nil {
+		t.Error("Expected the text to be blocked when moderation fails")
+	}
+	if got, err := NewGuard(failing, WithFailOpen()).Guardrail(context.Background(), text); err != nil || got != "hello" {
+		t.Errorf("Expected the text to pass when failing open, got %q %v", got, err)
+	}
+}
+
+func TestHandleTranscripts(t *testing.T) {
+	flags := make(chan messaging.GuardedText, 2)
+	guard := NewGuard(keywordModeration(), WithOnFlagged(func(ctx context.Context, text messaging.GuardedText, flagged *FlaggedError) {
+		flags <- text
+	}))
+
+	for _, transcript := range []string{"hello", "I will fight you"} {
+		msg, err := incoming.UnmarshalRcvdMsg([]byte(`{"type":"conversation.item.input_audio_transcription.completed","item_id":"item_1","content_index":0,"transcript":"` + transcript + `"}`))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		guard.Handle(context.Background(), msg)
+	}
+
+	select {
+	case text := <-flags:
+		if text.Target != TargetTranscript || text.Text != "I will fight you" {
+			t.Errorf("Expected the flagged transcript, got %+v", text)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the transcript to be flagged")
+	}
+	select {
+	case text := <-flags:
+		t.Errorf("Expected one flag, got another for %q", text.Text)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -0,0 +1,290 @@
+package openaiClient
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/clock"
+	"github.com/Mliviu79/openai-realtime-go/session"
+)
+
+// Default session cache settings
+const (
+	// DefaultCacheMinRemaining is how long a cached client secret must remain valid to be reused
+	DefaultCacheMinRemaining = 10 * time.Second
+
+	// DefaultCacheMaxEntries is the maximum number of configurations cached at once
+	DefaultCacheMaxEntries = 100
+)
+
+// SessionCacheOption configures a SessionCache
+type SessionCacheOption func(*SessionCache)
+
+// WithCacheMinRemaining sets how long a cached client secret must remain valid to be reused.
+// Secrets closer to expiry are replaced, so clients have time to connect with them.
+func WithCacheMinRemaining(d time.Duration) SessionCacheOption {
+	return func(c *SessionCache) {
+		c.minRemaining = d
+	}
+}
+
+// WithCacheMaxEntries sets the maximum number of configurations cached at once.
+// When the cache is full, the entry closest to expiry is evicted.
+func WithCacheMaxEntries(n int) SessionCacheOption {
+	return func(c *SessionCache) {
+		if n > 0 {
+			c.maxEntries = n
+		}
+	}
+}
+
+// WithCacheClock sets the clock client secret expiry is checked against, e.g. a clock.Fake
+// in tests. The default is the system clock.
+func WithCacheClock(clk clock.Clock) SessionCacheOption {
+	return func(c *SessionCache) {
+		if clk != nil {
+			c.clock = clk
+		}
+	}
+}
+
+// cacheEntry is a cached session and the time its client secret expires
+type cacheEntry struct {
+	value     any
+	expiresAt time.Time
+}
+
+// cacheCall is a session creation in flight, shared by concurrent requests for the same configuration
+type cacheCall struct {
+	done  chan struct{}
+	value any
+	err   error
+}
+
+// SessionCache reuses an unexpired session and its client secret for requests with the same
+// configuration, instead of creating a new session for every request. It suits relay and
+// token-vending servers that hand out ephemeral keys to many browser or mobile clients.
+//
+// Requests are keyed by a fingerprint of their JSON encoding, so only identical
+// configurations share a session. Concurrent requests for a configuration that is not
+// cached yet wait for a single creation. Every caller receives its own copy of the response.
+//
+// Example:
+//
+//	cache := openaiClient.NewSessionCache(client)
+//	resp, err := cache.CreateSession(ctx, req)
+//	// hand resp.ClientSecret.Value to the browser
+type SessionCache struct {
+	client       *Client
+	minRemaining time.Duration
+	maxEntries   int
+
+	mu       sync.Mutex
+	entries  map[string]cacheEntry
+	inflight map[string]*cacheCall
+
+	clock clock.Clock
+
+	// joined is called when a caller starts waiting for a creation in flight, so tests can
+	// wait for callers to share it
+	joined func()
+}
+
+// NewSessionCache creates a SessionCache that creates sessions with client
+func NewSessionCache(client *Client, opts ...SessionCacheOption) *SessionCache {
+	c := &SessionCache{
+		client:       client,
+		minRemaining: DefaultCacheMinRemaining,
+		maxEntries:   DefaultCacheMaxEntries,
+		entries:      make(map[string]cacheEntry),
+		inflight:     make(map[string]*cacheCall),
+		clock:        clock.Real(),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// CreateSession returns a cached session for the configuration if its client secret is
+// still valid, or creates a new one with Client.CreateSession
+//
+// Parameters:
+//   - ctx: The context for the request
+//   - req: The session creation request
+//
+// Returns:
+//   - *session.CreateResponse: The cached or newly created session
+//   - error: An error if the request failed
+func (c *SessionCache) CreateSession(ctx context.Context, req *session.CreateRequest) (*session.CreateResponse, error) {
+	value, err := c.get(ctx, "session", req, func(ctx context.Context) (any, time.Time, error) {
+		resp, err := c.client.CreateSession(ctx, req)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		return resp, secretExpiry(&resp.ClientSecret, resp.ExpiresAt), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &session.CreateResponse{Session: value.(*session.CreateResponse).Session.Clone()}, nil
+}
+
+// CreateTranscriptionSession returns a cached transcription session for the configuration
+// if its client secret is still valid, or creates a new one with Client.CreateTranscriptionSession
+//
+// Parameters:
+//   - ctx: The context for the request
+//   - req: The transcription session creation request
+//
+// Returns:
+//   - *session.CreateTranscriptionSessionResponse: The cached or newly created transcription session
+//   - error: An error if the request failed
+func (c *SessionCache) CreateTranscriptionSession(ctx context.Context, req *session.CreateTranscriptionSessionRequest) (*session.CreateTranscriptionSessionResponse, error) {
+	value, err := c.get(ctx, "transcription_session", req, func(ctx context.Context) (any, time.Time, error) {
+		resp, err := c.client.CreateTranscriptionSession(ctx, req)
+		if err != nil {
+			return nil, time.Time{}, err
+		}
+		return resp, secretExpiry(resp.ClientSecret, resp.ExpiresAt), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Transcription sessions have no Clone, so the caller's copy is made through JSON
+	var resp session.CreateTranscriptionSessionResponse
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy cached transcription session: %w", err)
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to copy cached transcription session: %w", err)
+	}
+	return &resp, nil
+}
+
+// Len returns the number of cached configurations, including expired ones not yet evicted
+func (c *SessionCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// Purge removes all cached sessions
+func (c *SessionCache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	clear(c.entries)
+}
+
+// get returns the cached value for the request, or creates it, sharing a creation
+// already in flight for the same request. A creation runs with the context of the
+// caller that started it; if that context ends, the callers sharing the creation whose
+// own context is still live start a new one instead of failing with the other caller's
+// cancellation.
+func (c *SessionCache) get(ctx context.Context, kind string, req any, create func(context.Context) (any, time.Time, error)) (any, error) {
+	key, err := fingerprint(kind, req)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		c.mu.Lock()
+		if entry, ok := c.entries[key]; ok {
+			if entry.expiresAt.Sub(c.clock.Now()) >= c.minRemaining {
+				c.mu.Unlock()
+				return entry.value, nil
+			}
+			delete(c.entries, key)
+		}
+		if call, ok := c.inflight[key]; ok {
+			c.mu.Unlock()
+			if c.joined != nil {
+				c.joined()
+			}
+			select {
+			case <-call.done:
+				if isContextError(call.err) && ctx.Err() == nil {
+					continue
+				}
+				return call.value, call.err
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+		call := &cacheCall{done: make(chan struct{})}
+		c.inflight[key] = call
+		c.mu.Unlock()
+
+		value, expiresAt, err := create(ctx)
+
+		c.mu.Lock()
+		delete(c.inflight, key)
+		if err == nil && expiresAt.Sub(c.clock.Now()) >= c.minRemaining {
+			c.evict()
+			c.entries[key] = cacheEntry{value: value, expiresAt: expiresAt}
+		}
+		c.mu.Unlock()
+
+		call.value, call.err = value, err
+		close(call.done)
+		return value, err
+	}
+}
+
+// isContextError reports whether err is caused by a cancelled or expired context
+func isContextError(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// evict makes room for a new entry by removing expired entries and, if the cache is
+// still full, the entry closest to expiry; the lock must be held
+func (c *SessionCache) evict() {
+	now := c.clock.Now()
+	for key, entry := range c.entries {
+		if entry.expiresAt.Sub(now) < c.minRemaining {
+			delete(c.entries, key)
+		}
+	}
+	for len(c.entries) >= c.maxEntries {
+		var oldest string
+		for key, entry := range c.entries {
+			if oldest == "" || entry.expiresAt.Before(c.entries[oldest].expiresAt) {
+				oldest = key
+			}
+		}
+		delete(c.entries, oldest)
+	}
+}
+
+// fingerprint identifies a request configuration by the hash of its JSON encoding.
+// Struct fields are encoded in declaration order and map keys sorted, so equal
+// configurations always have the same fingerprint.
+func fingerprint(kind string, req any) (string, error) {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fingerprint request: %w", err)
+	}
+	sum := sha256.Sum256(append([]byte(kind+":"), data...))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// secretExpiry returns when a client secret expires, falling back to the session expiry.
+// A zero time means the expiry is unknown and the session is not cached.
+func secretExpiry(secret *session.ClientSecret, sessionExpiresAt int64) time.Time {
+	switch {
+	case secret != nil && secret.ExpiresAt > 0:
+		return time.Unix(secret.ExpiresAt, 0)
+	case sessionExpiresAt > 0:
+		return time.Unix(sessionExpiresAt, 0)
+	default:
+		return time.Time{}
+	}
+}
@@ -0,0 +1,206 @@
+package openaiClient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/clock"
+	"github.com/Mliviu79/openai-realtime-go/httpClient"
+	"github.com/Mliviu79/openai-realtime-go/session"
+)
+
+// cacheServer creates sessions whose client secrets expire expiresIn after now, counting requests
+func cacheServer(t *testing.T, now func() time.Time, expiresIn time.Duration) (*Client, *atomic.Int32) {
+	t.Helper()
+	var created atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := created.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"id": "sess_%d", "object": "realtime.session", "client_secret": {"value": "ek_%d", "expires_at": %d}}`,
+			n, n, now().Add(expiresIn).Unix())
+	}))
+	t.Cleanup(server.Close)
+
+	config := httpClient.DefaultConfig("test-token")
+	config.APIBaseURL = server.URL
+	config.HTTPClient = server.Client()
+	return NewClientWithConfig(config), &created
+}
+
+func TestSessionCacheReusesSecret(t *testing.T) {
+	fake := clock.NewFake(time.Unix(1742188000, 0))
+	client, created := cacheServer(t, fake.Now, time.Minute)
+
+	cache := NewSessionCache(client, WithCacheMinRemaining(10*time.Second), WithCacheClock(fake))
+
+	instructions := "Be brief."
+	req := &session.CreateRequest{SessionRequest: session.SessionRequest{ResponseParams: session.ResponseParams{Instructions: &instructions}}}
+	other := "Be verbose."
+	otherReq := &session.CreateRequest{SessionRequest: session.SessionRequest{ResponseParams: session.ResponseParams{Instructions: &other}}}
+
+	tests := []struct {
+		name          string
+		advance       time.Duration
+		req           *session.CreateRequest
+		expectedID    string
+		expectedCount int32
+	}{
+		{name: "first request creates", req: req, expectedID: "sess_1", expectedCount: 1},
+		{name: "same configuration reuses", advance: 30 * time.Second, req: req, expectedID: "sess_1", expectedCount: 1},
+		{name: "different configuration creates", req: otherReq, expectedID: "sess_2", expectedCount: 2},
+		{name: "secret close to expiry is replaced", advance: 25 * time.Second, req: req, expectedID: "sess_3", expectedCount: 3},
+	}
+
+	for _, tt := range tests {
+		fake.Advance(tt.advance)
+		resp, err := cache.CreateSession(context.Background(), tt.req)
+		if err != nil {
+			t.Fatalf("%s: Unexpected error: %v", tt.name, err)
+		}
+		if resp.ID != tt.expectedID {
+			t.Errorf("%s: Expected session %s, got %s", tt.name, tt.expectedID, resp.ID)
+		}
+		if got := created.Load(); got != tt.expectedCount {
+			t.Errorf("%s: Expected %d sessions created, got %d", tt.name, tt.expectedCount, got)
+		}
+	}
+
+	// Callers get independent copies
+	first, _ := cache.CreateSession(context.Background(), req)
+	first.ClientSecret.Value = "modified"
+	second, _ := cache.CreateSession(context.Background(), req)
+	if second.ClientSecret.Value == "modified" {
+		t.Error("Expected cached response to be unaffected by changes to a returned copy")
+	}
+
+	cache.Purge()
+	if cache.Len() != 0 {
+		t.Errorf("Expected empty cache after Purge, got %d entries", cache.Len())
+	}
+}
+
+func TestSessionCacheSharesInflightCreation(t *testing.T) {
+	release := make(chan struct{})
+	var created atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		created.Add(1)
+		<-release
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"id": "sess_1", "client_secret": {"value": "ek_1", "expires_at": %d}}`, time.Now().Add(time.Minute).Unix())
+	}))
+	defer server.Close()
+
+	config := httpClient.DefaultConfig("test-token")
+	config.APIBaseURL = server.URL
+	config.HTTPClient = &http.Client{}
+	cache := NewSessionCache(NewClientWithConfig(config))
+	joined := make(chan struct{}, 5)
+	cache.joined = func() { joined <- struct{}{} }
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := cache.CreateSession(context.Background(), &session.CreateRequest{})
+			errs <- err
+		}()
+	}
+
+	// Release the creation once the other requests have joined it
+	for i := 0; i < 4; i++ {
+		<-joined
+	}
+	close(release)
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+	}
+	if got := created.Load(); got != 1 {
+		t.Errorf("Expected 1 session created, got %d", got)
+	}
+}
+
+func TestSessionCacheRetriesAfterCancelledCreation(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{})
+	var created atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if created.Add(1) == 1 {
+			close(started)
+			<-release
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"id": "sess_2", "client_secret": {"value": "ek_2", "expires_at": %d}}`, time.Now().Add(time.Minute).Unix())
+	}))
+	defer server.Close()
+
+	config := httpClient.DefaultConfig("test-token")
+	config.APIBaseURL = server.URL
+	config.HTTPClient = &http.Client{}
+	defer close(release)
+	cache := NewSessionCache(NewClientWithConfig(config))
+	joined := make(chan struct{}, 1)
+	cache.joined = func() { joined <- struct{}{} }
+
+	first, cancel := context.WithCancel(context.Background())
+	firstErr := make(chan error, 1)
+	go func() {
+		_, err := cache.CreateSession(first, &session.CreateRequest{})
+		firstErr <- err
+	}()
+	<-started
+
+	secondErr := make(chan error, 1)
+	go func() {
+		_, err := cache.CreateSession(context.Background(), &session.CreateRequest{})
+		secondErr <- err
+	}()
+
+	// Cancel the first request once the second has joined its creation
+	<-joined
+	cancel()
+
+	if err := <-firstErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the cancelled caller to get context.Canceled, got %v", err)
+	}
+	if err := <-secondErr; err != nil {
+		t.Errorf("Expected the live caller to create a session, got %v", err)
+	}
+	if got := created.Load(); got != 2 {
+		t.Errorf("Expected 2 session requests, got %d", got)
+	}
+}
+
+func TestSessionCacheEviction(t *testing.T) {
+	fake := clock.NewFake(time.Unix(1742188000, 0))
+	client, _ := cacheServer(t, fake.Now, time.Minute)
+
+	cache := NewSessionCache(client, WithCacheMaxEntries(2), WithCacheClock(fake))
+
+	for i := 0; i < 3; i++ {
+		instructions := fmt.Sprintf("config %d", i)
+		req := &session.CreateRequest{SessionRequest: session.SessionRequest{ResponseParams: session.ResponseParams{Instructions: &instructions}}}
+		if _, err := cache.CreateSession(context.Background(), req); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		fake.Advance(time.Second)
+	}
+
+	if cache.Len() != 2 {
+		t.Errorf("Expected 2 entries, got %d", cache.Len())
+	}
+}
@@ -111,7 +111,10 @@ package openaiClient
 import (
 	"context"
 	"fmt"
+	"net"
+	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/Mliviu79/openai-realtime-go/httpClient"
 	logger "github.com/Mliviu79/openai-realtime-go/logger"
@@ -119,15 +122,40 @@ import (
 	"github.com/Mliviu79/openai-realtime-go/ws"
 )
 
+// Intent selects the kind of realtime connection to establish
+type Intent string
+
+const (
+	// IntentConversation is a model-based conversation. It is the default and requires WithModel.
+	IntentConversation Intent = ""
+
+	// IntentTranscription is a transcription-only connection; no model is required
+	IntentTranscription Intent = "transcription"
+)
+
 // ConnectOption is a function that configures connection options
 type ConnectOption func(*connectOptions)
 
 // connectOptions holds the options for establishing a connection
 type connectOptions struct {
 	model     string        // The model to use for the connection
+	strict    bool          // Reject models unknown to the session package
+	intent    Intent        // The kind of connection to establish
 	logger    logger.Logger // Logger for the connection
 	sessionID string        // Session ID for the connection
 	readLimit int64         // Maximum size of a WebSocket message in bytes
+	headers   http.Header   // Extra headers for the upgrade request
+	query     url.Values    // Extra query parameters for the upgrade request
+
+	subprotocolAuth bool // Authenticate via WebSocket subprotocols instead of headers
+
+	// dialContext creates the underlying network connection
+	dialContext func(ctx context.Context, network, addr string) (net.Conn, error)
+
+	metrics ws.MetricsHook // Receives dial failure and abnormal close telemetry
+
+	keepAliveInterval time.Duration // Interval between keep-alive pings; zero disables them
+	keepAliveTimeout  time.Duration // Maximum time a keep-alive ping may take
 }
 
 // WithModel sets the model for the connection
@@ -140,6 +168,26 @@ func WithModel(model session.Model) ConnectOption {
 	}
 }
 
+// WithStrictModel rejects models that are not known to the session package,
+// catching typos in model names before connecting. By default any model string is
+// accepted so that new model snapshots can be used without a library update.
+func WithStrictModel() ConnectOption {
+	return func(o *connectOptions) {
+		o.strict = true
+	}
+}
+
+// WithIntent sets the kind of connection to establish.
+// Use IntentTranscription to connect to a transcription session.
+//
+// Parameters:
+//   - intent: The connection intent
+func WithIntent(intent Intent) ConnectOption {
+	return func(o *connectOptions) {
+		o.intent = intent
+	}
+}
+
 // WithLogger sets the logger for the connection
 //
 // Parameters:
@@ -170,46 +218,137 @@ func WithReadLimit(readLimit int64) ConnectOption {
 	}
 }
 
-// TranscriptionConnectOption is a function that configures transcription connection options
-type TranscriptionConnectOption func(*transcriptionConnectOptions)
+// WithHeader sets an extra header on the WebSocket upgrade request, such as a tenant
+// or trace header required by an API gateway. It replaces any header with the same
+// key, including the default authorization and beta headers.
+//
+// Parameters:
+//   - key: The header name
+//   - value: The header value
+func WithHeader(key, value string) ConnectOption {
+	return func(o *connectOptions) {
+		if o.headers == nil {
+			o.headers = make(http.Header)
+		}
+		o.headers.Set(key, value)
+	}
+}
 
-// transcriptionConnectOptions holds the options for establishing a transcription connection
-type transcriptionConnectOptions struct {
-	logger    logger.Logger // Logger for the connection
-	sessionID string        // Session ID for the connection
-	readLimit int64         // Maximum size of a WebSocket message in bytes
+// WithHeaders sets extra headers on the WebSocket upgrade request.
+// Each key replaces any header with the same key, including the default headers.
+//
+// Parameters:
+//   - headers: The headers to set
+func WithHeaders(headers http.Header) ConnectOption {
+	return func(o *connectOptions) {
+		if o.headers == nil {
+			o.headers = make(http.Header)
+		}
+		for key, values := range headers {
+			o.headers[http.CanonicalHeaderKey(key)] = append([]string(nil), values...)
+		}
+	}
 }
 
-// WithTranscriptionLogger sets the logger for the transcription connection
+// WithQueryParam adds an extra query parameter to the WebSocket URL.
+// The model, intent and session_id parameters are controlled by their dedicated
+// options and cannot be overridden.
 //
 // Parameters:
-//   - logger: The logger to use for the connection
-func WithTranscriptionLogger(logger logger.Logger) TranscriptionConnectOption {
-	return func(o *transcriptionConnectOptions) {
-		o.logger = logger
+//   - key: The parameter name
+//   - value: The parameter value
+func WithQueryParam(key, value string) ConnectOption {
+	return func(o *connectOptions) {
+		if o.query == nil {
+			o.query = make(url.Values)
+		}
+		o.query.Add(key, value)
 	}
 }
 
-// WithTranscriptionSessionID sets the session ID for the transcription connection
+// WithSubprotocolAuth authenticates with the openai-insecure-api-key and
+// openai-beta.realtime-v1 WebSocket subprotocols instead of the Authorization header,
+// the way browsers and relays connect. It has no effect for Azure configurations.
+func WithSubprotocolAuth() ConnectOption {
+	return func(o *connectOptions) {
+		o.subprotocolAuth = true
+	}
+}
+
+// WithDialContext sets the function used to create the underlying network connection,
+// for example to connect over a Unix socket, resolve hosts with custom DNS or tunnel
+// the connection through another transport. The URL host is still sent in the handshake.
 //
 // Parameters:
-//   - sessionID: The session ID to use for the connection
-func WithTranscriptionSessionID(sessionID string) TranscriptionConnectOption {
-	return func(o *transcriptionConnectOptions) {
-		o.sessionID = sessionID
+//   - dialContext: The function that dials the network connection
+func WithDialContext(dialContext func(ctx context.Context, network, addr string) (net.Conn, error)) ConnectOption {
+	return func(o *connectOptions) {
+		o.dialContext = dialContext
 	}
 }
 
-// WithTranscriptionReadLimit sets the maximum size of a WebSocket message in bytes
+// WithNetConn runs the WebSocket handshake over a pre-established connection,
+// such as one end of a net.Pipe in tests. The connection is used for a single dial.
 //
 // Parameters:
-//   - readLimit: The maximum size in bytes (0 or negative means no limit)
-func WithTranscriptionReadLimit(readLimit int64) TranscriptionConnectOption {
-	return func(o *transcriptionConnectOptions) {
-		o.readLimit = readLimit
+//   - conn: The connection to use
+func WithNetConn(conn net.Conn) ConnectOption {
+	return WithDialContext(ws.NetConnDialContext(conn))
+}
+
+// WithMetricsHook sets the hook that receives connection telemetry, such as dial
+// failures and abnormal closes, so connection churn can be counted and alerted on.
+// Use ws.NewConnCounters for a ready-made counting hook.
+//
+// Parameters:
+//   - hook: The hook to receive connection events
+func WithMetricsHook(hook ws.MetricsHook) ConnectOption {
+	return func(o *connectOptions) {
+		o.metrics = hook
 	}
 }
 
+// WithKeepAlive pings the server every interval for as long as the connection is open,
+// so idle sessions are not dropped by proxies, and closes the connection when a ping
+// fails or takes longer than timeout (the interval if zero). It applies to conversation
+// and transcription connections alike. See ws.Conn.StartKeepAlive.
+//
+// Parameters:
+//   - interval: The time between pings
+//   - timeout: The maximum time a ping may take
+func WithKeepAlive(interval, timeout time.Duration) ConnectOption {
+	return func(o *connectOptions) {
+		o.keepAliveInterval = interval
+		o.keepAliveTimeout = timeout
+	}
+}
+
+// TranscriptionConnectOption is a function that configures transcription connection options.
+//
+// Deprecated: Use ConnectOption with Connect and WithIntent(IntentTranscription).
+type TranscriptionConnectOption = ConnectOption
+
+// WithTranscriptionLogger sets the logger for the transcription connection
+//
+// Deprecated: Use WithLogger.
+func WithTranscriptionLogger(logger logger.Logger) TranscriptionConnectOption {
+	return WithLogger(logger)
+}
+
+// WithTranscriptionSessionID sets the session ID for the transcription connection
+//
+// Deprecated: Use WithSessionID.
+func WithTranscriptionSessionID(sessionID string) TranscriptionConnectOption {
+	return WithSessionID(sessionID)
+}
+
+// WithTranscriptionReadLimit sets the maximum size of a WebSocket message in bytes
+//
+// Deprecated: Use WithReadLimit.
+func WithTranscriptionReadLimit(readLimit int64) TranscriptionConnectOption {
+	return WithReadLimit(readLimit)
+}
+
 // Client is OpenAI Realtime API client
 type Client struct {
 	config httpClient.ClientConfig
@@ -250,12 +389,43 @@ func NewClientWithConfig(config httpClient.ClientConfig) *Client {
 //   - *session.CreateResponse: The session creation response
 //   - error: An error if the request failed
 func (c *Client) CreateSession(ctx context.Context, req *session.CreateRequest) (*session.CreateResponse, error) {
+	if req != nil {
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
+	}
 	return httpClient.Do[session.CreateRequest, session.CreateResponse](
 		ctx,
 		c.config.APIBaseURL+"/realtime/sessions",
 		req,
 		httpClient.WithHeaders(httpClient.GetHeaders(c.config)),
 		httpClient.WithClient(c.config.HTTPClient),
+		httpClient.WithRateLimiter(c.config.RateLimiter),
+	)
+}
+
+// GetSession retrieves an existing session, including its configuration and expiry,
+// without holding a WebSocket connection to it
+//
+// Parameters:
+//   - ctx: The context for the request
+//   - sessionID: The ID of the session to retrieve
+//
+// Returns:
+//   - *session.Session: The session details
+//   - error: An error if the request failed or the session ID is empty
+func (c *Client) GetSession(ctx context.Context, sessionID string) (*session.Session, error) {
+	if sessionID == "" {
+		return nil, fmt.Errorf("session ID is required")
+	}
+	return httpClient.Do[struct{}, session.Session](
+		ctx,
+		c.config.APIBaseURL+"/realtime/sessions/"+url.PathEscape(sessionID),
+		nil,
+		httpClient.WithMethod(http.MethodGet),
+		httpClient.WithHeaders(httpClient.GetHeaders(c.config)),
+		httpClient.WithClient(c.config.HTTPClient),
+		httpClient.WithRateLimiter(c.config.RateLimiter),
 	)
 }
 
@@ -267,18 +437,26 @@ func (c *Client) CreateSession(ctx context.Context, req *session.CreateRequest)
 //
 // Returns:
 //   - *session.CreateTranscriptionSessionResponse: The transcription session creation response
-//   - error: An error if the request failed
+//   - error: An error if the request failed, or a validation error if the request is invalid
 func (c *Client) CreateTranscriptionSession(ctx context.Context, req *session.CreateTranscriptionSessionRequest) (*session.CreateTranscriptionSessionResponse, error) {
+	if req != nil {
+		if err := req.Validate(); err != nil {
+			return nil, err
+		}
+	}
 	return httpClient.Do[session.CreateTranscriptionSessionRequest, session.CreateTranscriptionSessionResponse](
 		ctx,
 		c.config.APIBaseURL+"/realtime/transcription_sessions",
 		req,
 		httpClient.WithHeaders(httpClient.GetHeaders(c.config)),
 		httpClient.WithClient(c.config.HTTPClient),
+		httpClient.WithRateLimiter(c.config.RateLimiter),
 	)
 }
 
-// Connect establishes a WebSocket connection to the OpenAI Realtime API for model-based conversations
+// Connect establishes a WebSocket connection to the OpenAI Realtime API.
+// By default it connects to a model-based conversation, which requires WithModel.
+// Use WithIntent(IntentTranscription) to connect to a transcription session instead.
 //
 // Parameters:
 //   - ctx: The context for the connection
@@ -293,30 +471,24 @@ func (c *Client) Connect(ctx context.Context, opts ...ConnectOption) (*ws.Conn,
 		opt(options)
 	}
 
-	if options.model == "" {
-		return nil, fmt.Errorf("model is required")
+	url, err := c.connectURL(options)
+	if err != nil {
+		return nil, err
 	}
 
 	// Create dialer with custom read limit if specified
 	dialer := ws.DirectDialer(ws.DialerOptions{
-		ReadLimit: options.readLimit,
+		ReadLimit:      options.readLimit,
+		Subprotocols:   c.connectSubprotocols(options),
+		NetDialContext: options.dialContext,
+		MetricsHook:    options.metrics,
 	})
 
-	// Construct URL with query parameters
-	query := url.Values{}
-	query.Set("model", options.model)
-	if options.sessionID != "" {
-		query.Set("session_id", options.sessionID)
-	}
-
-	// Set the base URL
-	baseURL := c.config.BaseURL
-	url := baseURL + "?" + query.Encode()
-
-	headers := httpClient.GetHeaders(c.config)
-
-	wsConn, err := dialer.Dial(ctx, url, headers)
+	wsConn, err := dialer.Dial(ctx, url, c.connectHeaders(options))
 	if err != nil {
+		if options.intent == IntentTranscription {
+			return nil, fmt.Errorf("failed to connect to OpenAI transcription service: %w", err)
+		}
 		return nil, fmt.Errorf("failed to connect to OpenAI: %w", err)
 	}
 
@@ -324,52 +496,71 @@ func (c *Client) Connect(ctx context.Context, opts ...ConnectOption) (*ws.Conn,
 	if options.logger != nil {
 		conn.SetLogger(options.logger)
 	}
+	if options.metrics != nil {
+		conn.SetMetricsHook(options.metrics)
+	}
+	conn.StartKeepAlive(options.keepAliveInterval, options.keepAliveTimeout)
 
 	return conn, nil
 }
 
-// ConnectTranscription establishes a WebSocket connection to the OpenAI Realtime API for transcription
+// ConnectTranscription establishes a WebSocket connection to the OpenAI Realtime API for transcription.
+// It accepts the same options as Connect, including WithKeepAlive and WithMetricsHook.
 //
-// Parameters:
-//   - ctx: The context for the connection
-//   - opts: Options for the connection
-//
-// Returns:
-//   - *ws.Conn: The WebSocket connection
-//   - error: An error if the connection failed
+// Deprecated: Use Connect with WithIntent(IntentTranscription).
 func (c *Client) ConnectTranscription(ctx context.Context, opts ...TranscriptionConnectOption) (*ws.Conn, error) {
-	options := &transcriptionConnectOptions{}
-	for _, opt := range opts {
-		opt(options)
-	}
-
-	// Create dialer with custom read limit if specified
-	dialer := ws.DirectDialer(ws.DialerOptions{
-		ReadLimit: options.readLimit,
-	})
+	return c.Connect(ctx, append([]ConnectOption{WithIntent(IntentTranscription)}, opts...)...)
+}
 
-	// Construct URL with query parameters
-	query := url.Values{}
-	query.Set("intent", "transcription")
-	if options.sessionID != "" {
-		query.Set("session_id", options.sessionID)
+// connectHeaders returns the default headers merged with any extra headers from the options
+func (c *Client) connectHeaders(options *connectOptions) http.Header {
+	headers := httpClient.GetHeaders(c.config)
+	if c.connectSubprotocols(options) != nil {
+		headers.Del("Authorization")
+	}
+	for key, values := range options.headers {
+		headers[key] = values
 	}
+	return headers
+}
 
-	// Set the base URL
-	baseURL := c.config.BaseURL
-	url := baseURL + "?" + query.Encode()
+// connectSubprotocols returns the subprotocols carrying the credentials, or nil
+// when the connection authenticates with headers
+func (c *Client) connectSubprotocols(options *connectOptions) []string {
+	if !options.subprotocolAuth {
+		return nil
+	}
+	return httpClient.GetSubprotocols(c.config)
+}
 
-	headers := httpClient.GetHeaders(c.config)
+// connectURL builds the WebSocket URL with query parameters for the given options
+func (c *Client) connectURL(options *connectOptions) (string, error) {
+	if options.strict && options.model != "" {
+		if err := session.Model(options.model).ValidateKnown(); err != nil {
+			return "", err
+		}
+	}
 
-	wsConn, err := dialer.Dial(ctx, url, headers)
-	if err != nil {
-		return nil, fmt.Errorf("failed to connect to OpenAI transcription service: %w", err)
+	query := url.Values{}
+	for key, values := range options.query {
+		query[key] = append([]string(nil), values...)
 	}
 
-	conn := ws.NewConn(wsConn)
-	if options.logger != nil {
-		conn.SetLogger(options.logger)
+	switch options.intent {
+	case IntentConversation:
+		if options.model == "" {
+			return "", fmt.Errorf("model is required")
+		}
+		query.Set("model", options.model)
+	default:
+		query.Set("intent", string(options.intent))
+		if options.model != "" {
+			query.Set("model", options.model)
+		}
+	}
+	if options.sessionID != "" {
+		query.Set("session_id", options.sessionID)
 	}
 
-	return conn, nil
+	return c.config.BaseURL + "?" + query.Encode(), nil
 }
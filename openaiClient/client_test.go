@@ -2,12 +2,16 @@ package openaiClient
 
 import (
 	"context"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/Mliviu79/openai-realtime-go/httpClient"
 	"github.com/Mliviu79/openai-realtime-go/session"
+	"github.com/gorilla/websocket"
 )
 
 func TestNewClient(t *testing.T) {
@@ -151,3 +155,322 @@ func TestConnectOptions(t *testing.T) {
 		})
 	}
 }
+
+func TestConnectURL(t *testing.T) {
+	client := NewClient("test-token")
+
+	tests := []struct {
+		name        string
+		opts        []ConnectOption
+		expectedURL string
+		expectErr   bool
+	}{
+		{
+			name:        "Conversation",
+			opts:        []ConnectOption{WithModel("gpt-4o-realtime-preview"), WithSessionID("sess_1")},
+			expectedURL: client.config.BaseURL + "?model=gpt-4o-realtime-preview&session_id=sess_1",
+		},
+		{
+			name:      "ConversationWithoutModel",
+			opts:      nil,
+			expectErr: true,
+		},
+		{
+			name:        "Transcription",
+			opts:        []ConnectOption{WithIntent(IntentTranscription), WithSessionID("sess_2")},
+			expectedURL: client.config.BaseURL + "?intent=transcription&session_id=sess_2",
+		},
+		{
+			name:        "UnknownModelSnapshot",
+			opts:        []ConnectOption{WithModel("gpt-realtime-2099-01-01")},
+			expectedURL: client.config.BaseURL + "?model=gpt-realtime-2099-01-01",
+		},
+		{
+			name:        "StrictKnownModel",
+			opts:        []ConnectOption{WithModel(session.GPTRealtime), WithStrictModel()},
+			expectedURL: client.config.BaseURL + "?model=gpt-realtime",
+		},
+		{
+			name:      "StrictUnknownModel",
+			opts:      []ConnectOption{WithModel("gpt-realtme"), WithStrictModel()},
+			expectErr: true,
+		},
+		{
+			name:        "DeprecatedTranscriptionOptions",
+			opts:        []ConnectOption{WithIntent(IntentTranscription), WithTranscriptionSessionID("sess_3")},
+			expectedURL: client.config.BaseURL + "?intent=transcription&session_id=sess_3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			options := &connectOptions{}
+			for _, opt := range tt.opts {
+				opt(options)
+			}
+
+			url, err := client.connectURL(options)
+			if tt.expectErr {
+				if err == nil {
+					t.Error("Expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if url != tt.expectedURL {
+				t.Errorf("Expected URL %s, got %s", tt.expectedURL, url)
+			}
+		})
+	}
+}
+
+func TestConnectCustomHeadersAndQuery(t *testing.T) {
+	client := NewClient("test-token")
+
+	options := &connectOptions{}
+	for _, opt := range []ConnectOption{
+		WithModel("gpt-4o-realtime-preview"),
+		WithHeader("X-Tenant-ID", "tenant-1"),
+		WithHeaders(http.Header{"traceparent": {"00-abc-def-01"}}),
+		WithQueryParam("region", "eu"),
+		WithQueryParam("model", "ignored"),
+	} {
+		opt(options)
+	}
+
+	headers := client.connectHeaders(options)
+	if headers.Get("X-Tenant-ID") != "tenant-1" {
+		t.Errorf("Expected tenant header, got %q", headers.Get("X-Tenant-ID"))
+	}
+	if headers.Get("Traceparent") != "00-abc-def-01" {
+		t.Errorf("Expected traceparent header, got %q", headers.Get("Traceparent"))
+	}
+	if headers.Get("Authorization") != "Bearer test-token" {
+		t.Errorf("Expected default authorization header, got %q", headers.Get("Authorization"))
+	}
+
+	url, err := client.connectURL(options)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := client.config.BaseURL + "?model=gpt-4o-realtime-preview&region=eu"
+	if url != expected {
+		t.Errorf("Expected URL %s, got %s", expected, url)
+	}
+}
+
+func TestConnectSubprotocolAuth(t *testing.T) {
+	var gotAuth string
+	var gotProtocols []string
+	upgrader := websocket.Upgrader{Subprotocols: []string{"realtime"}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAuth = r.Header.Get("Authorization")
+		gotProtocols = websocket.Subprotocols(r)
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		conn.Close()
+	}))
+	defer server.Close()
+
+	config := httpClient.DefaultConfig("test-token")
+	config.BaseURL = "ws" + server.URL[len("http"):]
+	client := NewClientWithConfig(config)
+
+	conn, err := client.Connect(context.Background(), WithModel("gpt-4o-realtime-preview"), WithSubprotocolAuth())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer conn.Close()
+
+	if gotAuth != "" {
+		t.Errorf("Expected no Authorization header, got %q", gotAuth)
+	}
+	expected := []string{"realtime", "openai-insecure-api-key.test-token", "openai-beta.realtime-v1"}
+	if len(gotProtocols) != len(expected) {
+		t.Fatalf("Expected subprotocols %v, got %v", expected, gotProtocols)
+	}
+	for i := range expected {
+		if gotProtocols[i] != expected[i] {
+			t.Errorf("Expected subprotocol %q, got %q", expected[i], gotProtocols[i])
+		}
+	}
+}
+
+func TestConnectWithDialContext(t *testing.T) {
+	var gotHost string
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHost = r.Host
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		conn.Close()
+	}))
+	defer server.Close()
+
+	var dialedAddr string
+	dialContext := func(ctx context.Context, network, addr string) (net.Conn, error) {
+		dialedAddr = addr
+		var d net.Dialer
+		return d.DialContext(ctx, network, server.Listener.Addr().String())
+	}
+
+	config := httpClient.DefaultConfig("test-token")
+	config.BaseURL = "ws://realtime.invalid/v1/realtime"
+	client := NewClientWithConfig(config)
+
+	conn, err := client.Connect(context.Background(), WithModel("gpt-4o-realtime-preview"), WithDialContext(dialContext))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer conn.Close()
+
+	if dialedAddr != "realtime.invalid:80" {
+		t.Errorf("Expected dial address %q, got %q", "realtime.invalid:80", dialedAddr)
+	}
+	if gotHost != "realtime.invalid" {
+		t.Errorf("Expected host %q, got %q", "realtime.invalid", gotHost)
+	}
+}
+
+func TestGetSession(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/realtime/sessions/sess_123" {
+			t.Errorf("Expected request to '/realtime/sessions/sess_123', got %q", r.URL.Path)
+		}
+
+		if r.Method != http.MethodGet {
+			t.Errorf("Expected GET request, got %s", r.Method)
+		}
+
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Errorf("Expected Authorization header to be 'Bearer test-token', got %q", r.Header.Get("Authorization"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"id": "sess_123", "object": "realtime.session", "model": "gpt-4o-realtime-preview", "instructions": "Be brief.", "expires_at": 1742188264}`))
+	}))
+	defer server.Close()
+
+	config := httpClient.DefaultConfig("test-token")
+	config.APIBaseURL = server.URL
+	config.HTTPClient = server.Client()
+	client := NewClientWithConfig(config)
+
+	resp, err := client.GetSession(context.Background(), "sess_123")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if resp.ID != "sess_123" {
+		t.Errorf("Expected session ID to be 'sess_123', got %q", resp.ID)
+	}
+	if resp.ExpiresAt != 1742188264 {
+		t.Errorf("Expected expires_at to be 1742188264, got %d", resp.ExpiresAt)
+	}
+	if resp.Instructions == nil || *resp.Instructions != "Be brief." {
+		t.Errorf("Expected instructions to be 'Be brief.', got %v", resp.Instructions)
+	}
+}
+
+func TestGetSessionErrors(t *testing.T) {
+	client := NewClient("test-token")
+	if _, err := client.GetSession(context.Background(), ""); err == nil {
+		t.Error("Expected error for empty session ID")
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"type": "error", "error": {"type": "invalid_request_error", "message": "Session not found"}}`))
+	}))
+	defer server.Close()
+
+	config := httpClient.DefaultConfig("test-token")
+	config.APIBaseURL = server.URL
+	config.HTTPClient = server.Client()
+	client = NewClientWithConfig(config)
+
+	if _, err := client.GetSession(context.Background(), "sess_missing"); err == nil {
+		t.Error("Expected error for missing session")
+	}
+}
+
+func TestClientRateLimiter(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": "sess_123", "object": "realtime.session"}`))
+	}))
+	defer server.Close()
+
+	config := httpClient.DefaultConfig("test-token")
+	config.APIBaseURL = server.URL
+	config.HTTPClient = server.Client()
+	config.RateLimiter = httpClient.NewRateLimiter(0.001, 1)
+	client := NewClientWithConfig(config)
+
+	if _, err := client.GetSession(context.Background(), "sess_123"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := client.CreateSession(ctx, &session.CreateRequest{}); err == nil {
+		t.Error("Expected the rate limiter to hold the second request until the deadline")
+	}
+	if requests != 1 {
+		t.Errorf("Expected 1 request to reach the server, got %d", requests)
+	}
+}
+
+func TestConnectTranscriptionKeepAlive(t *testing.T) {
+	pings := make(chan struct{}, 16)
+	var gotQuery string
+	upgrader := websocket.Upgrader{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotQuery = r.URL.RawQuery
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		conn.SetPingHandler(func(string) error {
+			pings <- struct{}{}
+			return nil
+		})
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}))
+	defer server.Close()
+
+	config := httpClient.DefaultConfig("test-token")
+	config.BaseURL = "ws" + strings.TrimPrefix(server.URL, "http")
+	client := NewClientWithConfig(config)
+
+	conn, err := client.ConnectTranscription(context.Background(), WithKeepAlive(10*time.Millisecond, 0))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer conn.Close()
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-pings:
+		case <-time.After(time.Second):
+			t.Fatalf("Expected keep-alive pings on the transcription connection, got %d", i)
+		}
+	}
+	if gotQuery != "intent=transcription" {
+		t.Errorf("Expected a transcription connection, got query %q", gotQuery)
+	}
+}
@@ -0,0 +1,63 @@
+package openaiClient
+
+import (
+	"context"
+
+	"github.com/Mliviu79/openai-realtime-go/httpClient"
+)
+
+// DefaultModerationModel is the moderation model used when a ModerationRequest names none
+const DefaultModerationModel = "omni-moderation-latest"
+
+// ModerationRequest is a request to the Moderations endpoint
+type ModerationRequest struct {
+	// Input is the text to classify
+	Input string `json:"input"`
+
+	// Model is the moderation model; empty uses DefaultModerationModel
+	Model string `json:"model,omitempty"`
+}
+
+// ModerationResult is the classification of one input
+type ModerationResult struct {
+	// Flagged is true if the input violates any category
+	Flagged bool `json:"flagged"`
+
+	// Categories reports, for each category such as "harassment" or "self-harm/intent",
+	// whether the input violates it
+	Categories map[string]bool `json:"categories"`
+
+	// CategoryScores is the model's confidence in each category, between 0 and 1
+	CategoryScores map[string]float64 `json:"category_scores"`
+}
+
+// ModerationResponse is the response of the Moderations endpoint
+type ModerationResponse struct {
+	ID      string             `json:"id"`
+	Model   string             `json:"model"`
+	Results []ModerationResult `json:"results"`
+}
+
+// CreateModeration classifies text with the Moderations endpoint
+//
+// Parameters:
+//   - ctx: The context for the request
+//   - req: The moderation request
+//
+// Returns:
+//   - *ModerationResponse: The classification of the input
+//   - error: An error if the request failed
+func (c *Client) CreateModeration(ctx context.Context, req *ModerationRequest) (*ModerationResponse, error) {
+	body := *req
+	if body.Model == "" {
+		body.Model = DefaultModerationModel
+	}
+	return httpClient.Do[ModerationRequest, ModerationResponse](
+		ctx,
+		c.config.APIBaseURL+"/moderations",
+		&body,
+		httpClient.WithHeaders(httpClient.GetHeaders(c.config)),
+		httpClient.WithClient(c.config.HTTPClient),
+		httpClient.WithRateLimiter(c.config.RateLimiter),
+	)
+}
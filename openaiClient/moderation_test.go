@@ -0,0 +1,47 @@
+package openaiClient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Mliviu79/openai-realtime-go/httpClient"
+)
+
+func TestCreateModeration(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/moderations" || r.Method != http.MethodPost {
+			t.Errorf("Expected POST /moderations, got %s %s", r.Method, r.URL.Path)
+		}
+
+		var req ModerationRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Errorf("Failed to decode request: %v", err)
+		}
+		if req.Input != "some text" || req.Model != DefaultModerationModel {
+			t.Errorf("Expected the input with the default model, got %+v", req)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"id": "modr_1", "model": "omni-moderation-latest", "results": [{"flagged": true, "categories": {"harassment": true, "violence": false}, "category_scores": {"harassment": 0.91, "violence": 0.02}}]}`))
+	}))
+	defer server.Close()
+
+	config := httpClient.DefaultConfig("test-token")
+	config.APIBaseURL = server.URL
+	config.HTTPClient = server.Client()
+	client := NewClientWithConfig(config)
+
+	resp, err := client.CreateModeration(context.Background(), &ModerationRequest{Input: "some text"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(resp.Results) != 1 || !resp.Results[0].Flagged || !resp.Results[0].Categories["harassment"] {
+		t.Errorf("Expected a flagged harassment result, got %+v", resp.Results)
+	}
+	if resp.Results[0].CategoryScores["harassment"] != 0.91 {
+		t.Errorf("Expected a harassment score of 0.91, got %v", resp.Results[0].CategoryScores["harassment"])
+	}
+}
@@ -0,0 +1,204 @@
+package recording
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/clock"
+)
+
+//-----------------------------------------------------------------------------
+// AES-GCM
+//-----------------------------------------------------------------------------
+
+// KeySize is the size of the keys created by NewKey, which select AES-256
+const KeySize = 32
+
+// Key is an AES key and the ID it is stored under in the records it encrypts
+type Key struct {
+	// ID identifies the key in a key store; it must not contain spaces
+	ID string
+	// Secret is the AES key: 16, 24 or 32 bytes for AES-128, AES-192 or AES-256
+	Secret []byte
+}
+
+// NewKey creates a random AES-256 key with the given ID
+func NewKey(id string) (Key, error) {
+	secret := make([]byte, KeySize)
+	if _, err := rand.Read(secret); err != nil {
+		return Key{}, err
+	}
+	return Key{ID: id, Secret: secret}, nil
+}
+
+// RotateFunc returns the key that replaces current. It is where an application creates
+// the next key and saves it to its key store before anything is encrypted with it.
+type RotateFunc func(current Key) (Key, error)
+
+// AESGCMOption configures an AESGCM
+type AESGCMOption func(*AESGCM)
+
+// WithKeyRotation replaces the encryption key with the one returned by rotate once it
+// has been in use for interval. Zero disables rotation by age; Rotate can still be
+// called to rotate on demand.
+func WithKeyRotation(interval time.Duration, rotate RotateFunc) AESGCMOption {
+	return func(a *AESGCM) {
+		a.interval = interval
+		a.rotate = rotate
+	}
+}
+
+// WithDecryptionKeys adds keys that can decrypt records but are not used to encrypt,
+// such as the keys of earlier recordings
+func WithDecryptionKeys(keys ...Key) AESGCMOption {
+	return func(a *AESGCM) {
+		a.extra = append(a.extra, keys...)
+	}
+}
+
+// WithAESGCMClock sets the clock that key ages are measured with; the default is the
+// real clock. A nil clock is ignored.
+func WithAESGCMClock(c clock.Clock) AESGCMOption {
+	return func(a *AESGCM) {
+		if c != nil {
+			a.clock = c
+		}
+	}
+}
+
+// AESGCM is the reference Encrypter and Decrypter. Each record is sealed with AES-GCM
+// under a fresh random nonce, which is stored in front of the ciphertext, and the key
+// ID is authenticated with the record so a record cannot be passed off as encrypted
+// with another key. It keeps every key it has encrypted with, so it can decrypt a whole
+// recording across rotations. It is safe for concurrent use.
+type AESGCM struct {
+	interval time.Duration
+	rotate   RotateFunc
+	extra    []Key
+	clock    clock.Clock
+
+	mu        sync.Mutex
+	current   Key
+	rotatedAt time.Time
+	aeads     map[string]cipher.AEAD
+}
+
+// NewAESGCM creates an AESGCM that encrypts with key. It returns an error if a key has
+// an invalid ID or secret.
+func NewAESGCM(key Key, opts ...AESGCMOption) (*AESGCM, error) {
+	a := &AESGCM{
+		clock: clock.Real(),
+		aeads: make(map[string]cipher.AEAD),
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+	for _, k := range a.extra {
+		if err := a.addKey(k); err != nil {
+			return nil, err
+		}
+	}
+	if err := a.addKey(key); err != nil {
+		return nil, err
+	}
+	a.current = key
+	a.rotatedAt = a.clock.Now()
+	return a, nil
+}
+
+// KeyID returns the ID of the key that encrypts the next record
+func (a *AESGCM) KeyID() string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.current.ID
+}
+
+// Rotate replaces the encryption key with the one returned by the rotation callback,
+// e.g. after the current key has been compromised. Records encrypted with earlier keys
+// can still be decrypted.
+func (a *AESGCM) Rotate() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.rotateLocked()
+}
+
+// Encrypt seals one record with the current key, rotating it first if it is older than
+// the rotation interval. If rotation fails, nothing is encrypted, so records are never
+// written under a key that should have been retired.
+func (a *AESGCM) Encrypt(plaintext []byte) (string, []byte, error) {
+	a.mu.Lock()
+	if a.interval > 0 && a.clock.Now().Sub(a.rotatedAt) >= a.interval {
+		if err := a.rotateLocked(); err != nil {
+			a.mu.Unlock()
+			return "", nil, err
+		}
+	}
+	keyID := a.current.ID
+	aead := a.aeads[keyID]
+	a.mu.Unlock()
+
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", nil, err
+	}
+	return keyID, aead.Seal(nonce, nonce, plaintext, []byte(keyID)), nil
+}
+
+// Decrypt opens one record encrypted with the key keyID
+func (a *AESGCM) Decrypt(keyID string, ciphertext []byte) ([]byte, error) {
+	a.mu.Lock()
+	aead, ok := a.aeads[keyID]
+	a.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown key %q", keyID)
+	}
+
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, errors.New("ciphertext is too short")
+	}
+	nonce, sealed := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	return aead.Open(nil, nonce, sealed, []byte(keyID))
+}
+
+// rotateLocked replaces the current key; a.mu must be held
+func (a *AESGCM) rotateLocked() error {
+	if a.rotate == nil {
+		return errors.New("key rotation is not configured")
+	}
+	key, err := a.rotate(a.current)
+	if err != nil {
+		return fmt.Errorf("failed to rotate key %q: %w", a.current.ID, err)
+	}
+	if err := a.addKey(key); err != nil {
+		return err
+	}
+	a.current = key
+	a.rotatedAt = a.clock.Now()
+	return nil
+}
+
+// addKey makes key available for encryption and decryption. Key IDs must be unique, so
+// records always decrypt with the key they name.
+func (a *AESGCM) addKey(key Key) error {
+	if !validKeyID(key.ID) {
+		return fmt.Errorf("invalid key ID %q", key.ID)
+	}
+	if _, ok := a.aeads[key.ID]; ok {
+		return fmt.Errorf("duplicate key ID %q", key.ID)
+	}
+	block, err := aes.NewCipher(key.Secret)
+	if err != nil {
+		return fmt.Errorf("invalid key %q: %w", key.ID, err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return err
+	}
+	a.aeads[key.ID] = aead
+	return nil
+}
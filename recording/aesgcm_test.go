@@ -0,0 +1,131 @@
+package recording
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/clock"
+)
+
+func TestAESGCMRotation(t *testing.T) {
+	clk := clock.NewFake(time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC))
+	first, err := NewKey("k0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var saved []Key
+	rotate := func(current Key) (Key, error) {
+		key, err := NewKey(fmt.Sprintf("k%d", len(saved)+1))
+		saved = append(saved, key)
+		return key, err
+	}
+	enc, err := NewAESGCM(first, WithKeyRotation(time.Hour, rotate), WithAESGCMClock(clk))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type record struct {
+		keyID, plaintext string
+		ciphertext       []byte
+	}
+	var records []record
+	encrypt := func(plaintext string) {
+		keyID, ciphertext, err := enc.Encrypt([]byte(plaintext))
+		if err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, record{keyID, plaintext, ciphertext})
+	}
+
+	encrypt("a")
+	clk.Advance(59 * time.Minute)
+	encrypt("b")
+	clk.Advance(time.Minute)
+	encrypt("c")
+	if err := enc.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+	encrypt("d")
+
+	wantIDs := []string{"k0", "k0", "k1", "k2"}
+	for i, r := range records {
+		if r.keyID != wantIDs[i] {
+			t.Errorf("Record %q: expected key %s, got %s", r.plaintext, wantIDs[i], r.keyID)
+		}
+		got, err := enc.Decrypt(r.keyID, r.ciphertext)
+		if err != nil || string(got) != r.plaintext {
+			t.Errorf("Record %q: expected it to decrypt, got %q, %v", r.plaintext, got, err)
+		}
+	}
+	if enc.KeyID() != "k2" || len(saved) != 2 {
+		t.Errorf("Expected 2 rotations ending with k2, got %d ending with %s", len(saved), enc.KeyID())
+	}
+
+	// A later reader with the saved keys decrypts every record
+	reader, err := NewAESGCM(saved[1], WithDecryptionKeys(first, saved[0]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, r := range records {
+		if got, err := reader.Decrypt(r.keyID, r.ciphertext); err != nil || string(got) != r.plaintext {
+			t.Errorf("Record %q: expected the reader to decrypt it, got %q, %v", r.plaintext, got, err)
+		}
+	}
+}
+
+func TestAESGCMRotationFailure(t *testing.T) {
+	clk := clock.NewFake(time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC))
+	key, _ := NewKey("k0")
+	failure := errors.New("key store unavailable")
+	enc, err := NewAESGCM(key, WithKeyRotation(time.Hour, func(Key) (Key, error) { return Key{}, failure }), WithAESGCMClock(clk))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clk.Advance(time.Hour)
+	if _, _, err := enc.Encrypt([]byte("a")); !errors.Is(err, failure) {
+		t.Errorf("Expected the rotation failure, got %v", err)
+	}
+	if enc.KeyID() != "k0" {
+		t.Errorf("Expected the key to be kept, got %s", enc.KeyID())
+	}
+
+	duplicate, _ := NewKey("k0")
+	enc, _ = NewAESGCM(key, WithKeyRotation(0, func(Key) (Key, error) { return duplicate, nil }))
+	if err := enc.Rotate(); err == nil {
+		t.Error("Expected a rotated key reusing an ID to be rejected")
+	}
+	enc, _ = NewAESGCM(key)
+	if err := enc.Rotate(); err == nil {
+		t.Error("Expected Rotate to fail without a rotation callback")
+	}
+}
+
+func TestNewAESGCMInvalidKeys(t *testing.T) {
+	for _, key := range []Key{
+		{ID: "k0", Secret: []byte("short")},
+		{ID: "", Secret: make([]byte, KeySize)},
+		{ID: "my key", Secret: make([]byte, KeySize)},
+	} {
+		if _, err := NewAESGCM(key); err == nil {
+			t.Errorf("Expected key %q with a %d byte secret to be rejected", key.ID, len(key.Secret))
+		}
+	}
+	key, _ := NewKey("k0")
+	if _, err := NewAESGCM(key, WithDecryptionKeys(key)); err == nil {
+		t.Error("Expected a duplicate key ID to be rejected")
+	}
+}
+
+func TestWithAESGCMClockIgnoresNil(t *testing.T) {
+	key, _ := NewKey("k0")
+	enc, err := NewAESGCM(key, WithAESGCMClock(nil), WithKeyRotation(time.Hour, func(Key) (Key, error) { return NewKey("k1") }))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := enc.Encrypt([]byte("x")); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,193 @@
+// Package recording encrypts recordings of sessions at rest, so captured events, audio
+// and transcripts can be stored where data-at-rest encryption is required.
+//
+// A Writer encrypts every Write as one record with an Encrypter and writes it to the
+// underlying writer, and a Reader decrypts the records back into the original stream.
+// messaging.EventDumper and ws.Tee write one record or frame per Write call, so they can
+// write to a Writer unchanged. AESGCM is a reference Encrypter and Decrypter with key
+// rotation; an Encrypter backed by a KMS or an HSM can be used in its place.
+//
+// Example:
+//
+//	key, _ := recording.NewKey("2025-04")
+//	enc, err := recording.NewAESGCM(key, recording.WithKeyRotation(24*time.Hour, rotateKey))
+//	if err != nil {
+//		return err
+//	}
+//	f, _ := os.Create("events.jsonl.enc")
+//	defer f.Close()
+//	msgClient.SetEventDumper(messaging.NewEventDumper(recording.NewWriter(f, enc)))
+//	// ... later
+//	f, _ = os.Open("events.jsonl.enc")
+//	events := json.NewDecoder(recording.NewReader(f, enc))
+package recording
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+//-----------------------------------------------------------------------------
+// Encryption Interfaces
+//-----------------------------------------------------------------------------
+
+// Encrypter encrypts the records of a recording
+type Encrypter interface {
+	// Encrypt encrypts one record and returns the ID of the key it used, which is stored
+	// in the clear next to the ciphertext so the record can be decrypted after rotation.
+	// Key IDs must be non-empty and must not contain spaces or control characters.
+	Encrypt(plaintext []byte) (keyID string, ciphertext []byte, err error)
+}
+
+// Decrypter decrypts the records of a recording
+type Decrypter interface {
+	// Decrypt decrypts one record that was encrypted with the key keyID
+	Decrypt(keyID string, ciphertext []byte) ([]byte, error)
+}
+
+//-----------------------------------------------------------------------------
+// Writer
+//-----------------------------------------------------------------------------
+
+// Writer encrypts each Write as one record. Records are written as a one-line header
+// followed by the ciphertext and a newline:
+//
+//	<key id> <length>\n<ciphertext>\n
+//
+// where length is the ciphertext size in bytes. Each record is written with a single
+// Write call to the underlying writer, and Writes are serialized, so a Writer is safe
+// for concurrent use.
+type Writer struct {
+	enc Encrypter
+
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewWriter creates a Writer encrypting records with enc and writing them to w
+func NewWriter(w io.Writer, enc Encrypter) *Writer {
+	return &Writer{w: w, enc: enc}
+}
+
+// Write encrypts p as one record and writes it. It returns len(p) once the record has
+// been written, so callers see the size of what they wrote rather than of the ciphertext.
+func (w *Writer) Write(p []byte) (int, error) {
+	keyID, ciphertext, err := w.enc.Encrypt(p)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encrypt record: %w", err)
+	}
+	if !validKeyID(keyID) {
+		return 0, fmt.Errorf("invalid key ID %q", keyID)
+	}
+
+	header := keyID + " " + strconv.Itoa(len(ciphertext)) + "\n"
+	out := make([]byte, 0, len(header)+len(ciphertext)+1)
+	out = append(out, header...)
+	out = append(out, ciphertext...)
+	out = append(out, '\n')
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if _, err := w.w.Write(out); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Close closes the underlying writer if it is an io.Closer
+func (w *Writer) Close() error {
+	if c, ok := w.w.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+//-----------------------------------------------------------------------------
+// Reader
+//-----------------------------------------------------------------------------
+
+// Reader decrypts the records written by a Writer. Next returns one record at a time,
+// and Read returns the plaintext as a continuous stream, so a decrypted recording can
+// be passed to a JSON decoder or to ws.ReadTeeFrame.
+type Reader struct {
+	r   *bufio.Reader
+	dec Decrypter
+
+	// pending is the unread part of the last record returned to Read
+	pending []byte
+}
+
+// NewReader creates a Reader decrypting the records read from r with dec
+func NewReader(r io.Reader, dec Decrypter) *Reader {
+	return &Reader{r: bufio.NewReader(r), dec: dec}
+}
+
+// Next decrypts the next record. It returns io.EOF when the recording ends between
+// records and io.ErrUnexpectedEOF if it ends inside a record.
+func (r *Reader) Next() ([]byte, error) {
+	line, err := r.r.ReadString('\n')
+	if err != nil {
+		if err == io.EOF && line != "" {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+
+	fields := strings.Fields(line)
+	if len(fields) != 2 || !validKeyID(fields[0]) {
+		return nil, fmt.Errorf("invalid record header %q", strings.TrimSpace(line))
+	}
+	size, err := strconv.Atoi(fields[1])
+	if err != nil || size < 0 {
+		return nil, fmt.Errorf("invalid record length %q", fields[1])
+	}
+
+	ciphertext := make([]byte, size+1)
+	if _, err := io.ReadFull(r.r, ciphertext); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
+	}
+	if ciphertext[size] != '\n' {
+		return nil, fmt.Errorf("record of %d bytes is not followed by a newline", size)
+	}
+
+	plaintext, err := r.dec.Decrypt(fields[0], ciphertext[:size])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt record with key %q: %w", fields[0], err)
+	}
+	return plaintext, nil
+}
+
+// Read reads decrypted data, implementing io.Reader
+func (r *Reader) Read(p []byte) (int, error) {
+	for len(r.pending) == 0 {
+		record, err := r.Next()
+		if err != nil {
+			return 0, err
+		}
+		r.pending = record
+	}
+	n := copy(p, r.pending)
+	r.pending = r.pending[n:]
+	return n, nil
+}
+
+// validKeyID reports whether id can be stored in a record header
+func validKeyID(id string) bool {
+	if id == "" {
+		return false
+	}
+	for _, r := range id {
+		if unicode.IsSpace(r) || unicode.IsControl(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,197 @@
+package recording
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/Mliviu79/openai-realtime-go/messaging"
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+// testCipher is an AESGCM with a random key
+func testCipher(t *testing.T, id string) *AESGCM {
+	t.Helper()
+	key, err := NewKey(id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	enc, err := NewAESGCM(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return enc
+}
+
+func TestWriterRoundTrip(t *testing.T) {
+	enc := testCipher(t, "k1")
+	var buf bytes.Buffer
+	w := NewWriter(&buf, enc)
+
+	records := [][]byte{[]byte(`{"type":"session.created"}` + "\n"), {0x00, '\n', 0xff}, {}}
+	for _, record := range records {
+		n, err := w.Write(record)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != len(record) {
+			t.Errorf("Expected Write to report %d bytes, got %d", len(record), n)
+		}
+	}
+	if bytes.Contains(buf.Bytes(), []byte("session.created")) {
+		t.Error("Expected the recording not to contain plaintext")
+	}
+	if !strings.HasPrefix(buf.String(), "k1 ") {
+		t.Errorf("Expected the record header to name the key, got %q", buf.String()[:10])
+	}
+
+	r := NewReader(bytes.NewReader(buf.Bytes()), enc)
+	for i, want := range records {
+		got, err := r.Next()
+		if err != nil {
+			t.Fatalf("Record %d: %v", i, err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("Record %d: expected %q, got %q", i, want, got)
+		}
+	}
+	if _, err := r.Next(); err != io.EOF {
+		t.Errorf("Expected io.EOF after the last record, got %v", err)
+	}
+
+	// Read returns the records as one stream
+	all, err := io.ReadAll(NewReader(bytes.NewReader(buf.Bytes()), enc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := bytes.Join(records, nil); !bytes.Equal(all, want) {
+		t.Errorf("Expected %q, got %q", want, all)
+	}
+}
+
+func TestReaderErrors(t *testing.T) {
+	key, err := NewKey("k1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	enc, err := NewAESGCM(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	if _, err := NewWriter(&buf, enc).Write([]byte("secret")); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+
+	if _, err := NewReader(bytes.NewReader(data[:len(data)-4]), enc).Next(); err != io.ErrUnexpectedEOF {
+		t.Errorf("Expected io.ErrUnexpectedEOF for a truncated record, got %v", err)
+	}
+	if _, err := NewReader(strings.NewReader("k1\n"), enc).Next(); err == nil {
+		t.Error("Expected an invalid header to fail")
+	}
+
+	tampered := bytes.Clone(data)
+	tampered[len(tampered)-2] ^= 0x01
+	if _, err := NewReader(bytes.NewReader(tampered), enc).Next(); err == nil {
+		t.Error("Expected a tampered record to fail to decrypt")
+	}
+
+	// A record cannot be relabeled with another key the reader knows
+	other, _ := NewKey("k2")
+	both, err := NewAESGCM(other, WithDecryptionKeys(key))
+	if err != nil {
+		t.Fatal(err)
+	}
+	relabeled := append([]byte("k2"), data[2:]...)
+	if _, err := NewReader(bytes.NewReader(relabeled), both).Next(); err == nil {
+		t.Error("Expected a record relabeled with another key to fail to decrypt")
+	}
+	if got, err := NewReader(bytes.NewReader(data), both).Next(); err != nil || string(got) != "secret" {
+		t.Errorf("Expected a decryption key to open the record, got %q, %v", got, err)
+	}
+
+	if _, err := NewReader(bytes.NewReader(data), testCipher(t, "k3")).Next(); err == nil {
+		t.Error("Expected a record with an unknown key to fail")
+	}
+}
+
+func TestWriterEncryptError(t *testing.T) {
+	failure := errors.New("kms unavailable")
+	var buf bytes.Buffer
+	w := NewWriter(&buf, encrypterFunc(func([]byte) (string, []byte, error) { return "", nil, failure }))
+	if _, err := w.Write([]byte("x")); !errors.Is(err, failure) {
+		t.Errorf("Expected the encryption error, got %v", err)
+	}
+
+	w = NewWriter(&buf, encrypterFunc(func(p []byte) (string, []byte, error) { return "bad id", p, nil }))
+	if _, err := w.Write([]byte("x")); err == nil {
+		t.Error("Expected a key ID with a space to be rejected")
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Expected nothing to be written, got %q", buf.String())
+	}
+}
+
+// encrypterFunc adapts a function to an Encrypter
+type encrypterFunc func([]byte) (string, []byte, error)
+
+func (f encrypterFunc) Encrypt(p []byte) (string, []byte, error) { return f(p) }
+
+func TestWriterWithRecorders(t *testing.T) {
+	enc := testCipher(t, "k1")
+
+	// Event dumps decrypt back to their JSON lines
+	var dump bytes.Buffer
+	dumper := messaging.NewEventDumper(NewWriter(&dump, enc))
+	dumper.Dump(messaging.DumpDirectionIncoming, []byte(`{"type":"session.created"}`))
+	dumper.Dump(messaging.DumpDirectionIncoming, []byte(`{"type":"response.done"}`))
+	if err := dumper.Err(); err != nil {
+		t.Fatal(err)
+	}
+	lines := bufio.NewScanner(NewReader(&dump, enc))
+	var count int
+	for lines.Scan() {
+		if !strings.Contains(lines.Text(), `"direction":"in"`) {
+			t.Errorf("Unexpected dump record %q", lines.Text())
+		}
+		count++
+	}
+	if count != 2 {
+		t.Errorf("Expected 2 dump records, got %d", count)
+	}
+
+	// Tee frames, including binary audio, decrypt back to frames
+	var tee bytes.Buffer
+	audio := []byte{0x00, '\n', 0xff}
+	conn := ws.TeeConn(nopConn{}, NewWriter(&tee, enc))
+	if err := conn.WriteMessage(context.Background(), ws.MessageBinary, audio); err != nil {
+		t.Fatal(err)
+	}
+	if err := conn.Err(); err != nil {
+		t.Fatal(err)
+	}
+	frame, err := ws.ReadTeeFrame(bufio.NewReader(NewReader(&tee, enc)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if frame.Type != ws.MessageBinary || !bytes.Equal(frame.Data, audio) {
+		t.Errorf("Expected the binary frame, got %v %q", frame.Type, frame.Data)
+	}
+}
+
+// nopConn is a WebSocketConn that discards writes
+type nopConn struct{}
+
+func (nopConn) ReadMessage(ctx context.Context) (ws.MessageType, []byte, error) {
+	return 0, nil, io.EOF
+}
+func (nopConn) WriteMessage(ctx context.Context, messageType ws.MessageType, data []byte) error {
+	return nil
+}
+func (nopConn) Close() error                   { return nil }
+func (nopConn) Ping(ctx context.Context) error { return nil }
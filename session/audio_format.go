@@ -0,0 +1,68 @@
+package session
+
+import "time"
+
+//-----------------------------------------------------------------------------
+// Audio Format Metadata
+//-----------------------------------------------------------------------------
+
+// audioFormatInfo describes the sampling properties of an audio format
+type audioFormatInfo struct {
+	sampleRate     int
+	bytesPerSample int
+}
+
+// audioFormatInfos maps each supported AudioFormat to its sampling properties.
+// All formats are mono.
+var audioFormatInfos = map[AudioFormat]audioFormatInfo{
+	AudioFormatPCM16:    {sampleRate: 24000, bytesPerSample: 2},
+	AudioFormatG711ULaw: {sampleRate: 8000, bytesPerSample: 1},
+	AudioFormatG711ALaw: {sampleRate: 8000, bytesPerSample: 1},
+}
+
+// IsValid returns true if the format is one of the audio formats supported by the API
+func (f AudioFormat) IsValid() bool {
+	_, ok := audioFormatInfos[f]
+	return ok
+}
+
+// SampleRate returns the sample rate of the format in Hz.
+// Returns 0 for unknown formats.
+func (f AudioFormat) SampleRate() int {
+	return audioFormatInfos[f].sampleRate
+}
+
+// BytesPerSample returns the number of bytes used to encode a single sample.
+// Returns 0 for unknown formats.
+func (f AudioFormat) BytesPerSample() int {
+	return audioFormatInfos[f].bytesPerSample
+}
+
+// BytesPerSecond returns the number of bytes needed to encode one second of audio.
+// Returns 0 for unknown formats.
+func (f AudioFormat) BytesPerSecond() int {
+	info := audioFormatInfos[f]
+	return info.sampleRate * info.bytesPerSample
+}
+
+// BytesForDuration returns the number of bytes needed to encode d of audio,
+// rounded down to a whole number of samples.
+// Returns 0 for unknown formats or non-positive durations.
+func (f AudioFormat) BytesForDuration(d time.Duration) int {
+	info := audioFormatInfos[f]
+	if d <= 0 || info.sampleRate == 0 {
+		return 0
+	}
+	samples := int64(info.sampleRate) * int64(d) / int64(time.Second)
+	return int(samples) * info.bytesPerSample
+}
+
+// DurationForBytes returns the playback duration of n bytes of audio in this format.
+// Returns 0 for unknown formats or non-positive byte counts.
+func (f AudioFormat) DurationForBytes(n int) time.Duration {
+	bps := f.BytesPerSecond()
+	if n <= 0 || bps == 0 {
+		return 0
+	}
+	return time.Duration(int64(n) * int64(time.Second) / int64(bps))
+}
@@ -0,0 +1,57 @@
+package session
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAudioFormatMetadata(t *testing.T) {
+	tests := []struct {
+		name           string
+		format         AudioFormat
+		sampleRate     int
+		bytesPerSecond int
+		bytesFor100ms  int
+	}{
+		{name: "PCM16", format: AudioFormatPCM16, sampleRate: 24000, bytesPerSecond: 48000, bytesFor100ms: 4800},
+		{name: "G711ULaw", format: AudioFormatG711ULaw, sampleRate: 8000, bytesPerSecond: 8000, bytesFor100ms: 800},
+		{name: "G711ALaw", format: AudioFormatG711ALaw, sampleRate: 8000, bytesPerSecond: 8000, bytesFor100ms: 800},
+		{name: "Unknown", format: AudioFormat("opus"), sampleRate: 0, bytesPerSecond: 0, bytesFor100ms: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.format.SampleRate(); got != tt.sampleRate {
+				t.Errorf("Expected sample rate %d, got %d", tt.sampleRate, got)
+			}
+			if got := tt.format.BytesPerSecond(); got != tt.bytesPerSecond {
+				t.Errorf("Expected %d bytes per second, got %d", tt.bytesPerSecond, got)
+			}
+			if got := tt.format.BytesForDuration(100 * time.Millisecond); got != tt.bytesFor100ms {
+				t.Errorf("Expected %d bytes for 100ms, got %d", tt.bytesFor100ms, got)
+			}
+			if got := tt.format.IsValid(); got != (tt.sampleRate != 0) {
+				t.Errorf("Expected IsValid to be %v, got %v", tt.sampleRate != 0, got)
+			}
+		})
+	}
+}
+
+func TestAudioFormatDurationForBytes(t *testing.T) {
+	if d := AudioFormatPCM16.DurationForBytes(48000); d != time.Second {
+		t.Errorf("Expected 1s, got %v", d)
+	}
+
+	if d := AudioFormatG711ULaw.DurationForBytes(400); d != 50*time.Millisecond {
+		t.Errorf("Expected 50ms, got %v", d)
+	}
+
+	// Odd byte counts are rounded down to whole samples for PCM16
+	if n := AudioFormatPCM16.BytesForDuration(time.Second / 48000); n != 0 {
+		t.Errorf("Expected 0 bytes for less than one sample, got %d", n)
+	}
+
+	if d := AudioFormat("unknown").DurationForBytes(100); d != 0 {
+		t.Errorf("Expected 0 for unknown format, got %v", d)
+	}
+}
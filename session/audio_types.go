@@ -16,12 +16,42 @@ const (
 
 	// TranscriptionModelGPT4oMiniTranscribe is the GPT-4o mini transcription model
 	TranscriptionModelGPT4oMiniTranscribe TranscriptionModel = "gpt-4o-mini-transcribe"
+
+	// TranscriptionModelGPT4oTranscribeLatest always points to the latest GPT-4o transcription snapshot
+	TranscriptionModelGPT4oTranscribeLatest TranscriptionModel = "gpt-4o-transcribe-latest"
 )
 
+// IsKnown returns true if the model is one of the transcription models defined in this package
+func (m TranscriptionModel) IsKnown() bool {
+	switch m {
+	case TranscriptionModelWhisper1,
+		TranscriptionModelGPT4oTranscribe,
+		TranscriptionModelGPT4oMiniTranscribe,
+		TranscriptionModelGPT4oTranscribeLatest:
+		return true
+	default:
+		return false
+	}
+}
+
+// SupportsLogprobs returns true if the model can return log probabilities for transcriptions.
+// Only the GPT-4o transcription models support logprobs; whisper-1 does not.
+func (m TranscriptionModel) SupportsLogprobs() bool {
+	switch m {
+	case TranscriptionModelGPT4oTranscribe,
+		TranscriptionModelGPT4oMiniTranscribe,
+		TranscriptionModelGPT4oTranscribeLatest:
+		return true
+	default:
+		return false
+	}
+}
+
 // InputAudioTranscription represents configuration for audio transcription
 type InputAudioTranscription struct {
 	// Model specifies which model to use for transcription
-	// Currently supported models are "whisper-1", "gpt-4o-transcribe", and "gpt-4o-mini-transcribe"
+	// Currently supported models are "whisper-1", "gpt-4o-transcribe", "gpt-4o-mini-transcribe"
+	// and "gpt-4o-transcribe-latest"
 	Model TranscriptionModel `json:"model,omitempty"`
 
 	// Language specifies the language of the audio in ISO-639-1 format
@@ -30,3 +60,18 @@ type InputAudioTranscription struct {
 	// Prompt provides optional text to guide the model's style
 	Prompt string `json:"prompt,omitempty"`
 }
+
+// TranscriptionEnabled returns true if input audio transcription is configured, in which case
+// the server sends transcripts of user audio alongside the speech-to-speech conversation
+func (r SessionRequest) TranscriptionEnabled() bool {
+	return r.InputAudioTranscription != nil
+}
+
+// Transcription returns the input audio transcription configuration and whether it is set.
+// The zero value is returned when transcription is disabled.
+func (r SessionRequest) Transcription() (InputAudioTranscription, bool) {
+	if r.InputAudioTranscription == nil {
+		return InputAudioTranscription{}, false
+	}
+	return *r.InputAudioTranscription, true
+}
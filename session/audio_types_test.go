@@ -0,0 +1,61 @@
+package session
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSessionTranscriptionAccessors(t *testing.T) {
+	var s Session
+	data := `{
+		"id": "sess_1",
+		"model": "gpt-realtime",
+		"input_audio_transcription": {"model": "gpt-4o-transcribe", "language": "en", "prompt": "OpenAI, Realtime"}
+	}`
+	if err := json.Unmarshal([]byte(data), &s); err != nil {
+		t.Fatalf("Failed to unmarshal session: %v", err)
+	}
+
+	if !s.TranscriptionEnabled() {
+		t.Fatal("Expected transcription to be enabled")
+	}
+	transcription, ok := s.Transcription()
+	if !ok {
+		t.Fatal("Expected transcription to be set")
+	}
+	expected := InputAudioTranscription{Model: TranscriptionModelGPT4oTranscribe, Language: "en", Prompt: "OpenAI, Realtime"}
+	if transcription != expected {
+		t.Errorf("Expected transcription %+v, got %+v", expected, transcription)
+	}
+
+	var disabled Session
+	if err := json.Unmarshal([]byte(`{"id":"sess_2","input_audio_transcription":null}`), &disabled); err != nil {
+		t.Fatalf("Failed to unmarshal session: %v", err)
+	}
+	if disabled.TranscriptionEnabled() {
+		t.Error("Expected transcription to be disabled")
+	}
+	if transcription, ok := disabled.Transcription(); ok || transcription != (InputAudioTranscription{}) {
+		t.Errorf("Expected no transcription, got %+v", transcription)
+	}
+}
+
+func TestSessionRequestTranscriptionJSON(t *testing.T) {
+	req := NewSessionRequest(
+		WithModel(GPTRealtime),
+		WithInputAudioTranscription(InputAudioTranscription{
+			Model:    TranscriptionModelWhisper1,
+			Language: "fr",
+			Prompt:   "Bonjour",
+		}),
+	)
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("Failed to marshal request: %v", err)
+	}
+	expected := `{"model":"gpt-realtime","input_audio_transcription":{"model":"whisper-1","language":"fr","prompt":"Bonjour"}}`
+	if string(data) != expected {
+		t.Errorf("Expected %s, got %s", expected, data)
+	}
+}
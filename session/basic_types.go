@@ -1,5 +1,11 @@
 package session
 
+import (
+	"fmt"
+
+	"github.com/Mliviu79/openai-realtime-go/apierrs"
+)
+
 //-----------------------------------------------------------------------------
 // Basic Types and Constants
 //-----------------------------------------------------------------------------
@@ -29,10 +35,24 @@ const (
 	ModalityText Modality = "text"
 )
 
-// Model represents available OpenAI models
+// Model identifies a realtime model.
+// Any model string can be used, so new snapshots work without a library update;
+// the constants below are the models known to this package.
 type Model string
 
 const (
+	// GPTRealtime is the generally available realtime model
+	GPTRealtime Model = "gpt-realtime"
+
+	// GPTRealtime20250828 is the August 2025 snapshot of the generally available realtime model
+	GPTRealtime20250828 Model = "gpt-realtime-2025-08-28"
+
+	// GPTRealtimeMini is the generally available mini realtime model
+	GPTRealtimeMini Model = "gpt-realtime-mini"
+
+	// GPTRealtimeMini20251006 is the October 2025 snapshot of the mini realtime model
+	GPTRealtimeMini20251006 Model = "gpt-realtime-mini-2025-10-06"
+
 	// GPT4oRealtimePreview is the base GPT-4o realtime preview model
 	GPT4oRealtimePreview Model = "gpt-4o-realtime-preview"
 
@@ -42,6 +62,9 @@ const (
 	// GPT4oRealtimePreview20241217 is the December 2024 version of GPT-4o realtime
 	GPT4oRealtimePreview20241217 Model = "gpt-4o-realtime-preview-2024-12-17"
 
+	// GPT4oRealtimePreview20250603 is the June 2025 version of GPT-4o realtime
+	GPT4oRealtimePreview20250603 Model = "gpt-4o-realtime-preview-2025-06-03"
+
 	// GPT4oMiniRealtimePreview is the base GPT-4o mini realtime preview model
 	GPT4oMiniRealtimePreview Model = "gpt-4o-mini-realtime-preview"
 
@@ -49,6 +72,35 @@ const (
 	GPT4oMiniRealtimePreview20241217 Model = "gpt-4o-mini-realtime-preview-2024-12-17"
 )
 
+// IsKnown returns true if the model is one of the models defined in this package
+func (m Model) IsKnown() bool {
+	switch m {
+	case GPTRealtime,
+		GPTRealtime20250828,
+		GPTRealtimeMini,
+		GPTRealtimeMini20251006,
+		GPT4oRealtimePreview,
+		GPT4oRealtimePreview20241001,
+		GPT4oRealtimePreview20241217,
+		GPT4oRealtimePreview20250603,
+		GPT4oMiniRealtimePreview,
+		GPT4oMiniRealtimePreview20241217:
+		return true
+	default:
+		return false
+	}
+}
+
+// ValidateKnown returns an *apierrs.APIError if the model is not known to this package.
+// It is used by strict mode to catch typos in model names before connecting; without
+// strict mode any model string is accepted.
+func (m Model) ValidateKnown() error {
+	if !m.IsKnown() {
+		return apierrs.NewInvalidField("model", fmt.Sprintf("unknown model %q", m))
+	}
+	return nil
+}
+
 type Intent string
 
 const (
@@ -1,5 +1,7 @@
 package session
 
+import "slices"
+
 // ConfigOption is a function that configures a SessionRequest
 type ConfigOption func(*SessionRequest)
 
@@ -12,8 +14,20 @@ func NewSessionRequest(opts ...ConfigOption) *SessionRequest {
 	return req
 }
 
+// BuildSessionRequest creates a new session request with the given options and validates it.
+// It returns the validation errors of SessionRequest.Validate, so misconfigured options are
+// reported before the request is sent.
+func BuildSessionRequest(opts ...ConfigOption) (*SessionRequest, error) {
+	req := NewSessionRequest(opts...)
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
 // WithModalities sets the modalities for the session
 func WithModalities(modalities []Modality) ConfigOption {
+	modalities = slices.Clone(modalities)
 	return func(c *SessionRequest) {
 		c.Modalities = &modalities
 	}
@@ -117,3 +131,10 @@ func WithInputAudioNoiseReduction(noiseReduction InputAudioNoiseReduction) Confi
 		c.InputAudioNoiseReduction = &noiseReduction
 	}
 }
+
+// WithPrompt sets the reusable prompt for the session
+func WithPrompt(prompt Prompt) ConfigOption {
+	return func(c *SessionRequest) {
+		c.Prompt = &prompt
+	}
+}
@@ -0,0 +1,105 @@
+package session
+
+import (
+	"reflect"
+	"slices"
+)
+
+//-----------------------------------------------------------------------------
+// Session Request Diffing
+//-----------------------------------------------------------------------------
+
+// Diff returns a SessionRequest containing only the fields of desired that differ from current.
+// Fields that are nil in desired are treated as "leave unchanged" and are never included.
+// The result can be sent as a session.update to move the server from current to desired
+// without re-sending the full configuration and clobbering server-side defaults.
+//
+// Example:
+//
+//	update := session.Diff(current, desired)
+//	if !update.IsEmpty() {
+//		err := msgClient.SendSessionUpdate(ctx, update)
+//	}
+func Diff(current, desired SessionRequest) SessionRequest {
+	return SessionRequest{
+		Modalities:               diffField(current.Modalities, desired.Modalities),
+		Model:                    diffField(current.Model, desired.Model),
+		ResponseParams:           current.ResponseParams.Diff(desired.ResponseParams),
+		InputAudioFormat:         diffField(current.InputAudioFormat, desired.InputAudioFormat),
+		InputAudioTranscription:  diffField(current.InputAudioTranscription, desired.InputAudioTranscription),
+		TurnDetection:            diffField(current.TurnDetection, desired.TurnDetection),
+		InputAudioNoiseReduction: diffField(current.InputAudioNoiseReduction, desired.InputAudioNoiseReduction),
+		Tools:                    diffField(current.Tools, desired.Tools),
+		MaxResponseOutputTokens:  diffField(current.MaxResponseOutputTokens, desired.MaxResponseOutputTokens),
+		Prompt:                   diffField(current.Prompt, desired.Prompt),
+	}
+}
+
+// IsEmpty returns true if no field of the request is set
+func (r SessionRequest) IsEmpty() bool {
+	return reflect.DeepEqual(r, SessionRequest{})
+}
+
+// Clone returns a deep copy of the request.
+// Modifying the copy, including values behind its pointers, does not affect the original.
+func (r SessionRequest) Clone() SessionRequest {
+	out := SessionRequest{
+		Model:                    clonePtr(r.Model),
+		ResponseParams:           r.ResponseParams.Clone(),
+		InputAudioFormat:         clonePtr(r.InputAudioFormat),
+		InputAudioTranscription:  clonePtr(r.InputAudioTranscription),
+		InputAudioNoiseReduction: clonePtr(r.InputAudioNoiseReduction),
+		MaxResponseOutputTokens:  clonePtr(r.MaxResponseOutputTokens),
+		Prompt:                   r.Prompt.clone(),
+	}
+
+	if r.Modalities != nil {
+		modalities := slices.Clone(*r.Modalities)
+		out.Modalities = &modalities
+	}
+
+	if r.TurnDetection != nil {
+		td := *r.TurnDetection
+		td.CreateResponse = clonePtr(td.CreateResponse)
+		td.InterruptResponse = clonePtr(td.InterruptResponse)
+		out.TurnDetection = &td
+	}
+
+	if r.Tools != nil {
+		tools := make([]Tool, len(*r.Tools))
+		for i, tool := range *r.Tools {
+			tool.Parameters = slices.Clone(tool.Parameters)
+			tools[i] = tool
+		}
+		out.Tools = &tools
+	}
+
+	return out
+}
+
+// Clone returns a deep copy of the session.
+// Modifying the copy, including values behind its pointers, does not affect the original.
+func (s Session) Clone() Session {
+	s.SessionRequest = s.SessionRequest.Clone()
+	return s
+}
+
+// diffField returns desired if it is set and differs from current, or nil otherwise
+func diffField[T any](current, desired *T) *T {
+	if desired == nil {
+		return nil
+	}
+	if current != nil && reflect.DeepEqual(*current, *desired) {
+		return nil
+	}
+	return desired
+}
+
+// clonePtr returns a pointer to a shallow copy of the value behind p, or nil if p is nil
+func clonePtr[T any](p *T) *T {
+	if p == nil {
+		return nil
+	}
+	v := *p
+	return &v
+}
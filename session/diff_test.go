@@ -0,0 +1,88 @@
+package session
+
+import (
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	alloy := VoiceAlloy
+	coral := VoiceCoral
+	instructions := "Be helpful"
+	temp := 0.8
+	otherTemp := 0.6
+
+	current := SessionRequest{
+		ResponseParams: ResponseParams{
+			Voice:        &alloy,
+			Instructions: &instructions,
+			Temperature:  &temp,
+		},
+		TurnDetection: &TurnDetection{
+			Type: TurnDetectionTypeServerVad,
+		},
+	}
+
+	desired := current.Clone()
+	desired.Voice = &coral
+	desired.Temperature = &otherTemp
+	desired.Instructions = nil
+
+	update := Diff(current, desired)
+
+	if update.Voice == nil || *update.Voice != coral {
+		t.Errorf("Expected voice %s, got %v", coral, update.Voice)
+	}
+	if update.Temperature == nil || *update.Temperature != otherTemp {
+		t.Errorf("Expected temperature %v, got %v", otherTemp, update.Temperature)
+	}
+	if update.Instructions != nil {
+		t.Errorf("Expected instructions to be omitted, got %v", *update.Instructions)
+	}
+	if update.TurnDetection != nil {
+		t.Errorf("Expected unchanged turn detection to be omitted, got %+v", update.TurnDetection)
+	}
+}
+
+func TestDiffNoChanges(t *testing.T) {
+	alloy := VoiceAlloy
+	modalities := []Modality{ModalityText, ModalityAudio}
+	current := SessionRequest{ResponseParams: ResponseParams{Voice: &alloy}, Modalities: &modalities}
+
+	update := Diff(current, current.Clone())
+	if !update.IsEmpty() {
+		t.Errorf("Expected empty diff, got %+v", update)
+	}
+}
+
+func TestDiffFromEmpty(t *testing.T) {
+	alloy := VoiceAlloy
+	desired := SessionRequest{ResponseParams: ResponseParams{Voice: &alloy}}
+
+	update := Diff(SessionRequest{}, desired)
+	if update.Voice == nil || *update.Voice != alloy {
+		t.Errorf("Expected voice %s, got %v", alloy, update.Voice)
+	}
+}
+
+func TestCloneIsDeep(t *testing.T) {
+	createResponse := true
+	modalities := []Modality{ModalityText}
+	original := SessionRequest{
+		Modalities: &modalities,
+		TurnDetection: &TurnDetection{
+			Type:           TurnDetectionTypeServerVad,
+			CreateResponse: &createResponse,
+		},
+	}
+
+	clone := original.Clone()
+	(*clone.Modalities)[0] = ModalityAudio
+	*clone.TurnDetection.CreateResponse = false
+
+	if (*original.Modalities)[0] != ModalityText {
+		t.Errorf("Expected original modalities to be unchanged, got %v", *original.Modalities)
+	}
+	if !*original.TurnDetection.CreateResponse {
+		t.Error("Expected original create_response to be unchanged")
+	}
+}
@@ -0,0 +1,84 @@
+package session
+
+import (
+	"fmt"
+
+	"golang.org/x/text/language"
+
+	"github.com/Mliviu79/openai-realtime-go/apierrs"
+)
+
+//-----------------------------------------------------------------------------
+// Locale
+//-----------------------------------------------------------------------------
+
+// Locale is the user's language as a canonical BCP-47 tag, such as "en-US" or "pt-BR".
+// It is the one place to declare the language: WithLocale and Apply propagate it to the
+// transcription configuration, the instructions package turns it into template
+// variables, and Tag exposes it to golang.org/x/text for formatting numbers and dates.
+// Display names are left to the instructions package, so the session package does not
+// pull in the golang.org/x/text display tables.
+type Locale string
+
+// ParseLocale parses and canonicalizes a BCP-47 tag, so "EN_us" becomes "en-US".
+// It returns an *apierrs.APIError if the tag is not well-formed or has no language.
+func ParseLocale(s string) (Locale, error) {
+	tag, err := language.Parse(s)
+	if err != nil {
+		return "", apierrs.NewInvalidField("locale", fmt.Sprintf("locale must be a BCP-47 tag such as \"en-US\", got %q", s))
+	}
+	if _, ok := Locale(tag.String()).Base(); !ok {
+		return "", apierrs.NewInvalidField("locale", fmt.Sprintf("locale must name a language, got %q", s))
+	}
+	return Locale(tag.String()), nil
+}
+
+// Tag returns the locale as a language.Tag, for golang.org/x/text formatting packages.
+// An invalid locale returns language.Und.
+func (l Locale) Tag() language.Tag {
+	tag, err := language.Parse(string(l))
+	if err != nil {
+		return language.Und
+	}
+	return tag
+}
+
+// TranscriptionLanguage returns the ISO-639-1 code of the locale's language, as expected
+// by InputAudioTranscription.Language, or an empty string if the language has no
+// two-letter code, in which case the transcription model detects the language itself
+func (l Locale) TranscriptionLanguage() string {
+	base, ok := l.Base()
+	if code := base.String(); ok && isISO6391(code) {
+		return code
+	}
+	return ""
+}
+
+// Base returns the language named by the locale; the second return value is false for
+// an empty or invalid locale or one without a language, whose language would otherwise
+// be guessed from the region
+func (l Locale) Base() (language.Base, bool) {
+	base, _, _ := l.Tag().Raw()
+	return base, base.String() != "und"
+}
+
+// Apply sets the transcription language to the locale's language. The language is
+// cleared if it has no ISO-639-1 code, so the model detects it instead of rejecting it.
+func (l Locale) Apply(t *InputAudioTranscription) {
+	if t != nil {
+		t.Language = l.TranscriptionLanguage()
+	}
+}
+
+// WithLocale sets the input audio transcription language from the locale. It only
+// changes a transcription configuration that is already set, so it must come after
+// WithInputAudioTranscription; it does not turn transcription on.
+func WithLocale(l Locale) ConfigOption {
+	return func(c *SessionRequest) {
+		if c.InputAudioTranscription != nil {
+			transcription := *c.InputAudioTranscription
+			l.Apply(&transcription)
+			c.InputAudioTranscription = &transcription
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package session
+
+import (
+	"testing"
+)
+
+func TestParseLocale(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Locale
+		wantErr  bool
+	}{
+		{input: "en-US", expected: "en-US"},
+		{input: "EN_us", expected: "en-US"},
+		{input: "pt-BR", expected: "pt-BR"},
+		{input: "not a tag!", wantErr: true},
+		{input: "und", wantErr: true},
+		{input: "und-US", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseLocale(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected an error, got %q", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestLocaleLanguage(t *testing.T) {
+	tests := []struct {
+		locale Locale
+		code   string
+	}{
+		{locale: "pt-BR", code: "pt"},
+		{locale: "zh-Hant-TW", code: "zh"},
+		{locale: "fil", code: ""},
+		{locale: "", code: ""},
+	}
+
+	for _, tt := range tests {
+		if got := tt.locale.TranscriptionLanguage(); got != tt.code {
+			t.Errorf("%q: expected transcription language %q, got %q", tt.locale, tt.code, got)
+		}
+	}
+}
+
+func TestWithLocale(t *testing.T) {
+	transcription := InputAudioTranscription{Model: TranscriptionModelGPT4oTranscribe}
+	req := NewSessionRequest(WithInputAudioTranscription(transcription), WithLocale("fr-CA"))
+	if req.InputAudioTranscription.Language != "fr" {
+		t.Errorf("Expected transcription language fr, got %q", req.InputAudioTranscription.Language)
+	}
+	if err := req.Validate(); err != nil {
+		t.Errorf("Expected the request to be valid, got %v", err)
+	}
+
+	if req := NewSessionRequest(WithLocale("fr-CA")); req.TranscriptionEnabled() {
+		t.Error("Expected WithLocale not to turn transcription on")
+	}
+}
@@ -0,0 +1,54 @@
+package session
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/Mliviu79/openai-realtime-go/apierrs"
+)
+
+//-----------------------------------------------------------------------------
+// Modality Sets
+//-----------------------------------------------------------------------------
+
+// The canonical modality sets accepted by sessions and responses. They are shared, so
+// callers must not modify them; WithModalities stores a copy.
+var (
+	// TextOnly produces text output only
+	TextOnly = []Modality{ModalityText}
+
+	// AudioOnly produces audio output, with its transcript
+	AudioOnly = []Modality{ModalityAudio}
+
+	// TextAndAudio produces both text and audio output
+	TextAndAudio = []Modality{ModalityText, ModalityAudio}
+)
+
+// HasModality reports whether modalities include m
+func HasModality(modalities []Modality, m Modality) bool {
+	return slices.Contains(modalities, m)
+}
+
+// ValidateModalities checks that modalities are one of the sets TextOnly, AudioOnly or
+// TextAndAudio, in any order. A nil slice means the modalities are not set and is valid.
+// The field name is used to identify the modalities in the returned *apierrs.APIError.
+func ValidateModalities(field string, modalities []Modality) error {
+	if modalities == nil {
+		return nil
+	}
+	if len(modalities) == 0 {
+		return apierrs.NewInvalidField(field, "at least one modality is required")
+	}
+
+	seen := make(map[Modality]bool, len(modalities))
+	for _, m := range modalities {
+		if m != ModalityText && m != ModalityAudio {
+			return apierrs.NewInvalidField(field, fmt.Sprintf("unknown modality %q, expected %q or %q", m, ModalityText, ModalityAudio))
+		}
+		if seen[m] {
+			return apierrs.NewInvalidField(field, fmt.Sprintf("modality %q is listed more than once", m))
+		}
+		seen[m] = true
+	}
+	return nil
+}
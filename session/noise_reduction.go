@@ -1,5 +1,11 @@
 package session
 
+import (
+	"fmt"
+
+	"github.com/Mliviu79/openai-realtime-go/apierrs"
+)
+
 //-----------------------------------------------------------------------------
 // Noise Reduction Types
 //-----------------------------------------------------------------------------
@@ -22,3 +28,34 @@ type InputAudioNoiseReduction struct {
 	// FarField is for far-field microphones such as laptop or conference room microphones
 	Type NoiseReductionType `json:"type,omitempty"`
 }
+
+// InputDevice describes the microphone the user speaks into, as declared by the application
+type InputDevice string
+
+const (
+	// InputDeviceHeadset is a headset, earbuds or another microphone close to the mouth
+	InputDeviceHeadset InputDevice = "headset"
+
+	// InputDeviceSpeakerphone is a speakerphone or conference room microphone
+	InputDeviceSpeakerphone InputDevice = "speakerphone"
+
+	// InputDeviceTelephony is a phone call, where the caller normally holds a handset
+	InputDeviceTelephony InputDevice = "telephony"
+
+	// InputDeviceLaptop is the built-in microphone of a laptop or tablet
+	InputDeviceLaptop InputDevice = "laptop"
+)
+
+// NoiseReductionFor returns the noise reduction suited to an input device: near field
+// for microphones close to the mouth and far field for microphones across the room.
+// It returns an *apierrs.APIError for an unknown device.
+func NoiseReductionFor(device InputDevice) (InputAudioNoiseReduction, error) {
+	switch device {
+	case InputDeviceHeadset, InputDeviceTelephony:
+		return InputAudioNoiseReduction{Type: NoiseReductionTypeNearField}, nil
+	case InputDeviceSpeakerphone, InputDeviceLaptop:
+		return InputAudioNoiseReduction{Type: NoiseReductionTypeFarField}, nil
+	default:
+		return InputAudioNoiseReduction{}, apierrs.NewInvalidField("input_device", fmt.Sprintf("unknown input device %q", device))
+	}
+}
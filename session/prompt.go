@@ -0,0 +1,47 @@
+package session
+
+import (
+	"maps"
+
+	"github.com/Mliviu79/openai-realtime-go/apierrs"
+)
+
+//-----------------------------------------------------------------------------
+// Reusable Prompts
+//-----------------------------------------------------------------------------
+
+// Prompt references a reusable prompt managed in the OpenAI dashboard
+type Prompt struct {
+	// ID is the unique identifier of the prompt template
+	ID string `json:"id"`
+
+	// Version is an optional version of the prompt; the current version is used if empty
+	Version string `json:"version,omitempty"`
+
+	// Variables maps prompt variable names to values substituted into the template.
+	// Values are usually strings, but may also be input content objects such as input_text.
+	Variables map[string]any `json:"variables,omitempty"`
+}
+
+// Validate checks that the prompt identifies a template.
+// It returns an *apierrs.APIError identifying the offending field, or nil if the
+// prompt is valid or nil.
+func (p *Prompt) Validate() error {
+	if p == nil {
+		return nil
+	}
+	if p.ID == "" {
+		return apierrs.NewInvalidField("prompt.id", "prompt id is required")
+	}
+	return nil
+}
+
+// clone returns a copy of the prompt with its own variables map
+func (p *Prompt) clone() *Prompt {
+	if p == nil {
+		return nil
+	}
+	out := *p
+	out.Variables = maps.Clone(p.Variables)
+	return &out
+}
@@ -0,0 +1,73 @@
+package session
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPromptJSON(t *testing.T) {
+	req := NewSessionRequest(WithPrompt(Prompt{
+		ID:        "pmpt_123",
+		Version:   "2",
+		Variables: map[string]any{"city": "Paris"},
+	}))
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := `{"prompt":{"id":"pmpt_123","version":"2","variables":{"city":"Paris"}}}`
+	if string(data) != expected {
+		t.Errorf("Expected %s, got %s", expected, string(data))
+	}
+
+	var decoded Session
+	if err := json.Unmarshal([]byte(`{"id":"sess_1","prompt":{"id":"pmpt_123","variables":{"city":"Paris"}}}`), &decoded); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if decoded.Prompt == nil || decoded.Prompt.ID != "pmpt_123" {
+		t.Fatalf("Expected prompt pmpt_123, got %+v", decoded.Prompt)
+	}
+	if decoded.Prompt.Variables["city"] != "Paris" {
+		t.Errorf("Expected variable city to be Paris, got %v", decoded.Prompt.Variables["city"])
+	}
+}
+
+func TestPromptValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		prompt  *Prompt
+		wantErr bool
+	}{
+		{name: "nil", prompt: nil},
+		{name: "with id", prompt: &Prompt{ID: "pmpt_123"}},
+		{name: "missing id", prompt: &Prompt{Version: "1"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.prompt.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestPromptCloneAndDiff(t *testing.T) {
+	original := SessionRequest{Prompt: &Prompt{ID: "pmpt_123", Variables: map[string]any{"city": "Paris"}}}
+
+	clone := original.Clone()
+	clone.Prompt.Variables["city"] = "Rome"
+	if original.Prompt.Variables["city"] != "Paris" {
+		t.Errorf("Expected original variables to be unchanged, got %v", original.Prompt.Variables)
+	}
+
+	if update := Diff(original, original.Clone()); !update.IsEmpty() {
+		t.Errorf("Expected no changes, got %+v", update)
+	}
+	update := Diff(original, clone)
+	if update.Prompt == nil || update.Prompt.Variables["city"] != "Rome" {
+		t.Errorf("Expected prompt change, got %+v", update.Prompt)
+	}
+}
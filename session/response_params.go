@@ -0,0 +1,105 @@
+package session
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/Mliviu79/openai-realtime-go/apierrs"
+)
+
+//-----------------------------------------------------------------------------
+// Response Parameters
+//-----------------------------------------------------------------------------
+
+// ResponseParams are the response parameters that a session and a single response share.
+// On a SessionRequest they are the defaults for every response; on a response.create
+// (types.ResponseConfig) they override the session for that response only. Both embed
+// this struct, so a parameter added here is sent, cloned and validated the same way in
+// both places.
+//
+// Modalities, Tools and the output token limit are not shared: the session update
+// distinguishes unset from empty for the first two, and the token limit has a different
+// wire name in each request.
+type ResponseParams struct {
+	// Instructions provide system instructions to guide the model
+	Instructions *string `json:"instructions,omitempty"`
+
+	// Voice specifies which voice to use for audio responses
+	// Options: VoiceAlloy, VoiceAsh, VoiceBallad, VoiceCoral, VoiceEcho, VoiceSage, VoiceShimmer, VoiceVerse
+	Voice *Voice `json:"voice,omitempty"`
+
+	// OutputAudioFormat specifies the format for audio output
+	// Options: AudioFormatPCM16, AudioFormatG711ULaw, AudioFormatG711ALaw
+	OutputAudioFormat *AudioFormat `json:"output_audio_format,omitempty"`
+
+	// ToolChoice controls how the model selects tools
+	// Options: ToolChoiceAuto, ToolChoiceNone, ToolChoiceRequired
+	ToolChoice *ToolChoiceObj `json:"tool_choice,omitempty"`
+
+	// Temperature controls the randomness of the model's output
+	// Range: [0.6, 1.2], default 0.8
+	Temperature *float64 `json:"temperature,omitempty"`
+}
+
+// Clone returns a deep copy of the parameters
+func (p ResponseParams) Clone() ResponseParams {
+	out := ResponseParams{
+		Instructions:      clonePtr(p.Instructions),
+		Voice:             clonePtr(p.Voice),
+		OutputAudioFormat: clonePtr(p.OutputAudioFormat),
+		Temperature:       clonePtr(p.Temperature),
+	}
+	if p.ToolChoice != nil {
+		tc := *p.ToolChoice
+		tc.Function = clonePtr(tc.Function)
+		out.ToolChoice = &tc
+	}
+	return out
+}
+
+// Diff returns the parameters of desired that differ from current, like the package
+// level Diff does for a whole SessionRequest
+func (p ResponseParams) Diff(desired ResponseParams) ResponseParams {
+	return ResponseParams{
+		Instructions:      diffField(p.Instructions, desired.Instructions),
+		Voice:             diffField(p.Voice, desired.Voice),
+		OutputAudioFormat: diffField(p.OutputAudioFormat, desired.OutputAudioFormat),
+		ToolChoice:        diffField(p.ToolChoice, desired.ToolChoice),
+		Temperature:       diffField(p.Temperature, desired.Temperature),
+	}
+}
+
+// MaxInstructionsLength is the longest instructions string, in characters, that
+// validation accepts. Longer instructions crowd the conversation out of the model's
+// context and are usually the result of a runaway template.
+const MaxInstructionsLength = 32768
+
+// ValidateInstructions checks that instructions are within MaxInstructionsLength.
+// The field name is used to identify the instructions in the returned *apierrs.APIError.
+func ValidateInstructions(field, instructions string) error {
+	if n := utf8.RuneCountInString(instructions); n > MaxInstructionsLength {
+		return apierrs.NewInvalidField(
+			field,
+			fmt.Sprintf("instructions are limited to %d characters, got %d", MaxInstructionsLength, n),
+		)
+	}
+	return nil
+}
+
+// Validate checks the parameters against a model's limits and the instructions against
+// MaxInstructionsLength. It returns an *apierrs.APIError identifying the offending
+// field, or nil if the parameters are valid.
+func (p ResponseParams) Validate(limits ParameterLimits) error {
+	if p.Instructions != nil {
+		if err := ValidateInstructions("instructions", *p.Instructions); err != nil {
+			return err
+		}
+	}
+	if p.Temperature != nil && (*p.Temperature < limits.MinTemperature || *p.Temperature > limits.MaxTemperature) {
+		return apierrs.NewInvalidField(
+			"temperature",
+			fmt.Sprintf("temperature must be between %g and %g, got %g", limits.MinTemperature, limits.MaxTemperature, *p.Temperature),
+		)
+	}
+	return nil
+}
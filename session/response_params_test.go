@@ -0,0 +1,63 @@
+package session
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestResponseParamsMarshalFlat(t *testing.T) {
+	instructions := "Be brief"
+	voice := VoiceCoral
+	temperature := 0.7
+	req := SessionRequest{ResponseParams: ResponseParams{
+		Instructions: &instructions,
+		Voice:        &voice,
+		Temperature:  &temperature,
+		ToolChoice:   &ToolChoiceObj{Type: ToolChoiceAuto},
+	}}
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("Failed to marshal: %v", err)
+	}
+	expected := `{"instructions":"Be brief","voice":"coral","tool_choice":"auto","temperature":0.7}`
+	if string(data) != expected {
+		t.Errorf("Expected %s, got %s", expected, data)
+	}
+
+	var decoded SessionRequest
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to unmarshal: %v", err)
+	}
+	if decoded.Instructions == nil || *decoded.Instructions != instructions || decoded.Voice == nil || *decoded.Voice != voice {
+		t.Errorf("Expected the shared parameters to round-trip, got %+v", decoded.ResponseParams)
+	}
+}
+
+func TestResponseParamsClone(t *testing.T) {
+	temperature := 0.7
+	params := ResponseParams{Temperature: &temperature, ToolChoice: &ToolChoiceObj{Type: ToolChoiceAuto}}
+
+	clone := params.Clone()
+	*clone.Temperature = 1.0
+	clone.ToolChoice.Type = ToolChoiceNone
+	if *params.Temperature != 0.7 || params.ToolChoice.Type != ToolChoiceAuto {
+		t.Errorf("Expected the clone not to share values with the original, got %+v", params)
+	}
+}
+
+func TestResponseParamsValidateInstructionsLength(t *testing.T) {
+	limits, _ := Model("").Limits()
+
+	instructions := strings.Repeat("é", MaxInstructionsLength)
+	if err := (ResponseParams{Instructions: &instructions}).Validate(limits); err != nil {
+		t.Errorf("Expected instructions at the limit to be accepted, got %v", err)
+	}
+
+	instructions += "!"
+	err := (ResponseParams{Instructions: &instructions}).Validate(limits)
+	if err == nil || !strings.Contains(err.Error(), "instructions are limited") {
+		t.Errorf("Expected overlong instructions to be rejected, got %v", err)
+	}
+}
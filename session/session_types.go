@@ -27,6 +27,8 @@ type Session struct {
 	// Server-assigned fields
 	ID     string `json:"id,omitempty"`
 	Object string `json:"object,omitempty"` // Always "realtime.session" when present
+	// ExpiresAt is the Unix timestamp (in seconds) at which the session expires, when reported
+	ExpiresAt int64 `json:"expires_at,omitempty"`
 	ClientSecretInfo
 
 	SessionRequest
@@ -48,18 +50,12 @@ type SessionRequest struct {
 	// Model specifies which model to use for the session
 	Model *Model `json:"model,omitempty"`
 
-	// Instructions provide system instructions to guide the model
-	Instructions *string `json:"instructions,omitempty"`
-
-	// Voice specifies which voice to use for audio responses
-	Voice *Voice `json:"voice,omitempty"`
+	// ResponseParams are the defaults for every response of the session
+	ResponseParams
 
 	// InputAudioFormat specifies the format for audio input
 	InputAudioFormat *AudioFormat `json:"input_audio_format,omitempty"`
 
-	// OutputAudioFormat specifies the format for audio output
-	OutputAudioFormat *AudioFormat `json:"output_audio_format,omitempty"`
-
 	// InputAudioTranscription configures audio transcription settings
 	InputAudioTranscription *InputAudioTranscription `json:"input_audio_transcription,omitempty"`
 
@@ -72,12 +68,9 @@ type SessionRequest struct {
 	// Tools specifies the available functions the model can call
 	Tools *[]Tool `json:"tools,omitempty"`
 
-	// ToolChoice controls how the model selects tools
-	ToolChoice *ToolChoiceObj `json:"tool_choice,omitempty"`
-
-	// Temperature controls the randomness of the model's output
-	Temperature *float64 `json:"temperature,omitempty"`
-
 	// MaxResponseOutputTokens limits the length of responses
 	MaxResponseOutputTokens *IntOrInf `json:"max_response_output_tokens,omitempty"`
+
+	// Prompt references a reusable prompt managed in the OpenAI dashboard
+	Prompt *Prompt `json:"prompt,omitempty"`
 }
@@ -1,5 +1,7 @@
 package session
 
+import "encoding/json"
+
 //-----------------------------------------------------------------------------
 // Turn Detection Types
 //-----------------------------------------------------------------------------
@@ -15,6 +17,11 @@ const (
 	// TurnDetectionTypeSemanticVad uses semantic turn detection with VAD.
 	// This mode uses a turn detection model to semantically estimate whether the user has finished speaking.
 	TurnDetectionTypeSemanticVad TurnDetectionType = "semantic_vad"
+
+	// TurnDetectionTypeNone turns turn detection off, so the client commits the input
+	// audio buffer and requests responses itself, e.g. for push-to-talk. A TurnDetection
+	// of this type is sent as null.
+	TurnDetectionTypeNone TurnDetectionType = "none"
 )
 
 // EagernessLevel represents the eagerness of the model to respond in semantic VAD mode
@@ -67,3 +74,13 @@ type TurnDetection struct {
 	// when a VAD start event occurs. Defaults to true
 	InterruptResponse *bool `json:"interrupt_response,omitempty"`
 }
+
+// MarshalJSON encodes turn detection of type TurnDetectionTypeNone as null, which is how
+// the API turns it off
+func (t TurnDetection) MarshalJSON() ([]byte, error) {
+	if t.Type == TurnDetectionTypeNone {
+		return []byte("null"), nil
+	}
+	type plain TurnDetection
+	return json.Marshal(plain(t))
+}
@@ -0,0 +1,84 @@
+package session
+
+//-----------------------------------------------------------------------------
+// VAD Presets
+//-----------------------------------------------------------------------------
+
+// VADPreset bundles turn detection and noise reduction settings for a common acoustic
+// environment, as a starting point that avoids tuning VAD by trial and error
+type VADPreset struct {
+	// Name identifies the preset in logs
+	Name string
+
+	// TurnDetection is the turn detection configuration of the preset
+	TurnDetection TurnDetection
+
+	// NoiseReduction is the input audio noise reduction of the preset
+	NoiseReduction InputAudioNoiseReduction
+}
+
+var (
+	// VADPresetPhoneCall suits callers on a handset. Semantic VAD with low eagerness
+	// waits out the pauses people make on the phone instead of cutting them off.
+	VADPresetPhoneCall = VADPreset{
+		Name: "phone_call",
+		TurnDetection: TurnDetection{
+			Type:      TurnDetectionTypeSemanticVad,
+			Eagerness: EagernessLevelLow,
+		},
+		NoiseReduction: InputAudioNoiseReduction{Type: NoiseReductionTypeNearField},
+	}
+
+	// VADPresetPushToTalk turns turn detection off, so the application commits the input
+	// audio buffer and requests a response when the user releases the button
+	VADPresetPushToTalk = VADPreset{
+		Name:           "push_to_talk",
+		TurnDetection:  TurnDetection{Type: TurnDetectionTypeNone},
+		NoiseReduction: InputAudioNoiseReduction{Type: NoiseReductionTypeNearField},
+	}
+
+	// VADPresetKiosk suits a far-field microphone in a noisy public space. A higher
+	// threshold ignores background chatter, and a longer silence lets users finish
+	// speaking to an unfamiliar device.
+	VADPresetKiosk = VADPreset{
+		Name: "kiosk",
+		TurnDetection: TurnDetection{
+			Type:              TurnDetectionTypeServerVad,
+			Threshold:         0.7,
+			PrefixPaddingMs:   300,
+			SilenceDurationMs: 800,
+		},
+		NoiseReduction: InputAudioNoiseReduction{Type: NoiseReductionTypeFarField},
+	}
+
+	// VADPresetHeadset suits a headset or earbuds in a quiet room, where semantic VAD
+	// can respond promptly
+	VADPresetHeadset = VADPreset{
+		Name: "headset",
+		TurnDetection: TurnDetection{
+			Type:      TurnDetectionTypeSemanticVad,
+			Eagerness: EagernessLevelAuto,
+		},
+		NoiseReduction: InputAudioNoiseReduction{Type: NoiseReductionTypeNearField},
+	}
+)
+
+// WithVADPreset sets the turn detection and input audio noise reduction of the session
+// from a preset. Options after it can override single settings.
+func WithVADPreset(preset VADPreset) ConfigOption {
+	return func(c *SessionRequest) {
+		turnDetection := preset.TurnDetection
+		noiseReduction := preset.NoiseReduction
+		c.TurnDetection = &turnDetection
+		c.InputAudioNoiseReduction = &noiseReduction
+	}
+}
+
+// ApplyTranscription sets the turn detection and input audio noise reduction of a
+// transcription session request from the preset
+func (p VADPreset) ApplyTranscription(req *TranscriptionSessionRequest) {
+	turnDetection := p.TurnDetection
+	noiseReduction := p.NoiseReduction
+	req.TurnDetection = &turnDetection
+	req.InputAudioNoiseReduction = &noiseReduction
+}
@@ -0,0 +1,46 @@
+package session
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestWithVADPreset(t *testing.T) {
+	req := NewSessionRequest(
+		WithVADPreset(VADPresetKiosk),
+		WithInputAudioNoiseReduction(InputAudioNoiseReduction{Type: NoiseReductionTypeNearField}),
+	)
+	if req.TurnDetection.Type != TurnDetectionTypeServerVad || req.TurnDetection.Threshold != 0.7 {
+		t.Errorf("Expected the kiosk turn detection, got %+v", req.TurnDetection)
+	}
+	if req.InputAudioNoiseReduction.Type != NoiseReductionTypeNearField {
+		t.Errorf("Expected a later option to override the preset, got %s", req.InputAudioNoiseReduction.Type)
+	}
+
+	// Changing the request must not change the preset
+	req.TurnDetection.Threshold = 0.1
+	if VADPresetKiosk.TurnDetection.Threshold != 0.7 {
+		t.Error("Expected the preset to be copied")
+	}
+}
+
+func TestVADPresetPushToTalk(t *testing.T) {
+	data, err := json.Marshal(NewSessionRequest(WithVADPreset(VADPresetPushToTalk)))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(string(data), `"turn_detection":null`) {
+		t.Errorf("Expected push-to-talk to send null turn detection, got %s", data)
+	}
+
+	var req TranscriptionSessionRequest
+	VADPresetPhoneCall.ApplyTranscription(&req)
+	data, err = json.Marshal(req)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expected := `{"turn_detection":{"type":"semantic_vad","eagerness":"low"},"input_audio_noise_reduction":{"type":"near_field"}}`; string(data) != expected {
+		t.Errorf("Expected %s, got %s", expected, data)
+	}
+}
@@ -0,0 +1,165 @@
+package session
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/Mliviu79/openai-realtime-go/apierrs"
+)
+
+//-----------------------------------------------------------------------------
+// Request Validation
+//-----------------------------------------------------------------------------
+
+// WhisperMaxPromptTokens is the maximum prompt length whisper-1 takes into account.
+// Anything beyond this is silently ignored by the model.
+const WhisperMaxPromptTokens = 224
+
+// approxCharsPerToken is the rough number of characters per token used to estimate prompt length
+const approxCharsPerToken = 4
+
+// Validate checks the transcription configuration for model-specific restrictions.
+// It returns an *apierrs.APIError identifying the offending field, or nil if the
+// configuration is valid. Unknown models are not rejected so that new models can
+// be used without a library update, but model-specific checks are skipped for them.
+func (t *InputAudioTranscription) Validate() error {
+	if t == nil {
+		return nil
+	}
+
+	if t.Language != "" && !isISO6391(t.Language) {
+		return apierrs.NewInvalidField(
+			"input_audio_transcription.language",
+			fmt.Sprintf("language must be an ISO-639-1 code such as \"en\", got %q", t.Language),
+		)
+	}
+
+	if t.Model == TranscriptionModelWhisper1 {
+		// whisper-1 treats the prompt as a list of keywords and only uses the last 224 tokens
+		if estimated := (len(t.Prompt) + approxCharsPerToken - 1) / approxCharsPerToken; estimated > WhisperMaxPromptTokens {
+			return apierrs.NewInvalidField(
+				"input_audio_transcription.prompt",
+				fmt.Sprintf("whisper-1 prompts are limited to %d tokens, got an estimated %d", WhisperMaxPromptTokens, estimated),
+			)
+		}
+	}
+
+	return nil
+}
+
+// Validate checks the transcription session request for model-specific restrictions,
+// such as requesting logprobs from a model that does not support them.
+// It returns an *apierrs.APIError identifying the offending field, or nil if the
+// request is valid.
+func (r *TranscriptionSessionRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+
+	if err := r.InputAudioTranscription.Validate(); err != nil {
+		return err
+	}
+
+	if r.Include != nil && slices.Contains(*r.Include, TranscriptionSessionIncludeLogprobs) {
+		model := TranscriptionModel("")
+		if r.InputAudioTranscription != nil {
+			model = r.InputAudioTranscription.Model
+		}
+		if model.IsKnown() && !model.SupportsLogprobs() {
+			return apierrs.NewInvalidField(
+				"include",
+				fmt.Sprintf("%s requires a gpt-4o transcription model, got %q", TranscriptionSessionIncludeLogprobs, model),
+			)
+		}
+	}
+
+	return nil
+}
+
+// ParameterLimits are the accepted ranges for a model's sampling parameters
+type ParameterLimits struct {
+	// MinTemperature and MaxTemperature bound the sampling temperature
+	MinTemperature float64
+	MaxTemperature float64
+
+	// MaxOutputTokens is the largest finite max_response_output_tokens; "inf" is always accepted
+	MaxOutputTokens int
+}
+
+// realtimeLimits are the limits shared by every realtime model known to this package
+var realtimeLimits = ParameterLimits{
+	MinTemperature:  0.6,
+	MaxTemperature:  1.2,
+	MaxOutputTokens: 4096,
+}
+
+// Limits returns the parameter limits for the model. The second result is false for
+// models unknown to this package, whose limits are not checked.
+// An empty model uses the limits of the server's default realtime model.
+func (m Model) Limits() (ParameterLimits, bool) {
+	if m == "" || m.IsKnown() {
+		return realtimeLimits, true
+	}
+	return ParameterLimits{}, false
+}
+
+// Validate checks the session request before it is sent, so misconfigurations are
+// caught without a session update round-trip. It checks the modalities, the input audio
+// transcription, the prompt and the instructions length, and the temperature and
+// max_response_output_tokens against the limits of the request's model. Every problem
+// found is reported: the result joins one *apierrs.APIError per offending field, or is
+// nil if the request is valid.
+//
+// The model's limits are skipped for models unknown to this package, so new models can
+// be used without a library update.
+func (r *SessionRequest) Validate() error {
+	if r == nil {
+		return nil
+	}
+
+	var errs []error
+	if err := r.InputAudioTranscription.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if err := r.Prompt.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if r.Modalities != nil {
+		if err := ValidateModalities("modalities", *r.Modalities); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	model := Model("")
+	if r.Model != nil {
+		model = *r.Model
+	}
+	if limits, ok := model.Limits(); ok {
+		if err := r.ResponseParams.Validate(limits); err != nil {
+			errs = append(errs, err)
+		}
+		if r.MaxResponseOutputTokens != nil && !r.MaxResponseOutputTokens.IsInf() &&
+			(*r.MaxResponseOutputTokens < 1 || int(*r.MaxResponseOutputTokens) > limits.MaxOutputTokens) {
+			errs = append(errs, apierrs.NewInvalidField(
+				"max_response_output_tokens",
+				fmt.Sprintf("max_response_output_tokens must be between 1 and %d or \"inf\", got %d", limits.MaxOutputTokens, *r.MaxResponseOutputTokens),
+			))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// isISO6391 reports whether s looks like a two-letter lowercase ISO-639-1 language code
+func isISO6391(s string) bool {
+	if len(s) != 2 {
+		return false
+	}
+	for _, r := range s {
+		if r < 'a' || r > 'z' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,274 @@
+package session
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Mliviu79/openai-realtime-go/apierrs"
+)
+
+func TestInputAudioTranscriptionValidate(t *testing.T) {
+	tests := []struct {
+		name          string
+		transcription *InputAudioTranscription
+		expectedParam string
+	}{
+		{
+			name:          "Nil",
+			transcription: nil,
+		},
+		{
+			name:          "ValidWhisper",
+			transcription: &InputAudioTranscription{Model: TranscriptionModelWhisper1, Language: "en", Prompt: "OpenAI, Realtime"},
+		},
+		{
+			name:          "InvalidLanguage",
+			transcription: &InputAudioTranscription{Model: TranscriptionModelGPT4oTranscribe, Language: "english"},
+			expectedParam: "input_audio_transcription.language",
+		},
+		{
+			name:          "UppercaseLanguage",
+			transcription: &InputAudioTranscription{Model: TranscriptionModelGPT4oTranscribe, Language: "EN"},
+			expectedParam: "input_audio_transcription.language",
+		},
+		{
+			name:          "WhisperPromptTooLong",
+			transcription: &InputAudioTranscription{Model: TranscriptionModelWhisper1, Prompt: strings.Repeat("word ", 200)},
+			expectedParam: "input_audio_transcription.prompt",
+		},
+		{
+			name:          "LongPromptAllowedForGPT4o",
+			transcription: &InputAudioTranscription{Model: TranscriptionModelGPT4oTranscribe, Prompt: strings.Repeat("word ", 200)},
+		},
+		{
+			name:          "UnknownModel",
+			transcription: &InputAudioTranscription{Model: "future-transcribe", Prompt: strings.Repeat("word ", 200)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.transcription.Validate()
+			checkValidationError(t, err, tt.expectedParam)
+		})
+	}
+}
+
+func TestTranscriptionSessionRequestValidate(t *testing.T) {
+	logprobs := []TranscriptionSessionInclude{TranscriptionSessionIncludeLogprobs}
+
+	tests := []struct {
+		name          string
+		req           *TranscriptionSessionRequest
+		expectedParam string
+	}{
+		{
+			name: "LogprobsWithGPT4o",
+			req: &TranscriptionSessionRequest{
+				InputAudioTranscription: &InputAudioTranscription{Model: TranscriptionModelGPT4oMiniTranscribe},
+				Include:                 &logprobs,
+			},
+		},
+		{
+			name: "LogprobsWithWhisper",
+			req: &TranscriptionSessionRequest{
+				InputAudioTranscription: &InputAudioTranscription{Model: TranscriptionModelWhisper1},
+				Include:                 &logprobs,
+			},
+			expectedParam: "include",
+		},
+		{
+			name: "LogprobsWithoutModel",
+			req: &TranscriptionSessionRequest{
+				Include: &logprobs,
+			},
+		},
+		{
+			name: "InvalidTranscriptionConfig",
+			req: &TranscriptionSessionRequest{
+				InputAudioTranscription: &InputAudioTranscription{Language: "xyz"},
+			},
+			expectedParam: "input_audio_transcription.language",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			checkValidationError(t, err, tt.expectedParam)
+		})
+	}
+}
+
+func TestTranscriptionModelCapabilities(t *testing.T) {
+	if TranscriptionModelWhisper1.SupportsLogprobs() {
+		t.Error("Expected whisper-1 not to support logprobs")
+	}
+	if !TranscriptionModelGPT4oTranscribeLatest.SupportsLogprobs() {
+		t.Error("Expected gpt-4o-transcribe-latest to support logprobs")
+	}
+	if TranscriptionModel("custom").IsKnown() {
+		t.Error("Expected custom model not to be known")
+	}
+}
+
+func TestModelIsKnown(t *testing.T) {
+	tests := []struct {
+		name          string
+		model         Model
+		known         bool
+		expectedParam string
+	}{
+		{name: "GA", model: GPTRealtime, known: true},
+		{name: "GASnapshot", model: GPTRealtime20250828, known: true},
+		{name: "Mini", model: GPTRealtimeMini, known: true},
+		{name: "MiniSnapshot", model: GPTRealtimeMini20251006, known: true},
+		{name: "Preview", model: GPT4oRealtimePreview20250603, known: true},
+		{name: "FutureSnapshot", model: "gpt-realtime-2099-01-01", known: false, expectedParam: "model"},
+		{name: "Empty", model: "", known: false, expectedParam: "model"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.model.IsKnown(); got != tt.known {
+				t.Errorf("Expected IsKnown to be %v, got %v", tt.known, got)
+			}
+			checkValidationError(t, tt.model.ValidateKnown(), tt.expectedParam)
+		})
+	}
+}
+
+func TestSessionRequestValidate(t *testing.T) {
+	temperature := func(v float64) *float64 { return &v }
+	model := func(m Model) *Model { return &m }
+
+	tests := []struct {
+		name          string
+		req           *SessionRequest
+		expectedParam string
+	}{
+		{
+			name: "Nil",
+		},
+		{
+			name: "Valid",
+			req:  &SessionRequest{Model: model(GPTRealtime), ResponseParams: ResponseParams{Temperature: temperature(0.8)}, MaxResponseOutputTokens: NewIntOrInf(4096)},
+		},
+		{
+			name: "InfiniteTokens",
+			req:  &SessionRequest{MaxResponseOutputTokens: NewInfinity()},
+		},
+		{
+			name:          "TemperatureTooLow",
+			req:           &SessionRequest{ResponseParams: ResponseParams{Temperature: temperature(0.2)}},
+			expectedParam: "temperature",
+		},
+		{
+			name:          "TemperatureTooHigh",
+			req:           &SessionRequest{Model: model(GPT4oRealtimePreview), ResponseParams: ResponseParams{Temperature: temperature(1.5)}},
+			expectedParam: "temperature",
+		},
+		{
+			name:          "TooManyTokens",
+			req:           &SessionRequest{Model: model(GPTRealtimeMini), MaxResponseOutputTokens: NewIntOrInf(5000)},
+			expectedParam: "max_response_output_tokens",
+		},
+		{
+			name:          "ZeroTokens",
+			req:           &SessionRequest{MaxResponseOutputTokens: NewIntOrInf(0)},
+			expectedParam: "max_response_output_tokens",
+		},
+		{
+			name: "UnknownModel",
+			req:  &SessionRequest{Model: model("future-realtime"), ResponseParams: ResponseParams{Temperature: temperature(1.8)}, MaxResponseOutputTokens: NewIntOrInf(9000)},
+		},
+		{
+			name:          "InvalidPrompt",
+			req:           &SessionRequest{Prompt: &Prompt{}},
+			expectedParam: "prompt.id",
+		},
+		{
+			name: "TextAndAudio",
+			req:  NewSessionRequest(WithModalities(TextAndAudio)),
+		},
+		{
+			name:          "UnknownModality",
+			req:           NewSessionRequest(WithModalities([]Modality{ModalityText, "video"})),
+			expectedParam: "modalities",
+		},
+		{
+			name:          "DuplicateModality",
+			req:           NewSessionRequest(WithModalities([]Modality{ModalityAudio, ModalityAudio})),
+			expectedParam: "modalities",
+		},
+		{
+			name:          "NoModalities",
+			req:           NewSessionRequest(WithModalities([]Modality{})),
+			expectedParam: "modalities",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checkValidationError(t, tt.req.Validate(), tt.expectedParam)
+		})
+	}
+}
+
+func TestWithModalitiesCopies(t *testing.T) {
+	req := NewSessionRequest(WithModalities(TextAndAudio))
+	(*req.Modalities)[0] = ModalityAudio
+	if TextAndAudio[0] != ModalityText {
+		t.Error("Expected WithModalities to copy the shared modality set")
+	}
+}
+
+func TestSessionRequestValidateAggregates(t *testing.T) {
+	_, err := BuildSessionRequest(
+		WithTemperature(2),
+		WithMaxResponseOutputTokens(10000),
+		WithInputAudioTranscription(InputAudioTranscription{Language: "english"}),
+	)
+	if err == nil {
+		t.Fatal("Expected validation errors")
+	}
+
+	var params []string
+	for _, e := range err.(interface{ Unwrap() []error }).Unwrap() {
+		var apiErr *apierrs.APIError
+		if errors.As(e, &apiErr) && apiErr.Response.Error.Param != nil {
+			params = append(params, *apiErr.Response.Error.Param)
+		}
+	}
+	expected := []string{"input_audio_transcription.language", "temperature", "max_response_output_tokens"}
+	if strings.Join(params, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected errors for %v, got %v", expected, params)
+	}
+
+	req, err := BuildSessionRequest(WithTemperature(0.7), WithMaxResponseOutputTokens(-1))
+	if err != nil || req == nil || *req.Temperature != 0.7 {
+		t.Errorf("Expected a valid request, got %+v, %v", req, err)
+	}
+}
+
+// checkValidationError verifies err is nil when expectedParam is empty,
+// or an invalid field error for expectedParam otherwise
+func checkValidationError(t *testing.T, err error, expectedParam string) {
+	t.Helper()
+
+	if expectedParam == "" {
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+		}
+		return
+	}
+
+	var apiErr *apierrs.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("Expected *apierrs.APIError, got %v", err)
+	}
+	if apiErr.Response.Error.Param == nil || *apiErr.Response.Error.Param != expectedParam {
+		t.Errorf("Expected param %q, got %v", expectedParam, apiErr.Response.Error.Param)
+	}
+}
@@ -0,0 +1,90 @@
+package store
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/Mliviu79/openai-realtime-go/messages/types"
+)
+
+// Memory is an in-memory Store. Conversations live as long as the process, so it suits
+// tests and single-process deployments that only need to survive session restarts.
+type Memory struct {
+	mu            sync.Mutex
+	conversations map[string]*Conversation
+}
+
+// NewMemory creates an empty in-memory Store
+func NewMemory() *Memory {
+	return &Memory{conversations: make(map[string]*Conversation)}
+}
+
+// SaveItem implements Store
+func (m *Memory) SaveItem(ctx context.Context, conversationID, previousItemID string, item types.MessageItem) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c := m.conversation(conversationID)
+	c.Items = InsertItem(c.Items, previousItemID, item.Clone())
+	return nil
+}
+
+// SaveResponse implements Store
+func (m *Memory) SaveResponse(ctx context.Context, conversationID string, response types.Response) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c := m.conversation(conversationID)
+	response = response.Clone()
+	if i := slices.IndexFunc(c.Responses, func(r types.Response) bool { return r.ID == response.ID }); i >= 0 {
+		c.Responses[i] = response
+	} else {
+		c.Responses = append(c.Responses, response)
+	}
+	return nil
+}
+
+// SaveUsage implements Store
+func (m *Memory) SaveUsage(ctx context.Context, conversationID, responseID string, usage types.Usage) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.conversation(conversationID).Usage[responseID] = usage
+	return nil
+}
+
+// LoadConversation implements Store. The returned conversation is a copy.
+func (m *Memory) LoadConversation(ctx context.Context, conversationID string) (*Conversation, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c, ok := m.conversations[conversationID]
+	if !ok {
+		return nil, ErrNotFound
+	}
+
+	out := &Conversation{
+		ID:        c.ID,
+		Items:     make([]types.MessageItem, len(c.Items)),
+		Responses: make([]types.Response, len(c.Responses)),
+		Usage:     make(map[string]types.Usage, len(c.Usage)),
+	}
+	for i, item := range c.Items {
+		out.Items[i] = item.Clone()
+	}
+	for i, response := range c.Responses {
+		out.Responses[i] = response.Clone()
+	}
+	for id, usage := range c.Usage {
+		out.Usage[id] = usage
+	}
+	return out, nil
+}
+
+// conversation returns the conversation with the given ID, creating it if needed;
+// the lock must be held
+func (m *Memory) conversation(id string) *Conversation {
+	c, ok := m.conversations[id]
+	if !ok {
+		c = &Conversation{ID: id, Usage: make(map[string]types.Usage)}
+		m.conversations[id] = c
+	}
+	return c
+}
@@ -0,0 +1,140 @@
+// Package store persists conversations so transcripts survive process restarts.
+//
+// A Store records the items, responses and token usage of a conversation under a key
+// chosen by the application. messaging.ConversationTracker writes to a store when it is
+// created with messaging.WithConversationStore, and LoadConversation reads the
+// conversation back, e.g. to re-import it into a new session:
+//
+//	s := store.NewMemory()
+//	tracker := messaging.NewConversationTracker(messaging.WithConversationStore(s, "call-42"))
+//	handler := messaging.NewHandler(ctx, msgClient, tracker.Handle)
+//	// ... after a restart
+//	conv, err := s.LoadConversation(ctx, "call-42")
+//	if err == nil {
+//		_, err = msgClient.SendConversationItems(ctx, conv.ImportableItems())
+//	}
+//
+// NewMemory is an in-memory reference implementation; contrib/sqlite stores
+// conversations in SQLite.
+package store
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/Mliviu79/openai-realtime-go/messages/types"
+)
+
+// ErrNotFound is returned by LoadConversation for a conversation that has nothing stored
+var ErrNotFound = errors.New("conversation not found")
+
+// Store persists conversations. Implementations must be safe for concurrent use.
+type Store interface {
+	// SaveItem stores an item of the conversation after the item with previousItemID,
+	// or at the end if previousItemID is empty or unknown. Saving an item with the ID
+	// of a stored item replaces it in place, e.g. when its transcript arrives.
+	SaveItem(ctx context.Context, conversationID, previousItemID string, item types.MessageItem) error
+
+	// SaveResponse stores a finished response, replacing a stored response with the same ID
+	SaveResponse(ctx context.Context, conversationID string, response types.Response) error
+
+	// SaveUsage stores the token usage of a response, replacing the usage stored for it
+	SaveUsage(ctx context.Context, conversationID, responseID string, usage types.Usage) error
+
+	// LoadConversation returns everything stored for the conversation, or ErrNotFound
+	LoadConversation(ctx context.Context, conversationID string) (*Conversation, error)
+}
+
+// Conversation is a stored conversation
+type Conversation struct {
+	// ID is the key the conversation is stored under
+	ID string
+
+	// Items are the conversation items in conversation order
+	Items []types.MessageItem
+
+	// Responses are the finished responses in the order they were first saved
+	Responses []types.Response
+
+	// Usage is the token usage of each response, by response ID
+	Usage map[string]types.Usage
+}
+
+// TotalUsage returns the token usage of all responses
+func (c *Conversation) TotalUsage() types.Usage {
+	var total types.Usage
+	for _, usage := range c.Usage {
+		total.Add(usage)
+	}
+	return total
+}
+
+// ImportableItems returns the items in a form that can be sent with conversation.item.create
+func (c *Conversation) ImportableItems() []types.MessageItem {
+	return ImportableItems(c.Items)
+}
+
+// ImportableItems returns copies of items that can be sent with conversation.item.create.
+// Audio cannot be re-imported, so audio content is replaced by its transcript and
+// messages left without content are skipped.
+func ImportableItems(items []types.MessageItem) []types.MessageItem {
+	out := make([]types.MessageItem, 0, len(items))
+	for _, item := range items {
+		item = item.Clone()
+		item.Object = ""
+		item.Status = ""
+		if item.Type == types.MessageItemTypeMessage {
+			item.Content = importableContent(item.Content)
+			if len(item.Content) == 0 {
+				continue
+			}
+		}
+		out = append(out, item)
+	}
+	return out
+}
+
+// InsertItem adds item to items after the item with previousItemID, or at the end if
+// previousItemID is empty or unknown, replacing an item with the same ID in place.
+// Store implementations that record items in the order they were saved can replay
+// them with InsertItem to rebuild the conversation order.
+func InsertItem(items []types.MessageItem, previousItemID string, item types.MessageItem) []types.MessageItem {
+	if i := indexOf(items, item.ID); i >= 0 {
+		items[i] = item
+		return items
+	}
+	if i := indexOf(items, previousItemID); i >= 0 {
+		return slices.Insert(items, i+1, item)
+	}
+	return append(items, item)
+}
+
+// indexOf returns the position of the item with the given ID, or -1
+func indexOf(items []types.MessageItem, id string) int {
+	if id == "" {
+		return -1
+	}
+	return slices.IndexFunc(items, func(item types.MessageItem) bool { return item.ID == id })
+}
+
+// importableContent replaces audio content parts with their transcripts as text
+func importableContent(content []types.MessageContentPart) []types.MessageContentPart {
+	var out []types.MessageContentPart
+	for _, part := range content {
+		switch part.Type {
+		case types.MessageContentTypeInputAudio:
+			if part.Transcript == "" {
+				continue
+			}
+			part = types.MessageContentPart{Type: types.MessageContentTypeInputText, Text: part.Transcript}
+		case types.MessageContentTypeAudio:
+			if part.Transcript == "" {
+				continue
+			}
+			part = types.MessageContentPart{Type: types.MessageContentTypeText, Text: part.Transcript}
+		}
+		out = append(out, part)
+	}
+	return out
+}
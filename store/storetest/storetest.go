@@ -0,0 +1,119 @@
+// Package storetest checks that a store.Store implementation behaves like the reference
+// in-memory store. Implementations outside this module can run it in their own tests:
+//
+//	func TestStore(t *testing.T) {
+//		storetest.Run(t, func(t *testing.T) store.Store {
+//			return newTestStore(t)
+//		})
+//	}
+package storetest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Mliviu79/openai-realtime-go/messages/types"
+	"github.com/Mliviu79/openai-realtime-go/store"
+)
+
+// Run runs the conformance tests, calling newStore for an empty store in each subtest
+func Run(t *testing.T, newStore func(t *testing.T) store.Store) {
+	t.Run("item order", func(t *testing.T) { testItemOrder(t, newStore(t)) })
+	t.Run("responses and usage", func(t *testing.T) { testResponses(t, newStore(t)) })
+	t.Run("conversations are separate", func(t *testing.T) { testSeparate(t, newStore(t)) })
+}
+
+// message creates a message item with a single text part
+func message(id string, role types.MessageRole, text string) types.MessageItem {
+	contentType := types.MessageContentTypeText
+	if role == types.MessageRoleUser {
+		contentType = types.MessageContentTypeInputText
+	}
+	return types.MessageItem{
+		ID:      id,
+		Type:    types.MessageItemTypeMessage,
+		Role:    role,
+		Content: []types.MessageContentPart{{Type: contentType, Text: text}},
+	}
+}
+
+func testItemOrder(t *testing.T, s store.Store) {
+	ctx := context.Background()
+	save := func(previousItemID string, item types.MessageItem) {
+		t.Helper()
+		if err := s.SaveItem(ctx, "conv", previousItemID, item); err != nil {
+			t.Fatalf("Unexpected error saving %s: %v", item.ID, err)
+		}
+	}
+
+	save("", message("item_1", types.MessageRoleUser, "Hi"))
+	save("item_1", message("item_3", types.MessageRoleAssistant, "Hello"))
+	// Inserted between the first two items
+	save("item_1", message("item_2", types.MessageRoleUser, "Are you there?"))
+	// Replaced in place, e.g. when a transcript arrives
+	save("", message("item_1", types.MessageRoleUser, "Hi!"))
+
+	conv, err := s.LoadConversation(ctx, "conv")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := []string{"item_1:Hi!", "item_2:Are you there?", "item_3:Hello"}
+	if len(conv.Items) != len(expected) {
+		t.Fatalf("Expected %d items, got %+v", len(expected), conv.Items)
+	}
+	for i, want := range expected {
+		got := conv.Items[i].ID + ":" + conv.Items[i].Content[0].Text
+		if got != want {
+			t.Errorf("Expected item %d to be %s, got %s", i, want, got)
+		}
+	}
+	if conv.ID != "conv" {
+		t.Errorf("Expected conversation ID conv, got %s", conv.ID)
+	}
+}
+
+func testResponses(t *testing.T, s store.Store) {
+	ctx := context.Background()
+	for _, response := range []types.Response{
+		{ID: "resp_1", Status: types.ResponseStatusInProgress},
+		{ID: "resp_2", Status: types.ResponseStatusCompleted},
+		{ID: "resp_1", Status: types.ResponseStatusCancelled},
+	} {
+		if err := s.SaveResponse(ctx, "conv", response); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if err := s.SaveUsage(ctx, "conv", "resp_1", types.Usage{TotalTokens: 10, InputTokens: 4, OutputTokens: 6}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := s.SaveUsage(ctx, "conv", "resp_2", types.Usage{TotalTokens: 5, InputTokens: 5}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	conv, err := s.LoadConversation(ctx, "conv")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(conv.Responses) != 2 || conv.Responses[0].ID != "resp_1" || conv.Responses[0].Status != types.ResponseStatusCancelled {
+		t.Errorf("Expected resp_1 replaced in place before resp_2, got %+v", conv.Responses)
+	}
+	if total := conv.TotalUsage(); total.TotalTokens != 15 || total.InputTokens != 9 || total.OutputTokens != 6 {
+		t.Errorf("Expected a total of 15 tokens, got %+v", total)
+	}
+}
+
+func testSeparate(t *testing.T, s store.Store) {
+	ctx := context.Background()
+	if err := s.SaveItem(ctx, "a", "", message("item_1", types.MessageRoleUser, "Hi")); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if _, err := s.LoadConversation(ctx, "b"); !errors.Is(err, store.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+	conv, err := s.LoadConversation(ctx, "a")
+	if err != nil || len(conv.Items) != 1 {
+		t.Errorf("Expected one item in conversation a, got %v %v", conv, err)
+	}
+}
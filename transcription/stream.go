@@ -0,0 +1,230 @@
+// Package transcription turns a realtime transcription session into a stream of typed
+// segments, hiding the session update, audio buffer and read loop choreography of the
+// raw protocol.
+//
+// Example:
+//
+//	conn, err := client.Connect(ctx, openaiClient.WithIntent(openaiClient.IntentTranscription))
+//	// ...
+//	stream := transcription.NewStream(messaging.NewClient(conn),
+//		transcription.WithSessionUpdate(session.TranscriptionSessionRequest{
+//			InputAudioTranscription: &session.InputAudioTranscription{Model: session.TranscriptionModelGPT4oTranscribe},
+//		}),
+//	)
+//	go func() {
+//		for chunk := range microphone {
+//			_ = stream.Write(ctx, chunk)
+//		}
+//	}()
+//	for segment, err := range stream.Segments(ctx) {
+//		if err != nil {
+//			return err
+//		}
+//		fmt.Printf("[%d-%d ms] %s\n", segment.StartMs, segment.EndMs, segment.Text)
+//	}
+package transcription
+
+import (
+	"context"
+	"encoding/base64"
+	"iter"
+	"strings"
+
+	"github.com/Mliviu79/openai-realtime-go/apierrs"
+	"github.com/Mliviu79/openai-realtime-go/messages/incoming"
+	"github.com/Mliviu79/openai-realtime-go/messaging"
+	"github.com/Mliviu79/openai-realtime-go/session"
+)
+
+// Segment is the transcript of one committed piece of input audio, normally one utterance
+type Segment struct {
+	// ItemID identifies the conversation item the audio was committed as
+	ItemID string
+
+	// ContentIndex is the position of the transcribed content part within the item
+	ContentIndex int
+
+	// Text is the transcript. For partial segments it is the transcript so far.
+	Text string
+
+	// Partial is set for segments yielded while the transcription is still in progress,
+	// with WithPartialSegments
+	Partial bool
+
+	// Logprobs are the token log probabilities, if the session includes them
+	Logprobs []incoming.LogProbItem
+
+	// StartMs and EndMs are where server VAD detected the start and end of speech, in
+	// milliseconds of audio from the start of the session. They are zero without VAD.
+	StartMs int64
+	EndMs   int64
+}
+
+// Option configures a Stream
+type Option func(*Stream)
+
+// WithSessionUpdate sends a transcription session update before the first segment is
+// read, and waits for the server to confirm it
+func WithSessionUpdate(req session.TranscriptionSessionRequest) Option {
+	return func(s *Stream) {
+		s.update = &req
+	}
+}
+
+// WithPartialSegments also yields a partial segment for every transcription delta, so
+// text can be shown while the user is still speaking
+func WithPartialSegments() Option {
+	return func(s *Stream) {
+		s.partials = true
+	}
+}
+
+// Stream is a transcription session read as a sequence of segments. Audio is sent with
+// Write, and with Commit when server VAD is off; segments are read with Segments.
+type Stream struct {
+	client   *messaging.Client
+	update   *session.TranscriptionSessionRequest
+	partials bool
+}
+
+// segmentState is what a Stream knows about an item that has not finished transcribing
+type segmentState struct {
+	startMs int64
+	endMs   int64
+	text    strings.Builder
+}
+
+// NewStream creates a Stream over a messaging client connected to a transcription session
+func NewStream(client *messaging.Client, opts ...Option) *Stream {
+	s := &Stream{client: client}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Write appends raw audio, in the session's input audio format, to the input audio buffer
+func (s *Stream) Write(ctx context.Context, audio []byte) error {
+	return s.client.SendAudioBufferAppend(ctx, base64.StdEncoding.EncodeToString(audio))
+}
+
+// Commit commits the input audio buffer, so the audio written since the last commit is
+// transcribed. It is only needed when server VAD is off; with VAD the server commits at
+// the end of each utterance.
+func (s *Stream) Commit(ctx context.Context) error {
+	return s.client.SendAudioBufferCommit(ctx, "")
+}
+
+// Segments returns an iterator over the segments of the session, in the order their
+// transcriptions complete.
+//
+// The iterator reads messages from the connection itself, so it must not be used while
+// another goroutine (such as a Handler) is reading from the same client. Write and Commit
+// may be called concurrently from other goroutines.
+//
+// A transcription that fails is yielded as a segment with its item ID and an
+// *apierrs.APIError, and iteration continues unless the caller stops. Iteration ends when
+// the server reports an error, when reading fails, or when the caller stops ranging.
+func (s *Stream) Segments(ctx context.Context) iter.Seq2[Segment, error] {
+	return func(yield func(Segment, error) bool) {
+		if s.update != nil {
+			if err := s.configure(ctx); err != nil {
+				yield(Segment{}, err)
+				return
+			}
+		}
+
+		states := make(map[string]*segmentState)
+		state := func(itemID string) *segmentState {
+			st, ok := states[itemID]
+			if !ok {
+				st = &segmentState{}
+				states[itemID] = st
+			}
+			return st
+		}
+
+		for {
+			msg, err := s.client.ReadMessage(ctx)
+			if err != nil {
+				yield(Segment{}, err)
+				return
+			}
+
+			switch m := msg.(type) {
+			case *incoming.ErrorMessage:
+				yield(Segment{}, m.AsAPIError())
+				return
+			case *incoming.AudioBufferSpeechStartedMessage:
+				state(m.ItemID).startMs = m.AudioStartMs
+			case *incoming.AudioBufferSpeechStoppedMessage:
+				state(m.ItemID).endMs = m.AudioEndMs
+			case *incoming.ConversationItemTranscriptionDeltaMessage:
+				st := state(m.ItemID)
+				st.text.WriteString(m.Delta)
+				if !s.partials {
+					continue
+				}
+				segment := Segment{
+					ItemID:       m.ItemID,
+					ContentIndex: m.ContentIndex,
+					Text:         st.text.String(),
+					Partial:      true,
+					StartMs:      st.startMs,
+					EndMs:        st.endMs,
+				}
+				if !yield(segment, nil) {
+					return
+				}
+			case *incoming.ConversationItemTranscriptionCompletedMessage:
+				st := state(m.ItemID)
+				delete(states, m.ItemID)
+				segment := Segment{
+					ItemID:       m.ItemID,
+					ContentIndex: m.ContentIndex,
+					Text:         m.Transcript,
+					StartMs:      st.startMs,
+					EndMs:        st.endMs,
+				}
+				for _, lp := range m.Logprobs {
+					segment.Logprobs = append(segment.Logprobs, incoming.LogProbItem{Token: lp.Token, LogProb: lp.Logprob})
+				}
+				if !yield(segment, nil) {
+					return
+				}
+			case *incoming.ConversationItemTranscriptionFailedMessage:
+				st := state(m.ItemID)
+				delete(states, m.ItemID)
+				segment := Segment{
+					ItemID:       m.ItemID,
+					ContentIndex: m.ContentIndex,
+					StartMs:      st.startMs,
+					EndMs:        st.endMs,
+				}
+				failure := apierrs.NewAPIError(m.Error.Type, string(m.Error.Code), m.Error.Message)
+				if !yield(segment, failure) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// configure sends the session update and reads until the server confirms or rejects it
+func (s *Stream) configure(ctx context.Context) error {
+	if err := s.client.SendTranscriptionSessionUpdate(ctx, *s.update); err != nil {
+		return err
+	}
+	for {
+		msg, err := s.client.ReadMessage(ctx)
+		if err != nil {
+			return err
+		}
+		switch m := msg.(type) {
+		case *incoming.TranscriptionSessionUpdatedMessage:
+			return nil
+		case *incoming.ErrorMessage:
+			return m.AsAPIError()
+		}
+	}
+}
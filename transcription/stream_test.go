@@ -0,0 +1,157 @@
+package transcription
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Mliviu79/openai-realtime-go/apierrs"
+	"github.com/Mliviu79/openai-realtime-go/messaging"
+	"github.com/Mliviu79/openai-realtime-go/session"
+	"github.com/Mliviu79/openai-realtime-go/ws"
+)
+
+// MockConn is a mock implementation of ws.WebSocketConn that replays scripted messages
+// and records written frames
+type MockConn struct {
+	mu       sync.Mutex
+	messages []string
+	written  []string
+}
+
+func (m *MockConn) WriteMessage(ctx context.Context, messageType ws.MessageType, data []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.written = append(m.written, string(data))
+	return nil
+}
+
+func (m *MockConn) ReadMessage(ctx context.Context) (ws.MessageType, []byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.messages) == 0 {
+		return ws.MessageText, nil, errors.New("no more messages")
+	}
+	data := m.messages[0]
+	m.messages = m.messages[1:]
+	return ws.MessageText, []byte(data), nil
+}
+
+func (m *MockConn) Close() error { return nil }
+
+func (m *MockConn) Ping(ctx context.Context) error { return nil }
+
+// sentTypes returns the types of the written frames
+func (m *MockConn) sentTypes() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	var types []string
+	for _, frame := range m.written {
+		var event struct {
+			Type string `json:"type"`
+		}
+		_ = json.Unmarshal([]byte(frame), &event)
+		types = append(types, event.Type)
+	}
+	return types
+}
+
+func newStream(conn *MockConn, opts ...Option) *Stream {
+	return NewStream(messaging.NewClient(ws.NewConn(conn)), opts...)
+}
+
+func TestStreamSegments(t *testing.T) {
+	conn := &MockConn{messages: []string{
+		`{"type":"transcription_session.updated","session":{}}`,
+		`{"type":"input_audio_buffer.speech_started","audio_start_ms":120,"item_id":"item_1"}`,
+		`{"type":"input_audio_buffer.speech_stopped","audio_end_ms":1850,"item_id":"item_1"}`,
+		`{"type":"input_audio_buffer.committed","item_id":"item_1"}`,
+		`{"type":"conversation.item.input_audio_transcription.delta","item_id":"item_1","content_index":0,"delta":"Hello"}`,
+		`{"type":"conversation.item.input_audio_transcription.delta","item_id":"item_1","content_index":0,"delta":" there"}`,
+		`{"type":"conversation.item.input_audio_transcription.completed","item_id":"item_1","content_index":0,"transcript":"Hello there","logprobs":[{"token":"Hello","logprob":-0.1},{"token":" there","logprob":-0.2}]}`,
+		`{"type":"conversation.item.input_audio_transcription.failed","item_id":"item_2","content_index":0,"error":{"type":"transcription_error","code":"audio_unintelligible","message":"Audio could not be transcribed"}}`,
+		`{"type":"conversation.item.input_audio_transcription.completed","item_id":"item_3","content_index":0,"transcript":"Bye"}`,
+	}}
+	stream := newStream(conn, WithPartialSegments(), WithSessionUpdate(session.TranscriptionSessionRequest{}))
+
+	var texts []string
+	var failed []string
+	var last Segment
+	for segment, err := range stream.Segments(context.Background()) {
+		if err != nil {
+			var apiErr *apierrs.APIError
+			if !errors.As(err, &apiErr) {
+				break
+			}
+			if apiErr.Response.Error.Code != "audio_unintelligible" {
+				t.Errorf("Expected the transcription failure, got %v", err)
+			}
+			failed = append(failed, segment.ItemID)
+			continue
+		}
+		texts = append(texts, segment.Text)
+		if segment.ItemID == "item_1" && !segment.Partial {
+			last = segment
+		}
+	}
+
+	if expected := "Hello,Hello there,Hello there,Bye"; strings.Join(texts, ",") != expected {
+		t.Errorf("Expected segments %s, got %s", expected, strings.Join(texts, ","))
+	}
+	if len(failed) != 1 || failed[0] != "item_2" {
+		t.Errorf("Expected item_2 to fail, got %v", failed)
+	}
+	if last.StartMs != 120 || last.EndMs != 1850 {
+		t.Errorf("Expected the segment to be timed by VAD, got %d-%d", last.StartMs, last.EndMs)
+	}
+	if len(last.Logprobs) != 2 || last.Logprobs[1].Token != " there" || last.Logprobs[1].LogProb != -0.2 {
+		t.Errorf("Expected the segment logprobs, got %+v", last.Logprobs)
+	}
+	if types := conn.sentTypes(); len(types) != 1 || types[0] != "transcription_session.update" {
+		t.Errorf("Expected a transcription session update to be sent, got %v", types)
+	}
+}
+
+func TestStreamSessionUpdateRejected(t *testing.T) {
+	conn := &MockConn{messages: []string{
+		`{"type":"error","error":{"type":"invalid_request_error","code":"invalid_value","message":"Invalid model"}}`,
+		`{"type":"conversation.item.input_audio_transcription.completed","item_id":"item_1","content_index":0,"transcript":"Hi"}`,
+	}}
+	stream := newStream(conn, WithSessionUpdate(session.TranscriptionSessionRequest{}))
+
+	var segments int
+	var errs []error
+	for segment, err := range stream.Segments(context.Background()) {
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		_ = segment
+		segments++
+	}
+	if segments != 0 || len(errs) != 1 || !apierrs.IsAPIError(errs[0]) {
+		t.Errorf("Expected the rejected update to end the stream, got %d segments and errors %v", segments, errs)
+	}
+}
+
+func TestStreamWriteAndCommit(t *testing.T) {
+	conn := &MockConn{}
+	stream := newStream(conn)
+	ctx := context.Background()
+
+	if err := stream.Write(ctx, []byte{1, 2, 3}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := stream.Commit(ctx); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if types := conn.sentTypes(); strings.Join(types, ",") != "input_audio_buffer.append,input_audio_buffer.commit" {
+		t.Errorf("Expected the audio to be appended and committed, got %v", types)
+	}
+	if !strings.Contains(conn.written[0], `"audio":"AQID"`) {
+		t.Errorf("Expected base64 audio, got %s", conn.written[0])
+	}
+}
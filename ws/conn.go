@@ -35,6 +35,7 @@ import (
 	"context"
 	"sync"
 
+	"github.com/Mliviu79/openai-realtime-go/clock"
 	"github.com/Mliviu79/openai-realtime-go/logger"
 )
 
@@ -42,9 +43,26 @@ import (
 // It provides thread-safe methods for sending and receiving messages over a WebSocket connection.
 // Conn implements connection management, including thread safety, logging, and error handling.
 type Conn struct {
-	mu     sync.RWMutex
-	logger logger.Logger
-	conn   WebSocketConn
+	// conn is set by NewConn and never changes, so it is used without holding mu; mu
+	// guards the logger and metrics hook and is never held during I/O, so setting them
+	// cannot wait for a blocked read
+	mu          sync.RWMutex
+	logger      logger.Logger
+	conn        WebSocketConn
+	metrics     MetricsHook
+	closeReport sync.Once
+
+	// onPanic is called with panics recovered from background goroutines, which panic
+	// again if rethrowPanics is set
+	panicMu       sync.Mutex
+	onPanic       PanicHandler
+	rethrowPanics bool
+
+	// done is closed by Close; keepAlive is set once a keep-alive is started, timed by clock
+	done      chan struct{}
+	closeOnce sync.Once
+	keepAlive bool
+	clock     clock.Clock
 }
 
 // NewConn creates a new Conn instance
@@ -52,6 +70,7 @@ type Conn struct {
 func NewConn(conn WebSocketConn) *Conn {
 	return &Conn{
 		conn: conn,
+		done: make(chan struct{}),
 	}
 }
 
@@ -64,12 +83,20 @@ func (c *Conn) SetLogger(logger logger.Logger) {
 	c.logger = logger
 }
 
+// SetMetricsHook sets the hook that receives connection telemetry.
+// A ConnEventAbnormalClose event is reported the first time a read fails because the
+// connection ended without a normal close. If nil, no telemetry is reported.
+func (c *Conn) SetMetricsHook(hook MetricsHook) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.metrics = hook
+}
+
 // Close closes the connection.
 // This method is thread-safe and can be called from any goroutine.
 // After closing, no more messages can be sent or received.
 func (c *Conn) Close() error {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
+	c.closeOnce.Do(func() { close(c.done) })
 	if c.conn == nil {
 		return nil
 	}
@@ -82,10 +109,11 @@ func (c *Conn) Close() error {
 // This method is thread-safe and can be called from any goroutine.
 func (c *Conn) SendRaw(ctx context.Context, messageType MessageType, data []byte) error {
 	c.mu.RLock()
-	defer c.mu.RUnlock()
+	log := c.logger
+	c.mu.RUnlock()
 
-	if c.logger != nil {
-		c.logger.Debugf("sending raw message: type=%s data=%s", messageType.String(), string(data))
+	if log != nil {
+		log.Debugf("sending raw message: type=%s data=%s", messageType.String(), string(data))
 	}
 
 	return c.conn.WriteMessage(ctx, messageType, data)
@@ -97,11 +125,13 @@ func (c *Conn) SendRaw(ctx context.Context, messageType MessageType, data []byte
 // This method is thread-safe and can be called from any goroutine.
 // It will block until a message is received, the context is canceled, or an error occurs.
 func (c *Conn) ReadRaw(ctx context.Context) (MessageType, []byte, error) {
+	messageType, data, err := c.conn.ReadMessage(ctx)
+
 	c.mu.RLock()
 	defer c.mu.RUnlock()
-
-	messageType, data, err := c.conn.ReadMessage(ctx)
 	if err != nil {
+		c.reportFrameTooLarge(err)
+		c.reportAbnormalClose(err)
 		return 0, nil, err
 	}
 
@@ -112,11 +142,54 @@ func (c *Conn) ReadRaw(ctx context.Context) (MessageType, []byte, error) {
 	return messageType, data, nil
 }
 
+// Underlying returns the WebSocketConn wrapped by this connection.
+// It is an escape hatch for advanced use cases, such as reaching implementation-specific
+// features of the Gorilla connection. Reads and writes made directly on the returned
+// connection bypass the thread safety and logging provided by Conn.
+func (c *Conn) Underlying() WebSocketConn {
+	return c.conn
+}
+
 // Ping sends a ping message to the WebSocket connection.
 // This can be used to keep the connection alive or check if it's still operational.
 // This method is thread-safe and can be called from any goroutine.
 func (c *Conn) Ping(ctx context.Context) error {
-	c.mu.RLock()
-	defer c.mu.RUnlock()
 	return c.conn.Ping(ctx)
 }
+
+// reportAbnormalClose reports the first abnormal close to the metrics hook.
+// The caller must hold c.mu.
+func (c *Conn) reportAbnormalClose(err error) {
+	if c.metrics == nil {
+		return
+	}
+	reason, abnormal := AbnormalCloseReason(err)
+	if !abnormal {
+		return
+	}
+	c.closeReport.Do(func() {
+		c.metrics.RecordConnEvent(ConnEvent{
+			Kind:   ConnEventAbnormalClose,
+			Reason: reason,
+			Err:    err,
+		})
+	})
+}
+
+// reportFrameTooLarge reports a discarded oversized message to the metrics hook.
+// The caller must hold c.mu.
+func (c *Conn) reportFrameTooLarge(err error) {
+	tooLarge, ok := err.(*FrameTooLargeError)
+	if c.metrics == nil || !ok {
+		return
+	}
+	reason := tooLarge.EventType
+	if reason == "" {
+		reason = ReasonOther
+	}
+	c.metrics.RecordConnEvent(ConnEvent{
+		Kind:   ConnEventFrameTooLarge,
+		Reason: reason,
+		Err:    err,
+	})
+}
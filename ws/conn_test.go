@@ -2,7 +2,9 @@ package ws
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 )
 
 func TestNewConn(t *testing.T) {
@@ -163,3 +165,54 @@ func (m *MockWebSocketConn) Ping(ctx context.Context) error {
 	}
 	return nil
 }
+
+func TestConnUnderlying(t *testing.T) {
+	mockConn := &MockWebSocketConn{}
+	conn := NewConn(mockConn)
+
+	if conn.Underlying() != WebSocketConn(mockConn) {
+		t.Error("Expected Underlying to return the wrapped connection")
+	}
+}
+
+func TestConnSettersDoNotWaitForReads(t *testing.T) {
+	reading := make(chan struct{})
+	closed := make(chan struct{})
+	mockConn := &MockWebSocketConn{
+		ReadMessageFunc: func(ctx context.Context) (MessageType, []byte, error) {
+			close(reading)
+			<-closed
+			return 0, nil, errors.New("use of closed network connection")
+		},
+		CloseFunc: func() error {
+			close(closed)
+			return nil
+		},
+	}
+	conn := NewConn(mockConn)
+
+	readDone := make(chan error, 1)
+	go func() {
+		_, _, err := conn.ReadRaw(context.Background())
+		readDone <- err
+	}()
+	<-reading
+
+	// A blocked read must not hold up setters, writes or Close
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		conn.SetLogger(nil)
+		conn.SetMetricsHook(nil)
+		_ = conn.SendRaw(context.Background(), MessageText, []byte("{}"))
+		_ = conn.Close()
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the connection to be usable while a read is blocked")
+	}
+	if err := <-readDone; err == nil {
+		t.Error("Expected the read to fail once the connection was closed")
+	}
+}
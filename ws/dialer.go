@@ -3,7 +3,10 @@ package ws
 
 import (
 	"context"
+	"errors"
+	"net"
 	"net/http"
+	"sync"
 )
 
 // WebSocketDialer is the interface for WebSocket dialers.
@@ -23,6 +26,16 @@ type DialerOptions struct {
 	// If set to 0 or negative, the underlying implementation will use its default
 	// For Gorilla WebSocket, this means -1 (no limit)
 	ReadLimit int64
+
+	// Subprotocols are offered to the server during the handshake
+	Subprotocols []string
+
+	// NetDialContext creates the underlying network connection.
+	// If nil, the connection is dialed over TCP using the host in the URL.
+	NetDialContext func(ctx context.Context, network, addr string) (net.Conn, error)
+
+	// MetricsHook receives a ConnEventDialFailure event when dialing fails
+	MetricsHook MetricsHook
 }
 
 // DefaultDialer returns a default WebSocket dialer
@@ -37,6 +50,27 @@ func DirectDialer(options DialerOptions) WebSocketDialer {
 	// Pass the ReadLimit directly to the Gorilla implementation
 	// The Gorilla implementation will handle the default value if ReadLimit <= 0
 	return NewGorillaWebSocketDialer(GorillaWebSocketOptions{
-		ReadLimit: options.ReadLimit,
+		ReadLimit:      options.ReadLimit,
+		Subprotocols:   options.Subprotocols,
+		NetDialContext: options.NetDialContext,
+		MetricsHook:    options.MetricsHook,
 	})
 }
+
+// ErrNetConnUsed is returned when a pre-established connection is dialed more than once
+var ErrNetConnUsed = errors.New("pre-established connection already used")
+
+// NetConnDialContext returns a NetDialContext function that hands out the given
+// pre-established connection, such as one end of a net.Pipe or a tunneled stream.
+// The connection can only be used for a single dial; later calls return ErrNetConnUsed.
+func NetConnDialContext(conn net.Conn) func(ctx context.Context, network, addr string) (net.Conn, error) {
+	var once sync.Once
+	return func(ctx context.Context, network, addr string) (net.Conn, error) {
+		used := true
+		once.Do(func() { used = false })
+		if used {
+			return nil, ErrNetConnUsed
+		}
+		return conn, nil
+	}
+}
@@ -0,0 +1,101 @@
+package ws
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+// pipeListener is a net.Listener that accepts a single pre-established connection
+type pipeListener struct {
+	conns chan net.Conn
+	once  sync.Once
+	done  chan struct{}
+}
+
+func newPipeListener(conn net.Conn) *pipeListener {
+	l := &pipeListener{conns: make(chan net.Conn, 1), done: make(chan struct{})}
+	l.conns <- conn
+	return l
+}
+
+func (l *pipeListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.done:
+		return nil, net.ErrClosed
+	}
+}
+
+func (l *pipeListener) Close() error {
+	l.once.Do(func() { close(l.done) })
+	return nil
+}
+
+func (l *pipeListener) Addr() net.Addr {
+	return &net.UnixAddr{Name: "pipe", Net: "unix"}
+}
+
+func TestDirectDialerNetConn(t *testing.T) {
+	clientConn, serverConn := net.Pipe()
+
+	upgrader := websocket.Upgrader{}
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		messageType, data, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		_ = conn.WriteMessage(messageType, data)
+	})}
+	listener := newPipeListener(serverConn)
+	go func() { _ = server.Serve(listener) }()
+	defer server.Close()
+
+	dialer := DirectDialer(DialerOptions{NetDialContext: NetConnDialContext(clientConn)})
+	conn, err := dialer.Dial(context.Background(), "ws://realtime.invalid/v1/realtime", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer conn.Close()
+
+	if err := conn.WriteMessage(context.Background(), MessageText, []byte("ping")); err != nil {
+		t.Fatalf("Unexpected write error: %v", err)
+	}
+	_, data, err := conn.ReadMessage(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected read error: %v", err)
+	}
+	if string(data) != "ping" {
+		t.Errorf("Expected echoed message %q, got %q", "ping", string(data))
+	}
+}
+
+func TestNetConnDialContextSingleUse(t *testing.T) {
+	clientConn, serverConn := net.Pipe()
+	defer clientConn.Close()
+	defer serverConn.Close()
+
+	dial := NetConnDialContext(clientConn)
+	conn, err := dial(context.Background(), "tcp", "realtime.invalid:80")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if conn != clientConn {
+		t.Errorf("Expected the pre-established connection to be returned")
+	}
+
+	if _, err := dial(context.Background(), "tcp", "realtime.invalid:80"); !errors.Is(err, ErrNetConnUsed) {
+		t.Errorf("Expected ErrNetConnUsed, got %v", err)
+	}
+}
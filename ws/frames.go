@@ -0,0 +1,99 @@
+package ws
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrFrameTooLarge is wrapped by FrameTooLargeError
+var ErrFrameTooLarge = errors.New("frame exceeds read limit")
+
+// sniffLimit is how much of an oversized frame is kept to identify its event type
+const sniffLimit = 4 * 1024
+
+// FrameTooLargeError is returned by ReadMessage when a message exceeds the read limit.
+// The rest of the message is read and discarded, so the connection stays usable and
+// the next read returns the following message.
+type FrameTooLargeError struct {
+	// EventType is the type of the event, or empty if it could not be identified from
+	// the start of the message
+	EventType string
+
+	// Size is the size of the whole message in bytes
+	Size int64
+
+	// Limit is the read limit in bytes
+	Limit int64
+}
+
+// Error implements the error interface
+func (e *FrameTooLargeError) Error() string {
+	eventType := e.EventType
+	if eventType == "" {
+		eventType = "unknown"
+	}
+	return fmt.Sprintf("%s event of %d bytes exceeds the read limit of %d bytes", eventType, e.Size, e.Limit)
+}
+
+// Unwrap returns ErrFrameTooLarge
+func (e *FrameTooLargeError) Unwrap() error {
+	return ErrFrameTooLarge
+}
+
+// readFrame reads a message of at most limit bytes from r; a limit of 0 or less means
+// no limit. A larger message is drained and reported as a *FrameTooLargeError.
+func readFrame(r io.Reader, limit int64) ([]byte, error) {
+	if limit <= 0 {
+		return io.ReadAll(r)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) <= limit {
+		return data, nil
+	}
+
+	tooLarge := &FrameTooLargeError{EventType: SniffEventType(data[:min(len(data), sniffLimit)]), Limit: limit}
+	rest, err := io.Copy(io.Discard, r)
+	tooLarge.Size = int64(len(data)) + rest
+	if err != nil {
+		return nil, fmt.Errorf("failed to discard %w: %w", tooLarge, err)
+	}
+	return nil, tooLarge
+}
+
+// SniffEventType returns the value of the top-level "type" field of a JSON event from
+// the start of the event, without needing the rest of it. It returns an empty string
+// if the type does not appear in prefix.
+func SniffEventType(prefix []byte) string {
+	dec := json.NewDecoder(bytes.NewReader(prefix))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return ""
+	}
+
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return ""
+		}
+		if key == "type" {
+			var eventType string
+			if err := dec.Decode(&eventType); err != nil {
+				return ""
+			}
+			return eventType
+		}
+
+		// Skip the value of other fields
+		var skip json.RawMessage
+		if err := dec.Decode(&skip); err != nil {
+			return ""
+		}
+	}
+	return ""
+}
@@ -0,0 +1,89 @@
+package ws
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestSniffEventType(t *testing.T) {
+	tests := []struct {
+		name     string
+		prefix   string
+		expected string
+	}{
+		{name: "type first", prefix: `{"type":"response.done","response":{"output":[`, expected: "response.done"},
+		{name: "type after other fields", prefix: `{"event_id":"evt_1","meta":{"type":"nested"},"type":"response.done","response":`, expected: "response.done"},
+		{name: "type cut off", prefix: `{"response":{"output":[{"text":"`},
+		{name: "not an object", prefix: `["type"]`},
+		{name: "not json", prefix: `type`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SniffEventType([]byte(tt.prefix)); got != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestReadLimitDiscardsLargeFrame(t *testing.T) {
+	clientConn, serverConn := net.Pipe()
+	large := `{"type":"response.done","response":{"output":"` + strings.Repeat("x", 2048) + `"}}`
+
+	upgrader := websocket.Upgrader{}
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_ = conn.WriteMessage(websocket.TextMessage, []byte(large))
+		_ = conn.WriteMessage(websocket.TextMessage, []byte(`{"type":"session.updated"}`))
+		_, _, _ = conn.ReadMessage()
+	})}
+	listener := newPipeListener(serverConn)
+	go func() { _ = server.Serve(listener) }()
+	defer server.Close()
+
+	counters := NewConnCounters()
+	dialer := DirectDialer(DialerOptions{ReadLimit: 1024, NetDialContext: NetConnDialContext(clientConn)})
+	wsConn, err := dialer.Dial(context.Background(), "ws://realtime.invalid/v1/realtime", nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	conn := NewConn(wsConn)
+	conn.SetMetricsHook(counters)
+	defer conn.Close()
+
+	_, _, err = conn.ReadRaw(context.Background())
+	var tooLarge *FrameTooLargeError
+	if !errors.As(err, &tooLarge) || !errors.Is(err, ErrFrameTooLarge) {
+		t.Fatalf("Expected a FrameTooLargeError, got %v", err)
+	}
+	if tooLarge.EventType != "response.done" || tooLarge.Size != int64(len(large)) || tooLarge.Limit != 1024 {
+		t.Errorf("Expected a response.done of %d bytes over 1024, got %+v", len(large), tooLarge)
+	}
+
+	// The connection is still usable
+	_, data, err := conn.ReadRaw(context.Background())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(data) != `{"type":"session.updated"}` {
+		t.Errorf("Expected the next message, got %s", data)
+	}
+
+	if got := counters.Count(ConnEventFrameTooLarge, "response.done"); got != 1 {
+		t.Errorf("Expected 1 oversized frame, got %d", got)
+	}
+	if got := counters.Total(ConnEventAbnormalClose); got != 0 {
+		t.Errorf("Expected no abnormal closes, got %d", got)
+	}
+}
@@ -3,6 +3,7 @@ package ws
 import (
 	"context"
 	"errors"
+	"net"
 	"net/http"
 	"time"
 
@@ -32,9 +33,19 @@ var (
 // GorillaWebSocketOptions is the options for GorillaWebSocketConn.
 type GorillaWebSocketOptions struct {
 	// ReadLimit is the maximum size of a message in bytes. -1 means no limit. Default is -1.
+	// A larger message is discarded and ReadMessage returns a *FrameTooLargeError
+	// naming its event type and size; the connection stays open.
 	ReadLimit int64
 	// Dialer is the websocket dialer to use. If nil, websocket.DefaultDialer will be used.
 	Dialer *websocket.Dialer
+	// Subprotocols are offered to the server during the handshake. If set, they replace
+	// the Subprotocols of the Dialer.
+	Subprotocols []string
+	// NetDialContext creates the underlying network connection. If set, it replaces
+	// the NetDialContext of the Dialer.
+	NetDialContext func(ctx context.Context, network, addr string) (net.Conn, error)
+	// MetricsHook receives a ConnEventDialFailure event when dialing fails. Optional.
+	MetricsHook MetricsHook
 }
 
 // GorillaWebSocketDialer is a WebSocket dialer implementation based on gorilla/websocket.
@@ -59,19 +70,32 @@ func (d *GorillaWebSocketDialer) Dial(ctx context.Context, url string, header ht
 	if dialer == nil {
 		dialer = websocket.DefaultDialer
 	}
+	if len(d.options.Subprotocols) > 0 || d.options.NetDialContext != nil {
+		custom := *dialer
+		if len(d.options.Subprotocols) > 0 {
+			custom.Subprotocols = d.options.Subprotocols
+		}
+		if d.options.NetDialContext != nil {
+			custom.NetDialContext = d.options.NetDialContext
+		}
+		dialer = &custom
+	}
 
 	conn, resp, err := dialer.DialContext(ctx, url, header)
 	if err != nil {
 		if resp != nil && resp.Body != nil {
 			_ = resp.Body.Close()
 		}
+		if d.options.MetricsHook != nil {
+			d.options.MetricsHook.RecordConnEvent(ConnEvent{
+				Kind:   ConnEventDialFailure,
+				Reason: DialFailureReason(err),
+				Err:    err,
+			})
+		}
 		return nil, err
 	}
 
-	if d.options.ReadLimit > 0 {
-		conn.SetReadLimit(d.options.ReadLimit)
-	}
-
 	return &GorillaWebSocketConn{conn: conn, resp: resp, options: d.options}, nil
 }
 
@@ -95,7 +119,13 @@ func (c *GorillaWebSocketConn) ReadMessage(ctx context.Context) (MessageType, []
 	}()
 	defer close(done)
 
-	messageType, data, err := c.conn.ReadMessage()
+	// The limit is applied here rather than by gorilla, which closes the connection
+	// without saying which message was too large
+	messageType, r, err := c.conn.NextReader()
+	if err != nil {
+		return 0, nil, err
+	}
+	data, err := readFrame(r, c.options.ReadLimit)
 	if err != nil {
 		return 0, nil, err
 	}
@@ -137,17 +167,25 @@ func (c *GorillaWebSocketConn) Close() error {
 
 // Ping sends a ping message to the WebSocket connection.
 func (c *GorillaWebSocketConn) Ping(ctx context.Context) error {
-	// Set up context cancellation
+	// Set up context cancellation. Keep-alive cancels the context as soon as Ping
+	// returns, so a cancellation seen after done is closed must not close the connection.
 	done := make(chan struct{})
 	go func() {
 		select {
 		case <-ctx.Done():
-			c.conn.Close()
+			select {
+			case <-done:
+			default:
+				c.conn.Close()
+			}
 		case <-done:
 		}
 	}()
 	defer close(done)
 
 	deadline := time.Now().Add(59 * time.Second)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
 	return c.conn.WriteControl(websocket.PingMessage, []byte{}, deadline)
 }
@@ -3,6 +3,7 @@ package ws
 import (
 	"context"
 	"errors"
+	"fmt"
 	"net"
 	"strings"
 
@@ -48,7 +49,7 @@ func (c *ConnHandler) Start() {
 		c.conn.logger.Debugf("Starting connection handler")
 	}
 	go func() {
-		err := c.run()
+		err := c.runRecovered()
 		if err != nil {
 			if c.conn.logger != nil {
 				c.conn.logger.Errorf("Connection handler exited with error: %v", err)
@@ -67,6 +68,8 @@ func (c *ConnHandler) Start() {
 // This could be used to wait for the goroutine to exit.
 // If you don't need to wait for the goroutine to exit, there's no need to call this.
 // This must be called after the connection is closed, otherwise it will block indefinitely.
+// A panic that escapes the read loop, rather than a handler, stops it and is reported
+// as a *PanicError.
 func (c *ConnHandler) Err() <-chan error {
 	return c.errCh
 }
@@ -93,6 +96,17 @@ func (c *ConnHandler) Stop() {
 	}
 }
 
+// runRecovered runs the read loop, returning a panic that escapes it, for example from
+// the metrics hook, as a *PanicError
+func (c *ConnHandler) runRecovered() (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = c.conn.recovered("read loop", r)
+		}
+	}()
+	return c.run()
+}
+
 func (c *ConnHandler) run() error {
 	if c.conn.logger != nil {
 		c.conn.logger.Debugf("Connection handler running")
@@ -130,6 +144,14 @@ func (c *ConnHandler) run() error {
 				return permanentErr.Err
 			}
 
+			// An oversized message was discarded; the next one can still be read
+			if tooLarge, ok := err.(*FrameTooLargeError); ok {
+				if c.conn.logger != nil {
+					c.conn.logger.Errorf("Discarded message: %v", tooLarge)
+				}
+				continue
+			}
+
 			// Special cases for network errors
 			var netErr net.Error
 			if errors.As(err, &netErr) {
@@ -173,9 +195,7 @@ func (c *ConnHandler) run() error {
 			func() {
 				defer func() {
 					if r := recover(); r != nil {
-						if c.conn.logger != nil {
-							c.conn.logger.Errorf("Handler %d panicked: %v", i, r)
-						}
+						c.conn.recovered(fmt.Sprintf("handler %d", i), r)
 					}
 				}()
 				handler(c.ctx, messageType, data)
@@ -0,0 +1,111 @@
+package ws
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/clock"
+)
+
+//-----------------------------------------------------------------------------
+// Keep-Alive
+//-----------------------------------------------------------------------------
+
+// SetClock sets the clock that times keep-alive pings. If nil, the system clock is used.
+func (c *Conn) SetClock(clk clock.Clock) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.clock = clk
+}
+
+// StartKeepAlive pings the server every interval until the connection is closed, so
+// long-lived sessions, including transcription sessions that can sit idle between
+// utterances, are not dropped by proxies and load balancers with idle timeouts.
+//
+// A ping that fails, or does not complete within timeout, closes the connection, so a
+// blocked read returns an error and a Handler or Supervisor can react to the lost
+// connection. The failure is reported to the metrics hook as ConnEventKeepAliveFailure.
+// A zero timeout uses the interval. StartKeepAlive does nothing if interval is not
+// positive or a keep-alive is already running.
+func (c *Conn) StartKeepAlive(interval, timeout time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	if timeout <= 0 {
+		timeout = interval
+	}
+
+	c.mu.Lock()
+	if c.keepAlive {
+		c.mu.Unlock()
+		return
+	}
+	c.keepAlive = true
+	clk := c.clock
+	c.mu.Unlock()
+
+	if clk == nil {
+		clk = clock.Real()
+	}
+	go c.runKeepAlive(clk, interval, timeout)
+}
+
+// runKeepAlive pings the server until the connection is closed or a ping fails
+func (c *Conn) runKeepAlive(clk clock.Clock, interval, timeout time.Duration) {
+	defer func() {
+		if r := recover(); r != nil {
+			c.recovered("keep-alive", r)
+		}
+	}()
+
+	timer := clk.NewTimer(interval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-timer.C():
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		err := c.Ping(ctx)
+		cancel()
+		if err == nil {
+			timer.Reset(interval)
+			continue
+		}
+		if c.isClosed() {
+			return
+		}
+
+		// The connection is closed even if reporting the failure panics
+		defer func() { _ = c.Close() }()
+
+		reason := ReasonOther
+		if errors.Is(err, context.DeadlineExceeded) {
+			reason = ReasonTimeout
+		}
+		c.mu.RLock()
+		log, metrics := c.logger, c.metrics
+		c.mu.RUnlock()
+		if log != nil {
+			log.Warnf("keep-alive ping failed, closing connection: %v", err)
+		}
+		if metrics != nil {
+			metrics.RecordConnEvent(ConnEvent{Kind: ConnEventKeepAliveFailure, Reason: reason, Err: err})
+		}
+		return
+	}
+}
+
+// isClosed reports whether Close has been called
+func (c *Conn) isClosed() bool {
+	select {
+	case <-c.done:
+		return true
+	default:
+		return false
+	}
+}
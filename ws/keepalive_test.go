@@ -0,0 +1,114 @@
+package ws
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/clock"
+)
+
+func TestKeepAlive(t *testing.T) {
+	pings := make(chan struct{})
+	conn := NewConn(&MockWebSocketConn{
+		PingFunc: func(ctx context.Context) error {
+			pings <- struct{}{}
+			return nil
+		},
+	})
+	fake := clock.NewFake(time.Unix(0, 0))
+	conn.SetClock(fake)
+	conn.StartKeepAlive(time.Second, 0)
+	conn.StartKeepAlive(time.Second, 0)
+
+	for i := 0; i < 3; i++ {
+		fake.BlockUntil(1)
+		if fake.Timers() != 1 {
+			t.Fatalf("Expected a single keep-alive timer, got %d", fake.Timers())
+		}
+		fake.Advance(999 * time.Millisecond)
+		select {
+		case <-pings:
+			t.Fatal("Expected no ping before the interval elapses")
+		default:
+		}
+		fake.Advance(time.Millisecond)
+		select {
+		case <-pings:
+		case <-time.After(time.Second):
+			t.Fatalf("Expected ping %d after the interval", i+1)
+		}
+	}
+
+	fake.BlockUntil(1)
+	if err := conn.Close(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	deadline := time.Now().Add(time.Second)
+	for fake.Timers() != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("Expected the keep-alive to stop once the connection is closed")
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestKeepAliveFailureClosesConnection(t *testing.T) {
+	closed := make(chan struct{})
+	counters := NewConnCounters()
+	conn := NewConn(&MockWebSocketConn{
+		PingFunc: func(ctx context.Context) error {
+			return errors.New("broken pipe")
+		},
+		CloseFunc: func() error {
+			close(closed)
+			return nil
+		},
+	})
+	fake := clock.NewFake(time.Unix(0, 0))
+	conn.SetClock(fake)
+	conn.SetMetricsHook(counters)
+	conn.StartKeepAlive(time.Second, time.Second)
+
+	fake.BlockUntil(1)
+	fake.Advance(time.Second)
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("Expected a failed ping to close the connection")
+	}
+	if got := counters.Count(ConnEventKeepAliveFailure, ReasonOther); got != 1 {
+		t.Errorf("Expected 1 keep-alive failure, got %v", counters.Snapshot())
+	}
+}
+
+func TestKeepAlivePingTimeout(t *testing.T) {
+	closed := make(chan struct{})
+	counters := NewConnCounters()
+	conn := NewConn(&MockWebSocketConn{
+		PingFunc: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+		CloseFunc: func() error {
+			close(closed)
+			return nil
+		},
+	})
+	fake := clock.NewFake(time.Unix(0, 0))
+	conn.SetClock(fake)
+	conn.SetMetricsHook(counters)
+	conn.StartKeepAlive(time.Second, time.Millisecond)
+
+	fake.BlockUntil(1)
+	fake.Advance(time.Second)
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("Expected a ping timeout to close the connection")
+	}
+	if got := counters.Count(ConnEventKeepAliveFailure, ReasonTimeout); got != 1 {
+		t.Errorf("Expected 1 keep-alive timeout, got %v", counters.Snapshot())
+	}
+}
@@ -0,0 +1,180 @@
+package ws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+
+	"github.com/gorilla/websocket"
+)
+
+// ConnEventKind identifies a connection lifecycle event reported to a MetricsHook
+type ConnEventKind string
+
+const (
+	// ConnEventDialFailure is reported when establishing a connection fails
+	ConnEventDialFailure ConnEventKind = "dial_failure"
+	// ConnEventAbnormalClose is reported when an established connection ends without a normal close
+	ConnEventAbnormalClose ConnEventKind = "abnormal_close"
+	// ConnEventReconnectAttempt is reported by reconnect loops before each new dial
+	ConnEventReconnectAttempt ConnEventKind = "reconnect_attempt"
+	// ConnEventReconnectGiveUp is reported by reconnect loops when they stop retrying
+	ConnEventReconnectGiveUp ConnEventKind = "reconnect_give_up"
+	// ConnEventCircuitOpen is reported when a circuit breaker opens after consecutive failures
+	ConnEventCircuitOpen ConnEventKind = "circuit_open"
+	// ConnEventCircuitHalfOpen is reported when a circuit breaker lets a probe connection through
+	ConnEventCircuitHalfOpen ConnEventKind = "circuit_half_open"
+	// ConnEventCircuitClosed is reported when a circuit breaker closes after a successful probe
+	ConnEventCircuitClosed ConnEventKind = "circuit_closed"
+	// ConnEventFrameTooLarge is reported when a message exceeding the read limit is
+	// discarded; the reason is its event type
+	ConnEventFrameTooLarge ConnEventKind = "frame_too_large"
+	// ConnEventKeepAliveFailure is reported when a keep-alive ping fails and the
+	// connection is closed
+	ConnEventKeepAliveFailure ConnEventKind = "keepalive_failure"
+)
+
+// Reasons reported with connection events that are not close codes
+const (
+	ReasonCanceled      = "canceled"
+	ReasonTimeout       = "timeout"
+	ReasonHandshake     = "handshake"
+	ReasonDNS           = "dns"
+	ReasonNetwork       = "network"
+	ReasonUnexpectedEOF = "unexpected_eof"
+	ReasonOther         = "other"
+)
+
+// ConnEvent describes a connection lifecycle event
+type ConnEvent struct {
+	// Kind is the type of event
+	Kind ConnEventKind
+	// Reason is a low-cardinality label for the cause, suitable for metric tags
+	// (e.g. "timeout", "handshake" or a close code such as "close_1011")
+	Reason string
+	// Attempt is the reconnect attempt number, starting at 1; zero for other events
+	Attempt int
+	// Err is the underlying error, if any
+	Err error
+}
+
+// MetricsHook receives connection telemetry.
+// Implementations must be safe for concurrent use and should not block.
+type MetricsHook interface {
+	RecordConnEvent(event ConnEvent)
+}
+
+// MetricsHookFunc adapts a function to the MetricsHook interface
+type MetricsHookFunc func(event ConnEvent)
+
+// RecordConnEvent calls f(event)
+func (f MetricsHookFunc) RecordConnEvent(event ConnEvent) {
+	f(event)
+}
+
+// ConnCounters is a MetricsHook that counts events by kind and reason.
+// It is useful for exporting counters to a metrics system or asserting on churn in tests.
+type ConnCounters struct {
+	mu     sync.Mutex
+	counts map[ConnEventKind]map[string]int
+}
+
+// NewConnCounters creates an empty ConnCounters
+func NewConnCounters() *ConnCounters {
+	return &ConnCounters{counts: make(map[ConnEventKind]map[string]int)}
+}
+
+// RecordConnEvent increments the counter for the event's kind and reason
+func (c *ConnCounters) RecordConnEvent(event ConnEvent) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	reasons, ok := c.counts[event.Kind]
+	if !ok {
+		reasons = make(map[string]int)
+		c.counts[event.Kind] = reasons
+	}
+	reasons[event.Reason]++
+}
+
+// Count returns the number of events recorded with the given kind and reason
+func (c *ConnCounters) Count(kind ConnEventKind, reason string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.counts[kind][reason]
+}
+
+// Total returns the number of events recorded with the given kind across all reasons
+func (c *ConnCounters) Total(kind ConnEventKind) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	total := 0
+	for _, n := range c.counts[kind] {
+		total += n
+	}
+	return total
+}
+
+// Snapshot returns a copy of all counters keyed by kind and reason
+func (c *ConnCounters) Snapshot() map[ConnEventKind]map[string]int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	snapshot := make(map[ConnEventKind]map[string]int, len(c.counts))
+	for kind, reasons := range c.counts {
+		copied := make(map[string]int, len(reasons))
+		for reason, n := range reasons {
+			copied[reason] = n
+		}
+		snapshot[kind] = copied
+	}
+	return snapshot
+}
+
+// DialFailureReason classifies a dial error into a low-cardinality reason
+func DialFailureReason(err error) string {
+	var dnsErr *net.DNSError
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.Canceled):
+		return ReasonCanceled
+	case errors.Is(err, context.DeadlineExceeded):
+		return ReasonTimeout
+	case errors.Is(err, websocket.ErrBadHandshake):
+		return ReasonHandshake
+	case errors.As(err, &dnsErr):
+		return ReasonDNS
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return ReasonTimeout
+	case errors.As(err, &netErr):
+		return ReasonNetwork
+	default:
+		return ReasonOther
+	}
+}
+
+// AbnormalCloseReason classifies a read error from an established connection.
+// It returns false for normal closes (1000 and 1001), for connections closed locally and
+// for discarded oversized messages, which are not abnormal.
+func AbnormalCloseReason(err error) (string, bool) {
+	var closeErr *websocket.CloseError
+	_, tooLarge := err.(*FrameTooLargeError)
+	switch {
+	case err == nil, errors.Is(err, net.ErrClosed), errors.Is(err, context.Canceled):
+		return "", false
+	case tooLarge:
+		// The message was discarded and the connection is still open; a failure to
+		// discard it is wrapped and classified below
+		return "", false
+	case errors.As(err, &closeErr):
+		if closeErr.Code == websocket.CloseNormalClosure || closeErr.Code == websocket.CloseGoingAway {
+			return "", false
+		}
+		return fmt.Sprintf("close_%d", closeErr.Code), true
+	case errors.Is(err, io.ErrUnexpectedEOF), errors.Is(err, io.EOF):
+		return ReasonUnexpectedEOF, true
+	default:
+		return ReasonNetwork, true
+	}
+}
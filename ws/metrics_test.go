@@ -0,0 +1,148 @@
+package ws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/gorilla/websocket"
+)
+
+func TestDialFailureReason(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+	}{
+		{name: "canceled", err: context.Canceled, expected: ReasonCanceled},
+		{name: "deadline", err: fmt.Errorf("dial: %w", context.DeadlineExceeded), expected: ReasonTimeout},
+		{name: "handshake", err: websocket.ErrBadHandshake, expected: ReasonHandshake},
+		{name: "dns", err: &net.DNSError{Err: "no such host", Name: "realtime.invalid"}, expected: ReasonDNS},
+		{name: "network", err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}, expected: ReasonNetwork},
+		{name: "other", err: errors.New("boom"), expected: ReasonOther},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DialFailureReason(tt.err); got != tt.expected {
+				t.Errorf("Expected reason %q, got %q", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestAbnormalCloseReason(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected string
+		abnormal bool
+	}{
+		{name: "normal close", err: &websocket.CloseError{Code: websocket.CloseNormalClosure}},
+		{name: "going away", err: &websocket.CloseError{Code: websocket.CloseGoingAway}},
+		{name: "local close", err: fmt.Errorf("read: %w", net.ErrClosed)},
+		{name: "frame too large", err: &FrameTooLargeError{EventType: "response.done", Size: 2048, Limit: 1024}},
+		{name: "server error", err: &websocket.CloseError{Code: websocket.CloseInternalServerErr}, expected: "close_1011", abnormal: true},
+		{name: "abnormal closure", err: &websocket.CloseError{Code: websocket.CloseAbnormalClosure}, expected: "close_1006", abnormal: true},
+		{name: "eof", err: io.ErrUnexpectedEOF, expected: ReasonUnexpectedEOF, abnormal: true},
+		{name: "network", err: errors.New("connection reset by peer"), expected: ReasonNetwork, abnormal: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason, abnormal := AbnormalCloseReason(tt.err)
+			if abnormal != tt.abnormal {
+				t.Errorf("Expected abnormal %v, got %v", tt.abnormal, abnormal)
+			}
+			if reason != tt.expected {
+				t.Errorf("Expected reason %q, got %q", tt.expected, reason)
+			}
+		})
+	}
+}
+
+func TestConnReportsAbnormalCloseOnce(t *testing.T) {
+	counters := NewConnCounters()
+	conn := NewConn(&MockWebSocketConn{
+		ReadMessageFunc: func(ctx context.Context) (MessageType, []byte, error) {
+			return 0, nil, &websocket.CloseError{Code: websocket.CloseInternalServerErr}
+		},
+	})
+	conn.SetMetricsHook(counters)
+
+	for i := 0; i < 3; i++ {
+		if _, _, err := conn.ReadRaw(context.Background()); err == nil {
+			t.Fatal("Expected read error")
+		}
+	}
+
+	if got := counters.Count(ConnEventAbnormalClose, "close_1011"); got != 1 {
+		t.Errorf("Expected 1 abnormal close, got %d", got)
+	}
+}
+
+func TestConnDoesNotReportNormalClose(t *testing.T) {
+	counters := NewConnCounters()
+	conn := NewConn(&MockWebSocketConn{
+		ReadMessageFunc: func(ctx context.Context) (MessageType, []byte, error) {
+			return 0, nil, &websocket.CloseError{Code: websocket.CloseNormalClosure}
+		},
+	})
+	conn.SetMetricsHook(counters)
+
+	_, _, _ = conn.ReadRaw(context.Background())
+
+	if got := counters.Total(ConnEventAbnormalClose); got != 0 {
+		t.Errorf("Expected no abnormal closes, got %d", got)
+	}
+}
+
+func TestDialerReportsDialFailure(t *testing.T) {
+	var events []ConnEvent
+	hook := MetricsHookFunc(func(event ConnEvent) {
+		events = append(events, event)
+	})
+	dialer := DirectDialer(DialerOptions{
+		MetricsHook: hook,
+		NetDialContext: func(ctx context.Context, network, addr string) (net.Conn, error) {
+			return nil, &net.OpError{Op: "dial", Net: network, Err: errors.New("connection refused")}
+		},
+	})
+
+	if _, err := dialer.Dial(context.Background(), "ws://realtime.invalid/v1/realtime", nil); err == nil {
+		t.Fatal("Expected dial error")
+	}
+
+	if len(events) != 1 {
+		t.Fatalf("Expected 1 event, got %d", len(events))
+	}
+	if events[0].Kind != ConnEventDialFailure {
+		t.Errorf("Expected kind %q, got %q", ConnEventDialFailure, events[0].Kind)
+	}
+	if events[0].Reason != ReasonNetwork {
+		t.Errorf("Expected reason %q, got %q", ReasonNetwork, events[0].Reason)
+	}
+}
+
+func TestConnCountersSnapshot(t *testing.T) {
+	counters := NewConnCounters()
+	counters.RecordConnEvent(ConnEvent{Kind: ConnEventReconnectAttempt, Reason: ReasonNetwork, Attempt: 1})
+	counters.RecordConnEvent(ConnEvent{Kind: ConnEventReconnectAttempt, Reason: ReasonNetwork, Attempt: 2})
+	counters.RecordConnEvent(ConnEvent{Kind: ConnEventReconnectGiveUp, Reason: ReasonTimeout})
+
+	snapshot := counters.Snapshot()
+	if snapshot[ConnEventReconnectAttempt][ReasonNetwork] != 2 {
+		t.Errorf("Expected 2 reconnect attempts, got %d", snapshot[ConnEventReconnectAttempt][ReasonNetwork])
+	}
+	if counters.Total(ConnEventReconnectGiveUp) != 1 {
+		t.Errorf("Expected 1 give-up, got %d", counters.Total(ConnEventReconnectGiveUp))
+	}
+
+	snapshot[ConnEventReconnectAttempt][ReasonNetwork] = 10
+	if counters.Count(ConnEventReconnectAttempt, ReasonNetwork) != 2 {
+		t.Error("Expected snapshot to be a copy")
+	}
+}
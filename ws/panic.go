@@ -0,0 +1,86 @@
+package ws
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+//-----------------------------------------------------------------------------
+// Panic Recovery
+//-----------------------------------------------------------------------------
+
+// PanicError is a panic recovered from a callback run by a background goroutine, such as
+// a message handler called by the read loop or a metrics hook called by the keep-alive
+type PanicError struct {
+	// Where names the goroutine or callback that panicked, such as "handler 2"
+	Where string
+	// Value is the value passed to panic
+	Value any
+	// Stack is the stack trace of the goroutine at the time of the panic
+	Stack []byte
+}
+
+// NewPanicError creates a PanicError for a value returned by recover, capturing the
+// current stack. It must be called from the deferred function that recovered.
+func NewPanicError(where string, value any) *PanicError {
+	return &PanicError{Where: where, Value: value, Stack: debug.Stack()}
+}
+
+// Error returns a description of the panic
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic in %s: %v", e.Where, e.Value)
+}
+
+// Unwrap returns the panic value if it is an error
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// PanicHandler is called with the panics recovered from background goroutines. It is
+// called on the goroutine that panicked and must not block.
+type PanicHandler func(err *PanicError)
+
+// SetPanicHandler sets the function that is called with panics recovered from the
+// callbacks run by the connection's background goroutines: the raw message handlers of a
+// ConnHandler and the metrics hook and logger called by the keep-alive. Panics are logged
+// either way, and the goroutine carries on unless SetRethrowPanics is enabled. If nil,
+// panics are only logged.
+func (c *Conn) SetPanicHandler(onPanic PanicHandler) {
+	c.panicMu.Lock()
+	defer c.panicMu.Unlock()
+	c.onPanic = onPanic
+}
+
+// SetRethrowPanics makes recovered panics panic again once they have been logged and
+// passed to the panic handler, crashing the process as if they had not been recovered.
+// This is useful in tests and development to fail fast on bugs in callbacks.
+func (c *Conn) SetRethrowPanics(rethrow bool) {
+	c.panicMu.Lock()
+	defer c.panicMu.Unlock()
+	c.rethrowPanics = rethrow
+}
+
+// recovered reports a value returned by recover and panics again if configured to.
+// It must be called from the deferred function that recovered.
+func (c *Conn) recovered(where string, value any) *PanicError {
+	err := NewPanicError(where, value)
+
+	c.mu.RLock()
+	log := c.logger
+	c.mu.RUnlock()
+	c.panicMu.Lock()
+	onPanic, rethrow := c.onPanic, c.rethrowPanics
+	c.panicMu.Unlock()
+
+	if log != nil {
+		log.Errorf("Recovered %v", err)
+	}
+	if onPanic != nil {
+		onPanic(err)
+	}
+	if rethrow {
+		panic(value)
+	}
+	return err
+}
@@ -0,0 +1,133 @@
+package ws
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/clock"
+)
+
+func TestConnHandlerRecoversHandlerPanics(t *testing.T) {
+	reads := 0
+	conn := NewConn(&MockWebSocketConn{
+		ReadMessageFunc: func(ctx context.Context) (MessageType, []byte, error) {
+			reads++
+			if reads > 2 {
+				<-ctx.Done()
+				return 0, nil, ctx.Err()
+			}
+			return MessageText, []byte(`{}`), nil
+		},
+	})
+	panics := make(chan *PanicError, 2)
+	conn.SetPanicHandler(func(err *PanicError) { panics <- err })
+
+	handled := make(chan struct{}, 2)
+	handler := NewConnHandler(context.Background(), conn,
+		func(ctx context.Context, messageType MessageType, data []byte) { panic("boom") },
+		func(ctx context.Context, messageType MessageType, data []byte) { handled <- struct{}{} },
+	)
+	handler.Start()
+	defer handler.Stop()
+
+	for i := 0; i < 2; i++ {
+		select {
+		case err := <-panics:
+			if err.Where != "handler 0" || err.Value != "boom" || len(err.Stack) == 0 {
+				t.Errorf("Unexpected panic error %+v", err)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Expected the panic to be reported")
+		}
+		select {
+		case <-handled:
+		case <-time.After(time.Second):
+			t.Fatal("Expected the next handler to be called after a panic")
+		}
+	}
+}
+
+func TestConnHandlerReportsReadLoopPanic(t *testing.T) {
+	conn := NewConn(&MockWebSocketConn{
+		ReadMessageFunc: func(ctx context.Context) (MessageType, []byte, error) {
+			return 0, nil, errors.New("websocket: close 1006 (abnormal closure)")
+		},
+	})
+	failure := errors.New("metrics backend down")
+	conn.SetMetricsHook(MetricsHookFunc(func(event ConnEvent) { panic(failure) }))
+
+	handler := NewConnHandler(context.Background(), conn)
+	handler.Start()
+
+	err := <-handler.Err()
+	var panicErr *PanicError
+	if !errors.As(err, &panicErr) || panicErr.Where != "read loop" {
+		t.Fatalf("Expected the read loop panic as a *PanicError, got %v", err)
+	}
+	if !errors.Is(err, failure) {
+		t.Errorf("Expected the panic value to be unwrapped, got %v", err)
+	}
+}
+
+func TestKeepAliveRecoversPanics(t *testing.T) {
+	closed := make(chan struct{})
+	conn := NewConn(&MockWebSocketConn{
+		PingFunc: func(ctx context.Context) error { return errors.New("broken pipe") },
+		CloseFunc: func() error {
+			close(closed)
+			return nil
+		},
+	})
+	panics := make(chan *PanicError, 1)
+	conn.SetPanicHandler(func(err *PanicError) { panics <- err })
+	conn.SetMetricsHook(MetricsHookFunc(func(event ConnEvent) { panic("boom") }))
+	fake := clock.NewFake(time.Unix(0, 0))
+	conn.SetClock(fake)
+	conn.StartKeepAlive(time.Second, time.Second)
+
+	fake.BlockUntil(1)
+	fake.Advance(time.Second)
+	select {
+	case err := <-panics:
+		if err.Where != "keep-alive" {
+			t.Errorf("Expected a keep-alive panic, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Expected the panic to be reported")
+	}
+	select {
+	case <-closed:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the connection to be closed despite the panic")
+	}
+}
+
+func TestRethrowPanics(t *testing.T) {
+	conn := NewConn(&MockWebSocketConn{})
+	conn.SetRethrowPanics(true)
+
+	defer func() {
+		if r := recover(); r != "boom" {
+			t.Errorf("Expected the panic to be rethrown, got %v", r)
+		}
+	}()
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				conn.recovered("test", r)
+			}
+		}()
+		panic("boom")
+	}()
+	t.Error("Expected the recovered panic to panic again")
+}
+
+func TestPanicErrorMessage(t *testing.T) {
+	err := NewPanicError("handler 1", "boom")
+	if !strings.Contains(err.Error(), "panic in handler 1: boom") {
+		t.Errorf("Unexpected message %q", err.Error())
+	}
+}
@@ -0,0 +1,204 @@
+package ws
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/clock"
+)
+
+//-----------------------------------------------------------------------------
+// Tee Connection
+//-----------------------------------------------------------------------------
+
+// Tee directions
+const (
+	// TeeDirectionIn marks frames read from the server
+	TeeDirectionIn = "in"
+	// TeeDirectionOut marks frames written by the client
+	TeeDirectionOut = "out"
+)
+
+// TeeFrame is a frame copied by a Tee
+type TeeFrame struct {
+	// Direction is TeeDirectionIn or TeeDirectionOut
+	Direction string
+	// Type is the message type of the frame
+	Type MessageType
+	// Time is when the frame was read or written
+	Time time.Time
+	// Data is the raw payload, exactly as it was on the wire
+	Data []byte
+}
+
+// Tee is a WebSocketConn that copies every frame read from and written to the
+// connection it wraps to a writer, for piping a live session into debugging tools.
+// Each frame is written as a one-line header followed by the payload and a newline:
+//
+//	<direction> <type> <time> <length>\n<payload>\n
+//
+// where direction is "in" or "out", type is the MessageType name, time is RFC 3339 with
+// nanoseconds and length is the payload size in bytes, so payloads containing newlines
+// or binary audio can be read back with ReadTeeFrame.
+//
+// A Tee wraps any WebSocketConn, so it can be layered over a scripted or replayed
+// connection as well as a live one. Failing to write a copy never fails the connection:
+// copying stops and Err reports the failure.
+//
+// Example:
+//
+//	f, _ := os.Create("session.tee")
+//	defer f.Close()
+//	conn := ws.NewConn(ws.TeeConn(gorillaConn, f))
+type Tee struct {
+	conn WebSocketConn
+
+	mu    sync.Mutex
+	w     io.Writer
+	err   error
+	clock clock.Clock
+}
+
+// TeeOption configures a Tee
+type TeeOption func(*Tee)
+
+// WithTeeClock sets the clock that frames are timestamped with; the default is the real
+// clock
+func WithTeeClock(clk clock.Clock) TeeOption {
+	return func(t *Tee) {
+		if clk != nil {
+			t.clock = clk
+		}
+	}
+}
+
+// TeeConn wraps conn so that its frames are copied to w. Copies are written with one
+// Write call per frame and never concurrently, so w need not be safe for concurrent use.
+func TeeConn(conn WebSocketConn, w io.Writer, opts ...TeeOption) *Tee {
+	t := &Tee{conn: conn, w: w, clock: clock.Real()}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// ReadMessage reads a message from the wrapped connection and copies it
+func (t *Tee) ReadMessage(ctx context.Context) (MessageType, []byte, error) {
+	messageType, data, err := t.conn.ReadMessage(ctx)
+	if err == nil {
+		t.copy(TeeDirectionIn, messageType, data)
+	}
+	return messageType, data, err
+}
+
+// WriteMessage writes a message to the wrapped connection and copies it once written
+func (t *Tee) WriteMessage(ctx context.Context, messageType MessageType, data []byte) error {
+	if err := t.conn.WriteMessage(ctx, messageType, data); err != nil {
+		return err
+	}
+	t.copy(TeeDirectionOut, messageType, data)
+	return nil
+}
+
+// Close closes the wrapped connection
+func (t *Tee) Close() error {
+	return t.conn.Close()
+}
+
+// Ping pings the wrapped connection; pings are not copied
+func (t *Tee) Ping(ctx context.Context) error {
+	return t.conn.Ping(ctx)
+}
+
+// Err returns the error that stopped copying, or nil if every frame has been copied
+func (t *Tee) Err() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.err
+}
+
+// copy writes one frame to the tee writer unless a previous write failed
+func (t *Tee) copy(direction string, messageType MessageType, data []byte) {
+	header := fmt.Sprintf("%s %s %s %d\n", direction, messageType, t.clock.Now().UTC().Format(time.RFC3339Nano), len(data))
+	out := make([]byte, 0, len(header)+len(data)+1)
+	out = append(out, header...)
+	out = append(out, data...)
+	out = append(out, '\n')
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.err != nil {
+		return
+	}
+	if _, err := t.w.Write(out); err != nil {
+		t.err = err
+	}
+}
+
+// ReadTeeFrame reads the next frame written by a Tee from r. It returns io.EOF when
+// r is exhausted between frames and io.ErrUnexpectedEOF if it ends inside a frame.
+//
+// Example:
+//
+//	r := bufio.NewReader(f)
+//	for {
+//		frame, err := ws.ReadTeeFrame(r)
+//		if err != nil {
+//			break
+//		}
+//		fmt.Printf("%s %s\n", frame.Direction, frame.Data)
+//	}
+func ReadTeeFrame(r *bufio.Reader) (TeeFrame, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		if err == io.EOF && line != "" {
+			err = io.ErrUnexpectedEOF
+		}
+		return TeeFrame{}, err
+	}
+
+	fields := strings.Fields(line)
+	if len(fields) != 4 {
+		return TeeFrame{}, fmt.Errorf("invalid tee frame header %q", strings.TrimSpace(line))
+	}
+	frame := TeeFrame{Direction: fields[0], Type: parseMessageType(fields[1])}
+	if frame.Direction != TeeDirectionIn && frame.Direction != TeeDirectionOut {
+		return TeeFrame{}, fmt.Errorf("invalid tee frame direction %q", frame.Direction)
+	}
+	if frame.Time, err = time.Parse(time.RFC3339Nano, fields[2]); err != nil {
+		return TeeFrame{}, fmt.Errorf("invalid tee frame time: %w", err)
+	}
+	size, err := strconv.Atoi(fields[3])
+	if err != nil || size < 0 {
+		return TeeFrame{}, fmt.Errorf("invalid tee frame length %q", fields[3])
+	}
+
+	frame.Data = make([]byte, size+1)
+	if _, err := io.ReadFull(r, frame.Data); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return TeeFrame{}, err
+	}
+	if frame.Data[size] != '\n' {
+		return TeeFrame{}, fmt.Errorf("tee frame of %d bytes is not followed by a newline", size)
+	}
+	frame.Data = frame.Data[:size]
+	return frame, nil
+}
+
+// parseMessageType returns the MessageType with the given name, or zero if it is unknown
+func parseMessageType(name string) MessageType {
+	for messageType, n := range messageTypeNames {
+		if n == name {
+			return messageType
+		}
+	}
+	return 0
+}
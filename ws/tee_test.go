@@ -0,0 +1,110 @@
+package ws
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Mliviu79/openai-realtime-go/clock"
+)
+
+// failingWriter fails every write
+type failingWriter struct{ err error }
+
+func (w failingWriter) Write(p []byte) (int, error) { return 0, w.err }
+
+func TestTeeConn(t *testing.T) {
+	reads := [][]byte{[]byte(`{"type":"session.created"}`), {0x00, '\n', 0xff}}
+	mockConn := &MockWebSocketConn{
+		ReadMessageFunc: func(ctx context.Context) (MessageType, []byte, error) {
+			if len(reads) == 0 {
+				return 0, nil, io.EOF
+			}
+			data := reads[0]
+			reads = reads[1:]
+			if data[0] == 0x00 {
+				return MessageBinary, data, nil
+			}
+			return MessageText, data, nil
+		},
+	}
+	var buf bytes.Buffer
+	fake := clock.NewFake(time.Date(2025, 1, 2, 3, 4, 5, 6, time.UTC))
+	tee := TeeConn(mockConn, &buf, WithTeeClock(fake))
+	conn := NewConn(tee)
+	ctx := context.Background()
+
+	if err := conn.SendRaw(ctx, MessageText, []byte("{\"type\":\"response.create\"}")); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		_, _, _ = conn.ReadRaw(ctx)
+	}
+
+	if !strings.HasPrefix(buf.String(), "out text 2025-01-02T03:04:05.000000006Z 26\n{\"type\":\"response.create\"}\n") {
+		t.Errorf("Unexpected tee output %q", buf.String())
+	}
+
+	r := bufio.NewReader(&buf)
+	var frames []TeeFrame
+	for {
+		frame, err := ReadTeeFrame(r)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		frames = append(frames, frame)
+	}
+	if len(frames) != 3 {
+		t.Fatalf("Expected the write and two reads to be copied, got %d frames", len(frames))
+	}
+	if frames[1].Direction != TeeDirectionIn || frames[1].Type != MessageText || string(frames[1].Data) != `{"type":"session.created"}` {
+		t.Errorf("Unexpected text frame %+v", frames[1])
+	}
+	if frames[2].Type != MessageBinary || !bytes.Equal(frames[2].Data, []byte{0x00, '\n', 0xff}) {
+		t.Errorf("Expected the binary payload to round-trip, got %+v", frames[2])
+	}
+	if !frames[0].Time.Equal(fake.Now()) {
+		t.Errorf("Expected the frame time to round-trip, got %v", frames[0].Time)
+	}
+}
+
+func TestTeeConnWriteFailure(t *testing.T) {
+	failure := errors.New("disk full")
+	tee := TeeConn(&MockWebSocketConn{}, failingWriter{failure})
+
+	if err := tee.WriteMessage(context.Background(), MessageText, []byte("{}")); err != nil {
+		t.Fatalf("Expected the frame to be sent despite the copy failing, got %v", err)
+	}
+	if !errors.Is(tee.Err(), failure) {
+		t.Errorf("Expected the copy failure to be reported, got %v", tee.Err())
+	}
+}
+
+func TestReadTeeFrameErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+	}{
+		{name: "truncated payload", input: "in text 2025-01-02T03:04:05Z 10\n{}"},
+		{name: "truncated header", input: "in text"},
+		{name: "bad direction", input: "up text 2025-01-02T03:04:05Z 2\n{}\n"},
+		{name: "bad length", input: "in text 2025-01-02T03:04:05Z -1\n"},
+		{name: "missing newline", input: "in text 2025-01-02T03:04:05Z 1\n{}\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ReadTeeFrame(bufio.NewReader(strings.NewReader(tt.input))); err == nil || err == io.EOF {
+				t.Errorf("Expected an error, got %v", err)
+			}
+		})
+	}
+}